@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package audit provides the CLI subcommand for viewing the audit log
+package audit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mindersec/minder/cmd/cli/app"
+)
+
+// auditCmd is the root command for the audit subcommands
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "View the audit log",
+	Long:  `The audit subcommands allows the record of the changes made to a project to be viewed.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return cmd.Usage()
+	},
+}
+
+func init() {
+	app.RootCmd.AddCommand(auditCmd)
+	auditCmd.PersistentFlags().StringP("project", "j", "", "ID of the project")
+	auditCmd.PersistentFlags().StringP("output", "o", app.Table,
+		fmt.Sprintf("Output format (one of %s)", strings.Join(app.SupportedOutputFormats(), ",")))
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/mindersec/minder/cmd/cli/app"
+	"github.com/mindersec/minder/internal/util"
+	"github.com/mindersec/minder/internal/util/cli"
+	"github.com/mindersec/minder/internal/util/cli/table"
+	"github.com/mindersec/minder/internal/util/cli/table/layouts"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List audit events",
+	Long: `The audit list subcommand lets you list the changes made to a project,
+from the newest to the oldest.`,
+	RunE: cli.GRPCClientWrapRunE(listCommand),
+}
+
+const (
+	defaultPageSize = 25
+)
+
+// listCommand is the audit "list" subcommand
+func listCommand(ctx context.Context, cmd *cobra.Command, _ []string, conn *grpc.ClientConn) error {
+	client := minderv1.NewAuditServiceClient(conn)
+
+	project := viper.GetString("project")
+	actor := viper.GetString("actor")
+	rpc := viper.GetString("rpc")
+
+	// time range
+	from := viper.GetTime("from")
+	to := viper.GetTime("to")
+
+	// page options
+	cursorStr := viper.GetString("cursor")
+	size := viper.GetUint32("size")
+
+	format := viper.GetString("output")
+
+	// Ensure the output format is supported
+	if !app.IsOutputFormatSupported(format) {
+		return cli.MessageAndError(fmt.Sprintf("Output format %s not supported", format), fmt.Errorf("invalid argument"))
+	}
+
+	req := &minderv1.ListAuditEventsRequest{
+		Context: &minderv1.Context{Project: &project},
+		Actor:   actor,
+		Rpc:     rpc,
+		Cursor: &minderv1.Cursor{
+			Cursor: cursorStr,
+			Size:   size,
+		},
+	}
+
+	// Viper returns time.Time rather than a pointer to it, so we
+	// have to check whether from and/or to were specified by
+	// other means.
+	if cmd.Flags().Lookup("from").Changed {
+		req.From = timestamppb.New(from)
+	}
+	if cmd.Flags().Lookup("to").Changed {
+		req.To = timestamppb.New(to)
+	}
+
+	resp, err := client.ListAuditEvents(ctx, req)
+	if err != nil {
+		return cli.MessageAndError("Error getting audit events", err)
+	}
+
+	switch format {
+	case app.JSON:
+		out, err := util.GetJsonFromProto(resp)
+		if err != nil {
+			return cli.MessageAndError("Error getting json from proto", err)
+		}
+		cmd.Println(out)
+	case app.YAML:
+		out, err := util.GetYamlFromProto(resp)
+		if err != nil {
+			return cli.MessageAndError("Error getting yaml from proto", err)
+		}
+		cmd.Println(out)
+	case app.Table:
+		printTable(cmd.OutOrStderr(), resp)
+	}
+
+	return nil
+}
+
+func printTable(w io.Writer, resp *minderv1.ListAuditEventsResponse) {
+	t := table.New(table.Simple, layouts.Default, []string{"Time", "Actor", "RPC", "Target", "Status"})
+	for _, event := range resp.GetEvents() {
+		t.AddRow(
+			event.GetCreatedAt().AsTime().Format(time.DateTime),
+			event.GetActorDisplayName(),
+			event.GetRpc(),
+			event.GetTarget(),
+			event.GetStatus(),
+		)
+	}
+	t.Render()
+	if next := resp.GetPage().GetNext(); next != nil {
+		// The audit log is listed from newest to oldest, so the
+		// next page points to older records.
+		msg := fmt.Sprintf("Older records: %s",
+			cli.CursorStyle.Render(next.GetCursor()),
+		)
+		fmt.Fprintln(w, msg)
+	}
+}
+
+func init() {
+	auditCmd.AddCommand(listCmd)
+
+	// Flags
+	listCmd.Flags().String("actor", "", "Filter audit events by the user who made the change")
+	listCmd.Flags().String("rpc", "", "Filter audit events by method, e.g. CreateProfile")
+	listCmd.Flags().String("from", "", "Filter audit events by time")
+	listCmd.Flags().String("to", "", "Filter audit events by time")
+	listCmd.Flags().StringP("cursor", "c", "", "Fetch the next page from the list")
+	listCmd.Flags().Uint32P("size", "s", defaultPageSize, "Change the number of items fetched")
+}
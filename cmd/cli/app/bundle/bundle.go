@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package bundle contains the bundle subcommands
+package bundle
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/mindersec/minder/cmd/cli/app"
+)
+
+// BundleCmd is the root command for the bundle subcommands
+var BundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Manage bundle subscriptions within a minder control plane",
+	Long: `The bundle subcommands allow the management of the subscriptions of projects
+to the bundles of the marketplace, which provide rule types, data sources and
+profiles.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return cmd.Usage()
+	},
+}
+
+func init() {
+	app.RootCmd.AddCommand(BundleCmd)
+	// Flags for all subcommands
+	BundleCmd.PersistentFlags().StringP("project", "j", "", "ID of the project")
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+
+	"github.com/mindersec/minder/cmd/cli/app"
+	"github.com/mindersec/minder/internal/util"
+	"github.com/mindersec/minder/internal/util/cli"
+	"github.com/mindersec/minder/internal/util/cli/table"
+	"github.com/mindersec/minder/internal/util/cli/table/layouts"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the bundle subscriptions of a project",
+	Long: `The bundle status subcommand lists the bundle subscriptions of a project and
+its child projects, and shows which projects run an outdated version of a
+bundle.`,
+	RunE: cli.GRPCClientWrapRunE(statusCommand),
+}
+
+// statusCommand is the bundle status subcommand
+func statusCommand(ctx context.Context, cmd *cobra.Command, _ []string, conn *grpc.ClientConn) error {
+	client := minderv1.NewBundleServiceClient(conn)
+
+	project := viper.GetString("project")
+	format := viper.GetString("output")
+	outdatedOnly := viper.GetBool("outdated")
+
+	// Ensure the output format is supported
+	if !app.IsOutputFormatSupported(format) {
+		return cli.MessageAndError(fmt.Sprintf("Output format %s not supported", format), fmt.Errorf("invalid argument"))
+	}
+
+	// No longer print usage on returned error, since we've parsed our inputs
+	// See https://github.com/spf13/cobra/issues/340#issuecomment-374617413
+	cmd.SilenceUsage = true
+
+	resp, err := client.ListBundleSubscriptions(ctx, &minderv1.ListBundleSubscriptionsRequest{
+		Context: &minderv1.Context{Project: &project},
+	})
+	if err != nil {
+		return cli.MessageAndError("Error listing bundle subscriptions", err)
+	}
+
+	if outdatedOnly {
+		subscriptions := make([]*minderv1.BundleSubscription, 0, len(resp.GetSubscriptions()))
+		for _, s := range resp.GetSubscriptions() {
+			if s.GetOutdated() {
+				subscriptions = append(subscriptions, s)
+			}
+		}
+		resp.Subscriptions = subscriptions
+	}
+
+	switch format {
+	case app.JSON:
+		out, err := util.GetJsonFromProto(resp)
+		if err != nil {
+			return cli.MessageAndError("Error getting json from proto", err)
+		}
+		cmd.Println(out)
+	case app.YAML:
+		out, err := util.GetYamlFromProto(resp)
+		if err != nil {
+			return cli.MessageAndError("Error getting yaml from proto", err)
+		}
+		cmd.Println(out)
+	case app.Table:
+		t := table.New(table.Simple, layouts.Default,
+			[]string{"Project", "Bundle", "Current Version", "Latest Version", "Status"})
+		for _, s := range resp.GetSubscriptions() {
+			t.AddRowWithColor(
+				layouts.NoColor(s.GetProject()),
+				layouts.NoColor(fmt.Sprintf("%s/%s", s.GetNamespace(), s.GetName())),
+				layouts.NoColor(s.GetCurrentVersion()),
+				layouts.NoColor(s.GetLatestVersion()),
+				subscriptionStatus(s),
+			)
+		}
+		t.Render()
+	}
+	return nil
+}
+
+// subscriptionStatus returns the colored status of a subscription
+func subscriptionStatus(s *minderv1.BundleSubscription) layouts.ColoredColumn {
+	switch {
+	case s.GetLatestVersion() == "":
+		return layouts.YellowColumn("Unavailable")
+	case s.GetOutdated():
+		return layouts.RedColumn("Outdated")
+	default:
+		return layouts.GreenColumn("Up to date")
+	}
+}
+
+func init() {
+	BundleCmd.AddCommand(statusCmd)
+	// Flags
+	statusCmd.Flags().Bool("outdated", false, "Only show outdated subscriptions")
+	statusCmd.Flags().StringP("output", "o", app.Table,
+		fmt.Sprintf("Output format (one of %s)", strings.Join(app.SupportedOutputFormats(), ",")))
+}
@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+
+	"github.com/mindersec/minder/cmd/cli/app"
+	"github.com/mindersec/minder/internal/util"
+	"github.com/mindersec/minder/internal/util/cli"
+	"github.com/mindersec/minder/internal/util/cli/table"
+	"github.com/mindersec/minder/internal/util/cli/table/layouts"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+var upgradeCmd = &cobra.Command{
+	Use:   "upgrade NAMESPACE/NAME",
+	Short: "Upgrade a project to the latest version of a bundle",
+	Long: `The bundle upgrade subcommand upgrades the rule types, data sources and
+profiles installed in a project from a bundle to the version of the bundle in
+the marketplace. All the changes are applied at once.
+
+Items which changed in the bundle but were also modified in the project since
+they were installed are conflicts: the upgrade fails without changing the
+project, unless --overwrite-local-changes is set. Use --dry-run to review the
+changes and conflicts before applying them.`,
+	RunE: cli.GRPCClientWrapRunE(upgradeCommand),
+	Args: cobra.ExactArgs(1),
+}
+
+// upgradeCommand is the bundle upgrade subcommand
+func upgradeCommand(ctx context.Context, cmd *cobra.Command, args []string, conn *grpc.ClientConn) error {
+	client := minderv1.NewBundleServiceClient(conn)
+
+	project := viper.GetString("project")
+	format := viper.GetString("output")
+	dryRun := viper.GetBool("dry-run")
+	overwrite := viper.GetBool("overwrite-local-changes")
+
+	namespace, name, ok := strings.Cut(args[0], "/")
+	if !ok || namespace == "" || name == "" {
+		return cli.MessageAndError("Invalid bundle", fmt.Errorf("expected NAMESPACE/NAME, got %q", args[0]))
+	}
+
+	// Ensure the output format is supported
+	if !app.IsOutputFormatSupported(format) {
+		return cli.MessageAndError(fmt.Sprintf("Output format %s not supported", format), fmt.Errorf("invalid argument"))
+	}
+
+	// No longer print usage on returned error, since we've parsed our inputs
+	// See https://github.com/spf13/cobra/issues/340#issuecomment-374617413
+	cmd.SilenceUsage = true
+
+	resp, err := client.UpgradeBundle(ctx, &minderv1.UpgradeBundleRequest{
+		Context:               &minderv1.Context{Project: &project},
+		Namespace:             namespace,
+		Name:                  name,
+		DryRun:                dryRun,
+		OverwriteLocalChanges: overwrite,
+	})
+	if err != nil {
+		return cli.MessageAndError("Error upgrading bundle", err)
+	}
+
+	switch format {
+	case app.JSON:
+		out, err := util.GetJsonFromProto(resp)
+		if err != nil {
+			return cli.MessageAndError("Error getting json from proto", err)
+		}
+		cmd.Println(out)
+	case app.YAML:
+		out, err := util.GetYamlFromProto(resp)
+		if err != nil {
+			return cli.MessageAndError("Error getting yaml from proto", err)
+		}
+		cmd.Println(out)
+	case app.Table:
+		t := table.New(table.Simple, layouts.Default, []string{"Type", "Name", "Change", "Locally Modified"})
+		for _, c := range resp.GetChanges() {
+			modified := layouts.NoColor("No")
+			if c.GetLocallyModified() {
+				modified = layouts.YellowColumn("Yes")
+			}
+			t.AddRowWithColor(
+				layouts.NoColor(c.GetType()),
+				layouts.NoColor(c.GetName()),
+				layouts.NoColor(c.GetChange()),
+				modified,
+			)
+		}
+		t.Render()
+		if conflicts := countConflicts(resp.GetChanges()); conflicts > 0 {
+			cmd.Printf("%d item(s) were modified in the project, "+
+				"use --overwrite-local-changes to upgrade them\n", conflicts)
+		}
+		if resp.GetDryRun() {
+			cmd.Printf("Dry run: %s would be upgraded from version %s to %s\n",
+				args[0], resp.GetFromVersion(), resp.GetToVersion())
+		} else {
+			cmd.Printf("Upgraded %s from version %s to %s\n", args[0], resp.GetFromVersion(), resp.GetToVersion())
+		}
+	}
+	return nil
+}
+
+// countConflicts returns the number of items which were modified in the
+// project, and which a dry run reports
+func countConflicts(changes []*minderv1.BundleContentChange) int {
+	conflicts := 0
+	for _, c := range changes {
+		if c.GetChange() == "conflict" {
+			conflicts++
+		}
+	}
+	return conflicts
+}
+
+func init() {
+	BundleCmd.AddCommand(upgradeCmd)
+	// Flags
+	upgradeCmd.Flags().Bool("dry-run", false, "Report the changes without applying them")
+	upgradeCmd.Flags().Bool("overwrite-local-changes", false,
+		"Upgrade the items modified in the project, discarding these modifications")
+	upgradeCmd.Flags().StringP("output", "o", app.Table,
+		fmt.Sprintf("Output format (one of %s)", strings.Join(app.SupportedOutputFormats(), ",")))
+}
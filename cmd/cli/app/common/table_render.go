@@ -16,6 +16,7 @@ const (
 	errorStatus        = "error"
 	skippedStatus      = "skipped"
 	pendingStatus      = "pending"
+	exemptStatus       = "exempt"
 	notAvailableStatus = "not_available"
 	onStatus           = "on"
 	offStatus          = "off"
@@ -24,7 +25,7 @@ const (
 // GetEvalStatusColor maps the alert status to coloured text
 func GetEvalStatusColor(status string) layouts.ColoredColumn {
 	txt := getStatusText(status)
-	// eval statuses can be 'success', 'failure', 'error', 'skipped', 'pending'
+	// eval statuses can be 'success', 'failure', 'error', 'skipped', 'pending', 'exempt'
 	switch strings.ToLower(status) {
 	case successStatus:
 		return layouts.GreenColumn(txt)
@@ -32,7 +33,7 @@ func GetEvalStatusColor(status string) layouts.ColoredColumn {
 		return layouts.RedColumn(txt)
 	case errorStatus:
 		return layouts.RedColumn(txt)
-	case skippedStatus:
+	case skippedStatus, exemptStatus:
 		return layouts.YellowColumn(txt)
 	default:
 		return layouts.NoColor(txt)
@@ -92,6 +93,8 @@ func getStatusText(status string) string {
 		return "Skipped" // visually empty as we didn't have to remediate
 	case pendingStatus:
 		return "Pending"
+	case exemptStatus:
+		return "Exempt"
 	case notAvailableStatus:
 		return "Not Available"
 	default:
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+
+	"github.com/mindersec/minder/internal/util/cli"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export current evaluation findings",
+	Long: `The history export subcommand lets you export the current findings of a
+project, that is the rules whose latest evaluation on an entity failed or
+errored, in a format that can be consumed by other tools, such as SARIF for
+code-scanning dashboards.`,
+	RunE: cli.GRPCClientWrapRunE(exportCommand),
+}
+
+const (
+	// exportFormatSARIF exports the findings as a SARIF 2.1.0 log
+	exportFormatSARIF = "sarif"
+)
+
+var exportFormats = []string{
+	exportFormatSARIF,
+}
+
+// exportCommand is the history "export" subcommand
+func exportCommand(ctx context.Context, cmd *cobra.Command, _ []string, conn *grpc.ClientConn) error {
+	client := minderv1.NewEvalResultsServiceClient(conn)
+
+	project := viper.GetString("project")
+	profileName := viper.GetStringSlice("profile-name")
+	entityType := viper.GetStringSlice("entity-type")
+
+	format := viper.GetString("format")
+	outFile := viper.GetString("file")
+
+	if err := validatedFilter([]string{format}, exportFormats); err != nil {
+		return cli.MessageAndError(fmt.Sprintf("Export format %s not supported", format), err)
+	}
+
+	if err := validatedFilter(entityType, entityTypes); err != nil {
+		return err
+	}
+
+	// No longer print usage on returned error, since we've parsed our inputs
+	// See https://github.com/spf13/cobra/issues/340#issuecomment-374617413
+	cmd.SilenceUsage = true
+
+	resp, err := client.ExportEvaluationResults(ctx, &minderv1.ExportEvaluationResultsRequest{
+		Context:     &minderv1.Context{Project: &project},
+		Format:      format,
+		ProfileName: profileName,
+		EntityType:  entityType,
+	})
+	if err != nil {
+		return cli.MessageAndError("Error exporting evaluation results", err)
+	}
+
+	out, err := json.MarshalIndent(resp.GetSarif(), "", "  ")
+	if err != nil {
+		return cli.MessageAndError("Error marshalling SARIF log", err)
+	}
+
+	if outFile == "" || outFile == "-" {
+		cmd.Println(string(out))
+		return nil
+	}
+
+	if err := os.WriteFile(filepath.Clean(outFile), out, 0600); err != nil {
+		return cli.MessageAndError("Error writing export file", err)
+	}
+	cmd.Printf("Exported findings to %s\n", outFile)
+
+	return nil
+}
+
+func init() {
+	historyCmd.AddCommand(exportCmd)
+
+	entityTypesMsg := fmt.Sprintf("Filter exported findings by entity type - one of %s", strings.Join(entityTypes, ", "))
+
+	// Flags
+	exportCmd.Flags().String("format", exportFormatSARIF,
+		fmt.Sprintf("Export format (one of %s)", strings.Join(exportFormats, ",")))
+	exportCmd.Flags().StringP("file", "f", "", "Path to write the export to (defaults to stdout)")
+	exportCmd.Flags().StringSlice("profile-name", nil, "Filter exported findings by profile name")
+	exportCmd.Flags().StringSlice("entity-type", nil, entityTypesMsg)
+}
@@ -245,6 +245,7 @@ var evalStatuses = []string{
 	string(db.EvalStatusTypesError),
 	string(db.EvalStatusTypesSuccess),
 	string(db.EvalStatusTypesSkipped),
+	string(db.EvalStatusTypesExempt),
 }
 
 var remediationStatuses = []string{
@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package profile
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+
+	"github.com/mindersec/minder/cmd/cli/app"
+	"github.com/mindersec/minder/cmd/cli/app/common"
+	"github.com/mindersec/minder/internal/util"
+	"github.com/mindersec/minder/internal/util/cli"
+	"github.com/mindersec/minder/internal/util/cli/table"
+	"github.com/mindersec/minder/internal/util/cli/table/layouts"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+// previewCmd represents the profile preview command
+var previewCmd = &cobra.Command{
+	Use:   "preview",
+	Short: "Preview the evaluation of a profile",
+	Long: `The profile preview subcommand evaluates a profile which has not been created
+against the entities of a project, and prints the result for each entity.
+The evaluation results are not recorded, and no remediations or alerts are
+triggered.
+
+Selectors restrict the evaluated entities. They are given as ENTITY=EXPRESSION,
+or as an expression which applies to all entity types, e.g.
+
+	minder profile preview -f profile.yaml --selector 'repository=repository.is_fork != true'`,
+	RunE: cli.GRPCClientWrapRunE(previewCommand),
+}
+
+// selectorEntityPrefix matches the optional entity type of a selector flag
+var selectorEntityPrefix = regexp.MustCompile(`^([a-z]+(_[a-z]+)*)=([^=].*)$`)
+
+// previewCommand is the profile preview subcommand
+func previewCommand(_ context.Context, cmd *cobra.Command, _ []string, conn *grpc.ClientConn) error {
+	client := minderv1.NewProfileServiceClient(conn)
+
+	project := viper.GetString("project")
+	f := viper.GetString("file")
+	format := viper.GetString("output")
+	maxEntities := viper.GetUint32("max-entities")
+	// read the selectors from the flag directly, as viper splits them on commas
+	selectors, err := cmd.Flags().GetStringArray("selector")
+	if err != nil {
+		return cli.MessageAndError("Error reading selectors", err)
+	}
+
+	// Ensure the output format is supported
+	if !app.IsOutputFormatSupported(format) {
+		return cli.MessageAndError(fmt.Sprintf("Output format %s not supported", format), fmt.Errorf("invalid argument"))
+	}
+
+	// No longer print usage on returned error, since we've parsed our inputs
+	// See https://github.com/spf13/cobra/issues/340#issuecomment-374617413
+	cmd.SilenceUsage = true
+
+	ctx, cancel := cli.GetAppContext(cmd.Context(), viper.GetViper())
+	defer cancel()
+
+	reader, closer, err := util.OpenFileArg(f, cmd.InOrStdin())
+	if err != nil {
+		return cli.MessageAndError("Error opening file arg", err)
+	}
+	defer closer()
+
+	p, err := parseProfile(reader, project)
+	if err != nil {
+		return cli.MessageAndError(fmt.Sprintf("error parsing profile from %s", f), err)
+	}
+
+	resp, err := client.PreviewProfile(ctx, &minderv1.PreviewProfileRequest{
+		Context:     &minderv1.Context{Project: &project},
+		Profile:     p,
+		Selection:   parseSelectorFlags(selectors),
+		MaxEntities: maxEntities,
+	})
+	if err != nil {
+		return cli.MessageAndError("Error previewing profile", err)
+	}
+
+	switch format {
+	case app.JSON:
+		out, err := util.GetJsonFromProto(resp)
+		if err != nil {
+			return cli.MessageAndError("Error getting json from proto", err)
+		}
+		cmd.Println(out)
+	case app.YAML:
+		out, err := util.GetYamlFromProto(resp)
+		if err != nil {
+			return cli.MessageAndError("Error getting yaml from proto", err)
+		}
+		cmd.Println(out)
+	case app.Table:
+		t := table.New(table.Simple, layouts.Default, []string{"Rule Name", "Entity", "Status", "Details"})
+		for _, res := range resp.GetResults() {
+			for _, rule := range res.GetRules() {
+				t.AddRowWithColor(
+					layouts.NoColor(fmt.Sprintf("%s\n[%s]", rule.GetRuleName(), rule.GetRuleType())),
+					layouts.NoColor(fmt.Sprintf("%s\n[%s]", res.GetEntityName(), res.GetEntity().GetType())),
+					common.GetEvalStatusColor(rule.GetStatus()),
+					layouts.NoColor(rule.GetDetails()),
+				)
+			}
+		}
+		t.Render()
+		if resp.GetTruncated() {
+			cmd.Println("Only the first entities of the project were evaluated, use --max-entities to evaluate more")
+		}
+	}
+	return nil
+}
+
+// parseSelectorFlags converts the selector flags to profile selectors
+func parseSelectorFlags(flags []string) []*minderv1.Profile_Selector {
+	selection := make([]*minderv1.Profile_Selector, 0, len(flags))
+	for _, flag := range flags {
+		sel := &minderv1.Profile_Selector{Selector: strings.TrimSpace(flag)}
+		if m := selectorEntityPrefix.FindStringSubmatch(sel.Selector); m != nil {
+			sel.Entity = m[1]
+			sel.Selector = strings.TrimSpace(m[3])
+		}
+		selection = append(selection, sel)
+	}
+	return selection
+}
+
+func init() {
+	ProfileCmd.AddCommand(previewCmd)
+	// Flags
+	previewCmd.Flags().StringP("file", "f", "", "Path to the YAML defining the profile (or - for stdin)")
+	previewCmd.Flags().StringArray("selector", nil, "Selector restricting the evaluated entities (can be repeated)")
+	previewCmd.Flags().Uint32("max-entities", 0, "Maximum number of entities to evaluate (defaults to 50 on the server)")
+	previewCmd.Flags().StringP("output", "o", app.Table,
+		fmt.Sprintf("Output format (one of %s)", strings.Join(app.SupportedOutputFormats(), ",")))
+	// Required
+	if err := previewCmd.MarkFlagRequired("file"); err != nil {
+		previewCmd.Printf("Error marking flag required: %s", err)
+		os.Exit(1)
+	}
+}
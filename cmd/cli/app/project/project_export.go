@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+
+	"github.com/mindersec/minder/cmd/cli/app"
+	"github.com/mindersec/minder/internal/util"
+	"github.com/mindersec/minder/internal/util/cli"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+var projectExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the configuration of a project",
+	Long: `The export subcommand writes a snapshot of the configuration of a project:
+its data sources, rule types, profiles, custom roles, role assignments and
+the metadata of its providers.  Provider credentials are not exported.
+
+The snapshot can be restored into another project, or into another Minder
+instance, with "minder project import".`,
+	RunE: cli.GRPCClientWrapRunE(exportCommand),
+}
+
+// exportCommand is the project "export" subcommand
+func exportCommand(ctx context.Context, cmd *cobra.Command, _ []string, conn *grpc.ClientConn) error {
+	client := minderv1.NewProjectsServiceClient(conn)
+
+	format := viper.GetString("output")
+	project := viper.GetString("project")
+	outFile := viper.GetString("file")
+
+	if format != app.JSON && format != app.YAML {
+		return cli.MessageAndError(fmt.Sprintf("Output format %s not supported", format), fmt.Errorf("invalid argument"))
+	}
+
+	// No longer print usage on returned error, since we've parsed our inputs
+	// See https://github.com/spf13/cobra/issues/340#issuecomment-374617413
+	cmd.SilenceUsage = true
+
+	resp, err := client.ExportProject(ctx, &minderv1.ExportProjectRequest{
+		Context: &minderv1.Context{Project: &project},
+	})
+	if err != nil {
+		return cli.MessageAndError("Error exporting project", err)
+	}
+
+	var out string
+	if format == app.JSON {
+		out, err = util.GetJsonFromProto(resp.GetExport())
+	} else {
+		out, err = util.GetYamlFromProto(resp.GetExport())
+	}
+	if err != nil {
+		return cli.MessageAndError("Error marshalling project export", err)
+	}
+
+	if outFile == "" || outFile == "-" {
+		cmd.Println(out)
+		return nil
+	}
+
+	if err := os.WriteFile(filepath.Clean(outFile), []byte(out), 0600); err != nil {
+		return cli.MessageAndError("Error writing export file", err)
+	}
+	cmd.Printf("Exported project %s to %s\n", resp.GetExport().GetProject(), outFile)
+
+	return nil
+}
+
+func init() {
+	ProjectCmd.AddCommand(projectExportCmd)
+
+	projectExportCmd.Flags().StringP("project", "j", "", "The project to export")
+	projectExportCmd.Flags().StringP("file", "f", "", "Path to write the export to (defaults to stdout)")
+	projectExportCmd.Flags().StringP("output", "o", app.YAML,
+		fmt.Sprintf("Output format (one of %s)", strings.Join([]string{app.JSON, app.YAML}, ",")))
+}
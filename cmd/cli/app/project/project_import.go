@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package project
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/mindersec/minder/cmd/cli/app"
+	"github.com/mindersec/minder/internal/util"
+	"github.com/mindersec/minder/internal/util/cli"
+	"github.com/mindersec/minder/internal/util/cli/table"
+	"github.com/mindersec/minder/internal/util/cli/table/layouts"
+	"github.com/mindersec/minder/internal/util/jsonyaml"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+var projectImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import the configuration of a project",
+	Long: `The import subcommand restores a snapshot written by "minder project export"
+into a project.
+
+The items of the snapshot which conflict with the project, such as a profile
+which already exists, are reported before anything is changed: the import is
+applied only if there are no conflicts.  Use --dry-run to report the changes
+without applying them.  Providers are not imported, as they must be enrolled
+again.`,
+	RunE: cli.GRPCClientWrapRunE(importCommand),
+}
+
+// importCommand is the project "import" subcommand
+func importCommand(ctx context.Context, cmd *cobra.Command, _ []string, conn *grpc.ClientConn) error {
+	client := minderv1.NewProjectsServiceClient(conn)
+
+	format := viper.GetString("output")
+	project := viper.GetString("project")
+	file := viper.GetString("file")
+	dryRun := viper.GetBool("dry-run")
+
+	// Ensure the output format is supported
+	if !app.IsOutputFormatSupported(format) {
+		return cli.MessageAndError(fmt.Sprintf("Output format %s not supported", format), fmt.Errorf("invalid argument"))
+	}
+
+	reader, closer, err := util.OpenFileArg(file, cmd.InOrStdin())
+	if err != nil {
+		return cli.MessageAndError("Error opening file", err)
+	}
+	defer closer()
+
+	// Exports may be written as JSON or YAML
+	w := &bytes.Buffer{}
+	if err := jsonyaml.TranscodeYAMLToJSON(reader, w); err != nil {
+		return cli.MessageAndError("Error parsing project export", err)
+	}
+	export := &minderv1.ProjectExport{}
+	if err := protojson.Unmarshal(w.Bytes(), export); err != nil {
+		return cli.MessageAndError("Error parsing project export", err)
+	}
+
+	// No longer print usage on returned error, since we've parsed our inputs
+	// See https://github.com/spf13/cobra/issues/340#issuecomment-374617413
+	cmd.SilenceUsage = true
+
+	resp, err := client.ImportProject(ctx, &minderv1.ImportProjectRequest{
+		Context: &minderv1.Context{Project: &project},
+		Export:  export,
+		DryRun:  dryRun,
+	})
+	if err != nil {
+		return cli.MessageAndError("Error importing project", err)
+	}
+
+	switch format {
+	case app.JSON:
+		out, err := util.GetJsonFromProto(resp)
+		if err != nil {
+			return cli.MessageAndError("Error getting json from proto", err)
+		}
+		cmd.Println(out)
+	case app.YAML:
+		out, err := util.GetYamlFromProto(resp)
+		if err != nil {
+			return cli.MessageAndError("Error getting yaml from proto", err)
+		}
+		cmd.Println(out)
+	case app.Table:
+		t := table.New(table.Simple, layouts.Default, []string{"Type", "Name", "Action", "Reason"})
+		for _, item := range resp.GetItems() {
+			t.AddRow(item.GetType(), item.GetName(), item.GetAction(), item.GetReason())
+		}
+		t.Render()
+		switch {
+		case resp.GetApplied():
+			cmd.Println("The project export was imported")
+		case dryRun:
+			cmd.Println("Dry run: nothing was imported")
+		default:
+			cmd.Println("Nothing was imported, as some items conflict with the project")
+		}
+	}
+
+	return nil
+}
+
+func init() {
+	ProjectCmd.AddCommand(projectImportCmd)
+
+	projectImportCmd.Flags().StringP("project", "j", "", "The project to import into")
+	projectImportCmd.Flags().StringP("file", "f", "", "Path to the project export (- for stdin)")
+	projectImportCmd.Flags().Bool("dry-run", false, "Report the changes and conflicts without applying them")
+	projectImportCmd.Flags().StringP("output", "o", app.Table,
+		fmt.Sprintf("Output format (one of %s)", strings.Join(app.SupportedOutputFormats(), ",")))
+	// mark as required
+	if err := projectImportCmd.MarkFlagRequired("file"); err != nil {
+		panic(err)
+	}
+}
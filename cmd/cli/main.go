@@ -7,9 +7,11 @@ package main
 import (
 	"github.com/mindersec/minder/cmd/cli/app"
 	_ "github.com/mindersec/minder/cmd/cli/app/artifact"
+	_ "github.com/mindersec/minder/cmd/cli/app/audit"
 	_ "github.com/mindersec/minder/cmd/cli/app/auth"
 	_ "github.com/mindersec/minder/cmd/cli/app/auth/invite"
 	_ "github.com/mindersec/minder/cmd/cli/app/auth/offline_token"
+	_ "github.com/mindersec/minder/cmd/cli/app/bundle"
 	_ "github.com/mindersec/minder/cmd/cli/app/datasource"
 	_ "github.com/mindersec/minder/cmd/cli/app/docs"
 	_ "github.com/mindersec/minder/cmd/cli/app/history"
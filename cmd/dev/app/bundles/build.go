@@ -27,14 +27,18 @@ Arguments:
 build_id: an identifier of the form 'namespace/name@version'
 input: Directory containing bundle profiles and rule types
 output: Path to tar where bundle will be written
+
+If --signing-key is set, the bundle manifest is signed with the PEM encoded
+ECDSA or Ed25519 private key, and the signature is included in the bundle.
 `,
 		RunE:         buildCmdRun,
 		SilenceUsage: true,
 	}
+	buildCmd.Flags().String("signing-key", "", "Path to the private key used to sign the bundle")
 	return buildCmd
 }
 
-func buildCmdRun(_ *cobra.Command, args []string) error {
+func buildCmdRun(cmd *cobra.Command, args []string) error {
 	metadata, err := parseVersion(args[0])
 	if err != nil {
 		return err
@@ -43,6 +47,18 @@ func buildCmdRun(_ *cobra.Command, args []string) error {
 	options := build.InitOptions{
 		Metadata: metadata,
 		Path:     args[1],
+		Out:      cmd.OutOrStdout(),
+	}
+
+	keyPath, err := cmd.Flags().GetString("signing-key")
+	if err != nil {
+		return err
+	}
+	if keyPath != "" {
+		options.SigningKey, err = mindpak.LoadSigningKey(keyPath)
+		if err != nil {
+			return fmt.Errorf("loading signing key: %w", err)
+		}
 	}
 
 	bundle, err := packer.InitBundle(&options)
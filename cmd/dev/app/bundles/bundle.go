@@ -14,6 +14,8 @@ func CmdBundle() *cobra.Command {
 	}
 
 	rtCmd.AddCommand(CmdBuild())
+	rtCmd.AddCommand(CmdVerify())
+	rtCmd.AddCommand(CmdPush())
 
 	return rtCmd
 }
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bundles
+
+import (
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/spf13/cobra"
+
+	"github.com/mindersec/minder/pkg/mindpak/build"
+)
+
+// CmdPush is the push command
+func CmdPush() *cobra.Command {
+	var pushCmd = &cobra.Command{
+		Use:   "push bundle reference",
+		Short: "push a mindpak bundle to an OCI registry",
+		Args:  cobra.ExactArgs(2),
+		Long: `
+The 'bundle push' subcommand publishes a mindpak bundle built with
+'bundle build' to an OCI registry. Registry credentials are read from the
+docker configuration, e.g. as set by 'docker login'.
+
+The digest of the pushed bundle is printed, and can be used to pin the
+bundle in the marketplace configuration of the server.
+
+Arguments:
+
+bundle: Path to the bundle tar
+reference: Reference to push the bundle to, e.g. 'ghcr.io/org/bundle:v1.0.0'
+`,
+		RunE:         pushCmdRun,
+		SilenceUsage: true,
+	}
+	return pushCmd
+}
+
+func pushCmdRun(cmd *cobra.Command, args []string) error {
+	packer := build.NewPacker()
+	digest, err := packer.Push(cmd.Context(), args[0], args[1],
+		remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf("pushed %s@%s\n", args[1], digest)
+	return nil
+}
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bundles
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mindersec/minder/pkg/mindpak"
+)
+
+// CmdVerify is the verify command
+func CmdVerify() *cobra.Command {
+	var verifyCmd = &cobra.Command{
+		Use:   "verify bundle",
+		Short: "verify a mindpak bundle",
+		Args:  cobra.ExactArgs(1),
+		Long: `
+The 'bundle verify' subcommand checks the files of a mindpak bundle against
+its manifest. If --public-key is set, the signature of the manifest is
+verified as well, and unsigned bundles are refused.
+
+Arguments:
+
+bundle: Path to the bundle tar
+`,
+		RunE:         verifyCmdRun,
+		SilenceUsage: true,
+	}
+	verifyCmd.Flags().String("public-key", "", "Path to the public key used to verify the bundle signature")
+	return verifyCmd
+}
+
+func verifyCmdRun(cmd *cobra.Command, args []string) error {
+	bundle, err := mindpak.NewBundleFromTarGZ(args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := bundle.Verify(); err != nil {
+		return fmt.Errorf("bundle failed verification: %w", err)
+	}
+
+	keyPath, err := cmd.Flags().GetString("public-key")
+	if err != nil {
+		return err
+	}
+	if keyPath != "" {
+		key, err := mindpak.LoadVerificationKey(keyPath)
+		if err != nil {
+			return fmt.Errorf("loading public key: %w", err)
+		}
+		if err := bundle.VerifySignature(key); err != nil {
+			return fmt.Errorf("bundle failed signature verification: %w", err)
+		}
+	}
+
+	cmd.Printf("bundle %s is valid\n", args[0])
+	return nil
+}
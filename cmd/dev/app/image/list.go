@@ -16,6 +16,7 @@ import (
 	"github.com/mindersec/minder/internal/providers/credentials"
 	"github.com/mindersec/minder/internal/providers/dockerhub"
 	"github.com/mindersec/minder/internal/providers/github/ghcr"
+	"github.com/mindersec/minder/internal/providers/ociregistry"
 	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
 	provifv1 "github.com/mindersec/minder/pkg/providers/v1"
 )
@@ -31,6 +32,8 @@ func CmdList() *cobra.Command {
 
 	listCmd.Flags().StringP("provider", "p", "", "provider class to use for listing containers")
 	listCmd.Flags().StringP("namespace", "n", "", "namespace to list containers from")
+	listCmd.Flags().String("base-url", "", "registry host to use with the oci provider")
+	listCmd.Flags().String("username", "", "username for basic authentication with the oci provider")
 	//nolint:goconst // let's not use a const for this one
 	listCmd.Flags().StringP("token", "t", "", "token to authenticate to the provider."+
 		//nolint:goconst // let's not use a const for this one
@@ -75,6 +78,18 @@ func runCmdList(cmd *cobra.Command, _ []string) error {
 		prov = ghcr.New(cred, &minderv1.GHCRProviderConfig{
 			Namespace: proto.String(ns.Value.String()),
 		})
+	case "oci":
+		var err error
+		cfg := &minderv1.OCIProviderConfig{
+			BaseUrl:   cmd.Flag("base-url").Value.String(),
+			Namespace: proto.String(ns.Value.String()),
+			Username:  proto.String(cmd.Flag("username").Value.String()),
+		}
+		cred := credentials.NewOCIRegistryCredential(cfg.GetUsername(), viper.GetString("auth.token"))
+		prov, err = ociregistry.New(cred, cfg)
+		if err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("unknown provider: %s", pclass.Value.String())
 	}
@@ -26,11 +26,13 @@ import (
 	engif "github.com/mindersec/minder/internal/engine/interfaces"
 	"github.com/mindersec/minder/internal/engine/options"
 	entModels "github.com/mindersec/minder/internal/entities/models"
+	"github.com/mindersec/minder/internal/providers/bitbucket"
 	"github.com/mindersec/minder/internal/providers/credentials"
 	"github.com/mindersec/minder/internal/providers/dockerhub"
 	"github.com/mindersec/minder/internal/providers/github/clients"
 	"github.com/mindersec/minder/internal/providers/github/properties"
 	"github.com/mindersec/minder/internal/providers/gitlab"
+	"github.com/mindersec/minder/internal/providers/ociregistry"
 	"github.com/mindersec/minder/internal/providers/ratecache"
 	provsel "github.com/mindersec/minder/internal/providers/selectors"
 	"github.com/mindersec/minder/internal/providers/telemetry"
@@ -201,7 +203,7 @@ func testCmdRun(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		return fmt.Errorf("cannot create rule type engine: %w", err)
 	}
-	actionEngine, err := actions.NewRuleActions(ctx, ruletype, prov, &actionConfig)
+	actionEngine, err := actions.NewRuleActions(ctx, ruletype, prov, &actionConfig, nil /*alertsCfg*/, nil /*evt*/)
 	if err != nil {
 		return fmt.Errorf("cannot create rule actions engine: %w", err)
 	}
@@ -463,6 +465,31 @@ func getProvider(pstr string, token string, providerConfigFile string) (provifv1
 			return nil, fmt.Errorf("error instantiating gitlab provider: %w", err)
 		}
 		return client, nil
+	case "bitbucket":
+		// read provider config
+		cfg, err := bitbucket.ParseV1Config(cfgbytes)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing bitbucket provider config: %w", err)
+		}
+
+		// We may pass a "fake" webhook URL here as it is not used in the test
+		client, err := bitbucket.New(credentials.NewBitbucketTokenCredential(token), cfg, "fake", "fake")
+		if err != nil {
+			return nil, fmt.Errorf("error instantiating bitbucket provider: %w", err)
+		}
+		return client, nil
+	case "oci":
+		// read provider config
+		cfg, err := ociregistry.ParseV1Config(cfgbytes)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing oci provider config: %w", err)
+		}
+
+		client, err := ociregistry.New(credentials.NewOCIRegistryCredential(cfg.GetUsername(), token), cfg)
+		if err != nil {
+			return nil, fmt.Errorf("error instantiating oci provider: %w", err)
+		}
+		return client, nil
 	default:
 		return nil, fmt.Errorf("unknown or unsupported provider: %s", pstr)
 	}
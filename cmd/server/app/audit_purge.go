@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/pkg/config"
+	serverconfig "github.com/mindersec/minder/pkg/config/server"
+)
+
+// auditPurgeCmd represents the `audit purge` command
+var auditPurgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Removes audit log entries",
+	Long:  `deletes all audit log entries older than the retention period, 90 days by default`,
+	RunE:  auditPurgeCommand,
+}
+
+func auditPurgeCommand(cmd *cobra.Command, _ []string) error {
+	if err := viper.BindPFlags(cmd.Flags()); err != nil {
+		return fmt.Errorf("error binding flags: %s", err)
+	}
+	cfg, err := config.ReadConfigFromViper[serverconfig.Config](viper.GetViper())
+	if err != nil {
+		cliErrorf(cmd, "unable to read config: %s", err)
+	}
+
+	batchSize := viper.GetUint("batch-size")
+	dryRun := viper.GetBool("dry-run")
+	retentionDays := viper.GetUint("retention-days")
+	if batchSize == 0 || retentionDays == 0 {
+		cliErrorf(cmd, "batch-size and retention-days must be positive")
+	}
+
+	ctx := serverconfig.LoggerFromConfigFlags(cfg.LoggingConfig).WithContext(context.Background())
+
+	// instantiate `db.Store` so we can run queries
+	store, closer, err := wireUpDB(ctx, cfg)
+	if err != nil {
+		cliErrorf(cmd, "unable to connect to database: %s", err)
+	}
+	defer closer()
+
+	threshold := time.Now().UTC().AddDate(0, 0, -int(retentionDays))
+	zerolog.Ctx(ctx).Info().Msgf("Calculated threshold is %s", threshold)
+
+	if err := auditPurgeLoop(ctx, store, threshold, batchSize, dryRun); err != nil {
+		cliErrorf(cmd, "failed purging audit log: %s", err)
+	}
+
+	return nil
+}
+
+// auditPurgeLoop deletes the audit events older than the given threshold.
+// Unlike the evaluation history, no audit event has to be retained past
+// the threshold, so batches are deleted until none are left.
+func auditPurgeLoop(
+	ctx context.Context,
+	store db.Store,
+	threshold time.Time,
+	batchSize uint,
+	dryRun bool,
+) error {
+	// Skip deletion if --dry-run was passed.
+	if dryRun {
+		count, err := store.CountAuditEventsBefore(ctx, threshold)
+		if err != nil {
+			return fmt.Errorf("error counting audit events: %w", err)
+		}
+		zerolog.Ctx(ctx).Info().Msgf("Would delete %d records", count)
+		return nil
+	}
+
+	var deleted int64
+	for {
+		partial, err := store.DeleteAuditEventsBefore(ctx, db.DeleteAuditEventsBeforeParams{
+			Threshold: threshold,
+			Size:      int64(batchSize),
+		})
+		if err != nil {
+			return fmt.Errorf("error while deleting old audit events: %w", err)
+		}
+		deleted += partial
+		if partial < int64(batchSize) {
+			break
+		}
+	}
+
+	zerolog.Ctx(ctx).Info().Msgf("Done purging audit log, deleted %d records", deleted)
+
+	return nil
+}
+
+func init() {
+	auditCmd.AddCommand(auditPurgeCmd)
+	auditPurgeCmd.Flags().UintP("batch-size", "", 1000, "Size of the deletion batch")
+	auditPurgeCmd.Flags().Uint("retention-days", 90, "Number of days for which audit log entries are kept")
+	auditPurgeCmd.Flags().Bool("dry-run", false, "Avoids deleting, printing out details about the operation")
+}
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/mindersec/minder/internal/db"
+	dbf "github.com/mindersec/minder/internal/db/fixtures"
+)
+
+func TestAuditPurgeLoop(t *testing.T) {
+	t.Parallel()
+
+	threshold := time.Now().AddDate(0, 0, -90)
+	params := db.DeleteAuditEventsBeforeParams{
+		Threshold: threshold,
+		Size:      2,
+	}
+
+	tests := []struct {
+		name    string
+		dbSetup dbf.DBMockBuilder
+		dryRun  bool
+		err     bool
+	}{
+		{
+			name: "batches",
+			dbSetup: dbf.NewDBMock(
+				withDeleteAuditEventsBefore(params, nil, 2, 2, 1),
+			),
+		},
+		{
+			name: "last batch is full",
+			dbSetup: dbf.NewDBMock(
+				withDeleteAuditEventsBefore(params, nil, 2, 0),
+			),
+		},
+		{
+			name: "dry run",
+			dbSetup: dbf.NewDBMock(
+				func(mock dbf.DBMock) {
+					mock.EXPECT().
+						CountAuditEventsBefore(gomock.Any(), threshold).
+						Return(int64(5), nil)
+				},
+			),
+			dryRun: true,
+		},
+		{
+			name: "write error",
+			dbSetup: dbf.NewDBMock(
+				withDeleteAuditEventsBefore(params, errors.New("boom"), 0),
+			),
+			err: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := tt.dbSetup(ctrl)
+
+			err := auditPurgeLoop(context.Background(), store, threshold, 2, tt.dryRun)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func withDeleteAuditEventsBefore(
+	params db.DeleteAuditEventsBeforeParams,
+	err error,
+	deleted ...int64,
+) func(dbf.DBMock) {
+	return func(mock dbf.DBMock) {
+		calls := []any{}
+		for _, d := range deleted {
+			call := mock.EXPECT().
+				DeleteAuditEventsBefore(gomock.Any(), params).
+				Return(d, err)
+			calls = append(calls, call)
+		}
+		gomock.InOrder(calls...)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/mindersec/minder/internal/auth/jwt/dynamic"
 	"github.com/mindersec/minder/internal/auth/jwt/merged"
 	"github.com/mindersec/minder/internal/auth/keycloak"
+	"github.com/mindersec/minder/internal/auth/oidc"
 	"github.com/mindersec/minder/internal/authz"
 	cpmetrics "github.com/mindersec/minder/internal/controlplane/metrics"
 	"github.com/mindersec/minder/internal/db"
@@ -84,22 +85,44 @@ var serveCmd = &cobra.Command{
 		// .../.well-known/jwks.json or .../.well-known/openid-configuration endpoint.  Right
 		// now it's just a hostname.  When we have this, we can consolidate the jwksUrl and issUrl,
 		// and remove the Keycloak-specific paths.
-		jwksUrl, err := cfg.Identity.Server.Path("/realms/stacklok/protocol/openid-connect/certs")
-		if err != nil {
-			return fmt.Errorf("failed to create JWKS URL: %w\n", err)
-		}
-		issUrl, err := cfg.Identity.Server.JwtUrl()
-		if err != nil {
-			return fmt.Errorf("failed to create issuer URL: %w\n", err)
+		var validators []jwt.Validator
+		var idProviders []auth.IdentityProvider
+		var allowedIssuers []string
+		// A generic OIDC provider may replace Keycloak as the default identity provider
+		if cfg.Identity.DefaultOIDC() == nil {
+			jwksUrl, err := cfg.Identity.Server.Path("/realms/stacklok/protocol/openid-connect/certs")
+			if err != nil {
+				return fmt.Errorf("failed to create JWKS URL: %w\n", err)
+			}
+			issUrl, err := cfg.Identity.Server.JwtUrl()
+			if err != nil {
+				return fmt.Errorf("failed to create issuer URL: %w\n", err)
+			}
+			staticJwt, err := jwt.NewJwtValidator(ctx, jwksUrl.String(), issUrl.String(), cfg.Identity.Server.Audience)
+			if err != nil {
+				return fmt.Errorf("failed to fetch and cache identity provider JWKS: %w\n", err)
+			}
+			validators = append(validators, staticJwt)
+			allowedIssuers = append(allowedIssuers, issUrl.String())
+
+			kc, err := keycloak.NewKeyCloak("", cfg.Identity.Server)
+			if err != nil {
+				return fmt.Errorf("unable to create keycloak identity provider: %w", err)
+			}
+			idProviders = append(idProviders, kc)
 		}
-		staticJwt, err := jwt.NewJwtValidator(ctx, jwksUrl.String(), issUrl.String(), cfg.Identity.Server.Audience)
-		if err != nil {
-			return fmt.Errorf("failed to fetch and cache identity provider JWKS: %w\n", err)
+		// Generic OIDC providers validate the tokens they issue themselves
+		for _, oidcCfg := range cfg.Identity.OIDC {
+			oidcProvider, err := oidc.NewOIDC(ctx, oidcCfg, store)
+			if err != nil {
+				return fmt.Errorf("unable to create OIDC identity provider %q: %w", oidcCfg.Name, err)
+			}
+			validators = append(validators, oidcProvider)
+			idProviders = append(idProviders, oidcProvider)
 		}
-		allowedIssuers := []string{issUrl.String()}
 		allowedIssuers = append(allowedIssuers, cfg.Identity.AdditionalIssuers...)
 		dynamicJwt := dynamic.NewDynamicValidator(ctx, cfg.Identity.Server.Audience, allowedIssuers)
-		jwt := merged.Validator{Validators: []jwt.Validator{staticJwt, dynamicJwt}}
+		jwt := merged.Validator{Validators: append(validators, dynamicJwt)}
 
 		authzc, err := authz.NewAuthzClient(&cfg.Authz, l)
 		if err != nil {
@@ -110,11 +133,7 @@ var serveCmd = &cobra.Command{
 			return fmt.Errorf("unable to prepare authz client for run: %w", err)
 		}
 
-		kc, err := keycloak.NewKeyCloak("", cfg.Identity.Server)
-		if err != nil {
-			return fmt.Errorf("unable to create keycloak identity provider: %w", err)
-		}
-		idClient, err := auth.NewIdentityClient(kc, &githubactions.GitHubActions{})
+		idClient, err := auth.NewIdentityClient(append(idProviders, &githubactions.GitHubActions{})...)
 		if err != nil {
 			return fmt.Errorf("unable to create identity client: %w", err)
 		}
@@ -0,0 +1,5 @@
+-- SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+-- SPDX-License-Identifier: Apache-2.0
+
+-- Add `bitbucket` provider class
+ALTER TYPE provider_class ADD VALUE 'bitbucket';
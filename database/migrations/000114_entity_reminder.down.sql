@@ -0,0 +1,10 @@
+-- SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+-- SPDX-License-Identifier: Apache-2.0
+
+BEGIN;
+
+DROP INDEX IF EXISTS entity_instances_entity_type_id_idx;
+
+ALTER TABLE entity_instances DROP COLUMN reminder_last_sent;
+
+COMMIT;
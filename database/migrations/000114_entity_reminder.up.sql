@@ -0,0 +1,11 @@
+-- SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+-- SPDX-License-Identifier: Apache-2.0
+
+BEGIN;
+
+ALTER TABLE entity_instances ADD COLUMN reminder_last_sent TIMESTAMP;
+
+-- The reminder pages through the entities of each type ordered by ID.
+CREATE INDEX entity_instances_entity_type_id_idx ON entity_instances (entity_type, id);
+
+COMMIT;
@@ -0,0 +1,6 @@
+-- SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+-- SPDX-License-Identifier: Apache-2.0
+
+-- Add `exempt` evaluation status, used when a rule exception covers the
+-- evaluated entity
+ALTER TYPE eval_status_types ADD VALUE 'exempt';
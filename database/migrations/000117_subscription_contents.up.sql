@@ -0,0 +1,22 @@
+-- SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+-- SPDX-License-Identifier: Apache-2.0
+
+BEGIN;
+
+-- Subscription contents record the rule types, data sources and profiles
+-- installed in a project from a bundle. The bundle digest is the digest of
+-- the content as shipped in the bundle, and is used to tell which contents
+-- changed in a new version of the bundle. The installed digest is the
+-- digest of the content as stored after installing it, and is used to tell
+-- whether it was modified since.
+CREATE TABLE subscription_contents(
+    subscription_id UUID NOT NULL REFERENCES subscriptions(id) ON DELETE CASCADE,
+    content_type TEXT NOT NULL CHECK (content_type IN ('rule_type', 'data_source', 'profile')),
+    name TEXT NOT NULL,
+    bundle_digest TEXT NOT NULL,
+    installed_digest TEXT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (subscription_id, content_type, name)
+);
+
+COMMIT;
@@ -0,0 +1,5 @@
+-- SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+-- SPDX-License-Identifier: Apache-2.0
+
+-- Postgres doesn't support removing values from an enum, so the
+-- organization entity type is left in place.
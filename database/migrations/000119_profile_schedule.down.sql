@@ -0,0 +1,9 @@
+-- SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+-- SPDX-License-Identifier: Apache-2.0
+
+BEGIN;
+
+ALTER TABLE profiles DROP COLUMN schedule_min_freshness;
+ALTER TABLE profiles DROP COLUMN schedule_cron;
+
+COMMIT;
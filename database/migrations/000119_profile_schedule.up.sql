@@ -0,0 +1,11 @@
+-- SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+-- SPDX-License-Identifier: Apache-2.0
+
+BEGIN;
+
+-- Profiles with a schedule are evaluated by the reminder according to it,
+-- rather than on every entity event. At most one of the columns is set.
+ALTER TABLE profiles ADD COLUMN schedule_cron TEXT NOT NULL DEFAULT '';
+ALTER TABLE profiles ADD COLUMN schedule_min_freshness TEXT NOT NULL DEFAULT '';
+
+COMMIT;
@@ -0,0 +1,26 @@
+-- SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+-- SPDX-License-Identifier: Apache-2.0
+
+BEGIN;
+
+-- Cached identities record the claims of the users who logged in through a
+-- generic OIDC identity provider. Unlike Keycloak, those providers have no
+-- standard API to look users up, so users are resolved from this table.
+-- The issuer is used rather than the provider name, so renaming a provider
+-- in the configuration does not lose its users.
+CREATE TABLE cached_identities(
+    issuer TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    human_name TEXT NOT NULL,
+    email TEXT NOT NULL DEFAULT '',
+    first_name TEXT NOT NULL DEFAULT '',
+    last_name TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (issuer, subject)
+);
+
+-- users may be resolved by their human-readable name or email
+CREATE INDEX cached_identities_human_name_idx ON cached_identities (issuer, human_name);
+CREATE INDEX cached_identities_email_idx ON cached_identities (issuer, email);
+
+COMMIT;
@@ -0,0 +1,23 @@
+-- SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+-- SPDX-License-Identifier: Apache-2.0
+
+BEGIN;
+
+-- Audit events record the mutating API calls made to Minder. The project
+-- is not a foreign key, so that the record of changes to a project
+-- outlives the project itself.
+CREATE TABLE audit_events(
+    id UUID NOT NULL DEFAULT gen_random_uuid() PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    project_id UUID,
+    actor TEXT NOT NULL,
+    actor_name TEXT NOT NULL DEFAULT '',
+    rpc TEXT NOT NULL,
+    target TEXT NOT NULL DEFAULT '',
+    request JSONB NOT NULL,
+    status TEXT NOT NULL
+);
+
+CREATE INDEX audit_events_project_id_created_at_idx ON audit_events(project_id, created_at);
+
+COMMIT;
@@ -0,0 +1,10 @@
+-- SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+-- SPDX-License-Identifier: Apache-2.0
+
+BEGIN;
+
+-- Inherited profiles are evaluated against the entities of the descendant
+-- projects of their project, as well as its own entities.
+ALTER TABLE profiles ADD COLUMN inherited BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMIT;
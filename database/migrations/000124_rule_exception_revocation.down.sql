@@ -0,0 +1,12 @@
+-- SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+-- SPDX-License-Identifier: Apache-2.0
+
+BEGIN;
+
+-- Revoked exceptions would become active again once the columns are gone
+DELETE FROM rule_exceptions WHERE revoked_at IS NOT NULL;
+
+ALTER TABLE rule_exceptions DROP COLUMN revoked_at;
+ALTER TABLE rule_exceptions DROP COLUMN revoked_by;
+
+COMMIT;
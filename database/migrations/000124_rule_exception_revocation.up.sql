@@ -0,0 +1,11 @@
+-- SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+-- SPDX-License-Identifier: Apache-2.0
+
+BEGIN;
+
+-- Rule exceptions are revoked instead of deleted, so that the audit trail
+-- of who approved an exemption, and who ended it, is kept.
+ALTER TABLE rule_exceptions ADD COLUMN revoked_by TEXT;
+ALTER TABLE rule_exceptions ADD COLUMN revoked_at TIMESTAMP WITH TIME ZONE;
+
+COMMIT;
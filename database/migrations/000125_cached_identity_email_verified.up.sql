@@ -0,0 +1,10 @@
+-- SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+-- SPDX-License-Identifier: Apache-2.0
+
+BEGIN;
+
+-- Users are only resolved by email if their identity provider verified it.
+-- Existing identities are verified again the next time their users log in.
+ALTER TABLE cached_identities ADD COLUMN email_verified BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMIT;
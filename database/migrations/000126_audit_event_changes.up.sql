@@ -0,0 +1,10 @@
+-- SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+-- SPDX-License-Identifier: Apache-2.0
+
+BEGIN;
+
+-- The redacted before and after values of the fields a call changed, for
+-- the calls on resources whose state can be read.
+ALTER TABLE audit_events ADD COLUMN changes JSONB;
+
+COMMIT;
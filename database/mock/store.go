@@ -14,6 +14,7 @@ import (
 	sql "database/sql"
 	json "encoding/json"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	db "github.com/mindersec/minder/internal/db"
@@ -132,6 +133,36 @@ func (mr *MockStoreMockRecorder) Commit(tx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockStore)(nil).Commit), tx)
 }
 
+// CountAuditEventsBefore mocks base method.
+func (m *MockStore) CountAuditEventsBefore(ctx context.Context, threshold time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAuditEventsBefore", ctx, threshold)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAuditEventsBefore indicates an expected call of CountAuditEventsBefore.
+func (mr *MockStoreMockRecorder) CountAuditEventsBefore(ctx, threshold any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAuditEventsBefore", reflect.TypeOf((*MockStore)(nil).CountAuditEventsBefore), ctx, threshold)
+}
+
+// CountEntitiesByEvaluationStatus mocks base method.
+func (m *MockStore) CountEntitiesByEvaluationStatus(ctx context.Context, arg db.CountEntitiesByEvaluationStatusParams) ([]db.CountEntitiesByEvaluationStatusRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountEntitiesByEvaluationStatus", ctx, arg)
+	ret0, _ := ret[0].([]db.CountEntitiesByEvaluationStatusRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountEntitiesByEvaluationStatus indicates an expected call of CountEntitiesByEvaluationStatus.
+func (mr *MockStoreMockRecorder) CountEntitiesByEvaluationStatus(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountEntitiesByEvaluationStatus", reflect.TypeOf((*MockStore)(nil).CountEntitiesByEvaluationStatus), ctx, arg)
+}
+
 // CountProfilesByEntityType mocks base method.
 func (m *MockStore) CountProfilesByEntityType(ctx context.Context) ([]db.CountProfilesByEntityTypeRow, error) {
 	m.ctrl.T.Helper()
@@ -222,6 +253,21 @@ func (mr *MockStoreMockRecorder) CountUsers(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsers", reflect.TypeOf((*MockStore)(nil).CountUsers), ctx)
 }
 
+// CreateCustomRole mocks base method.
+func (m *MockStore) CreateCustomRole(ctx context.Context, arg db.CreateCustomRoleParams) (db.CustomRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCustomRole", ctx, arg)
+	ret0, _ := ret[0].(db.CustomRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCustomRole indicates an expected call of CreateCustomRole.
+func (mr *MockStoreMockRecorder) CreateCustomRole(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomRole", reflect.TypeOf((*MockStore)(nil).CreateCustomRole), ctx, arg)
+}
+
 // CreateDataSource mocks base method.
 func (m *MockStore) CreateDataSource(ctx context.Context, arg db.CreateDataSourceParams) (db.DataSource, error) {
 	m.ctrl.T.Helper()
@@ -401,6 +447,21 @@ func (mr *MockStoreMockRecorder) CreateRepository(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRepository", reflect.TypeOf((*MockStore)(nil).CreateRepository), ctx, arg)
 }
 
+// CreateRuleException mocks base method.
+func (m *MockStore) CreateRuleException(ctx context.Context, arg db.CreateRuleExceptionParams) (db.RuleException, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRuleException", ctx, arg)
+	ret0, _ := ret[0].(db.RuleException)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRuleException indicates an expected call of CreateRuleException.
+func (mr *MockStoreMockRecorder) CreateRuleException(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRuleException", reflect.TypeOf((*MockStore)(nil).CreateRuleException), ctx, arg)
+}
+
 // CreateRuleType mocks base method.
 func (m *MockStore) CreateRuleType(ctx context.Context, arg db.CreateRuleTypeParams) (db.RuleType, error) {
 	m.ctrl.T.Helper()
@@ -504,6 +565,50 @@ func (mr *MockStoreMockRecorder) DeleteArtifact(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteArtifact", reflect.TypeOf((*MockStore)(nil).DeleteArtifact), ctx, id)
 }
 
+// DeleteAuditEventsBefore mocks base method.
+func (m *MockStore) DeleteAuditEventsBefore(ctx context.Context, arg db.DeleteAuditEventsBeforeParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAuditEventsBefore", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAuditEventsBefore indicates an expected call of DeleteAuditEventsBefore.
+func (mr *MockStoreMockRecorder) DeleteAuditEventsBefore(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAuditEventsBefore", reflect.TypeOf((*MockStore)(nil).DeleteAuditEventsBefore), ctx, arg)
+}
+
+// DeleteCachedIdentity mocks base method.
+func (m *MockStore) DeleteCachedIdentity(ctx context.Context, arg db.DeleteCachedIdentityParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCachedIdentity", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCachedIdentity indicates an expected call of DeleteCachedIdentity.
+func (mr *MockStoreMockRecorder) DeleteCachedIdentity(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCachedIdentity", reflect.TypeOf((*MockStore)(nil).DeleteCachedIdentity), ctx, arg)
+}
+
+// DeleteCustomRole mocks base method.
+func (m *MockStore) DeleteCustomRole(ctx context.Context, arg db.DeleteCustomRoleParams) (db.CustomRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCustomRole", ctx, arg)
+	ret0, _ := ret[0].(db.CustomRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCustomRole indicates an expected call of DeleteCustomRole.
+func (mr *MockStoreMockRecorder) DeleteCustomRole(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomRole", reflect.TypeOf((*MockStore)(nil).DeleteCustomRole), ctx, arg)
+}
+
 // DeleteDataSource mocks base method.
 func (m *MockStore) DeleteDataSource(ctx context.Context, arg db.DeleteDataSourceParams) (db.DataSource, error) {
 	m.ctrl.T.Helper()
@@ -834,6 +939,21 @@ func (mr *MockStoreMockRecorder) EnqueueFlush(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueFlush", reflect.TypeOf((*MockStore)(nil).EnqueueFlush), ctx, arg)
 }
 
+// EntityExistsAfterID mocks base method.
+func (m *MockStore) EntityExistsAfterID(ctx context.Context, arg db.EntityExistsAfterIDParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EntityExistsAfterID", ctx, arg)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EntityExistsAfterID indicates an expected call of EntityExistsAfterID.
+func (mr *MockStoreMockRecorder) EntityExistsAfterID(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EntityExistsAfterID", reflect.TypeOf((*MockStore)(nil).EntityExistsAfterID), ctx, arg)
+}
+
 // FindProviders mocks base method.
 func (m *MockStore) FindProviders(ctx context.Context, arg db.FindProvidersParams) ([]db.Provider, error) {
 	m.ctrl.T.Helper()
@@ -924,6 +1044,21 @@ func (mr *MockStoreMockRecorder) GetAccessTokenSinceDate(ctx, arg any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccessTokenSinceDate", reflect.TypeOf((*MockStore)(nil).GetAccessTokenSinceDate), ctx, arg)
 }
 
+// GetActiveRuleException mocks base method.
+func (m *MockStore) GetActiveRuleException(ctx context.Context, arg db.GetActiveRuleExceptionParams) (db.RuleException, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveRuleException", ctx, arg)
+	ret0, _ := ret[0].(db.RuleException)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveRuleException indicates an expected call of GetActiveRuleException.
+func (mr *MockStoreMockRecorder) GetActiveRuleException(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveRuleException", reflect.TypeOf((*MockStore)(nil).GetActiveRuleException), ctx, arg)
+}
+
 // GetAllPropertiesForEntity mocks base method.
 func (m *MockStore) GetAllPropertiesForEntity(ctx context.Context, entityID uuid.UUID) ([]db.Property, error) {
 	m.ctrl.T.Helper()
@@ -1014,6 +1149,21 @@ func (mr *MockStoreMockRecorder) GetChildrenProjects(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChildrenProjects", reflect.TypeOf((*MockStore)(nil).GetChildrenProjects), ctx, id)
 }
 
+// GetCustomRoleByName mocks base method.
+func (m *MockStore) GetCustomRoleByName(ctx context.Context, arg db.GetCustomRoleByNameParams) (db.CustomRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomRoleByName", ctx, arg)
+	ret0, _ := ret[0].(db.CustomRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomRoleByName indicates an expected call of GetCustomRoleByName.
+func (mr *MockStoreMockRecorder) GetCustomRoleByName(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomRoleByName", reflect.TypeOf((*MockStore)(nil).GetCustomRoleByName), ctx, arg)
+}
+
 // GetDataSource mocks base method.
 func (m *MockStore) GetDataSource(ctx context.Context, arg db.GetDataSourceParams) (db.DataSource, error) {
 	m.ctrl.T.Helper()
@@ -1658,6 +1808,21 @@ func (mr *MockStoreMockRecorder) GetRuleEvaluationByProfileIdAndRuleType(ctx, pr
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuleEvaluationByProfileIdAndRuleType", reflect.TypeOf((*MockStore)(nil).GetRuleEvaluationByProfileIdAndRuleType), ctx, profileID, ruleName, entityID, ruleTypeName)
 }
 
+// GetRuleInstanceByProfileAndName mocks base method.
+func (m *MockStore) GetRuleInstanceByProfileAndName(ctx context.Context, arg db.GetRuleInstanceByProfileAndNameParams) (db.RuleInstance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuleInstanceByProfileAndName", ctx, arg)
+	ret0, _ := ret[0].(db.RuleInstance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRuleInstanceByProfileAndName indicates an expected call of GetRuleInstanceByProfileAndName.
+func (mr *MockStoreMockRecorder) GetRuleInstanceByProfileAndName(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuleInstanceByProfileAndName", reflect.TypeOf((*MockStore)(nil).GetRuleInstanceByProfileAndName), ctx, arg)
+}
+
 // GetRuleInstancesEntityInProjects mocks base method.
 func (m *MockStore) GetRuleInstancesEntityInProjects(ctx context.Context, arg db.GetRuleInstancesEntityInProjectsParams) ([]db.RuleInstance, error) {
 	m.ctrl.T.Helper()
@@ -1927,6 +2092,20 @@ func (mr *MockStoreMockRecorder) InsertAlertEvent(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAlertEvent", reflect.TypeOf((*MockStore)(nil).InsertAlertEvent), ctx, arg)
 }
 
+// InsertAuditEvent mocks base method.
+func (m *MockStore) InsertAuditEvent(ctx context.Context, arg db.InsertAuditEventParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAuditEvent", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertAuditEvent indicates an expected call of InsertAuditEvent.
+func (mr *MockStoreMockRecorder) InsertAuditEvent(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAuditEvent", reflect.TypeOf((*MockStore)(nil).InsertAuditEvent), ctx, arg)
+}
+
 // InsertEvaluationRuleEntity mocks base method.
 func (m *MockStore) InsertEvaluationRuleEntity(ctx context.Context, arg db.InsertEvaluationRuleEntityParams) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -1971,6 +2150,21 @@ func (mr *MockStoreMockRecorder) InsertRemediationEvent(ctx, arg any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertRemediationEvent", reflect.TypeOf((*MockStore)(nil).InsertRemediationEvent), ctx, arg)
 }
 
+// ListAlertEventsByDelivery mocks base method.
+func (m *MockStore) ListAlertEventsByDelivery(ctx context.Context, eventID string) ([]db.AlertEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAlertEventsByDelivery", ctx, eventID)
+	ret0, _ := ret[0].([]db.AlertEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAlertEventsByDelivery indicates an expected call of ListAlertEventsByDelivery.
+func (mr *MockStoreMockRecorder) ListAlertEventsByDelivery(ctx, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAlertEventsByDelivery", reflect.TypeOf((*MockStore)(nil).ListAlertEventsByDelivery), ctx, eventID)
+}
+
 // ListAllRootProjects mocks base method.
 func (m *MockStore) ListAllRootProjects(ctx context.Context) ([]db.Project, error) {
 	m.ctrl.T.Helper()
@@ -2001,6 +2195,51 @@ func (mr *MockStoreMockRecorder) ListArtifactsByRepoID(ctx, repositoryID any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListArtifactsByRepoID", reflect.TypeOf((*MockStore)(nil).ListArtifactsByRepoID), ctx, repositoryID)
 }
 
+// ListAuditEvents mocks base method.
+func (m *MockStore) ListAuditEvents(ctx context.Context, arg db.ListAuditEventsParams) ([]db.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditEvents", ctx, arg)
+	ret0, _ := ret[0].([]db.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditEvents indicates an expected call of ListAuditEvents.
+func (mr *MockStoreMockRecorder) ListAuditEvents(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditEvents", reflect.TypeOf((*MockStore)(nil).ListAuditEvents), ctx, arg)
+}
+
+// ListCachedIdentities mocks base method.
+func (m *MockStore) ListCachedIdentities(ctx context.Context, arg db.ListCachedIdentitiesParams) ([]db.CachedIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCachedIdentities", ctx, arg)
+	ret0, _ := ret[0].([]db.CachedIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCachedIdentities indicates an expected call of ListCachedIdentities.
+func (mr *MockStoreMockRecorder) ListCachedIdentities(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCachedIdentities", reflect.TypeOf((*MockStore)(nil).ListCachedIdentities), ctx, arg)
+}
+
+// ListCustomRolesByProject mocks base method.
+func (m *MockStore) ListCustomRolesByProject(ctx context.Context, projectID uuid.UUID) ([]db.CustomRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCustomRolesByProject", ctx, projectID)
+	ret0, _ := ret[0].([]db.CustomRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCustomRolesByProject indicates an expected call of ListCustomRolesByProject.
+func (mr *MockStoreMockRecorder) ListCustomRolesByProject(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCustomRolesByProject", reflect.TypeOf((*MockStore)(nil).ListCustomRolesByProject), ctx, projectID)
+}
+
 // ListDataSourceFunctions mocks base method.
 func (m *MockStore) ListDataSourceFunctions(ctx context.Context, arg db.ListDataSourceFunctionsParams) ([]db.DataSourcesFunction, error) {
 	m.ctrl.T.Helper()
@@ -2031,6 +2270,21 @@ func (mr *MockStoreMockRecorder) ListDataSources(ctx, projects any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDataSources", reflect.TypeOf((*MockStore)(nil).ListDataSources), ctx, projects)
 }
 
+// ListEntitiesAfterID mocks base method.
+func (m *MockStore) ListEntitiesAfterID(ctx context.Context, arg db.ListEntitiesAfterIDParams) ([]db.EntityInstance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntitiesAfterID", ctx, arg)
+	ret0, _ := ret[0].([]db.EntityInstance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntitiesAfterID indicates an expected call of ListEntitiesAfterID.
+func (mr *MockStoreMockRecorder) ListEntitiesAfterID(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntitiesAfterID", reflect.TypeOf((*MockStore)(nil).ListEntitiesAfterID), ctx, arg)
+}
+
 // ListEvaluationHistory mocks base method.
 func (m *MockStore) ListEvaluationHistory(ctx context.Context, arg db.ListEvaluationHistoryParams) ([]db.ListEvaluationHistoryRow, error) {
 	m.ctrl.T.Helper()
@@ -2061,6 +2315,36 @@ func (mr *MockStoreMockRecorder) ListEvaluationHistoryStaleRecords(ctx, arg any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvaluationHistoryStaleRecords", reflect.TypeOf((*MockStore)(nil).ListEvaluationHistoryStaleRecords), ctx, arg)
 }
 
+// ListEvaluationRemediationTimes mocks base method.
+func (m *MockStore) ListEvaluationRemediationTimes(ctx context.Context, arg db.ListEvaluationRemediationTimesParams) ([]db.ListEvaluationRemediationTimesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEvaluationRemediationTimes", ctx, arg)
+	ret0, _ := ret[0].([]db.ListEvaluationRemediationTimesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEvaluationRemediationTimes indicates an expected call of ListEvaluationRemediationTimes.
+func (mr *MockStoreMockRecorder) ListEvaluationRemediationTimes(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvaluationRemediationTimes", reflect.TypeOf((*MockStore)(nil).ListEvaluationRemediationTimes), ctx, arg)
+}
+
+// ListEvaluationTrends mocks base method.
+func (m *MockStore) ListEvaluationTrends(ctx context.Context, arg db.ListEvaluationTrendsParams) ([]db.ListEvaluationTrendsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEvaluationTrends", ctx, arg)
+	ret0, _ := ret[0].([]db.ListEvaluationTrendsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEvaluationTrends indicates an expected call of ListEvaluationTrends.
+func (mr *MockStoreMockRecorder) ListEvaluationTrends(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvaluationTrends", reflect.TypeOf((*MockStore)(nil).ListEvaluationTrends), ctx, arg)
+}
+
 // ListFlushCache mocks base method.
 func (m *MockStore) ListFlushCache(ctx context.Context) ([]db.FlushCache, error) {
 	m.ctrl.T.Helper()
@@ -2076,6 +2360,21 @@ func (mr *MockStoreMockRecorder) ListFlushCache(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFlushCache", reflect.TypeOf((*MockStore)(nil).ListFlushCache), ctx)
 }
 
+// ListInheritedProfileStatusByProject mocks base method.
+func (m *MockStore) ListInheritedProfileStatusByProject(ctx context.Context, arg db.ListInheritedProfileStatusByProjectParams) ([]db.ListInheritedProfileStatusByProjectRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInheritedProfileStatusByProject", ctx, arg)
+	ret0, _ := ret[0].([]db.ListInheritedProfileStatusByProjectRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInheritedProfileStatusByProject indicates an expected call of ListInheritedProfileStatusByProject.
+func (mr *MockStoreMockRecorder) ListInheritedProfileStatusByProject(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInheritedProfileStatusByProject", reflect.TypeOf((*MockStore)(nil).ListInheritedProfileStatusByProject), ctx, arg)
+}
+
 // ListInvitationsForProject mocks base method.
 func (m *MockStore) ListInvitationsForProject(ctx context.Context, project uuid.UUID) ([]db.ListInvitationsForProjectRow, error) {
 	m.ctrl.T.Helper()
@@ -2091,19 +2390,34 @@ func (mr *MockStoreMockRecorder) ListInvitationsForProject(ctx, project any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvitationsForProject", reflect.TypeOf((*MockStore)(nil).ListInvitationsForProject), ctx, project)
 }
 
-// ListOldestRuleEvaluationsByRepositoryId mocks base method.
-func (m *MockStore) ListOldestRuleEvaluationsByRepositoryId(ctx context.Context, repositoryIds []uuid.UUID) ([]db.ListOldestRuleEvaluationsByRepositoryIdRow, error) {
+// ListOldestRuleEvaluationsByEntityID mocks base method.
+func (m *MockStore) ListOldestRuleEvaluationsByEntityID(ctx context.Context, entityIds []uuid.UUID) ([]db.ListOldestRuleEvaluationsByEntityIDRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOldestRuleEvaluationsByEntityID", ctx, entityIds)
+	ret0, _ := ret[0].([]db.ListOldestRuleEvaluationsByEntityIDRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOldestRuleEvaluationsByEntityID indicates an expected call of ListOldestRuleEvaluationsByEntityID.
+func (mr *MockStoreMockRecorder) ListOldestRuleEvaluationsByEntityID(ctx, entityIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOldestRuleEvaluationsByEntityID", reflect.TypeOf((*MockStore)(nil).ListOldestRuleEvaluationsByEntityID), ctx, entityIds)
+}
+
+// ListProfileEvaluationTimesByEntity mocks base method.
+func (m *MockStore) ListProfileEvaluationTimesByEntity(ctx context.Context, arg db.ListProfileEvaluationTimesByEntityParams) ([]db.ListProfileEvaluationTimesByEntityRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListOldestRuleEvaluationsByRepositoryId", ctx, repositoryIds)
-	ret0, _ := ret[0].([]db.ListOldestRuleEvaluationsByRepositoryIdRow)
+	ret := m.ctrl.Call(m, "ListProfileEvaluationTimesByEntity", ctx, arg)
+	ret0, _ := ret[0].([]db.ListProfileEvaluationTimesByEntityRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListOldestRuleEvaluationsByRepositoryId indicates an expected call of ListOldestRuleEvaluationsByRepositoryId.
-func (mr *MockStoreMockRecorder) ListOldestRuleEvaluationsByRepositoryId(ctx, repositoryIds any) *gomock.Call {
+// ListProfileEvaluationTimesByEntity indicates an expected call of ListProfileEvaluationTimesByEntity.
+func (mr *MockStoreMockRecorder) ListProfileEvaluationTimesByEntity(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOldestRuleEvaluationsByRepositoryId", reflect.TypeOf((*MockStore)(nil).ListOldestRuleEvaluationsByRepositoryId), ctx, repositoryIds)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProfileEvaluationTimesByEntity", reflect.TypeOf((*MockStore)(nil).ListProfileEvaluationTimesByEntity), ctx, arg)
 }
 
 // ListProfilesByProjectIDAndLabel mocks base method.
@@ -2181,21 +2495,6 @@ func (mr *MockStoreMockRecorder) ListRegisteredRepositoriesByProjectIDAndProvide
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRegisteredRepositoriesByProjectIDAndProvider", reflect.TypeOf((*MockStore)(nil).ListRegisteredRepositoriesByProjectIDAndProvider), ctx, arg)
 }
 
-// ListRepositoriesAfterID mocks base method.
-func (m *MockStore) ListRepositoriesAfterID(ctx context.Context, arg db.ListRepositoriesAfterIDParams) ([]db.Repository, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListRepositoriesAfterID", ctx, arg)
-	ret0, _ := ret[0].([]db.Repository)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// ListRepositoriesAfterID indicates an expected call of ListRepositoriesAfterID.
-func (mr *MockStoreMockRecorder) ListRepositoriesAfterID(ctx, arg any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRepositoriesAfterID", reflect.TypeOf((*MockStore)(nil).ListRepositoriesAfterID), ctx, arg)
-}
-
 // ListRepositoriesByProjectID mocks base method.
 func (m *MockStore) ListRepositoriesByProjectID(ctx context.Context, arg db.ListRepositoriesByProjectIDParams) ([]db.Repository, error) {
 	m.ctrl.T.Helper()
@@ -2226,6 +2525,21 @@ func (mr *MockStoreMockRecorder) ListRuleEvaluationsByProfileId(ctx, arg any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRuleEvaluationsByProfileId", reflect.TypeOf((*MockStore)(nil).ListRuleEvaluationsByProfileId), ctx, arg)
 }
 
+// ListRuleExceptionsByProject mocks base method.
+func (m *MockStore) ListRuleExceptionsByProject(ctx context.Context, projectID uuid.UUID) ([]db.ListRuleExceptionsByProjectRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRuleExceptionsByProject", ctx, projectID)
+	ret0, _ := ret[0].([]db.ListRuleExceptionsByProjectRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRuleExceptionsByProject indicates an expected call of ListRuleExceptionsByProject.
+func (mr *MockStoreMockRecorder) ListRuleExceptionsByProject(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRuleExceptionsByProject", reflect.TypeOf((*MockStore)(nil).ListRuleExceptionsByProject), ctx, projectID)
+}
+
 // ListRuleTypesByProject mocks base method.
 func (m *MockStore) ListRuleTypesByProject(ctx context.Context, projectID uuid.UUID) ([]db.RuleType, error) {
 	m.ctrl.T.Helper()
@@ -2256,6 +2570,51 @@ func (mr *MockStoreMockRecorder) ListRuleTypesReferencesByDataSource(ctx, dataSo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRuleTypesReferencesByDataSource", reflect.TypeOf((*MockStore)(nil).ListRuleTypesReferencesByDataSource), ctx, dataSourcesID)
 }
 
+// ListScheduledProfiles mocks base method.
+func (m *MockStore) ListScheduledProfiles(ctx context.Context) ([]db.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScheduledProfiles", ctx)
+	ret0, _ := ret[0].([]db.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScheduledProfiles indicates an expected call of ListScheduledProfiles.
+func (mr *MockStoreMockRecorder) ListScheduledProfiles(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScheduledProfiles", reflect.TypeOf((*MockStore)(nil).ListScheduledProfiles), ctx)
+}
+
+// ListSubscriptionContents mocks base method.
+func (m *MockStore) ListSubscriptionContents(ctx context.Context, subscriptionID uuid.UUID) ([]db.SubscriptionContent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubscriptionContents", ctx, subscriptionID)
+	ret0, _ := ret[0].([]db.SubscriptionContent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubscriptionContents indicates an expected call of ListSubscriptionContents.
+func (mr *MockStoreMockRecorder) ListSubscriptionContents(ctx, subscriptionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscriptionContents", reflect.TypeOf((*MockStore)(nil).ListSubscriptionContents), ctx, subscriptionID)
+}
+
+// ListSubscriptionsByProjects mocks base method.
+func (m *MockStore) ListSubscriptionsByProjects(ctx context.Context, projects []uuid.UUID) ([]db.ListSubscriptionsByProjectsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubscriptionsByProjects", ctx, projects)
+	ret0, _ := ret[0].([]db.ListSubscriptionsByProjectsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubscriptionsByProjects indicates an expected call of ListSubscriptionsByProjects.
+func (mr *MockStoreMockRecorder) ListSubscriptionsByProjects(ctx, projects any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscriptionsByProjects", reflect.TypeOf((*MockStore)(nil).ListSubscriptionsByProjects), ctx, projects)
+}
+
 // ListTokensToMigrate mocks base method.
 func (m *MockStore) ListTokensToMigrate(ctx context.Context, arg db.ListTokensToMigrateParams) ([]db.ProviderAccessToken, error) {
 	m.ctrl.T.Helper()
@@ -2330,19 +2689,19 @@ func (mr *MockStoreMockRecorder) ReleaseLock(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseLock", reflect.TypeOf((*MockStore)(nil).ReleaseLock), ctx, arg)
 }
 
-// RepositoryExistsAfterID mocks base method.
-func (m *MockStore) RepositoryExistsAfterID(ctx context.Context, id uuid.UUID) (bool, error) {
+// RevokeRuleException mocks base method.
+func (m *MockStore) RevokeRuleException(ctx context.Context, arg db.RevokeRuleExceptionParams) (db.RuleException, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RepositoryExistsAfterID", ctx, id)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "RevokeRuleException", ctx, arg)
+	ret0, _ := ret[0].(db.RuleException)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// RepositoryExistsAfterID indicates an expected call of RepositoryExistsAfterID.
-func (mr *MockStoreMockRecorder) RepositoryExistsAfterID(ctx, id any) *gomock.Call {
+// RevokeRuleException indicates an expected call of RevokeRuleException.
+func (mr *MockStoreMockRecorder) RevokeRuleException(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepositoryExistsAfterID", reflect.TypeOf((*MockStore)(nil).RepositoryExistsAfterID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRuleException", reflect.TypeOf((*MockStore)(nil).RevokeRuleException), ctx, arg)
 }
 
 // Rollback mocks base method.
@@ -2373,6 +2732,35 @@ func (mr *MockStoreMockRecorder) SetSubscriptionBundleVersion(ctx, arg any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubscriptionBundleVersion", reflect.TypeOf((*MockStore)(nil).SetSubscriptionBundleVersion), ctx, arg)
 }
 
+// UpdateAlertEventMetadata mocks base method.
+func (m *MockStore) UpdateAlertEventMetadata(ctx context.Context, arg db.UpdateAlertEventMetadataParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAlertEventMetadata", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAlertEventMetadata indicates an expected call of UpdateAlertEventMetadata.
+func (mr *MockStoreMockRecorder) UpdateAlertEventMetadata(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAlertEventMetadata", reflect.TypeOf((*MockStore)(nil).UpdateAlertEventMetadata), ctx, arg)
+}
+
+// UpdateCustomRole mocks base method.
+func (m *MockStore) UpdateCustomRole(ctx context.Context, arg db.UpdateCustomRoleParams) (db.CustomRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCustomRole", ctx, arg)
+	ret0, _ := ret[0].(db.CustomRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCustomRole indicates an expected call of UpdateCustomRole.
+func (mr *MockStoreMockRecorder) UpdateCustomRole(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomRole", reflect.TypeOf((*MockStore)(nil).UpdateCustomRole), ctx, arg)
+}
+
 // UpdateDataSource mocks base method.
 func (m *MockStore) UpdateDataSource(ctx context.Context, arg db.UpdateDataSourceParams) (db.DataSource, error) {
 	m.ctrl.T.Helper()
@@ -2490,18 +2878,18 @@ func (mr *MockStoreMockRecorder) UpdateProvider(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProvider", reflect.TypeOf((*MockStore)(nil).UpdateProvider), ctx, arg)
 }
 
-// UpdateReminderLastSentForRepositories mocks base method.
-func (m *MockStore) UpdateReminderLastSentForRepositories(ctx context.Context, repositoryIds []uuid.UUID) error {
+// UpdateReminderLastSentForEntities mocks base method.
+func (m *MockStore) UpdateReminderLastSentForEntities(ctx context.Context, entityIds []uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateReminderLastSentForRepositories", ctx, repositoryIds)
+	ret := m.ctrl.Call(m, "UpdateReminderLastSentForEntities", ctx, entityIds)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateReminderLastSentForRepositories indicates an expected call of UpdateReminderLastSentForRepositories.
-func (mr *MockStoreMockRecorder) UpdateReminderLastSentForRepositories(ctx, repositoryIds any) *gomock.Call {
+// UpdateReminderLastSentForEntities indicates an expected call of UpdateReminderLastSentForEntities.
+func (mr *MockStoreMockRecorder) UpdateReminderLastSentForEntities(ctx, entityIds any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReminderLastSentForRepositories", reflect.TypeOf((*MockStore)(nil).UpdateReminderLastSentForRepositories), ctx, repositoryIds)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReminderLastSentForEntities", reflect.TypeOf((*MockStore)(nil).UpdateReminderLastSentForEntities), ctx, entityIds)
 }
 
 // UpdateRuleType mocks base method.
@@ -2534,6 +2922,20 @@ func (mr *MockStoreMockRecorder) UpdateSelector(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSelector", reflect.TypeOf((*MockStore)(nil).UpdateSelector), ctx, arg)
 }
 
+// UpdateSubscriptionVersion mocks base method.
+func (m *MockStore) UpdateSubscriptionVersion(ctx context.Context, arg db.UpdateSubscriptionVersionParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubscriptionVersion", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSubscriptionVersion indicates an expected call of UpdateSubscriptionVersion.
+func (mr *MockStoreMockRecorder) UpdateSubscriptionVersion(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscriptionVersion", reflect.TypeOf((*MockStore)(nil).UpdateSubscriptionVersion), ctx, arg)
+}
+
 // UpsertAccessToken mocks base method.
 func (m *MockStore) UpsertAccessToken(ctx context.Context, arg db.UpsertAccessTokenParams) (db.ProviderAccessToken, error) {
 	m.ctrl.T.Helper()
@@ -2578,6 +2980,20 @@ func (mr *MockStoreMockRecorder) UpsertBundle(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertBundle", reflect.TypeOf((*MockStore)(nil).UpsertBundle), ctx, arg)
 }
 
+// UpsertCachedIdentity mocks base method.
+func (m *MockStore) UpsertCachedIdentity(ctx context.Context, arg db.UpsertCachedIdentityParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertCachedIdentity", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertCachedIdentity indicates an expected call of UpsertCachedIdentity.
+func (mr *MockStoreMockRecorder) UpsertCachedIdentity(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCachedIdentity", reflect.TypeOf((*MockStore)(nil).UpsertCachedIdentity), ctx, arg)
+}
+
 // UpsertInstallationID mocks base method.
 func (m *MockStore) UpsertInstallationID(ctx context.Context, arg db.UpsertInstallationIDParams) (db.ProviderGithubAppInstallation, error) {
 	m.ctrl.T.Helper()
@@ -2667,6 +3083,20 @@ func (mr *MockStoreMockRecorder) UpsertRuleInstance(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertRuleInstance", reflect.TypeOf((*MockStore)(nil).UpsertRuleInstance), ctx, arg)
 }
 
+// UpsertSubscriptionContent mocks base method.
+func (m *MockStore) UpsertSubscriptionContent(ctx context.Context, arg db.UpsertSubscriptionContentParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertSubscriptionContent", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertSubscriptionContent indicates an expected call of UpsertSubscriptionContent.
+func (mr *MockStoreMockRecorder) UpsertSubscriptionContent(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertSubscriptionContent", reflect.TypeOf((*MockStore)(nil).UpsertSubscriptionContent), ctx, arg)
+}
+
 // WithTransactionErr mocks base method.
 func (m *MockStore) WithTransactionErr(fn func(db.ExtendQuerier) error) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,38 @@
+-- InsertAuditEvent records a mutating API call.
+
+-- name: InsertAuditEvent :exec
+INSERT INTO audit_events (project_id, actor, actor_name, rpc, target, request, status, changes)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- ListAuditEvents lists the audit events of a project, from newest to
+-- oldest. The cursor is the ID of the last event of the previous page.
+
+-- name: ListAuditEvents :many
+SELECT * FROM audit_events
+WHERE project_id = sqlc.arg(project_id)
+  AND (sqlc.narg(actor)::text IS NULL OR actor = sqlc.narg(actor) OR actor_name = sqlc.narg(actor))
+  AND (sqlc.narg(rpc)::text IS NULL OR rpc = sqlc.narg(rpc) OR rpc LIKE '%/' || sqlc.narg(rpc))
+  AND (sqlc.narg(fromts)::timestamp with time zone IS NULL OR created_at >= sqlc.narg(fromts))
+  AND (sqlc.narg(tots)::timestamp with time zone IS NULL OR created_at < sqlc.narg(tots))
+  AND (sqlc.narg(cursor)::uuid IS NULL
+    OR (created_at, id) < (SELECT c.created_at, c.id FROM audit_events c WHERE c.id = sqlc.narg(cursor)))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(size)::bigint;
+
+-- DeleteAuditEventsBefore deletes a batch of the audit events recorded
+-- before the threshold, returning the number of deleted events.
+
+-- name: DeleteAuditEventsBefore :execrows
+DELETE FROM audit_events
+WHERE id IN (
+    SELECT id FROM audit_events
+    WHERE created_at < sqlc.arg(threshold)
+    ORDER BY created_at
+    LIMIT sqlc.arg(size)::bigint
+);
+
+-- CountAuditEventsBefore counts the audit events recorded before the
+-- threshold.
+
+-- name: CountAuditEventsBefore :one
+SELECT COUNT(*) FROM audit_events WHERE created_at < sqlc.arg(threshold);
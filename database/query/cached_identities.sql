@@ -0,0 +1,22 @@
+-- UpsertCachedIdentity records the claims of a user of a generic OIDC
+-- identity provider, replacing the ones recorded before.
+
+-- name: UpsertCachedIdentity :exec
+INSERT INTO cached_identities (issuer, subject, human_name, email, email_verified, first_name, last_name)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (issuer, subject) DO UPDATE
+SET human_name = $3, email = $4, email_verified = $5, first_name = $6, last_name = $7, updated_at = NOW();
+
+-- ListCachedIdentities returns the identities of an issuer whose subject,
+-- human-readable name or verified email match the given identifier.
+
+-- name: ListCachedIdentities :many
+SELECT * FROM cached_identities
+WHERE issuer = sqlc.arg(issuer)
+  AND (subject = sqlc.arg(id) OR human_name = sqlc.arg(id) OR (email = sqlc.arg(id) AND email_verified))
+ORDER BY subject;
+
+-- DeleteCachedIdentity deletes the cached identity of a user of an issuer.
+
+-- name: DeleteCachedIdentity :exec
+DELETE FROM cached_identities WHERE issuer = $1 AND subject = $2;
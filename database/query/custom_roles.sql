@@ -0,0 +1,32 @@
+-- CreateCustomRole defines a new custom role in a project.
+
+-- name: CreateCustomRole :one
+INSERT INTO custom_roles (project_id, name, display_name, description, permissions)
+VALUES ($1, $2, $3, $4, sqlc.arg(permissions)::TEXT[])
+RETURNING *;
+
+-- UpdateCustomRole replaces the description and permissions of a custom role.
+
+-- name: UpdateCustomRole :one
+UPDATE custom_roles
+SET display_name = $3, description = $4, permissions = sqlc.arg(permissions)::TEXT[], updated_at = NOW()
+WHERE project_id = $1 AND name = $2
+RETURNING *;
+
+-- GetCustomRoleByName returns the custom role with the given name, as defined
+-- in the given project.
+
+-- name: GetCustomRoleByName :one
+SELECT * FROM custom_roles WHERE project_id = $1 AND name = $2;
+
+-- ListCustomRolesByProject returns the custom roles defined in a project.
+
+-- name: ListCustomRolesByProject :many
+SELECT * FROM custom_roles WHERE project_id = $1
+ORDER BY name;
+
+-- DeleteCustomRole deletes a custom role from a project, returning it.
+
+-- name: DeleteCustomRole :one
+DELETE FROM custom_roles WHERE project_id = $1 AND name = $2
+RETURNING *;
@@ -120,4 +120,27 @@ WHERE ei.entity_type = sqlc.arg(entity_type)
   AND (sqlc.arg(project_id)::uuid = '00000000-0000-0000-0000-000000000000'::uuid OR ei.project_id = sqlc.arg(project_id))
   AND (sqlc.arg(provider_id)::uuid = '00000000-0000-0000-0000-000000000000'::uuid OR ei.provider_id = sqlc.arg(provider_id))
   AND p.key = sqlc.arg(key)
-  AND p.value @> sqlc.arg(value)::jsonb;
\ No newline at end of file
+  AND p.value @> sqlc.arg(value)::jsonb;
+
+-- ListEntitiesAfterID lists the entities of a given type with an ID greater
+-- than the cursor. It is used by the reminder to page through all entities.
+
+-- name: ListEntitiesAfterID :many
+SELECT * FROM entity_instances
+WHERE entity_instances.entity_type = $1
+    AND entity_instances.id > sqlc.arg(id)
+ORDER BY entity_instances.id
+LIMIT sqlc.arg('limit')::bigint;
+
+-- name: EntityExistsAfterID :one
+SELECT EXISTS (
+  SELECT 1
+  FROM entity_instances
+  WHERE entity_instances.entity_type = $1
+    AND entity_instances.id > sqlc.arg(id))
+AS exists;
+
+-- name: UpdateReminderLastSentForEntities :exec
+UPDATE entity_instances
+SET reminder_last_sent = NOW()
+WHERE id = ANY (sqlc.arg('entity_ids')::uuid[]);
@@ -73,6 +73,18 @@ INSERT INTO alert_events(
     $4
 );
 
+-- ListAlertEventsByDelivery lists the alert events whose metadata records the
+-- delivery of the given webhook event.
+
+-- name: ListAlertEventsByDelivery :many
+SELECT * FROM alert_events
+WHERE metadata->'deliveries' @> jsonb_build_array(jsonb_build_object('event_id', sqlc.arg(event_id)::text));
+
+-- UpdateAlertEventMetadata replaces the metadata of an alert event.
+
+-- name: UpdateAlertEventMetadata :exec
+UPDATE alert_events SET metadata = $2 WHERE id = $1;
+
 -- name: GetEvaluationHistory :one
 SELECT s.id::uuid AS evaluation_id,
     s.evaluation_time as evaluated_at,
@@ -171,6 +183,98 @@ SELECT s.id::uuid AS evaluation_id,
  CASE WHEN sqlc.narg(prev)::timestamp without time zone IS NULL THEN s.evaluation_time END DESC
  LIMIT sqlc.arg(size)::bigint;
 
+-- name: ListEvaluationTrends :many
+-- ListEvaluationTrends counts the last evaluation status of each
+-- rule/entity pair within each time bucket, grouped by profile, rule
+-- type or entity.
+WITH bucketed AS (
+    SELECT DISTINCT ON (s.rule_entity_id, date_trunc(sqlc.arg(bucket)::text, s.evaluation_time))
+           date_trunc(sqlc.arg(bucket)::text, s.evaluation_time) AS bucket_start,
+           CASE sqlc.arg(group_by)::text
+               WHEN 'rule_type' THEN rt.id
+               WHEN 'entity' THEN ei.id
+               ELSE p.id
+           END AS group_id,
+           CASE sqlc.arg(group_by)::text
+               WHEN 'rule_type' THEN rt.name
+               WHEN 'entity' THEN ei.name
+               ELSE p.name
+           END AS group_name,
+           s.status
+      FROM evaluation_statuses s
+      JOIN evaluation_rule_entities ere ON ere.id = s.rule_entity_id
+      JOIN rule_instances ri ON ere.rule_id = ri.id
+      JOIN rule_type rt ON ri.rule_type_id = rt.id
+      JOIN profiles p ON ri.profile_id = p.id
+      JOIN entity_instances ei ON ere.entity_instance_id = ei.id
+     WHERE ei.project_id = sqlc.arg(project_id)
+       AND s.evaluation_time >= sqlc.arg(fromts)::timestamp without time zone
+       AND s.evaluation_time < sqlc.arg(tots)::timestamp without time zone
+     ORDER BY s.rule_entity_id, date_trunc(sqlc.arg(bucket)::text, s.evaluation_time), s.evaluation_time DESC
+)
+SELECT b.bucket_start::timestamp without time zone AS bucket_start,
+       b.group_id::uuid AS group_id,
+       b.group_name::text AS group_name,
+       COUNT(*) FILTER (WHERE b.status = 'success') AS success_count,
+       COUNT(*) FILTER (WHERE b.status = 'failure') AS failure_count,
+       COUNT(*) FILTER (WHERE b.status = 'error') AS error_count,
+       COUNT(*) FILTER (WHERE b.status = 'skipped') AS skipped_count,
+       COUNT(*) FILTER (WHERE b.status = 'exempt') AS exempt_count
+  FROM bucketed b
+ GROUP BY b.group_id, b.group_name, b.bucket_start
+ ORDER BY b.group_name, b.group_id, b.bucket_start;
+
+-- name: ListEvaluationRemediationTimes :many
+-- ListEvaluationRemediationTimes computes, per profile, rule type or
+-- entity, the number of failures remediated within the time range and
+-- the mean time between the first failure of a rule/entity pair and the
+-- next successful evaluation. Each successful evaluation closes a
+-- "streak" made of the evaluations following the previous success.
+WITH streaks AS (
+    SELECT s.rule_entity_id,
+           s.evaluation_time,
+           s.status,
+           CASE sqlc.arg(group_by)::text
+               WHEN 'rule_type' THEN rt.id
+               WHEN 'entity' THEN ei.id
+               ELSE p.id
+           END AS group_id,
+           CASE sqlc.arg(group_by)::text
+               WHEN 'rule_type' THEN rt.name
+               WHEN 'entity' THEN ei.name
+               ELSE p.name
+           END AS group_name,
+           COUNT(*) FILTER (WHERE s.status = 'success') OVER (
+               PARTITION BY s.rule_entity_id ORDER BY s.evaluation_time
+               ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING
+           ) AS streak
+      FROM evaluation_statuses s
+      JOIN evaluation_rule_entities ere ON ere.id = s.rule_entity_id
+      JOIN rule_instances ri ON ere.rule_id = ri.id
+      JOIN rule_type rt ON ri.rule_type_id = rt.id
+      JOIN profiles p ON ri.profile_id = p.id
+      JOIN entity_instances ei ON ere.entity_instance_id = ei.id
+     WHERE ei.project_id = sqlc.arg(project_id)
+       -- failures preceding the time range are needed to compute
+       -- the time to remediate
+       AND s.evaluation_time < sqlc.arg(tots)::timestamp without time zone
+), remediations AS (
+    SELECT st.group_id,
+           st.group_name,
+           MIN(st.evaluation_time) FILTER (WHERE st.status = 'failure') AS failed_at,
+           MAX(st.evaluation_time) FILTER (WHERE st.status = 'success') AS remediated_at
+      FROM streaks st
+     GROUP BY st.rule_entity_id, st.streak, st.group_id, st.group_name
+)
+SELECT r.group_id::uuid AS group_id,
+       r.group_name::text AS group_name,
+       COUNT(*) AS remediated_count,
+       AVG(EXTRACT(EPOCH FROM r.remediated_at - r.failed_at))::float8 AS mean_seconds
+  FROM remediations r
+ WHERE r.failed_at IS NOT NULL
+   AND r.remediated_at >= sqlc.arg(fromts)::timestamp without time zone
+ GROUP BY r.group_id, r.group_name;
+
 -- name: ListEvaluationHistoryStaleRecords :many
 SELECT s.evaluation_time,
        s.id,
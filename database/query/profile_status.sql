@@ -1,3 +1,22 @@
+-- name: CountEntitiesByEvaluationStatus :many
+-- CountEntitiesByEvaluationStatus counts the entities per latest
+-- evaluation status, project, profile and rule type. The profile and
+-- rule type names are left empty unless requested, which reduces the
+-- number of groups.
+SELECT ei.project_id,
+       (CASE WHEN sqlc.arg(by_profile)::boolean THEN p.name ELSE '' END)::text AS profile_name,
+       (CASE WHEN sqlc.arg(by_rule_type)::boolean THEN rt.name ELSE '' END)::text AS rule_type_name,
+       es.status,
+       COUNT(DISTINCT ere.entity_instance_id) AS num_entities
+FROM latest_evaluation_statuses les
+    INNER JOIN evaluation_rule_entities ere ON ere.id = les.rule_entity_id
+    INNER JOIN evaluation_statuses es ON es.id = les.evaluation_history_id
+    INNER JOIN rule_instances ri ON ri.id = ere.rule_id
+    INNER JOIN rule_type rt ON rt.id = ri.rule_type_id
+    INNER JOIN profiles p ON p.id = ri.profile_id
+    INNER JOIN entity_instances ei ON ei.id = ere.entity_instance_id
+GROUP BY ei.project_id, profile_name, rule_type_name, es.status;
+
 -- name: GetProfileStatusByIdAndProject :one
 SELECT p.id, p.name, ps.profile_status, ps.last_updated FROM profile_status ps
 INNER JOIN profiles p ON p.id = ps.profile_id
@@ -13,18 +32,74 @@ SELECT p.id, p.name, ps.profile_status, ps.last_updated FROM profile_status ps
 INNER JOIN profiles p ON p.id = ps.profile_id
 WHERE p.project_id = $1;
 
--- ListOldestRuleEvaluationsByRepositoryId has casts in select statement as sqlc generates incorrect types.
+-- ListInheritedProfileStatusByProject lists the profiles a project inherits
+-- from its parent projects. Their status is computed from the latest
+-- evaluations of the project's own entities, with the same precedence as the
+-- profile_status table: error, failure, success and then skipped, or pending
+-- if no rule was evaluated yet.
+
+-- name: ListInheritedProfileStatusByProject :many
+SELECT p.id, p.name,
+    (CASE
+        WHEN bool_or(es.status = 'error') THEN 'error'
+        WHEN bool_or(es.status = 'failure') THEN 'failure'
+        WHEN bool_or(es.status = 'success') THEN 'success'
+        WHEN bool_or(es.status IN ('skipped', 'exempt')) THEN 'skipped'
+        ELSE 'pending'
+    END)::eval_status_types AS profile_status,
+    COALESCE(MAX(es.evaluation_time), p.updated_at)::timestamp AS last_updated
+FROM profiles AS p
+    LEFT JOIN rule_instances AS ri ON ri.profile_id = p.id
+    LEFT JOIN evaluation_rule_entities AS ere ON ere.rule_id = ri.id
+        AND ere.entity_instance_id IN (SELECT id FROM entity_instances WHERE project_id = sqlc.arg(project_id))
+    LEFT JOIN latest_evaluation_statuses AS les ON les.rule_entity_id = ere.id
+    LEFT JOIN evaluation_statuses AS es ON es.id = les.evaluation_history_id
+WHERE p.inherited AND p.project_id = ANY(sqlc.arg(parent_ids)::uuid[])
+GROUP BY p.id
+ORDER BY p.name;
+
+-- ListOldestRuleEvaluationsByEntityID has casts in select statement as sqlc generates incorrect types.
 -- cast after MIN is required due to a known bug in sqlc: https://github.com/sqlc-dev/sqlc/issues/1965
 
--- name: ListOldestRuleEvaluationsByRepositoryId :many
-SELECT ere.entity_instance_id::uuid AS repository_id, MIN(es.evaluation_time)::timestamp AS oldest_last_updated
+-- name: ListOldestRuleEvaluationsByEntityID :many
+SELECT ere.entity_instance_id::uuid AS entity_id, MIN(es.evaluation_time)::timestamp AS oldest_last_updated
 FROM evaluation_rule_entities AS ere
     INNER JOIN latest_evaluation_statuses AS les ON ere.id = les.rule_entity_id
     INNER JOIN evaluation_statuses AS es ON les.evaluation_history_id = es.id
-WHERE ere.entity_instance_id = ANY (sqlc.arg('repository_ids')::uuid[])
-AND ere.entity_type = 'repository'
+    INNER JOIN profiles AS p ON les.profile_id = p.id
+WHERE ere.entity_instance_id = ANY (sqlc.arg('entity_ids')::uuid[])
+    -- profiles with a schedule are evaluated according to it instead
+    AND p.schedule_cron = '' AND p.schedule_min_freshness = ''
 GROUP BY ere.entity_instance_id;
 
+-- ListProfileEvaluationTimesByEntity lists the entities which a profile has
+-- rules for, along with the oldest time the rules were last evaluated for each
+-- entity. The time is NULL if any of the rules was never evaluated for the
+-- entity. The entities of the descendant projects of the profile's project are
+-- included if the profile is inherited.
+
+-- name: ListProfileEvaluationTimesByEntity :many
+WITH RECURSIVE profile_projects AS (
+    SELECT projects.id FROM projects
+    WHERE projects.id = sqlc.arg(project_id)
+
+    UNION
+
+    SELECT p.id FROM projects p
+    INNER JOIN profile_projects pp ON p.parent_id = pp.id
+    INNER JOIN profiles pr ON pr.id = sqlc.arg(profile_id) AND pr.inherited
+)
+SELECT ei.id AS entity_id, ei.entity_type, ei.project_id, ei.provider_id,
+    (CASE WHEN bool_and(es.id IS NOT NULL) THEN MIN(es.evaluation_time) END)::timestamp AS oldest_last_updated
+FROM entity_instances AS ei
+    INNER JOIN rule_instances AS ri ON ri.entity_type = ei.entity_type AND ri.profile_id = sqlc.arg(profile_id)
+    LEFT JOIN evaluation_rule_entities AS ere ON ere.rule_id = ri.id AND ere.entity_instance_id = ei.id
+    LEFT JOIN latest_evaluation_statuses AS les ON les.rule_entity_id = ere.id
+    LEFT JOIN evaluation_statuses AS es ON les.evaluation_history_id = es.id
+WHERE ei.project_id IN (SELECT id FROM profile_projects)
+GROUP BY ei.id
+ORDER BY ei.id;
+
 -- name: ListRuleEvaluationsByProfileId :many
 WITH
    eval_details AS (
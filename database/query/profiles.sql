@@ -6,8 +6,12 @@ INSERT INTO profiles (
     name,
     subscription_id,
     display_name,
-    labels
-) VALUES ($1, $2, $3, $4, sqlc.narg(subscription_id), sqlc.arg(display_name), COALESCE(sqlc.arg(labels)::text[], '{}'::text[])) RETURNING *;
+    labels,
+    schedule_cron,
+    schedule_min_freshness,
+    inherited
+) VALUES ($1, $2, $3, $4, sqlc.narg(subscription_id), sqlc.arg(display_name), COALESCE(sqlc.arg(labels)::text[], '{}'::text[]),
+    sqlc.arg(schedule_cron), sqlc.arg(schedule_min_freshness), sqlc.arg(inherited)) RETURNING *;
 
 -- name: UpdateProfile :one
 UPDATE profiles SET
@@ -15,7 +19,10 @@ UPDATE profiles SET
     alert = $4,
     updated_at = NOW(),
     display_name = sqlc.arg(display_name),
-    labels = COALESCE(sqlc.arg(labels)::TEXT[], '{}'::TEXT[])
+    labels = COALESCE(sqlc.arg(labels)::TEXT[], '{}'::TEXT[]),
+    schedule_cron = sqlc.arg(schedule_cron),
+    schedule_min_freshness = sqlc.arg(schedule_min_freshness),
+    inherited = sqlc.arg(inherited)
 WHERE id = $1 AND project_id = $2 RETURNING *;
 
 -- name: CreateProfileForEntity :one
@@ -135,6 +142,11 @@ FROM profiles AS p
 JOIN rule_instances AS r ON p.id = r.profile_id
 WHERE r.rule_type_id = $1;
 
+-- name: ListScheduledProfiles :many
+SELECT * FROM profiles
+WHERE schedule_cron != '' OR schedule_min_freshness != ''
+ORDER BY id;
+
 -- name: CountProfilesByEntityType :many
 SELECT COUNT(DISTINCT(p.id)) AS num_profiles, r.entity_type AS profile_entity
 FROM profiles AS p
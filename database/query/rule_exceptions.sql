@@ -0,0 +1,56 @@
+-- SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+-- SPDX-License-Identifier: Apache-2.0
+
+-- CreateRuleException exempts an entity from a rule instance until
+-- the given expiry.
+
+-- name: CreateRuleException :one
+INSERT INTO rule_exceptions (
+    project_id,
+    rule_instance_id,
+    entity_instance_id,
+    reason,
+    approver,
+    created_by,
+    expires_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- ListRuleExceptionsByProject lists all the rule exceptions of a
+-- project, including the expired and revoked ones, together with the profile and
+-- rule they refer to.
+
+-- name: ListRuleExceptionsByProject :many
+SELECT sqlc.embed(rule_exceptions),
+       ri.name AS rule_name,
+       ri.profile_id,
+       p.name AS profile_name,
+       ei.entity_type
+FROM rule_exceptions
+JOIN rule_instances ri ON ri.id = rule_exceptions.rule_instance_id
+JOIN profiles p ON p.id = ri.profile_id
+JOIN entity_instances ei ON ei.id = rule_exceptions.entity_instance_id
+WHERE rule_exceptions.project_id = $1
+ORDER BY rule_exceptions.created_at, rule_exceptions.id;
+
+-- GetActiveRuleException returns the exception which is furthest from
+-- expiring for the given rule instance and entity, if any.
+
+-- name: GetActiveRuleException :one
+SELECT * FROM rule_exceptions
+WHERE rule_instance_id = $1
+AND entity_instance_id = $2
+AND expires_at > NOW()
+AND revoked_at IS NULL
+ORDER BY expires_at DESC
+LIMIT 1;
+
+-- RevokeRuleException ends a rule exception before it expires, recording
+-- who revoked it. Revoking an exception again keeps the first revocation.
+
+-- name: RevokeRuleException :one
+UPDATE rule_exceptions
+SET revoked_by = COALESCE(revoked_by, sqlc.arg(revoked_by)::TEXT),
+    revoked_at = COALESCE(revoked_at, NOW())
+WHERE id = $1 AND project_id = $2
+RETURNING *;
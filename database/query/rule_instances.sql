@@ -54,3 +54,9 @@ AND profile_id = $3;
 
 -- name: DeleteRuleInstanceOfProfileInProject :exec
 DELETE FROM rule_instances WHERE project_id = $1 AND profile_id = $2 AND rule_type_id = $3;
+
+-- name: GetRuleInstanceByProfileAndName :one
+SELECT * FROM rule_instances
+WHERE profile_id = $1
+AND entity_type = $2
+AND lower(name) = lower(sqlc.arg(name));
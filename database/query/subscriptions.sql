@@ -24,3 +24,25 @@ WHERE bu.namespace = $1 AND bu.name = $2 AND su.project_id = $3;
 
 -- name: SetSubscriptionBundleVersion :exec
 UPDATE subscriptions SET current_version = $2 WHERE project_id = $1;
+
+-- name: UpdateSubscriptionVersion :exec
+UPDATE subscriptions SET current_version = $2 WHERE id = $1;
+
+-- name: ListSubscriptionsByProjects :many
+SELECT su.id, su.project_id, su.current_version, bu.namespace, bu.name
+FROM subscriptions AS su
+JOIN bundles AS bu ON bu.id = su.bundle_id
+WHERE su.project_id = ANY(sqlc.arg(projects)::UUID[])
+ORDER BY su.project_id, bu.namespace, bu.name;
+
+-- Subscription contents --
+
+-- name: UpsertSubscriptionContent :exec
+INSERT INTO subscription_contents (subscription_id, content_type, name, bundle_digest, installed_digest)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (subscription_id, content_type, name) DO UPDATE
+SET bundle_digest = $4, installed_digest = $5, updated_at = NOW();
+
+-- name: ListSubscriptionContents :many
+SELECT * FROM subscription_contents WHERE subscription_id = $1
+ORDER BY content_type, name;
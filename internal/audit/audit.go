@@ -0,0 +1,309 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package audit contains the helpers to record the mutating calls made to
+// the Minder API in the audit log.
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// RedactedValue replaces the value of sensitive fields in recorded requests
+const RedactedValue = "REDACTED"
+
+// sensitiveNames are the fragments of field and key names which hold
+// sensitive values, for the fields which aren't annotated with debug_redact
+var sensitiveNames = []string{
+	"token", "secret", "password", "private_key",
+	"authorization", "api_key", "apikey", "credential",
+}
+
+// headersName is the name of the fields and keys which hold HTTP headers.
+// Any header may carry a credential, so all of their values are redacted.
+const headersName = "headers"
+
+// Redact returns the JSON representation of a request, with the values of
+// sensitive fields replaced.  A field is sensitive if it is annotated with
+// the debug_redact option, or if its name hints at a secret.  The keys of
+// free-form maps and structs are checked as well, and the values of headers
+// are always redacted, keeping the header names.
+func Redact(msg proto.Message) (json.RawMessage, error) {
+	clone := proto.Clone(msg)
+	RedactMessage(clone)
+	return protojson.Marshal(clone)
+}
+
+// Diff returns the JSON representation of the fields which differ between
+// the states of a resource before and after a call, as an object holding the
+// "before" and "after" values of those fields.  Nested objects are compared
+// field by field, lists as a whole.  Both states are redacted like requests,
+// so changes to sensitive values don't show.  A nil state stands for a
+// resource which doesn't exist, and nil is returned if nothing changed.
+func Diff(before, after proto.Message) (json.RawMessage, error) {
+	beforeFields, err := redactedFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := redactedFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changedBefore, changedAfter := diffFields(beforeFields, afterFields)
+	if len(changedBefore) == 0 && len(changedAfter) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(map[string]any{
+		"before": changedBefore,
+		"after":  changedAfter,
+	})
+}
+
+func redactedFields(msg proto.Message) (map[string]any, error) {
+	fields := map[string]any{}
+	if msg == nil || !msg.ProtoReflect().IsValid() {
+		return fields, nil
+	}
+	redacted, err := Redact(msg)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(redacted, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// diffFields returns the values of the fields which differ between two
+// objects, on each side.  A field missing on one side only shows on the other.
+func diffFields(before, after map[string]any) (map[string]any, map[string]any) {
+	changedBefore := map[string]any{}
+	changedAfter := map[string]any{}
+	for key, beforeValue := range before {
+		afterValue, ok := after[key]
+		if !ok {
+			changedBefore[key] = beforeValue
+			continue
+		}
+		beforeObj, beforeIsObj := beforeValue.(map[string]any)
+		afterObj, afterIsObj := afterValue.(map[string]any)
+		if beforeIsObj && afterIsObj {
+			nestedBefore, nestedAfter := diffFields(beforeObj, afterObj)
+			if len(nestedBefore) > 0 {
+				changedBefore[key] = nestedBefore
+			}
+			if len(nestedAfter) > 0 {
+				changedAfter[key] = nestedAfter
+			}
+			continue
+		}
+		if !reflect.DeepEqual(beforeValue, afterValue) {
+			changedBefore[key] = beforeValue
+			changedAfter[key] = afterValue
+		}
+	}
+	for key, afterValue := range after {
+		if _, ok := before[key]; !ok {
+			changedAfter[key] = afterValue
+		}
+	}
+	return changedBefore, changedAfter
+}
+
+// RedactMessage replaces the values of the sensitive fields of a message in
+// place, following the same rules as Redact.
+func RedactMessage(msg proto.Message) {
+	redactMessage(msg.ProtoReflect())
+}
+
+func redactMessage(m protoreflect.Message) {
+	// Fields can't be changed while ranging over them
+	var fields []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields = append(fields, fd)
+		return true
+	})
+
+	for _, fd := range fields {
+		if isSensitiveField(fd) {
+			if fd.Kind() == protoreflect.StringKind && fd.Cardinality() != protoreflect.Repeated {
+				m.Set(fd, protoreflect.ValueOfString(RedactedValue))
+			} else {
+				m.Clear(fd)
+			}
+			continue
+		}
+
+		if isHeadersName(string(fd.Name())) {
+			redactHeadersField(m, fd)
+			continue
+		}
+
+		switch {
+		case fd.IsMap():
+			redactMap(m.Mutable(fd).Map(), fd, false)
+		case fd.IsList():
+			if fd.Message() == nil {
+				continue
+			}
+			list := m.Mutable(fd).List()
+			for i := 0; i < list.Len(); i++ {
+				redactMessage(list.Get(i).Message())
+			}
+		case fd.Message() != nil:
+			redactMessage(m.Mutable(fd).Message())
+		}
+	}
+}
+
+// redactMap redacts the values of the sensitive keys of a map, or the values
+// of all of its keys if all is set
+func redactMap(mv protoreflect.Map, fd protoreflect.FieldDescriptor, all bool) {
+	valueFd := fd.MapValue()
+	stringKeys := fd.MapKey().Kind() == protoreflect.StringKind
+
+	var sensitive []protoreflect.MapKey
+	mv.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+		switch {
+		case all || stringKeys && isSensitiveName(k.String()):
+			sensitive = append(sensitive, k)
+		case valueFd.Message() != nil && stringKeys && isHeadersName(k.String()):
+			redactAllValues(v.Message())
+		case valueFd.Message() != nil:
+			redactMessage(v.Message())
+		}
+		return true
+	})
+
+	for _, k := range sensitive {
+		switch {
+		case valueFd.Kind() == protoreflect.StringKind:
+			mv.Set(k, protoreflect.ValueOfString(RedactedValue))
+		case valueFd.Message() != nil && valueFd.Message().FullName() == "google.protobuf.Value":
+			mv.Set(k, protoreflect.ValueOfMessage(structpb.NewStringValue(RedactedValue).ProtoReflect()))
+		default:
+			mv.Clear(k)
+		}
+	}
+}
+
+// redactHeadersField redacts the values of a field which holds HTTP headers,
+// either as a map or as a list of "Name: value" strings
+func redactHeadersField(m protoreflect.Message, fd protoreflect.FieldDescriptor) {
+	switch {
+	case fd.IsMap():
+		redactMap(m.Mutable(fd).Map(), fd, true)
+	case fd.IsList() && fd.Kind() == protoreflect.StringKind:
+		list := m.Mutable(fd).List()
+		for i := 0; i < list.Len(); i++ {
+			name, _, _ := strings.Cut(list.Get(i).String(), ":")
+			list.Set(i, protoreflect.ValueOfString(name+": "+RedactedValue))
+		}
+	case fd.Message() != nil && !fd.IsList():
+		redactAllValues(m.Mutable(fd).Message())
+	case fd.Kind() == protoreflect.StringKind && !fd.IsList():
+		m.Set(fd, protoreflect.ValueOfString(RedactedValue))
+	default:
+		m.Clear(fd)
+	}
+}
+
+// redactAllValues redacts every value held by a free-form struct, keeping
+// its keys.  Other messages are redacted as usual.
+func redactAllValues(m protoreflect.Message) {
+	switch m.Descriptor().FullName() {
+	case "google.protobuf.Struct":
+		fd := m.Descriptor().Fields().ByName("fields")
+		redactMap(m.Mutable(fd).Map(), fd, true)
+	case "google.protobuf.Value":
+		structFd := m.Descriptor().Fields().ByName("struct_value")
+		if m.Has(structFd) {
+			redactAllValues(m.Mutable(structFd).Message())
+			return
+		}
+		m.Set(m.Descriptor().Fields().ByName("string_value"), protoreflect.ValueOfString(RedactedValue))
+	default:
+		redactMessage(m)
+	}
+}
+
+func isHeadersName(name string) bool {
+	return strings.ToLower(name) == headersName
+}
+
+func isSensitiveField(fd protoreflect.FieldDescriptor) bool {
+	if opts, ok := fd.Options().(*descriptorpb.FieldOptions); ok && opts.GetDebugRedact() {
+		return true
+	}
+	return isSensitiveName(string(fd.Name()))
+}
+
+func isSensitiveName(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range sensitiveNames {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Target returns a best-effort identifier of the resource a request acts
+// on, such as the name of a profile or the subject of a role assignment.
+// The fields of the request are searched first, then the fields of the
+// messages it contains.  An empty string is returned if nothing is found.
+func Target(msg proto.Message) string {
+	m := msg.ProtoReflect()
+	if target := targetField(m); target != "" {
+		return target
+	}
+
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		// the project is recorded separately
+		if fd.Name() == "context" || fd.Message() == nil || fd.IsList() || fd.IsMap() || !m.Has(fd) {
+			continue
+		}
+		if target := targetField(m.Get(fd).Message()); target != "" {
+			return target
+		}
+	}
+	return ""
+}
+
+func targetField(m protoreflect.Message) string {
+	fields := m.Descriptor().Fields()
+	stringValue := func(fd protoreflect.FieldDescriptor) string {
+		if fd == nil || fd.Kind() != protoreflect.StringKind || fd.Cardinality() == protoreflect.Repeated {
+			return ""
+		}
+		return m.Get(fd).String()
+	}
+
+	for _, name := range []protoreflect.Name{"name", "id", "subject", "email"} {
+		if value := stringValue(fields.ByName(name)); value != "" {
+			return value
+		}
+	}
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		name := string(fd.Name())
+		if !strings.HasSuffix(name, "_id") && !strings.HasSuffix(name, "_name") {
+			continue
+		}
+		if value := stringValue(fd); value != "" {
+			return value
+		}
+	}
+	return ""
+}
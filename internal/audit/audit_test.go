@@ -0,0 +1,251 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+func TestRedact(t *testing.T) {
+	t.Parallel()
+
+	config, err := structpb.NewStruct(map[string]any{
+		"github": map[string]any{
+			"endpoint":       "https://github.example.com",
+			"webhook_secret": "s3cr3t",
+		},
+		"password": "hunter2",
+	})
+	require.NoError(t, err)
+
+	ruleDef, err := structpb.NewStruct(map[string]any{
+		"endpoint": "https://api.example.com",
+		"headers": map[string]any{
+			"X-Custom-Auth": "abc123",
+		},
+		"api_key":     "k3y",
+		"credentials": "c0nf1dential",
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		msg      proto.Message
+		expected string
+	}{
+		{
+			name: "debug_redact field",
+			msg: &minderv1.StoreProviderTokenRequest{
+				AccessToken: "gho_abcdef",
+				Owner:       proto.String("stacklok"),
+			},
+			expected: `{"accessToken":"REDACTED","owner":"stacklok"}`,
+		},
+		{
+			name: "sensitive keys of a nested struct",
+			msg: &minderv1.CreateProviderRequest{
+				Provider: &minderv1.Provider{
+					Name:   "github-app",
+					Config: config,
+				},
+			},
+			expected: `{"provider":{"name":"github-app","config":{` +
+				`"github":{"endpoint":"https://github.example.com","webhook_secret":"REDACTED"},` +
+				`"password":"REDACTED"}}}`,
+		},
+		{
+			name: "values of header maps",
+			msg: &minderv1.CreateDataSourceRequest{
+				DataSource: &minderv1.DataSource{
+					Name: "osv",
+					Driver: &minderv1.DataSource_Rest{
+						Rest: &minderv1.RestDataSource{
+							Def: map[string]*minderv1.RestDataSource_Def{
+								"query": {
+									Endpoint: "https://api.example.com",
+									Headers: map[string]string{
+										"Authorization": "Bearer abc123",
+										"Accept":        "application/json",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: `{"dataSource":{"name":"osv","rest":{"def":{"query":{` +
+				`"endpoint":"https://api.example.com",` +
+				`"headers":{"Authorization":"REDACTED","Accept":"REDACTED"}}}}}}`,
+		},
+		{
+			name: "values of header lists",
+			msg: &minderv1.RestType{
+				Endpoint: "https://api.example.com",
+				Headers:  []string{"X-Api-Version: 2", "X-Custom-Auth: abc123"},
+			},
+			expected: `{"endpoint":"https://api.example.com",` +
+				`"headers":["X-Api-Version: REDACTED","X-Custom-Auth: REDACTED"]}`,
+		},
+		{
+			name: "headers and credentials of a free-form struct",
+			msg: &minderv1.Profile_Rule{
+				Type: "rest_check",
+				Def:  ruleDef,
+			},
+			expected: `{"type":"rest_check","def":{"endpoint":"https://api.example.com",` +
+				`"headers":{"X-Custom-Auth":"REDACTED"},"api_key":"REDACTED","credentials":"REDACTED"}}`,
+		},
+		{
+			name: "nothing to redact",
+			msg: &minderv1.AssignRoleRequest{
+				RoleAssignment: &minderv1.RoleAssignment{
+					Role:    "admin",
+					Subject: "alexsmith",
+				},
+			},
+			expected: `{"roleAssignment":{"role":"admin","subject":"alexsmith"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			before := proto.Clone(tt.msg)
+			redacted, err := Redact(tt.msg)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.expected, string(redacted))
+			// the request itself is left untouched
+			require.True(t, proto.Equal(before, tt.msg))
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	providerConfig := func(endpoint, secret string) *structpb.Struct {
+		config, err := structpb.NewStruct(map[string]any{
+			"github": map[string]any{
+				"endpoint":       endpoint,
+				"webhook_secret": secret,
+			},
+		})
+		require.NoError(t, err)
+		return config
+	}
+
+	tests := []struct {
+		name     string
+		before   proto.Message
+		after    proto.Message
+		expected string
+	}{
+		{
+			name:     "changed field",
+			before:   &minderv1.Profile{Name: "profile", DisplayName: "Old name", Labels: []string{"a"}},
+			after:    &minderv1.Profile{Name: "profile", DisplayName: "New name", Labels: []string{"a"}},
+			expected: `{"before":{"displayName":"Old name"},"after":{"displayName":"New name"}}`,
+		},
+		{
+			name:     "changed list",
+			before:   &minderv1.Profile{Name: "profile", Labels: []string{"a"}},
+			after:    &minderv1.Profile{Name: "profile", Labels: []string{"a", "b"}},
+			expected: `{"before":{"labels":["a"]},"after":{"labels":["a","b"]}}`,
+		},
+		{
+			name: "changed nested field and hidden secret",
+			before: &minderv1.Provider{
+				Name:   "github",
+				Config: providerConfig("https://github.example.com", "old"),
+			},
+			after: &minderv1.Provider{
+				Name:   "github",
+				Config: providerConfig("https://ghe.example.com", "new"),
+			},
+			expected: `{"before":{"config":{"github":{"endpoint":"https://github.example.com"}}},` +
+				`"after":{"config":{"github":{"endpoint":"https://ghe.example.com"}}}}`,
+		},
+		{
+			name:     "created",
+			after:    &minderv1.Profile{Name: "profile"},
+			expected: `{"before":{},"after":{"name":"profile"}}`,
+		},
+		{
+			name:     "deleted",
+			before:   &minderv1.Profile{Name: "profile"},
+			after:    (*minderv1.Profile)(nil),
+			expected: `{"before":{"name":"profile"},"after":{}}`,
+		},
+		{
+			name:   "unchanged",
+			before: &minderv1.Profile{Name: "profile"},
+			after:  &minderv1.Profile{Name: "profile"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Diff(tt.before, tt.after)
+			require.NoError(t, err)
+			if tt.expected == "" {
+				require.Nil(t, got)
+				return
+			}
+			require.JSONEq(t, tt.expected, string(got))
+		})
+	}
+}
+
+func TestTarget(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		msg      proto.Message
+		expected string
+	}{
+		{
+			name:     "top-level id",
+			msg:      &minderv1.DeleteProfileRequest{Id: "4b4f5c2e-0ed2-4ed6-a1c4-f1c0c7a0a4f5"},
+			expected: "4b4f5c2e-0ed2-4ed6-a1c4-f1c0c7a0a4f5",
+		},
+		{
+			name: "nested name",
+			msg: &minderv1.CreateProviderRequest{
+				Context:  &minderv1.Context{Provider: proto.String("github")},
+				Provider: &minderv1.Provider{Name: "github-app"},
+			},
+			expected: "github-app",
+		},
+		{
+			name: "nested subject",
+			msg: &minderv1.AssignRoleRequest{
+				RoleAssignment: &minderv1.RoleAssignment{Role: "admin", Subject: "alexsmith"},
+			},
+			expected: "alexsmith",
+		},
+		{
+			name:     "no target",
+			msg:      &minderv1.CreateProviderRequest{Context: &minderv1.Context{Provider: proto.String("github")}},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.expected, Target(tt.msg))
+		})
+	}
+}
@@ -44,6 +44,9 @@ type Identity struct {
 	// empty.
 	FirstName string
 	LastName  string
+	// Email is an optional email address provided by the identity provider.
+	// Like HumanName, it is not guaranteed to be stable over time.
+	Email string
 }
 
 // String implements strings.Stringer, and also provides a stable storage
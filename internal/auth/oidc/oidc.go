@@ -0,0 +1,282 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package oidc provides an implementation of a generic OpenID Connect
+// IdentityProvider, for identity servers such as Dex or Okta.
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/lestrrat-go/jwx/v2/jwt/openid"
+	"github.com/puzpuzpuz/xsync/v3"
+	"github.com/rs/zerolog"
+
+	"github.com/mindersec/minder/internal/auth"
+	stacklok_jwt "github.com/mindersec/minder/internal/auth/jwt"
+	"github.com/mindersec/minder/internal/db"
+	serverconfig "github.com/mindersec/minder/pkg/config/server"
+)
+
+// OIDC is an implementation of the auth.IdentityProvider interface for
+// generic OpenID Connect identity servers.
+//
+// Generic identity servers have no standard API to look up users, so the
+// claims of each user are recorded in a local cache when they present a
+// token, and users are resolved from that cache.
+type OIDC struct {
+	name   string
+	issuer string
+	url    url.URL
+	claims serverconfig.OIDCClaimsConfig
+
+	validator stacklok_jwt.Validator
+	store     db.Store
+
+	// recorded holds the identities last written to the cache, by subject,
+	// so the cache is only written to when the claims of a user change, or
+	// when the entry is old enough that the cache may have been cleared, as
+	// when a user deletes their account
+	recorded *xsync.MapOf[string, recordedIdentity]
+}
+
+// recordRefreshInterval is how often the cached identity of an active user
+// is written again, even if their claims did not change
+const recordRefreshInterval = time.Hour
+
+type recordedIdentity struct {
+	identity db.UpsertCachedIdentityParams
+	at       time.Time
+}
+
+var _ auth.IdentityProvider = (*OIDC)(nil)
+var _ stacklok_jwt.Validator = (*OIDC)(nil)
+
+var errNotFound = errors.New("user not found in identity cache")
+
+// a subset of the OpenID Connect discovery document for JSON parsing
+type discoveryDocument struct {
+	Issuer  string `json:"issuer"`
+	JwksURI string `json:"jwks_uri"`
+}
+
+// NewOIDC creates a new generic OIDC identity provider.  The keys which sign
+// the tokens of the provider are found through OpenID Connect discovery.
+func NewOIDC(ctx context.Context, cfg serverconfig.OIDCConfig, store db.Store) (*OIDC, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	issuerUrl, err := url.Parse(cfg.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid issuer URL: %w", err)
+	}
+
+	jwksUrl, err := discoverJWKSUrl(ctx, cfg.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover JWKS URL for %s: %w", cfg.Issuer, err)
+	}
+	validator, err := stacklok_jwt.NewJwtValidator(ctx, jwksUrl, cfg.Issuer, cfg.Audience)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS for %s: %w", cfg.Issuer, err)
+	}
+
+	return &OIDC{
+		name:      cfg.Name,
+		issuer:    cfg.Issuer,
+		url:       *issuerUrl,
+		claims:    cfg.Claims.WithDefaults(),
+		validator: validator,
+		store:     store,
+		recorded:  xsync.NewMapOf[string, recordedIdentity](),
+	}, nil
+}
+
+// String implements auth.IdentityProvider.
+func (o *OIDC) String() string {
+	return o.name
+}
+
+// URL implements auth.IdentityProvider.
+func (o *OIDC) URL() url.URL {
+	return o.url
+}
+
+// ParseAndValidate implements jwt.Validator.  It validates tokens issued by
+// this provider against the provider's JWKS.
+func (o *OIDC) ParseAndValidate(tokenString string) (openid.Token, error) {
+	return o.validator.ParseAndValidate(tokenString)
+}
+
+// Resolve implements auth.IdentityProvider.  The id may be the subject,
+// human-readable name or email of a user who logged in before.  Users are
+// only resolved by email if the identity server verified it, otherwise
+// anyone could claim the email address of someone else.
+func (o *OIDC) Resolve(ctx context.Context, id string) (*auth.Identity, error) {
+	cached, err := o.store.ListCachedIdentities(ctx, db.ListCachedIdentitiesParams{
+		Issuer: o.issuer,
+		ID:     id,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve user: %w", err)
+	}
+
+	// A subject match wins over names and emails, which are not unique over time
+	for _, c := range cached {
+		if c.Subject == id {
+			return o.cachedToIdentity(c), nil
+		}
+	}
+	cached = slices.DeleteFunc(cached, func(c db.CachedIdentity) bool {
+		return c.HumanName != id && !c.EmailVerified
+	})
+	switch len(cached) {
+	case 0:
+		return nil, errNotFound
+	case 1:
+		return o.cachedToIdentity(cached[0]), nil
+	default:
+		return nil, fmt.Errorf("%d users match %q", len(cached), id)
+	}
+}
+
+// Validate implements auth.IdentityProvider.
+func (o *OIDC) Validate(ctx context.Context, token jwt.Token) (*auth.Identity, error) {
+	if token.Issuer() != o.issuer {
+		return nil, errors.New("token issuer is not the expected issuer")
+	}
+
+	subject := stringClaim(token, o.claims.Subject)
+	if subject == "" {
+		return nil, fmt.Errorf("%s not found in token", o.claims.Subject)
+	}
+	email := stringClaim(token, o.claims.Email)
+	emailVerified := email != "" && boolClaim(token, o.claims.EmailVerified)
+	// Not every identity server vends a username, fall back to something
+	// which is more readable than the subject if possible.  Users can be
+	// resolved by name, so an unverified email can't stand in for it.
+	humanName := stringClaim(token, o.claims.HumanName)
+	if humanName == "" && emailVerified {
+		humanName = email
+	}
+	if humanName == "" {
+		humanName = subject
+	}
+
+	identity := db.UpsertCachedIdentityParams{
+		Issuer:        o.issuer,
+		Subject:       subject,
+		HumanName:     humanName,
+		Email:         email,
+		EmailVerified: emailVerified,
+		FirstName:     stringClaim(token, o.claims.FirstName),
+		LastName:      stringClaim(token, o.claims.LastName),
+	}
+	if err := o.record(ctx, identity); err != nil {
+		// The caller is authenticated regardless, they just can't be
+		// resolved by others until the cache is updated.
+		zerolog.Ctx(ctx).Error().Err(err).Str("issuer", o.issuer).Msg("unable to cache identity")
+	}
+
+	return &auth.Identity{
+		UserID:    subject,
+		HumanName: humanName,
+		Provider:  o,
+		FirstName: identity.FirstName,
+		LastName:  identity.LastName,
+		Email:     email,
+	}, nil
+}
+
+func (o *OIDC) record(ctx context.Context, identity db.UpsertCachedIdentityParams) error {
+	if prev, ok := o.recorded.Load(identity.Subject); ok && prev.identity == identity &&
+		time.Since(prev.at) < recordRefreshInterval {
+		return nil
+	}
+	if err := o.store.UpsertCachedIdentity(ctx, identity); err != nil {
+		return err
+	}
+	o.recorded.Store(identity.Subject, recordedIdentity{identity: identity, at: time.Now()})
+	return nil
+}
+
+func (o *OIDC) cachedToIdentity(c db.CachedIdentity) *auth.Identity {
+	return &auth.Identity{
+		UserID:    c.Subject,
+		HumanName: c.HumanName,
+		Provider:  o,
+		FirstName: c.FirstName,
+		LastName:  c.LastName,
+		Email:     c.Email,
+	}
+}
+
+func stringClaim(token jwt.Token, claim string) string {
+	value, ok := token.Get(claim)
+	if !ok {
+		return ""
+	}
+	str, ok := value.(string)
+	if !ok {
+		return ""
+	}
+	return str
+}
+
+// boolClaim returns the value of a boolean claim.  Some identity servers
+// send booleans as strings, so those are accepted too.
+func boolClaim(token jwt.Token, claim string) bool {
+	value, ok := token.Get(claim)
+	if !ok {
+		return false
+	}
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		b, err := strconv.ParseBool(v)
+		return err == nil && b
+	}
+	return false
+}
+
+func discoverJWKSUrl(ctx context.Context, issuer string) (string, error) {
+	wellKnownUrl := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, wellKnownUrl, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	doc := discoveryDocument{}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return "", fmt.Errorf("failed to decode discovery document: %w", err)
+	}
+	// OpenID Connect Discovery requires the issuer to match exactly
+	if doc.Issuer != issuer {
+		return "", fmt.Errorf("discovery document is for issuer %q", doc.Issuer)
+	}
+	if doc.JwksURI == "" {
+		return "", errors.New("discovery document has no jwks_uri")
+	}
+
+	return doc.JwksURI, nil
+}
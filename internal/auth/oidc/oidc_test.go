@@ -0,0 +1,333 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/lestrrat-go/jwx/v2/jwt/openid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockdb "github.com/mindersec/minder/database/mock"
+	"github.com/mindersec/minder/internal/db"
+	serverconfig "github.com/mindersec/minder/pkg/config/server"
+)
+
+// newTestIssuer starts an identity server which serves a discovery document
+// and a JWKS, and returns its URL along with the key signing its tokens
+func newTestIssuer(t *testing.T) (string, jwk.Key) {
+	t.Helper()
+
+	keyGen := rand.New(rand.NewSource(12345))
+	key, err := rsa.GenerateKey(keyGen, 2048)
+	require.NoError(t, err)
+	jwkKey, err := jwk.FromRaw(key)
+	require.NoError(t, err)
+	require.NoError(t, jwkKey.Set(jwk.KeyIDKey, "test"))
+	require.NoError(t, jwkKey.Set(jwk.AlgorithmKey, jwa.RS256))
+	require.NoError(t, jwkKey.Set(jwk.KeyUsageKey, "sig"))
+	pubKey, err := jwkKey.PublicKey()
+	require.NoError(t, err)
+
+	keySet := jwk.NewSet()
+	require.NoError(t, keySet.AddKey(pubKey))
+	keySetJSON, err := json.Marshal(keySet)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(keySetJSON)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	// We need to add this to the mux after server start, because it includes the server.URL
+	mux.HandleFunc("/dex/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"issuer":"%[1]s/dex","jwks_uri":"%[1]s/keys"}`, server.URL)))
+	})
+
+	return server.URL + "/dex", jwkKey
+}
+
+func signToken(t *testing.T, key jwk.Key, token openid.Token) string {
+	t.Helper()
+
+	signed, err := jwt.Sign(token, jwt.WithKey(jwa.RS256, key))
+	require.NoError(t, err)
+	return string(signed)
+}
+
+func TestNewOIDC(t *testing.T) {
+	t.Parallel()
+
+	issuer, _ := newTestIssuer(t)
+
+	_, err := NewOIDC(context.Background(), serverconfig.OIDCConfig{
+		Issuer:   issuer,
+		Audience: "minder",
+	}, nil)
+	require.NoError(t, err)
+
+	// the discovery document is for a different issuer
+	_, err = NewOIDC(context.Background(), serverconfig.OIDCConfig{
+		Issuer:   issuer + "/",
+		Audience: "minder",
+	}, nil)
+	require.ErrorContains(t, err, "discovery document is for issuer")
+
+	_, err = NewOIDC(context.Background(), serverconfig.OIDCConfig{
+		Issuer: issuer,
+	}, nil)
+	require.ErrorContains(t, err, "audience is required")
+}
+
+func TestOIDC_ParseAndValidate(t *testing.T) {
+	t.Parallel()
+
+	issuer, key := newTestIssuer(t)
+	provider, err := NewOIDC(context.Background(), serverconfig.OIDCConfig{
+		Issuer:   issuer,
+		Audience: "minder",
+	}, nil)
+	require.NoError(t, err)
+
+	token, err := openid.NewBuilder().
+		Issuer(issuer).
+		Subject("CiQwOGE4Njg0Yi1kYjg4").
+		Audience([]string{"minder"}).
+		Expiration(time.Now().Add(time.Minute)).
+		IssuedAt(time.Now()).
+		Build()
+	require.NoError(t, err)
+	parsed, err := provider.ParseAndValidate(signToken(t, key, token))
+	require.NoError(t, err)
+	require.Equal(t, "CiQwOGE4Njg0Yi1kYjg4", parsed.Subject())
+
+	otherAudience, err := openid.NewBuilder().
+		Issuer(issuer).
+		Subject("CiQwOGE4Njg0Yi1kYjg4").
+		Audience([]string{"other"}).
+		Expiration(time.Now().Add(time.Minute)).
+		IssuedAt(time.Now()).
+		Build()
+	require.NoError(t, err)
+	_, err = provider.ParseAndValidate(signToken(t, key, otherAudience))
+	require.Error(t, err)
+}
+
+func TestOIDC_Validate(t *testing.T) {
+	t.Parallel()
+
+	issuer, _ := newTestIssuer(t)
+
+	tests := []struct {
+		name     string
+		claims   serverconfig.OIDCClaimsConfig
+		token    map[string]any
+		expected db.UpsertCachedIdentityParams
+		wantErr  string
+	}{{
+		name: "standard claims",
+		token: map[string]any{
+			"sub":                "CiQwOGE4Njg0Yi1kYjg4",
+			"preferred_username": "alexsmith",
+			"email":              "alex@example.com",
+			"email_verified":     true,
+			"given_name":         "Alex",
+			"family_name":        "Smith",
+		},
+		expected: db.UpsertCachedIdentityParams{
+			Subject:       "CiQwOGE4Njg0Yi1kYjg4",
+			HumanName:     "alexsmith",
+			Email:         "alex@example.com",
+			EmailVerified: true,
+			FirstName:     "Alex",
+			LastName:      "Smith",
+		},
+	}, {
+		name: "configured claims",
+		claims: serverconfig.OIDCClaimsConfig{
+			Subject:   "uid",
+			HumanName: "login",
+		},
+		token: map[string]any{
+			"sub":   "CiQwOGE4Njg0Yi1kYjg4",
+			"uid":   "00u1a2b3c4",
+			"login": "alexsmith",
+		},
+		expected: db.UpsertCachedIdentityParams{
+			Subject:   "00u1a2b3c4",
+			HumanName: "alexsmith",
+		},
+	}, {
+		name: "no username",
+		token: map[string]any{
+			"sub":            "CiQwOGE4Njg0Yi1kYjg4",
+			"email":          "alex@example.com",
+			"email_verified": "true",
+		},
+		expected: db.UpsertCachedIdentityParams{
+			Subject:       "CiQwOGE4Njg0Yi1kYjg4",
+			HumanName:     "alex@example.com",
+			Email:         "alex@example.com",
+			EmailVerified: true,
+		},
+	}, {
+		name: "no username and unverified email",
+		token: map[string]any{
+			"sub":   "CiQwOGE4Njg0Yi1kYjg4",
+			"email": "alex@example.com",
+		},
+		expected: db.UpsertCachedIdentityParams{
+			Subject:   "CiQwOGE4Njg0Yi1kYjg4",
+			HumanName: "CiQwOGE4Njg0Yi1kYjg4",
+			Email:     "alex@example.com",
+		},
+	}, {
+		name: "no subject",
+		claims: serverconfig.OIDCClaimsConfig{
+			Subject: "uid",
+		},
+		token: map[string]any{
+			"sub": "CiQwOGE4Njg0Yi1kYjg4",
+		},
+		wantErr: "uid not found in token",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+
+			provider, err := NewOIDC(context.Background(), serverconfig.OIDCConfig{
+				Name:     "dex",
+				Issuer:   issuer,
+				Audience: "minder",
+				Claims:   tt.claims,
+			}, store)
+			require.NoError(t, err)
+
+			token := jwt.New()
+			require.NoError(t, token.Set(jwt.IssuerKey, issuer))
+			for k, v := range tt.token {
+				require.NoError(t, token.Set(k, v))
+			}
+
+			if tt.wantErr == "" {
+				tt.expected.Issuer = issuer
+				// the cache is only written to when the claims change
+				store.EXPECT().UpsertCachedIdentity(gomock.Any(), tt.expected).Return(nil).Times(1)
+			}
+
+			for range 2 {
+				identity, err := provider.Validate(context.Background(), token)
+				if tt.wantErr != "" {
+					require.ErrorContains(t, err, tt.wantErr)
+					return
+				}
+				require.NoError(t, err)
+				require.Equal(t, tt.expected.Subject, identity.UserID)
+				require.Equal(t, tt.expected.HumanName, identity.HumanName)
+				require.Equal(t, tt.expected.Email, identity.Email)
+				require.Equal(t, "dex/"+tt.expected.Subject, identity.String())
+			}
+		})
+	}
+}
+
+func TestOIDC_Resolve(t *testing.T) {
+	t.Parallel()
+
+	issuer, _ := newTestIssuer(t)
+
+	alex := db.CachedIdentity{Issuer: issuer, Subject: "CiQwOGE4", HumanName: "alexsmith", Email: "alex@example.com", EmailVerified: true}
+	// a user who took over the handle of another one
+	otherAlex := db.CachedIdentity{Issuer: issuer, Subject: "alexsmith", HumanName: "alex", Email: "other@example.com"}
+	sam := db.CachedIdentity{Issuer: issuer, Subject: "CiQxMjM0", HumanName: "sam", Email: "shared@example.com", EmailVerified: true}
+	// someone who claims the email of alex, without the identity server verifying it
+	mallory := db.CachedIdentity{Issuer: issuer, Subject: "CiQ5MDEy", HumanName: "mallory", Email: "alex@example.com"}
+	kim := db.CachedIdentity{Issuer: issuer, Subject: "CiQ1Njc4", HumanName: "kim", Email: "shared@example.com", EmailVerified: true}
+
+	tests := []struct {
+		name     string
+		id       string
+		cached   []db.CachedIdentity
+		expected string
+		wantErr  string
+	}{{
+		name:     "by name",
+		id:       "alexsmith",
+		cached:   []db.CachedIdentity{alex},
+		expected: alex.Subject,
+	}, {
+		name:     "subject wins over name",
+		id:       "alexsmith",
+		cached:   []db.CachedIdentity{otherAlex, alex},
+		expected: otherAlex.Subject,
+	}, {
+		name:     "by verified email",
+		id:       "alex@example.com",
+		cached:   []db.CachedIdentity{alex, mallory},
+		expected: alex.Subject,
+	}, {
+		name:    "unverified email",
+		id:      "alex@example.com",
+		cached:  []db.CachedIdentity{mallory},
+		wantErr: errNotFound.Error(),
+	}, {
+		name:    "ambiguous email",
+		id:      "shared@example.com",
+		cached:  []db.CachedIdentity{sam, kim},
+		wantErr: "2 users match",
+	}, {
+		name:    "unknown",
+		id:      "nobody",
+		wantErr: errNotFound.Error(),
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().ListCachedIdentities(gomock.Any(), db.ListCachedIdentitiesParams{
+				Issuer: issuer,
+				ID:     tt.id,
+			}).Return(tt.cached, nil)
+
+			provider, err := NewOIDC(context.Background(), serverconfig.OIDCConfig{
+				Issuer:   issuer,
+				Audience: "minder",
+			}, store)
+			require.NoError(t, err)
+
+			identity, err := provider.Resolve(context.Background(), tt.id)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, identity.UserID)
+			// the default provider doesn't prefix identities
+			require.Equal(t, tt.expected, identity.String())
+		})
+	}
+}
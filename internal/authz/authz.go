@@ -34,6 +34,9 @@ var (
 	authzModel string
 )
 
+// customRoleAssignee is the relation between a custom role and its assignees
+const customRoleAssignee = "assignee"
+
 // ClientWrapper is a wrapper for the OpenFgaClient.
 // It is used to provide a common interface for the client and a way to
 // refresh authentication to the authz provider when needed.
@@ -260,6 +263,13 @@ func (a *ClientWrapper) Check(ctx context.Context, action string, project uuid.U
 
 // Write persists the given role for the given user and project
 func (a *ClientWrapper) Write(ctx context.Context, user string, role Role, project uuid.UUID) error {
+	if role.IsCustom() {
+		return a.write(ctx, fgasdk.TupleKey{
+			User:     getUserForTuple(user),
+			Relation: customRoleAssignee,
+			Object:   getCustomRoleForTuple(project, role),
+		})
+	}
 	return a.write(ctx, fgasdk.TupleKey{
 		User:     getUserForTuple(user),
 		Relation: role.String(),
@@ -296,6 +306,9 @@ func (a *ClientWrapper) write(ctx context.Context, t fgasdk.TupleKey) error {
 
 // Delete removes the given role for the given user and project
 func (a *ClientWrapper) Delete(ctx context.Context, user string, role Role, project uuid.UUID) error {
+	if role.IsCustom() {
+		return a.doDelete(ctx, getUserForTuple(user), customRoleAssignee, getCustomRoleForTuple(project, role))
+	}
 	return a.doDelete(ctx, getUserForTuple(user), role.String(), getProjectForTuple(project))
 }
 
@@ -348,6 +361,20 @@ func (a *ClientWrapper) DeleteUser(ctx context.Context, user string) error {
 		}
 	}
 
+	listresp, err := a.cli.ListObjects(ctx).Body(fgaclient.ClientListObjectsRequest{
+		Type:     "role",
+		Relation: customRoleAssignee,
+		User:     getUserForTuple(user),
+	}).Execute()
+	if err != nil {
+		return fmt.Errorf("unable to list authorization tuples: %w", err)
+	}
+	for _, obj := range listresp.GetObjects() {
+		if err := a.doDelete(ctx, getUserForTuple(user), customRoleAssignee, obj); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -360,6 +387,7 @@ func (a *ClientWrapper) AssignmentsToProject(ctx context.Context, project uuid.U
 	var contTok *string = nil
 
 	assignments := []*minderv1.RoleAssignment{}
+	customRoles := map[string]any{}
 
 	for {
 		resp, err := a.cli.Read(ctx).Options(fgaclient.ClientReadOptions{
@@ -374,6 +402,12 @@ func (a *ClientWrapper) AssignmentsToProject(ctx context.Context, project uuid.U
 
 		for _, t := range resp.GetTuples() {
 			k := t.GetKey()
+			// The permissions of custom roles are granted to their assignees,
+			// which are listed below.
+			if customRole, ok := strings.CutSuffix(k.GetUser(), "#"+customRoleAssignee); ok {
+				customRoles[customRole] = struct{}{}
+				continue
+			}
 			r, err := ParseRole(k.GetRelation())
 			if err != nil {
 				a.l.Err(err).Msg("Found invalid role in authz store")
@@ -393,6 +427,23 @@ func (a *ClientWrapper) AssignmentsToProject(ctx context.Context, project uuid.U
 		contTok = &resp.ContinuationToken
 	}
 
+	for customRole := range customRoles {
+		tuples, err := a.readTuples(ctx, fgaclient.ClientReadRequest{
+			Object: &customRole,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tuples {
+			k := t.GetKey()
+			assignments = append(assignments, &minderv1.RoleAssignment{
+				Subject: getUserFromTuple(k.GetUser()),
+				Role:    getCustomRoleFromTuple(customRole).String(),
+				Project: &prjStr,
+			})
+		}
+	}
+
 	return assignments, nil
 }
 
@@ -431,6 +482,21 @@ func (a *ClientWrapper) ProjectsForUser(ctx context.Context, sub string) ([]uuid
 		contTok = &resp.ContinuationToken
 	}
 
+	// Custom roles are assigned on the project they are defined in
+	roleObj := "role:"
+	tuples, err := a.readTuples(ctx, fgaclient.ClientReadRequest{
+		User:   &u,
+		Object: &roleObj,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tuples {
+		k := t.GetKey()
+		project, _, _ := strings.Cut(strings.TrimPrefix(k.GetObject(), "role:"), "/")
+		projs["project:"+project] = struct{}{}
+	}
+
 	out := []uuid.UUID{}
 	for proj := range projs {
 		u, err := uuid.Parse(getProjectFromTuple(proj))
@@ -451,6 +517,102 @@ func (a *ClientWrapper) ProjectsForUser(ctx context.Context, sub string) ([]uuid
 	return out, nil
 }
 
+// WriteCustomRole grants the given permissions on the project to the
+// assignees of the custom role, and revokes the ones it granted before
+func (a *ClientWrapper) WriteCustomRole(ctx context.Context, project uuid.UUID, role Role, permissions []string) error {
+	assignees := getCustomRoleForTuple(project, role) + "#" + customRoleAssignee
+	projectObj := getProjectForTuple(project)
+
+	tuples, err := a.readTuples(ctx, fgaclient.ClientReadRequest{
+		User:   &assignees,
+		Object: &projectObj,
+	})
+	if err != nil {
+		return err
+	}
+
+	granted := map[string]any{}
+	for _, t := range tuples {
+		k := t.GetKey()
+		granted[k.GetRelation()] = struct{}{}
+	}
+
+	for _, perm := range permissions {
+		if _, ok := granted[perm]; ok {
+			delete(granted, perm)
+			continue
+		}
+		if err := a.write(ctx, fgasdk.TupleKey{
+			User:     assignees,
+			Relation: perm,
+			Object:   projectObj,
+		}); err != nil {
+			return err
+		}
+	}
+
+	for perm := range granted {
+		if err := a.doDelete(ctx, assignees, perm, projectObj); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteCustomRole revokes the permissions of the custom role, and removes
+// all of its assignments
+func (a *ClientWrapper) DeleteCustomRole(ctx context.Context, project uuid.UUID, role Role) error {
+	if err := a.WriteCustomRole(ctx, project, role, nil); err != nil {
+		return err
+	}
+
+	roleObj := getCustomRoleForTuple(project, role)
+	tuples, err := a.readTuples(ctx, fgaclient.ClientReadRequest{
+		Object: &roleObj,
+	})
+	if err != nil {
+		return err
+	}
+	for _, t := range tuples {
+		k := t.GetKey()
+		if err := a.doDelete(ctx, k.GetUser(), k.GetRelation(), roleObj); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readTuples reads all the tuples matching the request, following the
+// continuation tokens
+func (a *ClientWrapper) readTuples(ctx context.Context, req fgaclient.ClientReadRequest) ([]fgasdk.Tuple, error) {
+	var pagesize int32 = 50
+	var contTok *string = nil
+
+	tuples := []fgasdk.Tuple{}
+
+	for {
+		resp, err := a.cli.Read(ctx).Options(fgaclient.ClientReadOptions{
+			PageSize:          &pagesize,
+			ContinuationToken: contTok,
+		}).Body(req).Execute()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read authorization tuples: %w", err)
+		}
+
+		tuples = append(tuples, resp.GetTuples()...)
+
+		if resp.GetContinuationToken() == "" {
+			break
+		}
+
+		contTok = &resp.ContinuationToken
+	}
+
+	return tuples, nil
+}
+
 // traverseProjectsForParent is a recursive function that traverses the project
 // hierarchy to find all projects that the parent project has access to.
 func (a *ClientWrapper) traverseProjectsForParent(ctx context.Context, parent uuid.UUID) ([]uuid.UUID, error) {
@@ -500,3 +662,14 @@ func getUserFromTuple(user string) string {
 func getProjectFromTuple(project string) string {
 	return strings.TrimPrefix(project, "project:")
 }
+
+// Custom roles are named after the project they are defined in, since role
+// names are only unique within a project.
+func getCustomRoleForTuple(project uuid.UUID, role Role) string {
+	return "role:" + project.String() + "/" + role.String()
+}
+
+func getCustomRoleFromTuple(role string) Role {
+	_, name, _ := strings.Cut(role, "/")
+	return Role(name)
+}
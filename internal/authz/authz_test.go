@@ -18,9 +18,11 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/mindersec/minder/internal/auth"
 	"github.com/mindersec/minder/internal/authz"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
 	srvconfig "github.com/mindersec/minder/pkg/config/server"
 )
 
@@ -62,6 +64,39 @@ func TestAllRolesExistInFGAModel(t *testing.T) {
 	}
 }
 
+func TestAllPermissionsGrantableToCustomRoles(t *testing.T) {
+	t.Parallel()
+
+	var m fgasdk.WriteAuthorizationModelRequest
+	require.NoError(t, json.Unmarshal([]byte(authzModel), &m), "failed to unmarshal authz model")
+
+	var projectTypeDef fgasdk.TypeDefinition
+	for _, td := range m.TypeDefinitions {
+		if td.Type == "project" {
+			projectTypeDef = td
+			break
+		}
+	}
+	require.NotNil(t, projectTypeDef.Metadata, "project type definition not found in authz model")
+
+	values := minderv1.Relation_RELATION_UNSPECIFIED.Descriptor().Values()
+	for i := 1; i < values.Len(); i++ {
+		name, ok := proto.GetExtension(values.Get(i).Options(), minderv1.E_Name).(string)
+		require.True(t, ok, "relation %s has no name", values.Get(i).Name())
+
+		require.NoError(t, authz.ValidatePermission(name))
+
+		relation, ok := (*projectTypeDef.Metadata.Relations)[name]
+		require.True(t, ok, "permission %s not found in authz model", name)
+		assert.Contains(t, relation.GetDirectlyRelatedUserTypes(),
+			fgasdk.RelationReference{Type: "role", Relation: fgasdk.PtrString("assignee")},
+			"permission %s cannot be granted to custom roles", name)
+	}
+
+	assert.Error(t, authz.ValidatePermission(""))
+	assert.Error(t, authz.ValidatePermission("admin"))
+}
+
 func TestMigration(t *testing.T) {
 	t.Parallel()
 
@@ -205,6 +240,70 @@ func TestVerifyMultipleProjects(t *testing.T) {
 	assert.Len(t, assignments, 0, "expected 0 assignments to project")
 }
 
+func TestVerifyCustomRole(t *testing.T) {
+	t.Parallel()
+
+	c, stopFunc := newOpenFGAServerAndClient(t)
+	defer stopFunc()
+	assert.NotNil(t, c)
+
+	ctx := context.Background()
+
+	assert.NoError(t, c.MigrateUp(ctx), "failed to migrate up")
+
+	// this is required to auto-detect the generated model and store
+	assert.NoError(t, c.PrepareForRun(ctx), "failed to prepare for run")
+
+	// create a project hierarchy
+	parent := uuid.New()
+	child := uuid.New()
+	assert.NoError(t, c.Write(ctx, "user-1", authz.RoleAdmin, parent), "failed to write project")
+	assert.NoError(t, c.Adopt(ctx, parent, child), "failed to adopt project")
+
+	// define a custom role on the parent project and assign it
+	role := authz.Role("data_source_manager")
+	assert.NoError(t, c.WriteCustomRole(ctx, parent, role, []string{"get", "data_source_get"}),
+		"failed to write custom role")
+	assert.NoError(t, c.Write(ctx, "user-2", role, parent), "failed to assign custom role")
+
+	userctx := auth.WithIdentityContext(ctx, &auth.Identity{
+		UserID: "user-2",
+	})
+
+	// the permissions apply to the project and its descendants
+	assert.NoError(t, c.Check(userctx, "data_source_get", parent), "failed to check project")
+	assert.NoError(t, c.Check(userctx, "data_source_get", child), "failed to check child project")
+	assert.Error(t, c.Check(userctx, "data_source_create", parent), "expected permission to not be granted")
+
+	// ensure projects for user returns the project
+	projects, err := c.ProjectsForUser(userctx, "user-2")
+	assert.NoError(t, err, "failed to get projects for user")
+	assert.Contains(t, projects, parent, "expected project to be returned")
+
+	// ensure assignments to project returns the custom role
+	assignments, err := c.AssignmentsToProject(userctx, parent)
+	assert.NoError(t, err, "failed to get assignments to project")
+	assert.Len(t, assignments, 2, "expected 2 assignments to project")
+	for _, a := range assignments {
+		if a.Subject == "user-2" {
+			assert.Equal(t, role.String(), a.Role, "expected custom role assignment")
+		}
+	}
+
+	// replacing the permissions revokes the ones which were removed
+	assert.NoError(t, c.WriteCustomRole(ctx, parent, role, []string{"get", "data_source_create"}),
+		"failed to update custom role")
+	assert.Error(t, c.Check(userctx, "data_source_get", child), "expected permission to be revoked")
+	assert.NoError(t, c.Check(userctx, "data_source_create", child), "failed to check child project")
+
+	// deleting the role removes its assignments
+	assert.NoError(t, c.DeleteCustomRole(ctx, parent, role), "failed to delete custom role")
+	assert.Error(t, c.Check(userctx, "get", parent), "expected role to be gone")
+	assignments, err = c.AssignmentsToProject(userctx, parent)
+	assert.NoError(t, err, "failed to get assignments to project")
+	assert.Len(t, assignments, 1, "expected 1 assignment to project")
+}
+
 func newOpenFGAServerAndClient(t *testing.T) (authz.Client, func()) {
 	t.Helper()
 
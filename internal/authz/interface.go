@@ -9,6 +9,7 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
 
 	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
 )
@@ -66,6 +67,13 @@ func (r Role) String() string {
 	return string(r)
 }
 
+// IsCustom returns true if the role is not one of the built-in roles, but a
+// custom role defined in a project.
+func (r Role) IsCustom() bool {
+	_, ok := AllRolesDescriptions[r]
+	return !ok
+}
+
 // ParseRole parses a string into a Role
 func ParseRole(r string) (Role, error) {
 	if r == "" {
@@ -79,6 +87,22 @@ func ParseRole(r string) (Role, error) {
 	return rr, nil
 }
 
+// ValidatePermission checks that a permission is the name of a project
+// relation, which is what custom roles are made of.
+func ValidatePermission(permission string) error {
+	values := minderv1.Relation_RELATION_UNSPECIFIED.Descriptor().Values()
+	for i := 0; i < values.Len(); i++ {
+		v := values.Get(i)
+		if minderv1.Relation(v.Number()) == minderv1.Relation_RELATION_UNSPECIFIED {
+			continue
+		}
+		if name, ok := proto.GetExtension(v.Options(), minderv1.E_Name).(string); ok && name == permission {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid permission %s", permission)
+}
+
 // Client provides an abstract interface which simplifies interacting with
 // OpenFGA and supports no-op and fake implementations.
 type Client interface {
@@ -86,7 +110,8 @@ type Client interface {
 	Check(ctx context.Context, action string, project uuid.UUID) error
 
 	// Write stores an authorization tuple allowing user (an OAuth2 subject) to
-	// act in the specified role on the project.
+	// act in the specified role on the project.  If the role is custom, it
+	// must be defined in the project with WriteCustomRole.
 	//
 	// NOTE: this method _DOES NOT CHECK_ that the current user in the context
 	// has permissions to update the project.
@@ -119,4 +144,16 @@ type Client interface {
 
 	// Orphan removes an authorization relationship from one project to another
 	Orphan(ctx context.Context, parent, child uuid.UUID) error
+
+	// WriteCustomRole defines a custom role on the project, replacing the
+	// permissions it grants if it already exists.  The permissions apply to
+	// the project and its descendants.
+	//
+	// NOTE: this method _DOES NOT CHECK_ that the current user in the context
+	// has permissions to update the project.
+	WriteCustomRole(ctx context.Context, project uuid.UUID, role Role, permissions []string) error
+
+	// DeleteCustomRole removes a custom role from the project, along with
+	// its assignments.
+	DeleteCustomRole(ctx context.Context, project uuid.UUID, role Role) error
 }
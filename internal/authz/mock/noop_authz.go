@@ -76,3 +76,13 @@ func (_ *NoopClient) Adopt(_ context.Context, _, _ uuid.UUID) error {
 func (_ *NoopClient) Orphan(_ context.Context, _, _ uuid.UUID) error {
 	return nil
 }
+
+// WriteCustomRole implements authz.Client
+func (_ *NoopClient) WriteCustomRole(_ context.Context, _ uuid.UUID, _ authz.Role, _ []string) error {
+	return nil
+}
+
+// DeleteCustomRole implements authz.Client
+func (_ *NoopClient) DeleteCustomRole(_ context.Context, _ uuid.UUID, _ authz.Role) error {
+	return nil
+}
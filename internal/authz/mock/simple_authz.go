@@ -26,6 +26,9 @@ type SimpleClient struct {
 
 	// OrphanCalls is a counter for the number of times Orphan is called
 	OrphanCalls atomic.Int32
+
+	// CustomRoles is a map of project to the permissions of its custom roles
+	CustomRoles map[uuid.UUID]map[authz.Role][]string
 }
 
 var _ authz.Client = &SimpleClient{}
@@ -132,3 +135,27 @@ func (n *SimpleClient) Orphan(_ context.Context, _, c uuid.UUID) error {
 
 	return nil
 }
+
+// WriteCustomRole implements authz.Client
+func (n *SimpleClient) WriteCustomRole(_ context.Context, project uuid.UUID, role authz.Role, permissions []string) error {
+	if n.CustomRoles == nil {
+		n.CustomRoles = make(map[uuid.UUID]map[authz.Role][]string)
+	}
+	if n.CustomRoles[project] == nil {
+		n.CustomRoles[project] = make(map[authz.Role][]string)
+	}
+	n.CustomRoles[project][role] = slices.Clone(permissions)
+	return nil
+}
+
+// DeleteCustomRole implements authz.Client
+func (n *SimpleClient) DeleteCustomRole(_ context.Context, project uuid.UUID, role authz.Role) error {
+	delete(n.CustomRoles[project], role)
+	if n.Assignments == nil {
+		return nil
+	}
+	n.Assignments[project] = slices.DeleteFunc(n.Assignments[project], func(a *minderv1.RoleAssignment) bool {
+		return a.Role == string(role)
+	})
+	return nil
+}
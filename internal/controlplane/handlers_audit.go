@@ -0,0 +1,325 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controlplane
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/sqlc-dev/pqtype"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/mindersec/minder/internal/audit"
+	"github.com/mindersec/minder/internal/auth"
+	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/internal/util"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+const auditErrMsg = "error retrieving audit events"
+
+// AuditInterceptor is a server interceptor that records the mutating calls
+// in the audit log, whether they succeed or not.  It runs after the project
+// of the call is known, and before the call is authorized.  For the calls
+// on resources whose state can be read, the fields the call changed are
+// recorded as well.
+func AuditInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (any, error) {
+
+	msg, ok := req.(proto.Message)
+	if !ok || !isMutatingMethod(info.FullMethod) {
+		return handler(ctx, req)
+	}
+	server, ok := info.Server.(*Server)
+	if !ok {
+		return handler(ctx, req)
+	}
+
+	// The call may have been cancelled, but it should be recorded anyway
+	auditCtx := context.WithoutCancel(ctx)
+
+	var before proto.Message
+	getState, tracked := auditStateGetters[info.FullMethod]
+	if tracked {
+		before, tracked = server.readAuditState(auditCtx, getState, msg)
+	}
+
+	resp, err := handler(ctx, req)
+
+	var changes json.RawMessage
+	if tracked {
+		changes = server.auditChanges(auditCtx, getState, msg, before)
+	}
+	server.recordAuditEvent(auditCtx, info.FullMethod, msg, changes, err)
+
+	return resp, err
+}
+
+// auditStateGetter reads the current state of the resource a call acts on,
+// returning nil if the resource doesn't exist
+type auditStateGetter func(ctx context.Context, s *Server, req proto.Message) (proto.Message, error)
+
+// auditStateGetters are the getters of the resources whose changes are
+// recorded in the audit log, by method.  The other calls are only recorded
+// with their requests.
+var auditStateGetters = map[string]auditStateGetter{
+	minderv1.ProfileService_UpdateProfile_FullMethodName:             profileAuditState,
+	minderv1.ProfileService_PatchProfile_FullMethodName:              profileAuditState,
+	minderv1.ProfileService_DeleteProfile_FullMethodName:             profileAuditState,
+	minderv1.RuleTypeService_UpdateRuleType_FullMethodName:           ruleTypeAuditState,
+	minderv1.RuleTypeService_DeleteRuleType_FullMethodName:           ruleTypeAuditState,
+	minderv1.DataSourceService_UpdateDataSource_FullMethodName:       dataSourceAuditState,
+	minderv1.DataSourceService_DeleteDataSourceById_FullMethodName:   dataSourceAuditState,
+	minderv1.DataSourceService_DeleteDataSourceByName_FullMethodName: dataSourceAuditState,
+	minderv1.ProvidersService_PatchProvider_FullMethodName:           providerAuditState,
+	minderv1.ProvidersService_DeleteProvider_FullMethodName:          providerAuditState,
+}
+
+func profileAuditState(ctx context.Context, s *Server, req proto.Message) (proto.Message, error) {
+	var resp interface{ GetProfile() *minderv1.Profile }
+	var err error
+	switch r := req.(type) {
+	case *minderv1.UpdateProfileRequest:
+		resp, err = s.GetProfileByName(ctx, &minderv1.GetProfileByNameRequest{Name: r.GetProfile().GetName()})
+	case *minderv1.PatchProfileRequest:
+		resp, err = s.GetProfileById(ctx, &minderv1.GetProfileByIdRequest{Id: r.GetId()})
+	case *minderv1.DeleteProfileRequest:
+		resp, err = s.GetProfileById(ctx, &minderv1.GetProfileByIdRequest{Id: r.GetId()})
+	default:
+		return nil, fmt.Errorf("unexpected request %T", req)
+	}
+	return resp.GetProfile(), err
+}
+
+func ruleTypeAuditState(ctx context.Context, s *Server, req proto.Message) (proto.Message, error) {
+	var resp interface{ GetRuleType() *minderv1.RuleType }
+	var err error
+	switch r := req.(type) {
+	case *minderv1.UpdateRuleTypeRequest:
+		resp, err = s.GetRuleTypeByName(ctx, &minderv1.GetRuleTypeByNameRequest{Name: r.GetRuleType().GetName()})
+	case *minderv1.DeleteRuleTypeRequest:
+		resp, err = s.GetRuleTypeById(ctx, &minderv1.GetRuleTypeByIdRequest{Id: r.GetId()})
+	default:
+		return nil, fmt.Errorf("unexpected request %T", req)
+	}
+	return resp.GetRuleType(), err
+}
+
+func dataSourceAuditState(ctx context.Context, s *Server, req proto.Message) (proto.Message, error) {
+	var resp interface{ GetDataSource() *minderv1.DataSource }
+	var err error
+	switch r := req.(type) {
+	case *minderv1.UpdateDataSourceRequest:
+		resp, err = s.GetDataSourceByName(ctx, &minderv1.GetDataSourceByNameRequest{Name: r.GetDataSource().GetName()})
+	case *minderv1.DeleteDataSourceByIdRequest:
+		resp, err = s.GetDataSourceById(ctx, &minderv1.GetDataSourceByIdRequest{Id: r.GetId()})
+	case *minderv1.DeleteDataSourceByNameRequest:
+		resp, err = s.GetDataSourceByName(ctx, &minderv1.GetDataSourceByNameRequest{Name: r.GetName()})
+	default:
+		return nil, fmt.Errorf("unexpected request %T", req)
+	}
+	return resp.GetDataSource(), err
+}
+
+func providerAuditState(ctx context.Context, s *Server, req proto.Message) (proto.Message, error) {
+	// the provider is named by the context of the call
+	r, ok := req.(interface{ GetContext() *minderv1.Context })
+	if !ok {
+		return nil, fmt.Errorf("unexpected request %T", req)
+	}
+	resp, err := s.GetProvider(ctx, &minderv1.GetProviderRequest{Name: r.GetContext().GetProvider()})
+	return resp.GetProvider(), err
+}
+
+// readAuditState reads the state of the resource a call acts on.  It returns
+// false if the state can't be read, in which case the changes made by the
+// call aren't recorded.
+func (s *Server) readAuditState(ctx context.Context, getState auditStateGetter, req proto.Message) (proto.Message, bool) {
+	state, err := getState(ctx, s, req)
+	if status.Code(err) == codes.NotFound {
+		return nil, true
+	}
+	if err != nil {
+		zerolog.Ctx(ctx).Debug().Err(err).Msg("unable to read resource state for audit log")
+		return nil, false
+	}
+	return state, true
+}
+
+// auditChanges returns the redacted changes a call made to the resource it
+// acts on, nil if there are none or if they can't be computed
+func (s *Server) auditChanges(
+	ctx context.Context, getState auditStateGetter, req proto.Message, before proto.Message,
+) json.RawMessage {
+	after, ok := s.readAuditState(ctx, getState, req)
+	if !ok {
+		return nil
+	}
+	changes, err := audit.Diff(before, after)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("unable to compute resource changes for audit log")
+		return nil
+	}
+	return changes
+}
+
+// isMutatingMethod returns true unless the method is exposed as an HTTP GET
+func isMutatingMethod(fullMethod string) bool {
+	formattedName := strings.ReplaceAll(strings.TrimPrefix(fullMethod, "/"), "/", ".")
+	descriptor, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(formattedName))
+	if err != nil {
+		return true
+	}
+	rule, ok := proto.GetExtension(descriptor.Options(), annotations.E_Http).(*annotations.HttpRule)
+	if !ok || rule == nil {
+		return true
+	}
+	return rule.GetGet() == ""
+}
+
+func (s *Server) recordAuditEvent(
+	ctx context.Context, method string, req proto.Message, changes json.RawMessage, callErr error,
+) {
+	logger := zerolog.Ctx(ctx).With().Str("rpc", method).Logger()
+
+	request, err := audit.Redact(req)
+	if err != nil {
+		logger.Error().Err(err).Msg("unable to redact request for audit log")
+		return
+	}
+
+	var projectID uuid.NullUUID
+	if project := GetProjectID(ctx); project != uuid.Nil {
+		projectID = uuid.NullUUID{UUID: project, Valid: true}
+	}
+
+	identity := auth.IdentityFromContext(ctx)
+	var actorName string
+	if identity != nil {
+		actorName = identity.Human()
+	}
+
+	if err := s.store.InsertAuditEvent(ctx, db.InsertAuditEventParams{
+		ProjectID: projectID,
+		Actor:     identity.String(),
+		ActorName: actorName,
+		Rpc:       method,
+		Target:    audit.Target(req),
+		Request:   request,
+		Status:    status.Code(callErr).String(),
+		Changes:   pqtype.NullRawMessage{RawMessage: changes, Valid: changes != nil},
+	}); err != nil {
+		// The outcome of the call doesn't depend on the audit log
+		logger.Error().Err(err).Msg("unable to record audit event")
+	}
+}
+
+// ListAuditEvents lists the mutating calls made on a project, from newest
+// to oldest.
+func (s *Server) ListAuditEvents(
+	ctx context.Context,
+	in *minderv1.ListAuditEventsRequest,
+) (*minderv1.ListAuditEventsResponse, error) {
+	projectID := GetProjectID(ctx)
+
+	size := defaultPageSize
+	if in.GetCursor().GetSize() != 0 {
+		size = in.GetCursor().GetSize()
+	}
+	if size > maxPageSize {
+		return nil, util.UserVisibleError(
+			codes.InvalidArgument,
+			"requested page size was %d, max is %d",
+			size, maxPageSize,
+		)
+	}
+
+	params := db.ListAuditEventsParams{
+		ProjectID: uuid.NullUUID{UUID: projectID, Valid: true},
+		Size:      int64(size),
+	}
+	if cursor := in.GetCursor().GetCursor(); cursor != "" {
+		id, err := uuid.Parse(cursor)
+		if err != nil {
+			return nil, util.UserVisibleError(codes.InvalidArgument, "invalid cursor: %s", cursor)
+		}
+		params.Cursor = uuid.NullUUID{UUID: id, Valid: true}
+	}
+	if in.GetActor() != "" {
+		params.Actor = sql.NullString{String: in.GetActor(), Valid: true}
+	}
+	if in.GetRpc() != "" {
+		params.Rpc = sql.NullString{String: in.GetRpc(), Valid: true}
+	}
+	if in.GetFrom() != nil {
+		params.Fromts = sql.NullTime{Time: in.GetFrom().AsTime(), Valid: true}
+	}
+	if in.GetTo() != nil {
+		params.Tots = sql.NullTime{Time: in.GetTo().AsTime(), Valid: true}
+	}
+
+	events, err := s.store.ListAuditEvents(ctx, params)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg(auditErrMsg)
+		return nil, status.Error(codes.Internal, auditErrMsg)
+	}
+
+	resp := &minderv1.ListAuditEventsResponse{
+		Events: make([]*minderv1.AuditEvent, 0, len(events)),
+		Page:   &minderv1.CursorPage{},
+	}
+	for _, event := range events {
+		request := &structpb.Struct{}
+		if err := request.UnmarshalJSON(event.Request); err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Str("event", event.ID.String()).Msg(auditErrMsg)
+			return nil, status.Error(codes.Internal, auditErrMsg)
+		}
+		var changes *structpb.Struct
+		if event.Changes.Valid {
+			changes = &structpb.Struct{}
+			if err := changes.UnmarshalJSON(event.Changes.RawMessage); err != nil {
+				zerolog.Ctx(ctx).Error().Err(err).Str("event", event.ID.String()).Msg(auditErrMsg)
+				return nil, status.Error(codes.Internal, auditErrMsg)
+			}
+		}
+		resp.Events = append(resp.Events, &minderv1.AuditEvent{
+			Id:               event.ID.String(),
+			CreatedAt:        timestamppb.New(event.CreatedAt),
+			Project:          projectID.String(),
+			Actor:            event.Actor,
+			ActorDisplayName: event.ActorName,
+			Rpc:              event.Rpc,
+			Target:           event.Target,
+			Request:          request,
+			Status:           event.Status,
+			Changes:          changes,
+		})
+	}
+
+	// A full page may be followed by more events
+	if len(events) == int(size) {
+		last := events[len(events)-1].ID
+		resp.Page.Next = &minderv1.Cursor{
+			// the undashed form of the ID is a valid cursor
+			Cursor: hex.EncodeToString(last[:]),
+			Size:   size,
+		}
+	}
+
+	return resp, nil
+}
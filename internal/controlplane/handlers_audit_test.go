@@ -0,0 +1,308 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controlplane
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sqlc-dev/pqtype"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	mockdb "github.com/mindersec/minder/database/mock"
+	"github.com/mindersec/minder/internal/auth"
+	mock_service "github.com/mindersec/minder/internal/datasources/service/mock"
+	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/internal/engine/engcontext"
+	"github.com/mindersec/minder/internal/flags"
+	"github.com/mindersec/minder/internal/util"
+	minder "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+func TestAuditInterceptor(t *testing.T) {
+	t.Parallel()
+
+	projectID := uuid.New()
+
+	testCases := []struct {
+		name       string
+		method     string
+		req        any
+		handlerErr error
+		expected   *db.InsertAuditEventParams
+		insertErr  error
+	}{
+		{
+			name:   "mutating call is recorded",
+			method: "/minder.v1.ProvidersService/StoreProviderToken",
+			req: &minder.StoreProviderTokenRequest{
+				AccessToken: "gho_abcdef",
+			},
+			expected: &db.InsertAuditEventParams{
+				ProjectID: uuid.NullUUID{UUID: projectID, Valid: true},
+				Actor:     "subject1",
+				ActorName: "alexsmith",
+				Rpc:       "/minder.v1.ProvidersService/StoreProviderToken",
+				Request:   json.RawMessage(`{"accessToken":"REDACTED"}`),
+				Status:    codes.OK.String(),
+			},
+		},
+		{
+			name:       "failed call is recorded",
+			method:     "/minder.v1.RepositoryService/DeleteRepositoryById",
+			req:        &minder.DeleteRepositoryByIdRequest{RepositoryId: "repo1"},
+			handlerErr: status.Error(codes.PermissionDenied, "not allowed"),
+			expected: &db.InsertAuditEventParams{
+				ProjectID: uuid.NullUUID{UUID: projectID, Valid: true},
+				Actor:     "subject1",
+				ActorName: "alexsmith",
+				Rpc:       "/minder.v1.RepositoryService/DeleteRepositoryById",
+				Target:    "repo1",
+				Request:   json.RawMessage(`{"repositoryId":"repo1"}`),
+				Status:    codes.PermissionDenied.String(),
+			},
+		},
+		{
+			name:   "read-only call is not recorded",
+			method: "/minder.v1.ProfileService/GetProfileById",
+			req:    &minder.GetProfileByIdRequest{Id: "profile1"},
+		},
+		{
+			name:   "audit log errors don't fail the call",
+			method: "/minder.v1.RepositoryService/DeleteRepositoryById",
+			req:    &minder.DeleteRepositoryByIdRequest{RepositoryId: "repo1"},
+			expected: &db.InsertAuditEventParams{
+				ProjectID: uuid.NullUUID{UUID: projectID, Valid: true},
+				Actor:     "subject1",
+				ActorName: "alexsmith",
+				Rpc:       "/minder.v1.RepositoryService/DeleteRepositoryById",
+				Target:    "repo1",
+				Request:   json.RawMessage(`{"repositoryId":"repo1"}`),
+				Status:    codes.OK.String(),
+			},
+			insertErr: errors.New("database is down"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			store := mockdb.NewMockStore(ctrl)
+
+			if tc.expected != nil {
+				store.EXPECT().InsertAuditEvent(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, arg db.InsertAuditEventParams) error {
+						require.JSONEq(t, string(tc.expected.Request), string(arg.Request))
+						arg.Request = tc.expected.Request
+						require.Equal(t, *tc.expected, arg)
+						return tc.insertErr
+					})
+			}
+
+			server := Server{store: store}
+			ctx := engcontext.WithEntityContext(context.Background(), &engcontext.EntityContext{
+				Project: engcontext.Project{ID: projectID},
+			})
+			ctx = auth.WithIdentityContext(ctx, &auth.Identity{
+				UserID:    "subject1",
+				HumanName: "alexsmith",
+			})
+
+			unaryHandler := func(_ context.Context, _ interface{}) (any, error) {
+				return "reply", tc.handlerErr
+			}
+			reply, err := AuditInterceptor(ctx, tc.req, &grpc.UnaryServerInfo{
+				Server:     &server,
+				FullMethod: tc.method,
+			}, unaryHandler)
+			require.Equal(t, tc.handlerErr, err)
+			require.Equal(t, "reply", reply)
+		})
+	}
+}
+
+func TestAuditInterceptorRecordsChanges(t *testing.T) {
+	t.Parallel()
+
+	projectID := uuid.New()
+	dataSource := func(endpoint, token string) *minder.DataSource {
+		return &minder.DataSource{
+			Name: "ds1",
+			Driver: &minder.DataSource_Rest{Rest: &minder.RestDataSource{
+				Def: map[string]*minder.RestDataSource_Def{
+					"get": {
+						Endpoint: endpoint,
+						Headers:  map[string]string{"Authorization": token},
+					},
+				},
+			}},
+		}
+	}
+
+	testCases := []struct {
+		name            string
+		method          string
+		req             any
+		before          *minder.DataSource
+		after           *minder.DataSource
+		expectedChanges string
+	}{
+		{
+			name:   "changed fields are recorded",
+			method: minder.DataSourceService_UpdateDataSource_FullMethodName,
+			req: &minder.UpdateDataSourceRequest{
+				DataSource: dataSource("https://api.example.com/v2", "new-token"),
+			},
+			before: dataSource("https://api.example.com/v1", "old-token"),
+			after:  dataSource("https://api.example.com/v2", "new-token"),
+			expectedChanges: `{
+				"before": {"rest": {"def": {"get": {"endpoint": "https://api.example.com/v1"}}}},
+				"after": {"rest": {"def": {"get": {"endpoint": "https://api.example.com/v2"}}}}
+			}`,
+		},
+		{
+			name:            "deleted resource is recorded",
+			method:          minder.DataSourceService_DeleteDataSourceByName_FullMethodName,
+			req:             &minder.DeleteDataSourceByNameRequest{Name: "ds1"},
+			before:          &minder.DataSource{Name: "ds1"},
+			expectedChanges: `{"before": {"name": "ds1"}, "after": {}}`,
+		},
+		{
+			name:   "unchanged resource records no changes",
+			method: minder.DataSourceService_UpdateDataSource_FullMethodName,
+			req: &minder.UpdateDataSourceRequest{
+				DataSource: &minder.DataSource{Name: "ds1"},
+			},
+			before: &minder.DataSource{Name: "ds1"},
+			after:  &minder.DataSource{Name: "ds1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetProjectByID(gomock.Any(), projectID).
+				Return(db.Project{ID: projectID}, nil).AnyTimes()
+
+			dsService := mock_service.NewMockDataSourcesService(ctrl)
+			afterErr := error(nil)
+			if tc.after == nil {
+				afterErr = util.UserVisibleError(codes.NotFound, "data source not found")
+			}
+			gomock.InOrder(
+				dsService.EXPECT().GetByName(gomock.Any(), "ds1", projectID, gomock.Any()).Return(tc.before, nil),
+				dsService.EXPECT().GetByName(gomock.Any(), "ds1", projectID, gomock.Any()).Return(tc.after, afterErr),
+			)
+
+			store.EXPECT().InsertAuditEvent(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, arg db.InsertAuditEventParams) error {
+					if tc.expectedChanges == "" {
+						require.False(t, arg.Changes.Valid)
+						return nil
+					}
+					require.True(t, arg.Changes.Valid)
+					require.JSONEq(t, tc.expectedChanges, string(arg.Changes.RawMessage))
+					return nil
+				})
+
+			server := Server{
+				store:              store,
+				dataSourcesService: dsService,
+				featureFlags:       &flags.FakeClient{Data: map[string]any{"data_sources": true}},
+			}
+			ctx := engcontext.WithEntityContext(context.Background(), &engcontext.EntityContext{
+				Project: engcontext.Project{ID: projectID},
+			})
+			ctx = auth.WithIdentityContext(ctx, &auth.Identity{UserID: "subject1"})
+
+			unaryHandler := func(_ context.Context, _ interface{}) (any, error) {
+				return "reply", nil
+			}
+			_, err := AuditInterceptor(ctx, tc.req, &grpc.UnaryServerInfo{
+				Server:     &server,
+				FullMethod: tc.method,
+			}, unaryHandler)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestListAuditEvents(t *testing.T) {
+	t.Parallel()
+
+	projectID := uuid.New()
+	createdAt := time.Date(2024, 10, 16, 3, 0, 0, 0, time.UTC)
+	events := make([]db.AuditEvent, 0, 2)
+	for range 2 {
+		events = append(events, db.AuditEvent{
+			ID:        uuid.New(),
+			CreatedAt: createdAt,
+			ProjectID: uuid.NullUUID{UUID: projectID, Valid: true},
+			Actor:     "subject1",
+			ActorName: "alexsmith",
+			Rpc:       "/minder.v1.ProfileService/DeleteProfile",
+			Target:    "profile1",
+			Request:   json.RawMessage(`{"id":"profile1"}`),
+			Status:    codes.OK.String(),
+			Changes: pqtype.NullRawMessage{
+				RawMessage: json.RawMessage(`{"before":{"name":"profile1"},"after":{}}`),
+				Valid:      true,
+			},
+		})
+	}
+	cursor := uuid.New()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ListAuditEvents(gomock.Any(), db.ListAuditEventsParams{
+		ProjectID: uuid.NullUUID{UUID: projectID, Valid: true},
+		Rpc:       sql.NullString{String: "DeleteProfile", Valid: true},
+		Cursor:    uuid.NullUUID{UUID: cursor, Valid: true},
+		Size:      2,
+	}).Return(events, nil)
+
+	server := Server{store: store}
+	ctx := engcontext.WithEntityContext(context.Background(), &engcontext.EntityContext{
+		Project: engcontext.Project{ID: projectID},
+	})
+
+	resp, err := server.ListAuditEvents(ctx, &minder.ListAuditEventsRequest{
+		Rpc:    "DeleteProfile",
+		Cursor: &minder.Cursor{Cursor: strings.ReplaceAll(cursor.String(), "-", ""), Size: 2},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.GetEvents(), 2)
+	require.Equal(t, events[0].ID.String(), resp.GetEvents()[0].GetId())
+	require.Equal(t, "alexsmith", resp.GetEvents()[0].GetActorDisplayName())
+	require.Equal(t, "profile1", resp.GetEvents()[0].GetRequest().GetFields()["id"].GetStringValue())
+	require.Equal(t, "profile1",
+		resp.GetEvents()[0].GetChanges().GetFields()["before"].GetStructValue().GetFields()["name"].GetStringValue())
+
+	// the page is full, so the next one starts after its last event
+	next, err := uuid.Parse(resp.GetPage().GetNext().GetCursor())
+	require.NoError(t, err)
+	require.Equal(t, events[1].ID, next)
+
+	_, err = server.ListAuditEvents(ctx, &minder.ListAuditEventsRequest{
+		Cursor: &minder.Cursor{Size: maxPageSize + 1},
+	})
+	require.ErrorContains(t, err, "requested page size")
+}
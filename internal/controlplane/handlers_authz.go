@@ -23,6 +23,7 @@ import (
 	"github.com/mindersec/minder/internal/engine/engcontext"
 	"github.com/mindersec/minder/internal/flags"
 	"github.com/mindersec/minder/internal/invites"
+	"github.com/mindersec/minder/internal/roles"
 	"github.com/mindersec/minder/internal/util"
 	minder "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
 )
@@ -78,16 +79,9 @@ func ProjectAuthorizationInterceptor(ctx context.Context, req interface{}, info
 		return handler(ctx, req)
 	}
 
-	relation := opts.GetRelation()
-
-	relationValue := relation.Descriptor().Values().ByNumber(relation.Number())
-	if relationValue == nil {
-		return nil, status.Errorf(codes.Internal, "error reading relation value %v", relation)
-	}
-	extension := proto.GetExtension(relationValue.Options(), minder.E_Name)
-	relationName, ok := extension.(string)
-	if !ok {
-		return nil, status.Errorf(codes.Internal, "error getting name for requested relation %v", relation)
+	relationName, err := getRelationName(opts.GetRelation())
+	if err != nil {
+		return nil, err
 	}
 
 	entityCtx := engcontext.EntityFromContext(ctx)
@@ -103,6 +97,36 @@ func ProjectAuthorizationInterceptor(ctx context.Context, req interface{}, info
 	return handler(ctx, req)
 }
 
+// getRelationName returns the name of a relation in the authorization model
+func getRelationName(relation minder.Relation) (string, error) {
+	relationValue := relation.Descriptor().Values().ByNumber(relation.Number())
+	if relationValue == nil {
+		return "", status.Errorf(codes.Internal, "error reading relation value %v", relation)
+	}
+	extension := proto.GetExtension(relationValue.Options(), minder.E_Name)
+	relationName, ok := extension.(string)
+	if !ok {
+		return "", status.Errorf(codes.Internal, "error getting name for requested relation %v", relation)
+	}
+	return relationName, nil
+}
+
+// isAuthorized returns whether the user is authorized on the project for a
+// relation other than the one of the RPC
+func (s *Server) isAuthorized(ctx context.Context, relation minder.Relation, project uuid.UUID) (bool, error) {
+	relationName, err := getRelationName(relation)
+	if err != nil {
+		return false, err
+	}
+	if err := s.authzClient.Check(ctx, relationName, project); err != nil {
+		if errors.Is(err, authz.ErrNotAuthorized) {
+			return false, nil
+		}
+		return false, status.Errorf(codes.Internal, "error checking authorization: %v", err)
+	}
+	return true, nil
+}
+
 // populateEntityContext populates the project in the entity context, by looking at the proto context or
 // fetching the default project
 func populateEntityContext(
@@ -203,10 +227,17 @@ func getDefaultProjectID(
 // ensure interface implementation
 var _ minder.PermissionsServiceServer = (*Server)(nil)
 
-// ListRoles returns the list of available roles for the minder instance
-func (*Server) ListRoles(_ context.Context, _ *minder.ListRolesRequest) (*minder.ListRolesResponse, error) {
+// ListRoles returns the list of available roles for the minder instance,
+// followed by the custom roles defined in the project
+func (s *Server) ListRoles(ctx context.Context, _ *minder.ListRolesRequest) (*minder.ListRolesResponse, error) {
+	entityCtx := engcontext.EntityFromContext(ctx)
+	customRoles, err := s.store.ListCustomRolesByProject(ctx, entityCtx.Project.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error listing custom roles: %v", err)
+	}
+
 	resp := minder.ListRolesResponse{
-		Roles: make([]*minder.Role, 0, len(authz.AllRolesDescriptions)),
+		Roles: make([]*minder.Role, 0, len(authz.AllRolesDescriptions)+len(customRoles)),
 	}
 	// Iterate over all roles and add them to the response if they have a description. Skip if they don't.
 	// The roles are sorted by the order in which they are defined in the authz package, i.e. admin, editor, viewer, etc.
@@ -222,9 +253,94 @@ func (*Server) ListRoles(_ context.Context, _ *minder.ListRolesRequest) (*minder
 			Description: authz.AllRolesDescriptions[role],
 		})
 	}
+	for _, role := range customRoles {
+		resp.Roles = append(resp.Roles, roles.CustomRoleToProto(role))
+	}
 	return &resp, nil
 }
 
+// CreateCustomRole defines a custom role on the project, granting a set of
+// the project permissions.
+func (s *Server) CreateCustomRole(
+	ctx context.Context,
+	req *minder.CreateCustomRoleRequest,
+) (*minder.CreateCustomRoleResponse, error) {
+	entityCtx := engcontext.EntityFromContext(ctx)
+
+	role, err := s.withCustomRoleTransaction(ctx, entityCtx.Project.ID, req.GetRole().GetName(),
+		func(qtx db.ExtendQuerier) (*minder.Role, error) {
+			return s.roles.CreateCustomRole(ctx, qtx, s.authzClient, entityCtx.Project.ID, req.GetRole())
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &minder.CreateCustomRoleResponse{Role: role}, nil
+}
+
+// UpdateCustomRole updates the display name, description and permissions of
+// a custom role on the project.
+func (s *Server) UpdateCustomRole(
+	ctx context.Context,
+	req *minder.UpdateCustomRoleRequest,
+) (*minder.UpdateCustomRoleResponse, error) {
+	entityCtx := engcontext.EntityFromContext(ctx)
+
+	role, err := s.withCustomRoleTransaction(ctx, entityCtx.Project.ID, req.GetRole().GetName(),
+		func(qtx db.ExtendQuerier) (*minder.Role, error) {
+			return s.roles.UpdateCustomRole(ctx, qtx, s.authzClient, entityCtx.Project.ID, req.GetRole())
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &minder.UpdateCustomRoleResponse{Role: role}, nil
+}
+
+// DeleteCustomRole deletes a custom role from the project, along with its
+// assignments.
+func (s *Server) DeleteCustomRole(
+	ctx context.Context,
+	req *minder.DeleteCustomRoleRequest,
+) (*minder.DeleteCustomRoleResponse, error) {
+	entityCtx := engcontext.EntityFromContext(ctx)
+
+	role, err := s.withCustomRoleTransaction(ctx, entityCtx.Project.ID, req.GetName(),
+		func(qtx db.ExtendQuerier) (*minder.Role, error) {
+			return s.roles.DeleteCustomRole(ctx, qtx, s.authzClient, entityCtx.Project.ID, req.GetName())
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &minder.DeleteCustomRoleResponse{Role: role}, nil
+}
+
+// withCustomRoleTransaction runs a change to a custom role in a transaction.
+// The role service writes the permissions of the role to the authorization
+// store before the transaction is committed, so they are synced back to the
+// database if the commit fails.
+func (s *Server) withCustomRoleTransaction(
+	ctx context.Context,
+	project uuid.UUID,
+	name string,
+	fn func(qtx db.ExtendQuerier) (*minder.Role, error),
+) (*minder.Role, error) {
+	written := false
+	role, err := db.WithTransaction(s.store, func(qtx db.ExtendQuerier) (*minder.Role, error) {
+		role, err := fn(qtx)
+		written = err == nil
+		return role, err
+	})
+	if err != nil && written {
+		if err := roles.SyncCustomRole(ctx, s.store, s.authzClient, project, name); err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Str("role", name).
+				Msg("error syncing the permissions of a custom role")
+		}
+	}
+	return role, err
+}
+
 // ListRoleAssignments returns the list of role assignments for the given project
 func (s *Server) ListRoleAssignments(
 	ctx context.Context,
@@ -307,9 +423,9 @@ func (s *Server) AssignRole(ctx context.Context, req *minder.AssignRoleRequest)
 	}
 
 	// Parse role (this also validates)
-	authzRole, err := authz.ParseRole(role)
+	authzRole, err := s.parseRole(ctx, targetProject, role)
 	if err != nil {
-		return nil, util.UserVisibleError(codes.InvalidArgument, "%s", err.Error())
+		return nil, err
 	}
 
 	// Ensure the target project exists
@@ -324,6 +440,9 @@ func (s *Server) AssignRole(ctx context.Context, req *minder.AssignRoleRequest)
 
 	// Decide if it's an invitation or a role assignment
 	if sub == "" && inviteeEmail != "" {
+		if authzRole.IsCustom() {
+			return nil, util.UserVisibleError(codes.InvalidArgument, "custom roles cannot be granted through invitations")
+		}
 		if flags.Bool(ctx, s.featureFlags, flags.UserManagement) {
 			invitation, err := db.WithTransaction(s.store, func(qtx db.ExtendQuerier) (*minder.Invitation, error) {
 				return s.invites.CreateInvite(ctx, qtx, s.evt, s.cfg.Email, targetProject, authzRole, inviteeEmail)
@@ -368,9 +487,9 @@ func (s *Server) RemoveRole(ctx context.Context, req *minder.RemoveRoleRequest)
 	targetProject := entityCtx.Project.ID
 
 	// Parse role (this also validates)
-	authzRole, err := authz.ParseRole(role)
+	authzRole, err := s.parseRole(ctx, targetProject, role)
 	if err != nil {
-		return nil, util.UserVisibleError(codes.InvalidArgument, "%s", err.Error())
+		return nil, err
 	}
 
 	// Validate the subject and email - decide if it's about removing an invitation or a role assignment
@@ -424,13 +543,16 @@ func (s *Server) UpdateRole(ctx context.Context, req *minder.UpdateRoleRequest)
 	}
 
 	// Parse role (this also validates)
-	authzRole, err := authz.ParseRole(role)
+	authzRole, err := s.parseRole(ctx, targetProject, role)
 	if err != nil {
-		return nil, util.UserVisibleError(codes.InvalidArgument, "%s", err.Error())
+		return nil, err
 	}
 
 	// Validate the subject and email - decide if it's about updating an invitation or a role assignment
 	if sub == "" && inviteeEmail != "" {
+		if authzRole.IsCustom() {
+			return nil, util.UserVisibleError(codes.InvalidArgument, "custom roles cannot be granted through invitations")
+		}
 		if flags.Bool(ctx, s.featureFlags, flags.UserManagement) {
 			updatedInvitation, err := db.WithTransaction(s.store, func(qtx db.ExtendQuerier) (*minder.Invitation, error) {
 				return s.invites.UpdateInvite(ctx, qtx, s.evt, s.cfg.Email, targetProject, authzRole, inviteeEmail)
@@ -464,6 +586,30 @@ func (s *Server) UpdateRole(ctx context.Context, req *minder.UpdateRoleRequest)
 	return nil, util.UserVisibleError(codes.InvalidArgument, "one of subject or email must be specified")
 }
 
+// parseRole parses the name of a built-in role, or of a custom role defined
+// in the project
+func (s *Server) parseRole(ctx context.Context, project uuid.UUID, role string) (authz.Role, error) {
+	authzRole, err := authz.ParseRole(role)
+	if err == nil {
+		return authzRole, nil
+	}
+	if role == "" {
+		return "", util.UserVisibleError(codes.InvalidArgument, "%s", err.Error())
+	}
+
+	_, err = s.store.GetCustomRoleByName(ctx, db.GetCustomRoleByNameParams{
+		ProjectID: project,
+		Name:      role,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", util.UserVisibleError(codes.InvalidArgument, "invalid role %s", role)
+	} else if err != nil {
+		return "", status.Errorf(codes.Internal, "error getting role: %v", err)
+	}
+
+	return authz.Role(role), nil
+}
+
 // isUserSelfUpdating is used to prevent if the user is trying to update their own role
 func isUserSelfUpdating(ctx context.Context, subject, inviteeEmail string) error {
 	if subject != "" {
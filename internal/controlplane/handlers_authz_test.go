@@ -803,6 +803,143 @@ func TestAssignRole(t *testing.T) {
 	}
 }
 
+func TestAssignCustomRole(t *testing.T) {
+	t.Parallel()
+
+	customRole := authz.Role("data_source_manager")
+	projectId := uuid.New()
+	projectIdString := projectId.String()
+
+	tests := []struct {
+		name          string
+		role          string
+		inviteeEmail  string
+		subject       string
+		roleDefined   bool
+		expectedError string
+	}{
+		{
+			name:          "error when the role is not defined in the project",
+			role:          "data_source_admin",
+			subject:       "user",
+			expectedError: "invalid role data_source_admin",
+		},
+		{
+			name:          "error when inviting by email",
+			role:          customRole.String(),
+			inviteeEmail:  "other@example.com",
+			roleDefined:   true,
+			expectedError: "custom roles cannot be granted through invitations",
+		},
+		{
+			name:        "request with subject creates role assignment",
+			role:        customRole.String(),
+			subject:     "user",
+			roleDefined: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			user := openid.New()
+			assert.NoError(t, user.Set("email", "user@test.com"))
+
+			ctx := context.Background()
+			ctx = authjwt.WithAuthTokenContext(ctx, user)
+			ctx = engcontext.WithEntityContext(ctx, &engcontext.EntityContext{
+				Project: engcontext.Project{ID: projectId},
+			})
+
+			mockStore := mockdb.NewMockStore(ctrl)
+			mockStore.EXPECT().BeginTransaction().AnyTimes()
+			mockStore.EXPECT().GetQuerierWithTransaction(gomock.Any()).AnyTimes()
+			mockStore.EXPECT().Commit(gomock.Any()).AnyTimes()
+			mockStore.EXPECT().Rollback(gomock.Any()).AnyTimes()
+			if tc.roleDefined {
+				mockStore.EXPECT().GetCustomRoleByName(gomock.Any(), db.GetCustomRoleByNameParams{
+					ProjectID: projectId,
+					Name:      tc.role,
+				}).Return(db.CustomRole{Name: tc.role}, nil)
+				mockStore.EXPECT().GetProjectByID(gomock.Any(), projectId).Return(db.Project{ID: projectId}, nil)
+			} else {
+				mockStore.EXPECT().GetCustomRoleByName(gomock.Any(), gomock.Any()).Return(db.CustomRole{}, sql.ErrNoRows)
+			}
+
+			mockRoleService := mockroles.NewMockRoleService(ctrl)
+			if tc.expectedError == "" {
+				mockRoleService.EXPECT().CreateRoleAssignment(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+					projectId, tc.subject, customRole).Return(&minder.RoleAssignment{
+					Role:    customRole.String(),
+					Project: &projectIdString,
+				}, nil)
+			}
+
+			server := &Server{
+				featureFlags: &flags.FakeClient{},
+				roles:        mockRoleService,
+				store:        mockStore,
+			}
+
+			response, err := server.AssignRole(ctx, &minder.AssignRoleRequest{
+				Context: &minder.Context{
+					Project: &projectIdString,
+				},
+				RoleAssignment: &minder.RoleAssignment{
+					Role:    tc.role,
+					Subject: tc.subject,
+					Email:   tc.inviteeEmail,
+				},
+			})
+
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, customRole.String(), response.RoleAssignment.Role)
+		})
+	}
+}
+
+func TestListRoles(t *testing.T) {
+	t.Parallel()
+
+	projectId := uuid.New()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mockdb.NewMockStore(ctrl)
+	mockStore.EXPECT().ListCustomRolesByProject(gomock.Any(), projectId).Return([]db.CustomRole{{
+		ProjectID:   projectId,
+		Name:        "data_source_manager",
+		DisplayName: "Data Source Manager",
+		Permissions: []string{"data_source_create", "data_source_get"},
+	}}, nil)
+
+	ctx := engcontext.WithEntityContext(context.Background(), &engcontext.EntityContext{
+		Project: engcontext.Project{ID: projectId},
+	})
+
+	server := &Server{store: mockStore}
+	resp, err := server.ListRoles(ctx, &minder.ListRolesRequest{})
+	require.NoError(t, err)
+
+	// built-in roles come first
+	require.Len(t, resp.Roles, len(authz.AllRolesSorted)+1)
+	require.Equal(t, authz.RoleAdmin.String(), resp.Roles[0].Name)
+	require.Empty(t, resp.Roles[0].Permissions)
+	custom := resp.Roles[len(resp.Roles)-1]
+	require.Equal(t, "data_source_manager", custom.Name)
+	require.Equal(t, []string{"data_source_create", "data_source_get"}, custom.Permissions)
+}
+
 func TestRemoveRole(t *testing.T) {
 	t.Parallel()
 
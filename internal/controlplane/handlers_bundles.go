@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controlplane
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"golang.org/x/mod/semver"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/internal/engine/engcontext"
+	"github.com/mindersec/minder/internal/logger"
+	"github.com/mindersec/minder/internal/marketplaces"
+	sub "github.com/mindersec/minder/internal/marketplaces/subscriptions"
+	"github.com/mindersec/minder/internal/util"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/mindersec/minder/pkg/mindpak"
+)
+
+// ListBundleSubscriptions lists the bundle subscriptions of a project and
+// its child projects, along with the version of each bundle in the
+// marketplace
+func (s *Server) ListBundleSubscriptions(
+	ctx context.Context,
+	_ *minderv1.ListBundleSubscriptionsRequest,
+) (*minderv1.ListBundleSubscriptionsResponse, error) {
+	entityCtx := engcontext.EntityFromContext(ctx)
+	err := entityCtx.ValidateProject(ctx, s.store)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "error in entity context: %v", err)
+	}
+
+	projects, err := s.store.GetChildrenProjects(ctx, entityCtx.Project.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error getting child projects: %v", err)
+	}
+	projectIDs := make([]uuid.UUID, 0, len(projects))
+	for _, project := range projects {
+		projectIDs = append(projectIDs, project.ID)
+	}
+
+	rows, err := s.store.ListSubscriptionsByProjects(ctx, projectIDs)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error listing bundle subscriptions: %v", err)
+	}
+
+	resp := &minderv1.ListBundleSubscriptionsResponse{
+		Subscriptions: make([]*minderv1.BundleSubscription, 0, len(rows)),
+	}
+	for _, row := range rows {
+		latest, err := s.marketplace.BundleVersion(mindpak.ID(row.Namespace, row.Name))
+		if err != nil && !errors.Is(err, marketplaces.ErrUnknownBundle) {
+			return nil, status.Errorf(codes.Internal, "error getting bundle version: %v", err)
+		}
+		resp.Subscriptions = append(resp.Subscriptions, &minderv1.BundleSubscription{
+			Project:        row.ProjectID.String(),
+			Namespace:      row.Namespace,
+			Name:           row.Name,
+			CurrentVersion: row.CurrentVersion,
+			LatestVersion:  latest,
+			Outdated:       bundleOutdated(row.CurrentVersion, latest),
+		})
+	}
+
+	return resp, nil
+}
+
+// UpgradeBundle upgrades the subscription of a project to the version of
+// the bundle in the marketplace. All the changes are applied in a single
+// transaction.
+func (s *Server) UpgradeBundle(
+	ctx context.Context,
+	in *minderv1.UpgradeBundleRequest,
+) (*minderv1.UpgradeBundleResponse, error) {
+	entityCtx := engcontext.EntityFromContext(ctx)
+	err := entityCtx.ValidateProject(ctx, s.store)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "error in entity context: %v", err)
+	}
+	projectID := entityCtx.Project.ID
+	bundleID := mindpak.ID(in.GetNamespace(), in.GetName())
+
+	tx, err := s.store.BeginTransaction()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error starting transaction: %v", err)
+	}
+	defer s.store.Rollback(tx)
+
+	qtx := s.store.GetQuerierWithTransaction(tx)
+
+	_, err = qtx.GetSubscriptionByProjectBundle(ctx, db.GetSubscriptionByProjectBundleParams{
+		Namespace: bundleID.Namespace,
+		Name:      bundleID.Name,
+		ProjectID: projectID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, util.UserVisibleError(codes.NotFound, "project is not subscribed to bundle %s", bundleID)
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "error getting bundle subscription: %v", err)
+	}
+
+	plan, err := s.marketplace.Upgrade(ctx, projectID, bundleID, sub.UpgradeOptions{
+		DryRun:                in.GetDryRun(),
+		OverwriteLocalChanges: in.GetOverwriteLocalChanges(),
+	}, qtx)
+	if errors.Is(err, marketplaces.ErrUnknownBundle) {
+		return nil, util.UserVisibleError(codes.NotFound, "bundle %s is not in the marketplace", bundleID)
+	} else if errors.Is(err, sub.ErrContentConflict) {
+		return nil, util.UserVisibleError(codes.FailedPrecondition, "%s", err.Error())
+	} else if errors.Is(err, sub.ErrLocalChanges) {
+		return nil, util.UserVisibleError(codes.FailedPrecondition,
+			"%s; set overwrite_local_changes to discard the changes", err.Error())
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "error upgrading bundle: %v", err)
+	}
+
+	if !in.GetDryRun() {
+		if err := s.store.Commit(tx); err != nil {
+			return nil, status.Errorf(codes.Internal, "error committing transaction: %v", err)
+		}
+
+		// Telemetry logging
+		logger.BusinessRecord(ctx).Project = projectID
+		zerolog.Ctx(ctx).Info().
+			Str("bundle", bundleID.String()).
+			Str("from_version", plan.FromVersion).
+			Str("to_version", plan.ToVersion).
+			Msg("bundle subscription upgraded")
+	}
+
+	resp := &minderv1.UpgradeBundleResponse{
+		FromVersion: plan.FromVersion,
+		ToVersion:   plan.ToVersion,
+		Changes:     make([]*minderv1.BundleContentChange, 0, len(plan.Changes)),
+		DryRun:      in.GetDryRun(),
+	}
+	for _, change := range plan.Changes {
+		resp.Changes = append(resp.Changes, &minderv1.BundleContentChange{
+			Type:            string(change.Type),
+			Name:            change.Name,
+			Change:          string(change.Kind),
+			LocallyModified: change.LocallyModified,
+		})
+	}
+	return resp, nil
+}
+
+// bundleOutdated tells whether the version of a bundle installed in a project
+// is older than the version in the marketplace. Versions which are not
+// semantic versions are only compared for equality.
+func bundleOutdated(current string, latest string) bool {
+	if latest == "" {
+		return false
+	}
+	currentSemver := "v" + strings.TrimPrefix(current, "v")
+	latestSemver := "v" + strings.TrimPrefix(latest, "v")
+	if semver.IsValid(currentSemver) && semver.IsValid(latestSemver) {
+		return semver.Compare(currentSemver, latestSemver) < 0
+	}
+	return current != latest
+}
@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controlplane
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	mockdb "github.com/mindersec/minder/database/mock"
+	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/internal/engine/engcontext"
+	"github.com/mindersec/minder/internal/marketplaces"
+	mockmarketplace "github.com/mindersec/minder/internal/marketplaces/mock"
+	sub "github.com/mindersec/minder/internal/marketplaces/subscriptions"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/mindersec/minder/pkg/mindpak"
+)
+
+func TestListBundleSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	projectID := uuid.New()
+	childID := uuid.New()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mockdb.NewMockStore(ctrl)
+	mockStore.EXPECT().GetProjectByID(gomock.Any(), projectID).
+		Return(db.Project{ID: projectID}, nil).AnyTimes()
+	mockStore.EXPECT().GetChildrenProjects(gomock.Any(), projectID).
+		Return([]db.GetChildrenProjectsRow{{ID: projectID}, {ID: childID}}, nil)
+	mockStore.EXPECT().ListSubscriptionsByProjects(gomock.Any(), []uuid.UUID{projectID, childID}).
+		Return([]db.ListSubscriptionsByProjectsRow{
+			{ProjectID: projectID, Namespace: "stacklok", Name: "healthcheck", CurrentVersion: "1.2.0"},
+			{ProjectID: childID, Namespace: "stacklok", Name: "healthcheck", CurrentVersion: "1.0.0"},
+			{ProjectID: childID, Namespace: "stacklok", Name: "removed", CurrentVersion: "1.0.0"},
+		}, nil)
+
+	mockMarketplace := mockmarketplace.NewMockMarketplace(ctrl)
+	mockMarketplace.EXPECT().BundleVersion(mindpak.ID("stacklok", "healthcheck")).
+		Return("1.2.0", nil).Times(2)
+	mockMarketplace.EXPECT().BundleVersion(mindpak.ID("stacklok", "removed")).
+		Return("", fmt.Errorf("%w: stacklok/removed", marketplaces.ErrUnknownBundle))
+
+	srv := newDefaultServer(t, mockStore, nil, nil, nil)
+	srv.marketplace = mockMarketplace
+
+	ctx := engcontext.WithEntityContext(context.Background(), &engcontext.EntityContext{
+		Project: engcontext.Project{ID: projectID},
+	})
+
+	resp, err := srv.ListBundleSubscriptions(ctx, &minderv1.ListBundleSubscriptionsRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.GetSubscriptions(), 3)
+
+	require.False(t, resp.GetSubscriptions()[0].GetOutdated())
+	require.Equal(t, childID.String(), resp.GetSubscriptions()[1].GetProject())
+	require.Equal(t, "1.2.0", resp.GetSubscriptions()[1].GetLatestVersion())
+	require.True(t, resp.GetSubscriptions()[1].GetOutdated())
+	require.Empty(t, resp.GetSubscriptions()[2].GetLatestVersion())
+	require.False(t, resp.GetSubscriptions()[2].GetOutdated())
+}
+
+func TestUpgradeBundle(t *testing.T) {
+	t.Parallel()
+
+	projectID := uuid.New()
+	bundleID := mindpak.ID("stacklok", "healthcheck")
+	plan := &sub.UpgradePlan{
+		FromVersion: "1.0.0",
+		ToVersion:   "1.1.0",
+		Changes: []sub.ContentChange{
+			{Type: sub.ContentTypeRuleType, Name: "secret_scanning", Kind: sub.ChangeUpdated, LocallyModified: true},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		dryRun            bool
+		overwrite         bool
+		setupMocks        func(*mockdb.MockStore, *mockmarketplace.MockMarketplace)
+		expectedErrorCode codes.Code
+	}{
+		{
+			name: "upgrade is committed",
+			setupMocks: func(store *mockdb.MockStore, mp *mockmarketplace.MockMarketplace) {
+				store.EXPECT().GetSubscriptionByProjectBundle(gomock.Any(), gomock.Any()).
+					Return(db.Subscription{}, nil)
+				mp.EXPECT().Upgrade(gomock.Any(), projectID, bundleID, sub.UpgradeOptions{}, gomock.Any()).
+					Return(plan, nil)
+				store.EXPECT().Commit(gomock.Any())
+			},
+			expectedErrorCode: codes.OK,
+		},
+		{
+			name:   "dry run is not committed",
+			dryRun: true,
+			setupMocks: func(store *mockdb.MockStore, mp *mockmarketplace.MockMarketplace) {
+				store.EXPECT().GetSubscriptionByProjectBundle(gomock.Any(), gomock.Any()).
+					Return(db.Subscription{}, nil)
+				mp.EXPECT().Upgrade(gomock.Any(), projectID, bundleID, sub.UpgradeOptions{DryRun: true}, gomock.Any()).
+					Return(plan, nil)
+			},
+			expectedErrorCode: codes.OK,
+		},
+		{
+			name:      "local changes are overwritten on request",
+			overwrite: true,
+			setupMocks: func(store *mockdb.MockStore, mp *mockmarketplace.MockMarketplace) {
+				store.EXPECT().GetSubscriptionByProjectBundle(gomock.Any(), gomock.Any()).
+					Return(db.Subscription{}, nil)
+				mp.EXPECT().Upgrade(gomock.Any(), projectID, bundleID,
+					sub.UpgradeOptions{OverwriteLocalChanges: true}, gomock.Any()).
+					Return(plan, nil)
+				store.EXPECT().Commit(gomock.Any())
+			},
+			expectedErrorCode: codes.OK,
+		},
+		{
+			name: "project not subscribed",
+			setupMocks: func(store *mockdb.MockStore, _ *mockmarketplace.MockMarketplace) {
+				store.EXPECT().GetSubscriptionByProjectBundle(gomock.Any(), gomock.Any()).
+					Return(db.Subscription{}, sql.ErrNoRows)
+			},
+			expectedErrorCode: codes.NotFound,
+		},
+		{
+			name: "content conflict",
+			setupMocks: func(store *mockdb.MockStore, mp *mockmarketplace.MockMarketplace) {
+				store.EXPECT().GetSubscriptionByProjectBundle(gomock.Any(), gomock.Any()).
+					Return(db.Subscription{}, nil)
+				mp.EXPECT().Upgrade(gomock.Any(), projectID, bundleID, sub.UpgradeOptions{}, gomock.Any()).
+					Return(nil, fmt.Errorf("error while upgrading subscription: %w", sub.ErrContentConflict))
+			},
+			expectedErrorCode: codes.FailedPrecondition,
+		},
+		{
+			name: "local changes are not committed",
+			setupMocks: func(store *mockdb.MockStore, mp *mockmarketplace.MockMarketplace) {
+				store.EXPECT().GetSubscriptionByProjectBundle(gomock.Any(), gomock.Any()).
+					Return(db.Subscription{}, nil)
+				mp.EXPECT().Upgrade(gomock.Any(), projectID, bundleID, sub.UpgradeOptions{}, gomock.Any()).
+					Return(nil, fmt.Errorf("error while upgrading subscription: %w", sub.ErrLocalChanges))
+			},
+			expectedErrorCode: codes.FailedPrecondition,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mockdb.NewMockStore(ctrl)
+			mockStore.EXPECT().GetProjectByID(gomock.Any(), projectID).
+				Return(db.Project{ID: projectID}, nil).AnyTimes()
+			mockStore.EXPECT().BeginTransaction()
+			mockStore.EXPECT().GetQuerierWithTransaction(gomock.Any()).Return(mockStore)
+			mockStore.EXPECT().Rollback(gomock.Any())
+			mockMarketplace := mockmarketplace.NewMockMarketplace(ctrl)
+			tt.setupMocks(mockStore, mockMarketplace)
+
+			srv := newDefaultServer(t, mockStore, nil, nil, nil)
+			srv.marketplace = mockMarketplace
+
+			ctx := engcontext.WithEntityContext(context.Background(), &engcontext.EntityContext{
+				Project: engcontext.Project{ID: projectID},
+			})
+
+			resp, err := srv.UpgradeBundle(ctx, &minderv1.UpgradeBundleRequest{
+				Namespace:             bundleID.Namespace,
+				Name:                  bundleID.Name,
+				DryRun:                tt.dryRun,
+				OverwriteLocalChanges: tt.overwrite,
+			})
+			if tt.expectedErrorCode != codes.OK {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedErrorCode, st.Code())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.dryRun, resp.GetDryRun())
+			require.Equal(t, "1.0.0", resp.GetFromVersion())
+			require.Equal(t, "1.1.0", resp.GetToVersion())
+			require.Len(t, resp.GetChanges(), 1)
+			require.Equal(t, "rule_type", resp.GetChanges()[0].GetType())
+			require.Equal(t, "updated", resp.GetChanges()[0].GetChange())
+			require.True(t, resp.GetChanges()[0].GetLocallyModified())
+		})
+	}
+}
+
+func TestBundleOutdated(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		current  string
+		latest   string
+		outdated bool
+	}{
+		{current: "1.0.0", latest: "1.1.0", outdated: true},
+		{current: "v1.10.0", latest: "1.9.0", outdated: false},
+		{current: "1.1.0", latest: "1.1.0", outdated: false},
+		{current: "2024-01", latest: "2024-02", outdated: true},
+		{current: "1.0.0", latest: "", outdated: false},
+	} {
+		require.Equal(t, tc.outdated, bundleOutdated(tc.current, tc.latest), "%s -> %s", tc.current, tc.latest)
+	}
+}
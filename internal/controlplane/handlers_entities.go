@@ -5,18 +5,20 @@ package controlplane
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
 
 	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/protobuf/types/known/structpb"
 
 	"github.com/mindersec/minder/internal/db"
 	"github.com/mindersec/minder/internal/engine/engcontext"
 	"github.com/mindersec/minder/internal/logger"
 	"github.com/mindersec/minder/internal/providers"
+	ghprop "github.com/mindersec/minder/internal/providers/github/properties"
 	"github.com/mindersec/minder/internal/reconcilers/messages"
 	"github.com/mindersec/minder/internal/util"
 	pb "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
@@ -26,8 +28,8 @@ import (
 
 // ReconcileEntityRegistration reconciles the registration of an entity.
 //
-// Currently, this method only supports repositories but is intended to be
-// generic and handle all types of entities.
+// Currently, this method supports repositories and organizations but is
+// intended to be generic and handle all types of entities.
 // Todo: Utilise for other entities when such are supported.
 func (s *Server) ReconcileEntityRegistration(
 	ctx context.Context,
@@ -40,13 +42,21 @@ func (s *Server) ReconcileEntityRegistration(
 
 	logger.BusinessRecord(ctx).Project = projectID
 
+	providerNameParam := in.GetContext().GetProvider()
+
 	// Todo: We don't support other entities yet. This should be updated when we do.
 	entityType := in.GetEntity()
-	if pb.EntityFromString(entityType) != pb.Entity_ENTITY_REPOSITORIES {
+	switch pb.EntityFromString(entityType) {
+	case pb.Entity_ENTITY_REPOSITORIES:
+	case pb.Entity_ENTITY_ORGANIZATION:
+		if err := s.reconcileOrganizationRegistration(ctx, &l, projectID, providerNameParam); err != nil {
+			return nil, err
+		}
+		return &pb.ReconcileEntityRegistrationResponse{}, nil
+	default:
 		return nil, util.UserVisibleError(codes.InvalidArgument, "entity type %s not supported", entityType)
 	}
 
-	providerNameParam := in.GetContext().GetProvider()
 	provs, errorProvs, err := s.providerManager.BulkInstantiateByTrait(
 		ctx, projectID, db.ProviderTypeRepoLister, providerNameParam)
 	if err != nil {
@@ -74,7 +84,16 @@ func (s *Server) ReconcileEntityRegistration(
 				continue
 			}
 
-			msg, err := createEntityMessage(ctx, &l, projectID, providerID, repo.Entity.GetEntity().GetProperties())
+			repoProps, err := properties.NewProperties(repo.Entity.GetEntity().GetProperties().AsMap())
+			if err != nil {
+				l.Error().Err(err).
+					Int64("repoID", repo.Repo.RepoId).
+					Str("providerName", providerT.Name).
+					Msg("error creating repository properties")
+				continue
+			}
+
+			msg, err := createEntityMessage(ctx, &l, projectID, providerID, pb.Entity_ENTITY_REPOSITORIES, repoProps)
 			if err != nil {
 				l.Error().Err(err).
 					Int64("repoID", repo.Repo.RepoId).
@@ -98,6 +117,99 @@ func (s *Server) ReconcileEntityRegistration(
 	return &pb.ReconcileEntityRegistrationResponse{}, nil
 }
 
+// reconcileOrganizationRegistration registers the organizations the GitHub
+// providers of the project are installed on. Providers installed on a
+// personal account are skipped.
+func (s *Server) reconcileOrganizationRegistration(
+	ctx context.Context,
+	l *zerolog.Logger,
+	projectID uuid.UUID,
+	providerName string,
+) error {
+	provs, errorProvs, err := s.providerManager.BulkInstantiateByTrait(
+		ctx, projectID, db.ProviderTypeGithub, providerName)
+	if err != nil {
+		pErr := providers.ErrProviderNotFoundBy{}
+		if errors.As(err, &pErr) {
+			return util.UserVisibleError(codes.NotFound, "no suitable provider found, please enroll a provider")
+		}
+		return providerError(err)
+	}
+
+	for providerID, providerT := range provs {
+		orgProps, err := s.organizationPropsForProvider(ctx, projectID, providerID, providerT.Name)
+		if err != nil {
+			l.Error().
+				Str("providerName", providerT.Name).
+				Str("projectID", projectID.String()).
+				Err(err).
+				Msg("error fetching organization for provider")
+			errorProvs = append(errorProvs, providerT.Name)
+			continue
+		}
+		if orgProps == nil {
+			l.Debug().Str("providerName", providerT.Name).Msg("provider is not installed on an organization")
+			continue
+		}
+
+		msg, err := createEntityMessage(ctx, l, projectID, providerID, pb.Entity_ENTITY_ORGANIZATION, orgProps)
+		if err != nil {
+			l.Error().Err(err).
+				Str("providerName", providerT.Name).
+				Msg("error creating registration entity message")
+			continue
+		}
+
+		if err := s.publishEntityMessage(l, msg); err != nil {
+			l.Error().Err(err).Str("messageID", msg.UUID).Msg("error publishing register entities message")
+		}
+	}
+
+	// If all providers failed, return an error
+	if len(errorProvs) > 0 && len(provs) == len(errorProvs) {
+		return util.UserVisibleError(codes.Internal, "cannot register entities for providers: %v", errorProvs)
+	}
+
+	return nil
+}
+
+// organizationPropsForProvider returns the properties identifying the
+// organization a GitHub provider acts on, or nil if the provider acts on a
+// personal account. GitHub App installations record the organization ID,
+// while OAuth tokens are optionally restricted to an organization login.
+func (s *Server) organizationPropsForProvider(
+	ctx context.Context,
+	projectID, providerID uuid.UUID,
+	providerName string,
+) (*properties.Properties, error) {
+	installation, err := s.store.GetInstallationIDByProviderID(ctx, uuid.NullUUID{UUID: providerID, Valid: true})
+	if err == nil {
+		if !installation.IsOrg {
+			return nil, nil
+		}
+		return properties.NewProperties(map[string]any{
+			properties.PropertyUpstreamID: properties.NumericalValueToUpstreamID(installation.OrganizationID),
+		})
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("error getting installation: %w", err)
+	}
+
+	token, err := s.store.GetAccessTokenByProjectID(ctx,
+		db.GetAccessTokenByProjectIDParams{Provider: providerName, ProjectID: projectID})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error getting access token: %w", err)
+	}
+	if !token.OwnerFilter.Valid || token.OwnerFilter.String == "" {
+		return nil, nil
+	}
+
+	return properties.NewProperties(map[string]any{
+		ghprop.OrgPropertyLogin: token.OwnerFilter.String,
+	})
+}
+
 func (s *Server) publishEntityMessage(l *zerolog.Logger, msg *message.Message) error {
 	l.Info().Str("messageID", msg.UUID).Msg("publishing register entities message for execution")
 	return s.evt.Publish(constants.TopicQueueReconcileEntityAdd, msg)
@@ -107,23 +219,19 @@ func createEntityMessage(
 	ctx context.Context,
 	l *zerolog.Logger,
 	projectID, providerID uuid.UUID,
-	props *structpb.Struct,
+	entityType pb.Entity,
+	props *properties.Properties,
 ) (*message.Message, error) {
 	msg := message.NewMessage(uuid.New().String(), nil)
 	msg.SetContext(ctx)
 
-	repoProps, err := properties.NewProperties(props.AsMap())
-	if err != nil {
-		return nil, err
-	}
-
 	event := messages.NewMinderEvent().
 		WithProjectID(projectID).
 		WithProviderID(providerID).
-		WithEntityType(pb.Entity_ENTITY_REPOSITORIES).
-		WithProperties(repoProps)
+		WithEntityType(entityType).
+		WithProperties(props)
 
-	err = event.ToMessage(msg)
+	err := event.ToMessage(msg)
 	if err != nil {
 		l.Error().Err(err).Msg("error marshalling register entities message")
 		return nil, err
@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controlplane
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/internal/engine/entities"
+	"github.com/mindersec/minder/internal/history/sarif"
+	"github.com/mindersec/minder/internal/util"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/mindersec/minder/pkg/ruletypes"
+)
+
+const (
+	exportFormatSARIF = "sarif"
+	exportErrMsg      = "error exporting evaluation results"
+)
+
+// ExportEvaluationResults exports the current findings of a project, that
+// is the rules whose latest evaluation on an entity failed or errored, as a
+// SARIF log.
+func (s *Server) ExportEvaluationResults(
+	ctx context.Context,
+	in *minderv1.ExportEvaluationResultsRequest,
+) (*minderv1.ExportEvaluationResultsResponse, error) {
+	projectID := GetProjectID(ctx)
+
+	if in.GetFormat() != "" && in.GetFormat() != exportFormatSARIF {
+		return nil, util.UserVisibleError(codes.InvalidArgument, "unsupported export format %q", in.GetFormat())
+	}
+
+	entityTypes := make([]db.Entities, 0, len(in.GetEntityType()))
+	for _, et := range in.GetEntityType() {
+		entity := minderv1.EntityFromString(et)
+		if entity == minderv1.Entity_ENTITY_UNSPECIFIED {
+			return nil, util.UserVisibleError(codes.InvalidArgument, "invalid entity type %s, please use one of %s",
+				et, entities.KnownTypesCSV())
+		}
+		entityTypes = append(entityTypes, entities.EntityTypeToDB(entity))
+	}
+
+	profiles, err := s.store.GetProfileStatusByProject(ctx, projectID)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg(exportErrMsg)
+		return nil, status.Error(codes.Internal, exportErrMsg)
+	}
+
+	var findings []*minderv1.RuleEvaluationStatus
+	ruleTypes := map[uuid.UUID]*minderv1.RuleType{}
+	for _, profile := range profiles {
+		if len(in.GetProfileName()) > 0 && !slices.Contains(in.GetProfileName(), profile.Name) {
+			continue
+		}
+
+		// This lists the latest evaluation of each rule and entity
+		evals, err := s.store.ListRuleEvaluationsByProfileId(ctx, db.ListRuleEvaluationsByProfileIdParams{
+			ProfileID: profile.ID,
+		})
+		if err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Str("profile_id", profile.ID.String()).Msg(exportErrMsg)
+			return nil, status.Error(codes.Internal, exportErrMsg)
+		}
+
+		for _, eval := range evals {
+			if eval.EvalStatus != db.EvalStatusTypesFailure && eval.EvalStatus != db.EvalStatusTypesError {
+				continue
+			}
+			if len(entityTypes) > 0 && !slices.Contains(entityTypes, eval.EntityType) {
+				continue
+			}
+
+			finding, err := s.evaluationFinding(ctx, profile.ID, eval, ruleTypes)
+			if err != nil {
+				zerolog.Ctx(ctx).Error().Err(err).Str("entity_id", eval.EntityID.String()).Msg(exportErrMsg)
+				return nil, status.Error(codes.Internal, exportErrMsg)
+			}
+			findings = append(findings, finding)
+		}
+	}
+
+	rtList := make([]*minderv1.RuleType, 0, len(ruleTypes))
+	for _, rt := range ruleTypes {
+		rtList = append(rtList, rt)
+	}
+
+	out, err := json.Marshal(sarif.FromRuleEvaluations(findings, rtList))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error marshalling SARIF log: %v", err)
+	}
+	log := &structpb.Struct{}
+	if err := log.UnmarshalJSON(out); err != nil {
+		return nil, status.Errorf(codes.Internal, "error marshalling SARIF log: %v", err)
+	}
+
+	return &minderv1.ExportEvaluationResultsResponse{
+		Sarif: log,
+	}, nil
+}
+
+// evaluationFinding converts the latest evaluation of a rule on an entity
+// into a rule evaluation status, and caches the rule type it refers to.
+func (s *Server) evaluationFinding(
+	ctx context.Context,
+	profileID uuid.UUID,
+	eval db.ListRuleEvaluationsByProfileIdRow,
+	ruleTypes map[uuid.UUID]*minderv1.RuleType,
+) (*minderv1.RuleEvaluationStatus, error) {
+	if _, ok := ruleTypes[eval.RuleTypeID]; !ok {
+		dbRuleType, err := s.store.GetRuleTypeByID(ctx, eval.RuleTypeID)
+		if err != nil {
+			return nil, err
+		}
+		rt, err := ruletypes.RuleTypePBFromDB(&dbRuleType)
+		if err != nil {
+			return nil, err
+		}
+		ruleTypes[eval.RuleTypeID] = rt
+	}
+
+	entity, err := s.store.GetEntityByID(ctx, eval.EntityID)
+	if err != nil {
+		return nil, err
+	}
+
+	sev, err := dbSeverityToSeverity(eval.RuleTypeSeverityValue)
+	if err != nil {
+		zerolog.Ctx(ctx).Err(err).
+			Str("value", string(eval.RuleTypeSeverityValue)).
+			Msg("error converting severity will use defaults")
+	}
+
+	return &minderv1.RuleEvaluationStatus{
+		ProfileId:           profileID.String(),
+		RuleId:              eval.RuleTypeID.String(),
+		RuleTypeName:        eval.RuleTypeName,
+		RuleDescriptionName: eval.RuleName,
+		RuleDisplayName:     eval.RuleTypeDisplayName,
+		Entity:              string(eval.EntityType),
+		EntityInfo: map[string]string{
+			"name":        entity.Name,
+			"entity_id":   eval.EntityID.String(),
+			"entity_type": entities.EntityTypeFromDB(eval.EntityType).ToString(),
+			"provider":    eval.Provider,
+		},
+		Status:           string(eval.EvalStatus),
+		Details:          eval.EvalDetails,
+		Guidance:         eval.RuleTypeGuidance,
+		Severity:         sev,
+		LastUpdated:      timestamppb.New(eval.EvalLastUpdated),
+		RuleEvaluationId: eval.RuleEvaluationID.String(),
+	}, nil
+}
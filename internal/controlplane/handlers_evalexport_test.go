@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controlplane
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockdb "github.com/mindersec/minder/database/mock"
+	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/internal/engine/engcontext"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+func TestExportEvaluationResults(t *testing.T) {
+	t.Parallel()
+
+	projectID := uuid.New()
+	profileID := uuid.New()
+	ruleTypeID := uuid.New()
+	repoID := uuid.New()
+
+	evals := []db.ListRuleEvaluationsByProfileIdRow{
+		{
+			EvalStatus:            db.EvalStatusTypesFailure,
+			EvalDetails:           "disabled",
+			EntityType:            db.EntitiesRepository,
+			EntityID:              repoID,
+			RuleName:              "secrets",
+			RuleTypeName:          "secret_scanning",
+			RuleTypeID:            ruleTypeID,
+			RuleTypeSeverityValue: db.SeverityHigh,
+		},
+		{
+			EvalStatus:   db.EvalStatusTypesSuccess,
+			EntityType:   db.EntitiesRepository,
+			EntityID:     uuid.New(),
+			RuleName:     "secrets",
+			RuleTypeName: "secret_scanning",
+			RuleTypeID:   ruleTypeID,
+		},
+		{
+			EvalStatus:   db.EvalStatusTypesError,
+			EntityType:   db.EntitiesArtifact,
+			EntityID:     uuid.New(),
+			RuleName:     "signed",
+			RuleTypeName: "artifact_signature",
+			RuleTypeID:   uuid.New(),
+		},
+	}
+
+	tests := []struct {
+		name            string
+		req             *minderv1.ExportEvaluationResultsRequest
+		setupMock       func(*mockdb.MockStore)
+		expectedResults int
+		expectedErr     string
+	}{
+		{
+			name: "only current failures of the selected entity types are exported",
+			req: &minderv1.ExportEvaluationResultsRequest{
+				EntityType: []string{"repository"},
+			},
+			setupMock: func(mockStore *mockdb.MockStore) {
+				mockStore.EXPECT().GetProfileStatusByProject(gomock.Any(), projectID).
+					Return([]db.GetProfileStatusByProjectRow{{ID: profileID, Name: "default"}}, nil)
+				mockStore.EXPECT().ListRuleEvaluationsByProfileId(gomock.Any(), db.ListRuleEvaluationsByProfileIdParams{
+					ProfileID: profileID,
+				}).Return(evals, nil)
+				mockStore.EXPECT().GetRuleTypeByID(gomock.Any(), ruleTypeID).
+					Return(db.RuleType{
+						ID:         ruleTypeID,
+						Name:       "secret_scanning",
+						Guidance:   "Enable it",
+						Definition: []byte(`{}`),
+					}, nil)
+				mockStore.EXPECT().GetEntityByID(gomock.Any(), repoID).
+					Return(db.EntityInstance{ID: repoID, Name: "org/repo"}, nil)
+			},
+			expectedResults: 1,
+		},
+		{
+			name: "profiles can be filtered by name",
+			req: &minderv1.ExportEvaluationResultsRequest{
+				ProfileName: []string{"other"},
+			},
+			setupMock: func(mockStore *mockdb.MockStore) {
+				mockStore.EXPECT().GetProfileStatusByProject(gomock.Any(), projectID).
+					Return([]db.GetProfileStatusByProjectRow{{ID: profileID, Name: "default"}}, nil)
+			},
+			expectedResults: 0,
+		},
+		{
+			name: "unsupported format",
+			req: &minderv1.ExportEvaluationResultsRequest{
+				Format: "csv",
+			},
+			setupMock:   func(_ *mockdb.MockStore) {},
+			expectedErr: "unsupported export format",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mockdb.NewMockStore(ctrl)
+			tt.setupMock(mockStore)
+
+			server := Server{store: mockStore}
+			ctx := engcontext.WithEntityContext(context.Background(), &engcontext.EntityContext{
+				Project: engcontext.Project{ID: projectID},
+			})
+
+			resp, err := server.ExportEvaluationResults(ctx, tt.req)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			runs := resp.GetSarif().GetFields()["runs"].GetListValue().GetValues()
+			require.Len(t, runs, 1)
+			results := runs[0].GetStructValue().GetFields()["results"].GetListValue().GetValues()
+			require.Len(t, results, tt.expectedResults)
+			if tt.expectedResults > 0 {
+				result := results[0].GetStructValue().GetFields()
+				require.Equal(t, "secret_scanning", result["ruleId"].GetStringValue())
+				require.Equal(t, "fail", result["kind"].GetStringValue())
+			}
+		})
+	}
+}
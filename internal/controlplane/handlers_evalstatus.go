@@ -330,9 +330,15 @@ func (s *Server) ListEvaluationResults(
 	// Filter the profile and status lists to those in the reques params
 	profileList, profileStatusList = filterProfileLists(in, profileList, profileStatusList)
 
+	// Build an index of the rule exceptions currently in effect
+	exceptions, err := activeRuleExceptions(ctx, s.store, projectID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Do the final sort of all the data
 	entities, profileStatuses, statusByEntity, err := s.sortEntitiesEvaluationStatus(
-		ctx, s.store, profileList, profileStatusList, rtIndex, entIdIndex, entTypeIndex,
+		ctx, s.store, profileList, profileStatusList, rtIndex, entIdIndex, entTypeIndex, exceptions,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("sorting rule evaluations: %w", err)
@@ -352,6 +358,7 @@ func (s *Server) sortEntitiesEvaluationStatus(
 	profileList []db.ListProfilesByProjectIDAndLabelRow,
 	profileStatusList map[uuid.UUID]db.GetProfileStatusByProjectRow,
 	rtIndex, entIdIndex, entTypeIndex map[string]struct{},
+	exceptions map[string]*minderv1.RuleException,
 ) (
 	entities map[string]*minderv1.EntityTypedId,
 	profileStatuses map[uuid.UUID]*minderv1.ProfileStatus,
@@ -428,6 +435,7 @@ func (s *Server) sortEntitiesEvaluationStatus(
 				// A failure parsing the PR metadata points to a corrupt record. Log but don't err.
 				zerolog.Ctx(ctx).Error().Err(err).Msg("error building rule evaluation status")
 			} else {
+				stat.Exception = exceptions[ruleExceptionKey(p.Profile.ID, e.RuleName, e.EntityID)]
 				if _, ok := statusByEntity[entString]; !ok {
 					statusByEntity[entString] = make(map[uuid.UUID][]*minderv1.RuleEvaluationStatus)
 				}
@@ -739,6 +747,8 @@ func dbEntityToEntity(dbEnt db.Entities) minderv1.Entity {
 		return minderv1.Entity_ENTITY_TASK_RUN
 	case db.EntitiesBuild:
 		return minderv1.Entity_ENTITY_BUILD
+	case db.EntitiesOrganization:
+		return minderv1.Entity_ENTITY_ORGANIZATION
 	default:
 		return minderv1.Entity_ENTITY_UNSPECIFIED
 	}
@@ -148,6 +148,11 @@ func TestDBEntityToEntity(t *testing.T) {
 			input:  db.EntitiesBuild,
 			output: minderv1.Entity_ENTITY_BUILD,
 		},
+		{
+			name:   "organization",
+			input:  db.EntitiesOrganization,
+			output: minderv1.Entity_ENTITY_ORGANIZATION,
+		},
 		{
 			name:   "default",
 			input:  db.Entities("whatever"),
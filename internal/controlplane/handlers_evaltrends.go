@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controlplane
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/internal/util"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+const (
+	// defaultTrendsRange is the selection window used when the
+	// request does not specify a start time.
+	defaultTrendsRange = 30 * 24 * time.Hour
+	// maxTrendsRange bounds the selection window, as trends are
+	// computed by scanning the evaluation history.
+	maxTrendsRange = 366 * 24 * time.Hour
+	trendsErrMsg   = "error retrieving evaluation trends"
+)
+
+// GetEvaluationTrends returns evaluation counts per profile, rule type
+// or entity, bucketed by day or week, along with the mean time to
+// remediate failures.
+func (s *Server) GetEvaluationTrends(
+	ctx context.Context,
+	in *minderv1.GetEvaluationTrendsRequest,
+) (*minderv1.GetEvaluationTrendsResponse, error) {
+	projectID := GetProjectID(ctx)
+
+	groupBy, err := trendsGroupBy(in.GetGroupBy())
+	if err != nil {
+		return nil, err
+	}
+	bucket, err := trendsBucket(in.GetBucket())
+	if err != nil {
+		return nil, err
+	}
+
+	// evaluation times are stored in UTC without time zone
+	to := time.Now().UTC()
+	if in.GetTo() != nil {
+		to = in.GetTo().AsTime().UTC()
+	}
+	from := to.Add(-defaultTrendsRange)
+	if in.GetFrom() != nil {
+		from = in.GetFrom().AsTime().UTC()
+	}
+	if !from.Before(to) {
+		return nil, util.UserVisibleError(codes.InvalidArgument, "from must be before to")
+	}
+	if to.Sub(from) > maxTrendsRange {
+		return nil, util.UserVisibleError(codes.InvalidArgument,
+			"time range must not exceed %d days", int(maxTrendsRange.Hours()/24))
+	}
+
+	rows, err := s.store.ListEvaluationTrends(ctx, db.ListEvaluationTrendsParams{
+		Bucket:    bucket,
+		GroupBy:   groupBy,
+		ProjectID: projectID,
+		Fromts:    from,
+		Tots:      to,
+	})
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg(trendsErrMsg)
+		return nil, status.Error(codes.Internal, trendsErrMsg)
+	}
+
+	remediations, err := s.store.ListEvaluationRemediationTimes(ctx, db.ListEvaluationRemediationTimesParams{
+		GroupBy:   groupBy,
+		ProjectID: projectID,
+		Fromts:    from,
+		Tots:      to,
+	})
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg(trendsErrMsg)
+		return nil, status.Error(codes.Internal, trendsErrMsg)
+	}
+
+	return &minderv1.GetEvaluationTrendsResponse{
+		Series: fromEvaluationTrendsRows(rows, remediations),
+	}, nil
+}
+
+func trendsGroupBy(groupBy minderv1.GetEvaluationTrendsRequest_GroupBy) (string, error) {
+	switch groupBy {
+	case minderv1.GetEvaluationTrendsRequest_GROUP_BY_UNSPECIFIED,
+		minderv1.GetEvaluationTrendsRequest_GROUP_BY_PROFILE:
+		return "profile", nil
+	case minderv1.GetEvaluationTrendsRequest_GROUP_BY_RULE_TYPE:
+		return "rule_type", nil
+	case minderv1.GetEvaluationTrendsRequest_GROUP_BY_ENTITY:
+		return "entity", nil
+	default:
+		return "", util.UserVisibleError(codes.InvalidArgument, "invalid group by: %s", groupBy)
+	}
+}
+
+func trendsBucket(bucket minderv1.GetEvaluationTrendsRequest_Bucket) (string, error) {
+	switch bucket {
+	case minderv1.GetEvaluationTrendsRequest_BUCKET_UNSPECIFIED,
+		minderv1.GetEvaluationTrendsRequest_BUCKET_DAY:
+		return "day", nil
+	case minderv1.GetEvaluationTrendsRequest_BUCKET_WEEK:
+		return "week", nil
+	default:
+		return "", util.UserVisibleError(codes.InvalidArgument, "invalid bucket: %s", bucket)
+	}
+}
+
+// fromEvaluationTrendsRows builds one series per group, preserving the
+// ordering of the trends rows.
+func fromEvaluationTrendsRows(
+	rows []db.ListEvaluationTrendsRow,
+	remediations []db.ListEvaluationRemediationTimesRow,
+) []*minderv1.EvaluationTrendSeries {
+	series := make([]*minderv1.EvaluationTrendSeries, 0)
+	byGroup := make(map[uuid.UUID]*minderv1.EvaluationTrendSeries)
+
+	getSeries := func(id uuid.UUID, name string) *minderv1.EvaluationTrendSeries {
+		if s, ok := byGroup[id]; ok {
+			return s
+		}
+		s := &minderv1.EvaluationTrendSeries{
+			Id:      id.String(),
+			Name:    name,
+			Buckets: []*minderv1.EvaluationTrendBucket{},
+		}
+		byGroup[id] = s
+		series = append(series, s)
+		return s
+	}
+
+	for _, row := range rows {
+		s := getSeries(row.GroupID, row.GroupName)
+		s.Buckets = append(s.Buckets, &minderv1.EvaluationTrendBucket{
+			Start:   timestamppb.New(row.BucketStart),
+			Success: row.SuccessCount,
+			Failure: row.FailureCount,
+			Error:   row.ErrorCount,
+			Skipped: row.SkippedCount,
+			Exempt:  row.ExemptCount,
+		})
+	}
+
+	for _, rem := range remediations {
+		if rem.RemediatedCount == 0 {
+			continue
+		}
+		s := getSeries(rem.GroupID, rem.GroupName)
+		s.RemediatedCount = rem.RemediatedCount
+		s.MeanTimeToRemediateSeconds = int64(math.Round(rem.MeanSeconds))
+	}
+
+	return series
+}
@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controlplane
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	mockdb "github.com/mindersec/minder/database/mock"
+	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/internal/engine/engcontext"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+func TestGetEvaluationTrends(t *testing.T) {
+	t.Parallel()
+
+	projectID := uuid.New()
+	profileA := uuid.New()
+	profileB := uuid.New()
+	to := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	day1 := to.Add(-48 * time.Hour)
+	day2 := to.Add(-24 * time.Hour)
+
+	tests := []struct {
+		name              string
+		request           *minderv1.GetEvaluationTrendsRequest
+		setupMocks        func(*mockdb.MockStore)
+		checkf            func(*testing.T, *minderv1.GetEvaluationTrendsResponse)
+		expectedErrorCode codes.Code
+	}{
+		{
+			name: "defaults to daily buckets per profile",
+			request: &minderv1.GetEvaluationTrendsRequest{
+				To: timestamppb.New(to),
+			},
+			setupMocks: func(store *mockdb.MockStore) {
+				store.EXPECT().ListEvaluationTrends(gomock.Any(), db.ListEvaluationTrendsParams{
+					Bucket:    "day",
+					GroupBy:   "profile",
+					ProjectID: projectID,
+					Fromts:    to.Add(-defaultTrendsRange),
+					Tots:      to,
+				}).Return([]db.ListEvaluationTrendsRow{
+					{BucketStart: day1, GroupID: profileA, GroupName: "a", SuccessCount: 1, FailureCount: 2},
+					{BucketStart: day2, GroupID: profileA, GroupName: "a", SuccessCount: 3},
+					{BucketStart: day2, GroupID: profileB, GroupName: "b", ErrorCount: 1, ExemptCount: 1},
+				}, nil)
+				store.EXPECT().ListEvaluationRemediationTimes(gomock.Any(), db.ListEvaluationRemediationTimesParams{
+					GroupBy:   "profile",
+					ProjectID: projectID,
+					Fromts:    to.Add(-defaultTrendsRange),
+					Tots:      to,
+				}).Return([]db.ListEvaluationRemediationTimesRow{
+					{GroupID: profileA, GroupName: "a", RemediatedCount: 2, MeanSeconds: 3600.4},
+				}, nil)
+			},
+			checkf: func(t *testing.T, resp *minderv1.GetEvaluationTrendsResponse) {
+				t.Helper()
+				require.Len(t, resp.GetSeries(), 2)
+
+				a := resp.GetSeries()[0]
+				require.Equal(t, profileA.String(), a.GetId())
+				require.Equal(t, "a", a.GetName())
+				require.Len(t, a.GetBuckets(), 2)
+				require.Equal(t, day1, a.GetBuckets()[0].GetStart().AsTime())
+				require.Equal(t, int64(1), a.GetBuckets()[0].GetSuccess())
+				require.Equal(t, int64(2), a.GetBuckets()[0].GetFailure())
+				require.Equal(t, int64(3), a.GetBuckets()[1].GetSuccess())
+				require.Equal(t, int64(2), a.GetRemediatedCount())
+				require.Equal(t, int64(3600), a.GetMeanTimeToRemediateSeconds())
+
+				b := resp.GetSeries()[1]
+				require.Equal(t, profileB.String(), b.GetId())
+				require.Len(t, b.GetBuckets(), 1)
+				require.Equal(t, int64(1), b.GetBuckets()[0].GetError())
+				require.Equal(t, int64(1), b.GetBuckets()[0].GetExempt())
+				require.Zero(t, b.GetRemediatedCount())
+				require.Zero(t, b.GetMeanTimeToRemediateSeconds())
+			},
+			expectedErrorCode: codes.OK,
+		},
+		{
+			name: "weekly buckets per rule type",
+			request: &minderv1.GetEvaluationTrendsRequest{
+				GroupBy: minderv1.GetEvaluationTrendsRequest_GROUP_BY_RULE_TYPE,
+				Bucket:  minderv1.GetEvaluationTrendsRequest_BUCKET_WEEK,
+				From:    timestamppb.New(day1),
+				To:      timestamppb.New(to),
+			},
+			setupMocks: func(store *mockdb.MockStore) {
+				store.EXPECT().ListEvaluationTrends(gomock.Any(), db.ListEvaluationTrendsParams{
+					Bucket:    "week",
+					GroupBy:   "rule_type",
+					ProjectID: projectID,
+					Fromts:    day1,
+					Tots:      to,
+				}).Return(nil, nil)
+				store.EXPECT().ListEvaluationRemediationTimes(gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+			},
+			checkf: func(t *testing.T, resp *minderv1.GetEvaluationTrendsResponse) {
+				t.Helper()
+				require.Empty(t, resp.GetSeries())
+			},
+			expectedErrorCode: codes.OK,
+		},
+		{
+			name: "from after to",
+			request: &minderv1.GetEvaluationTrendsRequest{
+				From: timestamppb.New(to),
+				To:   timestamppb.New(day1),
+			},
+			expectedErrorCode: codes.InvalidArgument,
+		},
+		{
+			name: "range too large",
+			request: &minderv1.GetEvaluationTrendsRequest{
+				From: timestamppb.New(to.Add(-maxTrendsRange - time.Hour)),
+				To:   timestamppb.New(to),
+			},
+			expectedErrorCode: codes.InvalidArgument,
+		},
+		{
+			name: "invalid group by",
+			request: &minderv1.GetEvaluationTrendsRequest{
+				GroupBy: minderv1.GetEvaluationTrendsRequest_GroupBy(42),
+			},
+			expectedErrorCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mockdb.NewMockStore(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStore)
+			}
+
+			srv := newDefaultServer(t, mockStore, nil, nil, nil)
+
+			ctx := engcontext.WithEntityContext(context.Background(), &engcontext.EntityContext{
+				Project: engcontext.Project{ID: projectID},
+			})
+
+			resp, err := srv.GetEvaluationTrends(ctx, tt.request)
+			if tt.expectedErrorCode != codes.OK {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedErrorCode, st.Code())
+				return
+			}
+
+			require.NoError(t, err)
+			tt.checkf(t, resp)
+		})
+	}
+}
@@ -74,6 +74,14 @@ func (s *Server) GetAuthorizationURL(ctx context.Context,
 		!flags.Bool(ctx, s.featureFlags, flags.GitLabProvider) {
 		return nil, util.UserVisibleError(codes.Unimplemented, "GitLab provider is not enabled")
 	}
+	if providerClass == string(db.ProviderClassBitbucket) &&
+		!flags.Bool(ctx, s.featureFlags, flags.BitbucketProvider) {
+		return nil, util.UserVisibleError(codes.Unimplemented, "Bitbucket provider is not enabled")
+	}
+	if providerClass == string(db.ProviderClassOci) &&
+		!flags.Bool(ctx, s.featureFlags, flags.OCIProvider) {
+		return nil, util.UserVisibleError(codes.Unimplemented, "OCI provider is not enabled")
+	}
 
 	// Telemetry logging
 	logger.BusinessRecord(ctx).Provider = providerName
@@ -556,7 +564,8 @@ func (s *Server) StoreProviderToken(ctx context.Context,
 	}
 
 	// validate token
-	err = s.providerAuthManager.ValidateCredentials(ctx, provider.Class, in.AccessToken)
+	err = s.providerAuthManager.ValidateCredentials(ctx, provider.Class,
+		credentials.NewOAuth2TokenCredential(in.AccessToken))
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid token provided: %v", err)
 	}
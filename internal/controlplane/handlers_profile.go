@@ -8,8 +8,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
-	"strings"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
@@ -35,6 +35,8 @@ type contextKey string
 
 const requestKey contextKey = "request"
 
+const inheritedProfileErrMsg = "profile is inherited from a parent project and can only be modified there"
+
 // CreateProfile creates a profile for a project
 func (s *Server) CreateProfile(ctx context.Context,
 	cpr *minderv1.CreateProfileRequest) (*minderv1.CreateProfileResponse, error) {
@@ -69,6 +71,43 @@ func (s *Server) CreateProfile(ctx context.Context,
 	return resp, nil
 }
 
+// PreviewProfile evaluates a profile which has not been created yet against
+// the entities of the project, without recording the results or running
+// any remediation or alert
+func (s *Server) PreviewProfile(ctx context.Context,
+	in *minderv1.PreviewProfileRequest) (*minderv1.PreviewProfileResponse, error) {
+	profile := in.GetProfile()
+	if err := profile.Validate(); err != nil {
+		if errors.Is(err, minderv1.ErrValidationFailed) {
+			return nil, util.UserVisibleError(codes.InvalidArgument, "Couldn't preview profile: %s", err)
+		}
+		return nil, status.Errorf(codes.InvalidArgument, "invalid profile: %v", err)
+	}
+
+	entityCtx := engcontext.EntityFromContext(ctx)
+
+	// validate that project is valid and exist in the db
+	err := entityCtx.ValidateProject(ctx, s.store)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "error in entity context: %v", err)
+	}
+
+	resp, err := s.previewer.PreviewProfile(
+		ctx, entityCtx.Project.ID, profile, in.GetSelection(), int(in.GetMaxEntities()))
+	if err != nil {
+		// validation errors are already meaningful
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "error previewing profile: %v", err)
+	}
+
+	// Telemetry logging
+	logger.BusinessRecord(ctx).Project = entityCtx.Project.ID
+
+	return resp, nil
+}
+
 // DeleteProfile is a method to delete a profile
 func (s *Server) DeleteProfile(ctx context.Context,
 	in *minderv1.DeleteProfileRequest) (*minderv1.DeleteProfileResponse, error) {
@@ -90,6 +129,9 @@ func (s *Server) DeleteProfile(ctx context.Context,
 	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
+			if _, err := s.getInheritedProfileByID(ctx, entityCtx.Project.ID, parsedProfileID); err == nil {
+				return nil, util.UserVisibleError(codes.FailedPrecondition, inheritedProfileErrMsg)
+			}
 			return nil, status.Error(codes.NotFound, "profile not found")
 		}
 		return nil, status.Errorf(codes.Internal, "failed to get profile: %s", err)
@@ -141,20 +183,38 @@ func (s *Server) ListProfiles(ctx context.Context,
 
 	var resp minderv1.ListProfilesResponse
 	resp.Profiles = make([]*minderv1.Profile, 0, len(profiles))
-	profileMap := prof.MergeDatabaseListIntoProfiles(profiles)
-
-	// Sort the profiles by name to get a consistent order. This is important for UI.
-	profileNames := make([]string, 0, len(profileMap))
-	for prfName := range profileMap {
-		profileNames = append(profileNames, prfName)
+	for _, profile := range prof.MergeDatabaseListIntoProfiles(profiles) {
+		resp.Profiles = append(resp.Profiles, profile)
 	}
-	sort.Strings(profileNames)
 
-	for _, prfName := range profileNames {
-		profile := profileMap[prfName]
-		resp.Profiles = append(resp.Profiles, profile)
+	// Profiles inherited from the parent projects are listed as well. They
+	// are merged per project, as their names may collide with ours.
+	parents, err := s.getParentProjects(ctx, entityCtx.Project.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Unknown, "failed to get profiles: %s", err)
+	}
+	for _, parentID := range parents {
+		listParams.ProjectID = parentID
+		parentProfiles, err := s.store.ListProfilesByProjectIDAndLabel(ctx, listParams)
+		if err != nil {
+			return nil, status.Errorf(codes.Unknown, "failed to get profiles: %s", err)
+		}
+		inherited := make([]db.ListProfilesByProjectIDAndLabelRow, 0, len(parentProfiles))
+		for _, row := range parentProfiles {
+			if row.Profile.Inherited {
+				inherited = append(inherited, row)
+			}
+		}
+		for _, profile := range prof.MergeDatabaseListIntoProfiles(inherited) {
+			resp.Profiles = append(resp.Profiles, profile)
+		}
 	}
 
+	// Sort the profiles by name to get a consistent order. This is important for UI.
+	sort.SliceStable(resp.Profiles, func(i, j int) bool {
+		return resp.Profiles[i].GetName() < resp.Profiles[j].GetName()
+	})
+
 	// Telemetry logging
 	logger.BusinessRecord(ctx).Project = entityCtx.Project.ID
 
@@ -178,8 +238,11 @@ func (s *Server) GetProfileById(ctx context.Context,
 	}
 
 	profile, err := getProfilePBFromDB(ctx, parsedProfileID, entityCtx, s.store)
+	if errors.Is(err, sql.ErrNoRows) || errors.Is(err, errProfileNotFound) {
+		profile, err = s.getInheritedProfileByID(ctx, entityCtx.Project.ID, parsedProfileID)
+	}
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) || strings.Contains(err.Error(), "not found") {
+		if errors.Is(err, sql.ErrNoRows) {
 			return nil, util.UserVisibleError(codes.NotFound, "profile not found")
 		}
 
@@ -226,7 +289,15 @@ func (s *Server) GetProfileByName(ctx context.Context,
 	logger.BusinessRecord(ctx).Project = entityCtx.Project.ID
 
 	if len(pols) == 0 {
-		return nil, util.UserVisibleError(codes.NotFound, "profile %q not found", in.Name)
+		profile, err := s.getInheritedProfileByName(ctx, entityCtx.Project.ID, in.Name)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, util.UserVisibleError(codes.NotFound, "profile %q not found", in.Name)
+		} else if err != nil {
+			return nil, err
+		}
+		return &minderv1.GetProfileByNameResponse{
+			Profile: profile,
+		}, nil
 	} else if len(pols) > 1 {
 		return nil, fmt.Errorf("expected only one profile, got %d", len(pols))
 	}
@@ -241,6 +312,10 @@ func (s *Server) GetProfileByName(ctx context.Context,
 	return nil, util.UserVisibleError(codes.NotFound, "profile %q not found", in.Name)
 }
 
+// errProfileNotFound is returned when a project has no profile with the
+// requested ID
+var errProfileNotFound = errors.New("profile not found")
+
 func getProfilePBFromDB(
 	ctx context.Context,
 	id uuid.UUID,
@@ -257,7 +332,7 @@ func getProfilePBFromDB(
 
 	pols := prof.MergeDatabaseGetIntoProfiles(profiles)
 	if len(pols) == 0 {
-		return nil, fmt.Errorf("profile not found")
+		return nil, errProfileNotFound
 	} else if len(pols) > 1 {
 		return nil, fmt.Errorf("expected only one profile, got %d", len(pols))
 	}
@@ -267,7 +342,129 @@ func getProfilePBFromDB(
 		return profile, nil
 	}
 
-	return nil, fmt.Errorf("profile not found")
+	return nil, errProfileNotFound
+}
+
+// getParentProjects returns the ancestors of a project, from the nearest
+// to the farthest
+func (s *Server) getParentProjects(ctx context.Context, projectID uuid.UUID) ([]uuid.UUID, error) {
+	projects, err := s.store.GetParentProjects(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting parent projects: %w", err)
+	}
+
+	parents := make([]uuid.UUID, 0, len(projects))
+	for _, id := range projects {
+		if id != projectID {
+			parents = append(parents, id)
+		}
+	}
+	return parents, nil
+}
+
+// getInheritedProfileByID returns the profile with the given ID if the
+// project inherits it from one of its parents, or sql.ErrNoRows otherwise
+func (s *Server) getInheritedProfileByID(
+	ctx context.Context,
+	projectID uuid.UUID,
+	id uuid.UUID,
+) (*minderv1.Profile, error) {
+	parents, err := s.getParentProjects(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, parentID := range parents {
+		profiles, err := s.store.GetProfileByProjectAndID(ctx, db.GetProfileByProjectAndIDParams{
+			ProjectID: parentID,
+			ID:        id,
+		})
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		if len(profiles) == 0 {
+			continue
+		}
+		if !profiles[0].Profile.Inherited {
+			return nil, sql.ErrNoRows
+		}
+		for _, profile := range prof.MergeDatabaseGetIntoProfiles(profiles) {
+			return profile, nil
+		}
+	}
+
+	return nil, sql.ErrNoRows
+}
+
+// getInheritedProfileByName returns the profile with the given name which
+// the project inherits from its nearest parent, or sql.ErrNoRows if there is
+// none
+func (s *Server) getInheritedProfileByName(
+	ctx context.Context,
+	projectID uuid.UUID,
+	name string,
+) (*minderv1.Profile, error) {
+	parents, err := s.getParentProjects(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, parentID := range parents {
+		profiles, err := s.store.GetProfileByProjectAndName(ctx, db.GetProfileByProjectAndNameParams{
+			ProjectID: parentID,
+			Name:      name,
+		})
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		if len(profiles) == 0 || !profiles[0].Profile.Inherited {
+			continue
+		}
+		for _, profile := range prof.MergeDatabaseGetByNameIntoProfiles(profiles) {
+			return profile, nil
+		}
+	}
+
+	return nil, sql.ErrNoRows
+}
+
+// getInheritedProfileStatus returns the status of a profile which the
+// project inherits, computed from the project's own entities
+func (s *Server) getInheritedProfileStatus(
+	ctx context.Context,
+	projectID uuid.UUID,
+	profile *minderv1.Profile,
+) (*db.ListInheritedProfileStatusByProjectRow, error) {
+	parents, err := s.getParentProjects(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := s.store.ListInheritedProfileStatusByProject(ctx, db.ListInheritedProfileStatusByProjectParams{
+		ProjectID: projectID,
+		ParentIds: parents,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, st := range statuses {
+		if st.ID.String() == profile.GetId() {
+			return &st, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+// isInheritedProfile returns true if the profile to modify is not owned by
+// the project, but inherited from one of its parents
+func (s *Server) isInheritedProfile(ctx context.Context, projectID uuid.UUID, profile *minderv1.Profile) bool {
+	var err error
+	if id, perr := uuid.Parse(profile.GetId()); perr == nil {
+		_, err = s.getInheritedProfileByID(ctx, projectID, id)
+	} else {
+		_, err = s.getInheritedProfileByName(ctx, projectID, profile.GetName())
+	}
+	return err == nil
 }
 
 // TODO: We need to replace this with a more generic method that can be used for all entities
@@ -491,6 +688,29 @@ func (s *Server) GetProfileStatusByProject(ctx context.Context,
 		})
 	}
 
+	// Inherited profiles are reported with the status of our own entities
+	parents, err := s.getParentProjects(ctx, entityCtx.Project.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Unknown, "failed to get profile status: %s", err)
+	}
+	if len(parents) > 0 {
+		inherited, err := s.store.ListInheritedProfileStatusByProject(ctx, db.ListInheritedProfileStatusByProjectParams{
+			ProjectID: entityCtx.Project.ID,
+			ParentIds: parents,
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Unknown, "failed to get inherited profile status: %s", err)
+		}
+		for _, dbstat := range inherited {
+			res.ProfileStatus = append(res.ProfileStatus, &minderv1.ProfileStatus{
+				ProfileId:     dbstat.ID.String(),
+				ProfileName:   dbstat.Name,
+				ProfileStatus: string(dbstat.ProfileStatus),
+				Inherited:     true,
+			})
+		}
+	}
+
 	// Telemetry logging
 	logger.BusinessRecord(ctx).Project = entityCtx.Project.ID
 
@@ -520,6 +740,9 @@ func (s *Server) PatchProfile(ctx context.Context, ppr *minderv1.PatchProfileReq
 		return s.profiles.PatchProfile(ctx, entityCtx.Project.ID, profileID, patch, ppr.GetUpdateMask(), qtx)
 	})
 	if err != nil {
+		if _, ierr := s.getInheritedProfileByID(ctx, entityCtx.Project.ID, profileID); ierr == nil {
+			return nil, util.UserVisibleError(codes.FailedPrecondition, inheritedProfileErrMsg)
+		}
 		// assumption: service layer sets sensible errors
 		return nil, err
 	}
@@ -546,6 +769,9 @@ func (s *Server) UpdateProfile(ctx context.Context,
 	})
 
 	if err != nil {
+		if s.isInheritedProfile(ctx, entityCtx.Project.ID, in) {
+			return nil, util.UserVisibleError(codes.FailedPrecondition, inheritedProfileErrMsg)
+		}
 		// assumption: service layer sets sensible errors
 		return nil, err
 	}
@@ -573,10 +799,24 @@ func (s *Server) GetProfileStatusByName(
 		return nil, status.Errorf(codes.InvalidArgument, "error in entity context: %v", err)
 	}
 
+	inherited := false
 	dbProfileStatus, err := s.store.GetProfileStatusByNameAndProject(ctx, db.GetProfileStatusByNameAndProjectParams{
-		ProjectID: engcontext.EntityFromContext(ctx).Project.ID,
+		ProjectID: entityCtx.Project.ID,
 		Name:      in.Name,
 	})
+	if errors.Is(err, sql.ErrNoRows) {
+		// The profile may be inherited from a parent project
+		var profile *minderv1.Profile
+		profile, err = s.getInheritedProfileByName(ctx, entityCtx.Project.ID, in.Name)
+		if err == nil {
+			var st *db.ListInheritedProfileStatusByProjectRow
+			st, err = s.getInheritedProfileStatus(ctx, entityCtx.Project.ID, profile)
+			if err == nil {
+				dbProfileStatus = db.GetProfileStatusByNameAndProjectRow(*st)
+				inherited = true
+			}
+		}
+	}
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, util.UserVisibleError(codes.NotFound, "profile %q status not found", in.Name)
@@ -585,7 +825,7 @@ func (s *Server) GetProfileStatusByName(
 	}
 
 	resp, err := s.processProfileStatusByName(ctx, dbProfileStatus.Name, dbProfileStatus.ID,
-		timestamppb.New(dbProfileStatus.LastUpdated), string(dbProfileStatus.ProfileStatus), in)
+		timestamppb.New(dbProfileStatus.LastUpdated), string(dbProfileStatus.ProfileStatus), inherited, in)
 	if err != nil {
 		return nil, err
 	}
@@ -613,10 +853,25 @@ func (s *Server) GetProfileStatusById(
 		return nil, status.Errorf(codes.InvalidArgument, "error in entity context: %v", err)
 	}
 
+	profileID := uuid.MustParse(in.Id)
+	inherited := false
 	dbProfileStatus, err := s.store.GetProfileStatusByIdAndProject(ctx, db.GetProfileStatusByIdAndProjectParams{
-		ProjectID: engcontext.EntityFromContext(ctx).Project.ID,
-		ID:        uuid.MustParse(in.Id),
+		ProjectID: entityCtx.Project.ID,
+		ID:        profileID,
 	})
+	if errors.Is(err, sql.ErrNoRows) {
+		// The profile may be inherited from a parent project
+		var profile *minderv1.Profile
+		profile, err = s.getInheritedProfileByID(ctx, entityCtx.Project.ID, profileID)
+		if err == nil {
+			var st *db.ListInheritedProfileStatusByProjectRow
+			st, err = s.getInheritedProfileStatus(ctx, entityCtx.Project.ID, profile)
+			if err == nil {
+				dbProfileStatus = db.GetProfileStatusByIdAndProjectRow(*st)
+				inherited = true
+			}
+		}
+	}
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, util.UserVisibleError(codes.NotFound, "profile %q status not found", in.Id)
@@ -625,7 +880,7 @@ func (s *Server) GetProfileStatusById(
 	}
 
 	resp, err := s.processProfileStatusById(ctx, dbProfileStatus.Name, dbProfileStatus.ID,
-		timestamppb.New(dbProfileStatus.LastUpdated), string(dbProfileStatus.ProfileStatus), in)
+		timestamppb.New(dbProfileStatus.LastUpdated), string(dbProfileStatus.ProfileStatus), inherited, in)
 	if err != nil {
 		return nil, err
 	}
@@ -653,6 +908,7 @@ func (s *Server) processProfileStatusByName(
 	profileID uuid.UUID,
 	lastUpdated *timestamppb.Timestamp,
 	profileStatus string,
+	inherited bool,
 	req *minderv1.GetProfileStatusByNameRequest,
 ) (*minderv1.GetProfileStatusByNameResponse, error) {
 	var ruleEvaluationStatuses []*minderv1.RuleEvaluationStatus
@@ -676,6 +932,15 @@ func (s *Server) processProfileStatusByName(
 		if err != nil && !errors.Is(err, sql.ErrNoRows) {
 			return nil, status.Errorf(codes.Unknown, "failed to list rule evaluation status: %s", err)
 		}
+		if inherited {
+			// A parent's profile is evaluated on the entities of every
+			// project which inherits it, only report our own
+			projectID := engcontext.EntityFromContext(ctx).Project.ID
+			dbRuleEvaluationStatuses = slices.DeleteFunc(dbRuleEvaluationStatuses,
+				func(rs db.ListRuleEvaluationsByProfileIdRow) bool {
+					return rs.ProjectID != projectID
+				})
+		}
 
 		ruleEvaluationStatuses = s.getRuleEvaluationStatuses(
 			ctx, dbRuleEvaluationStatuses, profileID.String(),
@@ -693,6 +958,7 @@ func (s *Server) processProfileStatusByName(
 			ProfileName:   profileName,
 			ProfileStatus: profileStatus,
 			LastUpdated:   lastUpdated,
+			Inherited:     inherited,
 		},
 		RuleEvaluationStatus: ruleEvaluationStatuses,
 	}, nil
@@ -704,6 +970,7 @@ func (s *Server) processProfileStatusById(
 	profileID uuid.UUID,
 	lastUpdated *timestamppb.Timestamp,
 	profileStatus string,
+	inherited bool,
 	req *minderv1.GetProfileStatusByIdRequest,
 ) (*minderv1.GetProfileStatusByIdResponse, error) {
 	var ruleEvaluationStatuses []*minderv1.RuleEvaluationStatus
@@ -728,6 +995,15 @@ func (s *Server) processProfileStatusById(
 		if err != nil && !errors.Is(err, sql.ErrNoRows) {
 			return nil, status.Errorf(codes.Unknown, "failed to list rule evaluation status: %s", err)
 		}
+		if inherited {
+			// A parent's profile is evaluated on the entities of every
+			// project which inherits it, only report our own
+			projectID := engcontext.EntityFromContext(ctx).Project.ID
+			dbRuleEvaluationStatuses = slices.DeleteFunc(dbRuleEvaluationStatuses,
+				func(rs db.ListRuleEvaluationsByProfileIdRow) bool {
+					return rs.ProjectID != projectID
+				})
+		}
 
 		ruleEvaluationStatuses = s.getRuleEvaluationStatuses(
 			ctx, dbRuleEvaluationStatuses, profileID.String(),
@@ -745,6 +1021,7 @@ func (s *Server) processProfileStatusById(
 			ProfileName:   profileName,
 			ProfileStatus: profileStatus,
 			LastUpdated:   lastUpdated,
+			Inherited:     inherited,
 		},
 		RuleEvaluationStatus: ruleEvaluationStatuses,
 	}, nil
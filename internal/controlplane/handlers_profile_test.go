@@ -14,19 +14,28 @@ import (
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/structpb"
 
+	mockdb "github.com/mindersec/minder/database/mock"
 	"github.com/mindersec/minder/internal/db"
 	"github.com/mindersec/minder/internal/db/embedded"
 	"github.com/mindersec/minder/internal/engine/engcontext"
+	mockengine "github.com/mindersec/minder/internal/engine/mock"
+	propSvc "github.com/mindersec/minder/internal/entities/properties/service"
+	mockprops "github.com/mindersec/minder/internal/entities/properties/service/mock"
 	stubeventer "github.com/mindersec/minder/internal/events/stubs"
 	"github.com/mindersec/minder/internal/providers"
 	"github.com/mindersec/minder/internal/util"
+	"github.com/mindersec/minder/internal/util/ptr"
 	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
 	"github.com/mindersec/minder/pkg/engine/selectors"
 	"github.com/mindersec/minder/pkg/profiles"
+	mock_profiles "github.com/mindersec/minder/pkg/profiles/mock"
 )
 
 //nolint:gocyclo
@@ -1730,3 +1739,393 @@ func TestGetProfileByName(t *testing.T) {
 		})
 	}
 }
+
+func TestPreviewProfile(t *testing.T) {
+	t.Parallel()
+
+	projectID := uuid.New()
+	validProfile := func() *minderv1.Profile {
+		return &minderv1.Profile{
+			Name: "preview",
+			Repository: []*minderv1.Profile_Rule{{
+				Type: "secret_scanning",
+				Def:  &structpb.Struct{},
+			}},
+		}
+	}
+	selection := []*minderv1.Profile_Selector{{
+		Entity:   "repository",
+		Selector: "repository.name.startsWith('stacklok/')",
+	}}
+
+	tests := []struct {
+		name              string
+		request           *minderv1.PreviewProfileRequest
+		setupPreviewer    func(*mockengine.MockProfilePreviewer)
+		expectedErrorCode codes.Code
+	}{
+		{
+			name: "happy path",
+			request: &minderv1.PreviewProfileRequest{
+				Profile:     validProfile(),
+				Selection:   selection,
+				MaxEntities: 10,
+			},
+			setupPreviewer: func(previewer *mockengine.MockProfilePreviewer) {
+				previewer.EXPECT().PreviewProfile(gomock.Any(), projectID, gomock.Any(), gomock.Len(1), 10).
+					Return(&minderv1.PreviewProfileResponse{
+						Results: []*minderv1.ProfilePreviewEntityResult{{
+							EntityName: "stacklok/minder",
+							Rules: []*minderv1.ProfilePreviewRuleResult{{
+								RuleName: "secret_scanning",
+								RuleType: "secret_scanning",
+								Status:   "failure",
+							}},
+						}},
+					}, nil)
+			},
+			expectedErrorCode: codes.OK,
+		},
+		{
+			name: "invalid profile",
+			request: &minderv1.PreviewProfileRequest{
+				Profile: &minderv1.Profile{},
+			},
+			expectedErrorCode: codes.InvalidArgument,
+		},
+		{
+			name: "validation error from the previewer",
+			request: &minderv1.PreviewProfileRequest{
+				Profile: validProfile(),
+			},
+			setupPreviewer: func(previewer *mockengine.MockProfilePreviewer) {
+				previewer.EXPECT().PreviewProfile(gomock.Any(), projectID, gomock.Any(), gomock.Len(0), 0).
+					Return(nil, util.UserVisibleError(codes.InvalidArgument, "cannot find rule type"))
+			},
+			expectedErrorCode: codes.InvalidArgument,
+		},
+		{
+			name: "internal error from the previewer",
+			request: &minderv1.PreviewProfileRequest{
+				Profile: validProfile(),
+			},
+			setupPreviewer: func(previewer *mockengine.MockProfilePreviewer) {
+				previewer.EXPECT().PreviewProfile(gomock.Any(), projectID, gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("boom"))
+			},
+			expectedErrorCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mockdb.NewMockStore(ctrl)
+			mockStore.EXPECT().GetProjectByID(gomock.Any(), projectID).
+				Return(db.Project{ID: projectID}, nil).AnyTimes()
+			previewer := mockengine.NewMockProfilePreviewer(ctrl)
+			if tt.setupPreviewer != nil {
+				tt.setupPreviewer(previewer)
+			}
+
+			s := &Server{
+				store:     mockStore,
+				previewer: previewer,
+			}
+
+			ctx := engcontext.WithEntityContext(context.Background(), &engcontext.EntityContext{
+				Project: engcontext.Project{ID: projectID},
+			})
+
+			resp, err := s.PreviewProfile(ctx, tt.request)
+			if tt.expectedErrorCode != codes.OK {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedErrorCode, st.Code())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, resp.GetResults(), 1)
+			require.Equal(t, "failure", resp.GetResults()[0].GetRules()[0].GetStatus())
+		})
+	}
+}
+
+func TestInheritedProfiles(t *testing.T) {
+	t.Parallel()
+
+	parentID := uuid.New()
+	childID := uuid.New()
+	inheritedProfile := db.Profile{
+		ID:        uuid.New(),
+		Name:      "baseline",
+		ProjectID: parentID,
+		Inherited: true,
+	}
+
+	childEntityID := uuid.New()
+	inheritedStatus := []db.ListInheritedProfileStatusByProjectRow{{
+		ID:            inheritedProfile.ID,
+		Name:          inheritedProfile.Name,
+		ProfileStatus: db.EvalStatusTypesFailure,
+	}}
+	// the parent's profile is evaluated on the entities of every project
+	// which inherits it
+	evaluations := []db.ListRuleEvaluationsByProfileIdRow{
+		{EntityID: childEntityID, ProjectID: childID, EvalStatus: db.EvalStatusTypesFailure},
+		{EntityID: uuid.New(), ProjectID: uuid.New(), EvalStatus: db.EvalStatusTypesSuccess},
+	}
+	notFound := util.UserVisibleError(codes.NotFound, "profile not found")
+
+	tests := []struct {
+		name          string
+		setupMock     func(*mockdb.MockStore)
+		setupProps    func(*mockprops.MockPropertiesService)
+		setupProfiles func(*mock_profiles.MockProfileService)
+		call          func(context.Context, *Server) (any, error)
+		wantErr       string
+		check         func(*testing.T, any)
+	}{
+		{
+			name: "inherited profile statuses are reported",
+			setupMock: func(mockStore *mockdb.MockStore) {
+				mockStore.EXPECT().GetProfileStatusByProject(gomock.Any(), childID).
+					Return([]db.GetProfileStatusByProjectRow{{
+						ID:            uuid.New(),
+						Name:          "local",
+						ProfileStatus: db.EvalStatusTypesSuccess,
+					}}, nil)
+				mockStore.EXPECT().ListInheritedProfileStatusByProject(gomock.Any(),
+					db.ListInheritedProfileStatusByProjectParams{
+						ProjectID: childID,
+						ParentIds: []uuid.UUID{parentID},
+					}).Return([]db.ListInheritedProfileStatusByProjectRow{{
+					ID:            inheritedProfile.ID,
+					Name:          inheritedProfile.Name,
+					ProfileStatus: db.EvalStatusTypesFailure,
+				}}, nil)
+			},
+			call: func(ctx context.Context, s *Server) (any, error) {
+				return s.GetProfileStatusByProject(ctx, &minderv1.GetProfileStatusByProjectRequest{})
+			},
+			check: func(t *testing.T, res any) {
+				t.Helper()
+				statuses := res.(*minderv1.GetProfileStatusByProjectResponse).GetProfileStatus()
+				require.Len(t, statuses, 2)
+				require.False(t, statuses[0].GetInherited())
+				require.Equal(t, "baseline", statuses[1].GetProfileName())
+				require.Equal(t, "failure", statuses[1].GetProfileStatus())
+				require.True(t, statuses[1].GetInherited())
+			},
+		},
+		{
+			name: "inherited profile can be viewed",
+			setupMock: func(mockStore *mockdb.MockStore) {
+				mockStore.EXPECT().GetProfileByProjectAndName(gomock.Any(), db.GetProfileByProjectAndNameParams{
+					ProjectID: childID,
+					Name:      "baseline",
+				}).Return(nil, nil)
+				mockStore.EXPECT().GetProfileByProjectAndName(gomock.Any(), db.GetProfileByProjectAndNameParams{
+					ProjectID: parentID,
+					Name:      "baseline",
+				}).Return([]db.GetProfileByProjectAndNameRow{{Profile: inheritedProfile}}, nil)
+			},
+			call: func(ctx context.Context, s *Server) (any, error) {
+				return s.GetProfileByName(ctx, &minderv1.GetProfileByNameRequest{Name: "baseline"})
+			},
+			check: func(t *testing.T, res any) {
+				t.Helper()
+				profile := res.(*minderv1.GetProfileByNameResponse).GetProfile()
+				require.Equal(t, inheritedProfile.ID.String(), profile.GetId())
+				require.Equal(t, parentID.String(), profile.GetContext().GetProject())
+				require.True(t, profile.GetInherited())
+			},
+		},
+		{
+			name: "inherited profile cannot be deleted",
+			setupMock: func(mockStore *mockdb.MockStore) {
+				mockStore.EXPECT().GetProfileByID(gomock.Any(), db.GetProfileByIDParams{
+					ProjectID: childID,
+					ID:        inheritedProfile.ID,
+				}).Return(db.Profile{}, sql.ErrNoRows)
+				mockStore.EXPECT().GetProfileByProjectAndID(gomock.Any(), db.GetProfileByProjectAndIDParams{
+					ProjectID: parentID,
+					ID:        inheritedProfile.ID,
+				}).Return([]db.GetProfileByProjectAndIDRow{{Profile: inheritedProfile}}, nil)
+			},
+			call: func(ctx context.Context, s *Server) (any, error) {
+				return s.DeleteProfile(ctx, &minderv1.DeleteProfileRequest{Id: inheritedProfile.ID.String()})
+			},
+			wantErr: "can only be modified there",
+		},
+		{
+			name: "inherited profile status by name covers our own entities",
+			setupMock: func(mockStore *mockdb.MockStore) {
+				mockStore.EXPECT().GetProfileStatusByNameAndProject(gomock.Any(), db.GetProfileStatusByNameAndProjectParams{
+					ProjectID: childID,
+					Name:      "baseline",
+				}).Return(db.GetProfileStatusByNameAndProjectRow{}, sql.ErrNoRows)
+				mockStore.EXPECT().GetProfileByProjectAndName(gomock.Any(), db.GetProfileByProjectAndNameParams{
+					ProjectID: parentID,
+					Name:      "baseline",
+				}).Return([]db.GetProfileByProjectAndNameRow{{Profile: inheritedProfile}}, nil)
+				mockStore.EXPECT().ListInheritedProfileStatusByProject(gomock.Any(), gomock.Any()).
+					Return(inheritedStatus, nil)
+				mockStore.EXPECT().ListRuleEvaluationsByProfileId(gomock.Any(), gomock.Any()).
+					Return(evaluations, nil)
+			},
+			setupProps: func(mockProps *mockprops.MockPropertiesService) {
+				// only the entity of the child is looked up
+				mockProps.EXPECT().EntityWithPropertiesByID(gomock.Any(), childEntityID, gomock.Any()).
+					Return(nil, propSvc.ErrEntityNotFound)
+			},
+			call: func(ctx context.Context, s *Server) (any, error) {
+				return s.GetProfileStatusByName(ctx, &minderv1.GetProfileStatusByNameRequest{
+					Name: "baseline",
+					All:  true,
+				})
+			},
+			check: func(t *testing.T, res any) {
+				t.Helper()
+				st := res.(*minderv1.GetProfileStatusByNameResponse).GetProfileStatus()
+				require.Equal(t, inheritedProfile.ID.String(), st.GetProfileId())
+				require.Equal(t, "failure", st.GetProfileStatus())
+				require.True(t, st.GetInherited())
+			},
+		},
+		{
+			name: "inherited profile status by id",
+			setupMock: func(mockStore *mockdb.MockStore) {
+				mockStore.EXPECT().GetProfileStatusByIdAndProject(gomock.Any(), db.GetProfileStatusByIdAndProjectParams{
+					ProjectID: childID,
+					ID:        inheritedProfile.ID,
+				}).Return(db.GetProfileStatusByIdAndProjectRow{}, sql.ErrNoRows)
+				mockStore.EXPECT().GetProfileByProjectAndID(gomock.Any(), db.GetProfileByProjectAndIDParams{
+					ProjectID: parentID,
+					ID:        inheritedProfile.ID,
+				}).Return([]db.GetProfileByProjectAndIDRow{{Profile: inheritedProfile}}, nil)
+				mockStore.EXPECT().ListInheritedProfileStatusByProject(gomock.Any(), gomock.Any()).
+					Return(inheritedStatus, nil)
+			},
+			call: func(ctx context.Context, s *Server) (any, error) {
+				return s.GetProfileStatusById(ctx, &minderv1.GetProfileStatusByIdRequest{
+					Id: inheritedProfile.ID.String(),
+				})
+			},
+			check: func(t *testing.T, res any) {
+				t.Helper()
+				st := res.(*minderv1.GetProfileStatusByIdResponse).GetProfileStatus()
+				require.Equal(t, "baseline", st.GetProfileName())
+				require.Equal(t, "failure", st.GetProfileStatus())
+				require.True(t, st.GetInherited())
+			},
+		},
+		{
+			name: "status of a parent profile which isn't inherited is not found",
+			setupMock: func(mockStore *mockdb.MockStore) {
+				mockStore.EXPECT().GetProfileStatusByNameAndProject(gomock.Any(), gomock.Any()).
+					Return(db.GetProfileStatusByNameAndProjectRow{}, sql.ErrNoRows)
+				mockStore.EXPECT().GetProfileByProjectAndName(gomock.Any(), db.GetProfileByProjectAndNameParams{
+					ProjectID: parentID,
+					Name:      "baseline",
+				}).Return([]db.GetProfileByProjectAndNameRow{{Profile: db.Profile{
+					ID:        inheritedProfile.ID,
+					Name:      "baseline",
+					ProjectID: parentID,
+				}}}, nil)
+			},
+			call: func(ctx context.Context, s *Server) (any, error) {
+				return s.GetProfileStatusByName(ctx, &minderv1.GetProfileStatusByNameRequest{Name: "baseline"})
+			},
+			wantErr: "status not found",
+		},
+		{
+			name: "inherited profile cannot be updated",
+			setupMock: func(mockStore *mockdb.MockStore) {
+				mockStore.EXPECT().BeginTransaction()
+				mockStore.EXPECT().GetQuerierWithTransaction(gomock.Any()).Return(mockStore)
+				mockStore.EXPECT().Rollback(gomock.Any())
+				mockStore.EXPECT().GetProfileByProjectAndID(gomock.Any(), db.GetProfileByProjectAndIDParams{
+					ProjectID: parentID,
+					ID:        inheritedProfile.ID,
+				}).Return([]db.GetProfileByProjectAndIDRow{{Profile: inheritedProfile}}, nil)
+			},
+			setupProfiles: func(mockProfiles *mock_profiles.MockProfileService) {
+				mockProfiles.EXPECT().UpdateProfile(gomock.Any(), childID, uuid.Nil, gomock.Any(), gomock.Any()).
+					Return(nil, notFound)
+			},
+			call: func(ctx context.Context, s *Server) (any, error) {
+				return s.UpdateProfile(ctx, &minderv1.UpdateProfileRequest{
+					Profile: &minderv1.Profile{Id: ptr.Ptr(inheritedProfile.ID.String()), Name: "baseline"},
+				})
+			},
+			wantErr: "can only be modified there",
+		},
+		{
+			name: "inherited profile cannot be patched",
+			setupMock: func(mockStore *mockdb.MockStore) {
+				mockStore.EXPECT().BeginTransaction()
+				mockStore.EXPECT().GetQuerierWithTransaction(gomock.Any()).Return(mockStore)
+				mockStore.EXPECT().Rollback(gomock.Any())
+				mockStore.EXPECT().GetProfileByProjectAndID(gomock.Any(), db.GetProfileByProjectAndIDParams{
+					ProjectID: parentID,
+					ID:        inheritedProfile.ID,
+				}).Return([]db.GetProfileByProjectAndIDRow{{Profile: inheritedProfile}}, nil)
+			},
+			setupProfiles: func(mockProfiles *mock_profiles.MockProfileService) {
+				mockProfiles.EXPECT().PatchProfile(gomock.Any(), childID, inheritedProfile.ID,
+					gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, notFound)
+			},
+			call: func(ctx context.Context, s *Server) (any, error) {
+				return s.PatchProfile(ctx, &minderv1.PatchProfileRequest{
+					Id:    inheritedProfile.ID.String(),
+					Patch: &minderv1.Profile{Remediate: ptr.Ptr("on")},
+				})
+			},
+			wantErr: "can only be modified there",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mockdb.NewMockStore(ctrl)
+			mockStore.EXPECT().GetProjectByID(gomock.Any(), childID).
+				Return(db.Project{ID: childID}, nil).AnyTimes()
+			mockStore.EXPECT().GetParentProjects(gomock.Any(), childID).
+				Return([]uuid.UUID{childID, parentID}, nil).AnyTimes()
+			tt.setupMock(mockStore)
+			mockProps := mockprops.NewMockPropertiesService(ctrl)
+			if tt.setupProps != nil {
+				tt.setupProps(mockProps)
+			}
+			mockProfiles := mock_profiles.NewMockProfileService(ctrl)
+			if tt.setupProfiles != nil {
+				tt.setupProfiles(mockProfiles)
+			}
+
+			s := &Server{store: mockStore, props: mockProps, profiles: mockProfiles}
+			ctx := engcontext.WithEntityContext(context.Background(), &engcontext.EntityContext{
+				Project: engcontext.Project{ID: childID},
+			})
+
+			res, err := tt.call(ctx, s)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			tt.check(t, res)
+		})
+	}
+}
@@ -390,6 +390,39 @@ func (s *Server) PatchProject(
 			meta.Public.DisplayName = req.GetPatch().GetDisplayName()
 		case "description":
 			meta.Public.Description = req.GetPatch().GetDescription()
+		case "alert_webhook_url":
+			webhookURL := req.GetPatch().GetAlertWebhookUrl()
+			if webhookURL != "" {
+				if err := projects.ValidateAlertURL(ctx, webhookURL); err != nil {
+					return nil, util.UserVisibleError(codes.InvalidArgument, "invalid alert webhook URL: %v", err)
+				}
+			}
+			meta.Alerts.WebhookURL = webhookURL
+		case "alert_webhook_secret":
+			meta.Alerts.WebhookSecret, err = projects.EncryptOptional(s.cryptoEngine, req.GetPatch().GetAlertWebhookSecret())
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "error encrypting alert webhook secret: %v", err)
+			}
+		case "alert_chat_kind":
+			meta.Alerts.ChatKind = req.GetPatch().GetAlertChatKind()
+		case "alert_chat_webhook_url":
+			chatWebhookURL := req.GetPatch().GetAlertChatWebhookUrl()
+			if chatWebhookURL != "" {
+				if err := projects.ValidateAlertURL(ctx, chatWebhookURL); err != nil {
+					return nil, util.UserVisibleError(codes.InvalidArgument, "invalid alert chat webhook URL: %v", err)
+				}
+			}
+			meta.Alerts.ChatWebhookURL, err = projects.EncryptOptional(s.cryptoEngine, chatWebhookURL)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "error encrypting alert chat webhook URL: %v", err)
+			}
+		case "alert_chat_token":
+			meta.Alerts.ChatToken, err = projects.EncryptOptional(s.cryptoEngine, req.GetPatch().GetAlertChatToken())
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "error encrypting alert chat token: %v", err)
+			}
+		case "alert_chat_channel":
+			meta.Alerts.ChatChannel = req.GetPatch().GetAlertChatChannel()
 		}
 	}
 
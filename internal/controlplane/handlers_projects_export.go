@@ -0,0 +1,650 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controlplane
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/mindersec/minder/internal/audit"
+	"github.com/mindersec/minder/internal/authz"
+	datasourcessvc "github.com/mindersec/minder/internal/datasources/service"
+	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/internal/flags"
+	"github.com/mindersec/minder/internal/roles"
+	"github.com/mindersec/minder/internal/util"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	prof "github.com/mindersec/minder/pkg/profiles"
+	"github.com/mindersec/minder/pkg/ruletypes"
+)
+
+// projectExportVersion is the version of the project export format
+const projectExportVersion = "v1"
+
+// The types of the items of a project export
+const (
+	importTypeDataSource     = "data_source"
+	importTypeRuleType       = "rule_type"
+	importTypeProfile        = "profile"
+	importTypeCustomRole     = "custom_role"
+	importTypeRoleAssignment = "role_assignment"
+	importTypeProvider       = "provider"
+)
+
+// The outcomes of importing an item
+const (
+	importActionCreate   = "create"
+	importActionSkip     = "skip"
+	importActionConflict = "conflict"
+)
+
+// ExportProject returns a snapshot of the configuration of a project, which
+// can be imported in another project or Minder instance.  Provider
+// credentials are not exported, and other secrets in provider
+// configurations are redacted.
+func (s *Server) ExportProject(
+	ctx context.Context,
+	_ *minderv1.ExportProjectRequest,
+) (*minderv1.ExportProjectResponse, error) {
+	projectID := GetProjectID(ctx)
+
+	export, err := db.WithTransaction(s.store, func(qtx db.ExtendQuerier) (*minderv1.ProjectExport, error) {
+		return s.exportProject(ctx, qtx, projectID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Role assignments are kept in the authorization store
+	assignments, err := s.authzClient.AssignmentsToProject(ctx, projectID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error getting role assignments: %v", err)
+	}
+	for _, a := range assignments {
+		identity, err := s.idClient.Resolve(ctx, a.Subject)
+		if err != nil {
+			// The subject may still be resolved by its ID on import
+			zerolog.Ctx(ctx).Error().Err(err).Msg("error resolving identity")
+			a.DisplayName = a.Subject
+		} else {
+			a.DisplayName = identity.Human()
+		}
+		a.Project = nil
+		export.RoleAssignments = append(export.RoleAssignments, a)
+	}
+
+	return &minderv1.ExportProjectResponse{Export: export}, nil
+}
+
+func (s *Server) exportProject(
+	ctx context.Context, qtx db.ExtendQuerier, projectID uuid.UUID,
+) (*minderv1.ProjectExport, error) {
+	project, err := qtx.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error getting project: %v", err)
+	}
+
+	export := &minderv1.ProjectExport{
+		Version:    projectExportVersion,
+		ExportedAt: timestamppb.Now(),
+		Project:    project.Name,
+	}
+
+	dataSources, err := s.dataSourcesService.List(ctx, projectID, (&datasourcessvc.ReadOptions{}).WithTransaction(qtx))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error listing data sources: %v", err)
+	}
+	for _, ds := range dataSources {
+		ds.Id = ""
+		ds.Context = nil
+		// the headers of REST data sources often hold credentials
+		audit.RedactMessage(ds)
+	}
+	export.DataSources = dataSources
+
+	ruleTypes, err := qtx.ListRuleTypesByProject(ctx, projectID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error listing rule types: %v", err)
+	}
+	for idx := range ruleTypes {
+		rt, err := ruletypes.RuleTypePBFromDB(&ruleTypes[idx])
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "cannot convert rule type %s to pb: %v", ruleTypes[idx].Name, err)
+		}
+		rt.Id = nil
+		rt.Context = nil
+		export.RuleTypes = append(export.RuleTypes, rt)
+	}
+
+	listParams := db.ListProfilesByProjectIDAndLabelParams{ProjectID: projectID}
+	listParams.LabelsFromFilter("*")
+	profiles, err := qtx.ListProfilesByProjectIDAndLabel(ctx, listParams)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error listing profiles: %v", err)
+	}
+	for _, profile := range prof.MergeDatabaseListIntoProfiles(profiles) {
+		profile.Id = nil
+		profile.Context = nil
+		for _, sel := range profile.GetSelection() {
+			sel.Id = ""
+		}
+		export.Profiles = append(export.Profiles, profile)
+	}
+	// Sort the profiles by name, so that exports can be compared
+	sort.Slice(export.Profiles, func(i, j int) bool {
+		return export.Profiles[i].GetName() < export.Profiles[j].GetName()
+	})
+
+	customRoles, err := qtx.ListCustomRolesByProject(ctx, projectID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error listing custom roles: %v", err)
+	}
+	for _, role := range customRoles {
+		export.CustomRoles = append(export.CustomRoles, roles.CustomRoleToProto(role))
+	}
+
+	providers, err := qtx.ListProvidersByProjectID(ctx, []uuid.UUID{projectID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error listing providers: %v", err)
+	}
+	for _, p := range providers {
+		var cfg *structpb.Struct
+		if len(p.Definition) > 0 {
+			cfg = &structpb.Struct{}
+			if err := protojson.Unmarshal(p.Definition, cfg); err != nil {
+				return nil, status.Errorf(codes.Internal, "error unmarshalling provider definition: %v", err)
+			}
+		}
+		provider := &minderv1.Provider{
+			Name:       p.Name,
+			Class:      string(p.Class),
+			Version:    p.Version,
+			Implements: protobufProviderImplementsFromDB(ctx, p),
+			AuthFlows:  protobufProviderAuthFlowFromDB(ctx, p),
+			Config:     cfg,
+		}
+		audit.RedactMessage(provider)
+		export.Providers = append(export.Providers, provider)
+	}
+
+	return export, nil
+}
+
+// ImportProject imports a project export into the project.  The conflicts
+// between the export and the project are reported before anything is
+// changed: the import is applied only if there are none, and if it isn't a
+// dry run.  Providers are never imported, as they must be enrolled again.
+func (s *Server) ImportProject(
+	ctx context.Context,
+	in *minderv1.ImportProjectRequest,
+) (*minderv1.ImportProjectResponse, error) {
+	projectID := GetProjectID(ctx)
+
+	export := in.GetExport()
+	if export.GetVersion() != projectExportVersion {
+		return nil, util.UserVisibleError(codes.InvalidArgument,
+			"unsupported export version %q, expected %q", export.GetVersion(), projectExportVersion)
+	}
+
+	var applied *projectImportPlan
+	resp, err := db.WithTransaction(s.store, func(qtx db.ExtendQuerier) (*minderv1.ImportProjectResponse, error) {
+		plan, err := s.planProjectImport(ctx, qtx, projectID, export)
+		if err != nil {
+			return nil, err
+		}
+
+		resp := &minderv1.ImportProjectResponse{Items: plan.items}
+		if in.GetDryRun() || plan.hasConflicts() {
+			return resp, nil
+		}
+
+		applied = plan
+		if err := s.applyProjectImport(ctx, qtx, projectID, plan); err != nil {
+			return nil, err
+		}
+		resp.Applied = true
+		return resp, nil
+	})
+	if err != nil && applied != nil {
+		s.undoProjectImport(ctx, projectID, applied)
+	}
+	return resp, err
+}
+
+// projectImportPlan holds the outcome of every item of an import, and the
+// items to create
+type projectImportPlan struct {
+	items []*minderv1.ProjectImportItem
+
+	dataSources     []*minderv1.DataSource
+	ruleTypes       []*minderv1.RuleType
+	profiles        []*minderv1.Profile
+	customRoles     []*minderv1.Role
+	roleAssignments []*minderv1.RoleAssignment
+
+	// the number of custom roles and role assignments written to the
+	// authorization store by applyProjectImport
+	customRolesWritten     int
+	roleAssignmentsWritten int
+}
+
+func (p *projectImportPlan) add(itemType, name, action, reason string) {
+	p.items = append(p.items, &minderv1.ProjectImportItem{
+		Type:   itemType,
+		Name:   name,
+		Action: action,
+		Reason: reason,
+	})
+}
+
+func (p *projectImportPlan) hasConflicts() bool {
+	return slices.ContainsFunc(p.items, func(item *minderv1.ProjectImportItem) bool {
+		return item.GetAction() == importActionConflict
+	})
+}
+
+//nolint:gocyclo // every kind of item has its own checks
+func (s *Server) planProjectImport(
+	ctx context.Context, qtx db.ExtendQuerier, projectID uuid.UUID, export *minderv1.ProjectExport,
+) (*projectImportPlan, error) {
+	plan := &projectImportPlan{}
+
+	// Rule types and data sources are inherited from the parent projects
+	projects, err := qtx.GetParentProjects(ctx, projectID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error getting parent projects: %v", err)
+	}
+
+	exportedDataSources := make(map[string]bool, len(export.GetDataSources()))
+	for _, ds := range export.GetDataSources() {
+		exportedDataSources[ds.GetName()] = true
+		if !flags.Bool(ctx, s.featureFlags, flags.DataSources) {
+			plan.add(importTypeDataSource, ds.GetName(), importActionConflict, "data sources are disabled")
+			continue
+		}
+		if err := ds.Validate(); err != nil {
+			plan.add(importTypeDataSource, ds.GetName(), importActionConflict, err.Error())
+			continue
+		}
+		if hasRedactedValues(ds) {
+			plan.add(importTypeDataSource, ds.GetName(), importActionConflict,
+				"values were redacted on export, set them in the export file")
+			continue
+		}
+		_, err := qtx.GetDataSourceByName(ctx, db.GetDataSourceByNameParams{
+			Name:     ds.GetName(),
+			Projects: projects,
+		})
+		if err == nil {
+			plan.add(importTypeDataSource, ds.GetName(), importActionConflict, "data source already exists")
+			continue
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.Internal, "error getting data source: %v", err)
+		}
+		plan.add(importTypeDataSource, ds.GetName(), importActionCreate, "")
+		plan.dataSources = append(plan.dataSources, ds)
+	}
+
+	dataSourceExists := func(name string) (bool, error) {
+		if exportedDataSources[name] {
+			return true, nil
+		}
+		_, err := qtx.GetDataSourceByName(ctx, db.GetDataSourceByNameParams{Name: name, Projects: projects})
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	exportedRuleTypes := make(map[string]bool, len(export.GetRuleTypes()))
+	for _, rt := range export.GetRuleTypes() {
+		exportedRuleTypes[rt.GetName()] = true
+		if err := checkRuleDefinitionFlags(ctx, s.featureFlags, rt.GetDef()); err != nil {
+			plan.add(importTypeRuleType, rt.GetName(), importActionConflict, err.Details)
+			continue
+		}
+		_, err := qtx.GetRuleTypeByName(ctx, db.GetRuleTypeByNameParams{
+			Projects: projects,
+			Name:     rt.GetName(),
+		})
+		if err == nil {
+			plan.add(importTypeRuleType, rt.GetName(), importActionConflict, "rule type already exists")
+			continue
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.Internal, "error getting rule type: %v", err)
+		}
+
+		var missing []string
+		for _, ref := range rt.GetDef().GetEval().GetDataSources() {
+			exists, err := dataSourceExists(ref.GetName())
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "error getting data source: %v", err)
+			}
+			if !exists {
+				missing = append(missing, ref.GetName())
+			}
+		}
+		if len(missing) > 0 {
+			plan.add(importTypeRuleType, rt.GetName(), importActionConflict,
+				fmt.Sprintf("missing data sources: %v", missing))
+			continue
+		}
+		plan.add(importTypeRuleType, rt.GetName(), importActionCreate, "")
+		plan.ruleTypes = append(plan.ruleTypes, rt)
+	}
+
+	for _, profile := range export.GetProfiles() {
+		existing, err := qtx.GetProfileByProjectAndName(ctx, db.GetProfileByProjectAndNameParams{
+			ProjectID: projectID,
+			Name:      profile.GetName(),
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "error getting profile: %v", err)
+		}
+		if len(existing) > 0 {
+			plan.add(importTypeProfile, profile.GetName(), importActionConflict, "profile already exists")
+			continue
+		}
+
+		var missing []string
+		err = prof.TraverseAllRulesForPipeline(profile, func(rule *minderv1.Profile_Rule) error {
+			if exportedRuleTypes[rule.GetType()] || slices.Contains(missing, rule.GetType()) {
+				return nil
+			}
+			_, err := qtx.GetRuleTypeByName(ctx, db.GetRuleTypeByNameParams{
+				Projects: projects,
+				Name:     rule.GetType(),
+			})
+			if errors.Is(err, sql.ErrNoRows) {
+				missing = append(missing, rule.GetType())
+				return nil
+			}
+			return err
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "error getting rule type: %v", err)
+		}
+		if len(missing) > 0 {
+			plan.add(importTypeProfile, profile.GetName(), importActionConflict,
+				fmt.Sprintf("missing rule types: %v", missing))
+			continue
+		}
+		plan.add(importTypeProfile, profile.GetName(), importActionCreate, "")
+		plan.profiles = append(plan.profiles, profile)
+	}
+
+	// Roles are managed by their own permissions, which the permission to
+	// import a project doesn't imply
+	canCreateRoles := false
+	if len(export.GetCustomRoles()) > 0 {
+		canCreateRoles, err = s.isAuthorized(ctx, minderv1.Relation_RELATION_ROLE_CREATE, projectID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	exportedRoles := make(map[string]bool, len(export.GetCustomRoles()))
+	for _, role := range export.GetCustomRoles() {
+		exportedRoles[role.GetName()] = true
+		if reason := checkImportedCustomRole(role); reason != "" {
+			plan.add(importTypeCustomRole, role.GetName(), importActionConflict, reason)
+			continue
+		}
+		existing, err := qtx.GetCustomRoleByName(ctx, db.GetCustomRoleByNameParams{
+			ProjectID: projectID,
+			Name:      role.GetName(),
+		})
+		if err == nil {
+			permissions := slices.Clone(role.GetPermissions())
+			slices.Sort(permissions)
+			if slices.Equal(slices.Compact(permissions), existing.Permissions) {
+				plan.add(importTypeCustomRole, role.GetName(), importActionSkip, "role already exists")
+			} else {
+				plan.add(importTypeCustomRole, role.GetName(), importActionConflict,
+					"role already exists with different permissions")
+			}
+			continue
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.Internal, "error getting role: %v", err)
+		}
+		if !canCreateRoles {
+			plan.add(importTypeCustomRole, role.GetName(), importActionConflict,
+				"not allowed to create roles in the project")
+			continue
+		}
+		if reason, err := s.checkHeldPermissions(ctx, projectID, role); err != nil {
+			return nil, err
+		} else if reason != "" {
+			plan.add(importTypeCustomRole, role.GetName(), importActionConflict, reason)
+			continue
+		}
+		plan.add(importTypeCustomRole, role.GetName(), importActionCreate, "")
+		plan.customRoles = append(plan.customRoles, role)
+	}
+
+	if len(export.GetRoleAssignments()) > 0 {
+		if err := s.planRoleAssignmentsImport(ctx, qtx, projectID, export, exportedRoles, plan); err != nil {
+			return nil, err
+		}
+	}
+
+	providers, err := qtx.ListProvidersByProjectID(ctx, []uuid.UUID{projectID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error listing providers: %v", err)
+	}
+	for _, provider := range export.GetProviders() {
+		enrolled := slices.ContainsFunc(providers, func(p db.Provider) bool {
+			return p.Name == provider.GetName()
+		})
+		if enrolled {
+			plan.add(importTypeProvider, provider.GetName(), importActionSkip, "provider already enrolled")
+		} else {
+			plan.add(importTypeProvider, provider.GetName(), importActionSkip, "providers must be enrolled again")
+		}
+	}
+
+	return plan, nil
+}
+
+func (s *Server) planRoleAssignmentsImport(
+	ctx context.Context, qtx db.ExtendQuerier, projectID uuid.UUID,
+	export *minderv1.ProjectExport, exportedRoles map[string]bool, plan *projectImportPlan,
+) error {
+	assignments, err := s.authzClient.AssignmentsToProject(ctx, projectID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "error getting role assignments: %v", err)
+	}
+	canAssignRoles, err := s.isAuthorized(ctx, minderv1.Relation_RELATION_ROLE_ASSIGNMENT_CREATE, projectID)
+	if err != nil {
+		return err
+	}
+
+	for _, a := range export.GetRoleAssignments() {
+		name := a.GetDisplayName()
+		if name == "" {
+			name = a.GetSubject()
+		}
+
+		if _, err := authz.ParseRole(a.GetRole()); err != nil && !exportedRoles[a.GetRole()] {
+			_, err := qtx.GetCustomRoleByName(ctx, db.GetCustomRoleByNameParams{
+				ProjectID: projectID,
+				Name:      a.GetRole(),
+			})
+			if errors.Is(err, sql.ErrNoRows) {
+				plan.add(importTypeRoleAssignment, name, importActionConflict,
+					fmt.Sprintf("role %s not found", a.GetRole()))
+				continue
+			} else if err != nil {
+				return status.Errorf(codes.Internal, "error getting role: %v", err)
+			}
+		}
+
+		// Subjects are resolved by their ID first, as it is stable across
+		// projects of the same instance, then by their name
+		identity, err := s.idClient.Resolve(ctx, a.GetSubject())
+		if err != nil && a.GetDisplayName() != "" {
+			identity, err = s.idClient.Resolve(ctx, a.GetDisplayName())
+		}
+		if err != nil {
+			plan.add(importTypeRoleAssignment, name, importActionConflict, "user not found")
+			continue
+		}
+		if identity.Provider.String() == "" {
+			if _, err := qtx.GetUserBySubject(ctx, identity.String()); errors.Is(err, sql.ErrNoRows) {
+				plan.add(importTypeRoleAssignment, name, importActionConflict, "user not found")
+				continue
+			} else if err != nil {
+				return status.Errorf(codes.Internal, "error getting user: %v", err)
+			}
+		}
+
+		idx := slices.IndexFunc(assignments, func(existing *minderv1.RoleAssignment) bool {
+			return existing.GetSubject() == identity.String()
+		})
+		if idx >= 0 {
+			if assignments[idx].GetRole() == a.GetRole() {
+				plan.add(importTypeRoleAssignment, name, importActionSkip, "role already assigned")
+			} else {
+				plan.add(importTypeRoleAssignment, name, importActionConflict,
+					fmt.Sprintf("user already has role %s", assignments[idx].GetRole()))
+			}
+			continue
+		}
+
+		if !canAssignRoles {
+			plan.add(importTypeRoleAssignment, name, importActionConflict,
+				"not allowed to assign roles in the project")
+			continue
+		}
+		plan.add(importTypeRoleAssignment, name, importActionCreate, "")
+		plan.roleAssignments = append(plan.roleAssignments, &minderv1.RoleAssignment{
+			Role:    a.GetRole(),
+			Subject: identity.String(),
+		})
+	}
+	return nil
+}
+
+// checkImportedCustomRole returns the reason a custom role can't be created,
+// if any
+func checkImportedCustomRole(role *minderv1.Role) string {
+	if !authz.Role(role.GetName()).IsCustom() {
+		return fmt.Sprintf("role %s is a built-in role", role.GetName())
+	}
+	if len(role.GetPermissions()) == 0 {
+		return "a custom role must have at least one permission"
+	}
+	for _, perm := range role.GetPermissions() {
+		if err := authz.ValidatePermission(perm); err != nil {
+			return err.Error()
+		}
+	}
+	return ""
+}
+
+// checkHeldPermissions returns the reason the caller can't create a custom
+// role, if they don't hold every permission it grants
+func (s *Server) checkHeldPermissions(ctx context.Context, projectID uuid.UUID, role *minderv1.Role) (string, error) {
+	for _, perm := range role.GetPermissions() {
+		if err := s.authzClient.Check(ctx, perm, projectID); errors.Is(err, authz.ErrNotAuthorized) {
+			return fmt.Sprintf("cannot grant the %s permission, which you don't hold on the project", perm), nil
+		} else if err != nil {
+			return "", status.Errorf(codes.Internal, "error checking permission %s: %v", perm, err)
+		}
+	}
+	return "", nil
+}
+
+// hasRedactedValues returns whether values of the message were redacted,
+// as the credentials of data sources are on export
+func hasRedactedValues(msg proto.Message) bool {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(data), `"`+audit.RedactedValue+`"`)
+}
+
+// applyProjectImport creates the items of an import plan.  The items which
+// are only stored in the database are created first, so that a failure
+// leaves the authorization store untouched as often as possible.  The
+// custom roles and role assignments are written to the authorization store
+// within the transaction, so undoProjectImport must be called if it fails.
+func (s *Server) applyProjectImport(
+	ctx context.Context, qtx db.ExtendQuerier, projectID uuid.UUID, plan *projectImportPlan,
+) error {
+	for _, ds := range plan.dataSources {
+		_, err := s.dataSourcesService.Create(ctx, projectID, uuid.Nil, ds, datasourcessvc.OptionsBuilder().WithTransaction(qtx))
+		if err != nil {
+			return status.Errorf(codes.Internal, "error creating data source %s: %v", ds.GetName(), err)
+		}
+	}
+
+	for _, rt := range plan.ruleTypes {
+		if _, err := s.ruleTypes.CreateRuleType(ctx, projectID, uuid.Nil, rt, qtx); err != nil {
+			if errors.Is(err, ruletypes.ErrRuleTypeInvalid) {
+				return util.UserVisibleError(codes.InvalidArgument, "invalid rule type %s: %s", rt.GetName(), err)
+			}
+			return status.Errorf(codes.Internal, "error creating rule type %s: %v", rt.GetName(), err)
+		}
+	}
+
+	for _, profile := range plan.profiles {
+		// the service layer sets meaningful errors
+		if _, err := s.profiles.CreateProfile(ctx, projectID, uuid.Nil, profile, qtx); err != nil {
+			return err
+		}
+	}
+
+	for _, role := range plan.customRoles {
+		if _, err := s.roles.CreateCustomRole(ctx, qtx, s.authzClient, projectID, role); err != nil {
+			return err
+		}
+		plan.customRolesWritten++
+	}
+
+	for _, a := range plan.roleAssignments {
+		_, err := s.roles.CreateRoleAssignment(ctx, qtx, s.authzClient, s.idClient, projectID, a.GetSubject(), authz.Role(a.GetRole()))
+		if err != nil {
+			return err
+		}
+		plan.roleAssignmentsWritten++
+	}
+
+	return nil
+}
+
+// undoProjectImport removes the custom roles and role assignments of an
+// import from the authorization store, after the transaction which created
+// them failed.  None of them existed before the import, as the plan only
+// creates new ones.
+func (s *Server) undoProjectImport(ctx context.Context, projectID uuid.UUID, plan *projectImportPlan) {
+	logger := zerolog.Ctx(ctx)
+	for _, a := range plan.roleAssignments[:plan.roleAssignmentsWritten] {
+		if err := s.authzClient.Delete(ctx, a.GetSubject(), authz.Role(a.GetRole()), projectID); err != nil {
+			logger.Error().Err(err).Str("subject", a.GetSubject()).
+				Msg("error removing the role assignment of a failed import")
+		}
+	}
+	for _, role := range plan.customRoles[:plan.customRolesWritten] {
+		if err := roles.SyncCustomRole(ctx, s.store, s.authzClient, projectID, role.GetName()); err != nil {
+			logger.Error().Err(err).Str("role", role.GetName()).
+				Msg("error removing the custom role of a failed import")
+		}
+	}
+}
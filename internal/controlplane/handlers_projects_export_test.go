@@ -0,0 +1,339 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controlplane
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockdb "github.com/mindersec/minder/database/mock"
+	"github.com/mindersec/minder/internal/auth"
+	"github.com/mindersec/minder/internal/auth/keycloak"
+	"github.com/mindersec/minder/internal/authz"
+	"github.com/mindersec/minder/internal/authz/mock"
+	mock_service "github.com/mindersec/minder/internal/datasources/service/mock"
+	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/internal/engine/engcontext"
+	mockroles "github.com/mindersec/minder/internal/roles/mock"
+	minder "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	mock_profiles "github.com/mindersec/minder/pkg/profiles/mock"
+	mock_ruletypes "github.com/mindersec/minder/pkg/ruletypes/mock"
+)
+
+func TestExportProject(t *testing.T) {
+	t.Parallel()
+
+	projectID := uuid.New()
+	user1 := uuid.New()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mockdb.NewMockStore(ctrl)
+	mockStore.EXPECT().BeginTransaction()
+	mockStore.EXPECT().GetQuerierWithTransaction(gomock.Any()).Return(mockStore)
+	mockStore.EXPECT().Rollback(gomock.Any())
+	mockStore.EXPECT().Commit(gomock.Any())
+	mockStore.EXPECT().GetProjectByID(gomock.Any(), projectID).Return(db.Project{ID: projectID, Name: "acme"}, nil)
+	mockStore.EXPECT().ListRuleTypesByProject(gomock.Any(), projectID).Return(nil, nil)
+	mockStore.EXPECT().ListProfilesByProjectIDAndLabel(gomock.Any(), db.ListProfilesByProjectIDAndLabelParams{
+		ProjectID:     projectID,
+		IncludeLabels: []string{"*"},
+	}).Return(nil, nil)
+	mockStore.EXPECT().ListCustomRolesByProject(gomock.Any(), projectID).Return([]db.CustomRole{{
+		Name:        "auditor",
+		Permissions: []string{"audit_list"},
+	}}, nil)
+	mockStore.EXPECT().ListProvidersByProjectID(gomock.Any(), []uuid.UUID{projectID}).Return([]db.Provider{{
+		ID:         uuid.New(),
+		Name:       "github-app",
+		Class:      db.ProviderClassGithubApp,
+		Version:    "v1",
+		Definition: json.RawMessage(`{"github-app":{"endpoint":"https://api.github.com","webhook_secret":"s3cr3t"}}`),
+	}}, nil)
+
+	dsService := mock_service.NewMockDataSourcesService(ctrl)
+	dsService.EXPECT().List(gomock.Any(), projectID, gomock.Any()).Return([]*minder.DataSource{{
+		Id:      uuid.NewString(),
+		Name:    "osv",
+		Context: &minder.ContextV2{ProjectId: projectID.String()},
+		Driver: &minder.DataSource_Rest{
+			Rest: &minder.RestDataSource{
+				Def: map[string]*minder.RestDataSource_Def{
+					"query": {
+						Endpoint: "https://api.osv.dev/v1/query",
+						Headers:  map[string]string{"X-Api-Key": "s3cr3t"},
+					},
+				},
+			},
+		},
+	}}, nil)
+
+	server := Server{
+		store:              mockStore,
+		dataSourcesService: dsService,
+		authzClient: &mock.SimpleClient{
+			Assignments: map[uuid.UUID][]*minder.RoleAssignment{
+				projectID: {{Role: "auditor", Subject: user1.String()}},
+			},
+		},
+		idClient: &SimpleResolver{
+			data: []auth.Identity{{
+				UserID:    user1.String(),
+				HumanName: "user1",
+				Provider:  &keycloak.KeyCloak{},
+			}},
+		},
+	}
+
+	ctx := engcontext.WithEntityContext(context.Background(), &engcontext.EntityContext{
+		Project: engcontext.Project{ID: projectID},
+	})
+
+	resp, err := server.ExportProject(ctx, &minder.ExportProjectRequest{})
+	require.NoError(t, err)
+
+	export := resp.GetExport()
+	require.Equal(t, projectExportVersion, export.GetVersion())
+	require.Equal(t, "acme", export.GetProject())
+
+	// data sources are exported without their identity and credentials
+	require.Len(t, export.GetDataSources(), 1)
+	require.Empty(t, export.GetDataSources()[0].GetId())
+	require.Nil(t, export.GetDataSources()[0].GetContext())
+	def := export.GetDataSources()[0].GetRest().GetDef()["query"]
+	require.Equal(t, "https://api.osv.dev/v1/query", def.GetEndpoint())
+	require.Equal(t, map[string]string{"X-Api-Key": "REDACTED"}, def.GetHeaders())
+
+	require.Len(t, export.GetCustomRoles(), 1)
+	require.Equal(t, "auditor", export.GetCustomRoles()[0].GetName())
+
+	require.Len(t, export.GetRoleAssignments(), 1)
+	require.Equal(t, "user1", export.GetRoleAssignments()[0].GetDisplayName())
+	require.Nil(t, export.GetRoleAssignments()[0].Project)
+
+	// providers are exported without their secrets
+	require.Len(t, export.GetProviders(), 1)
+	provider := export.GetProviders()[0]
+	require.Equal(t, "github-app", provider.GetName())
+	require.Empty(t, provider.GetId())
+	appConfig := provider.GetConfig().GetFields()["github-app"].GetStructValue().GetFields()
+	require.Equal(t, "https://api.github.com", appConfig["endpoint"].GetStringValue())
+	require.Equal(t, "REDACTED", appConfig["webhook_secret"].GetStringValue())
+}
+
+func TestImportProject(t *testing.T) {
+	t.Parallel()
+
+	projectID := uuid.New()
+	user1 := uuid.New()
+
+	ruleType := &minder.RuleType{
+		Name: "secret_scanning",
+		Def:  &minder.RuleType_Definition{InEntity: minder.RepositoryEntity.String()},
+	}
+	profile := &minder.Profile{
+		Name:       "security-baseline",
+		Repository: []*minder.Profile_Rule{{Type: "secret_scanning"}},
+	}
+	role := &minder.Role{Name: "auditor", Permissions: []string{"audit_list"}}
+	export := &minder.ProjectExport{
+		Version:         projectExportVersion,
+		RuleTypes:       []*minder.RuleType{ruleType},
+		Profiles:        []*minder.Profile{profile},
+		CustomRoles:     []*minder.Role{role},
+		RoleAssignments: []*minder.RoleAssignment{{Role: "auditor", Subject: user1.String(), DisplayName: "user1"}},
+		Providers:       []*minder.Provider{{Name: "github-app"}},
+	}
+
+	tests := []struct {
+		name            string
+		export          *minder.ProjectExport
+		dryRun          bool
+		ruleTypeExists  bool
+		notAllowed      bool
+		expectedActions []string
+		expectedApplied bool
+		expectedErr     string
+	}{
+		{
+			name:            "import is applied",
+			export:          export,
+			expectedActions: []string{"create", "create", "create", "create", "skip"},
+			expectedApplied: true,
+		},
+		{
+			name:            "dry run changes nothing",
+			export:          export,
+			dryRun:          true,
+			expectedActions: []string{"create", "create", "create", "create", "skip"},
+		},
+		{
+			name:            "conflicts are reported without changes",
+			export:          export,
+			ruleTypeExists:  true,
+			expectedActions: []string{"conflict", "create", "create", "create", "skip"},
+		},
+		{
+			name:            "roles need their own permissions",
+			export:          export,
+			notAllowed:      true,
+			expectedActions: []string{"create", "create", "conflict", "conflict", "skip"},
+		},
+		{
+			name: "unsupported version",
+			export: &minder.ProjectExport{
+				Version: "v0",
+			},
+			expectedErr: "unsupported export version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mockdb.NewMockStore(ctrl)
+			mockRuleTypes := mock_ruletypes.NewMockRuleTypeService(ctrl)
+			mockProfiles := mock_profiles.NewMockProfileService(ctrl)
+			mockRoles := mockroles.NewMockRoleService(ctrl)
+
+			if tt.expectedErr == "" {
+				mockStore.EXPECT().BeginTransaction()
+				mockStore.EXPECT().GetQuerierWithTransaction(gomock.Any()).Return(mockStore)
+				mockStore.EXPECT().Rollback(gomock.Any())
+				mockStore.EXPECT().Commit(gomock.Any())
+				mockStore.EXPECT().GetParentProjects(gomock.Any(), projectID).Return([]uuid.UUID{projectID}, nil)
+				if tt.ruleTypeExists {
+					mockStore.EXPECT().GetRuleTypeByName(gomock.Any(), gomock.Any()).Return(db.RuleType{}, nil)
+				} else {
+					mockStore.EXPECT().GetRuleTypeByName(gomock.Any(), gomock.Any()).Return(db.RuleType{}, sql.ErrNoRows)
+				}
+				mockStore.EXPECT().GetProfileByProjectAndName(gomock.Any(), gomock.Any()).Return(nil, nil)
+				mockStore.EXPECT().GetCustomRoleByName(gomock.Any(), gomock.Any()).Return(db.CustomRole{}, sql.ErrNoRows)
+				mockStore.EXPECT().GetUserBySubject(gomock.Any(), user1.String()).Return(db.User{ID: 1}, nil)
+				mockStore.EXPECT().ListProvidersByProjectID(gomock.Any(), []uuid.UUID{projectID}).Return(nil, nil)
+			}
+			if tt.expectedApplied {
+				mockRuleTypes.EXPECT().CreateRuleType(gomock.Any(), projectID, uuid.Nil, ruleType, gomock.Any()).
+					Return(ruleType, nil)
+				mockProfiles.EXPECT().CreateProfile(gomock.Any(), projectID, uuid.Nil, profile, gomock.Any()).
+					Return(profile, nil)
+				mockRoles.EXPECT().CreateCustomRole(gomock.Any(), gomock.Any(), gomock.Any(), projectID, role).
+					Return(role, nil)
+				mockRoles.EXPECT().CreateRoleAssignment(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+					projectID, user1.String(), authz.Role("auditor")).Return(&minder.RoleAssignment{}, nil)
+			}
+
+			authzClient := &mock.SimpleClient{}
+			if !tt.notAllowed {
+				authzClient.Allowed = []uuid.UUID{projectID}
+			}
+
+			server := Server{
+				store:       mockStore,
+				ruleTypes:   mockRuleTypes,
+				profiles:    mockProfiles,
+				roles:       mockRoles,
+				authzClient: authzClient,
+				idClient: &SimpleResolver{
+					data: []auth.Identity{{
+						UserID:    user1.String(),
+						HumanName: "user1",
+						Provider:  &keycloak.KeyCloak{},
+					}},
+				},
+			}
+
+			ctx := engcontext.WithEntityContext(context.Background(), &engcontext.EntityContext{
+				Project: engcontext.Project{ID: projectID},
+			})
+
+			resp, err := server.ImportProject(ctx, &minder.ImportProjectRequest{
+				Export: tt.export,
+				DryRun: tt.dryRun,
+			})
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			actions := make([]string, 0, len(resp.GetItems()))
+			for _, item := range resp.GetItems() {
+				actions = append(actions, item.GetAction())
+			}
+			require.Equal(t, tt.expectedActions, actions)
+			require.Equal(t, tt.expectedApplied, resp.GetApplied())
+		})
+	}
+}
+
+func TestImportProjectUndoesRoleWrites(t *testing.T) {
+	t.Parallel()
+
+	projectID := uuid.New()
+	user1 := uuid.New()
+
+	role := &minder.Role{Name: "auditor", Permissions: []string{"audit_list"}}
+	export := &minder.ProjectExport{
+		Version:         projectExportVersion,
+		CustomRoles:     []*minder.Role{role},
+		RoleAssignments: []*minder.RoleAssignment{{Role: "auditor", Subject: user1.String()}},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mockdb.NewMockStore(ctrl)
+	mockStore.EXPECT().BeginTransaction()
+	mockStore.EXPECT().GetQuerierWithTransaction(gomock.Any()).Return(mockStore)
+	mockStore.EXPECT().Rollback(gomock.Any())
+	mockStore.EXPECT().GetParentProjects(gomock.Any(), projectID).Return([]uuid.UUID{projectID}, nil)
+	// once for the plan, once to sync the role after the rollback
+	mockStore.EXPECT().GetCustomRoleByName(gomock.Any(), gomock.Any()).Return(db.CustomRole{}, sql.ErrNoRows).Times(2)
+	mockStore.EXPECT().GetUserBySubject(gomock.Any(), user1.String()).Return(db.User{ID: 1}, nil)
+	mockStore.EXPECT().ListProvidersByProjectID(gomock.Any(), []uuid.UUID{projectID}).Return(nil, nil)
+
+	authzClient := &mock.SimpleClient{Allowed: []uuid.UUID{projectID}}
+
+	mockRoles := mockroles.NewMockRoleService(ctrl)
+	mockRoles.EXPECT().CreateCustomRole(gomock.Any(), gomock.Any(), gomock.Any(), projectID, role).
+		DoAndReturn(func(ctx context.Context, _ db.Querier, authzClient authz.Client, project uuid.UUID, role *minder.Role) (*minder.Role, error) {
+			return role, authzClient.WriteCustomRole(ctx, project, authz.Role(role.GetName()), role.GetPermissions())
+		})
+	mockRoles.EXPECT().CreateRoleAssignment(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+		projectID, user1.String(), authz.Role("auditor")).Return(nil, sql.ErrConnDone)
+
+	server := Server{
+		store:       mockStore,
+		roles:       mockRoles,
+		authzClient: authzClient,
+		idClient: &SimpleResolver{
+			data: []auth.Identity{{
+				UserID:   user1.String(),
+				Provider: &keycloak.KeyCloak{},
+			}},
+		},
+	}
+
+	ctx := engcontext.WithEntityContext(context.Background(), &engcontext.EntityContext{
+		Project: engcontext.Project{ID: projectID},
+	})
+
+	_, err := server.ImportProject(ctx, &minder.ImportProjectRequest{Export: export})
+	require.ErrorIs(t, err, sql.ErrConnDone)
+
+	// the custom role written before the failure is removed again
+	require.NotContains(t, authzClient.CustomRoles[projectID], authz.Role("auditor"))
+}
@@ -10,12 +10,21 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	mockdb "github.com/mindersec/minder/database/mock"
 	"github.com/mindersec/minder/internal/auth"
 	"github.com/mindersec/minder/internal/authz/mock"
+	"github.com/mindersec/minder/internal/crypto"
+	mockcrypto "github.com/mindersec/minder/internal/crypto/mock"
 	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/internal/engine/engcontext"
+	"github.com/mindersec/minder/internal/projects"
+	"github.com/mindersec/minder/internal/util/ptr"
 	minder "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
 )
 
@@ -91,3 +100,91 @@ func TestListProjectsWithOneDeletedWhileIterating(t *testing.T) {
 	assert.Equal(t, authzClient.Allowed[0].String(), resp.Projects[0].ProjectId)
 	assert.Equal(t, authzClient.Allowed[2].String(), resp.Projects[1].ProjectId)
 }
+
+func TestPatchProjectAlertDestinations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		patch        *minder.ProjectPatch
+		paths        []string
+		expectedCode codes.Code
+	}{
+		{
+			name: "public https URL and secret",
+			patch: &minder.ProjectPatch{
+				AlertWebhookUrl:    ptr.Ptr("https://8.8.8.8/hook"),
+				AlertWebhookSecret: ptr.Ptr("s3cr3t"),
+			},
+			paths:        []string{"alert_webhook_url", "alert_webhook_secret"},
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "private address",
+			patch:        &minder.ProjectPatch{AlertWebhookUrl: ptr.Ptr("https://10.0.0.1/hook")},
+			paths:        []string{"alert_webhook_url"},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "plain http",
+			patch:        &minder.ProjectPatch{AlertWebhookUrl: ptr.Ptr("http://8.8.8.8/hook")},
+			paths:        []string{"alert_webhook_url"},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "chat webhook on a link-local address",
+			patch:        &minder.ProjectPatch{AlertChatWebhookUrl: ptr.Ptr("https://169.254.169.254/hook")},
+			paths:        []string{"alert_chat_webhook_url"},
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			projectID := uuid.New()
+
+			mockStore := mockdb.NewMockStore(ctrl)
+			mockStore.EXPECT().BeginTransaction().Return(nil, nil)
+			mockStore.EXPECT().GetQuerierWithTransaction(gomock.Any()).Return(mockStore)
+			mockStore.EXPECT().Rollback(gomock.Any())
+			mockStore.EXPECT().GetProjectByID(gomock.Any(), projectID).
+				Return(db.Project{ID: projectID, Name: "test", Metadata: []byte(`{}`)}, nil)
+
+			encrypted := crypto.EncryptedData{EncodedData: "encrypted"}
+			cryptoEngine := mockcrypto.NewMockEngine(ctrl)
+			if tt.expectedCode == codes.OK {
+				cryptoEngine.EXPECT().EncryptString("s3cr3t").Return(encrypted, nil)
+				mockStore.EXPECT().UpdateProjectMeta(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, arg db.UpdateProjectMetaParams) (db.Project, error) {
+						// the secret is only stored encrypted
+						assert.NotContains(t, string(arg.Metadata), "s3cr3t")
+						meta, err := projects.ParseMetadata(&db.Project{Metadata: arg.Metadata})
+						assert.NoError(t, err)
+						assert.Equal(t, &encrypted, meta.Alerts.WebhookSecret)
+						return db.Project{ID: projectID, Metadata: arg.Metadata}, nil
+					})
+				mockStore.EXPECT().Commit(gomock.Any())
+			}
+
+			server := Server{
+				store:        mockStore,
+				cryptoEngine: cryptoEngine,
+			}
+
+			ctx := engcontext.WithEntityContext(context.Background(), &engcontext.EntityContext{
+				Project: engcontext.Project{ID: projectID},
+			})
+			_, err := server.PatchProject(ctx, &minder.PatchProjectRequest{
+				Patch:      tt.patch,
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: tt.paths},
+			})
+			if tt.expectedCode == codes.OK {
+				require.NoError(t, err)
+			} else {
+				require.Equal(t, tt.expectedCode, status.Code(err))
+			}
+		})
+	}
+}
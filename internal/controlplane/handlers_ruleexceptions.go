@@ -0,0 +1,252 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controlplane
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/mindersec/minder/internal/auth"
+	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/internal/engine/engcontext"
+	"github.com/mindersec/minder/internal/engine/entities"
+	"github.com/mindersec/minder/internal/logger"
+	"github.com/mindersec/minder/internal/util"
+	"github.com/mindersec/minder/internal/util/ptr"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+// CreateRuleException exempts an entity from a rule of a profile until
+// the exception expires
+func (s *Server) CreateRuleException(
+	ctx context.Context,
+	in *minderv1.CreateRuleExceptionRequest,
+) (*minderv1.CreateRuleExceptionResponse, error) {
+	entityCtx := engcontext.EntityFromContext(ctx)
+	err := entityCtx.ValidateProject(ctx, s.store)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "error in entity context: %v", err)
+	}
+	projectID := entityCtx.Project.ID
+
+	reason := strings.TrimSpace(in.GetReason())
+	approver := strings.TrimSpace(in.GetApprover())
+	if reason == "" || approver == "" {
+		return nil, util.UserVisibleError(codes.InvalidArgument, "reason and approver must not be blank")
+	}
+
+	expiresAt := in.GetExpiresAt().AsTime()
+	if in.GetExpiresAt() == nil || !expiresAt.After(time.Now()) {
+		return nil, util.UserVisibleError(codes.InvalidArgument, "expiry must be in the future")
+	}
+
+	entityID, err := uuid.Parse(in.GetEntity().GetId())
+	if err != nil {
+		return nil, util.UserVisibleError(codes.InvalidArgument, "invalid entity ID")
+	}
+
+	ent, err := s.store.GetEntityByID(ctx, entityID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && ent.ProjectID != projectID) {
+		return nil, util.UserVisibleError(codes.NotFound, "entity not found")
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "error getting entity: %v", err)
+	}
+
+	entityType := entities.EntityTypeToDB(in.GetEntity().GetType())
+	if ent.EntityType != entityType {
+		return nil, util.UserVisibleError(codes.InvalidArgument,
+			"entity %s is not of type %s", entityID, in.GetEntity().GetType())
+	}
+
+	profiles, err := s.store.GetProfileByProjectAndName(ctx, db.GetProfileByProjectAndNameParams{
+		ProjectID: projectID,
+		Name:      in.GetProfile(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error getting profile: %v", err)
+	}
+	if len(profiles) == 0 {
+		return nil, util.UserVisibleError(codes.NotFound, "profile %q not found", in.GetProfile())
+	}
+	profile := profiles[0].Profile
+
+	rule, err := s.store.GetRuleInstanceByProfileAndName(ctx, db.GetRuleInstanceByProfileAndNameParams{
+		ProfileID:  profile.ID,
+		EntityType: entityType,
+		Name:       in.GetRuleName(),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, util.UserVisibleError(codes.NotFound,
+			"rule %q for %s not found in profile %q", in.GetRuleName(), in.GetEntity().GetType(), profile.Name)
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "error getting rule: %v", err)
+	}
+
+	exception, err := s.store.CreateRuleException(ctx, db.CreateRuleExceptionParams{
+		ProjectID:        projectID,
+		RuleInstanceID:   rule.ID,
+		EntityInstanceID: entityID,
+		Reason:           reason,
+		Approver:         approver,
+		CreatedBy:        auth.IdentityFromContext(ctx).String(),
+		ExpiresAt:        expiresAt,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error creating rule exception: %v", err)
+	}
+
+	// Telemetry logging
+	logger.BusinessRecord(ctx).Project = projectID
+	logger.BusinessRecord(ctx).Profile = logger.Profile{Name: profile.Name, ID: profile.ID}
+	zerolog.Ctx(ctx).Info().
+		Str("rule_exception_id", exception.ID.String()).
+		Str("rule_name", rule.Name).
+		Str("entity_id", entityID.String()).
+		Str("approver", exception.Approver).
+		Time("expires_at", exception.ExpiresAt).
+		Msg("rule exception created")
+
+	return &minderv1.CreateRuleExceptionResponse{
+		Exception: ruleExceptionToPB(exception, profile.Name, rule.Name, ent.EntityType),
+	}, nil
+}
+
+// ListRuleExceptions lists the rule exceptions of a project, including the
+// expired and revoked ones
+func (s *Server) ListRuleExceptions(
+	ctx context.Context,
+	_ *minderv1.ListRuleExceptionsRequest,
+) (*minderv1.ListRuleExceptionsResponse, error) {
+	entityCtx := engcontext.EntityFromContext(ctx)
+	err := entityCtx.ValidateProject(ctx, s.store)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "error in entity context: %v", err)
+	}
+
+	rows, err := s.store.ListRuleExceptionsByProject(ctx, entityCtx.Project.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error listing rule exceptions: %v", err)
+	}
+
+	resp := &minderv1.ListRuleExceptionsResponse{
+		Exceptions: make([]*minderv1.RuleException, 0, len(rows)),
+	}
+	for _, row := range rows {
+		resp.Exceptions = append(resp.Exceptions,
+			ruleExceptionToPB(row.RuleException, row.ProfileName, row.RuleName, row.EntityType))
+	}
+
+	return resp, nil
+}
+
+// DeleteRuleException revokes a rule exception, so that the rule is
+// evaluated again for the entity. The exception is kept, together with who
+// revoked it and when, for auditing purposes.
+func (s *Server) DeleteRuleException(
+	ctx context.Context,
+	in *minderv1.DeleteRuleExceptionRequest,
+) (*minderv1.DeleteRuleExceptionResponse, error) {
+	id, err := uuid.Parse(in.GetId())
+	if err != nil {
+		return nil, util.UserVisibleError(codes.InvalidArgument, "invalid rule exception ID")
+	}
+
+	entityCtx := engcontext.EntityFromContext(ctx)
+	err = entityCtx.ValidateProject(ctx, s.store)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "error in entity context: %v", err)
+	}
+
+	exception, err := s.store.RevokeRuleException(ctx, db.RevokeRuleExceptionParams{
+		ID:        id,
+		ProjectID: entityCtx.Project.ID,
+		RevokedBy: auth.IdentityFromContext(ctx).String(),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, util.UserVisibleError(codes.NotFound, "rule exception not found")
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "error revoking rule exception: %v", err)
+	}
+
+	// Telemetry logging
+	logger.BusinessRecord(ctx).Project = entityCtx.Project.ID
+	zerolog.Ctx(ctx).Info().
+		Str("rule_exception_id", exception.ID.String()).
+		Str("revoked_by", exception.RevokedBy.String).
+		Msg("rule exception revoked")
+
+	return &minderv1.DeleteRuleExceptionResponse{}, nil
+}
+
+func ruleExceptionToPB(
+	exception db.RuleException,
+	profileName string,
+	ruleName string,
+	entityType db.Entities,
+) *minderv1.RuleException {
+	pbException := &minderv1.RuleException{
+		Id: exception.ID.String(),
+		Context: &minderv1.Context{
+			Project: ptr.Ptr(exception.ProjectID.String()),
+		},
+		Profile:  profileName,
+		RuleName: ruleName,
+		Entity: &minderv1.EntityTypedId{
+			Type: entities.EntityTypeFromDB(entityType),
+			Id:   exception.EntityInstanceID.String(),
+		},
+		Reason:    exception.Reason,
+		Approver:  exception.Approver,
+		ExpiresAt: timestamppb.New(exception.ExpiresAt),
+		CreatedBy: exception.CreatedBy,
+		CreatedAt: timestamppb.New(exception.CreatedAt),
+	}
+	if exception.RevokedAt.Valid {
+		pbException.RevokedBy = exception.RevokedBy.String
+		pbException.RevokedAt = timestamppb.New(exception.RevokedAt.Time)
+	}
+	return pbException
+}
+
+// ruleExceptionKey identifies the rule exceptions applying to a rule of
+// a profile for a given entity
+func ruleExceptionKey(profileID uuid.UUID, ruleName string, entityID uuid.UUID) string {
+	return fmt.Sprintf("%s/%s/%s", profileID, strings.ToLower(ruleName), entityID)
+}
+
+// activeRuleExceptions returns the active rule exceptions of a project
+// indexed by ruleExceptionKey
+func activeRuleExceptions(
+	ctx context.Context, store db.Store, projectID uuid.UUID,
+) (map[string]*minderv1.RuleException, error) {
+	rows, err := store.ListRuleExceptionsByProject(ctx, projectID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error listing rule exceptions: %v", err)
+	}
+
+	now := time.Now()
+	active := make(map[string]*minderv1.RuleException, len(rows))
+	for _, row := range rows {
+		if row.RuleException.RevokedAt.Valid || !row.RuleException.ExpiresAt.After(now) {
+			continue
+		}
+		key := ruleExceptionKey(row.ProfileID, row.RuleName, row.RuleException.EntityInstanceID)
+		// keep the exception furthest from expiring, as the executor does
+		if prev, ok := active[key]; ok && prev.GetExpiresAt().AsTime().After(row.RuleException.ExpiresAt) {
+			continue
+		}
+		active[key] = ruleExceptionToPB(row.RuleException, row.ProfileName, row.RuleName, row.EntityType)
+	}
+	return active, nil
+}
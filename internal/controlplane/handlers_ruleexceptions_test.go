@@ -0,0 +1,314 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controlplane
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	mockdb "github.com/mindersec/minder/database/mock"
+	"github.com/mindersec/minder/internal/auth"
+	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/internal/engine/engcontext"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+func TestCreateRuleException(t *testing.T) {
+	t.Parallel()
+
+	projectID := uuid.New()
+	profileID := uuid.New()
+	ruleID := uuid.New()
+	entityID := uuid.New()
+	exceptionID := uuid.New()
+	expiresAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+
+	validRequest := func() *minderv1.CreateRuleExceptionRequest {
+		return &minderv1.CreateRuleExceptionRequest{
+			Profile:  "my-profile",
+			RuleName: "secret_scanning",
+			Entity: &minderv1.EntityTypedId{
+				Type: minderv1.Entity_ENTITY_REPOSITORIES,
+				Id:   entityID.String(),
+			},
+			Reason:    "TICKET-123",
+			Approver:  "security-team",
+			ExpiresAt: timestamppb.New(expiresAt),
+		}
+	}
+
+	tests := []struct {
+		name              string
+		request           func() *minderv1.CreateRuleExceptionRequest
+		setupMocks        func(*mockdb.MockStore)
+		expectedErrorCode codes.Code
+	}{
+		{
+			name:    "happy path",
+			request: validRequest,
+			setupMocks: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEntityByID(gomock.Any(), entityID).
+					Return(db.EntityInstance{ID: entityID, ProjectID: projectID, EntityType: db.EntitiesRepository}, nil)
+				store.EXPECT().GetProfileByProjectAndName(gomock.Any(), db.GetProfileByProjectAndNameParams{
+					ProjectID: projectID,
+					Name:      "my-profile",
+				}).Return([]db.GetProfileByProjectAndNameRow{
+					{Profile: db.Profile{ID: profileID, Name: "my-profile"}},
+				}, nil)
+				store.EXPECT().GetRuleInstanceByProfileAndName(gomock.Any(), db.GetRuleInstanceByProfileAndNameParams{
+					ProfileID:  profileID,
+					EntityType: db.EntitiesRepository,
+					Name:       "secret_scanning",
+				}).Return(db.RuleInstance{ID: ruleID, Name: "secret_scanning"}, nil)
+				store.EXPECT().CreateRuleException(gomock.Any(), db.CreateRuleExceptionParams{
+					ProjectID:        projectID,
+					RuleInstanceID:   ruleID,
+					EntityInstanceID: entityID,
+					Reason:           "TICKET-123",
+					Approver:         "security-team",
+					CreatedBy:        "alice",
+					ExpiresAt:        expiresAt,
+				}).Return(db.RuleException{
+					ID:               exceptionID,
+					ProjectID:        projectID,
+					RuleInstanceID:   ruleID,
+					EntityInstanceID: entityID,
+					Reason:           "TICKET-123",
+					Approver:         "security-team",
+					CreatedBy:        "alice",
+					ExpiresAt:        expiresAt,
+				}, nil)
+			},
+			expectedErrorCode: codes.OK,
+		},
+		{
+			name: "expiry in the past",
+			request: func() *minderv1.CreateRuleExceptionRequest {
+				req := validRequest()
+				req.ExpiresAt = timestamppb.New(time.Now().Add(-time.Hour))
+				return req
+			},
+			expectedErrorCode: codes.InvalidArgument,
+		},
+		{
+			name: "blank reason",
+			request: func() *minderv1.CreateRuleExceptionRequest {
+				req := validRequest()
+				req.Reason = "   "
+				return req
+			},
+			expectedErrorCode: codes.InvalidArgument,
+		},
+		{
+			name: "blank approver",
+			request: func() *minderv1.CreateRuleExceptionRequest {
+				req := validRequest()
+				req.Approver = "\t\n"
+				return req
+			},
+			expectedErrorCode: codes.InvalidArgument,
+		},
+		{
+			name:    "entity in another project",
+			request: validRequest,
+			setupMocks: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEntityByID(gomock.Any(), entityID).
+					Return(db.EntityInstance{ID: entityID, ProjectID: uuid.New(), EntityType: db.EntitiesRepository}, nil)
+			},
+			expectedErrorCode: codes.NotFound,
+		},
+		{
+			name: "entity type mismatch",
+			request: func() *minderv1.CreateRuleExceptionRequest {
+				req := validRequest()
+				req.Entity.Type = minderv1.Entity_ENTITY_ARTIFACTS
+				return req
+			},
+			setupMocks: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEntityByID(gomock.Any(), entityID).
+					Return(db.EntityInstance{ID: entityID, ProjectID: projectID, EntityType: db.EntitiesRepository}, nil)
+			},
+			expectedErrorCode: codes.InvalidArgument,
+		},
+		{
+			name:    "rule not in profile",
+			request: validRequest,
+			setupMocks: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEntityByID(gomock.Any(), entityID).
+					Return(db.EntityInstance{ID: entityID, ProjectID: projectID, EntityType: db.EntitiesRepository}, nil)
+				store.EXPECT().GetProfileByProjectAndName(gomock.Any(), gomock.Any()).
+					Return([]db.GetProfileByProjectAndNameRow{
+						{Profile: db.Profile{ID: profileID, Name: "my-profile"}},
+					}, nil)
+				store.EXPECT().GetRuleInstanceByProfileAndName(gomock.Any(), gomock.Any()).
+					Return(db.RuleInstance{}, sql.ErrNoRows)
+			},
+			expectedErrorCode: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mockdb.NewMockStore(ctrl)
+			mockStore.EXPECT().GetProjectByID(gomock.Any(), projectID).
+				Return(db.Project{ID: projectID}, nil).AnyTimes()
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockStore)
+			}
+
+			srv := newDefaultServer(t, mockStore, nil, nil, nil)
+
+			ctx := engcontext.WithEntityContext(context.Background(), &engcontext.EntityContext{
+				Project: engcontext.Project{ID: projectID},
+			})
+			ctx = auth.WithIdentityContext(ctx, &auth.Identity{UserID: "alice"})
+
+			resp, err := srv.CreateRuleException(ctx, tt.request())
+			if tt.expectedErrorCode != codes.OK {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedErrorCode, st.Code())
+				return
+			}
+
+			require.NoError(t, err)
+			exception := resp.GetException()
+			require.Equal(t, exceptionID.String(), exception.GetId())
+			require.Equal(t, "my-profile", exception.GetProfile())
+			require.Equal(t, "secret_scanning", exception.GetRuleName())
+			require.Equal(t, minderv1.Entity_ENTITY_REPOSITORIES, exception.GetEntity().GetType())
+			require.Equal(t, entityID.String(), exception.GetEntity().GetId())
+			require.Equal(t, "alice", exception.GetCreatedBy())
+			require.Equal(t, expiresAt, exception.GetExpiresAt().AsTime())
+		})
+	}
+}
+
+func TestDeleteRuleException(t *testing.T) {
+	t.Parallel()
+
+	projectID := uuid.New()
+	exceptionID := uuid.New()
+
+	tests := []struct {
+		name              string
+		id                string
+		revokeErr         error
+		expectedErrorCode codes.Code
+	}{
+		{
+			name:              "happy path",
+			id:                exceptionID.String(),
+			expectedErrorCode: codes.OK,
+		},
+		{
+			name:              "not found",
+			id:                exceptionID.String(),
+			revokeErr:         sql.ErrNoRows,
+			expectedErrorCode: codes.NotFound,
+		},
+		{
+			name:              "invalid id",
+			id:                "not-a-uuid",
+			expectedErrorCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mockdb.NewMockStore(ctrl)
+			mockStore.EXPECT().GetProjectByID(gomock.Any(), projectID).
+				Return(db.Project{ID: projectID}, nil).AnyTimes()
+			if tt.id == exceptionID.String() {
+				mockStore.EXPECT().RevokeRuleException(gomock.Any(), db.RevokeRuleExceptionParams{
+					ID:        exceptionID,
+					ProjectID: projectID,
+					RevokedBy: "alice",
+				}).Return(db.RuleException{ID: exceptionID}, tt.revokeErr)
+			}
+
+			srv := newDefaultServer(t, mockStore, nil, nil, nil)
+
+			ctx := engcontext.WithEntityContext(context.Background(), &engcontext.EntityContext{
+				Project: engcontext.Project{ID: projectID},
+			})
+			ctx = auth.WithIdentityContext(ctx, &auth.Identity{UserID: "alice"})
+
+			_, err := srv.DeleteRuleException(ctx, &minderv1.DeleteRuleExceptionRequest{Id: tt.id})
+			if tt.expectedErrorCode != codes.OK {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, tt.expectedErrorCode, st.Code())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestActiveRuleExceptions(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := uuid.New()
+	profileID := uuid.New()
+	entityID := uuid.New()
+	now := time.Now()
+
+	row := func(expiresAt time.Time) db.ListRuleExceptionsByProjectRow {
+		return db.ListRuleExceptionsByProjectRow{
+			RuleException: db.RuleException{
+				ID:               uuid.New(),
+				ProjectID:        projectID,
+				EntityInstanceID: entityID,
+				ExpiresAt:        expiresAt,
+			},
+			RuleName:    "Secret_Scanning",
+			ProfileID:   profileID,
+			ProfileName: "my-profile",
+			EntityType:  db.EntitiesRepository,
+		}
+	}
+	expired := row(now.Add(-time.Hour))
+	soon := row(now.Add(time.Hour))
+	later := row(now.Add(48 * time.Hour))
+	revoked := row(now.Add(72 * time.Hour))
+	revoked.RuleException.RevokedBy = sql.NullString{String: "alice", Valid: true}
+	revoked.RuleException.RevokedAt = sql.NullTime{Time: now, Valid: true}
+
+	mockStore := mockdb.NewMockStore(ctrl)
+	mockStore.EXPECT().ListRuleExceptionsByProject(gomock.Any(), projectID).
+		Return([]db.ListRuleExceptionsByProjectRow{expired, later, revoked, soon}, nil)
+
+	active, err := activeRuleExceptions(context.Background(), mockStore, projectID)
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+
+	exception := active[ruleExceptionKey(profileID, "secret_scanning", entityID)]
+	require.NotNil(t, exception)
+	require.Equal(t, later.RuleException.ID.String(), exception.GetId())
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/google/uuid"
 	gauth "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/auth"
+	"github.com/lestrrat-go/jwx/v2/jwt/openid"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -28,6 +29,7 @@ import (
 	"github.com/mindersec/minder/internal/invites"
 	"github.com/mindersec/minder/internal/logger"
 	"github.com/mindersec/minder/internal/projects"
+	"github.com/mindersec/minder/internal/roles"
 	"github.com/mindersec/minder/internal/util"
 	pb "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
 )
@@ -56,7 +58,7 @@ func (s *Server) CreateUser(ctx context.Context,
 		return nil, status.Errorf(codes.Internal, "failed to get transaction")
 	}
 
-	subject := token.Subject()
+	subject := userSubject(ctx, token)
 
 	user, err := qtx.CreateUser(ctx, subject)
 	if err != nil {
@@ -149,6 +151,17 @@ func (s *Server) claimGitHubInstalls(ctx context.Context, qtx db.ExtendQuerier)
 	return userProjects
 }
 
+// userSubject returns the subject which identifies the caller in the database.
+// Users of the default identity provider are identified by their UserID, which
+// may come from a claim other than `sub` for generic OIDC providers.
+func userSubject(ctx context.Context, token openid.Token) string {
+	if id := auth.IdentityFromContext(ctx); id != nil && id.UserID != "" &&
+		(id.Provider == nil || id.Provider.String() == "") {
+		return id.UserID
+	}
+	return token.Subject()
+}
+
 // DeleteUser is a service for user self deletion
 func (s *Server) DeleteUser(ctx context.Context,
 	_ *pb.DeleteUserRequest) (*pb.DeleteUserResponse, error) {
@@ -163,13 +176,18 @@ func (s *Server) DeleteUser(ctx context.Context,
 		return nil, status.Errorf(codes.Unauthenticated, "failed to parse bearer token: %v", err)
 	}
 
-	subject := token.Subject()
+	subject := userSubject(ctx, token)
 
-	err = DeleteUser(ctx, s.store, s.authzClient, s.projectDeleter, subject)
+	err = DeleteUser(ctx, s.store, s.authzClient, s.projectDeleter, token.Issuer(), subject)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to delete user from database: %v", err)
 	}
 
+	// Generic OIDC providers have no standard API to delete accounts
+	if s.cfg.Identity.DefaultOIDC() != nil {
+		return &pb.DeleteUserResponse{}, nil
+	}
+
 	resp, err := s.cfg.Identity.Server.Do(ctx, "DELETE", path.Join("admin/realms/stacklok/users", subject), nil, nil)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to delete account on IdP: %v", err)
@@ -236,15 +254,9 @@ func (s *Server) getUserDependencies(ctx context.Context, user db.User) ([]*pb.P
 					roleString = a.Role
 				}
 			}
-			// Parse role
-			authzRole, err := authz.ParseRole(roleString)
+			projectRole, err = s.getProjectRole(ctx, proj, roleString)
 			if err != nil {
-				return nil, nil, status.Errorf(codes.Internal, "failed to parse role: %v", err)
-			}
-			projectRole = &pb.Role{
-				Name:        authzRole.String(),
-				DisplayName: authz.AllRolesDisplayName[authzRole],
-				Description: authz.AllRolesDescriptions[authzRole],
+				return nil, nil, err
 			}
 		}
 
@@ -458,11 +470,9 @@ func (s *Server) acceptInvitation(ctx context.Context, userInvite db.GetInvitati
 			if existing.Role == userInvite.Role {
 				return util.UserVisibleError(codes.AlreadyExists, "user already has the same role in the project")
 			}
-			// Revoke the existing role assignments for the user in the project
-			existingRole, err := authz.ParseRole(existing.Role)
-			if err != nil {
-				return status.Errorf(codes.Internal, "failed to parse existing role: %s", err)
-			}
+			// Revoke the existing role assignments for the user in the project.
+			// They may be to custom roles, so the role isn't parsed.
+			existingRole := authz.Role(existing.Role)
 			existingProject, err := uuid.Parse(*existing.Project)
 			if err != nil {
 				return status.Errorf(codes.Internal, "failed to parse existing project: %s", err)
@@ -490,6 +500,32 @@ func (s *Server) acceptInvitation(ctx context.Context, userInvite db.GetInvitati
 	return nil
 }
 
+// getProjectRole returns the details of a role assigned on a project, which
+// may be a custom role defined in the project
+func (s *Server) getProjectRole(ctx context.Context, project uuid.UUID, role string) (*pb.Role, error) {
+	if role != "" && authz.Role(role).IsCustom() {
+		customRole, err := s.store.GetCustomRoleByName(ctx, db.GetCustomRoleByNameParams{
+			ProjectID: project,
+			Name:      role,
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get custom role: %v", err)
+		}
+		return roles.CustomRoleToProto(customRole), nil
+	}
+
+	// Parse role
+	authzRole, err := authz.ParseRole(role)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to parse role: %v", err)
+	}
+	return &pb.Role{
+		Name:        authzRole.String(),
+		DisplayName: authz.AllRolesDisplayName[authzRole],
+		Description: authz.AllRolesDescriptions[authzRole],
+	}, nil
+}
+
 func ensureUser(ctx context.Context, s *Server, store db.ExtendQuerier) (db.User, error) {
 	id := auth.IdentityFromContext(ctx)
 	// Only allow invitation flow for users from the primary provider / who can accept
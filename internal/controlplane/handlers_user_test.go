@@ -320,7 +320,7 @@ func TestDeleteUserDBMock(t *testing.T) {
 	}))
 	defer testServer.Close()
 
-	tokenResult, _ := openid.NewBuilder().Subject("subject1").Build()
+	tokenResult, _ := openid.NewBuilder().Issuer(testIssuer).Subject("subject1").Build()
 	mockJwtValidator.EXPECT().ParseAndValidate(gomock.Any()).Return(tokenResult, nil)
 
 	tx := sql.Tx{}
@@ -329,6 +329,9 @@ func TestDeleteUserDBMock(t *testing.T) {
 	mockStore.EXPECT().
 		GetUserBySubject(gomock.Any(), "subject1").
 		Return(db.User{IdentitySubject: "subject1"}, nil)
+	mockStore.EXPECT().
+		DeleteCachedIdentity(gomock.Any(), db.DeleteCachedIdentityParams{Issuer: testIssuer, Subject: "subject1"}).
+		Return(nil)
 	mockStore.EXPECT().
 		DeleteUser(gomock.Any(), gomock.Any()).
 		Return(nil)
@@ -374,7 +377,7 @@ func TestDeleteUser_gRPC(t *testing.T) {
 			name: "Success",
 			req:  &pb.DeleteUserRequest{},
 			buildStubs: func(store *mockdb.MockStore, jwt *mockjwt.MockValidator) {
-				tokenResult, _ := openid.NewBuilder().Subject("subject1").Build()
+				tokenResult, _ := openid.NewBuilder().Issuer(testIssuer).Subject("subject1").Build()
 				jwt.EXPECT().ParseAndValidate(gomock.Any()).Return(tokenResult, nil)
 
 				tx := sql.Tx{}
@@ -385,6 +388,9 @@ func TestDeleteUser_gRPC(t *testing.T) {
 					Return(db.User{
 						IdentitySubject: "subject1",
 					}, nil)
+				store.EXPECT().
+					DeleteCachedIdentity(gomock.Any(), db.DeleteCachedIdentityParams{Issuer: testIssuer, Subject: "subject1"}).
+					Return(nil)
 				store.EXPECT().
 					DeleteUser(gomock.Any(), gomock.Any()).
 					Return(nil)
@@ -89,7 +89,7 @@ func HandleEvents(
 	}
 	for _, event := range events {
 		if event.Type == deleteAccountEventType {
-			err := DeleteUser(ctx, store, authzClient, projectDeleter, event.UserId)
+			err := DeleteUser(ctx, store, authzClient, projectDeleter, cfg.Identity.Server.IssuerClaim, event.UserId)
 			if err != nil {
 				zerolog.Ctx(ctx).Error().Msgf("events chron: error deleting user account: %v", err)
 			}
@@ -163,7 +163,7 @@ func HandleAdminEvents(
 	for _, event := range events {
 		if event.OperationType == "DELETE" && event.ResourceType == "USER" {
 			userId := strings.TrimPrefix(event.ResourcePath, "users/")
-			err := DeleteUser(ctx, store, authzClient, projectDeleter, userId)
+			err := DeleteUser(ctx, store, authzClient, projectDeleter, cfg.Identity.Server.IssuerClaim, userId)
 			if err != nil {
 				zerolog.Ctx(ctx).Error().Msgf("events cron: error deleting user account from admin event: %v", err)
 			}
@@ -171,12 +171,14 @@ func HandleAdminEvents(
 	}
 }
 
-// DeleteUser deletes a user and all their associated data from the minder database
+// DeleteUser deletes a user and all their associated data from the minder database.
+// The issuer is the one of the identity provider which vended the user's subject.
 func DeleteUser(
 	ctx context.Context,
 	store db.Store,
 	authzClient authz.Client,
 	projectDeleter projects.ProjectDeleter,
+	issuer string,
 	userId string,
 ) error {
 	l := zerolog.Ctx(ctx).With().
@@ -208,6 +210,16 @@ func DeleteUser(
 			}
 		}
 
+		// Users of generic OIDC providers could still be resolved from the
+		// identity cache, so drop it as well.  Subjects are only unique
+		// within an issuer.
+		if err := qtx.DeleteCachedIdentity(ctx, db.DeleteCachedIdentityParams{
+			Issuer:  issuer,
+			Subject: userId,
+		}); err != nil {
+			return db.User{}, fmt.Errorf("error deleting cached identities %v", err)
+		}
+
 		// We only delete the user if it still exists in the database
 		if usr.IdentitySubject != "" {
 			l = l.With().Int32("user_id", usr.ID).Logger()
@@ -29,6 +29,10 @@ import (
 	serverconfig "github.com/mindersec/minder/pkg/config/server"
 )
 
+// testIssuer is the issuer of the test users, whose cached identities are
+// the only ones deleted
+const testIssuer = "https://issuer.example.com/realms/stacklok"
+
 func TestHandleEvents(t *testing.T) {
 	t.Parallel()
 
@@ -57,6 +61,12 @@ func TestHandleEvents(t *testing.T) {
 		Return(db.User{
 			IdentitySubject: "existingUserId",
 		}, nil)
+	mockStore.EXPECT().
+		DeleteCachedIdentity(gomock.Any(), db.DeleteCachedIdentityParams{
+			Issuer:  testIssuer,
+			Subject: "existingUserId",
+		}).
+		Return(nil)
 	mockStore.EXPECT().
 		DeleteUser(gomock.Any(), gomock.Any()).
 		Return(nil)
@@ -69,6 +79,12 @@ func TestHandleEvents(t *testing.T) {
 	mockStore.EXPECT().
 		GetUserBySubject(gomock.Any(), "alreadyDeletedUserId").
 		Return(db.User{}, sql.ErrNoRows)
+	mockStore.EXPECT().
+		DeleteCachedIdentity(gomock.Any(), db.DeleteCachedIdentityParams{
+			Issuer:  testIssuer,
+			Subject: "alreadyDeletedUserId",
+		}).
+		Return(nil)
 	mockStore.EXPECT().Commit(gomock.Any())
 	mockStore.EXPECT().Rollback(gomock.Any())
 
@@ -76,6 +92,7 @@ func TestHandleEvents(t *testing.T) {
 		Identity: serverconfig.IdentityConfigWrapper{
 			Server: serverconfig.IdentityConfig{
 				IssuerUrl:    server.URL,
+				IssuerClaim:  testIssuer,
 				ClientId:     "client-id",
 				ClientSecret: "client-secret",
 			},
@@ -115,6 +132,12 @@ func TestHandleAdminEvents(t *testing.T) {
 		Return(db.User{
 			IdentitySubject: "existingUserId",
 		}, nil)
+	mockStore.EXPECT().
+		DeleteCachedIdentity(gomock.Any(), db.DeleteCachedIdentityParams{
+			Issuer:  testIssuer,
+			Subject: "existingUserId",
+		}).
+		Return(nil)
 	mockStore.EXPECT().
 		DeleteUser(gomock.Any(), gomock.Any()).
 		Return(nil)
@@ -127,6 +150,12 @@ func TestHandleAdminEvents(t *testing.T) {
 	mockStore.EXPECT().
 		GetUserBySubject(gomock.Any(), "alreadyDeletedUserId").
 		Return(db.User{}, sql.ErrNoRows)
+	mockStore.EXPECT().
+		DeleteCachedIdentity(gomock.Any(), db.DeleteCachedIdentityParams{
+			Issuer:  testIssuer,
+			Subject: "alreadyDeletedUserId",
+		}).
+		Return(nil)
 	mockStore.EXPECT().Commit(gomock.Any())
 	mockStore.EXPECT().Rollback(gomock.Any())
 
@@ -134,6 +163,7 @@ func TestHandleAdminEvents(t *testing.T) {
 		Identity: serverconfig.IdentityConfigWrapper{
 			Server: serverconfig.IdentityConfig{
 				IssuerUrl:    server.URL,
+				IssuerClaim:  testIssuer,
 				ClientId:     "client-id",
 				ClientSecret: "client-secret",
 			},
@@ -186,7 +216,7 @@ func TestDeleteUserOneProject(t *testing.T) {
 	deleter := projects.NewProjectDeleter(&authzClient, mockProviderManager)
 
 	t.Log("Deleting user")
-	err = DeleteUser(ctx, store, &authzClient, deleter, u1.IdentitySubject)
+	err = DeleteUser(ctx, store, &authzClient, deleter, testIssuer, u1.IdentitySubject)
 	assert.NoError(t, err, "DeleteUser failed")
 
 	t.Log("Checking if user is removed from DB")
@@ -267,7 +297,7 @@ func TestDeleteUserMultiProjectMembership(t *testing.T) {
 	deleter := projects.NewProjectDeleter(&authzClient, mockProviderManager)
 
 	t.Log("Deleting user")
-	err = DeleteUser(ctx, store, &authzClient, deleter, u1.IdentitySubject)
+	err = DeleteUser(ctx, store, &authzClient, deleter, testIssuer, u1.IdentitySubject)
 	assert.NoError(t, err, "DeleteUser failed")
 
 	t.Log("Checking if user1 is removed from DB")
@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/mindersec/minder/internal/db"
+	serverconfig "github.com/mindersec/minder/pkg/config/server"
+)
+
+// ComplianceMetrics reports the compliance posture of the projects, as
+// the number of entities per evaluation status, project, profile and
+// rule type. The gauges are computed periodically rather than on every
+// scrape, as this requires aggregating the latest evaluation statuses
+// of all projects.
+type ComplianceMetrics struct {
+	store db.Store
+	cfg   serverconfig.ComplianceMetricsConfig
+
+	mu      sync.RWMutex
+	series  []complianceSeries
+	dropped int64
+}
+
+// complianceSeries holds the number of entities per evaluation status
+// for a project, profile and rule type
+type complianceSeries struct {
+	projectID uuid.UUID
+	profile   string
+	ruleType  string
+	counts    map[db.EvalStatusTypes]int64
+	total     int64
+}
+
+// NewComplianceMetrics creates the compliance gauges. They report nothing
+// until Run computes them for the first time.
+func NewComplianceMetrics(store db.Store, cfg serverconfig.ComplianceMetricsConfig) (*ComplianceMetrics, error) {
+	if cfg.RefreshInterval <= 0 {
+		return nil, fmt.Errorf("invalid compliance metrics refresh interval: %s", cfg.RefreshInterval)
+	}
+
+	c := &ComplianceMetrics{
+		store: store,
+		cfg:   cfg,
+	}
+
+	meter := otel.Meter("controlplane")
+	_, err := meter.Int64ObservableGauge("compliance.entities",
+		metric.WithDescription("Number of entities by latest evaluation status, per project, profile and rule type"),
+		metric.WithUnit("entities"),
+		metric.WithInt64Callback(c.observeEntities),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create compliance entities gauge: %w", err)
+	}
+
+	_, err = meter.Int64ObservableGauge("compliance.series_dropped",
+		metric.WithDescription("Number of project, profile and rule type combinations not reported because of max_series"),
+		metric.WithUnit("series"),
+		metric.WithInt64Callback(func(_ context.Context, observer metric.Int64Observer) error {
+			c.mu.RLock()
+			defer c.mu.RUnlock()
+			observer.Observe(c.dropped)
+			return nil
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create compliance dropped series gauge: %w", err)
+	}
+
+	return c, nil
+}
+
+// Run refreshes the gauges every RefreshInterval until the context is
+// cancelled
+func (c *ComplianceMetrics) Run(ctx context.Context) {
+	logger := zerolog.Ctx(ctx)
+
+	ticker := time.NewTicker(c.cfg.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.refresh(ctx); err != nil {
+			logger.Error().Err(err).Msg("error refreshing compliance metrics")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *ComplianceMetrics) refresh(ctx context.Context) error {
+	rows, err := c.store.CountEntitiesByEvaluationStatus(ctx, db.CountEntitiesByEvaluationStatusParams{
+		ByProfile:  c.cfg.IncludeProfile,
+		ByRuleType: c.cfg.IncludeRuleType,
+	})
+	if err != nil {
+		return fmt.Errorf("error counting entities by evaluation status: %w", err)
+	}
+
+	series, dropped := limitComplianceSeries(groupComplianceSeries(rows), c.cfg.MaxSeries)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.series = series
+	c.dropped = int64(dropped)
+	return nil
+}
+
+func (c *ComplianceMetrics) observeEntities(_ context.Context, observer metric.Int64Observer) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, s := range c.series {
+		for status, count := range s.counts {
+			labels := []attribute.KeyValue{
+				attribute.String("project", s.projectID.String()),
+				attribute.String("status", string(status)),
+			}
+			if c.cfg.IncludeProfile {
+				labels = append(labels, attribute.String("profile", s.profile))
+			}
+			if c.cfg.IncludeRuleType {
+				labels = append(labels, attribute.String("rule_type", s.ruleType))
+			}
+			observer.Observe(count, metric.WithAttributes(labels...))
+		}
+	}
+	return nil
+}
+
+// groupComplianceSeries groups the per status counts by project, profile
+// and rule type
+func groupComplianceSeries(rows []db.CountEntitiesByEvaluationStatusRow) []complianceSeries {
+	type seriesKey struct {
+		projectID uuid.UUID
+		profile   string
+		ruleType  string
+	}
+
+	index := make(map[seriesKey]int)
+	series := make([]complianceSeries, 0)
+	for _, row := range rows {
+		key := seriesKey{projectID: row.ProjectID, profile: row.ProfileName, ruleType: row.RuleTypeName}
+		i, ok := index[key]
+		if !ok {
+			i = len(series)
+			index[key] = i
+			series = append(series, complianceSeries{
+				projectID: row.ProjectID,
+				profile:   row.ProfileName,
+				ruleType:  row.RuleTypeName,
+				counts:    make(map[db.EvalStatusTypes]int64),
+			})
+		}
+		series[i].counts[row.Status] += row.NumEntities
+		series[i].total += row.NumEntities
+	}
+	return series
+}
+
+// limitComplianceSeries keeps the maxSeries series with the most entities,
+// and returns the number of series dropped. A non-positive maxSeries
+// disables the limit.
+func limitComplianceSeries(series []complianceSeries, maxSeries int) ([]complianceSeries, int) {
+	if maxSeries <= 0 || len(series) <= maxSeries {
+		return series, 0
+	}
+
+	slices.SortStableFunc(series, func(a, b complianceSeries) int {
+		return cmp.Compare(b.total, a.total)
+	})
+	return series[:maxSeries], len(series) - maxSeries
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockdb "github.com/mindersec/minder/database/mock"
+	"github.com/mindersec/minder/internal/db"
+	serverconfig "github.com/mindersec/minder/pkg/config/server"
+)
+
+func TestComplianceMetricsRefresh(t *testing.T) {
+	t.Parallel()
+
+	projectA := uuid.New()
+	projectB := uuid.New()
+	rows := []db.CountEntitiesByEvaluationStatusRow{
+		{ProjectID: projectA, ProfileName: "p1", RuleTypeName: "rt1", Status: db.EvalStatusTypesSuccess, NumEntities: 5},
+		{ProjectID: projectA, ProfileName: "p1", RuleTypeName: "rt1", Status: db.EvalStatusTypesFailure, NumEntities: 2},
+		{ProjectID: projectA, ProfileName: "p1", RuleTypeName: "rt2", Status: db.EvalStatusTypesError, NumEntities: 1},
+		{ProjectID: projectB, ProfileName: "p2", RuleTypeName: "rt1", Status: db.EvalStatusTypesSkipped, NumEntities: 3},
+	}
+
+	tests := []struct {
+		name            string
+		maxSeries       int
+		expectedSeries  int
+		expectedDropped int64
+	}{
+		{name: "unbounded", maxSeries: 0, expectedSeries: 3},
+		{name: "within bound", maxSeries: 3, expectedSeries: 3},
+		{name: "bounded", maxSeries: 2, expectedSeries: 2, expectedDropped: 1},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mockdb.NewMockStore(ctrl)
+			mockStore.EXPECT().CountEntitiesByEvaluationStatus(gomock.Any(), db.CountEntitiesByEvaluationStatusParams{
+				ByProfile:  true,
+				ByRuleType: true,
+			}).Return(rows, nil)
+
+			cm, err := NewComplianceMetrics(mockStore, serverconfig.ComplianceMetricsConfig{
+				Enabled:         true,
+				RefreshInterval: time.Minute,
+				IncludeProfile:  true,
+				IncludeRuleType: true,
+				MaxSeries:       tt.maxSeries,
+			})
+			require.NoError(t, err)
+			require.NoError(t, cm.refresh(context.Background()))
+
+			require.Len(t, cm.series, tt.expectedSeries)
+			require.Equal(t, tt.expectedDropped, cm.dropped)
+			// the series with the most entities is always kept first
+			require.Equal(t, projectA, cm.series[0].projectID)
+			require.Equal(t, "rt1", cm.series[0].ruleType)
+			require.Equal(t, int64(7), cm.series[0].total)
+			require.Equal(t, int64(2), cm.series[0].counts[db.EvalStatusTypesFailure])
+			if tt.maxSeries == 2 {
+				// the smallest series is the one dropped
+				require.Equal(t, projectB, cm.series[1].projectID)
+			}
+		})
+	}
+}
+
+func TestNewComplianceMetricsInvalidInterval(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewComplianceMetrics(nil, serverconfig.ComplianceMetricsConfig{Enabled: true})
+	require.Error(t, err)
+}
@@ -79,6 +79,16 @@ func RegisterGatewayHTTPHandlers(ctx context.Context, gwmux *runtime.ServeMux, g
 	if err := pb.RegisterDataSourceServiceHandlerFromEndpoint(ctx, gwmux, grpcAddress, opts); err != nil {
 		log.Fatal().Err(err).Msg("failed to register gateway")
 	}
+
+	// Register the Bundle service
+	if err := pb.RegisterBundleServiceHandlerFromEndpoint(ctx, gwmux, grpcAddress, opts); err != nil {
+		log.Fatal().Err(err).Msg("failed to register gateway")
+	}
+
+	// Register the Audit service
+	if err := pb.RegisterAuditServiceHandlerFromEndpoint(ctx, gwmux, grpcAddress, opts); err != nil {
+		log.Fatal().Err(err).Msg("failed to register gateway")
+	}
 }
 
 // RegisterGRPCServices registers the GRPC services
@@ -121,4 +131,10 @@ func RegisterGRPCServices(s *Server) {
 
 	// Register the DataSource service
 	pb.RegisterDataSourceServiceServer(s.grpcServer, s)
+
+	// Register the Bundle service
+	pb.RegisterBundleServiceServer(s.grpcServer, s)
+
+	// Register the Audit service
+	pb.RegisterAuditServiceServer(s.grpcServer, s)
 }
@@ -48,10 +48,12 @@ import (
 	"github.com/mindersec/minder/internal/crypto"
 	datasourcessvc "github.com/mindersec/minder/internal/datasources/service"
 	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/internal/engine"
 	propSvc "github.com/mindersec/minder/internal/entities/properties/service"
 	"github.com/mindersec/minder/internal/history"
 	"github.com/mindersec/minder/internal/invites"
 	"github.com/mindersec/minder/internal/logger"
+	"github.com/mindersec/minder/internal/marketplaces"
 	"github.com/mindersec/minder/internal/projects"
 	"github.com/mindersec/minder/internal/providers"
 	ghprov "github.com/mindersec/minder/internal/providers/github"
@@ -102,6 +104,7 @@ type Server struct {
 	roles               roles.RoleService
 	profiles            profiles.ProfileService
 	history             history.EvaluationHistoryService
+	previewer           engine.ProfilePreviewer
 	ghProviders         service.GitHubProviderService
 	providerStore       providers.ProviderStore
 	ghClient            ghprov.ClientService
@@ -110,6 +113,7 @@ type Server struct {
 	providerAuthManager manager.AuthManager
 	projectCreator      projects.ProjectCreator
 	projectDeleter      projects.ProjectDeleter
+	marketplace         marketplaces.Marketplace
 
 	// Implementations for service registration
 	pb.UnimplementedHealthServiceServer
@@ -125,6 +129,8 @@ type Server struct {
 	pb.UnimplementedEvalResultsServiceServer
 	pb.UnimplementedInviteServiceServer
 	pb.UnimplementedDataSourceServiceServer
+	pb.UnimplementedBundleServiceServer
+	pb.UnimplementedAuditServiceServer
 }
 
 // NewServer creates a new server instance
@@ -143,6 +149,7 @@ func NewServer(
 	roleService roles.RoleService,
 	profileService profiles.ProfileService,
 	historyService history.EvaluationHistoryService,
+	previewer engine.ProfilePreviewer,
 	ruleService ruletypes.RuleTypeService,
 	dataSourcesService datasourcessvc.DataSourcesService,
 	ghProviders service.GitHubProviderService,
@@ -152,6 +159,7 @@ func NewServer(
 	sessionService session.ProviderSessionService,
 	projectDeleter projects.ProjectDeleter,
 	projectCreator projects.ProjectCreator,
+	marketplace marketplaces.Marketplace,
 	featureFlagClient *openfeature.Client,
 ) *Server {
 	return &Server{
@@ -163,6 +171,7 @@ func NewServer(
 		mt:                  serverMetrics,
 		profiles:            profileService,
 		history:             historyService,
+		previewer:           previewer,
 		ruleTypes:           ruleService,
 		dataSourcesService:  dataSourcesService,
 		providerStore:       providerStore,
@@ -180,6 +189,7 @@ func NewServer(
 		idClient:            idClient,
 		projectCreator:      projectCreator,
 		projectDeleter:      projectDeleter,
+		marketplace:         marketplace,
 	}
 }
 
@@ -256,6 +266,7 @@ func (s *Server) StartGRPCServer(ctx context.Context) error {
 		logger.Interceptor(s.cfg.LoggingConfig),
 		TokenValidationInterceptor,
 		EntityContextProjectInterceptor,
+		AuditInterceptor,
 		ProjectAuthorizationInterceptor,
 		recovery.UnaryServerInterceptor(recovery.WithRecoveryHandlerContext(recoveryHandler)),
 	}
@@ -475,6 +486,14 @@ func (s *Server) startMetricServer(ctx context.Context) error {
 		return fmt.Errorf("could not initialize instruments: %w", err)
 	}
 
+	if s.cfg.Metrics.Compliance.Enabled {
+		cm, err := metrics.NewComplianceMetrics(s.store, s.cfg.Metrics.Compliance)
+		if err != nil {
+			return fmt.Errorf("could not initialize compliance metrics: %w", err)
+		}
+		go cm.Run(ctx)
+	}
+
 	handler := promhttp.Handler()
 	mux := http.NewServeMux()
 	mux.Handle(metricsPath, handler)
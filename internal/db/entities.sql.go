@@ -22,7 +22,7 @@ INSERT INTO entity_instances (
     provider_id,
     originated_from
 ) VALUES ($1, $2, $3, $4, $5)
-RETURNING id, entity_type, name, project_id, provider_id, created_at, originated_from
+RETURNING id, entity_type, name, project_id, provider_id, created_at, originated_from, reminder_last_sent
 `
 
 type CreateEntityParams struct {
@@ -51,6 +51,7 @@ func (q *Queries) CreateEntity(ctx context.Context, arg CreateEntityParams) (Ent
 		&i.ProviderID,
 		&i.CreatedAt,
 		&i.OriginatedFrom,
+		&i.ReminderLastSent,
 	)
 	return i, err
 }
@@ -65,7 +66,7 @@ INSERT INTO entity_instances (
     provider_id,
     originated_from
 ) VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, entity_type, name, project_id, provider_id, created_at, originated_from
+RETURNING id, entity_type, name, project_id, provider_id, created_at, originated_from, reminder_last_sent
 `
 
 type CreateEntityWithIDParams struct {
@@ -96,6 +97,7 @@ func (q *Queries) CreateEntityWithID(ctx context.Context, arg CreateEntityWithID
 		&i.ProviderID,
 		&i.CreatedAt,
 		&i.OriginatedFrom,
+		&i.ReminderLastSent,
 	)
 	return i, err
 }
@@ -113,7 +115,7 @@ INSERT INTO entity_instances (
 ON CONFLICT (id) DO UPDATE
 SET
     id = entity_instances.id  -- This is a "noop" update to ensure the RETURNING clause works
-RETURNING id, entity_type, name, project_id, provider_id, created_at, originated_from
+RETURNING id, entity_type, name, project_id, provider_id, created_at, originated_from, reminder_last_sent
 `
 
 type CreateOrEnsureEntityByIDParams struct {
@@ -144,6 +146,7 @@ func (q *Queries) CreateOrEnsureEntityByID(ctx context.Context, arg CreateOrEnsu
 		&i.ProviderID,
 		&i.CreatedAt,
 		&i.OriginatedFrom,
+		&i.ReminderLastSent,
 	)
 	return i, err
 }
@@ -189,6 +192,27 @@ func (q *Queries) DeleteProperty(ctx context.Context, arg DeletePropertyParams)
 	return err
 }
 
+const entityExistsAfterID = `-- name: EntityExistsAfterID :one
+SELECT EXISTS (
+  SELECT 1
+  FROM entity_instances
+  WHERE entity_instances.entity_type = $1
+    AND entity_instances.id > $2)
+AS exists
+`
+
+type EntityExistsAfterIDParams struct {
+	EntityType Entities  `json:"entity_type"`
+	ID         uuid.UUID `json:"id"`
+}
+
+func (q *Queries) EntityExistsAfterID(ctx context.Context, arg EntityExistsAfterIDParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, entityExistsAfterID, arg.EntityType, arg.ID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const getAllPropertiesForEntity = `-- name: GetAllPropertiesForEntity :many
 SELECT id, entity_id, key, value, updated_at FROM properties
 WHERE entity_id = $1
@@ -225,7 +249,7 @@ func (q *Queries) GetAllPropertiesForEntity(ctx context.Context, entityID uuid.U
 
 const getEntitiesByProjectHierarchy = `-- name: GetEntitiesByProjectHierarchy :many
 
-SELECT id, entity_type, name, project_id, provider_id, created_at, originated_from FROM entity_instances
+SELECT id, entity_type, name, project_id, provider_id, created_at, originated_from, reminder_last_sent FROM entity_instances
 WHERE entity_instances.project_id = ANY($1::uuid[])
 `
 
@@ -247,6 +271,7 @@ func (q *Queries) GetEntitiesByProjectHierarchy(ctx context.Context, projects []
 			&i.ProviderID,
 			&i.CreatedAt,
 			&i.OriginatedFrom,
+			&i.ReminderLastSent,
 		); err != nil {
 			return nil, err
 		}
@@ -263,7 +288,7 @@ func (q *Queries) GetEntitiesByProjectHierarchy(ctx context.Context, projects []
 
 const getEntitiesByProvider = `-- name: GetEntitiesByProvider :many
 
-SELECT id, entity_type, name, project_id, provider_id, created_at, originated_from FROM entity_instances
+SELECT id, entity_type, name, project_id, provider_id, created_at, originated_from, reminder_last_sent FROM entity_instances
 WHERE entity_instances.provider_id = $1
 `
 
@@ -286,6 +311,7 @@ func (q *Queries) GetEntitiesByProvider(ctx context.Context, providerID uuid.UUI
 			&i.ProviderID,
 			&i.CreatedAt,
 			&i.OriginatedFrom,
+			&i.ReminderLastSent,
 		); err != nil {
 			return nil, err
 		}
@@ -302,7 +328,7 @@ func (q *Queries) GetEntitiesByProvider(ctx context.Context, providerID uuid.UUI
 
 const getEntitiesByType = `-- name: GetEntitiesByType :many
 
-SELECT id, entity_type, name, project_id, provider_id, created_at, originated_from FROM entity_instances
+SELECT id, entity_type, name, project_id, provider_id, created_at, originated_from, reminder_last_sent FROM entity_instances
 WHERE entity_instances.entity_type = $1
     AND entity_instances.provider_id = $2
     AND entity_instances.project_id = ANY($3::uuid[])
@@ -333,6 +359,7 @@ func (q *Queries) GetEntitiesByType(ctx context.Context, arg GetEntitiesByTypePa
 			&i.ProviderID,
 			&i.CreatedAt,
 			&i.OriginatedFrom,
+			&i.ReminderLastSent,
 		); err != nil {
 			return nil, err
 		}
@@ -348,7 +375,7 @@ func (q *Queries) GetEntitiesByType(ctx context.Context, arg GetEntitiesByTypePa
 }
 
 const getEntityByID = `-- name: GetEntityByID :one
-SELECT id, entity_type, name, project_id, provider_id, created_at, originated_from FROM entity_instances
+SELECT id, entity_type, name, project_id, provider_id, created_at, originated_from, reminder_last_sent FROM entity_instances
 WHERE entity_instances.id = $1
 LIMIT 1
 `
@@ -365,12 +392,13 @@ func (q *Queries) GetEntityByID(ctx context.Context, id uuid.UUID) (EntityInstan
 		&i.ProviderID,
 		&i.CreatedAt,
 		&i.OriginatedFrom,
+		&i.ReminderLastSent,
 	)
 	return i, err
 }
 
 const getEntityByName = `-- name: GetEntityByName :one
-SELECT id, entity_type, name, project_id, provider_id, created_at, originated_from FROM entity_instances
+SELECT id, entity_type, name, project_id, provider_id, created_at, originated_from, reminder_last_sent FROM entity_instances
 WHERE
     entity_instances.name = $3
     AND entity_instances.project_id = $1
@@ -403,6 +431,7 @@ func (q *Queries) GetEntityByName(ctx context.Context, arg GetEntityByNameParams
 		&i.ProviderID,
 		&i.CreatedAt,
 		&i.OriginatedFrom,
+		&i.ReminderLastSent,
 	)
 	return i, err
 }
@@ -431,7 +460,7 @@ func (q *Queries) GetProperty(ctx context.Context, arg GetPropertyParams) (Prope
 }
 
 const getTypedEntitiesByProperty = `-- name: GetTypedEntitiesByProperty :many
-SELECT ei.id, ei.entity_type, ei.name, ei.project_id, ei.provider_id, ei.created_at, ei.originated_from
+SELECT ei.id, ei.entity_type, ei.name, ei.project_id, ei.provider_id, ei.created_at, ei.originated_from, ei.reminder_last_sent
 FROM entity_instances ei
          JOIN properties p ON ei.id = p.entity_id
 WHERE ei.entity_type = $1
@@ -472,6 +501,56 @@ func (q *Queries) GetTypedEntitiesByProperty(ctx context.Context, arg GetTypedEn
 			&i.ProviderID,
 			&i.CreatedAt,
 			&i.OriginatedFrom,
+			&i.ReminderLastSent,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEntitiesAfterID = `-- name: ListEntitiesAfterID :many
+
+SELECT id, entity_type, name, project_id, provider_id, created_at, originated_from, reminder_last_sent FROM entity_instances
+WHERE entity_instances.entity_type = $1
+    AND entity_instances.id > $2
+ORDER BY entity_instances.id
+LIMIT $3::bigint
+`
+
+type ListEntitiesAfterIDParams struct {
+	EntityType Entities  `json:"entity_type"`
+	ID         uuid.UUID `json:"id"`
+	Limit      int64     `json:"limit"`
+}
+
+// ListEntitiesAfterID lists the entities of a given type with an ID greater
+// than the cursor. It is used by the reminder to page through all entities.
+func (q *Queries) ListEntitiesAfterID(ctx context.Context, arg ListEntitiesAfterIDParams) ([]EntityInstance, error) {
+	rows, err := q.db.QueryContext(ctx, listEntitiesAfterID, arg.EntityType, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []EntityInstance{}
+	for rows.Next() {
+		var i EntityInstance
+		if err := rows.Scan(
+			&i.ID,
+			&i.EntityType,
+			&i.Name,
+			&i.ProjectID,
+			&i.ProviderID,
+			&i.CreatedAt,
+			&i.OriginatedFrom,
+			&i.ReminderLastSent,
 		); err != nil {
 			return nil, err
 		}
@@ -486,6 +565,17 @@ func (q *Queries) GetTypedEntitiesByProperty(ctx context.Context, arg GetTypedEn
 	return items, nil
 }
 
+const updateReminderLastSentForEntities = `-- name: UpdateReminderLastSentForEntities :exec
+UPDATE entity_instances
+SET reminder_last_sent = NOW()
+WHERE id = ANY ($1::uuid[])
+`
+
+func (q *Queries) UpdateReminderLastSentForEntities(ctx context.Context, entityIds []uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, updateReminderLastSentForEntities, pq.Array(entityIds))
+	return err
+}
+
 const upsertProperty = `-- name: UpsertProperty :one
 INSERT INTO properties (
     entity_id,
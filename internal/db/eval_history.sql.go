@@ -263,6 +263,44 @@ func (q *Queries) InsertRemediationEvent(ctx context.Context, arg InsertRemediat
 	return err
 }
 
+const listAlertEventsByDelivery = `-- name: ListAlertEventsByDelivery :many
+
+SELECT id, evaluation_id, status, details, metadata, created_at FROM alert_events
+WHERE metadata->'deliveries' @> jsonb_build_array(jsonb_build_object('event_id', $1::text))
+`
+
+// ListAlertEventsByDelivery lists the alert events whose metadata records the
+// delivery of the given webhook event.
+func (q *Queries) ListAlertEventsByDelivery(ctx context.Context, eventID string) ([]AlertEvent, error) {
+	rows, err := q.db.QueryContext(ctx, listAlertEventsByDelivery, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AlertEvent{}
+	for rows.Next() {
+		var i AlertEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.EvaluationID,
+			&i.Status,
+			&i.Details,
+			&i.Metadata,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listEvaluationHistory = `-- name: ListEvaluationHistory :many
 SELECT s.id::uuid AS evaluation_id,
        s.evaluation_time as evaluated_at,
@@ -494,6 +532,220 @@ func (q *Queries) ListEvaluationHistoryStaleRecords(ctx context.Context, arg Lis
 	return items, nil
 }
 
+const listEvaluationRemediationTimes = `-- name: ListEvaluationRemediationTimes :many
+WITH streaks AS (
+    SELECT s.rule_entity_id,
+           s.evaluation_time,
+           s.status,
+           CASE $1::text
+               WHEN 'rule_type' THEN rt.id
+               WHEN 'entity' THEN ei.id
+               ELSE p.id
+           END AS group_id,
+           CASE $1::text
+               WHEN 'rule_type' THEN rt.name
+               WHEN 'entity' THEN ei.name
+               ELSE p.name
+           END AS group_name,
+           COUNT(*) FILTER (WHERE s.status = 'success') OVER (
+               PARTITION BY s.rule_entity_id ORDER BY s.evaluation_time
+               ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING
+           ) AS streak
+      FROM evaluation_statuses s
+      JOIN evaluation_rule_entities ere ON ere.id = s.rule_entity_id
+      JOIN rule_instances ri ON ere.rule_id = ri.id
+      JOIN rule_type rt ON ri.rule_type_id = rt.id
+      JOIN profiles p ON ri.profile_id = p.id
+      JOIN entity_instances ei ON ere.entity_instance_id = ei.id
+     WHERE ei.project_id = $2
+       -- failures preceding the time range are needed to compute
+       -- the time to remediate
+       AND s.evaluation_time < $3::timestamp without time zone
+), remediations AS (
+    SELECT st.group_id,
+           st.group_name,
+           MIN(st.evaluation_time) FILTER (WHERE st.status = 'failure') AS failed_at,
+           MAX(st.evaluation_time) FILTER (WHERE st.status = 'success') AS remediated_at
+      FROM streaks st
+     GROUP BY st.rule_entity_id, st.streak, st.group_id, st.group_name
+)
+SELECT r.group_id::uuid AS group_id,
+       r.group_name::text AS group_name,
+       COUNT(*) AS remediated_count,
+       AVG(EXTRACT(EPOCH FROM r.remediated_at - r.failed_at))::float8 AS mean_seconds
+  FROM remediations r
+ WHERE r.failed_at IS NOT NULL
+   AND r.remediated_at >= $4::timestamp without time zone
+ GROUP BY r.group_id, r.group_name
+`
+
+type ListEvaluationRemediationTimesParams struct {
+	GroupBy   string    `json:"group_by"`
+	ProjectID uuid.UUID `json:"project_id"`
+	Tots      time.Time `json:"tots"`
+	Fromts    time.Time `json:"fromts"`
+}
+
+type ListEvaluationRemediationTimesRow struct {
+	GroupID         uuid.UUID `json:"group_id"`
+	GroupName       string    `json:"group_name"`
+	RemediatedCount int64     `json:"remediated_count"`
+	MeanSeconds     float64   `json:"mean_seconds"`
+}
+
+// ListEvaluationRemediationTimes computes, per profile, rule type or
+// entity, the number of failures remediated within the time range and
+// the mean time between the first failure of a rule/entity pair and the
+// next successful evaluation. Each successful evaluation closes a
+// "streak" made of the evaluations following the previous success.
+func (q *Queries) ListEvaluationRemediationTimes(ctx context.Context, arg ListEvaluationRemediationTimesParams) ([]ListEvaluationRemediationTimesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listEvaluationRemediationTimes,
+		arg.GroupBy,
+		arg.ProjectID,
+		arg.Tots,
+		arg.Fromts,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListEvaluationRemediationTimesRow{}
+	for rows.Next() {
+		var i ListEvaluationRemediationTimesRow
+		if err := rows.Scan(
+			&i.GroupID,
+			&i.GroupName,
+			&i.RemediatedCount,
+			&i.MeanSeconds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEvaluationTrends = `-- name: ListEvaluationTrends :many
+WITH bucketed AS (
+    SELECT DISTINCT ON (s.rule_entity_id, date_trunc($1::text, s.evaluation_time))
+           date_trunc($1::text, s.evaluation_time) AS bucket_start,
+           CASE $2::text
+               WHEN 'rule_type' THEN rt.id
+               WHEN 'entity' THEN ei.id
+               ELSE p.id
+           END AS group_id,
+           CASE $2::text
+               WHEN 'rule_type' THEN rt.name
+               WHEN 'entity' THEN ei.name
+               ELSE p.name
+           END AS group_name,
+           s.status
+      FROM evaluation_statuses s
+      JOIN evaluation_rule_entities ere ON ere.id = s.rule_entity_id
+      JOIN rule_instances ri ON ere.rule_id = ri.id
+      JOIN rule_type rt ON ri.rule_type_id = rt.id
+      JOIN profiles p ON ri.profile_id = p.id
+      JOIN entity_instances ei ON ere.entity_instance_id = ei.id
+     WHERE ei.project_id = $3
+       AND s.evaluation_time >= $4::timestamp without time zone
+       AND s.evaluation_time < $5::timestamp without time zone
+     ORDER BY s.rule_entity_id, date_trunc($1::text, s.evaluation_time), s.evaluation_time DESC
+)
+SELECT b.bucket_start::timestamp without time zone AS bucket_start,
+       b.group_id::uuid AS group_id,
+       b.group_name::text AS group_name,
+       COUNT(*) FILTER (WHERE b.status = 'success') AS success_count,
+       COUNT(*) FILTER (WHERE b.status = 'failure') AS failure_count,
+       COUNT(*) FILTER (WHERE b.status = 'error') AS error_count,
+       COUNT(*) FILTER (WHERE b.status = 'skipped') AS skipped_count,
+       COUNT(*) FILTER (WHERE b.status = 'exempt') AS exempt_count
+  FROM bucketed b
+ GROUP BY b.group_id, b.group_name, b.bucket_start
+ ORDER BY b.group_name, b.group_id, b.bucket_start
+`
+
+type ListEvaluationTrendsParams struct {
+	Bucket    string    `json:"bucket"`
+	GroupBy   string    `json:"group_by"`
+	ProjectID uuid.UUID `json:"project_id"`
+	Fromts    time.Time `json:"fromts"`
+	Tots      time.Time `json:"tots"`
+}
+
+type ListEvaluationTrendsRow struct {
+	BucketStart  time.Time `json:"bucket_start"`
+	GroupID      uuid.UUID `json:"group_id"`
+	GroupName    string    `json:"group_name"`
+	SuccessCount int64     `json:"success_count"`
+	FailureCount int64     `json:"failure_count"`
+	ErrorCount   int64     `json:"error_count"`
+	SkippedCount int64     `json:"skipped_count"`
+	ExemptCount  int64     `json:"exempt_count"`
+}
+
+// ListEvaluationTrends counts the last evaluation status of each
+// rule/entity pair within each time bucket, grouped by profile, rule
+// type or entity.
+func (q *Queries) ListEvaluationTrends(ctx context.Context, arg ListEvaluationTrendsParams) ([]ListEvaluationTrendsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listEvaluationTrends,
+		arg.Bucket,
+		arg.GroupBy,
+		arg.ProjectID,
+		arg.Fromts,
+		arg.Tots,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListEvaluationTrendsRow{}
+	for rows.Next() {
+		var i ListEvaluationTrendsRow
+		if err := rows.Scan(
+			&i.BucketStart,
+			&i.GroupID,
+			&i.GroupName,
+			&i.SuccessCount,
+			&i.FailureCount,
+			&i.ErrorCount,
+			&i.SkippedCount,
+			&i.ExemptCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateAlertEventMetadata = `-- name: UpdateAlertEventMetadata :exec
+
+UPDATE alert_events SET metadata = $2 WHERE id = $1
+`
+
+type UpdateAlertEventMetadataParams struct {
+	ID       uuid.UUID       `json:"id"`
+	Metadata json.RawMessage `json:"metadata"`
+}
+
+// UpdateAlertEventMetadata replaces the metadata of an alert event.
+func (q *Queries) UpdateAlertEventMetadata(ctx context.Context, arg UpdateAlertEventMetadataParams) error {
+	_, err := q.db.ExecContext(ctx, updateAlertEventMetadata, arg.ID, arg.Metadata)
+	return err
+}
+
 const upsertLatestEvaluationStatus = `-- name: UpsertLatestEvaluationStatus :exec
 INSERT INTO latest_evaluation_statuses(
     rule_entity_id,
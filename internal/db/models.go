@@ -158,6 +158,7 @@ const (
 	EntitiesPipelineRun      Entities = "pipeline_run"
 	EntitiesTaskRun          Entities = "task_run"
 	EntitiesBuild            Entities = "build"
+	EntitiesOrganization     Entities = "organization"
 )
 
 func (e *Entities) Scan(src interface{}) error {
@@ -203,6 +204,7 @@ const (
 	EvalStatusTypesError   EvalStatusTypes = "error"
 	EvalStatusTypesSkipped EvalStatusTypes = "skipped"
 	EvalStatusTypesPending EvalStatusTypes = "pending"
+	EvalStatusTypesExempt  EvalStatusTypes = "exempt"
 )
 
 func (e *EvalStatusTypes) Scan(src interface{}) error {
@@ -248,6 +250,8 @@ const (
 	ProviderClassGhcr      ProviderClass = "ghcr"
 	ProviderClassDockerhub ProviderClass = "dockerhub"
 	ProviderClassGitlab    ProviderClass = "gitlab"
+	ProviderClassBitbucket ProviderClass = "bitbucket"
+	ProviderClassOci       ProviderClass = "oci"
 )
 
 func (e *ProviderClass) Scan(src interface{}) error {
@@ -489,12 +493,47 @@ type Artifact struct {
 	ProviderName       string        `json:"provider_name"`
 }
 
+type AuditEvent struct {
+	ID        uuid.UUID             `json:"id"`
+	CreatedAt time.Time             `json:"created_at"`
+	ProjectID uuid.NullUUID         `json:"project_id"`
+	Actor     string                `json:"actor"`
+	ActorName string                `json:"actor_name"`
+	Rpc       string                `json:"rpc"`
+	Target    string                `json:"target"`
+	Request   json.RawMessage       `json:"request"`
+	Status    string                `json:"status"`
+	Changes   pqtype.NullRawMessage `json:"changes"`
+}
+
 type Bundle struct {
 	ID        uuid.UUID `json:"id"`
 	Namespace string    `json:"namespace"`
 	Name      string    `json:"name"`
 }
 
+type CachedIdentity struct {
+	Issuer        string    `json:"issuer"`
+	Subject       string    `json:"subject"`
+	HumanName     string    `json:"human_name"`
+	Email         string    `json:"email"`
+	FirstName     string    `json:"first_name"`
+	LastName      string    `json:"last_name"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	EmailVerified bool      `json:"email_verified"`
+}
+
+type CustomRole struct {
+	ID          uuid.UUID `json:"id"`
+	ProjectID   uuid.UUID `json:"project_id"`
+	Name        string    `json:"name"`
+	DisplayName string    `json:"display_name"`
+	Description string    `json:"description"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
 type DataSource struct {
 	ID             uuid.UUID     `json:"id"`
 	Name           string        `json:"name"`
@@ -533,13 +572,14 @@ type EntityExecutionLock struct {
 }
 
 type EntityInstance struct {
-	ID             uuid.UUID     `json:"id"`
-	EntityType     Entities      `json:"entity_type"`
-	Name           string        `json:"name"`
-	ProjectID      uuid.UUID     `json:"project_id"`
-	ProviderID     uuid.UUID     `json:"provider_id"`
-	CreatedAt      time.Time     `json:"created_at"`
-	OriginatedFrom uuid.NullUUID `json:"originated_from"`
+	ID               uuid.UUID     `json:"id"`
+	EntityType       Entities      `json:"entity_type"`
+	Name             string        `json:"name"`
+	ProjectID        uuid.UUID     `json:"project_id"`
+	ProviderID       uuid.UUID     `json:"provider_id"`
+	CreatedAt        time.Time     `json:"created_at"`
+	OriginatedFrom   uuid.NullUUID `json:"originated_from"`
+	ReminderLastSent sql.NullTime  `json:"reminder_last_sent"`
 }
 
 type EntityProfile struct {
@@ -593,18 +633,21 @@ type LatestEvaluationStatus struct {
 }
 
 type Profile struct {
-	ID             uuid.UUID      `json:"id"`
-	Name           string         `json:"name"`
-	Provider       sql.NullString `json:"provider"`
-	ProjectID      uuid.UUID      `json:"project_id"`
-	Remediate      NullActionType `json:"remediate"`
-	Alert          NullActionType `json:"alert"`
-	CreatedAt      time.Time      `json:"created_at"`
-	UpdatedAt      time.Time      `json:"updated_at"`
-	ProviderID     uuid.NullUUID  `json:"provider_id"`
-	SubscriptionID uuid.NullUUID  `json:"subscription_id"`
-	DisplayName    string         `json:"display_name"`
-	Labels         []string       `json:"labels"`
+	ID                   uuid.UUID      `json:"id"`
+	Name                 string         `json:"name"`
+	Provider             sql.NullString `json:"provider"`
+	ProjectID            uuid.UUID      `json:"project_id"`
+	Remediate            NullActionType `json:"remediate"`
+	Alert                NullActionType `json:"alert"`
+	CreatedAt            time.Time      `json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at"`
+	ProviderID           uuid.NullUUID  `json:"provider_id"`
+	SubscriptionID       uuid.NullUUID  `json:"subscription_id"`
+	DisplayName          string         `json:"display_name"`
+	Labels               []string       `json:"labels"`
+	ScheduleCron         string         `json:"schedule_cron"`
+	ScheduleMinFreshness string         `json:"schedule_min_freshness"`
+	Inherited            bool           `json:"inherited"`
 }
 
 type ProfileSelector struct {
@@ -726,6 +769,20 @@ type Repository struct {
 	ReminderLastSent sql.NullTime   `json:"reminder_last_sent"`
 }
 
+type RuleException struct {
+	ID               uuid.UUID      `json:"id"`
+	ProjectID        uuid.UUID      `json:"project_id"`
+	RuleInstanceID   uuid.UUID      `json:"rule_instance_id"`
+	EntityInstanceID uuid.UUID      `json:"entity_instance_id"`
+	Reason           string         `json:"reason"`
+	Approver         string         `json:"approver"`
+	CreatedBy        string         `json:"created_by"`
+	ExpiresAt        time.Time      `json:"expires_at"`
+	CreatedAt        time.Time      `json:"created_at"`
+	RevokedBy        sql.NullString `json:"revoked_by"`
+	RevokedAt        sql.NullTime   `json:"revoked_at"`
+}
+
 type RuleInstance struct {
 	ID         uuid.UUID       `json:"id"`
 	ProfileID  uuid.UUID       `json:"profile_id"`
@@ -784,6 +841,15 @@ type Subscription struct {
 	CurrentVersion string    `json:"current_version"`
 }
 
+type SubscriptionContent struct {
+	SubscriptionID  uuid.UUID `json:"subscription_id"`
+	ContentType     string    `json:"content_type"`
+	Name            string    `json:"name"`
+	BundleDigest    string    `json:"bundle_digest"`
+	InstalledDigest string    `json:"installed_digest"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
 type User struct {
 	ID              int32     `json:"id"`
 	IdentitySubject string    `json:"identity_subject"`
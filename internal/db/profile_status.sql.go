@@ -15,6 +15,68 @@ import (
 	"github.com/lib/pq"
 )
 
+const countEntitiesByEvaluationStatus = `-- name: CountEntitiesByEvaluationStatus :many
+SELECT ei.project_id,
+       (CASE WHEN $1::boolean THEN p.name ELSE '' END)::text AS profile_name,
+       (CASE WHEN $2::boolean THEN rt.name ELSE '' END)::text AS rule_type_name,
+       es.status,
+       COUNT(DISTINCT ere.entity_instance_id) AS num_entities
+FROM latest_evaluation_statuses les
+    INNER JOIN evaluation_rule_entities ere ON ere.id = les.rule_entity_id
+    INNER JOIN evaluation_statuses es ON es.id = les.evaluation_history_id
+    INNER JOIN rule_instances ri ON ri.id = ere.rule_id
+    INNER JOIN rule_type rt ON rt.id = ri.rule_type_id
+    INNER JOIN profiles p ON p.id = ri.profile_id
+    INNER JOIN entity_instances ei ON ei.id = ere.entity_instance_id
+GROUP BY ei.project_id, profile_name, rule_type_name, es.status
+`
+
+type CountEntitiesByEvaluationStatusParams struct {
+	ByProfile  bool `json:"by_profile"`
+	ByRuleType bool `json:"by_rule_type"`
+}
+
+type CountEntitiesByEvaluationStatusRow struct {
+	ProjectID    uuid.UUID       `json:"project_id"`
+	ProfileName  string          `json:"profile_name"`
+	RuleTypeName string          `json:"rule_type_name"`
+	Status       EvalStatusTypes `json:"status"`
+	NumEntities  int64           `json:"num_entities"`
+}
+
+// CountEntitiesByEvaluationStatus counts the entities per latest
+// evaluation status, project, profile and rule type. The profile and
+// rule type names are left empty unless requested, which reduces the
+// number of groups.
+func (q *Queries) CountEntitiesByEvaluationStatus(ctx context.Context, arg CountEntitiesByEvaluationStatusParams) ([]CountEntitiesByEvaluationStatusRow, error) {
+	rows, err := q.db.QueryContext(ctx, countEntitiesByEvaluationStatus, arg.ByProfile, arg.ByRuleType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountEntitiesByEvaluationStatusRow{}
+	for rows.Next() {
+		var i CountEntitiesByEvaluationStatusRow
+		if err := rows.Scan(
+			&i.ProjectID,
+			&i.ProfileName,
+			&i.RuleTypeName,
+			&i.Status,
+			&i.NumEntities,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getProfileStatusByIdAndProject = `-- name: GetProfileStatusByIdAndProject :one
 SELECT p.id, p.name, ps.profile_status, ps.last_updated FROM profile_status ps
 INNER JOIN profiles p ON p.id = ps.profile_id
@@ -116,34 +178,173 @@ func (q *Queries) GetProfileStatusByProject(ctx context.Context, projectID uuid.
 	return items, nil
 }
 
-const listOldestRuleEvaluationsByRepositoryId = `-- name: ListOldestRuleEvaluationsByRepositoryId :many
+const listInheritedProfileStatusByProject = `-- name: ListInheritedProfileStatusByProject :many
+
+SELECT p.id, p.name,
+    (CASE
+        WHEN bool_or(es.status = 'error') THEN 'error'
+        WHEN bool_or(es.status = 'failure') THEN 'failure'
+        WHEN bool_or(es.status = 'success') THEN 'success'
+        WHEN bool_or(es.status IN ('skipped', 'exempt')) THEN 'skipped'
+        ELSE 'pending'
+    END)::eval_status_types AS profile_status,
+    COALESCE(MAX(es.evaluation_time), p.updated_at)::timestamp AS last_updated
+FROM profiles AS p
+    LEFT JOIN rule_instances AS ri ON ri.profile_id = p.id
+    LEFT JOIN evaluation_rule_entities AS ere ON ere.rule_id = ri.id
+        AND ere.entity_instance_id IN (SELECT id FROM entity_instances WHERE project_id = $1)
+    LEFT JOIN latest_evaluation_statuses AS les ON les.rule_entity_id = ere.id
+    LEFT JOIN evaluation_statuses AS es ON es.id = les.evaluation_history_id
+WHERE p.inherited AND p.project_id = ANY($2::uuid[])
+GROUP BY p.id
+ORDER BY p.name
+`
+
+type ListInheritedProfileStatusByProjectParams struct {
+	ProjectID uuid.UUID   `json:"project_id"`
+	ParentIds []uuid.UUID `json:"parent_ids"`
+}
+
+type ListInheritedProfileStatusByProjectRow struct {
+	ID            uuid.UUID       `json:"id"`
+	Name          string          `json:"name"`
+	ProfileStatus EvalStatusTypes `json:"profile_status"`
+	LastUpdated   time.Time       `json:"last_updated"`
+}
+
+// ListInheritedProfileStatusByProject lists the profiles a project inherits
+// from its parent projects. Their status is computed from the latest
+// evaluations of the project's own entities, with the same precedence as the
+// profile_status table: error, failure, success and then skipped, or pending
+// if no rule was evaluated yet.
+func (q *Queries) ListInheritedProfileStatusByProject(ctx context.Context, arg ListInheritedProfileStatusByProjectParams) ([]ListInheritedProfileStatusByProjectRow, error) {
+	rows, err := q.db.QueryContext(ctx, listInheritedProfileStatusByProject, arg.ProjectID, pq.Array(arg.ParentIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListInheritedProfileStatusByProjectRow{}
+	for rows.Next() {
+		var i ListInheritedProfileStatusByProjectRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.ProfileStatus,
+			&i.LastUpdated,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOldestRuleEvaluationsByEntityID = `-- name: ListOldestRuleEvaluationsByEntityID :many
 
-SELECT ere.entity_instance_id::uuid AS repository_id, MIN(es.evaluation_time)::timestamp AS oldest_last_updated
+SELECT ere.entity_instance_id::uuid AS entity_id, MIN(es.evaluation_time)::timestamp AS oldest_last_updated
 FROM evaluation_rule_entities AS ere
     INNER JOIN latest_evaluation_statuses AS les ON ere.id = les.rule_entity_id
     INNER JOIN evaluation_statuses AS es ON les.evaluation_history_id = es.id
+    INNER JOIN profiles AS p ON les.profile_id = p.id
 WHERE ere.entity_instance_id = ANY ($1::uuid[])
-AND ere.entity_type = 'repository'
+    -- profiles with a schedule are evaluated according to it instead
+    AND p.schedule_cron = '' AND p.schedule_min_freshness = ''
 GROUP BY ere.entity_instance_id
 `
 
-type ListOldestRuleEvaluationsByRepositoryIdRow struct {
-	RepositoryID      uuid.UUID `json:"repository_id"`
+type ListOldestRuleEvaluationsByEntityIDRow struct {
+	EntityID          uuid.UUID `json:"entity_id"`
 	OldestLastUpdated time.Time `json:"oldest_last_updated"`
 }
 
-// ListOldestRuleEvaluationsByRepositoryId has casts in select statement as sqlc generates incorrect types.
+// ListOldestRuleEvaluationsByEntityID has casts in select statement as sqlc generates incorrect types.
 // cast after MIN is required due to a known bug in sqlc: https://github.com/sqlc-dev/sqlc/issues/1965
-func (q *Queries) ListOldestRuleEvaluationsByRepositoryId(ctx context.Context, repositoryIds []uuid.UUID) ([]ListOldestRuleEvaluationsByRepositoryIdRow, error) {
-	rows, err := q.db.QueryContext(ctx, listOldestRuleEvaluationsByRepositoryId, pq.Array(repositoryIds))
+func (q *Queries) ListOldestRuleEvaluationsByEntityID(ctx context.Context, entityIds []uuid.UUID) ([]ListOldestRuleEvaluationsByEntityIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, listOldestRuleEvaluationsByEntityID, pq.Array(entityIds))
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []ListOldestRuleEvaluationsByRepositoryIdRow{}
+	items := []ListOldestRuleEvaluationsByEntityIDRow{}
 	for rows.Next() {
-		var i ListOldestRuleEvaluationsByRepositoryIdRow
-		if err := rows.Scan(&i.RepositoryID, &i.OldestLastUpdated); err != nil {
+		var i ListOldestRuleEvaluationsByEntityIDRow
+		if err := rows.Scan(&i.EntityID, &i.OldestLastUpdated); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProfileEvaluationTimesByEntity = `-- name: ListProfileEvaluationTimesByEntity :many
+
+WITH RECURSIVE profile_projects AS (
+    SELECT projects.id FROM projects
+    WHERE projects.id = $1
+
+    UNION
+
+    SELECT p.id FROM projects p
+    INNER JOIN profile_projects pp ON p.parent_id = pp.id
+    INNER JOIN profiles pr ON pr.id = $2 AND pr.inherited
+)
+SELECT ei.id AS entity_id, ei.entity_type, ei.project_id, ei.provider_id,
+    (CASE WHEN bool_and(es.id IS NOT NULL) THEN MIN(es.evaluation_time) END)::timestamp AS oldest_last_updated
+FROM entity_instances AS ei
+    INNER JOIN rule_instances AS ri ON ri.entity_type = ei.entity_type AND ri.profile_id = $2
+    LEFT JOIN evaluation_rule_entities AS ere ON ere.rule_id = ri.id AND ere.entity_instance_id = ei.id
+    LEFT JOIN latest_evaluation_statuses AS les ON les.rule_entity_id = ere.id
+    LEFT JOIN evaluation_statuses AS es ON les.evaluation_history_id = es.id
+WHERE ei.project_id IN (SELECT id FROM profile_projects)
+GROUP BY ei.id
+ORDER BY ei.id
+`
+
+type ListProfileEvaluationTimesByEntityParams struct {
+	ProjectID uuid.UUID `json:"project_id"`
+	ProfileID uuid.UUID `json:"profile_id"`
+}
+
+type ListProfileEvaluationTimesByEntityRow struct {
+	EntityID          uuid.UUID    `json:"entity_id"`
+	EntityType        Entities     `json:"entity_type"`
+	ProjectID         uuid.UUID    `json:"project_id"`
+	ProviderID        uuid.UUID    `json:"provider_id"`
+	OldestLastUpdated sql.NullTime `json:"oldest_last_updated"`
+}
+
+// ListProfileEvaluationTimesByEntity lists the entities in the project tree of a
+// profile which the profile has rules for, along with the oldest time the rules
+// were last evaluated for each entity. The time is NULL if any of the rules was
+// never evaluated for the entity.
+func (q *Queries) ListProfileEvaluationTimesByEntity(ctx context.Context, arg ListProfileEvaluationTimesByEntityParams) ([]ListProfileEvaluationTimesByEntityRow, error) {
+	rows, err := q.db.QueryContext(ctx, listProfileEvaluationTimesByEntity, arg.ProjectID, arg.ProfileID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProfileEvaluationTimesByEntityRow{}
+	for rows.Next() {
+		var i ListProfileEvaluationTimesByEntityRow
+		if err := rows.Scan(
+			&i.EntityID,
+			&i.EntityType,
+			&i.ProjectID,
+			&i.ProviderID,
+			&i.OldestLastUpdated,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -23,7 +23,7 @@ WITH helper AS(
     WHERE pr.id = ANY($1::UUID[])
     GROUP BY pr.id
 )
-SELECT profiles.id, profiles.name, profiles.provider, profiles.project_id, profiles.remediate, profiles.alert, profiles.created_at, profiles.updated_at, profiles.provider_id, profiles.subscription_id, profiles.display_name, profiles.labels,
+SELECT profiles.id, profiles.name, profiles.provider, profiles.project_id, profiles.remediate, profiles.alert, profiles.created_at, profiles.updated_at, profiles.provider_id, profiles.subscription_id, profiles.display_name, profiles.labels, profiles.schedule_cron, profiles.schedule_min_freshness, profiles.inherited,
        helper.selectors::profile_selector[] AS profiles_with_selectors
 FROM profiles
 LEFT JOIN helper ON profiles.id = helper.profid
@@ -57,6 +57,9 @@ func (q *Queries) BulkGetProfilesByID(ctx context.Context, profileIds []uuid.UUI
 			&i.Profile.SubscriptionID,
 			&i.Profile.DisplayName,
 			pq.Array(&i.Profile.Labels),
+			&i.Profile.ScheduleCron,
+			&i.Profile.ScheduleMinFreshness,
+			&i.Profile.Inherited,
 			pq.Array(&i.ProfilesWithSelectors),
 		); err != nil {
 			return nil, err
@@ -139,18 +142,25 @@ INSERT INTO profiles (
     name,
     subscription_id,
     display_name,
-    labels
-) VALUES ($1, $2, $3, $4, $5, $6, COALESCE($7::text[], '{}'::text[])) RETURNING id, name, provider, project_id, remediate, alert, created_at, updated_at, provider_id, subscription_id, display_name, labels
+    labels,
+    schedule_cron,
+    schedule_min_freshness,
+    inherited
+) VALUES ($1, $2, $3, $4, $5, $6, COALESCE($7::text[], '{}'::text[]),
+    $8, $9, $10) RETURNING id, name, provider, project_id, remediate, alert, created_at, updated_at, provider_id, subscription_id, display_name, labels, schedule_cron, schedule_min_freshness, inherited
 `
 
 type CreateProfileParams struct {
-	ProjectID      uuid.UUID      `json:"project_id"`
-	Remediate      NullActionType `json:"remediate"`
-	Alert          NullActionType `json:"alert"`
-	Name           string         `json:"name"`
-	SubscriptionID uuid.NullUUID  `json:"subscription_id"`
-	DisplayName    string         `json:"display_name"`
-	Labels         []string       `json:"labels"`
+	ProjectID            uuid.UUID      `json:"project_id"`
+	Remediate            NullActionType `json:"remediate"`
+	Alert                NullActionType `json:"alert"`
+	Name                 string         `json:"name"`
+	SubscriptionID       uuid.NullUUID  `json:"subscription_id"`
+	DisplayName          string         `json:"display_name"`
+	Labels               []string       `json:"labels"`
+	ScheduleCron         string         `json:"schedule_cron"`
+	ScheduleMinFreshness string         `json:"schedule_min_freshness"`
+	Inherited            bool           `json:"inherited"`
 }
 
 func (q *Queries) CreateProfile(ctx context.Context, arg CreateProfileParams) (Profile, error) {
@@ -162,6 +172,9 @@ func (q *Queries) CreateProfile(ctx context.Context, arg CreateProfileParams) (P
 		arg.SubscriptionID,
 		arg.DisplayName,
 		pq.Array(arg.Labels),
+		arg.ScheduleCron,
+		arg.ScheduleMinFreshness,
+		arg.Inherited,
 	)
 	var i Profile
 	err := row.Scan(
@@ -177,6 +190,9 @@ func (q *Queries) CreateProfile(ctx context.Context, arg CreateProfileParams) (P
 		&i.SubscriptionID,
 		&i.DisplayName,
 		pq.Array(&i.Labels),
+		&i.ScheduleCron,
+		&i.ScheduleMinFreshness,
+		&i.Inherited,
 	)
 	return i, err
 }
@@ -246,7 +262,7 @@ func (q *Queries) DeleteProfileForEntity(ctx context.Context, arg DeleteProfileF
 }
 
 const getProfileByID = `-- name: GetProfileByID :one
-SELECT id, name, provider, project_id, remediate, alert, created_at, updated_at, provider_id, subscription_id, display_name, labels FROM profiles WHERE id = $1 AND project_id = $2
+SELECT id, name, provider, project_id, remediate, alert, created_at, updated_at, provider_id, subscription_id, display_name, labels, schedule_cron, schedule_min_freshness, inherited FROM profiles WHERE id = $1 AND project_id = $2
 `
 
 type GetProfileByIDParams struct {
@@ -270,12 +286,15 @@ func (q *Queries) GetProfileByID(ctx context.Context, arg GetProfileByIDParams)
 		&i.SubscriptionID,
 		&i.DisplayName,
 		pq.Array(&i.Labels),
+		&i.ScheduleCron,
+		&i.ScheduleMinFreshness,
+		&i.Inherited,
 	)
 	return i, err
 }
 
 const getProfileByIDAndLock = `-- name: GetProfileByIDAndLock :one
-SELECT id, name, provider, project_id, remediate, alert, created_at, updated_at, provider_id, subscription_id, display_name, labels FROM profiles WHERE id = $1 AND project_id = $2 FOR UPDATE
+SELECT id, name, provider, project_id, remediate, alert, created_at, updated_at, provider_id, subscription_id, display_name, labels, schedule_cron, schedule_min_freshness, inherited FROM profiles WHERE id = $1 AND project_id = $2 FOR UPDATE
 `
 
 type GetProfileByIDAndLockParams struct {
@@ -299,12 +318,15 @@ func (q *Queries) GetProfileByIDAndLock(ctx context.Context, arg GetProfileByIDA
 		&i.SubscriptionID,
 		&i.DisplayName,
 		pq.Array(&i.Labels),
+		&i.ScheduleCron,
+		&i.ScheduleMinFreshness,
+		&i.Inherited,
 	)
 	return i, err
 }
 
 const getProfileByNameAndLock = `-- name: GetProfileByNameAndLock :one
-SELECT id, name, provider, project_id, remediate, alert, created_at, updated_at, provider_id, subscription_id, display_name, labels FROM profiles WHERE lower(name) = lower($2) AND project_id = $1 FOR UPDATE
+SELECT id, name, provider, project_id, remediate, alert, created_at, updated_at, provider_id, subscription_id, display_name, labels, schedule_cron, schedule_min_freshness, inherited FROM profiles WHERE lower(name) = lower($2) AND project_id = $1 FOR UPDATE
 `
 
 type GetProfileByNameAndLockParams struct {
@@ -328,6 +350,9 @@ func (q *Queries) GetProfileByNameAndLock(ctx context.Context, arg GetProfileByN
 		&i.SubscriptionID,
 		&i.DisplayName,
 		pq.Array(&i.Labels),
+		&i.ScheduleCron,
+		&i.ScheduleMinFreshness,
+		&i.Inherited,
 	)
 	return i, err
 }
@@ -343,7 +368,7 @@ WITH helper AS(
     GROUP BY pr.id
 )
 SELECT
-    profiles.id, profiles.name, profiles.provider, profiles.project_id, profiles.remediate, profiles.alert, profiles.created_at, profiles.updated_at, profiles.provider_id, profiles.subscription_id, profiles.display_name, profiles.labels,
+    profiles.id, profiles.name, profiles.provider, profiles.project_id, profiles.remediate, profiles.alert, profiles.created_at, profiles.updated_at, profiles.provider_id, profiles.subscription_id, profiles.display_name, profiles.labels, profiles.schedule_cron, profiles.schedule_min_freshness, profiles.inherited,
     profiles_with_entity_profiles.id, profiles_with_entity_profiles.entity, profiles_with_entity_profiles.profile_id, profiles_with_entity_profiles.contextual_rules, profiles_with_entity_profiles.created_at, profiles_with_entity_profiles.updated_at, profiles_with_entity_profiles.profid,
     helper.selectors::profile_selector[] AS profiles_with_selectors
 FROM profiles
@@ -385,6 +410,9 @@ func (q *Queries) GetProfileByProjectAndID(ctx context.Context, arg GetProfileBy
 			&i.Profile.SubscriptionID,
 			&i.Profile.DisplayName,
 			pq.Array(&i.Profile.Labels),
+			&i.Profile.ScheduleCron,
+			&i.Profile.ScheduleMinFreshness,
+			&i.Profile.Inherited,
 			&i.ProfilesWithEntityProfile.ID,
 			&i.ProfilesWithEntityProfile.Entity,
 			&i.ProfilesWithEntityProfile.ProfileID,
@@ -418,7 +446,7 @@ WITH helper AS(
     GROUP BY pr.id
 )
 SELECT
-    profiles.id, profiles.name, profiles.provider, profiles.project_id, profiles.remediate, profiles.alert, profiles.created_at, profiles.updated_at, profiles.provider_id, profiles.subscription_id, profiles.display_name, profiles.labels,
+    profiles.id, profiles.name, profiles.provider, profiles.project_id, profiles.remediate, profiles.alert, profiles.created_at, profiles.updated_at, profiles.provider_id, profiles.subscription_id, profiles.display_name, profiles.labels, profiles.schedule_cron, profiles.schedule_min_freshness, profiles.inherited,
     profiles_with_entity_profiles.id, profiles_with_entity_profiles.entity, profiles_with_entity_profiles.profile_id, profiles_with_entity_profiles.contextual_rules, profiles_with_entity_profiles.created_at, profiles_with_entity_profiles.updated_at, profiles_with_entity_profiles.profid,
     helper.selectors::profile_selector[] AS profiles_with_selectors
 FROM profiles
@@ -460,6 +488,9 @@ func (q *Queries) GetProfileByProjectAndName(ctx context.Context, arg GetProfile
 			&i.Profile.SubscriptionID,
 			&i.Profile.DisplayName,
 			pq.Array(&i.Profile.Labels),
+			&i.Profile.ScheduleCron,
+			&i.Profile.ScheduleMinFreshness,
+			&i.Profile.Inherited,
 			&i.ProfilesWithEntityProfile.ID,
 			&i.ProfilesWithEntityProfile.Entity,
 			&i.ProfilesWithEntityProfile.ProfileID,
@@ -492,7 +523,7 @@ WITH helper AS(
       WHERE pr.project_id = $1
       GROUP BY pr.id
 )
-SELECT profiles.id, profiles.name, profiles.provider, profiles.project_id, profiles.remediate, profiles.alert, profiles.created_at, profiles.updated_at, profiles.provider_id, profiles.subscription_id, profiles.display_name, profiles.labels,
+SELECT profiles.id, profiles.name, profiles.provider, profiles.project_id, profiles.remediate, profiles.alert, profiles.created_at, profiles.updated_at, profiles.provider_id, profiles.subscription_id, profiles.display_name, profiles.labels, profiles.schedule_cron, profiles.schedule_min_freshness, profiles.inherited,
        profiles_with_entity_profiles.id, profiles_with_entity_profiles.entity, profiles_with_entity_profiles.profile_id, profiles_with_entity_profiles.contextual_rules, profiles_with_entity_profiles.created_at, profiles_with_entity_profiles.updated_at, profiles_with_entity_profiles.profid,
        helper.selectors::profile_selector[] AS profiles_with_selectors
 FROM profiles
@@ -549,6 +580,9 @@ func (q *Queries) ListProfilesByProjectIDAndLabel(ctx context.Context, arg ListP
 			&i.Profile.SubscriptionID,
 			&i.Profile.DisplayName,
 			pq.Array(&i.Profile.Labels),
+			&i.Profile.ScheduleCron,
+			&i.Profile.ScheduleMinFreshness,
+			&i.Profile.Inherited,
 			&i.ProfilesWithEntityProfile.ID,
 			&i.ProfilesWithEntityProfile.Entity,
 			&i.ProfilesWithEntityProfile.ProfileID,
@@ -601,23 +635,74 @@ func (q *Queries) ListProfilesInstantiatingRuleType(ctx context.Context, ruleTyp
 	return items, nil
 }
 
+const listScheduledProfiles = `-- name: ListScheduledProfiles :many
+SELECT id, name, provider, project_id, remediate, alert, created_at, updated_at, provider_id, subscription_id, display_name, labels, schedule_cron, schedule_min_freshness, inherited FROM profiles
+WHERE schedule_cron != '' OR schedule_min_freshness != ''
+ORDER BY id
+`
+
+func (q *Queries) ListScheduledProfiles(ctx context.Context) ([]Profile, error) {
+	rows, err := q.db.QueryContext(ctx, listScheduledProfiles)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Profile{}
+	for rows.Next() {
+		var i Profile
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Provider,
+			&i.ProjectID,
+			&i.Remediate,
+			&i.Alert,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ProviderID,
+			&i.SubscriptionID,
+			&i.DisplayName,
+			pq.Array(&i.Labels),
+			&i.ScheduleCron,
+			&i.ScheduleMinFreshness,
+			&i.Inherited,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateProfile = `-- name: UpdateProfile :one
 UPDATE profiles SET
     remediate = $3,
     alert = $4,
     updated_at = NOW(),
     display_name = $5,
-    labels = COALESCE($6::TEXT[], '{}'::TEXT[])
-WHERE id = $1 AND project_id = $2 RETURNING id, name, provider, project_id, remediate, alert, created_at, updated_at, provider_id, subscription_id, display_name, labels
+    labels = COALESCE($6::TEXT[], '{}'::TEXT[]),
+    schedule_cron = $7,
+    schedule_min_freshness = $8,
+    inherited = $9
+WHERE id = $1 AND project_id = $2 RETURNING id, name, provider, project_id, remediate, alert, created_at, updated_at, provider_id, subscription_id, display_name, labels, schedule_cron, schedule_min_freshness, inherited
 `
 
 type UpdateProfileParams struct {
-	ID          uuid.UUID      `json:"id"`
-	ProjectID   uuid.UUID      `json:"project_id"`
-	Remediate   NullActionType `json:"remediate"`
-	Alert       NullActionType `json:"alert"`
-	DisplayName string         `json:"display_name"`
-	Labels      []string       `json:"labels"`
+	ID                   uuid.UUID      `json:"id"`
+	ProjectID            uuid.UUID      `json:"project_id"`
+	Remediate            NullActionType `json:"remediate"`
+	Alert                NullActionType `json:"alert"`
+	DisplayName          string         `json:"display_name"`
+	Labels               []string       `json:"labels"`
+	ScheduleCron         string         `json:"schedule_cron"`
+	ScheduleMinFreshness string         `json:"schedule_min_freshness"`
+	Inherited            bool           `json:"inherited"`
 }
 
 func (q *Queries) UpdateProfile(ctx context.Context, arg UpdateProfileParams) (Profile, error) {
@@ -628,6 +713,9 @@ func (q *Queries) UpdateProfile(ctx context.Context, arg UpdateProfileParams) (P
 		arg.Alert,
 		arg.DisplayName,
 		pq.Array(arg.Labels),
+		arg.ScheduleCron,
+		arg.ScheduleMinFreshness,
+		arg.Inherited,
 	)
 	var i Profile
 	err := row.Scan(
@@ -643,6 +731,9 @@ func (q *Queries) UpdateProfile(ctx context.Context, arg UpdateProfileParams) (P
 		&i.SubscriptionID,
 		&i.DisplayName,
 		pq.Array(&i.Labels),
+		&i.ScheduleCron,
+		&i.ScheduleMinFreshness,
+		&i.Inherited,
 	)
 	return i, err
 }
@@ -8,6 +8,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -20,12 +21,22 @@ type Querier interface {
 	//
 	AddRuleTypeDataSourceReference(ctx context.Context, arg AddRuleTypeDataSourceReferenceParams) (RuleTypeDataSource, error)
 	BulkGetProfilesByID(ctx context.Context, profileIds []uuid.UUID) ([]BulkGetProfilesByIDRow, error)
+	// CountAuditEventsBefore counts the audit events recorded before the
+	// threshold.
+	CountAuditEventsBefore(ctx context.Context, threshold time.Time) (int64, error)
+	// CountEntitiesByEvaluationStatus counts the entities per latest
+	// evaluation status, project, profile and rule type. The profile and
+	// rule type names are left empty unless requested, which reduces the
+	// number of groups.
+	CountEntitiesByEvaluationStatus(ctx context.Context, arg CountEntitiesByEvaluationStatusParams) ([]CountEntitiesByEvaluationStatusRow, error)
 	CountProfilesByEntityType(ctx context.Context) ([]CountProfilesByEntityTypeRow, error)
 	CountProfilesByName(ctx context.Context, name string) (int64, error)
 	CountProfilesByProjectID(ctx context.Context, projectID uuid.UUID) (int64, error)
 	CountRepositories(ctx context.Context) (int64, error)
 	CountRepositoriesByProjectID(ctx context.Context, projectID uuid.UUID) (int64, error)
 	CountUsers(ctx context.Context) (int64, error)
+	// CreateCustomRole defines a new custom role in a project.
+	CreateCustomRole(ctx context.Context, arg CreateCustomRoleParams) (CustomRole, error)
 	// CreateDataSource creates a new datasource in a given project.
 	CreateDataSource(ctx context.Context, arg CreateDataSourceParams) (DataSource, error)
 	CreateEntitlements(ctx context.Context, arg CreateEntitlementsParams) error
@@ -47,6 +58,9 @@ type Querier interface {
 	CreateProjectWithID(ctx context.Context, arg CreateProjectWithIDParams) (Project, error)
 	CreateProvider(ctx context.Context, arg CreateProviderParams) (Provider, error)
 	CreateRepository(ctx context.Context, arg CreateRepositoryParams) (Repository, error)
+	// CreateRuleException exempts an entity from a rule instance until
+	// the given expiry.
+	CreateRuleException(ctx context.Context, arg CreateRuleExceptionParams) (RuleException, error)
 	CreateRuleType(ctx context.Context, arg CreateRuleTypeParams) (RuleType, error)
 	CreateSelector(ctx context.Context, arg CreateSelectorParams) (ProfileSelector, error)
 	CreateSessionState(ctx context.Context, arg CreateSessionStateParams) (SessionStore, error)
@@ -55,6 +69,13 @@ type Querier interface {
 	CreateUser(ctx context.Context, identitySubject string) (User, error)
 	DeleteAllPropertiesForEntity(ctx context.Context, entityID uuid.UUID) error
 	DeleteArtifact(ctx context.Context, id uuid.UUID) error
+	// DeleteAuditEventsBefore deletes a batch of the audit events recorded
+	// before the threshold, returning the number of deleted events.
+	DeleteAuditEventsBefore(ctx context.Context, arg DeleteAuditEventsBeforeParams) (int64, error)
+	// DeleteCachedIdentity deletes the cached identity of a user of an issuer.
+	DeleteCachedIdentity(ctx context.Context, arg DeleteCachedIdentityParams) error
+	// DeleteCustomRole deletes a custom role from a project, returning it.
+	DeleteCustomRole(ctx context.Context, arg DeleteCustomRoleParams) (CustomRole, error)
 	DeleteDataSource(ctx context.Context, arg DeleteDataSourceParams) (DataSource, error)
 	DeleteDataSourceFunction(ctx context.Context, arg DeleteDataSourceFunctionParams) (DataSourcesFunction, error)
 	// DeleteDataSourceFunctions deletes all functions associated with a given datasource
@@ -84,6 +105,7 @@ type Querier interface {
 	DeleteSessionStateByProjectID(ctx context.Context, arg DeleteSessionStateByProjectIDParams) error
 	DeleteUser(ctx context.Context, id int32) error
 	EnqueueFlush(ctx context.Context, arg EnqueueFlushParams) (FlushCache, error)
+	EntityExistsAfterID(ctx context.Context, arg EntityExistsAfterIDParams) (bool, error)
 	// FindProviders allows us to take a trait and filter
 	// providers by it. It also optionally takes a name, in case we want to
 	// filter by name as well.
@@ -93,11 +115,17 @@ type Querier interface {
 	GetAccessTokenByProjectID(ctx context.Context, arg GetAccessTokenByProjectIDParams) (ProviderAccessToken, error)
 	GetAccessTokenByProvider(ctx context.Context, provider string) ([]ProviderAccessToken, error)
 	GetAccessTokenSinceDate(ctx context.Context, arg GetAccessTokenSinceDateParams) (ProviderAccessToken, error)
+	// GetActiveRuleException returns the exception which is furthest from
+	// expiring for the given rule instance and entity, if any.
+	GetActiveRuleException(ctx context.Context, arg GetActiveRuleExceptionParams) (RuleException, error)
 	GetAllPropertiesForEntity(ctx context.Context, entityID uuid.UUID) ([]Property, error)
 	GetArtifactByID(ctx context.Context, arg GetArtifactByIDParams) (Artifact, error)
 	GetArtifactByName(ctx context.Context, arg GetArtifactByNameParams) (Artifact, error)
 	GetBundle(ctx context.Context, arg GetBundleParams) (Bundle, error)
 	GetChildrenProjects(ctx context.Context, id uuid.UUID) ([]GetChildrenProjectsRow, error)
+	// GetCustomRoleByName returns the custom role with the given name, as defined
+	// in the given project.
+	GetCustomRoleByName(ctx context.Context, arg GetCustomRoleByNameParams) (CustomRole, error)
 	// GetDataSource retrieves a datasource by its id and a project hierarchy.
 	//
 	// Note that to get a datasource for a given project, one can simply
@@ -176,6 +204,7 @@ type Querier interface {
 	GetRepositoryByRepoID(ctx context.Context, repoID int64) (Repository, error)
 	GetRepositoryByRepoName(ctx context.Context, arg GetRepositoryByRepoNameParams) (Repository, error)
 	GetRootProjectByID(ctx context.Context, id uuid.UUID) (Project, error)
+	GetRuleInstanceByProfileAndName(ctx context.Context, arg GetRuleInstanceByProfileAndNameParams) (RuleInstance, error)
 	GetRuleInstancesEntityInProjects(ctx context.Context, arg GetRuleInstancesEntityInProjectsParams) ([]RuleInstance, error)
 	GetRuleInstancesForProfile(ctx context.Context, profileID uuid.UUID) ([]RuleInstance, error)
 	GetRuleTypeByID(ctx context.Context, id uuid.UUID) (RuleType, error)
@@ -197,11 +226,24 @@ type Querier interface {
 	GlobalListProviders(ctx context.Context) ([]Provider, error)
 	GlobalListProvidersByClass(ctx context.Context, class ProviderClass) ([]Provider, error)
 	InsertAlertEvent(ctx context.Context, arg InsertAlertEventParams) error
+	// InsertAuditEvent records a mutating API call.
+	InsertAuditEvent(ctx context.Context, arg InsertAuditEventParams) error
 	InsertEvaluationRuleEntity(ctx context.Context, arg InsertEvaluationRuleEntityParams) (uuid.UUID, error)
 	InsertEvaluationStatus(ctx context.Context, arg InsertEvaluationStatusParams) (uuid.UUID, error)
 	InsertRemediationEvent(ctx context.Context, arg InsertRemediationEventParams) error
+	// ListAlertEventsByDelivery lists the alert events whose metadata records the
+	// delivery of the given webhook event.
+	ListAlertEventsByDelivery(ctx context.Context, eventID string) ([]AlertEvent, error)
 	ListAllRootProjects(ctx context.Context) ([]Project, error)
 	ListArtifactsByRepoID(ctx context.Context, repositoryID uuid.NullUUID) ([]Artifact, error)
+	// ListAuditEvents lists the audit events of a project, from newest to
+	// oldest. The cursor is the ID of the last event of the previous page.
+	ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditEvent, error)
+	// ListCachedIdentities returns the identities of an issuer whose subject,
+	// human-readable name or verified email match the given identifier.
+	ListCachedIdentities(ctx context.Context, arg ListCachedIdentitiesParams) ([]CachedIdentity, error)
+	// ListCustomRolesByProject returns the custom roles defined in a project.
+	ListCustomRolesByProject(ctx context.Context, projectID uuid.UUID) ([]CustomRole, error)
 	// ListDataSourceFunctions retrieves all functions for a datasource.
 	ListDataSourceFunctions(ctx context.Context, arg ListDataSourceFunctionsParams) ([]DataSourcesFunction, error)
 	// ListDataSources retrieves all datasources for project hierarchy.
@@ -209,17 +251,42 @@ type Querier interface {
 	// Note that to get a datasource for a given project, one can simply
 	// pass one project id in the project_id array.
 	ListDataSources(ctx context.Context, projects []uuid.UUID) ([]DataSource, error)
+	// ListEntitiesAfterID lists the entities of a given type with an ID greater
+	// than the cursor. It is used by the reminder to page through all entities.
+	ListEntitiesAfterID(ctx context.Context, arg ListEntitiesAfterIDParams) ([]EntityInstance, error)
 	ListEvaluationHistory(ctx context.Context, arg ListEvaluationHistoryParams) ([]ListEvaluationHistoryRow, error)
 	ListEvaluationHistoryStaleRecords(ctx context.Context, arg ListEvaluationHistoryStaleRecordsParams) ([]ListEvaluationHistoryStaleRecordsRow, error)
+	// ListEvaluationRemediationTimes computes, per profile, rule type or
+	// entity, the number of failures remediated within the time range and
+	// the mean time between the first failure of a rule/entity pair and the
+	// next successful evaluation. Each successful evaluation closes a
+	// "streak" made of the evaluations following the previous success.
+	ListEvaluationRemediationTimes(ctx context.Context, arg ListEvaluationRemediationTimesParams) ([]ListEvaluationRemediationTimesRow, error)
+	// ListEvaluationTrends counts the last evaluation status of each
+	// rule/entity pair within each time bucket, grouped by profile, rule
+	// type or entity.
+	ListEvaluationTrends(ctx context.Context, arg ListEvaluationTrendsParams) ([]ListEvaluationTrendsRow, error)
 	ListFlushCache(ctx context.Context) ([]FlushCache, error)
+	// ListInheritedProfileStatusByProject lists the profiles a project inherits
+	// from its parent projects. Their status is computed from the latest
+	// evaluations of the project's own entities, with the same precedence as the
+	// profile_status table: error, failure, success and then skipped, or pending
+	// if no rule was evaluated yet.
+	ListInheritedProfileStatusByProject(ctx context.Context, arg ListInheritedProfileStatusByProjectParams) ([]ListInheritedProfileStatusByProjectRow, error)
 	// ListInvitationsForProject collects the information visible to project
 	// administrators after an invitation has been issued.  In particular, it
 	// *does not* report the invitation code, which is a secret intended for
 	// the invitee.
 	ListInvitationsForProject(ctx context.Context, project uuid.UUID) ([]ListInvitationsForProjectRow, error)
-	// ListOldestRuleEvaluationsByRepositoryId has casts in select statement as sqlc generates incorrect types.
+	// ListOldestRuleEvaluationsByEntityID has casts in select statement as sqlc generates incorrect types.
 	// cast after MIN is required due to a known bug in sqlc: https://github.com/sqlc-dev/sqlc/issues/1965
-	ListOldestRuleEvaluationsByRepositoryId(ctx context.Context, repositoryIds []uuid.UUID) ([]ListOldestRuleEvaluationsByRepositoryIdRow, error)
+	ListOldestRuleEvaluationsByEntityID(ctx context.Context, entityIds []uuid.UUID) ([]ListOldestRuleEvaluationsByEntityIDRow, error)
+	// ListProfileEvaluationTimesByEntity lists the entities which a profile has
+	// rules for, along with the oldest time the rules were last evaluated for each
+	// entity. The time is NULL if any of the rules was never evaluated for the
+	// entity. The entities of the descendant projects of the profile's project are
+	// included if the profile is inherited.
+	ListProfileEvaluationTimesByEntity(ctx context.Context, arg ListProfileEvaluationTimesByEntityParams) ([]ListProfileEvaluationTimesByEntityRow, error)
 	ListProfilesByProjectIDAndLabel(ctx context.Context, arg ListProfilesByProjectIDAndLabelParams) ([]ListProfilesByProjectIDAndLabelRow, error)
 	ListProfilesInstantiatingRuleType(ctx context.Context, ruleTypeID uuid.UUID) ([]string, error)
 	// ListProvidersByProjectID allows us to list all providers
@@ -229,14 +296,20 @@ type Querier interface {
 	// with pagination taken into account. In this case, the cursor is the creation date.
 	ListProvidersByProjectIDPaginated(ctx context.Context, arg ListProvidersByProjectIDPaginatedParams) ([]Provider, error)
 	ListRegisteredRepositoriesByProjectIDAndProvider(ctx context.Context, arg ListRegisteredRepositoriesByProjectIDAndProviderParams) ([]Repository, error)
-	ListRepositoriesAfterID(ctx context.Context, arg ListRepositoriesAfterIDParams) ([]Repository, error)
 	ListRepositoriesByProjectID(ctx context.Context, arg ListRepositoriesByProjectIDParams) ([]Repository, error)
 	ListRuleEvaluationsByProfileId(ctx context.Context, arg ListRuleEvaluationsByProfileIdParams) ([]ListRuleEvaluationsByProfileIdRow, error)
+	// ListRuleExceptionsByProject lists all the rule exceptions of a
+	// project, including the expired and revoked ones, together with the profile and
+	// rule they refer to.
+	ListRuleExceptionsByProject(ctx context.Context, projectID uuid.UUID) ([]ListRuleExceptionsByProjectRow, error)
 	ListRuleTypesByProject(ctx context.Context, projectID uuid.UUID) ([]RuleType, error)
 	// ListRuleTypesReferencesByDataSource retrieves all rule types
 	// referencing a given data source in a given project.
 	//
 	ListRuleTypesReferencesByDataSource(ctx context.Context, dataSourcesID uuid.UUID) ([]RuleTypeDataSource, error)
+	ListScheduledProfiles(ctx context.Context) ([]Profile, error)
+	ListSubscriptionContents(ctx context.Context, subscriptionID uuid.UUID) ([]SubscriptionContent, error)
+	ListSubscriptionsByProjects(ctx context.Context, projects []uuid.UUID) ([]ListSubscriptionsByProjectsRow, error)
 	// When doing a key/algorithm rotation, identify the secrets which need to be
 	// rotated. The criteria for rotation are:
 	// 1) The encrypted_access_token is NULL (this should be removed when we make
@@ -259,8 +332,14 @@ type Querier interface {
 	// entity_execution_lock record if the lock is held by the given locked_by
 	// value.
 	ReleaseLock(ctx context.Context, arg ReleaseLockParams) error
-	RepositoryExistsAfterID(ctx context.Context, id uuid.UUID) (bool, error)
+	// RevokeRuleException ends a rule exception before it expires, recording
+	// who revoked it. Revoking an exception again keeps the first revocation.
+	RevokeRuleException(ctx context.Context, arg RevokeRuleExceptionParams) (RuleException, error)
 	SetSubscriptionBundleVersion(ctx context.Context, arg SetSubscriptionBundleVersionParams) error
+	// UpdateAlertEventMetadata replaces the metadata of an alert event.
+	UpdateAlertEventMetadata(ctx context.Context, arg UpdateAlertEventMetadataParams) error
+	// UpdateCustomRole replaces the description and permissions of a custom role.
+	UpdateCustomRole(ctx context.Context, arg UpdateCustomRoleParams) (CustomRole, error)
 	// UpdateDataSource updates a datasource in a given project.
 	UpdateDataSource(ctx context.Context, arg UpdateDataSourceParams) (DataSource, error)
 	// UpdateDataSourceFunction updates a function in a datasource. We're
@@ -275,15 +354,19 @@ type Querier interface {
 	UpdateProfile(ctx context.Context, arg UpdateProfileParams) (Profile, error)
 	UpdateProjectMeta(ctx context.Context, arg UpdateProjectMetaParams) (Project, error)
 	UpdateProvider(ctx context.Context, arg UpdateProviderParams) error
-	UpdateReminderLastSentForRepositories(ctx context.Context, repositoryIds []uuid.UUID) error
+	UpdateReminderLastSentForEntities(ctx context.Context, entityIds []uuid.UUID) error
 	UpdateRuleType(ctx context.Context, arg UpdateRuleTypeParams) (RuleType, error)
 	UpdateSelector(ctx context.Context, arg UpdateSelectorParams) (ProfileSelector, error)
+	UpdateSubscriptionVersion(ctx context.Context, arg UpdateSubscriptionVersionParams) error
 	UpsertAccessToken(ctx context.Context, arg UpsertAccessTokenParams) (ProviderAccessToken, error)
 	UpsertArtifact(ctx context.Context, arg UpsertArtifactParams) (Artifact, error)
 	// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
 	// SPDX-License-Identifier: Apache-2.0
 	// Bundles --
 	UpsertBundle(ctx context.Context, arg UpsertBundleParams) error
+	// UpsertCachedIdentity records the claims of a user of a generic OIDC
+	// identity provider, replacing the ones recorded before.
+	UpsertCachedIdentity(ctx context.Context, arg UpsertCachedIdentityParams) error
 	UpsertInstallationID(ctx context.Context, arg UpsertInstallationIDParams) (ProviderGithubAppInstallation, error)
 	UpsertLatestEvaluationStatus(ctx context.Context, arg UpsertLatestEvaluationStatusParams) error
 	UpsertProfileForEntity(ctx context.Context, arg UpsertProfileForEntityParams) (EntityProfile, error)
@@ -291,6 +374,8 @@ type Querier interface {
 	// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
 	// SPDX-License-Identifier: Apache-2.0
 	UpsertRuleInstance(ctx context.Context, arg UpsertRuleInstanceParams) (uuid.UUID, error)
+	// Subscription contents --
+	UpsertSubscriptionContent(ctx context.Context, arg UpsertSubscriptionContentParams) error
 }
 
 var _ Querier = (*Queries)(nil)
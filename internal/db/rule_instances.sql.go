@@ -46,6 +46,37 @@ func (q *Queries) DeleteRuleInstanceOfProfileInProject(ctx context.Context, arg
 	return err
 }
 
+const getRuleInstanceByProfileAndName = `-- name: GetRuleInstanceByProfileAndName :one
+SELECT id, profile_id, rule_type_id, name, entity_type, def, params, created_at, updated_at, project_id FROM rule_instances
+WHERE profile_id = $1
+AND entity_type = $2
+AND lower(name) = lower($3)
+`
+
+type GetRuleInstanceByProfileAndNameParams struct {
+	ProfileID  uuid.UUID `json:"profile_id"`
+	EntityType Entities  `json:"entity_type"`
+	Name       string    `json:"name"`
+}
+
+func (q *Queries) GetRuleInstanceByProfileAndName(ctx context.Context, arg GetRuleInstanceByProfileAndNameParams) (RuleInstance, error) {
+	row := q.db.QueryRowContext(ctx, getRuleInstanceByProfileAndName, arg.ProfileID, arg.EntityType, arg.Name)
+	var i RuleInstance
+	err := row.Scan(
+		&i.ID,
+		&i.ProfileID,
+		&i.RuleTypeID,
+		&i.Name,
+		&i.EntityType,
+		&i.Def,
+		&i.Params,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ProjectID,
+	)
+	return i, err
+}
+
 const getRuleInstancesEntityInProjects = `-- name: GetRuleInstancesEntityInProjects :many
 SELECT id, profile_id, rule_type_id, name, entity_type, def, params, created_at, updated_at, project_id FROM rule_instances
 WHERE entity_type = $1
@@ -9,6 +9,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const createSubscription = `-- name: CreateSubscription :one
@@ -77,6 +78,86 @@ func (q *Queries) GetSubscriptionByProjectBundle(ctx context.Context, arg GetSub
 	return i, err
 }
 
+const listSubscriptionContents = `-- name: ListSubscriptionContents :many
+SELECT subscription_id, content_type, name, bundle_digest, installed_digest, updated_at FROM subscription_contents WHERE subscription_id = $1
+ORDER BY content_type, name
+`
+
+func (q *Queries) ListSubscriptionContents(ctx context.Context, subscriptionID uuid.UUID) ([]SubscriptionContent, error) {
+	rows, err := q.db.QueryContext(ctx, listSubscriptionContents, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SubscriptionContent{}
+	for rows.Next() {
+		var i SubscriptionContent
+		if err := rows.Scan(
+			&i.SubscriptionID,
+			&i.ContentType,
+			&i.Name,
+			&i.BundleDigest,
+			&i.InstalledDigest,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSubscriptionsByProjects = `-- name: ListSubscriptionsByProjects :many
+SELECT su.id, su.project_id, su.current_version, bu.namespace, bu.name
+FROM subscriptions AS su
+JOIN bundles AS bu ON bu.id = su.bundle_id
+WHERE su.project_id = ANY($1::UUID[])
+ORDER BY su.project_id, bu.namespace, bu.name
+`
+
+type ListSubscriptionsByProjectsRow struct {
+	ID             uuid.UUID `json:"id"`
+	ProjectID      uuid.UUID `json:"project_id"`
+	CurrentVersion string    `json:"current_version"`
+	Namespace      string    `json:"namespace"`
+	Name           string    `json:"name"`
+}
+
+func (q *Queries) ListSubscriptionsByProjects(ctx context.Context, projects []uuid.UUID) ([]ListSubscriptionsByProjectsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSubscriptionsByProjects, pq.Array(projects))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSubscriptionsByProjectsRow{}
+	for rows.Next() {
+		var i ListSubscriptionsByProjectsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProjectID,
+			&i.CurrentVersion,
+			&i.Namespace,
+			&i.Name,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const setSubscriptionBundleVersion = `-- name: SetSubscriptionBundleVersion :exec
 UPDATE subscriptions SET current_version = $2 WHERE project_id = $1
 `
@@ -91,6 +172,20 @@ func (q *Queries) SetSubscriptionBundleVersion(ctx context.Context, arg SetSubsc
 	return err
 }
 
+const updateSubscriptionVersion = `-- name: UpdateSubscriptionVersion :exec
+UPDATE subscriptions SET current_version = $2 WHERE id = $1
+`
+
+type UpdateSubscriptionVersionParams struct {
+	ID             uuid.UUID `json:"id"`
+	CurrentVersion string    `json:"current_version"`
+}
+
+func (q *Queries) UpdateSubscriptionVersion(ctx context.Context, arg UpdateSubscriptionVersionParams) error {
+	_, err := q.db.ExecContext(ctx, updateSubscriptionVersion, arg.ID, arg.CurrentVersion)
+	return err
+}
+
 const upsertBundle = `-- name: UpsertBundle :exec
 
 
@@ -110,3 +205,31 @@ func (q *Queries) UpsertBundle(ctx context.Context, arg UpsertBundleParams) erro
 	_, err := q.db.ExecContext(ctx, upsertBundle, arg.Namespace, arg.Name)
 	return err
 }
+
+const upsertSubscriptionContent = `-- name: UpsertSubscriptionContent :exec
+
+INSERT INTO subscription_contents (subscription_id, content_type, name, bundle_digest, installed_digest)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (subscription_id, content_type, name) DO UPDATE
+SET bundle_digest = $4, installed_digest = $5, updated_at = NOW()
+`
+
+type UpsertSubscriptionContentParams struct {
+	SubscriptionID  uuid.UUID `json:"subscription_id"`
+	ContentType     string    `json:"content_type"`
+	Name            string    `json:"name"`
+	BundleDigest    string    `json:"bundle_digest"`
+	InstalledDigest string    `json:"installed_digest"`
+}
+
+// Subscription contents --
+func (q *Queries) UpsertSubscriptionContent(ctx context.Context, arg UpsertSubscriptionContentParams) error {
+	_, err := q.db.ExecContext(ctx, upsertSubscriptionContent,
+		arg.SubscriptionID,
+		arg.ContentType,
+		arg.Name,
+		arg.BundleDigest,
+		arg.InstalledDigest,
+	)
+	return err
+}
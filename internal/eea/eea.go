@@ -199,6 +199,11 @@ func (e *EEA) FlushMessageHandler(msg *message.Message) error {
 
 	logger.Debug().Msg("re-publishing event because of flush")
 
+	// The flushed events are entity events, so the profile restriction of a
+	// scheduled evaluation must not be carried over. Scheduled evaluations
+	// which were flushed are sent again by the reminder.
+	inf.ProfileID = uuid.Nil
+
 	// Now that we've flushed the event, let's try to publish it again
 	// which means, go through the locking process again.
 	if err := inf.Publish(e.evt); err != nil {
@@ -256,7 +261,8 @@ func (e *EEA) buildEntityWrapper(
 	case db.EntitiesPullRequest:
 		return e.buildPullRequestInfoWrapper(ctx, entityID, projID)
 	case db.EntitiesBuildEnvironment, db.EntitiesRelease,
-		db.EntitiesPipelineRun, db.EntitiesTaskRun, db.EntitiesBuild:
+		db.EntitiesPipelineRun, db.EntitiesTaskRun, db.EntitiesBuild,
+		db.EntitiesOrganization:
 		return nil, fmt.Errorf("entity type %q not yet supported", entity)
 	default:
 		return nil, fmt.Errorf("unknown entity type: %q", entity)
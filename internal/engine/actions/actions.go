@@ -22,6 +22,8 @@ import (
 	enginerr "github.com/mindersec/minder/internal/engine/errors"
 	engif "github.com/mindersec/minder/internal/engine/interfaces"
 	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	serverconfig "github.com/mindersec/minder/pkg/config/server"
+	eventer "github.com/mindersec/minder/pkg/eventer/interfaces"
 	"github.com/mindersec/minder/pkg/profiles/models"
 	provinfv1 "github.com/mindersec/minder/pkg/providers/v1"
 )
@@ -37,6 +39,8 @@ func NewRuleActions(
 	ruletype *minderv1.RuleType,
 	provider provinfv1.Provider,
 	actionConfig *models.ActionConfiguration,
+	alertsCfg *serverconfig.AlertsConfig,
+	evt eventer.Publisher,
 ) (*RuleActionsEngine, error) {
 	// Create the remediation engine
 	remEngine, err := remediate.NewRuleRemediator(ruletype, provider, actionConfig.Remediate)
@@ -45,7 +49,7 @@ func NewRuleActions(
 	}
 
 	// Create the alert engine
-	alertEngine, err := alert.NewRuleAlert(ctx, ruletype, provider, actionConfig.Alert)
+	alertEngine, err := alert.NewRuleAlert(ctx, ruletype, provider, actionConfig.Alert, alertsCfg, evt)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create rule alerter: %w", err)
 	}
@@ -171,6 +175,7 @@ func shouldRemediate(prevEvalFromDb *db.ListRuleEvaluationsByProfileIdRow, evalE
 		return engif.ActionCmdDoNothing
 	case db.EvalStatusTypesSkipped:
 	case db.EvalStatusTypesPending:
+	case db.EvalStatusTypesExempt:
 		return engif.ActionCmdDoNothing
 	}
 
@@ -209,8 +214,7 @@ func shouldAlert(
 
 	// Proceed with use cases where the evaluation changed
 	switch newEval {
-	case db.EvalStatusTypesError:
-	case db.EvalStatusTypesFailure:
+	case db.EvalStatusTypesError, db.EvalStatusTypesFailure:
 		// Case 3 - Evaluation changed from something else to ERROR -> Alert should be ON
 		// Case 4 - Evaluation has changed from something else to FAILED -> Alert should be ON
 		// The Alert should be on (if it wasn't already)
@@ -227,6 +231,13 @@ func shouldAlert(
 		}
 		// We should do nothing if the Alert is already OFF
 		return engif.ActionCmdDoNothing
+	case db.EvalStatusTypesExempt:
+		// Case 6 - A rule exception started covering the entity -> Alert should be OFF
+		// The exception accepts the risk, so there is nothing left to alert about
+		if db.AlertStatusTypesOff != prevAlert {
+			return engif.ActionCmdOff
+		}
+		return engif.ActionCmdDoNothing
 	case db.EvalStatusTypesSkipped:
 	case db.EvalStatusTypesPending:
 		return engif.ActionCmdDoNothing
@@ -267,7 +278,10 @@ func (rae *RuleActionsEngine) isSkippable(ctx context.Context, actionType engif.
 			// rule evaluation was skipped, skip action too
 			errors.Is(evalErr, enginerr.ErrEvaluationSkipped) ||
 				// rule evaluation was skipped silently, skip action
-				errors.Is(evalErr, enginerr.ErrEvaluationSkipSilently)
+				errors.Is(evalErr, enginerr.ErrEvaluationSkipSilently) ||
+				// a rule exception covers the entity, skip action, but keep alerting
+				// so that the alerts opened before the exception are closed
+				(actionType != alert.ActionType && errors.Is(evalErr, enginerr.ErrEvaluationExempt))
 	}
 	logger.Bool("skip_action", skipAction).Msg("action skip decision")
 	// Everything else, do not skip
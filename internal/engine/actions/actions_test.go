@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/internal/engine/actions/alert/webhook"
+	enginerr "github.com/mindersec/minder/internal/engine/errors"
+	engif "github.com/mindersec/minder/internal/engine/interfaces"
+	"github.com/mindersec/minder/internal/events/stubs"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	serverconfig "github.com/mindersec/minder/pkg/config/server"
+	"github.com/mindersec/minder/pkg/profiles/models"
+)
+
+func TestShouldAlert(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		prevAlert db.AlertStatusTypes
+		noPrev    bool
+		evalErr   error
+		remErr    error
+		remType   string
+		expected  engif.ActionCmd
+	}{
+		{
+			name:      "failure turns the alert on",
+			prevAlert: db.AlertStatusTypesOff,
+			evalErr:   enginerr.NewErrEvaluationFailed("bad things"),
+			remErr:    enginerr.ErrActionSkipped,
+			expected:  engif.ActionCmdOn,
+		},
+		{
+			name:      "error turns the alert on",
+			prevAlert: db.AlertStatusTypesOff,
+			evalErr:   errors.New("boom"),
+			remErr:    enginerr.ErrActionSkipped,
+			expected:  engif.ActionCmdOn,
+		},
+		{
+			name:     "error on the first evaluation turns the alert on",
+			noPrev:   true,
+			evalErr:  errors.New("boom"),
+			remErr:   enginerr.ErrActionSkipped,
+			expected: engif.ActionCmdOn,
+		},
+		{
+			name:      "error keeps an alert which is already on",
+			prevAlert: db.AlertStatusTypesOn,
+			evalErr:   errors.New("boom"),
+			remErr:    enginerr.ErrActionSkipped,
+			expected:  engif.ActionCmdDoNothing,
+		},
+		{
+			name:      "success turns the alert off",
+			prevAlert: db.AlertStatusTypesOn,
+			remErr:    enginerr.ErrActionSkipped,
+			expected:  engif.ActionCmdOff,
+		},
+		{
+			name:      "success keeps an alert which is already off",
+			prevAlert: db.AlertStatusTypesOff,
+			remErr:    enginerr.ErrActionSkipped,
+			expected:  engif.ActionCmdDoNothing,
+		},
+		{
+			name:      "exemption turns the alert off",
+			prevAlert: db.AlertStatusTypesOn,
+			evalErr:   enginerr.NewErrEvaluationExempt("accepted risk"),
+			remErr:    enginerr.ErrActionSkipped,
+			expected:  engif.ActionCmdOff,
+		},
+		{
+			name:      "exemption keeps an alert which is already off",
+			prevAlert: db.AlertStatusTypesOff,
+			evalErr:   enginerr.NewErrEvaluationExempt("accepted risk"),
+			remErr:    enginerr.ErrActionSkipped,
+			expected:  engif.ActionCmdDoNothing,
+		},
+		{
+			name:      "successful remediation turns the alert off",
+			prevAlert: db.AlertStatusTypesOn,
+			evalErr:   enginerr.NewErrEvaluationFailed("bad things"),
+			remType:   "rest",
+			expected:  engif.ActionCmdOff,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var prev *db.ListRuleEvaluationsByProfileIdRow
+			if !tt.noPrev {
+				prev = &db.ListRuleEvaluationsByProfileIdRow{AlertStatus: tt.prevAlert}
+			}
+
+			require.Equal(t, tt.expected, shouldAlert(prev, tt.evalErr, tt.remErr, tt.remType))
+		})
+	}
+}
+
+func TestDoActionsQueuesWebhookAlertOnError(t *testing.T) {
+	t.Parallel()
+
+	ruleType := &minderv1.RuleType{
+		Name: "rule_type_1",
+		Def: &minderv1.RuleType_Definition{
+			Alert: &minderv1.RuleType_Definition_Alert{Type: webhook.AlertType},
+		},
+	}
+	alertsCfg := &serverconfig.AlertsConfig{
+		Webhook: serverconfig.WebhookAlertConfig{URL: "https://example.com/events"},
+	}
+	evt := &stubs.StubEventer{}
+
+	rae, err := NewRuleActions(context.Background(), ruleType, nil, &models.ActionConfiguration{
+		Alert: models.ActionOptOn,
+	}, alertsCfg, evt)
+	require.NoError(t, err)
+
+	params := &engif.EvalStatusParams{
+		EvalStatusFromDb: &db.ListRuleEvaluationsByProfileIdRow{
+			EvalStatus:  db.EvalStatusTypesSuccess,
+			AlertStatus: db.AlertStatusTypesOff,
+		},
+		Profile:    &models.ProfileAggregate{Name: "profile"},
+		Rule:       &models.RuleInstance{Name: "rule"},
+		ProjectID:  uuid.New(),
+		EntityType: db.EntitiesRepository,
+		EntityID:   uuid.New(),
+	}
+	params.SetEvalErr(errors.New("boom"))
+
+	result := rae.DoActions(context.Background(), &minderv1.Repository{Owner: "org", Name: "repo"}, params)
+	require.NoError(t, result.AlertErr)
+	require.Equal(t, []string{webhook.TopicQueueAlertWebhookDelivery}, evt.Topics)
+	require.Len(t, evt.Sent, 1)
+
+	var delivery webhook.DeliveryEvent
+	require.NoError(t, json.Unmarshal(evt.Sent[0].Payload, &delivery))
+	require.Equal(t, webhook.EventTypeError, delivery.EventType)
+	require.Equal(t, "https://example.com/events", delivery.URL)
+}
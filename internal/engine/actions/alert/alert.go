@@ -11,11 +11,15 @@ import (
 
 	"github.com/rs/zerolog"
 
+	"github.com/mindersec/minder/internal/engine/actions/alert/chat"
 	"github.com/mindersec/minder/internal/engine/actions/alert/noop"
 	"github.com/mindersec/minder/internal/engine/actions/alert/pull_request_comment"
 	"github.com/mindersec/minder/internal/engine/actions/alert/security_advisory"
+	"github.com/mindersec/minder/internal/engine/actions/alert/webhook"
 	engif "github.com/mindersec/minder/internal/engine/interfaces"
 	pb "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	serverconfig "github.com/mindersec/minder/pkg/config/server"
+	eventer "github.com/mindersec/minder/pkg/eventer/interfaces"
 	"github.com/mindersec/minder/pkg/profiles/models"
 	provinfv1 "github.com/mindersec/minder/pkg/providers/v1"
 )
@@ -29,6 +33,8 @@ func NewRuleAlert(
 	ruletype *pb.RuleType,
 	provider provinfv1.Provider,
 	setting models.ActionOpt,
+	alertsCfg *serverconfig.AlertsConfig,
+	evt eventer.Publisher,
 ) (engif.Action, error) {
 	alertCfg := ruletype.Def.GetAlert()
 	if alertCfg == nil {
@@ -61,6 +67,20 @@ func NewRuleAlert(
 		}
 		return pull_request_comment.NewPullRequestCommentAlert(
 			ActionType, alertCfg.GetPullRequestComment(), client, setting)
+	case webhook.AlertType:
+		if alertsCfg == nil || alertsCfg.Webhook.URL == "" {
+			zerolog.Ctx(ctx).Debug().Str("rule-type", ruletype.GetName()).
+				Msg("webhook alerts are not configured. Silently skipping alerts.")
+			return noop.NewNoopAlert(ActionType)
+		}
+		return webhook.NewWebhookAlert(ActionType, ruletype, &alertsCfg.Webhook, evt, setting)
+	case chat.AlertType:
+		if alertsCfg == nil || !alertsCfg.Chat.IsConfigured() {
+			zerolog.Ctx(ctx).Debug().Str("rule-type", ruletype.GetName()).
+				Msg("chat alerts are not configured. Silently skipping alerts.")
+			return noop.NewNoopAlert(ActionType)
+		}
+		return chat.NewChatAlert(ActionType, ruletype, &alertsCfg.Chat, setting)
 	}
 
 	return nil, fmt.Errorf("unknown alert type: %s", alertCfg.GetType())
@@ -0,0 +1,479 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package chat provides necessary interfaces and implementations for
+// creating alerts of type chat, which post messages to Slack or Microsoft
+// Teams incoming webhooks, or to a Slack channel through the Slack Web API.
+package chat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/internal/engine/actions/alert/common"
+	enginerr "github.com/mindersec/minder/internal/engine/errors"
+	"github.com/mindersec/minder/internal/engine/eval/rego"
+	"github.com/mindersec/minder/internal/engine/interfaces"
+	pb "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	serverconfig "github.com/mindersec/minder/pkg/config/server"
+	"github.com/mindersec/minder/pkg/profiles/models"
+)
+
+const (
+	// AlertType is the type of the chat alert engine
+	AlertType = "chat"
+
+	// KindSlack is the kind of Slack incoming webhooks
+	KindSlack = "slack"
+	// KindTeams is the kind of Microsoft Teams incoming webhooks
+	KindTeams = "teams"
+
+	tmplTitleName       = "title"
+	tmplTitle           = `Minder: rule {{.Rule}} is failing on {{.EntityName}}`
+	tmplResolvedName    = "resolved"
+	tmplResolved        = `Minder: rule {{.Rule}} is passing again on {{.EntityName}}`
+	tmplDescriptionName = "description"
+	tmplDescription     = `Profile {{.Profile}} found a problem with {{.EntityType}} {{.EntityName}}.
+{{- if .Details}}
+
+{{.Details}}
+{{- end}}`
+	tmplResolvedTextName = "resolved_text"
+	tmplResolvedText     = `The problem reported{{if .SentAt}} at {{.SentAt}}{{end}} by rule {{.Rule}} ` +
+		`of profile {{.Profile}} has been fixed.`
+)
+
+// Alert is the structure backing the chat alert action
+type Alert struct {
+	actionType   interfaces.ActionType
+	ruleType     *pb.RuleType
+	kind         string
+	webhookURL   string
+	token        string
+	channel      string
+	apiURL       string
+	cli          *http.Client
+	titleTmpl    *template.Template
+	resolvedTmpl *template.Template
+	descTmpl     *template.Template
+	resolvedText *template.Template
+	linkTmpl     *template.Template
+	setting      models.ActionOpt
+}
+
+// templateParams are the parameters the message templates are rendered with
+type templateParams struct {
+	ProjectID  uuid.UUID
+	Profile    string
+	Rule       string
+	RuleType   string
+	Severity   string
+	Guidance   string
+	EntityType string
+	EntityID   uuid.UUID
+	EntityName string
+	Details    string
+	// SentAt is the time the failure was reported, only set when resolving
+	SentAt string
+}
+
+// message is the chat-service agnostic content of a notification
+type message struct {
+	Title    string
+	Text     string
+	Guidance string
+	Link     string
+	Facts    [][2]string
+	Resolved bool
+}
+
+type alertMetadata struct {
+	// SentAt is the time the failure message was posted
+	SentAt *time.Time `json:"sent_at,omitempty"`
+	// Title is the title of the failure message
+	Title string `json:"title,omitempty"`
+	// ResolvedAt is the time the resolution message was posted
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	// Channel is the Slack channel the failure message was posted to with the Web API
+	Channel string `json:"channel,omitempty"`
+	// TS is the Slack timestamp identifying the failure message posted with the Web API
+	TS string `json:"ts,omitempty"`
+}
+
+type paramsChat struct {
+	tmpl       templateParams
+	metadata   *alertMetadata
+	prevStatus *db.ListRuleEvaluationsByProfileIdRow
+}
+
+// NewChatAlert creates a new chat alert action
+func NewChatAlert(
+	actionType interfaces.ActionType,
+	ruleType *pb.RuleType,
+	cfg *serverconfig.ChatAlertConfig,
+	setting models.ActionOpt,
+) (*Alert, error) {
+	if actionType == "" {
+		return nil, fmt.Errorf("action type cannot be empty")
+	}
+	if cfg.Kind != KindSlack && cfg.Kind != KindTeams {
+		return nil, fmt.Errorf("unsupported chat alert kind: %s", cfg.Kind)
+	}
+	token, err := cfg.GetToken()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read chat alert token: %w", err)
+	}
+	var webhookURL string
+	if token != "" {
+		if cfg.Kind != KindSlack {
+			return nil, fmt.Errorf("chat alert tokens are only supported by %s", KindSlack)
+		}
+		if cfg.Channel == "" {
+			return nil, fmt.Errorf("chat alert channel is not configured")
+		}
+	} else {
+		webhookURL, err = cfg.GetWebhookURL()
+		if err != nil {
+			return nil, fmt.Errorf("cannot read chat alert webhook URL: %w", err)
+		}
+		if webhookURL == "" {
+			return nil, fmt.Errorf("chat alert webhook URL is not configured")
+		}
+	}
+
+	alert := &Alert{
+		actionType: actionType,
+		ruleType:   ruleType,
+		kind:       cfg.Kind,
+		webhookURL: webhookURL,
+		token:      token,
+		channel:    cfg.Channel,
+		apiURL:     slackAPIURL,
+		cli: &http.Client{
+			Timeout: cfg.Timeout,
+			// Only public addresses are dialed, whatever the webhook URL
+			// resolves to. No proxy, so that the dialed address is the one checked.
+			Transport: rego.LimitedDialer(&http.Transport{}),
+		},
+		setting: setting,
+	}
+
+	for _, t := range []struct {
+		dst  **template.Template
+		name string
+		text string
+	}{
+		{&alert.titleTmpl, tmplTitleName, tmplTitle},
+		{&alert.resolvedTmpl, tmplResolvedName, tmplResolved},
+		{&alert.descTmpl, tmplDescriptionName, tmplDescription},
+		{&alert.resolvedText, tmplResolvedTextName, tmplResolvedText},
+		{&alert.linkTmpl, "link", cfg.LinkTemplate},
+	} {
+		*t.dst, err = template.New(t.name).Option("missingkey=error").Parse(t.text)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse %s template: %w", t.name, err)
+		}
+	}
+
+	return alert, nil
+}
+
+// Class returns the action type of the chat engine
+func (alert *Alert) Class() interfaces.ActionType {
+	return alert.actionType
+}
+
+// Type returns the action subtype of the chat engine
+func (_ *Alert) Type() string {
+	return AlertType
+}
+
+// GetOnOffState returns the alert action state read from the profile
+func (alert *Alert) GetOnOffState() models.ActionOpt {
+	return models.ActionOptOrDefault(alert.setting, models.ActionOptOff)
+}
+
+// Do alerts through the chat service
+func (alert *Alert) Do(
+	ctx context.Context,
+	cmd interfaces.ActionCmd,
+	entity protoreflect.ProtoMessage,
+	params interfaces.ActionsParams,
+	metadata *json.RawMessage,
+) (json.RawMessage, error) {
+	p := alert.getParamsForChat(ctx, entity, params, metadata)
+
+	// Process the command based on the action setting
+	switch alert.setting {
+	case models.ActionOptOn:
+		return alert.run(ctx, p, cmd)
+	case models.ActionOptDryRun:
+		return alert.runDry(ctx, p, cmd)
+	case models.ActionOptOff, models.ActionOptUnknown:
+		return nil, fmt.Errorf("unexpected action setting: %w", enginerr.ErrActionFailed)
+	}
+	return nil, enginerr.ErrActionSkipped
+}
+
+// run runs the chat action
+func (alert *Alert) run(ctx context.Context, params *paramsChat, cmd interfaces.ActionCmd) (json.RawMessage, error) {
+	logger := zerolog.Ctx(ctx)
+
+	switch cmd {
+	// Post a message about the failing rule
+	case interfaces.ActionCmdOn:
+		msg, err := alert.failureMessage(params)
+		if err != nil {
+			return nil, err
+		}
+		meta := alertMetadata{Title: msg.Title}
+		if alert.token != "" {
+			resp, err := alert.callSlackAPI(ctx, slackMethodPostMessage, msg, alert.channel, "")
+			if err != nil {
+				return nil, fmt.Errorf("error posting chat message: %w, %w", err, enginerr.ErrActionFailed)
+			}
+			// Keep the message identifiers so it can be updated once resolved
+			meta.Channel, meta.TS = resp.Channel, resp.TS
+		} else if err := alert.post(ctx, msg); err != nil {
+			return nil, fmt.Errorf("error posting chat message: %w, %w", err, enginerr.ErrActionFailed)
+		}
+		now := time.Now().UTC()
+		meta.SentAt = &now
+		newMeta, err := json.Marshal(meta)
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling alert metadata json: %w", err)
+		}
+		logger.Info().Str("kind", alert.kind).Msg("chat alert posted")
+		return newMeta, nil
+	// Resolve the message posted when the rule started failing
+	case interfaces.ActionCmdOff:
+		if params.metadata.SentAt == nil {
+			// No message was posted, so there is nothing to resolve
+			return nil, fmt.Errorf("no chat message to resolve: %w", enginerr.ErrActionTurnedOff)
+		}
+		msg, err := alert.resolvedMessage(params)
+		if err != nil {
+			return nil, err
+		}
+		if alert.token != "" && params.metadata.TS != "" {
+			// Replace the failure message, so the channel doesn't show a stale alert
+			_, err = alert.callSlackAPI(ctx, slackMethodUpdate, msg, params.metadata.Channel, params.metadata.TS)
+		} else {
+			// Incoming webhooks can't edit the messages they posted, so follow up instead
+			err = alert.post(ctx, msg)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error posting chat message: %w, %w", err, enginerr.ErrActionFailed)
+		}
+		now := time.Now().UTC()
+		newMeta, err := json.Marshal(alertMetadata{
+			SentAt:     params.metadata.SentAt,
+			Title:      params.metadata.Title,
+			ResolvedAt: &now,
+			Channel:    params.metadata.Channel,
+			TS:         params.metadata.TS,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling alert metadata json: %w", err)
+		}
+		logger.Info().Str("kind", alert.kind).Msg("chat alert resolved")
+		// Success - return ErrActionTurnedOff to indicate the action was successful
+		return newMeta, fmt.Errorf("%s : %w", alert.Class(), enginerr.ErrActionTurnedOff)
+	case interfaces.ActionCmdDoNothing:
+		// Return the previous alert status.
+		return common.RunDoNothing(ctx, params.prevStatus, params.tmpl.EntityID)
+	}
+	return nil, enginerr.ErrActionSkipped
+}
+
+// runDry runs the chat action in dry run mode
+func (alert *Alert) runDry(ctx context.Context, params *paramsChat, cmd interfaces.ActionCmd) (json.RawMessage, error) {
+	logger := zerolog.Ctx(ctx)
+
+	var msg *message
+	var err error
+	switch cmd {
+	case interfaces.ActionCmdOn:
+		msg, err = alert.failureMessage(params)
+	case interfaces.ActionCmdOff:
+		if params.metadata.SentAt == nil {
+			return nil, fmt.Errorf("no chat message to resolve: %w", enginerr.ErrActionTurnedOff)
+		}
+		msg, err = alert.resolvedMessage(params)
+	case interfaces.ActionCmdDoNothing:
+		// Return the previous alert status.
+		return common.RunDoNothing(ctx, params.prevStatus, params.tmpl.EntityID)
+	default:
+		return nil, enginerr.ErrActionSkipped
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := alert.payload(msg)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info().Str("kind", alert.kind).Msgf("would post the following chat message: \n%s\n", body)
+	return nil, nil
+}
+
+func (alert *Alert) failureMessage(params *paramsChat) (*message, error) {
+	title, err := render(alert.titleTmpl, params.tmpl)
+	if err != nil {
+		return nil, err
+	}
+	text, err := render(alert.descTmpl, params.tmpl)
+	if err != nil {
+		return nil, err
+	}
+	link, err := render(alert.linkTmpl, params.tmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &message{
+		Title:    title,
+		Text:     text,
+		Guidance: params.tmpl.Guidance,
+		Link:     link,
+		Facts: [][2]string{
+			{"Profile", params.tmpl.Profile},
+			{"Rule", params.tmpl.Rule},
+			{"Rule type", params.tmpl.RuleType},
+			{"Severity", params.tmpl.Severity},
+			{"Entity", params.tmpl.EntityName},
+		},
+	}, nil
+}
+
+func (alert *Alert) resolvedMessage(params *paramsChat) (*message, error) {
+	tmpl := params.tmpl
+	tmpl.SentAt = params.metadata.SentAt.Format(time.RFC1123)
+
+	title, err := render(alert.resolvedTmpl, tmpl)
+	if err != nil {
+		return nil, err
+	}
+	text, err := render(alert.resolvedText, tmpl)
+	if err != nil {
+		return nil, err
+	}
+	link, err := render(alert.linkTmpl, tmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &message{
+		Title:    title,
+		Text:     text,
+		Link:     link,
+		Resolved: true,
+	}, nil
+}
+
+// payload renders the message in the format expected by the chat service
+func (alert *Alert) payload(msg *message) ([]byte, error) {
+	var p any
+	switch alert.kind {
+	case KindSlack:
+		p = slackPayload(msg)
+	case KindTeams:
+		p = teamsPayload(msg)
+	default:
+		return nil, fmt.Errorf("unsupported chat alert kind: %s", alert.kind)
+	}
+
+	body, err := json.Marshal(p)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling chat message: %w", err)
+	}
+	return body, nil
+}
+
+func (alert *Alert) post(ctx context.Context, msg *message) error {
+	body, err := alert.payload(msg)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, alert.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := alert.cli.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
+
+// getParamsForChat extracts the details from the entity and the evaluation
+func (alert *Alert) getParamsForChat(
+	ctx context.Context,
+	entity protoreflect.ProtoMessage,
+	params interfaces.ActionsParams,
+	metadata *json.RawMessage,
+) *paramsChat {
+	logger := zerolog.Ctx(ctx)
+
+	result := &paramsChat{
+		metadata:   &alertMetadata{},
+		prevStatus: params.GetEvalStatusFromDb(),
+		tmpl: templateParams{
+			ProjectID:  params.GetProjectID(),
+			Profile:    params.GetProfile().Name,
+			Rule:       params.GetRule().Name,
+			RuleType:   alert.ruleType.GetName(),
+			Severity:   alert.ruleType.GetSeverity().InitializedStringValue(),
+			Guidance:   alert.ruleType.GetGuidance(),
+			EntityType: string(params.GetEntityType()),
+			EntityID:   params.GetEntityID(),
+			EntityName: common.EntityName(entity),
+			Details:    enginerr.ErrorAsEvalDetails(params.GetEvalErr()),
+		},
+	}
+	if result.tmpl.Rule == "" {
+		result.tmpl.Rule = result.tmpl.RuleType
+	}
+
+	// Unmarshal the existing alert metadata, if any
+	if metadata != nil && len(*metadata) > 0 {
+		if err := json.Unmarshal(*metadata, result.metadata); err != nil {
+			// There's nothing saved apparently, so no need to fail here, but do log the error
+			logger.Debug().Msgf("error unmarshalling alert metadata: %v", err)
+		}
+	}
+
+	return result
+}
+
+func render(tmpl *template.Template, params templateParams) (string, error) {
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, params); err != nil {
+		return "", fmt.Errorf("error executing %s template: %w", tmpl.Name(), err)
+	}
+	return sb.String(), nil
+}
+
+var _ interfaces.Action = (*Alert)(nil)
@@ -0,0 +1,449 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mindersec/minder/internal/db"
+	enginerr "github.com/mindersec/minder/internal/engine/errors"
+	"github.com/mindersec/minder/internal/engine/interfaces"
+	pb "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	serverconfig "github.com/mindersec/minder/pkg/config/server"
+	"github.com/mindersec/minder/pkg/profiles/models"
+)
+
+var TestActionTypeValid interfaces.ActionType = "alert-test"
+
+func TestChatAlert(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		kind          string
+		cmd           interfaces.ActionCmd
+		metadata      json.RawMessage
+		statusCode    int
+		expectedErr   error
+		expectedCalls int32
+		checkBody     func(t *testing.T, body map[string]any)
+		checkMetadata func(t *testing.T, meta *alertMetadata)
+	}{
+		{
+			name:          "slack failure is posted",
+			kind:          KindSlack,
+			cmd:           interfaces.ActionCmdOn,
+			statusCode:    http.StatusOK,
+			expectedCalls: 1,
+			checkBody: func(t *testing.T, body map[string]any) {
+				t.Helper()
+				require.Equal(t, "Minder: rule rule is failing on org/repo", body["text"])
+				raw, err := json.Marshal(body["blocks"])
+				require.NoError(t, err)
+				require.Contains(t, string(raw), "bad things")
+				require.Contains(t, string(raw), "Enable branch protection")
+				require.Contains(t, string(raw), "https://minder.example.com/entities/")
+			},
+			checkMetadata: func(t *testing.T, meta *alertMetadata) {
+				t.Helper()
+				require.NotNil(t, meta.SentAt)
+				require.Nil(t, meta.ResolvedAt)
+				require.Equal(t, "Minder: rule rule is failing on org/repo", meta.Title)
+			},
+		},
+		{
+			name:          "teams failure is posted",
+			kind:          KindTeams,
+			cmd:           interfaces.ActionCmdOn,
+			statusCode:    http.StatusOK,
+			expectedCalls: 1,
+			checkBody: func(t *testing.T, body map[string]any) {
+				t.Helper()
+				require.Equal(t, "MessageCard", body["@type"])
+				require.Equal(t, teamsColorFailing, body["themeColor"])
+				require.Equal(t, "Minder: rule rule is failing on org/repo", body["title"])
+				require.Contains(t, body["text"], "bad things")
+				require.NotEmpty(t, body["potentialAction"])
+			},
+			checkMetadata: func(t *testing.T, meta *alertMetadata) {
+				t.Helper()
+				require.NotNil(t, meta.SentAt)
+			},
+		},
+		{
+			name:          "failed post",
+			kind:          KindSlack,
+			cmd:           interfaces.ActionCmdOn,
+			statusCode:    http.StatusForbidden,
+			expectedErr:   enginerr.ErrActionFailed,
+			expectedCalls: 1,
+		},
+		{
+			name:          "resolving is posted",
+			kind:          KindTeams,
+			cmd:           interfaces.ActionCmdOff,
+			metadata:      json.RawMessage(`{"sent_at":"2024-10-01T10:00:00Z","title":"failing"}`),
+			statusCode:    http.StatusOK,
+			expectedErr:   enginerr.ErrActionTurnedOff,
+			expectedCalls: 1,
+			checkBody: func(t *testing.T, body map[string]any) {
+				t.Helper()
+				require.Equal(t, teamsColorResolved, body["themeColor"])
+				require.Equal(t, "Minder: rule rule is passing again on org/repo", body["title"])
+				require.Contains(t, body["text"], "Tue, 01 Oct 2024 10:00:00 UTC")
+			},
+			checkMetadata: func(t *testing.T, meta *alertMetadata) {
+				t.Helper()
+				require.Equal(t, "failing", meta.Title)
+				require.NotNil(t, meta.ResolvedAt)
+			},
+		},
+		{
+			name:          "nothing to resolve",
+			kind:          KindSlack,
+			cmd:           interfaces.ActionCmdOff,
+			expectedErr:   enginerr.ErrActionTurnedOff,
+			expectedCalls: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+
+				raw, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+					return
+				}
+				if tt.checkBody != nil {
+					body := map[string]any{}
+					if err := json.Unmarshal(raw, &body); err != nil {
+						t.Errorf("unexpected error: %v", err)
+						return
+					}
+					tt.checkBody(t, body)
+				}
+
+				w.WriteHeader(tt.statusCode)
+			}))
+			t.Cleanup(srv.Close)
+
+			ruleType := &pb.RuleType{
+				Name:     "rule_type_1",
+				Guidance: "Enable branch protection",
+				Severity: &pb.Severity{Value: pb.Severity_VALUE_HIGH},
+				Def: &pb.RuleType_Definition{
+					Alert: &pb.RuleType_Definition_Alert{Type: AlertType},
+				},
+			}
+			cfg := &serverconfig.ChatAlertConfig{
+				Kind:         tt.kind,
+				WebhookURL:   srv.URL,
+				LinkTemplate: "https://minder.example.com/entities/{{.EntityID}}",
+				Timeout:      time.Second,
+			}
+
+			alert, err := NewChatAlert(TestActionTypeValid, ruleType, cfg, models.ActionOptOn)
+			require.NoError(t, err)
+			// the test server listens on a loopback address
+			alert.cli = srv.Client()
+
+			evalParams := &interfaces.EvalStatusParams{
+				EvalStatusFromDb: &db.ListRuleEvaluationsByProfileIdRow{},
+				Profile:          &models.ProfileAggregate{Name: "profile"},
+				Rule:             &models.RuleInstance{Name: "rule"},
+				ProjectID:        uuid.New(),
+				EntityType:       db.EntitiesRepository,
+				EntityID:         uuid.New(),
+			}
+			evalParams.SetEvalErr(enginerr.NewErrEvaluationFailed("bad things"))
+
+			var prevMeta *json.RawMessage
+			if tt.metadata != nil {
+				prevMeta = &tt.metadata
+			}
+
+			retMeta, err := alert.Do(
+				context.Background(),
+				tt.cmd,
+				&pb.Repository{Owner: "org", Name: "repo"},
+				evalParams,
+				prevMeta,
+			)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.expectedCalls, calls.Load())
+
+			if tt.checkMetadata != nil {
+				meta := &alertMetadata{}
+				require.NoError(t, json.Unmarshal(retMeta, meta))
+				tt.checkMetadata(t, meta)
+			}
+		})
+	}
+}
+
+func TestChatAlertRefusesPrivateAddresses(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+	}))
+	t.Cleanup(srv.Close)
+
+	ruleType := &pb.RuleType{
+		Name: "rule_type_1",
+		Def: &pb.RuleType_Definition{
+			Alert: &pb.RuleType_Definition_Alert{Type: AlertType},
+		},
+	}
+	cfg := &serverconfig.ChatAlertConfig{
+		Kind:       KindSlack,
+		WebhookURL: srv.URL,
+		Timeout:    time.Second,
+	}
+
+	alert, err := NewChatAlert(TestActionTypeValid, ruleType, cfg, models.ActionOptOn)
+	require.NoError(t, err)
+
+	evalParams := &interfaces.EvalStatusParams{
+		EvalStatusFromDb: &db.ListRuleEvaluationsByProfileIdRow{},
+		Profile:          &models.ProfileAggregate{Name: "profile"},
+		Rule:             &models.RuleInstance{Name: "rule"},
+		ProjectID:        uuid.New(),
+		EntityType:       db.EntitiesRepository,
+		EntityID:         uuid.New(),
+	}
+	evalParams.SetEvalErr(enginerr.NewErrEvaluationFailed("bad things"))
+
+	_, err = alert.Do(
+		context.Background(),
+		interfaces.ActionCmdOn,
+		&pb.Repository{Owner: "org", Name: "repo"},
+		evalParams,
+		nil,
+	)
+	require.ErrorIs(t, err, enginerr.ErrActionFailed)
+	require.Zero(t, calls.Load())
+}
+
+func TestChatAlertSlackAPI(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		cmd           interfaces.ActionCmd
+		metadata      json.RawMessage
+		response      string
+		expectedErr   error
+		expectedPath  string
+		checkBody     func(t *testing.T, body map[string]any)
+		checkMetadata func(t *testing.T, meta *alertMetadata)
+	}{
+		{
+			name:         "failure is posted to the channel",
+			cmd:          interfaces.ActionCmdOn,
+			response:     `{"ok":true,"channel":"C123","ts":"1700000000.000100"}`,
+			expectedPath: "/" + slackMethodPostMessage,
+			checkBody: func(t *testing.T, body map[string]any) {
+				t.Helper()
+				require.Equal(t, "#alerts", body["channel"])
+				require.NotContains(t, body, "ts")
+			},
+			checkMetadata: func(t *testing.T, meta *alertMetadata) {
+				t.Helper()
+				require.NotNil(t, meta.SentAt)
+				require.Equal(t, "C123", meta.Channel)
+				require.Equal(t, "1700000000.000100", meta.TS)
+			},
+		},
+		{
+			name:         "resolving updates the original message",
+			cmd:          interfaces.ActionCmdOff,
+			metadata:     json.RawMessage(`{"sent_at":"2024-10-01T10:00:00Z","title":"failing","channel":"C123","ts":"1.1"}`),
+			response:     `{"ok":true,"channel":"C123","ts":"1.1"}`,
+			expectedErr:  enginerr.ErrActionTurnedOff,
+			expectedPath: "/" + slackMethodUpdate,
+			checkBody: func(t *testing.T, body map[string]any) {
+				t.Helper()
+				require.Equal(t, "C123", body["channel"])
+				require.Equal(t, "1.1", body["ts"])
+				require.Equal(t, "Minder: rule rule is passing again on org/repo", body["text"])
+			},
+			checkMetadata: func(t *testing.T, meta *alertMetadata) {
+				t.Helper()
+				require.NotNil(t, meta.ResolvedAt)
+				require.Equal(t, "1.1", meta.TS)
+			},
+		},
+		{
+			name:         "API errors fail the action",
+			cmd:          interfaces.ActionCmdOn,
+			response:     `{"ok":false,"error":"channel_not_found"}`,
+			expectedErr:  enginerr.ErrActionFailed,
+			expectedPath: "/" + slackMethodPostMessage,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+
+				if r.URL.Path != tt.expectedPath {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				if r.Header.Get("Authorization") != "Bearer xoxb-test" {
+					t.Errorf("unexpected authorization header %s", r.Header.Get("Authorization"))
+				}
+				if tt.checkBody != nil {
+					body := map[string]any{}
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						t.Errorf("unexpected error: %v", err)
+						return
+					}
+					tt.checkBody(t, body)
+				}
+
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			t.Cleanup(srv.Close)
+
+			ruleType := &pb.RuleType{
+				Name: "rule_type_1",
+				Def: &pb.RuleType_Definition{
+					Alert: &pb.RuleType_Definition_Alert{Type: AlertType},
+				},
+			}
+			cfg := &serverconfig.ChatAlertConfig{
+				Kind:    KindSlack,
+				Token:   "xoxb-test",
+				Channel: "#alerts",
+				Timeout: time.Second,
+			}
+
+			alert, err := NewChatAlert(TestActionTypeValid, ruleType, cfg, models.ActionOptOn)
+			require.NoError(t, err)
+			// the test server listens on a loopback address
+			alert.cli = srv.Client()
+			alert.apiURL = srv.URL
+
+			evalParams := &interfaces.EvalStatusParams{
+				EvalStatusFromDb: &db.ListRuleEvaluationsByProfileIdRow{},
+				Profile:          &models.ProfileAggregate{Name: "profile"},
+				Rule:             &models.RuleInstance{Name: "rule"},
+				ProjectID:        uuid.New(),
+				EntityType:       db.EntitiesRepository,
+				EntityID:         uuid.New(),
+			}
+			evalParams.SetEvalErr(enginerr.NewErrEvaluationFailed("bad things"))
+
+			var prevMeta *json.RawMessage
+			if tt.metadata != nil {
+				prevMeta = &tt.metadata
+			}
+
+			retMeta, err := alert.Do(
+				context.Background(),
+				tt.cmd,
+				&pb.Repository{Owner: "org", Name: "repo"},
+				evalParams,
+				prevMeta,
+			)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, int32(1), calls.Load())
+
+			if tt.checkMetadata != nil {
+				meta := &alertMetadata{}
+				require.NoError(t, json.Unmarshal(retMeta, meta))
+				tt.checkMetadata(t, meta)
+			}
+		})
+	}
+}
+
+func TestNewChatAlertValidatesConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		cfg  *serverconfig.ChatAlertConfig
+	}{
+		{
+			name: "missing webhook URL",
+			cfg:  &serverconfig.ChatAlertConfig{Kind: KindSlack},
+		},
+		{
+			name: "unknown kind",
+			cfg:  &serverconfig.ChatAlertConfig{Kind: "irc", WebhookURL: "https://example.com"},
+		},
+		{
+			name: "token with teams",
+			cfg:  &serverconfig.ChatAlertConfig{Kind: KindTeams, Token: "xoxb-test", Channel: "#alerts"},
+		},
+		{
+			name: "token without channel",
+			cfg:  &serverconfig.ChatAlertConfig{Kind: KindSlack, Token: "xoxb-test"},
+		},
+		{
+			name: "bad link template",
+			cfg: &serverconfig.ChatAlertConfig{
+				Kind:         KindTeams,
+				WebhookURL:   "https://example.com",
+				LinkTemplate: "{{.EntityID",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewChatAlert(TestActionTypeValid, &pb.RuleType{}, tt.cfg, models.ActionOptOn)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestSlackPayloadSkipsEmptyLink(t *testing.T) {
+	t.Parallel()
+
+	p := slackPayload(&message{Title: "title", Text: "text"})
+	raw, err := json.Marshal(p)
+	require.NoError(t, err)
+	require.False(t, strings.Contains(string(raw), "View in Minder"))
+}
@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package chat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// slackPayload builds a Slack incoming webhook payload using Block Kit
+func slackPayload(msg *message) map[string]any {
+	icon := ":red_circle:"
+	if msg.Resolved {
+		icon = ":large_green_circle:"
+	}
+
+	blocks := []map[string]any{
+		{
+			"type": "header",
+			"text": map[string]any{"type": "plain_text", "text": msg.Title},
+		},
+		{
+			"type": "section",
+			"text": map[string]any{"type": "mrkdwn", "text": fmt.Sprintf("%s %s", icon, msg.Text)},
+		},
+	}
+
+	if len(msg.Facts) > 0 {
+		fields := make([]map[string]any, 0, len(msg.Facts))
+		for _, f := range msg.Facts {
+			fields = append(fields, map[string]any{
+				"type": "mrkdwn",
+				"text": fmt.Sprintf("*%s*\n%s", f[0], f[1]),
+			})
+		}
+		blocks = append(blocks, map[string]any{"type": "section", "fields": fields})
+	}
+
+	if msg.Guidance != "" {
+		blocks = append(blocks, map[string]any{
+			"type": "section",
+			"text": map[string]any{"type": "mrkdwn", "text": "*Guidance*\n" + msg.Guidance},
+		})
+	}
+
+	if msg.Link != "" {
+		blocks = append(blocks, map[string]any{
+			"type": "actions",
+			"elements": []map[string]any{
+				{
+					"type": "button",
+					"text": map[string]any{"type": "plain_text", "text": "View in Minder"},
+					"url":  msg.Link,
+				},
+			},
+		})
+	}
+
+	return map[string]any{
+		// text is the fallback used in notifications
+		"text":   msg.Title,
+		"blocks": blocks,
+	}
+}
+
+const (
+	// slackAPIURL is the base URL of the Slack Web API
+	slackAPIURL = "https://slack.com/api"
+
+	slackMethodPostMessage = "chat.postMessage"
+	slackMethodUpdate      = "chat.update"
+)
+
+// slackAPIResponse contains the fields of the Slack Web API responses used
+// by the alert
+type slackAPIResponse struct {
+	OK      bool   `json:"ok"`
+	Error   string `json:"error,omitempty"`
+	Channel string `json:"channel,omitempty"`
+	TS      string `json:"ts,omitempty"`
+}
+
+// callSlackAPI posts or updates a message with the Slack Web API. The
+// timestamp is only set when updating a message.
+func (alert *Alert) callSlackAPI(
+	ctx context.Context,
+	method string,
+	msg *message,
+	channel, ts string,
+) (*slackAPIResponse, error) {
+	p := slackPayload(msg)
+	p["channel"] = channel
+	if ts != "" {
+		p["ts"] = ts
+	}
+	body, err := json.Marshal(p)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling chat message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, alert.apiURL+"/"+method, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Authorization", "Bearer "+alert.token)
+
+	resp, err := alert.cli.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	// The Slack Web API reports errors in the body of successful responses
+	var apiResp slackAPIResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&apiResp); err != nil {
+		return nil, fmt.Errorf("error decoding %s response: %w", method, err)
+	}
+	if !apiResp.OK {
+		return nil, fmt.Errorf("%s failed: %s", method, apiResp.Error)
+	}
+	return &apiResp, nil
+}
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package chat
+
+const (
+	teamsColorFailing  = "D70000"
+	teamsColorResolved = "2EB886"
+)
+
+// teamsPayload builds a Microsoft Teams incoming webhook payload using a message card
+func teamsPayload(msg *message) map[string]any {
+	color := teamsColorFailing
+	if msg.Resolved {
+		color = teamsColorResolved
+	}
+
+	card := map[string]any{
+		"@type":      "MessageCard",
+		"@context":   "https://schema.org/extensions",
+		"summary":    msg.Title,
+		"themeColor": color,
+		"title":      msg.Title,
+		"text":       msg.Text,
+	}
+
+	var sections []map[string]any
+	if len(msg.Facts) > 0 {
+		facts := make([]map[string]any, 0, len(msg.Facts))
+		for _, f := range msg.Facts {
+			facts = append(facts, map[string]any{"name": f[0], "value": f[1]})
+		}
+		sections = append(sections, map[string]any{"facts": facts})
+	}
+	if msg.Guidance != "" {
+		sections = append(sections, map[string]any{"title": "Guidance", "text": msg.Guidance})
+	}
+	if len(sections) > 0 {
+		card["sections"] = sections
+	}
+
+	if msg.Link != "" {
+		card["potentialAction"] = []map[string]any{
+			{
+				"@type": "OpenUri",
+				"name":  "View in Minder",
+				"targets": []map[string]any{
+					{"os": "default", "uri": msg.Link},
+				},
+			},
+		}
+	}
+
+	return card
+}
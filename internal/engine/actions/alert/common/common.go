@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package common contains the helpers shared by the alert types which
+// notify services outside of the provider.
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/mindersec/minder/internal/db"
+	enginerr "github.com/mindersec/minder/internal/engine/errors"
+	pbinternal "github.com/mindersec/minder/internal/proto"
+	pb "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+// EntityName returns a human-readable name of the evaluated entity
+func EntityName(entity protoreflect.ProtoMessage) string {
+	switch entity := entity.(type) {
+	case *pb.Repository:
+		return fmt.Sprintf("%s/%s", entity.GetOwner(), entity.GetName())
+	case *pbinternal.PullRequest:
+		return fmt.Sprintf("%s/%s#%d", entity.GetRepoOwner(), entity.GetRepoName(), entity.GetNumber())
+	case *pb.Artifact:
+		return fmt.Sprintf("%s/%s", entity.GetOwner(), entity.GetName())
+	case interface{ GetName() string }:
+		return entity.GetName()
+	}
+	return ""
+}
+
+// RunDoNothing returns the previous alert status and metadata, for the
+// evaluations which don't change the state of the alert
+func RunDoNothing(
+	ctx context.Context,
+	prevStatus *db.ListRuleEvaluationsByProfileIdRow,
+	entityID uuid.UUID,
+) (json.RawMessage, error) {
+	logger := zerolog.Ctx(ctx).With().Str("entity_id", entityID.String()).Logger()
+
+	logger.Debug().Msg("Running do nothing")
+
+	// Return the previous alert status.
+	err := enginerr.AlertStatusAsError(prevStatus)
+	// If there is a valid alert metadata, return it too
+	if prevStatus != nil {
+		return prevStatus.AlertMetadata, err
+	}
+	// If there is no alert metadata, return nil as the metadata and the error
+	return nil, err
+}
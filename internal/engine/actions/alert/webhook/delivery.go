@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/cenkalti/backoff/v4"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/rs/zerolog"
+
+	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/internal/engine/eval/rego"
+	serverconfig "github.com/mindersec/minder/pkg/config/server"
+	eventer "github.com/mindersec/minder/pkg/eventer/interfaces"
+)
+
+const (
+	// TopicQueueAlertWebhookDelivery is the topic for delivering webhook alerts
+	TopicQueueAlertWebhookDelivery = "alert.webhook.delivery.event"
+)
+
+// DeliveryEvent is the payload of the events which queue the delivery of a
+// webhook alert. The body is signed when it is queued, so the secret never
+// leaves the evaluation.
+type DeliveryEvent struct {
+	EventID   string          `json:"event_id"`
+	EventType string          `json:"event_type"`
+	URL       string          `json:"url"`
+	Signature string          `json:"signature"`
+	Body      json.RawMessage `json:"body"`
+}
+
+// Deliverer POSTs the webhook alerts queued by the rule evaluations,
+// retrying with an exponential backoff. Only public addresses are dialed,
+// whatever the URL of the alert resolves to. The outcome of each delivery
+// is recorded in the metadata of the alert events which queued it.
+type Deliverer struct {
+	cfg   *serverconfig.WebhookAlertConfig
+	store db.Store
+	cli   *http.Client
+}
+
+// NewDeliverer creates a new deliverer of webhook alerts
+func NewDeliverer(cfg *serverconfig.WebhookAlertConfig, store db.Store) *Deliverer {
+	return &Deliverer{
+		cfg:   cfg,
+		store: store,
+		cli: &http.Client{
+			Timeout: cfg.Timeout,
+			// no proxy, so that the dialed address is the one checked
+			Transport: rego.LimitedDialer(&http.Transport{}),
+		},
+	}
+}
+
+// Register implements the Consumer interface.
+func (d *Deliverer) Register(reg eventer.Registrar) {
+	reg.Register(TopicQueueAlertWebhookDelivery, func(msg *message.Message) error {
+		var evt DeliveryEvent
+		if err := json.Unmarshal(msg.Payload, &evt); err != nil {
+			return fmt.Errorf("error unmarshalling webhook alert delivery event: %w", err)
+		}
+
+		ctx := msg.Context()
+		logger := zerolog.Ctx(ctx).With().
+			Str("event_id", evt.EventID).
+			Str("event_type", evt.EventType).
+			Logger()
+
+		res, deliveryErr := d.deliver(ctx, &evt)
+		if err := d.record(ctx, &evt, res, deliveryErr); err != nil {
+			logger.Error().Err(err).Msg("error recording the webhook alert delivery")
+		}
+		if deliveryErr != nil {
+			logger.Error().Err(deliveryErr).Int("attempts", res.attempts).Int("status_code", res.statusCode).
+				Msg("webhook alert delivery failed")
+			// Once the event is retried, it ends up in the dead letter queue
+			return fmt.Errorf("error delivering webhook alert %s: %w", evt.EventID, deliveryErr)
+		}
+		logger.Info().Int("attempts", res.attempts).Msg("webhook alert delivered")
+		return nil
+	})
+}
+
+// deliveryResult is what the endpoint made of a delivery
+type deliveryResult struct {
+	attempts int
+	// statusCode is the HTTP status of the last attempt, zero if no
+	// response was received
+	statusCode int
+}
+
+// deliver POSTs the event in structured mode and returns the number of
+// attempts and the status of the last one
+func (d *Deliverer) deliver(ctx context.Context, evt *DeliveryEvent) (deliveryResult, error) {
+	res := deliveryResult{}
+
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = d.cfg.InitialBackoff
+	err := backoff.Retry(func() error {
+		res.attempts++
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, evt.URL, bytes.NewReader(evt.Body))
+		if err != nil {
+			return backoff.Permanent(err)
+		}
+		req.Header.Set("Content-Type", cloudevents.ApplicationCloudEventsJSON)
+		req.Header.Set(SignatureHeader, evt.Signature)
+
+		resp, err := d.cli.Do(req)
+		if err != nil {
+			zerolog.Ctx(ctx).Debug().Err(err).Int("attempt", res.attempts).Msg("webhook alert delivery failed")
+			return err
+		}
+		defer resp.Body.Close()
+		res.statusCode = resp.StatusCode
+		// drain the body so the connection can be reused
+		_, _ = io.Copy(io.Discard, resp.Body)
+
+		switch {
+		case resp.StatusCode >= 200 && resp.StatusCode < 300:
+			return nil
+		case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+			return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+		default:
+			// Retrying won't help with client errors
+			return backoff.Permanent(fmt.Errorf("unexpected status code %d", resp.StatusCode))
+		}
+	}, backoff.WithContext(backoff.WithMaxRetries(bo, d.cfg.MaxRetries), ctx))
+
+	return res, err
+}
+
+// errNoAlertEvent is returned while the evaluation which queued a delivery
+// hasn't stored its alert event yet
+var errNoAlertEvent = errors.New("no alert event records the delivery")
+
+// record stores the outcome of a delivery in the metadata of the alert
+// events which queued it. A redelivered event adds up its attempts.
+func (d *Deliverer) record(ctx context.Context, evt *DeliveryEvent, res deliveryResult, deliveryErr error) error {
+	// The alert event is stored when the evaluation completes, which may
+	// happen after a quick delivery
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = d.cfg.InitialBackoff
+	bo.MaxElapsedTime = 10 * time.Second
+	events, err := backoff.RetryWithData(func() ([]db.AlertEvent, error) {
+		events, err := d.store.ListAlertEventsByDelivery(ctx, evt.EventID)
+		if err != nil {
+			return nil, fmt.Errorf("error listing alert events: %w", err)
+		}
+		if len(events) == 0 {
+			return nil, errNoAlertEvent
+		}
+		return events, nil
+	}, backoff.WithContext(bo, ctx))
+	if err != nil {
+		return err
+	}
+
+	for _, ae := range events {
+		var meta alertMetadata
+		if err := json.Unmarshal(ae.Metadata, &meta); err != nil {
+			return fmt.Errorf("error unmarshalling alert metadata: %w", err)
+		}
+		for i := range meta.Deliveries {
+			if meta.Deliveries[i].EventID != evt.EventID {
+				continue
+			}
+			meta.Deliveries[i].Attempts += res.attempts
+			meta.Deliveries[i].StatusCode = res.statusCode
+			meta.Deliveries[i].Error = ""
+			if deliveryErr != nil {
+				meta.Deliveries[i].Error = deliveryErr.Error()
+			}
+		}
+		newMeta, err := json.Marshal(&meta)
+		if err != nil {
+			return fmt.Errorf("error marshalling alert metadata: %w", err)
+		}
+		if err := d.store.UpdateAlertEventMetadata(ctx, db.UpdateAlertEventMetadataParams{
+			ID:       ae.ID,
+			Metadata: newMeta,
+		}); err != nil {
+			return fmt.Errorf("error updating alert event %s: %w", ae.ID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,377 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package webhook provides necessary interfaces and implementations for
+// creating alerts of type webhook, which POST signed CloudEvents to an
+// external endpoint when a rule evaluation changes state.
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/internal/engine/actions/alert/common"
+	enginerr "github.com/mindersec/minder/internal/engine/errors"
+	"github.com/mindersec/minder/internal/engine/interfaces"
+	pb "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	serverconfig "github.com/mindersec/minder/pkg/config/server"
+	eventer "github.com/mindersec/minder/pkg/eventer/interfaces"
+	"github.com/mindersec/minder/pkg/profiles/models"
+)
+
+const (
+	// AlertType is the type of the webhook alert engine
+	AlertType = "webhook"
+
+	// SignatureHeader is the header which carries the HMAC-SHA256 signature of the payload
+	SignatureHeader = "X-Minder-Signature-256"
+
+	// EventSource is the CloudEvents source of the events sent by the webhook alert
+	EventSource = "minder"
+
+	// EventTypeFailure is the CloudEvents type sent when a rule evaluation starts failing
+	EventTypeFailure = "dev.minder.rule_evaluation.failure"
+	// EventTypeError is the CloudEvents type sent when a rule evaluation starts erroring
+	EventTypeError = "dev.minder.rule_evaluation.error"
+	// EventTypeSuccess is the CloudEvents type sent when a rule evaluation passes again
+	EventTypeSuccess = "dev.minder.rule_evaluation.success"
+
+	// maxDeliveries is the number of deliveries kept in the alert metadata
+	maxDeliveries = 10
+)
+
+// Alert is the structure backing the webhook alert action
+type Alert struct {
+	actionType interfaces.ActionType
+	ruleType   *pb.RuleType
+	cfg        *serverconfig.WebhookAlertConfig
+	secret     string
+	evt        eventer.Publisher
+	setting    models.ActionOpt
+}
+
+// EventData is the data of the CloudEvents sent by the webhook alert
+type EventData struct {
+	ProjectID  uuid.UUID `json:"project_id"`
+	Profile    string    `json:"profile"`
+	Rule       string    `json:"rule"`
+	RuleType   string    `json:"rule_type"`
+	Severity   string    `json:"severity"`
+	Guidance   string    `json:"guidance,omitempty"`
+	EntityType string    `json:"entity_type"`
+	EntityID   uuid.UUID `json:"entity_id"`
+	EntityName string    `json:"entity_name,omitempty"`
+	// PreviousStatus is the evaluation status before the transition
+	PreviousStatus string `json:"previous_status,omitempty"`
+	// Status is the evaluation status after the transition
+	Status  string `json:"status"`
+	Details string `json:"details,omitempty"`
+}
+
+// delivery records an event queued for delivery and, once the deliverer
+// is done with it, the outcome of the delivery
+type delivery struct {
+	EventID   string    `json:"event_id"`
+	EventType string    `json:"event_type"`
+	Timestamp time.Time `json:"timestamp"`
+	// Attempts is the number of POSTs made to deliver the event
+	Attempts int `json:"attempts,omitempty"`
+	// StatusCode is the HTTP status of the last attempt, if any
+	StatusCode int `json:"status_code,omitempty"`
+	// Error is the reason the delivery failed, empty if it succeeded
+	Error string `json:"error,omitempty"`
+}
+
+type alertMetadata struct {
+	// EventID is the ID of the event which turned the alert on
+	EventID string `json:"event_id,omitempty"`
+	// Deliveries are the events queued for delivery, most recent last
+	Deliveries []delivery `json:"deliveries,omitempty"`
+}
+
+type paramsWebhook struct {
+	data       EventData
+	metadata   *alertMetadata
+	prevStatus *db.ListRuleEvaluationsByProfileIdRow
+}
+
+// NewWebhookAlert creates a new webhook alert action
+func NewWebhookAlert(
+	actionType interfaces.ActionType,
+	ruleType *pb.RuleType,
+	cfg *serverconfig.WebhookAlertConfig,
+	evt eventer.Publisher,
+	setting models.ActionOpt,
+) (*Alert, error) {
+	if actionType == "" {
+		return nil, fmt.Errorf("action type cannot be empty")
+	}
+	if evt == nil {
+		return nil, fmt.Errorf("webhook alert needs an event publisher to queue deliveries")
+	}
+	if cfg == nil || cfg.URL == "" {
+		return nil, fmt.Errorf("webhook alert URL is not configured")
+	}
+
+	secret, err := cfg.GetSecret()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read webhook alert secret: %w", err)
+	}
+
+	return &Alert{
+		actionType: actionType,
+		ruleType:   ruleType,
+		cfg:        cfg,
+		secret:     secret,
+		evt:        evt,
+		setting:    setting,
+	}, nil
+}
+
+// Class returns the action type of the webhook engine
+func (alert *Alert) Class() interfaces.ActionType {
+	return alert.actionType
+}
+
+// Type returns the action subtype of the webhook engine
+func (_ *Alert) Type() string {
+	return AlertType
+}
+
+// GetOnOffState returns the alert action state read from the profile
+func (alert *Alert) GetOnOffState() models.ActionOpt {
+	return models.ActionOptOrDefault(alert.setting, models.ActionOptOff)
+}
+
+// Do alerts through the webhook
+func (alert *Alert) Do(
+	ctx context.Context,
+	cmd interfaces.ActionCmd,
+	entity protoreflect.ProtoMessage,
+	params interfaces.ActionsParams,
+	metadata *json.RawMessage,
+) (json.RawMessage, error) {
+	p := alert.getParamsForWebhook(ctx, entity, params, metadata)
+
+	// Process the command based on the action setting
+	switch alert.setting {
+	case models.ActionOptOn:
+		return alert.run(ctx, p, cmd)
+	case models.ActionOptDryRun:
+		return alert.runDry(ctx, p, cmd)
+	case models.ActionOptOff, models.ActionOptUnknown:
+		return nil, fmt.Errorf("unexpected action setting: %w", enginerr.ErrActionFailed)
+	}
+	return nil, enginerr.ErrActionSkipped
+}
+
+// run runs the webhook action
+func (alert *Alert) run(ctx context.Context, params *paramsWebhook, cmd interfaces.ActionCmd) (json.RawMessage, error) {
+	logger := zerolog.Ctx(ctx)
+
+	switch cmd {
+	// Notify that the rule started failing or erroring
+	case interfaces.ActionCmdOn:
+		event, err := alert.newEvent(params.data)
+		if err != nil {
+			return nil, err
+		}
+		d, err := alert.enqueue(event)
+		if err != nil {
+			return nil, fmt.Errorf("error queueing webhook alert: %w, %w", err, enginerr.ErrActionFailed)
+		}
+		newMeta, err := json.Marshal(params.metadata.withDelivery(d, event.ID()))
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling alert metadata json: %w", err)
+		}
+		logger.Info().Str("event_id", event.ID()).Msg("webhook alert queued")
+		return newMeta, nil
+	// Notify that the rule passes again
+	case interfaces.ActionCmdOff:
+		if params.metadata.EventID == "" {
+			// We never notified about this evaluation, so there's nothing to resolve
+			return nil, fmt.Errorf("no webhook alert event ID provided: %w", enginerr.ErrActionTurnedOff)
+		}
+		event, err := alert.newEvent(params.data)
+		if err != nil {
+			return nil, err
+		}
+		d, err := alert.enqueue(event)
+		if err != nil {
+			return nil, fmt.Errorf("error queueing webhook alert: %w, %w", err, enginerr.ErrActionFailed)
+		}
+		newMeta, err := json.Marshal(params.metadata.withDelivery(d, ""))
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling alert metadata json: %w", err)
+		}
+		logger.Info().Str("event_id", event.ID()).Msg("webhook alert resolution queued")
+		// Success - return ErrActionTurnedOff to indicate the action was successful
+		return newMeta, fmt.Errorf("%s : %w", alert.Class(), enginerr.ErrActionTurnedOff)
+	case interfaces.ActionCmdDoNothing:
+		// Return the previous alert status.
+		return common.RunDoNothing(ctx, params.prevStatus, params.data.EntityID)
+	}
+	return nil, enginerr.ErrActionSkipped
+}
+
+// runDry runs the webhook action in dry run mode
+func (alert *Alert) runDry(ctx context.Context, params *paramsWebhook, cmd interfaces.ActionCmd) (json.RawMessage, error) {
+	logger := zerolog.Ctx(ctx)
+
+	switch cmd {
+	case interfaces.ActionCmdOn, interfaces.ActionCmdOff:
+		if cmd == interfaces.ActionCmdOff && params.metadata.EventID == "" {
+			return nil, fmt.Errorf("no webhook alert event ID provided: %w", enginerr.ErrActionTurnedOff)
+		}
+		event, err := alert.newEvent(params.data)
+		if err != nil {
+			return nil, err
+		}
+		body, err := json.Marshal(event)
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling cloud event: %w", err)
+		}
+		logger.Info().Str("url", alert.cfg.URL).Msgf("would POST the following webhook alert: \n%s\n", body)
+		return nil, nil
+	case interfaces.ActionCmdDoNothing:
+		// Return the previous alert status.
+		return common.RunDoNothing(ctx, params.prevStatus, params.data.EntityID)
+	}
+	return nil, enginerr.ErrActionSkipped
+}
+
+// newEvent builds the CloudEvent describing the evaluation transition
+func (_ *Alert) newEvent(data EventData) (*cloudevents.Event, error) {
+	event := cloudevents.NewEvent()
+	event.SetID(uuid.New().String())
+	event.SetSource(EventSource)
+	event.SetType(eventTypeForStatus(data.Status))
+	event.SetSubject(data.EntityID.String())
+	event.SetTime(time.Now())
+	if err := event.SetData(cloudevents.ApplicationJSON, data); err != nil {
+		return nil, fmt.Errorf("error setting cloud event data: %w", err)
+	}
+	return &event, nil
+}
+
+// enqueue publishes the signed event for the deliverer to POST it, so the
+// evaluation doesn't wait for the endpoint and the retries
+func (alert *Alert) enqueue(event *cloudevents.Event) (delivery, error) {
+	d := delivery{
+		EventID:   event.ID(),
+		EventType: event.Type(),
+		Timestamp: time.Now(),
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return d, fmt.Errorf("error marshalling cloud event: %w", err)
+	}
+
+	payload, err := json.Marshal(&DeliveryEvent{
+		EventID:   event.ID(),
+		EventType: event.Type(),
+		URL:       alert.cfg.URL,
+		Signature: Sign(alert.secret, body),
+		Body:      body,
+	})
+	if err != nil {
+		return d, fmt.Errorf("error marshalling delivery event: %w", err)
+	}
+
+	msg := message.NewMessage(uuid.New().String(), payload)
+	if err := alert.evt.Publish(TopicQueueAlertWebhookDelivery, msg); err != nil {
+		return d, fmt.Errorf("error publishing delivery event: %w", err)
+	}
+
+	return d, nil
+}
+
+// Sign returns the value of the signature header for the given payload
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// withDelivery records a delivery in the alert metadata. The event ID is
+// only updated when the alert is turned on.
+func (m *alertMetadata) withDelivery(d delivery, eventID string) *alertMetadata {
+	meta := &alertMetadata{
+		EventID:    m.EventID,
+		Deliveries: append(append([]delivery{}, m.Deliveries...), d),
+	}
+	if eventID != "" {
+		meta.EventID = eventID
+	}
+	if len(meta.Deliveries) > maxDeliveries {
+		meta.Deliveries = meta.Deliveries[len(meta.Deliveries)-maxDeliveries:]
+	}
+	return meta
+}
+
+// getParamsForWebhook extracts the details from the entity and the evaluation
+func (alert *Alert) getParamsForWebhook(
+	ctx context.Context,
+	entity protoreflect.ProtoMessage,
+	params interfaces.ActionsParams,
+	metadata *json.RawMessage,
+) *paramsWebhook {
+	logger := zerolog.Ctx(ctx)
+
+	result := &paramsWebhook{
+		metadata:   &alertMetadata{},
+		prevStatus: params.GetEvalStatusFromDb(),
+		data: EventData{
+			ProjectID:  params.GetProjectID(),
+			Profile:    params.GetProfile().Name,
+			Rule:       params.GetRule().Name,
+			RuleType:   alert.ruleType.GetName(),
+			Severity:   alert.ruleType.GetSeverity().InitializedStringValue(),
+			Guidance:   alert.ruleType.GetGuidance(),
+			EntityType: string(params.GetEntityType()),
+			EntityID:   params.GetEntityID(),
+			EntityName: common.EntityName(entity),
+			Status:     string(enginerr.ErrorAsEvalStatus(params.GetEvalErr())),
+			Details:    enginerr.ErrorAsEvalDetails(params.GetEvalErr()),
+		},
+	}
+	if result.prevStatus != nil {
+		result.data.PreviousStatus = string(result.prevStatus.EvalStatus)
+	}
+
+	// Unmarshal the existing alert metadata, if any
+	if metadata != nil && len(*metadata) > 0 {
+		if err := json.Unmarshal(*metadata, result.metadata); err != nil {
+			// There's nothing saved apparently, so no need to fail here, but do log the error
+			logger.Debug().Msgf("error unmarshalling alert metadata: %v", err)
+		}
+	}
+
+	return result
+}
+
+func eventTypeForStatus(status string) string {
+	switch db.EvalStatusTypes(status) {
+	case db.EvalStatusTypesFailure:
+		return EventTypeFailure
+	case db.EvalStatusTypesError:
+		return EventTypeError
+	default:
+		return EventTypeSuccess
+	}
+}
+
+var _ interfaces.Action = (*Alert)(nil)
@@ -0,0 +1,390 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockdb "github.com/mindersec/minder/database/mock"
+	"github.com/mindersec/minder/internal/db"
+	enginerr "github.com/mindersec/minder/internal/engine/errors"
+	"github.com/mindersec/minder/internal/engine/interfaces"
+	"github.com/mindersec/minder/internal/events/stubs"
+	pb "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	serverconfig "github.com/mindersec/minder/pkg/config/server"
+	eventer "github.com/mindersec/minder/pkg/eventer/interfaces"
+	"github.com/mindersec/minder/pkg/profiles/models"
+)
+
+var TestActionTypeValid interfaces.ActionType = "alert-test"
+
+const testSecret = "s3cr3t"
+
+func TestWebhookAlert(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		cmd           interfaces.ActionCmd
+		evalErr       error
+		metadata      json.RawMessage
+		expectedErr   error
+		expectedType  string
+		checkMetadata func(t *testing.T, meta *alertMetadata)
+	}{
+		{
+			name:         "failure is queued",
+			cmd:          interfaces.ActionCmdOn,
+			evalErr:      enginerr.NewErrEvaluationFailed("bad things"),
+			expectedType: EventTypeFailure,
+			checkMetadata: func(t *testing.T, meta *alertMetadata) {
+				t.Helper()
+				require.NotEmpty(t, meta.EventID)
+				require.Len(t, meta.Deliveries, 1)
+				require.Equal(t, meta.EventID, meta.Deliveries[0].EventID)
+			},
+		},
+		{
+			name:         "error is queued",
+			cmd:          interfaces.ActionCmdOn,
+			evalErr:      errors.New("boom"),
+			expectedType: EventTypeError,
+			checkMetadata: func(t *testing.T, meta *alertMetadata) {
+				t.Helper()
+				require.Equal(t, EventTypeError, meta.Deliveries[0].EventType)
+			},
+		},
+		{
+			name:         "resolving is queued",
+			cmd:          interfaces.ActionCmdOff,
+			metadata:     json.RawMessage(`{"event_id":"123","deliveries":[{"event_id":"123"}]}`),
+			expectedErr:  enginerr.ErrActionTurnedOff,
+			expectedType: EventTypeSuccess,
+			checkMetadata: func(t *testing.T, meta *alertMetadata) {
+				t.Helper()
+				require.Equal(t, "123", meta.EventID)
+				require.Len(t, meta.Deliveries, 2)
+			},
+		},
+		{
+			name:        "nothing to resolve",
+			cmd:         interfaces.ActionCmdOff,
+			expectedErr: enginerr.ErrActionTurnedOff,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ruleType := &pb.RuleType{
+				Name:     "rule_type_1",
+				Severity: &pb.Severity{Value: pb.Severity_VALUE_HIGH},
+				Def: &pb.RuleType_Definition{
+					Alert: &pb.RuleType_Definition_Alert{Type: AlertType},
+				},
+			}
+			cfg := &serverconfig.WebhookAlertConfig{
+				URL:    "https://example.com/events",
+				Secret: testSecret,
+			}
+			evt := &stubs.StubEventer{}
+
+			alert, err := NewWebhookAlert(TestActionTypeValid, ruleType, cfg, evt, models.ActionOptOn)
+			require.NoError(t, err)
+
+			evalParams := &interfaces.EvalStatusParams{
+				EvalStatusFromDb: &db.ListRuleEvaluationsByProfileIdRow{},
+				Profile:          &models.ProfileAggregate{Name: "profile"},
+				Rule:             &models.RuleInstance{Name: "rule"},
+				ProjectID:        uuid.New(),
+				EntityType:       db.EntitiesRepository,
+				EntityID:         uuid.New(),
+			}
+			evalParams.SetEvalErr(tt.evalErr)
+
+			var prevMeta *json.RawMessage
+			if tt.metadata != nil {
+				prevMeta = &tt.metadata
+			}
+
+			retMeta, err := alert.Do(
+				context.Background(),
+				tt.cmd,
+				&pb.Repository{Owner: "org", Name: "repo"},
+				evalParams,
+				prevMeta,
+			)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			if tt.expectedType == "" {
+				require.Empty(t, evt.Sent)
+				return
+			}
+			require.Len(t, evt.Sent, 1)
+			var delivery DeliveryEvent
+			require.NoError(t, json.Unmarshal(evt.Sent[0].Payload, &delivery))
+			require.Equal(t, cfg.URL, delivery.URL)
+			require.Equal(t, tt.expectedType, delivery.EventType)
+			require.Equal(t, Sign(testSecret, delivery.Body), delivery.Signature)
+
+			if tt.checkMetadata != nil {
+				meta := &alertMetadata{}
+				require.NoError(t, json.Unmarshal(retMeta, meta))
+				tt.checkMetadata(t, meta)
+			}
+		})
+	}
+}
+
+func TestDelivererDeliver(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		statusCodes   []int
+		expectErr     bool
+		expectedCalls int32
+	}{
+		{
+			name:          "event is delivered",
+			statusCodes:   []int{http.StatusOK},
+			expectedCalls: 1,
+		},
+		{
+			name:          "server errors are retried",
+			statusCodes:   []int{http.StatusBadGateway, http.StatusOK},
+			expectedCalls: 2,
+		},
+		{
+			name:          "client errors are not retried",
+			statusCodes:   []int{http.StatusBadRequest},
+			expectErr:     true,
+			expectedCalls: 1,
+		},
+		{
+			name:          "deliveries are given up after the retries",
+			statusCodes:   []int{http.StatusServiceUnavailable},
+			expectErr:     true,
+			expectedCalls: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			body := []byte(`{"specversion":"1.0","id":"123"}`)
+
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				call := calls.Add(1)
+
+				got, err := io.ReadAll(r.Body)
+				if !assertNoError(t, err) {
+					return
+				}
+				if Sign(testSecret, got) != r.Header.Get(SignatureHeader) {
+					t.Errorf("unexpected signature %s", r.Header.Get(SignatureHeader))
+				}
+
+				w.WriteHeader(tt.statusCodes[min(int(call), len(tt.statusCodes))-1])
+			}))
+			t.Cleanup(srv.Close)
+
+			d := NewDeliverer(&serverconfig.WebhookAlertConfig{
+				MaxRetries:     2,
+				InitialBackoff: time.Millisecond,
+				Timeout:        time.Second,
+			}, nil)
+			// the test server listens on a loopback address
+			d.cli = srv.Client()
+
+			_, err := d.deliver(context.Background(), &DeliveryEvent{
+				EventID:   "123",
+				EventType: EventTypeFailure,
+				URL:       srv.URL,
+				Signature: Sign(testSecret, body),
+				Body:      body,
+			})
+			if tt.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.expectedCalls, calls.Load())
+		})
+	}
+}
+
+func TestDeliverRefusesPrivateAddresses(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+	}))
+	t.Cleanup(srv.Close)
+
+	d := NewDeliverer(&serverconfig.WebhookAlertConfig{
+		InitialBackoff: time.Millisecond,
+		Timeout:        time.Second,
+	}, nil)
+
+	_, err := d.deliver(context.Background(), &DeliveryEvent{
+		EventID:   "123",
+		EventType: EventTypeFailure,
+		URL:       srv.URL,
+		Body:      []byte(`{}`),
+	})
+	require.Error(t, err)
+	require.Zero(t, calls.Load())
+}
+
+func TestDelivererRecordsOutcome(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		statusCode      int
+		expectErr       bool
+		expectedOutcome delivery
+	}{
+		{
+			name:       "delivered",
+			statusCode: http.StatusOK,
+			expectedOutcome: delivery{
+				EventID:    "123",
+				EventType:  EventTypeFailure,
+				Attempts:   1,
+				StatusCode: http.StatusOK,
+			},
+		},
+		{
+			name:       "rejected by the endpoint",
+			statusCode: http.StatusBadRequest,
+			expectErr:  true,
+			expectedOutcome: delivery{
+				EventID:    "123",
+				EventType:  EventTypeFailure,
+				Attempts:   1,
+				StatusCode: http.StatusBadRequest,
+				Error:      "unexpected status code 400",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.statusCode)
+			}))
+			t.Cleanup(srv.Close)
+
+			ctrl := gomock.NewController(t)
+			mockStore := mockdb.NewMockStore(ctrl)
+
+			// an older delivery is left alone
+			older := delivery{EventID: "122", EventType: EventTypeSuccess}
+			meta, err := json.Marshal(&alertMetadata{
+				EventID:    "123",
+				Deliveries: []delivery{older, {EventID: "123", EventType: EventTypeFailure}},
+			})
+			require.NoError(t, err)
+
+			alertEventID := uuid.New()
+			mockStore.EXPECT().ListAlertEventsByDelivery(gomock.Any(), "123").
+				Return([]db.AlertEvent{{ID: alertEventID, Metadata: meta}}, nil)
+			mockStore.EXPECT().UpdateAlertEventMetadata(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, arg db.UpdateAlertEventMetadataParams) error {
+					require.Equal(t, alertEventID, arg.ID)
+					var got alertMetadata
+					require.NoError(t, json.Unmarshal(arg.Metadata, &got))
+					require.Equal(t, "123", got.EventID)
+					require.Equal(t, []delivery{older, tt.expectedOutcome}, got.Deliveries)
+					return nil
+				})
+
+			d := NewDeliverer(&serverconfig.WebhookAlertConfig{
+				InitialBackoff: time.Millisecond,
+				Timeout:        time.Second,
+			}, mockStore)
+			// the test server listens on a loopback address
+			d.cli = srv.Client()
+
+			reg := &handlerRegistrar{}
+			d.Register(reg)
+
+			body := []byte(`{"specversion":"1.0","id":"123"}`)
+			payload, err := json.Marshal(&DeliveryEvent{
+				EventID:   "123",
+				EventType: EventTypeFailure,
+				URL:       srv.URL,
+				Signature: Sign(testSecret, body),
+				Body:      body,
+			})
+			require.NoError(t, err)
+
+			err = reg.handlers[TopicQueueAlertWebhookDelivery](message.NewMessage(uuid.New().String(), payload))
+			if tt.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+// handlerRegistrar keeps the registered handlers so the tests can call them
+type handlerRegistrar struct {
+	handlers map[string]eventer.Handler
+}
+
+func (r *handlerRegistrar) Register(topic string, handler eventer.Handler, _ ...message.HandlerMiddleware) {
+	if r.handlers == nil {
+		r.handlers = make(map[string]eventer.Handler)
+	}
+	r.handlers[topic] = handler
+}
+
+func TestNewWebhookAlertRequiresURL(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewWebhookAlert(TestActionTypeValid, &pb.RuleType{}, &serverconfig.WebhookAlertConfig{}, &stubs.StubEventer{}, models.ActionOptOn)
+	require.Error(t, err)
+}
+
+func assertNoError(t *testing.T, err error) bool {
+	t.Helper()
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,261 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package gh_ruleset provides the github repository ruleset remediation engine
+package gh_ruleset
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/google/go-github/v63/github"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	engerrors "github.com/mindersec/minder/internal/engine/errors"
+	"github.com/mindersec/minder/internal/engine/interfaces"
+	"github.com/mindersec/minder/internal/util"
+	pb "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/mindersec/minder/pkg/profiles/models"
+	provifv1 "github.com/mindersec/minder/pkg/providers/v1"
+)
+
+const (
+	// RemediateType is the type of the GitHub ruleset remediation engine
+	RemediateType = "gh_ruleset"
+
+	// PatchTemplateLimit is the maximum number of bytes for the patch template.
+	// Rulesets are larger than branch protections, as they carry their
+	// conditions and a list of rules.
+	PatchTemplateLimit = 8192
+
+	// rulesetSourceRepository is the source type of rulesets configured
+	// on the repository itself
+	rulesetSourceRepository = "Repository"
+)
+
+// GhRulesetRemediator keeps the status for a rule type that uses the GH API
+// to create or update a repository ruleset
+type GhRulesetRemediator struct {
+	actionType    interfaces.ActionType
+	cli           provifv1.GitHub
+	name          string
+	patchTemplate *util.SafeTemplate
+	setting       models.ActionOpt
+}
+
+// NewGhRulesetRemediator creates a new remediation engine that uses the GitHub API for repository rulesets
+func NewGhRulesetRemediator(
+	actionType interfaces.ActionType,
+	ghr *pb.RuleType_Definition_Remediate_GhRulesetType,
+	cli provifv1.GitHub,
+	setting models.ActionOpt,
+) (*GhRulesetRemediator, error) {
+	if actionType == "" {
+		return nil, fmt.Errorf("action type cannot be empty")
+	}
+	if ghr.GetName() == "" {
+		return nil, fmt.Errorf("ruleset name cannot be empty")
+	}
+
+	patchTemplate, err := util.NewSafeTextTemplate(&ghr.Patch, "patch")
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse patch template: %w", err)
+	}
+
+	return &GhRulesetRemediator{
+		actionType:    actionType,
+		cli:           cli,
+		name:          ghr.GetName(),
+		patchTemplate: patchTemplate,
+		setting:       setting,
+	}, nil
+}
+
+// PatchTemplateParams is the parameters for the patch template
+type PatchTemplateParams struct {
+	// Entity is the entity to be evaluated
+	Entity any
+	// Profile are the parameters to be used in the template
+	Profile map[string]any
+	// Params are the rule instance parameters
+	Params map[string]any
+}
+
+// Class returns the action type of the remediation engine
+func (r *GhRulesetRemediator) Class() interfaces.ActionType {
+	return r.actionType
+}
+
+// Type returns the action subtype of the remediation engine
+func (_ *GhRulesetRemediator) Type() string {
+	return RemediateType
+}
+
+// GetOnOffState returns the alert action state read from the profile
+func (r *GhRulesetRemediator) GetOnOffState() models.ActionOpt {
+	return models.ActionOptOrDefault(r.setting, models.ActionOptOff)
+}
+
+// Do perform the remediation
+func (r *GhRulesetRemediator) Do(
+	ctx context.Context,
+	cmd interfaces.ActionCmd,
+	ent protoreflect.ProtoMessage,
+	params interfaces.ActionsParams,
+	_ *json.RawMessage,
+) (json.RawMessage, error) {
+	// As with gh_branch_protection, the ruleset is left in place when the
+	// remediation is turned off
+	if cmd != interfaces.ActionCmdOn {
+		return nil, engerrors.ErrActionSkipped
+	}
+
+	retp := &PatchTemplateParams{
+		Entity:  ent,
+		Profile: params.GetRule().Def,
+		Params:  params.GetRule().Params,
+	}
+
+	repo, ok := ent.(*pb.Repository)
+	if !ok {
+		return nil, fmt.Errorf("expected repository, got %T", ent)
+	}
+
+	current, err := r.findRuleset(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleset := current
+	if ruleset == nil {
+		ruleset = newRuleset(r.name)
+	}
+
+	var patch bytes.Buffer
+	err = r.patchTemplate.Execute(ctx, &patch, retp, PatchTemplateLimit)
+	if err != nil {
+		return nil, fmt.Errorf("cannot execute patch template: %w", err)
+	}
+
+	zerolog.Ctx(ctx).Debug().Str("patch", patch.String()).Msg("patch")
+
+	updated, err := patchRuleset(ruleset, patch.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error patching ruleset: %w", err)
+	}
+	// the name identifies the ruleset, so it can't be patched
+	updated.Name = r.name
+
+	switch r.setting {
+	case models.ActionOptOn:
+		if current == nil {
+			_, err = r.cli.CreateRuleset(ctx, repo.GetOwner(), repo.GetName(), updated)
+		} else {
+			_, err = r.cli.UpdateRuleset(ctx, repo.GetOwner(), repo.GetName(), current.GetID(), updated)
+		}
+	case models.ActionOptDryRun:
+		err = dryRun(ctx, r.cli.GetBaseURL(), repo.GetOwner(), repo.GetName(), current.GetID(), updated)
+	case models.ActionOptOff, models.ActionOptUnknown:
+		err = errors.New("unexpected action")
+	}
+	return nil, err
+}
+
+// findRuleset returns the repository ruleset with the remediator's name, or
+// nil if there is none. Rulesets inherited from the organization can't be
+// updated through the repository, so they're not considered.
+func (r *GhRulesetRemediator) findRuleset(ctx context.Context, repo *pb.Repository) (*github.Ruleset, error) {
+	rulesets, err := r.cli.ListRulesets(ctx, repo.GetOwner(), repo.GetName(), false)
+	if err != nil {
+		return nil, fmt.Errorf("error listing rulesets: %w", err)
+	}
+
+	for _, rs := range rulesets {
+		if rs.Name != r.name || rs.GetSourceType() != rulesetSourceRepository {
+			continue
+		}
+		// the list doesn't include the rules, so fetch the whole ruleset
+		ruleset, err := r.cli.GetRuleset(ctx, repo.GetOwner(), repo.GetName(), rs.GetID())
+		if err != nil {
+			return nil, fmt.Errorf("error getting ruleset: %w", err)
+		}
+		return ruleset, nil
+	}
+	return nil, nil
+}
+
+// newRuleset returns the ruleset which is patched when the repository
+// doesn't have one with the given name yet: an active ruleset targeting
+// the default branch, with no rules.
+func newRuleset(name string) *github.Ruleset {
+	return &github.Ruleset{
+		Name:        name,
+		Target:      github.String("branch"),
+		Enforcement: "active",
+		Conditions: &github.RulesetConditions{
+			RefName: &github.RulesetRefConditionParameters{
+				Include: []string{"~DEFAULT_BRANCH"},
+				Exclude: []string{},
+			},
+		},
+	}
+}
+
+func dryRun(ctx context.Context, baseUrl, owner, repo string, id int64, ruleset *github.Ruleset) error {
+	jsonReq, err := json.Marshal(ruleset)
+	if err != nil {
+		log.Err(err).Msg("Error marshalling data")
+		return fmt.Errorf("error marshalling data: %w", err)
+	}
+
+	method := http.MethodPut
+	endpoint := fmt.Sprintf("repos/%v/%v/rulesets/%v", owner, repo, id)
+	if id == 0 {
+		method = http.MethodPost
+		endpoint = fmt.Sprintf("repos/%v/%v/rulesets", owner, repo)
+	}
+	curlCmd, err := util.GenerateCurlCommand(ctx, method, baseUrl, endpoint, string(jsonReq))
+	if err != nil {
+		return fmt.Errorf("cannot generate curl command: %w", err)
+	}
+
+	log.Printf("run the following curl command: \n%s\n", curlCmd)
+	return nil
+}
+
+// patchRuleset applies a JSON merge patch to the ruleset. As with any merge
+// patch, arrays such as the rules are replaced as a whole.
+func patchRuleset(ruleset *github.Ruleset, patch []byte) (*github.Ruleset, error) {
+	// drop the read-only fields, which are not part of an update
+	req := *ruleset
+	req.ID = nil
+	req.NodeID = nil
+	req.Links = nil
+	req.SourceType = nil
+	req.Source = ""
+
+	jReq, err := json.Marshal(&req)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling ruleset: %w", err)
+	}
+
+	mergedBytes, err := jsonpatch.MergePatch(jReq, patch)
+	if err != nil {
+		return nil, fmt.Errorf("error merging patch: %w", err)
+	}
+
+	merged := &github.Ruleset{}
+	err = json.Unmarshal(mergedBytes, merged)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling merged ruleset: %w", err)
+	}
+
+	return merged, nil
+}
@@ -0,0 +1,211 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gh_ruleset
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/go-github/v63/github"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	engerrors "github.com/mindersec/minder/internal/engine/errors"
+	"github.com/mindersec/minder/internal/engine/interfaces"
+	mock_ghclient "github.com/mindersec/minder/internal/providers/github/mock"
+	pb "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/mindersec/minder/pkg/profiles/models"
+)
+
+const (
+	repoOwner = "stacklok"
+	repoName  = "minder"
+
+	reviewCountPatch = `{"rules": [{"type": "deletion"}, {"type": "pull_request", "parameters": {
+		"dismiss_stale_reviews_on_push": false,
+		"require_code_owner_review": false,
+		"require_last_push_approval": false,
+		"required_approving_review_count": {{ .Profile.required_approving_review_count }},
+		"required_review_thread_resolution": false}}]}`
+)
+
+var TestActionTypeValid interfaces.ActionType = "remediate-test"
+
+type rulesetMatcher struct {
+	name        string
+	enforcement string
+	include     []string
+	reviewCount int
+}
+
+func (m *rulesetMatcher) Matches(x interface{}) bool {
+	rs, ok := x.(*github.Ruleset)
+	if !ok {
+		return false
+	}
+	if rs.Name != m.name || rs.Enforcement != m.enforcement || rs.ID != nil || rs.SourceType != nil {
+		return false
+	}
+	if rs.GetConditions().GetRefName() == nil || !slices.Equal(rs.Conditions.RefName.Include, m.include) {
+		return false
+	}
+
+	for _, rule := range rs.Rules {
+		if rule.Type != "pull_request" {
+			continue
+		}
+		var params github.PullRequestRuleParameters
+		if err := json.Unmarshal(*rule.Parameters, &params); err != nil {
+			return false
+		}
+		return params.RequiredApprovingReviewCount == m.reviewCount
+	}
+	return false
+}
+
+func (m *rulesetMatcher) String() string {
+	return "matches the ruleset " + m.name
+}
+
+func TestRulesetRemediate(t *testing.T) {
+	t.Parallel()
+
+	repo := &pb.Repository{Owner: repoOwner, Name: repoName}
+
+	tests := []struct {
+		name        string
+		actionType  interfaces.ActionType
+		setting     models.ActionOpt
+		mockSetup   func(*mock_ghclient.MockGitHub)
+		wantErr     bool
+		wantInitErr bool
+	}{
+		{
+			name:        "invalid action type",
+			setting:     models.ActionOptOn,
+			mockSetup:   func(_ *mock_ghclient.MockGitHub) {},
+			wantInitErr: true,
+		},
+		{
+			name:       "create missing ruleset",
+			actionType: TestActionTypeValid,
+			setting:    models.ActionOptOn,
+			mockSetup: func(mockGitHub *mock_ghclient.MockGitHub) {
+				mockGitHub.EXPECT().
+					ListRulesets(gomock.Any(), repoOwner, repoName, false).
+					Return([]*github.Ruleset{
+						{ID: github.Int64(1), Name: "other", SourceType: github.String("Repository")},
+					}, nil)
+				mockGitHub.EXPECT().
+					CreateRuleset(gomock.Any(), repoOwner, repoName, &rulesetMatcher{
+						name:        "minder",
+						enforcement: "active",
+						include:     []string{"~DEFAULT_BRANCH"},
+						reviewCount: 2,
+					}).
+					Return(&github.Ruleset{ID: github.Int64(2)}, nil)
+			},
+		},
+		{
+			name:       "patch existing ruleset",
+			actionType: TestActionTypeValid,
+			setting:    models.ActionOptOn,
+			mockSetup: func(mockGitHub *mock_ghclient.MockGitHub) {
+				mockGitHub.EXPECT().
+					ListRulesets(gomock.Any(), repoOwner, repoName, false).
+					Return([]*github.Ruleset{
+						{ID: github.Int64(3), Name: "minder", SourceType: github.String("Repository")},
+					}, nil)
+				mockGitHub.EXPECT().
+					GetRuleset(gomock.Any(), repoOwner, repoName, int64(3)).
+					Return(&github.Ruleset{
+						ID:          github.Int64(3),
+						Name:        "minder",
+						SourceType:  github.String("Repository"),
+						Source:      repoOwner + "/" + repoName,
+						Target:      github.String("branch"),
+						Enforcement: "evaluate",
+						Conditions: &github.RulesetConditions{
+							RefName: &github.RulesetRefConditionParameters{
+								Include: []string{"refs/heads/release"},
+								Exclude: []string{},
+							},
+						},
+						Rules: []*github.RepositoryRule{github.NewDeletionRule()},
+					}, nil)
+				mockGitHub.EXPECT().
+					UpdateRuleset(gomock.Any(), repoOwner, repoName, int64(3), &rulesetMatcher{
+						name:        "minder",
+						enforcement: "evaluate",
+						include:     []string{"refs/heads/release"},
+						reviewCount: 2,
+					}).
+					Return(&github.Ruleset{ID: github.Int64(3)}, nil)
+			},
+		},
+		{
+			name:       "dry run does not create the ruleset",
+			actionType: TestActionTypeValid,
+			setting:    models.ActionOptDryRun,
+			mockSetup: func(mockGitHub *mock_ghclient.MockGitHub) {
+				mockGitHub.EXPECT().
+					ListRulesets(gomock.Any(), repoOwner, repoName, false).
+					Return(nil, nil)
+				mockGitHub.EXPECT().GetBaseURL().Return("https://api.github.com/")
+			},
+		},
+		{
+			name:       "error listing rulesets",
+			actionType: TestActionTypeValid,
+			setting:    models.ActionOptOn,
+			mockSetup: func(mockGitHub *mock_ghclient.MockGitHub) {
+				mockGitHub.EXPECT().
+					ListRulesets(gomock.Any(), repoOwner, repoName, false).
+					Return(nil, errors.New("boom"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			mockClient := mock_ghclient.NewMockGitHub(ctrl)
+			tt.mockSetup(mockClient)
+
+			engine, err := NewGhRulesetRemediator(
+				tt.actionType,
+				&pb.RuleType_Definition_Remediate_GhRulesetType{Name: "minder", Patch: reviewCountPatch},
+				mockClient,
+				tt.setting,
+			)
+			if tt.wantInitErr {
+				require.Error(t, err, "expected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error creating remediate engine")
+
+			evalParams := &interfaces.EvalStatusParams{
+				Rule: &models.RuleInstance{
+					Def: map[string]any{"required_approving_review_count": 2},
+				},
+			}
+
+			_, err = engine.Do(context.Background(), interfaces.ActionCmdOn, repo, evalParams, nil)
+			if tt.wantErr {
+				require.Error(t, err, "expected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error running remediate engine")
+
+			_, err = engine.Do(context.Background(), interfaces.ActionCmdOff, repo, evalParams, nil)
+			require.ErrorIs(t, err, engerrors.ErrActionSkipped)
+		})
+	}
+}
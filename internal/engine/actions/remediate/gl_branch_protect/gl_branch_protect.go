@@ -0,0 +1,196 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package gl_branch_protect provides the gitlab branch protection remediation engine
+package gl_branch_protect
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	engerrors "github.com/mindersec/minder/internal/engine/errors"
+	"github.com/mindersec/minder/internal/engine/interfaces"
+	"github.com/mindersec/minder/internal/util"
+	pb "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/mindersec/minder/pkg/profiles/models"
+	provifv1 "github.com/mindersec/minder/pkg/providers/v1"
+)
+
+const (
+	// RemediateType is the type of the GitLab branch protection remediation engine
+	RemediateType = "gl_branch_protection"
+
+	// PatchTemplateLimit is the maximum number of bytes for the patch template
+	PatchTemplateLimit = 2048
+)
+
+// GlBranchProtectRemediator keeps the status for a rule type that uses GitLab
+// protected branches and approval rules to remediate branch protection
+type GlBranchProtectRemediator struct {
+	actionType    interfaces.ActionType
+	cli           provifv1.GitLab
+	patchTemplate *util.SafeTemplate
+	setting       models.ActionOpt
+}
+
+// NewGlBranchProtectRemediator creates a new remediation engine that uses the GitLab API for branch protection
+func NewGlBranchProtectRemediator(
+	actionType interfaces.ActionType,
+	glp *pb.RuleType_Definition_Remediate_GlBranchProtectionType,
+	cli provifv1.GitLab,
+	setting models.ActionOpt,
+) (*GlBranchProtectRemediator, error) {
+	if actionType == "" {
+		return nil, fmt.Errorf("action type cannot be empty")
+	}
+
+	patchTemplate, err := util.NewSafeTextTemplate(&glp.Patch, "patch")
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse patch template: %w", err)
+	}
+
+	return &GlBranchProtectRemediator{
+		actionType:    actionType,
+		cli:           cli,
+		patchTemplate: patchTemplate,
+		setting:       setting,
+	}, nil
+}
+
+// PatchTemplateParams is the parameters for the patch template
+type PatchTemplateParams struct {
+	// Entity is the entity to be evaluated
+	Entity any
+	// Profile are the parameters to be used in the template
+	Profile map[string]any
+	// Params are the rule instance parameters
+	Params map[string]any
+}
+
+// Class returns the action type of the remediation engine
+func (r *GlBranchProtectRemediator) Class() interfaces.ActionType {
+	return r.actionType
+}
+
+// Type returns the action subtype of the remediation engine
+func (_ *GlBranchProtectRemediator) Type() string {
+	return RemediateType
+}
+
+// GetOnOffState returns the alert action state read from the profile
+func (r *GlBranchProtectRemediator) GetOnOffState() models.ActionOpt {
+	return models.ActionOptOrDefault(r.setting, models.ActionOptOff)
+}
+
+// Do perform the remediation
+func (r *GlBranchProtectRemediator) Do(
+	ctx context.Context,
+	cmd interfaces.ActionCmd,
+	ent protoreflect.ProtoMessage,
+	params interfaces.ActionsParams,
+	_ *json.RawMessage,
+) (json.RawMessage, error) {
+	// Like gh_branch_protection, there is no turn-off behavior, so only
+	// proceed with the remediation if the command is to turn on the action
+	if cmd != interfaces.ActionCmdOn {
+		return nil, engerrors.ErrActionSkipped
+	}
+
+	retp := &PatchTemplateParams{
+		Entity:  ent,
+		Profile: params.GetRule().Def,
+		Params:  params.GetRule().Params,
+	}
+
+	repo, ok := ent.(*pb.Repository)
+	if !ok {
+		return nil, fmt.Errorf("expected repository, got %T", ent)
+	}
+
+	branch, err := util.JQReadFrom[string](ctx, ".branch", params.GetRule().Params)
+	if err != nil && !errors.Is(err, util.ErrNoValueFound) {
+		return nil, fmt.Errorf("error reading branch from params: %w", err)
+	}
+	// Protect the default branch of the repository in case no branch
+	// is configured via rule parameters.
+	if branch == "" {
+		branch = repo.DefaultBranch
+	}
+	if branch == "" {
+		return nil, fmt.Errorf("both rule param branch name and repo default branch are empty: %w", engerrors.ErrActionSkipped)
+	}
+
+	// get the current protection, or the defaults if the branch is not protected
+	current, err := r.cli.GetBranchProtection(ctx, repo, branch)
+	if err != nil {
+		return nil, fmt.Errorf("error getting branch protection: %w", err)
+	}
+
+	var patch bytes.Buffer
+	err = r.patchTemplate.Execute(ctx, &patch, retp, PatchTemplateLimit)
+	if err != nil {
+		return nil, fmt.Errorf("cannot execute patch template: %w", err)
+	}
+
+	zerolog.Ctx(ctx).Debug().Str("patch", patch.String()).Msg("patch")
+
+	updated, err := patchProtection(current, patch.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error patching branch protection: %w", err)
+	}
+
+	switch r.setting {
+	case models.ActionOptOn:
+		err = r.cli.UpdateBranchProtection(ctx, repo, branch, updated)
+	case models.ActionOptDryRun:
+		err = dryRun(ctx, repo, branch, updated)
+	case models.ActionOptOff, models.ActionOptUnknown:
+		err = errors.New("unexpected action")
+	}
+	return nil, err
+}
+
+func dryRun(ctx context.Context, repo *pb.Repository, branch string, protection *provifv1.GitLabBranchProtection) error {
+	jsonProtection, err := json.Marshal(protection)
+	if err != nil {
+		return fmt.Errorf("error marshalling data: %w", err)
+	}
+
+	// The update takes several API calls, so describe the result instead
+	zerolog.Ctx(ctx).Info().
+		Str("repo", fmt.Sprintf("%s/%s", repo.GetOwner(), repo.GetName())).
+		Str("branch", branch).
+		RawJSON("protection", jsonProtection).
+		Msg("would update the branch protection")
+	return nil
+}
+
+func patchProtection(
+	protection *provifv1.GitLabBranchProtection,
+	patch []byte,
+) (*provifv1.GitLabBranchProtection, error) {
+	jProtection, err := json.Marshal(protection)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling branch protection: %w", err)
+	}
+
+	mergedBytes, err := jsonpatch.MergePatch(jProtection, patch)
+	if err != nil {
+		return nil, fmt.Errorf("error merging patch: %w", err)
+	}
+
+	merged := &provifv1.GitLabBranchProtection{}
+	err = json.Unmarshal(mergedBytes, merged)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling merged branch protection: %w", err)
+	}
+
+	return merged, nil
+}
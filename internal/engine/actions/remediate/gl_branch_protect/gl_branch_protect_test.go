@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gl_branch_protect
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	engerrors "github.com/mindersec/minder/internal/engine/errors"
+	"github.com/mindersec/minder/internal/engine/interfaces"
+	pb "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/mindersec/minder/pkg/profiles/models"
+	provifv1 "github.com/mindersec/minder/pkg/providers/v1"
+	mockprov "github.com/mindersec/minder/pkg/providers/v1/mock"
+)
+
+const (
+	approvalsPatch = `{"allow_force_push": false, "approval_rules": {"minder": {"approvals_required": {{ .Profile.approvals_required }}}}}`
+)
+
+var TestActionTypeValid interfaces.ActionType = "remediate-test"
+
+func TestBranchProtectionRemediate(t *testing.T) {
+	t.Parallel()
+
+	repo := &pb.Repository{
+		Owner:         "group",
+		Name:          "project",
+		RepoId:        42,
+		DefaultBranch: "main",
+	}
+
+	tests := []struct {
+		name        string
+		actionType  interfaces.ActionType
+		setting     models.ActionOpt
+		params      map[string]any
+		mockSetup   func(*mockprov.MockGitLab)
+		wantErr     error
+		wantInitErr bool
+	}{
+		{
+			name:        "invalid action type",
+			setting:     models.ActionOptOn,
+			mockSetup:   func(_ *mockprov.MockGitLab) {},
+			wantInitErr: true,
+		},
+		{
+			name:       "unprotected branch is protected with GitLab defaults",
+			actionType: TestActionTypeValid,
+			setting:    models.ActionOptOn,
+			mockSetup: func(gl *mockprov.MockGitLab) {
+				gl.EXPECT().GetBranchProtection(gomock.Any(), repo, "main").
+					Return(provifv1.NewGitLabBranchProtection(), nil)
+				gl.EXPECT().UpdateBranchProtection(gomock.Any(), repo, "main", &provifv1.GitLabBranchProtection{
+					PushAccessLevel:      provifv1.GitLabAccessLevelMaintainer,
+					MergeAccessLevel:     provifv1.GitLabAccessLevelMaintainer,
+					UnprotectAccessLevel: provifv1.GitLabAccessLevelMaintainer,
+					ApprovalRules: map[string]*provifv1.GitLabApprovalRule{
+						"minder": {ApprovalsRequired: 2},
+					},
+				}).Return(nil)
+			},
+		},
+		{
+			name:       "patch is merged into the existing protection",
+			actionType: TestActionTypeValid,
+			setting:    models.ActionOptOn,
+			params:     map[string]any{"branch": "release"},
+			mockSetup: func(gl *mockprov.MockGitLab) {
+				gl.EXPECT().GetBranchProtection(gomock.Any(), repo, "release").
+					Return(&provifv1.GitLabBranchProtection{
+						Protected:        true,
+						PushAccessLevel:  provifv1.GitLabAccessLevelNoAccess,
+						MergeAccessLevel: provifv1.GitLabAccessLevelDeveloper,
+						AllowForcePush:   true,
+						ApprovalRules: map[string]*provifv1.GitLabApprovalRule{
+							"security": {ApprovalsRequired: 1},
+							"minder":   {ApprovalsRequired: 1},
+						},
+					}, nil)
+				gl.EXPECT().UpdateBranchProtection(gomock.Any(), repo, "release", &provifv1.GitLabBranchProtection{
+					Protected:        true,
+					PushAccessLevel:  provifv1.GitLabAccessLevelNoAccess,
+					MergeAccessLevel: provifv1.GitLabAccessLevelDeveloper,
+					ApprovalRules: map[string]*provifv1.GitLabApprovalRule{
+						"security": {ApprovalsRequired: 1},
+						"minder":   {ApprovalsRequired: 2},
+					},
+				}).Return(nil)
+			},
+		},
+		{
+			name:       "dry run does not update the protection",
+			actionType: TestActionTypeValid,
+			setting:    models.ActionOptDryRun,
+			mockSetup: func(gl *mockprov.MockGitLab) {
+				gl.EXPECT().GetBranchProtection(gomock.Any(), repo, "main").
+					Return(provifv1.NewGitLabBranchProtection(), nil)
+			},
+		},
+		{
+			name:       "error getting the protection",
+			actionType: TestActionTypeValid,
+			setting:    models.ActionOptOn,
+			mockSetup: func(gl *mockprov.MockGitLab) {
+				gl.EXPECT().GetBranchProtection(gomock.Any(), repo, "main").
+					Return(nil, errors.New("boom"))
+			},
+			wantErr: errors.New("boom"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			mockClient := mockprov.NewMockGitLab(ctrl)
+			tt.mockSetup(mockClient)
+
+			engine, err := NewGlBranchProtectRemediator(
+				tt.actionType,
+				&pb.RuleType_Definition_Remediate_GlBranchProtectionType{Patch: approvalsPatch},
+				mockClient,
+				tt.setting,
+			)
+			if tt.wantInitErr {
+				require.Error(t, err, "expected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error creating remediate engine")
+
+			evalParams := &interfaces.EvalStatusParams{
+				Rule: &models.RuleInstance{
+					Def:    map[string]any{"approvals_required": 2},
+					Params: tt.params,
+				},
+			}
+
+			_, err = engine.Do(context.Background(), interfaces.ActionCmdOn, repo, evalParams, nil)
+			if tt.wantErr != nil {
+				require.ErrorContains(t, err, tt.wantErr.Error())
+				return
+			}
+			require.NoError(t, err, "unexpected error running remediate engine")
+
+			_, err = engine.Do(context.Background(), interfaces.ActionCmdOff, repo, evalParams, nil)
+			require.ErrorIs(t, err, engerrors.ErrActionSkipped)
+		})
+	}
+}
@@ -19,7 +19,6 @@ import (
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
-	"github.com/google/go-github/v63/github"
 	"github.com/rs/zerolog"
 	"google.golang.org/protobuf/reflect/protoreflect"
 
@@ -64,6 +63,9 @@ type pullRequestMetadata struct {
 
 // Remediator is the remediation engine for the Pull Request remediation type
 type Remediator struct {
+	cr provifv1.ChangeRequester
+	// ghCli is only set for GitHub providers, it's used to resolve
+	// action tags and to describe the API calls on dry runs
 	ghCli      provifv1.GitHub
 	actionType interfaces.ActionType
 	setting    models.ActionOpt
@@ -89,7 +91,7 @@ type paramsPR struct {
 func NewPullRequestRemediate(
 	actionType interfaces.ActionType,
 	prCfg *pb.RuleType_Definition_Remediate_PullRequestRemediation,
-	ghCli provifv1.GitHub,
+	cr provifv1.ChangeRequester,
 	setting models.ActionOpt,
 ) (*Remediator, error) {
 	err := prCfg.Validate()
@@ -110,7 +112,11 @@ func NewPullRequestRemediate(
 	modRegistry := newModificationRegistry()
 	modRegistry.registerBuiltIn()
 
+	// the GitHub client is optional, so the error is ignored
+	ghCli, _ := provifv1.As[provifv1.GitHub](cr)
+
 	return &Remediator{
+		cr:                   cr,
 		ghCli:                ghCli,
 		prCfg:                prCfg,
 		actionType:           actionType,
@@ -263,6 +269,10 @@ func (r *Remediator) dryRun(
 			// We cannot do anything without a PR number, so we assume that closing this is a success
 			return nil, fmt.Errorf("no pull request number provided: %w", enginerr.ErrActionSkipped)
 		}
+		if r.ghCli == nil {
+			logger.Msgf("would close change request %d\n", p.metadata.Number)
+			return nil, nil
+		}
 		endpoint := fmt.Sprintf("repos/%v/%v/pulls/%d", p.repo.GetOwner(), p.repo.GetName(), p.metadata.Number)
 		body := "{\"state\": \"closed\"}"
 		curlCmd, err := util.GenerateCurlCommand(ctx, "PATCH", r.ghCli.GetBaseURL(), endpoint, body)
@@ -292,9 +302,9 @@ func (r *Remediator) runOn(
 	}
 
 	logger.Debug().Msg("Getting authenticated user details")
-	email, err := r.ghCli.GetPrimaryEmail(ctx)
+	name, email, err := r.cr.GetCommitAuthor(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("cannot get primary email: %w", err)
+		return nil, fmt.Errorf("cannot get commit author: %w", err)
 	}
 
 	currentHeadReference, err := repo.Head()
@@ -332,7 +342,7 @@ func (r *Remediator) runOn(
 	logger.Debug().Msg("Committing changes")
 	_, err = wt.Commit(p.title, &git.CommitOptions{
 		Author: &object.Signature{
-			Name:  userNameForCommit(ctx, r.ghCli),
+			Name:  name,
 			Email: email,
 			When:  time.Now(),
 		},
@@ -341,35 +351,39 @@ func (r *Remediator) runOn(
 		return nil, fmt.Errorf("cannot commit: %w", err)
 	}
 
-	refspec := refFromBranch(branchBaseName(p.title))
-
-	l := logger.With().Str("branchBaseName", branchBaseName(p.title)).Logger()
+	branch := branchBaseName(p.title)
+	l := logger.With().Str("branchBaseName", branch).Logger()
 
 	// Check if a PR already exists for this branch
-	prNumber := getPRNumberFromBranch(ctx, r.ghCli, p.repo, branchBaseName(p.title))
+	pr, err := r.cr.FindChangeRequest(ctx, p.repo, branch)
+	if err != nil {
+		// We can still try to open a PR, which fails if there is one already
+		l.Debug().Err(err).Msg("cannot look up existing pull request")
+	}
 
 	// If no PR exists, push the branch and create a PR
-	if prNumber == 0 {
-		err = pushBranch(ctx, repo, refspec, r.ghCli)
+	if pr == nil {
+		err = pushBranch(ctx, repo, refFromBranch(branch), r.cr)
 		if err != nil {
 			return nil, fmt.Errorf("cannot push branch: %w", err)
 		}
 
-		pr, err := r.ghCli.CreatePullRequest(
-			ctx, p.repo.GetOwner(), p.repo.GetName(),
-			p.title, p.body,
-			refspec,
-			dflBranchTo,
-		)
+		pr, err = r.cr.CreateChangeRequest(ctx, p.repo, p.title, p.body, branch, baseBranch(p.repo))
 		if err != nil {
 			return nil, fmt.Errorf("cannot create pull request: %w, %w", err, enginerr.ErrActionFailed)
 		}
-		// Return the new PR number
-		prNumber = pr.GetNumber()
 		l = l.With().Str("pr_origin", "newly_created").Logger()
 	} else {
+		// Keep the description of the existing PR in sync with the rule type
+		if pr.Title != p.title || pr.Body != p.body {
+			err = r.cr.UpdateChangeRequest(ctx, p.repo, pr.Number, p.title, p.body)
+			if err != nil {
+				return nil, fmt.Errorf("cannot update pull request: %w, %w", err, enginerr.ErrActionFailed)
+			}
+		}
 		l = l.With().Str("pr_origin", "already_existed").Logger()
 	}
+	prNumber := pr.Number
 
 	newMeta, err := json.Marshal(pullRequestMetadata{Number: prNumber})
 	if err != nil {
@@ -380,29 +394,6 @@ func (r *Remediator) runOn(
 	return newMeta, enginerr.ErrActionPending
 }
 
-func getPRNumberFromBranch(
-	ctx context.Context,
-	cli provifv1.GitHub,
-	repo *pb.Repository,
-	branchName string,
-) int {
-	opts := &github.PullRequestListOptions{
-		// TODO: This is not working as expected, need to fix this
-		// Head: fmt.Sprintf("%s:%s", repo.GetOwner(), branchName),
-		State: "open",
-	}
-	openPrs, err := cli.ListPullRequests(ctx, repo.GetOwner(), repo.GetName(), opts)
-	if err != nil {
-		return 0
-	}
-	for _, pr := range openPrs {
-		if pr.GetHead().GetRef() == branchName {
-			return pr.GetNumber()
-		}
-	}
-	return 0
-}
-
 func (r *Remediator) runOff(
 	ctx context.Context,
 	p *paramsPR,
@@ -414,11 +405,11 @@ func (r *Remediator) runOff(
 		return nil, fmt.Errorf("no pull request number provided: %w", enginerr.ErrActionSkipped)
 	}
 
-	pr, err := r.ghCli.ClosePullRequest(ctx, p.repo.GetOwner(), p.repo.GetName(), p.metadata.Number)
+	err := r.cr.CloseChangeRequest(ctx, p.repo, p.metadata.Number)
 	if err != nil {
 		return nil, fmt.Errorf("error closing pull request %d: %w, %w", p.metadata.Number, err, enginerr.ErrActionFailed)
 	}
-	logger.Info().Int("pr_number", pr.GetNumber()).Msg("pull request closed")
+	logger.Info().Int("pr_number", p.metadata.Number).Msg("pull request closed")
 	return nil, enginerr.ErrActionSkipped
 }
 
@@ -439,7 +430,7 @@ func (r *Remediator) run(
 	return nil, enginerr.ErrActionSkipped
 }
 
-func pushBranch(ctx context.Context, repo *git.Repository, refspec string, gh provifv1.GitHub) error {
+func pushBranch(ctx context.Context, repo *git.Repository, refspec string, cr provifv1.ChangeRequester) error {
 	var b bytes.Buffer
 	pushOptions := &git.PushOptions{
 		RemoteName: guessRemote(repo),
@@ -451,7 +442,7 @@ func pushBranch(ctx context.Context, repo *git.Repository, refspec string, gh pr
 		},
 		Progress: &b,
 	}
-	err := gh.AddAuthToPushOptions(ctx, pushOptions)
+	err := cr.AddAuthToPushOptions(ctx, pushOptions)
 	if err != nil {
 		return fmt.Errorf("cannot add auth to push options: %w", err)
 	}
@@ -493,16 +484,12 @@ func branchBaseName(prTitle string) string {
 	return fmt.Sprintf("%s_%s", baseName, normalizedPrTitle)
 }
 
-func userNameForCommit(ctx context.Context, gh provifv1.GitHub) string {
-	var name string
-
-	// we ignore errors here, as we can still create a commit without a name
-	// and errors are checked when getting the primary email
-	name, _ = gh.GetName(ctx)
-	if name == "" {
-		name, _ = gh.GetLogin(ctx)
+// baseBranch returns the branch the PR is opened against
+func baseBranch(repo *pb.Repository) string {
+	if repo.GetDefaultBranch() != "" {
+		return repo.GetDefaultBranch()
 	}
-	return name
+	return dflBranchTo
 }
 
 func (r *Remediator) getPrBodyText(ctx context.Context, tmplParams *PrTemplateParams) (string, error) {
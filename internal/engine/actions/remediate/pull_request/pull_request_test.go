@@ -218,11 +218,9 @@ func createTestRemArgsWithExcludes() *remediateArgs {
 func happyPathMockSetup(mockGitHub *mockghclient.MockGitHub) {
 	// no pull request so far
 	mockGitHub.EXPECT().
-		ListPullRequests(gomock.Any(), repoOwner, repoName, gomock.Any()).Return([]*github.PullRequest{}, nil)
+		FindChangeRequest(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
 	mockGitHub.EXPECT().
-		GetName(gomock.Any()).Return("stacklok-bot", nil)
-	mockGitHub.EXPECT().
-		GetPrimaryEmail(gomock.Any()).Return("test@stacklok.com", nil)
+		GetCommitAuthor(gomock.Any()).Return("stacklok-bot", "test@stacklok.com", nil)
 	mockGitHub.EXPECT().
 		AddAuthToPushOptions(gomock.Any(), gomock.Any()).Return(nil)
 }
@@ -418,12 +416,11 @@ func TestPullRequestRemediate(t *testing.T) {
 				happyPathMockSetup(mockGitHub)
 
 				mockGitHub.EXPECT().
-					CreatePullRequest(
-						gomock.Any(),
-						repoOwner, repoName,
+					CreateChangeRequest(
+						gomock.Any(), gomock.Any(),
 						commitTitle, prBody,
-						refFromBranch(branchBaseName(commitTitle)), dflBranchTo).
-					Return(&github.PullRequest{Number: github.Int(42)}, nil)
+						branchBaseName(commitTitle), dflBranchTo).
+					Return(&provifv1.ChangeRequest{Number: 42}, nil)
 			},
 			expectedErr:      errors.ErrActionPending,
 			expectedMetadata: json.RawMessage(`{"pr_number":42}`),
@@ -440,11 +437,10 @@ func TestPullRequestRemediate(t *testing.T) {
 				happyPathMockSetup(mockGitHub)
 
 				mockGitHub.EXPECT().
-					CreatePullRequest(
-						gomock.Any(),
-						repoOwner, repoName,
+					CreateChangeRequest(
+						gomock.Any(), gomock.Any(),
 						commitTitle, prBody,
-						refFromBranch(branchBaseName(commitTitle)), dflBranchTo).
+						branchBaseName(commitTitle), dflBranchTo).
 					Return(nil, fmt.Errorf("failed to create PR"))
 			},
 			expectedErr:      errors.ErrActionFailed,
@@ -462,12 +458,11 @@ func TestPullRequestRemediate(t *testing.T) {
 				happyPathMockSetup(mockGitHub)
 
 				mockGitHub.EXPECT().
-					CreatePullRequest(
-						gomock.Any(),
-						repoOwner, repoName,
+					CreateChangeRequest(
+						gomock.Any(), gomock.Any(),
 						commitTitle, prBody,
-						refFromBranch(branchBaseName(commitTitle)), dflBranchTo).
-					Return(&github.PullRequest{Number: github.Int(41)}, nil)
+						branchBaseName(commitTitle), dflBranchTo).
+					Return(&provifv1.ChangeRequest{Number: 41}, nil)
 			},
 			expectedErr:      errors.ErrActionPending,
 			expectedMetadata: json.RawMessage(`{"pr_number":41}`),
@@ -482,24 +477,43 @@ func TestPullRequestRemediate(t *testing.T) {
 			repoSetup: defaultMockRepoSetup,
 			mockSetup: func(_ *testing.T, mockGitHub *mockghclient.MockGitHub) {
 				mockGitHub.EXPECT().
-					GetName(gomock.Any()).Return("stacklok-bot", nil)
-				mockGitHub.EXPECT().
-					GetPrimaryEmail(gomock.Any()).Return("test@stacklok.com", nil)
+					GetCommitAuthor(gomock.Any()).Return("stacklok-bot", "test@stacklok.com", nil)
 				mockGitHub.EXPECT().
-					ListPullRequests(gomock.Any(), repoOwner, repoName, gomock.Any()).
-					Return([]*github.PullRequest{
-						{
-							Body: github.String(prBody),
-							Head: &github.PullRequestBranch{
-								Ref: github.String("minder_add_dependabot_configuration_for_gomod"),
-							},
-							Number: github.Int(143),
-						},
+					FindChangeRequest(gomock.Any(), gomock.Any(), "minder_add_dependabot_configuration_for_gomod").
+					Return(&provifv1.ChangeRequest{
+						Title:  commitTitle,
+						Body:   prBody,
+						Number: 143,
 					}, nil)
 			},
 			expectedErr:      errors.ErrActionPending,
 			expectedMetadata: json.RawMessage(`{"pr_number":143}`),
 		},
+		{
+			name: "A PR already exists with an outdated description, update it",
+			newRemArgs: &newPullRequestRemediateArgs{
+				prRem:      dependabotPrRem(),
+				actionType: TestActionTypeValid,
+			},
+			remArgs:   createTestRemArgs(),
+			repoSetup: defaultMockRepoSetup,
+			mockSetup: func(_ *testing.T, mockGitHub *mockghclient.MockGitHub) {
+				mockGitHub.EXPECT().
+					GetCommitAuthor(gomock.Any()).Return("stacklok-bot", "test@stacklok.com", nil)
+				mockGitHub.EXPECT().
+					FindChangeRequest(gomock.Any(), gomock.Any(), "minder_add_dependabot_configuration_for_gomod").
+					Return(&provifv1.ChangeRequest{
+						Title:  commitTitle,
+						Body:   "outdated",
+						Number: 144,
+					}, nil)
+				mockGitHub.EXPECT().
+					UpdateChangeRequest(gomock.Any(), gomock.Any(), 144, commitTitle, prBody).
+					Return(nil)
+			},
+			expectedErr:      errors.ErrActionPending,
+			expectedMetadata: json.RawMessage(`{"pr_number":144}`),
+		},
 		//
 		//{
 		//	name: "A branch for this PR already exists, shouldn't open a new PR, but only update the branch",
@@ -550,12 +564,11 @@ func TestPullRequestRemediate(t *testing.T) {
 				resolveActionMockSetup(t, mockGitHub, "repos/actions/setup-go/git/refs/tags/v5", setupV5Ref)
 
 				mockGitHub.EXPECT().
-					CreatePullRequest(
-						gomock.Any(),
-						repoOwner, repoName,
+					CreateChangeRequest(
+						gomock.Any(), gomock.Any(),
 						frizbeeCommitTitle, frizbeePrBody,
-						refFromBranch(branchBaseName(frizbeeCommitTitle)), dflBranchTo).
-					Return(&github.PullRequest{Number: github.Int(40)}, nil)
+						branchBaseName(frizbeeCommitTitle), dflBranchTo).
+					Return(&provifv1.ChangeRequest{Number: 40}, nil)
 			},
 			expectedErr:      errors.ErrActionPending,
 			expectedMetadata: json.RawMessage(`{"pr_number":40}`),
@@ -575,12 +588,11 @@ func TestPullRequestRemediate(t *testing.T) {
 
 				resolveActionMockSetup(t, mockGitHub, "repos/actions/checkout/git/refs/tags/v4", checkoutV4Ref)
 				mockGitHub.EXPECT().
-					CreatePullRequest(
-						gomock.Any(),
-						repoOwner, repoName,
+					CreateChangeRequest(
+						gomock.Any(), gomock.Any(),
 						frizbeeCommitTitle, frizbeePrBodyWithExcludes,
-						refFromBranch(branchBaseName(frizbeeCommitTitle)), dflBranchTo).
-					Return(&github.PullRequest{Number: github.Int(43)}, nil)
+						branchBaseName(frizbeeCommitTitle), dflBranchTo).
+					Return(&provifv1.ChangeRequest{Number: 43}, nil)
 			},
 			expectedErr:      errors.ErrActionPending,
 			expectedMetadata: json.RawMessage(`{"pr_number":43}`),
@@ -601,12 +613,11 @@ func TestPullRequestRemediate(t *testing.T) {
 				resolveActionMockSetup(t, mockGitHub, "repos/actions/checkout/git/refs/tags/v4", checkoutV4Ref)
 
 				mockGitHub.EXPECT().
-					CreatePullRequest(
-						gomock.Any(),
-						repoOwner, repoName,
+					CreateChangeRequest(
+						gomock.Any(), gomock.Any(),
 						frizbeeCommitTitle, frizbeePrBodyWithExcludes,
-						refFromBranch(branchBaseName(frizbeeCommitTitle)), dflBranchTo).
-					Return(&github.PullRequest{Number: github.Int(44)}, nil)
+						branchBaseName(frizbeeCommitTitle), dflBranchTo).
+					Return(&provifv1.ChangeRequest{Number: 44}, nil)
 			},
 			expectedErr:      errors.ErrActionPending,
 			expectedMetadata: json.RawMessage(`{"pr_number":44}`),
@@ -624,12 +635,11 @@ func TestPullRequestRemediate(t *testing.T) {
 				happyPathMockSetup(mockGitHub)
 
 				mockGitHub.EXPECT().
-					CreatePullRequest(
-						gomock.Any(),
-						repoOwner, repoName,
+					CreateChangeRequest(
+						gomock.Any(), gomock.Any(),
 						yqCommitTitle, yqPrBody,
-						refFromBranch(branchBaseName(yqCommitTitle)), dflBranchTo).
-					Return(&github.PullRequest{Number: github.Int(45)}, nil)
+						branchBaseName(yqCommitTitle), dflBranchTo).
+					Return(&provifv1.ChangeRequest{Number: 45}, nil)
 			},
 			remArgs:          createTestRemArgs(),
 			expectedErr:      errors.ErrActionPending,
@@ -661,6 +671,7 @@ func TestPullRequestRemediate(t *testing.T) {
 
 			require.NoError(t, err, "unexpected error creating remediate engine")
 			// TODO(jakub): providerBuilder should be an interface so we can pass in mock more easily
+			engine.cr = mockClient
 			engine.ghCli = mockClient
 
 			require.NoError(t, err, "unexpected error creating remediate engine")
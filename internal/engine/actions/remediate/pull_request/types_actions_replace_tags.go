@@ -54,7 +54,11 @@ func newFrizbeeTagResolveModification(
 
 func (ftr *frizbeeTagResolveModification) createFsModEntries(ctx context.Context, _ interfaces.ActionsParams) error {
 	// Create a new Frizbee instance
-	r := replacer.NewGitHubActionsReplacer(&config.Config{GHActions: *ftr.fzcfg}).WithGitHubClient(ftr.ghCli)
+	r := replacer.NewGitHubActionsReplacer(&config.Config{GHActions: *ftr.fzcfg})
+	// Providers other than GitHub resolve the tags anonymously
+	if ftr.ghCli != nil {
+		r = r.WithGitHubClient(ftr.ghCli)
+	}
 
 	// Parse the .github/workflows directory and replace tags with digests
 	ret, err := r.ParsePathInFS(ctx, ftr.fs, ".github/workflows")
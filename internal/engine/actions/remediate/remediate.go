@@ -10,6 +10,8 @@ import (
 	"fmt"
 
 	"github.com/mindersec/minder/internal/engine/actions/remediate/gh_branch_protect"
+	"github.com/mindersec/minder/internal/engine/actions/remediate/gh_ruleset"
+	"github.com/mindersec/minder/internal/engine/actions/remediate/gl_branch_protect"
 	"github.com/mindersec/minder/internal/engine/actions/remediate/noop"
 	"github.com/mindersec/minder/internal/engine/actions/remediate/pull_request"
 	"github.com/mindersec/minder/internal/engine/actions/remediate/rest"
@@ -56,10 +58,32 @@ func NewRuleRemediator(
 		return gh_branch_protect.NewGhBranchProtectRemediator(
 			ActionType, remediate.GetGhBranchProtection(), client, setting)
 
-	case pull_request.RemediateType:
+	case gh_ruleset.RemediateType:
 		client, err := provinfv1.As[provinfv1.GitHub](provider)
 		if err != nil {
-			return nil, errors.New("provider does not implement git trait")
+			return nil, errors.New("provider does not implement github trait")
+		}
+		if remediate.GetGhRuleset() == nil {
+			return nil, fmt.Errorf("remediations engine missing gh_ruleset configuration")
+		}
+		return gh_ruleset.NewGhRulesetRemediator(
+			ActionType, remediate.GetGhRuleset(), client, setting)
+
+	case gl_branch_protect.RemediateType:
+		client, err := provinfv1.As[provinfv1.GitLab](provider)
+		if err != nil {
+			return nil, errors.New("provider does not implement gitlab trait")
+		}
+		if remediate.GetGlBranchProtection() == nil {
+			return nil, fmt.Errorf("remediations engine missing gl_branch_protection configuration")
+		}
+		return gl_branch_protect.NewGlBranchProtectRemediator(
+			ActionType, remediate.GetGlBranchProtection(), client, setting)
+
+	case pull_request.RemediateType:
+		client, err := provinfv1.As[provinfv1.ChangeRequester](provider)
+		if err != nil {
+			return nil, errors.New("provider does not implement change requester trait")
 		}
 		if remediate.GetPullRequest() == nil {
 			return nil, fmt.Errorf("remediations engine missing pull request configuration")
@@ -39,6 +39,9 @@ type EntityInfoWrapper struct {
 	OwnershipData map[string]string
 	ExecutionID   *uuid.UUID
 	ActionEvent   string
+	// ProfileID restricts the evaluation to a single profile. It is set for
+	// the evaluations of scheduled profiles, and nil otherwise.
+	ProfileID uuid.UUID
 }
 
 const (
@@ -60,6 +63,8 @@ const (
 	pullRequestIDEventKey = "pull_request_id"
 	// ExecutionIDKey is the key for the execution ID. This is set when acquiring a lock.
 	ExecutionIDKey = "execution_id"
+	// ProfileIDEventKey is the key for the ID of the only profile to evaluate
+	ProfileIDEventKey = "profile_id"
 )
 
 // NewEntityInfoWrapper creates a new EntityInfoWrapper
@@ -138,6 +143,13 @@ func (eiw *EntityInfoWrapper) WithExecutionID(id uuid.UUID) *EntityInfoWrapper {
 	return eiw
 }
 
+// WithProfileID restricts the evaluation to the given profile
+func (eiw *EntityInfoWrapper) WithProfileID(id uuid.UUID) *EntityInfoWrapper {
+	eiw.ProfileID = id
+
+	return eiw
+}
+
 // AsRepository sets the entity type to a repository
 func (eiw *EntityInfoWrapper) AsRepository() *EntityInfoWrapper {
 	eiw.Type = minderv1.Entity_ENTITY_REPOSITORIES
@@ -215,6 +227,10 @@ func (eiw *EntityInfoWrapper) ToMessage(msg *message.Message) error {
 		msg.Metadata.Set(ExecutionIDKey, eiw.ExecutionID.String())
 	}
 
+	if eiw.ProfileID != uuid.Nil {
+		msg.Metadata.Set(ProfileIDEventKey, eiw.ProfileID.String())
+	}
+
 	if eiw.Type == minderv1.Entity_ENTITY_UNSPECIFIED {
 		return fmt.Errorf("entity type is required")
 	}
@@ -330,6 +346,22 @@ func (eiw *EntityInfoWrapper) withEntityInstanceIDFromMessage(msg *message.Messa
 	return nil
 }
 
+// withProfileIDFromMessage sets the profile ID from the message, if any
+func (eiw *EntityInfoWrapper) withProfileIDFromMessage(msg *message.Message) error {
+	rawProfileID := msg.Metadata.Get(ProfileIDEventKey)
+	if rawProfileID == "" {
+		return nil
+	}
+
+	profileID, err := uuid.Parse(rawProfileID)
+	if err != nil {
+		return fmt.Errorf("malformed profile id %s", rawProfileID)
+	}
+
+	eiw.ProfileID = profileID
+	return nil
+}
+
 // WithExecutionIDFromMessage sets the execution ID from the message
 func (eiw *EntityInfoWrapper) WithExecutionIDFromMessage(msg *message.Message) error {
 	executionID := msg.Metadata.Get(ExecutionIDKey)
@@ -459,6 +491,10 @@ func ParseEntityEvent(msg *message.Message) (*EntityInfoWrapper, error) {
 		}
 	}
 
+	if err := out.withProfileIDFromMessage(msg); err != nil {
+		return nil, err
+	}
+
 	if err := out.unmarshalEntity(msg); err != nil {
 		return nil, fmt.Errorf("error unmarshalling payload: %w", err)
 	}
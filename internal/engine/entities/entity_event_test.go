@@ -278,6 +278,7 @@ func TestEntityInfoWrapper_ToMessage(t *testing.T) {
 	providerID := uuid.New()
 	artifactID := uuid.New()
 	pullRequestID := uuid.New()
+	profileID := uuid.New()
 
 	tests := []struct {
 		name     string
@@ -378,6 +379,25 @@ func TestEntityInfoWrapper_ToMessage(t *testing.T) {
 				EntityIDEventKey:   pullRequestID.String(),
 			},
 		},
+		{
+			name: "scheduled profile evaluation",
+			eiw: NewEntityInfoWrapper().
+				WithProviderID(providerID).
+				WithProjectID(projectID).
+				WithRepository(&pb.Repository{
+					Owner:  "test",
+					RepoId: 123,
+				}).
+				WithID(repoID).
+				WithProfileID(profileID),
+			expected: map[string]string{
+				ProviderIDEventKey: providerID.String(),
+				EntityTypeEventKey: pb.Entity_ENTITY_REPOSITORIES.ToString(),
+				ProjectIDEventKey:  projectID.String(),
+				EntityIDEventKey:   repoID.String(),
+				ProfileIDEventKey:  profileID.String(),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -390,6 +410,10 @@ func TestEntityInfoWrapper_ToMessage(t *testing.T) {
 			for key, expectedValue := range tt.expected {
 				assert.Equal(t, expectedValue, msg.Metadata.Get(key), key+" mismatch")
 			}
+
+			parsed, err := ParseEntityEvent(msg)
+			require.NoError(t, err, "unexpected error parsing message")
+			assert.Equal(t, tt.eiw.ProfileID, parsed.ProfileID)
 		})
 	}
 }
@@ -140,6 +140,16 @@ func NewErrEvaluationSkipSilently(sfmt string, args ...any) error {
 	return fmt.Errorf("%w: %s", ErrEvaluationSkipSilently, msg)
 }
 
+// ErrEvaluationExempt specifies that the rule was not evaluated because a
+// rule exception covers the entity.
+var ErrEvaluationExempt = errors.New("evaluation exempt")
+
+// NewErrEvaluationExempt creates a new evaluation error
+func NewErrEvaluationExempt(sfmt string, args ...any) error {
+	msg := fmt.Sprintf(sfmt, args...)
+	return fmt.Errorf("%w: %s", ErrEvaluationExempt, msg)
+}
+
 // ErrActionSkipped is an error code that indicates that the action was not performed at all because
 // the evaluation passed and the action was not needed
 var ErrActionSkipped = errors.New("action skipped")
@@ -189,6 +199,8 @@ func ErrorAsEvalStatus(err error) db.EvalStatusTypes {
 		return db.EvalStatusTypesFailure
 	} else if errors.Is(err, ErrEvaluationSkipped) {
 		return db.EvalStatusTypesSkipped
+	} else if errors.Is(err, ErrEvaluationExempt) {
+		return db.EvalStatusTypesExempt
 	} else if err != nil {
 		return db.EvalStatusTypesError
 	}
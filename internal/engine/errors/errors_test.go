@@ -4,10 +4,14 @@
 package errors
 
 import (
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/mindersec/minder/internal/db"
 )
 
 func TestLegacyEvaluationDetailRendering(t *testing.T) {
@@ -123,3 +127,54 @@ func TestEvaluationDetailRendering(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorAsEvalStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		err    error
+		status db.EvalStatusTypes
+	}{
+		{
+			name:   "success",
+			err:    nil,
+			status: db.EvalStatusTypesSuccess,
+		},
+		{
+			name:   "failure",
+			err:    NewErrEvaluationFailed("failed"),
+			status: db.EvalStatusTypesFailure,
+		},
+		{
+			name:   "skipped",
+			err:    NewErrEvaluationSkipped("skipped"),
+			status: db.EvalStatusTypesSkipped,
+		},
+		{
+			name:   "exempt",
+			err:    NewErrEvaluationExempt("exempt until %s", "2027-01-01"),
+			status: db.EvalStatusTypesExempt,
+		},
+		{
+			name:   "wrapped exempt",
+			err:    fmt.Errorf("wrapped: %w", NewErrEvaluationExempt("exempt")),
+			status: db.EvalStatusTypesExempt,
+		},
+		{
+			name:   "error",
+			err:    errors.New("boom"),
+			status: db.EvalStatusTypesError,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.status, ErrorAsEvalStatus(tt.err))
+		})
+	}
+}
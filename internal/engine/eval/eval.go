@@ -46,21 +46,21 @@ func NewRuleEvaluator(
 	case rego.RegoEvalType:
 		return rego.NewRegoEvaluator(e.GetRego(), featureFlags, opts...)
 	case vulncheck.VulncheckEvalType:
-		client, err := provinfv1.As[provinfv1.GitHub](provider)
+		client, err := provinfv1.As[vulncheck.Client](provider)
 		if err != nil {
-			return nil, errors.New("provider does not implement github trait")
+			return nil, errors.New("provider does not implement pull request reviewer and rest traits")
 		}
 		return vulncheck.NewVulncheckEvaluator(client, opts...)
 	case trusty.TrustyEvalType:
-		client, err := provinfv1.As[provinfv1.GitHub](provider)
+		client, err := provinfv1.As[provinfv1.PullRequestReviewer](provider)
 		if err != nil {
-			return nil, errors.New("provider does not implement github trait")
+			return nil, errors.New("provider does not implement pull request reviewer trait")
 		}
 		return trusty.NewTrustyEvaluator(ctx, client, opts...)
 	case application.HomoglyphsEvalType:
-		client, err := provinfv1.As[provinfv1.GitHub](provider)
+		client, err := provinfv1.As[provinfv1.PullRequestReviewer](provider)
 		if err != nil {
-			return nil, errors.New("provider does not implement git trait")
+			return nil, errors.New("provider does not implement pull request reviewer trait")
 		}
 		return application.NewHomoglyphsEvaluator(ctx, e.GetHomoglyphs(), client, opts...)
 	default:
@@ -9,10 +9,9 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/google/go-github/v63/github"
-
 	"github.com/mindersec/minder/internal/engine/eval/homoglyphs/communication"
 	"github.com/mindersec/minder/internal/engine/eval/homoglyphs/domain"
+	"github.com/mindersec/minder/internal/engine/eval/pr_actions"
 	eoptions "github.com/mindersec/minder/internal/engine/options"
 	pbinternal "github.com/mindersec/minder/internal/proto"
 	pb "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
@@ -32,10 +31,10 @@ const (
 func NewHomoglyphsEvaluator(
 	ctx context.Context,
 	reh *pb.RuleType_Definition_Eval_Homoglyphs,
-	ghClient provifv1.GitHub,
+	client provifv1.PullRequestReviewer,
 	opts ...eoptions.Option,
 ) (interfaces.Evaluator, error) {
-	if ghClient == nil {
+	if client == nil {
 		return nil, fmt.Errorf("provider builder is nil")
 	}
 	if reh == nil {
@@ -44,9 +43,9 @@ func NewHomoglyphsEvaluator(
 
 	switch reh.Type {
 	case invisibleCharacters:
-		return NewInvisibleCharactersEvaluator(ctx, ghClient, opts...)
+		return NewInvisibleCharactersEvaluator(ctx, client, opts...)
 	case mixedScript:
-		return NewMixedScriptEvaluator(ctx, ghClient, opts...)
+		return NewMixedScriptEvaluator(ctx, client, opts...)
 	default:
 		return nil, fmt.Errorf("unsupported homoglyphs type: %s", reh.Type)
 	}
@@ -54,33 +53,36 @@ func NewHomoglyphsEvaluator(
 
 // evaluateHomoglyphs is a helper function to evaluate the homoglyphs rule type
 // Return parameters:
-// - bool: whether the evaluation has found violations
+// - []*domain.Violation: the violations found by the evaluation
+// - []interfaces.Annotation: the violations located in the PR files
 // - error: an error if the evaluation failed
 func evaluateHomoglyphs(
 	ctx context.Context,
 	processor domain.HomoglyphProcessor,
 	res *interfaces.Result,
-	reviewHandler *communication.GhReviewPrHandler,
-) ([]*domain.Violation, error) {
+	reviewHandler *communication.ReviewPrHandler,
+	action pr_actions.Action,
+) ([]*domain.Violation, []interfaces.Annotation, error) {
 	// create an empty list of violations
 	var violationsList []*domain.Violation
+	var annotations []interfaces.Annotation
 
 	if res == nil {
-		return violationsList, fmt.Errorf("result is nil")
+		return violationsList, annotations, fmt.Errorf("result is nil")
 	}
 
 	//nolint:govet
 	prContents, ok := res.Object.(*pbinternal.PrContents)
 	if !ok {
-		return violationsList, fmt.Errorf("invalid object type for homoglyphs evaluator")
+		return violationsList, annotations, fmt.Errorf("invalid object type for homoglyphs evaluator")
 	}
 
 	if prContents.Pr == nil || prContents.Files == nil {
-		return violationsList, fmt.Errorf("invalid prContents fields: %v, %v", prContents.Pr, prContents.Files)
+		return violationsList, annotations, fmt.Errorf("invalid prContents fields: %v, %v", prContents.Pr, prContents.Files)
 	}
 
 	if len(prContents.Files) == 0 {
-		return violationsList, nil
+		return violationsList, annotations, nil
 	}
 
 	// Note: This is a mandatory step to reassign certain fields in the handler.
@@ -102,19 +104,31 @@ func evaluateHomoglyphs(
 				commentBody.WriteString(processor.GetLineCommentText(v))
 			}
 
-			reviewComment := &github.DraftReviewComment{
-				Path: github.String(file.Name),
-				Body: github.String(commentBody.String()),
-				Line: github.Int(int(line.LineNumber)),
+			reviewComment := &provifv1.PullRequestReviewComment{
+				Path: file.Name,
+				Body: commentBody.String(),
+				Line: int(line.LineNumber),
 			}
 
 			reviewHandler.AddComment(reviewComment)
+
+			annotations = append(annotations, interfaces.Annotation{
+				Path:      file.Name,
+				StartLine: int(line.LineNumber),
+				Message:   commentBody.String(),
+			})
 		}
 	}
 
+	// with check runs, the annotations replace the review comments, and
+	// with profile_only nothing is written to the pull request
+	if action == pr_actions.ActionCheckRun || action == pr_actions.ActionProfileOnly {
+		return violationsList, annotations, nil
+	}
+
 	if len(reviewHandler.GetComments()) > 0 {
-		return violationsList, reviewHandler.SubmitReview(ctx, processor.GetFailedReviewText())
+		return violationsList, annotations, reviewHandler.SubmitReview(ctx, processor.GetFailedReviewText())
 	}
 
-	return violationsList, nil
+	return violationsList, annotations, nil
 }
@@ -11,6 +11,7 @@ import (
 	evalerrors "github.com/mindersec/minder/internal/engine/errors"
 	"github.com/mindersec/minder/internal/engine/eval/homoglyphs/communication"
 	"github.com/mindersec/minder/internal/engine/eval/homoglyphs/domain"
+	"github.com/mindersec/minder/internal/engine/eval/pr_actions"
 	"github.com/mindersec/minder/internal/engine/eval/templates"
 	eoptions "github.com/mindersec/minder/internal/engine/options"
 	"github.com/mindersec/minder/pkg/engine/v1/interfaces"
@@ -20,18 +21,27 @@ import (
 // InvisibleCharactersEvaluator is an evaluator for the invisible characters rule type
 type InvisibleCharactersEvaluator struct {
 	processor     domain.HomoglyphProcessor
-	reviewHandler *communication.GhReviewPrHandler
+	reviewHandler *communication.ReviewPrHandler
+	profileOnly   bool
+}
+
+var _ eoptions.SupportsProfileOnly = (*InvisibleCharactersEvaluator)(nil)
+
+// SetProfileOnly keeps the evaluator from reviewing the pull request,
+// whatever the action of the rule.
+func (ice *InvisibleCharactersEvaluator) SetProfileOnly() {
+	ice.profileOnly = true
 }
 
 // NewInvisibleCharactersEvaluator creates a new invisible characters evaluator
 func NewInvisibleCharactersEvaluator(
 	_ context.Context,
-	ghClient provifv1.GitHub,
+	client provifv1.PullRequestReviewer,
 	opts ...eoptions.Option,
 ) (*InvisibleCharactersEvaluator, error) {
 	evaluator := &InvisibleCharactersEvaluator{
 		processor:     domain.NewInvisibleCharactersProcessor(),
-		reviewHandler: communication.NewGhReviewPrHandler(ghClient),
+		reviewHandler: communication.NewReviewPrHandler(client),
 	}
 
 	for _, opt := range opts {
@@ -46,17 +56,23 @@ func NewInvisibleCharactersEvaluator(
 // Eval evaluates the invisible characters rule type
 func (ice *InvisibleCharactersEvaluator) Eval(
 	ctx context.Context,
-	_ map[string]any,
+	pol map[string]any,
 	_ protoreflect.ProtoMessage,
 	res *interfaces.Result,
 ) (*interfaces.EvaluationResult, error) {
-	violations, err := evaluateHomoglyphs(ctx, ice.processor, res, ice.reviewHandler)
+	action := pr_actions.RuleDefAction(pol)
+	if ice.profileOnly {
+		action = pr_actions.ActionProfileOnly
+	}
+
+	violations, annotations, err := evaluateHomoglyphs(
+		ctx, ice.processor, res, ice.reviewHandler, action)
 	if err != nil {
 		return nil, err
 	}
 
 	if len(violations) > 0 {
-		return nil, evalerrors.NewDetailedErrEvaluationFailed(
+		return &interfaces.EvaluationResult{Annotations: annotations}, evalerrors.NewDetailedErrEvaluationFailed(
 			templates.InvisibleCharactersTemplate,
 			map[string]any{"violations": violations},
 			"found invisible characters violations",
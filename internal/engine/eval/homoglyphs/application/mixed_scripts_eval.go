@@ -12,6 +12,7 @@ import (
 	evalerrors "github.com/mindersec/minder/internal/engine/errors"
 	"github.com/mindersec/minder/internal/engine/eval/homoglyphs/communication"
 	"github.com/mindersec/minder/internal/engine/eval/homoglyphs/domain"
+	"github.com/mindersec/minder/internal/engine/eval/pr_actions"
 	"github.com/mindersec/minder/internal/engine/eval/templates"
 	eoptions "github.com/mindersec/minder/internal/engine/options"
 	"github.com/mindersec/minder/pkg/engine/v1/interfaces"
@@ -21,13 +22,22 @@ import (
 // MixedScriptsEvaluator is the evaluator for the mixed scripts rule type
 type MixedScriptsEvaluator struct {
 	processor     domain.HomoglyphProcessor
-	reviewHandler *communication.GhReviewPrHandler
+	reviewHandler *communication.ReviewPrHandler
+	profileOnly   bool
+}
+
+var _ eoptions.SupportsProfileOnly = (*MixedScriptsEvaluator)(nil)
+
+// SetProfileOnly keeps the evaluator from reviewing the pull request,
+// whatever the action of the rule.
+func (mse *MixedScriptsEvaluator) SetProfileOnly() {
+	mse.profileOnly = true
 }
 
 // NewMixedScriptEvaluator creates a new mixed scripts evaluator
 func NewMixedScriptEvaluator(
 	ctx context.Context,
-	ghClient provifv1.GitHub,
+	client provifv1.PullRequestReviewer,
 	opts ...eoptions.Option,
 ) (*MixedScriptsEvaluator, error) {
 	msProcessor, err := domain.NewMixedScriptsProcessor(ctx)
@@ -37,7 +47,7 @@ func NewMixedScriptEvaluator(
 
 	evaluator := &MixedScriptsEvaluator{
 		processor:     msProcessor,
-		reviewHandler: communication.NewGhReviewPrHandler(ghClient),
+		reviewHandler: communication.NewReviewPrHandler(client),
 	}
 
 	for _, opt := range opts {
@@ -52,17 +62,23 @@ func NewMixedScriptEvaluator(
 // Eval evaluates the mixed scripts rule type
 func (mse *MixedScriptsEvaluator) Eval(
 	ctx context.Context,
-	_ map[string]any,
+	pol map[string]any,
 	_ protoreflect.ProtoMessage,
 	res *interfaces.Result,
 ) (*interfaces.EvaluationResult, error) {
-	violations, err := evaluateHomoglyphs(ctx, mse.processor, res, mse.reviewHandler)
+	action := pr_actions.RuleDefAction(pol)
+	if mse.profileOnly {
+		action = pr_actions.ActionProfileOnly
+	}
+
+	violations, annotations, err := evaluateHomoglyphs(
+		ctx, mse.processor, res, mse.reviewHandler, action)
 	if err != nil {
 		return nil, err
 	}
 
 	if len(violations) > 0 {
-		return nil, evalerrors.NewDetailedErrEvaluationFailed(
+		return &interfaces.EvaluationResult{Annotations: annotations}, evalerrors.NewDetailedErrEvaluationFailed(
 			templates.MixedScriptsTemplate,
 			map[string]any{"violations": violations},
 			"found mixed scripts violations",
@@ -9,59 +9,64 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/google/go-github/v63/github"
 	"github.com/rs/zerolog"
 
 	"github.com/mindersec/minder/internal/engine/eval/homoglyphs/util"
+	"github.com/mindersec/minder/internal/engine/eval/pr_actions"
 	pbinternal "github.com/mindersec/minder/internal/proto"
 	provifv1 "github.com/mindersec/minder/pkg/providers/v1"
 )
 
-// GhReviewPrHandler is a GitHub pull request review handler
-type GhReviewPrHandler struct {
+// ReviewPrHandler is a pull request review handler
+type ReviewPrHandler struct {
 	logger zerolog.Logger
 
-	ghClient provifv1.GitHub
-	pr       *pbinternal.PullRequest
+	client provifv1.PullRequestReviewer
+	pr     *pbinternal.PullRequest
 
-	minderReview *github.PullRequestReview
-	comments     []*github.DraftReviewComment
+	minderReview *provifv1.PullRequestReview
+	comments     []*provifv1.PullRequestReviewComment
 }
 
-// NewGhReviewPrHandler creates a new GitHub pull request review handler
-func NewGhReviewPrHandler(ghClient provifv1.GitHub) *GhReviewPrHandler {
-	return &GhReviewPrHandler{
-		ghClient: ghClient,
+// NewReviewPrHandler creates a new pull request review handler
+func NewReviewPrHandler(client provifv1.PullRequestReviewer) *ReviewPrHandler {
+	return &ReviewPrHandler{
+		client: client,
 	}
 }
 
 // SubmitReview submits a review to a pull request
-func (ra *GhReviewPrHandler) SubmitReview(ctx context.Context, reviewText string) error {
-	if err := ra.findPreviousReview(ctx); err != nil {
+func (ra *ReviewPrHandler) SubmitReview(ctx context.Context, reviewText string) error {
+	prRef, err := pr_actions.NewPullRequestReference(ra.pr)
+	if err != nil {
+		return fmt.Errorf("could not reference pull request: %w", err)
+	}
+
+	if err := ra.findPreviousReview(ctx, prRef); err != nil {
 		return fmt.Errorf("could not find previous review: %w", err)
 	}
 
 	if ra.minderReview != nil {
-		if ra.minderReview.CommitID != nil && *ra.minderReview.CommitID == ra.pr.CommitSha {
+		if ra.minderReview.CommitSHA == ra.pr.CommitSha {
 			// if the previous review was on the same commit, keep it
 			ra.logger.Debug().
-				Int64("review-id", ra.minderReview.GetID()).
+				Str("review-id", ra.minderReview.ID).
 				Msg("previous review was on the same commit, will keep it")
 			return nil
 		}
 
-		err := ra.dismissReview(ctx)
+		err := ra.dismissReview(ctx, prRef)
 		if err != nil {
 			ra.logger.Error().Err(err).
-				Int64("review-id", ra.minderReview.GetID()).
+				Str("review-id", ra.minderReview.ID).
 				Msg("could not dismiss previous review")
 		}
 		ra.logger.Debug().
-			Int64("review-id", ra.minderReview.GetID()).
+			Str("review-id", ra.minderReview.ID).
 			Msg("dismissed previous review")
 	}
 
-	if err := ra.submitReview(ctx, reviewText); err != nil {
+	if err := ra.submitReview(ctx, prRef, reviewText); err != nil {
 		return fmt.Errorf("could not submit review: %w", err)
 	}
 	ra.logger.Debug().Msg("submitted review")
@@ -69,7 +74,7 @@ func (ra *GhReviewPrHandler) SubmitReview(ctx context.Context, reviewText string
 }
 
 // Hydrate hydrates the handler with a pull request
-func (ra *GhReviewPrHandler) Hydrate(ctx context.Context, pr *pbinternal.PullRequest) {
+func (ra *ReviewPrHandler) Hydrate(ctx context.Context, pr *pbinternal.PullRequest) {
 	logger := zerolog.Ctx(ctx).With().
 		Int64("pull-number", pr.Number).
 		Str("repo-owner", pr.RepoOwner).
@@ -78,29 +83,29 @@ func (ra *GhReviewPrHandler) Hydrate(ctx context.Context, pr *pbinternal.PullReq
 
 	ra.logger = logger
 	ra.pr = pr
-	ra.comments = make([]*github.DraftReviewComment, 0)
+	ra.comments = make([]*provifv1.PullRequestReviewComment, 0)
 	ra.minderReview = nil
 }
 
 // AddComment adds a comment to the review
-func (ra *GhReviewPrHandler) AddComment(comment *github.DraftReviewComment) {
+func (ra *ReviewPrHandler) AddComment(comment *provifv1.PullRequestReviewComment) {
 	ra.comments = append(ra.comments, comment)
 }
 
 // GetComments returns the comments of the review
-func (ra *GhReviewPrHandler) GetComments() []*github.DraftReviewComment {
+func (ra *ReviewPrHandler) GetComments() []*provifv1.PullRequestReviewComment {
 	return ra.comments
 }
 
-func (ra *GhReviewPrHandler) findPreviousReview(ctx context.Context) error {
-	reviews, err := ra.ghClient.ListReviews(ctx, ra.pr.RepoOwner, ra.pr.RepoName, int(ra.pr.Number), nil)
+func (ra *ReviewPrHandler) findPreviousReview(ctx context.Context, prRef *provifv1.PullRequestReference) error {
+	reviews, err := ra.client.ListPullRequestReviews(ctx, prRef)
 	if err != nil {
 		return fmt.Errorf("could not list reviews: %w", err)
 	}
 
 	ra.minderReview = nil
 	for _, r := range reviews {
-		if strings.HasPrefix(r.GetBody(), util.ReviewBodyMagicComment) && r.GetState() != "DISMISSED" {
+		if strings.HasPrefix(r.Body, util.ReviewBodyMagicComment) && !r.Dismissed {
 			ra.minderReview = r
 			break
 		}
@@ -109,26 +114,21 @@ func (ra *GhReviewPrHandler) findPreviousReview(ctx context.Context) error {
 	return nil
 }
 
-func (ra *GhReviewPrHandler) submitReview(ctx context.Context, reviewText string) error {
+func (ra *ReviewPrHandler) submitReview(
+	ctx context.Context, prRef *provifv1.PullRequestReference, reviewText string,
+) error {
 	body, err := util.CreateReviewBody(reviewText)
 	if err != nil {
 		return fmt.Errorf("could not create review body: %w", err)
 	}
 
-	review := &github.PullRequestReviewRequest{
-		CommitID: github.String(ra.pr.CommitSha),
-		Event:    github.String("COMMENT"),
-		Comments: ra.comments,
-		Body:     github.String(body),
+	review := &provifv1.PullRequestReview{
+		CommitSHA: ra.pr.CommitSha,
+		Comments:  ra.comments,
+		Body:      body,
 	}
 
-	_, err = ra.ghClient.CreateReview(
-		ctx,
-		ra.pr.RepoOwner,
-		ra.pr.RepoName,
-		int(ra.pr.Number),
-		review,
-	)
+	_, err = ra.client.SubmitPullRequestReview(ctx, prRef, review)
 	if err != nil {
 		return fmt.Errorf("could not create review: %w", err)
 	}
@@ -136,22 +136,12 @@ func (ra *GhReviewPrHandler) submitReview(ctx context.Context, reviewText string
 	return nil
 }
 
-func (ra *GhReviewPrHandler) dismissReview(ctx context.Context) error {
+func (ra *ReviewPrHandler) dismissReview(ctx context.Context, prRef *provifv1.PullRequestReference) error {
 	if ra.minderReview == nil {
 		return nil
 	}
 
-	dismissReview := &github.PullRequestReviewDismissalRequest{
-		Message: github.String(util.ReviewBodyDismissCommentText),
-	}
-
-	_, err := ra.ghClient.DismissReview(
-		ctx,
-		ra.pr.RepoOwner,
-		ra.pr.RepoName,
-		int(ra.pr.Number),
-		ra.minderReview.GetID(),
-		dismissReview)
+	err := ra.client.DismissPullRequestReview(ctx, prRef, ra.minderReview.ID, util.ReviewBodyDismissCommentText)
 	if err != nil {
 		return fmt.Errorf("could not dismiss review: %w", err)
 	}
@@ -18,4 +18,17 @@ const (
 	ActionProfileOnly Action = "profile_only"
 	// ActionSummary puts a summary of the findings into the PR
 	ActionSummary Action = "summary"
+	// ActionCheckRun reports the findings as annotations of a check run
+	// created for the profile
+	ActionCheckRun Action = "check_run"
 )
+
+// RuleDefAction returns the PR action set in the `action` key of a rule
+// definition, or an empty action if the rule does not set one.
+func RuleDefAction(def map[string]any) Action {
+	action, ok := def["action"].(string)
+	if !ok {
+		return ""
+	}
+	return Action(action)
+}
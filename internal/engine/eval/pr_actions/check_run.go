@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pr_actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v63/github"
+
+	"github.com/mindersec/minder/internal/db"
+	evalerrors "github.com/mindersec/minder/internal/engine/errors"
+	pbinternal "github.com/mindersec/minder/internal/proto"
+	"github.com/mindersec/minder/pkg/engine/v1/interfaces"
+	provifv1 "github.com/mindersec/minder/pkg/providers/v1"
+)
+
+const (
+	// maxAnnotationsPerRequest is the maximum number of annotations GitHub
+	// accepts in a single check run create or update request
+	maxAnnotationsPerRequest = 50
+
+	checkRunNamePrefix = "Minder: "
+)
+
+// CheckRun collects the outcome of the rules of a profile evaluated against
+// a pull request, so they can be reported as a single GitHub check run.
+type CheckRun struct {
+	name         string
+	pr           *pbinternal.PullRequest
+	failedRules  []string
+	erroredRules []string
+	annotations  []interfaces.Annotation
+}
+
+// NewCheckRun creates a new check run for the given profile and pull request
+func NewCheckRun(profileName string, pr *pbinternal.PullRequest) *CheckRun {
+	return &CheckRun{
+		name: checkRunNamePrefix + profileName,
+		pr:   pr,
+	}
+}
+
+// AddRuleResult tracks the outcome of evaluating a rule of the profile
+func (c *CheckRun) AddRuleResult(ruleName string, res *interfaces.EvaluationResult, evalErr error) {
+	switch evalerrors.ErrorAsEvalStatus(evalErr) {
+	case db.EvalStatusTypesFailure:
+		c.failedRules = append(c.failedRules, ruleName)
+	case db.EvalStatusTypesError:
+		// a rule which could not be evaluated may hide a failure, so it
+		// fails the check run as well
+		c.erroredRules = append(c.erroredRules, ruleName)
+	case db.EvalStatusTypesSuccess, db.EvalStatusTypesSkipped, db.EvalStatusTypesPending, db.EvalStatusTypesExempt:
+	}
+
+	if res != nil {
+		c.annotations = append(c.annotations, res.Annotations...)
+	}
+}
+
+// Submit creates the check run on the head commit of the pull request. The
+// annotations are sent in batches, as GitHub limits how many are accepted
+// per request.
+func (c *CheckRun) Submit(ctx context.Context, cli provifv1.GitHub) error {
+	if c.pr == nil {
+		return errors.New("pull request was nil, can't create check run")
+	}
+
+	batches := c.annotationBatches()
+
+	run, err := cli.StartCheckRun(ctx, c.pr.GetRepoOwner(), c.pr.GetRepoName(), &github.CreateCheckRunOptions{
+		Name:        c.name,
+		HeadSHA:     c.pr.GetCommitSha(),
+		Status:      github.String("completed"),
+		Conclusion:  github.String(c.conclusion()),
+		CompletedAt: &github.Timestamp{Time: time.Now()},
+		Output:      c.output(batches[0]),
+	})
+	if err != nil {
+		return fmt.Errorf("could not create check run: %w", err)
+	}
+
+	for _, batch := range batches[1:] {
+		if _, err := cli.UpdateCheckRun(ctx, c.pr.GetRepoOwner(), c.pr.GetRepoName(), run.GetID(),
+			&github.UpdateCheckRunOptions{
+				Name:   c.name,
+				Output: c.output(batch),
+			}); err != nil {
+			return fmt.Errorf("could not add annotations to check run: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (c *CheckRun) conclusion() string {
+	if len(c.failedRules) > 0 || len(c.erroredRules) > 0 {
+		return "failure"
+	}
+	return "success"
+}
+
+func (c *CheckRun) output(annotations []*github.CheckRunAnnotation) *github.CheckRunOutput {
+	var title string
+	var summary strings.Builder
+	switch {
+	case len(c.failedRules) > 0 && len(c.erroredRules) > 0:
+		title = fmt.Sprintf("%d rule(s) failed, %d rule(s) errored", len(c.failedRules), len(c.erroredRules))
+	case len(c.failedRules) > 0:
+		title = fmt.Sprintf("%d rule(s) failed", len(c.failedRules))
+	case len(c.erroredRules) > 0:
+		title = fmt.Sprintf("%d rule(s) errored", len(c.erroredRules))
+	default:
+		title = "All rules passed"
+		summary.WriteString("Minder found no issues in this pull request.\n")
+	}
+	if len(c.failedRules) > 0 {
+		summary.WriteString("The following rules failed:\n")
+		for _, rule := range c.failedRules {
+			fmt.Fprintf(&summary, "- `%s`\n", rule)
+		}
+	}
+	if len(c.erroredRules) > 0 {
+		summary.WriteString("The following rules could not be evaluated:\n")
+		for _, rule := range c.erroredRules {
+			fmt.Fprintf(&summary, "- `%s`\n", rule)
+		}
+	}
+
+	return &github.CheckRunOutput{
+		Title:       github.String(title),
+		Summary:     github.String(summary.String()),
+		Annotations: annotations,
+	}
+}
+
+// annotationBatches converts the tracked annotations and splits them into
+// batches. There is always at least one, possibly empty, batch.
+func (c *CheckRun) annotationBatches() [][]*github.CheckRunAnnotation {
+	batches := [][]*github.CheckRunAnnotation{{}}
+	for _, a := range c.annotations {
+		if a.Path == "" || a.StartLine <= 0 {
+			continue
+		}
+
+		last := len(batches) - 1
+		if len(batches[last]) == maxAnnotationsPerRequest {
+			batches = append(batches, []*github.CheckRunAnnotation{})
+			last++
+		}
+
+		endLine := a.EndLine
+		if endLine < a.StartLine {
+			endLine = a.StartLine
+		}
+
+		annotation := &github.CheckRunAnnotation{
+			Path:            github.String(a.Path),
+			StartLine:       github.Int(a.StartLine),
+			EndLine:         github.Int(endLine),
+			AnnotationLevel: github.String("failure"),
+			Message:         github.String(a.Message),
+		}
+		if a.Title != "" {
+			annotation.Title = github.String(a.Title)
+		}
+
+		batches[last] = append(batches[last], annotation)
+	}
+	return batches
+}
@@ -0,0 +1,176 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pr_actions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-github/v63/github"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	evalerrors "github.com/mindersec/minder/internal/engine/errors"
+	pbinternal "github.com/mindersec/minder/internal/proto"
+	mock_ghclient "github.com/mindersec/minder/internal/providers/github/mock"
+	"github.com/mindersec/minder/pkg/engine/v1/interfaces"
+)
+
+func TestRuleDefAction(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, ActionCheckRun, RuleDefAction(map[string]any{"action": "check_run"}))
+	require.Equal(t, Action(""), RuleDefAction(map[string]any{"action": 1}))
+	require.Equal(t, Action(""), RuleDefAction(nil))
+}
+
+func TestCheckRunSubmit(t *testing.T) {
+	t.Parallel()
+
+	pr := &pbinternal.PullRequest{
+		CommitSha: "27d6810b861c81e8c61e09c651875f5a976781d1",
+		Number:    43,
+		RepoOwner: "stacklok",
+		RepoName:  "minder",
+	}
+
+	manyAnnotations := make([]interfaces.Annotation, 0, maxAnnotationsPerRequest+1)
+	for i := 0; i <= maxAnnotationsPerRequest; i++ {
+		manyAnnotations = append(manyAnnotations, interfaces.Annotation{
+			Path:      "main.go",
+			StartLine: i + 1,
+			Message:   "violation",
+		})
+	}
+
+	tests := []struct {
+		name      string
+		addResult func(*CheckRun)
+		mockSetup func(*mock_ghclient.MockGitHub)
+		wantErr   bool
+	}{
+		{
+			name: "passing rules",
+			addResult: func(c *CheckRun) {
+				c.AddRuleResult("rule", &interfaces.EvaluationResult{}, nil)
+			},
+			mockSetup: func(mockGitHub *mock_ghclient.MockGitHub) {
+				mockGitHub.EXPECT().
+					StartCheckRun(gomock.Any(), "stacklok", "minder", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ string, opts *github.CreateCheckRunOptions) (*github.CheckRun, error) {
+						require.Equal(t, "Minder: profile", opts.Name)
+						require.Equal(t, pr.CommitSha, opts.HeadSHA)
+						require.Equal(t, "success", opts.GetConclusion())
+						require.Empty(t, opts.Output.Annotations)
+						return &github.CheckRun{ID: github.Int64(1)}, nil
+					})
+			},
+		},
+		{
+			name: "failing rule with annotations",
+			addResult: func(c *CheckRun) {
+				c.AddRuleResult("passing", &interfaces.EvaluationResult{}, nil)
+				c.AddRuleResult("failing", &interfaces.EvaluationResult{
+					Annotations: []interfaces.Annotation{
+						{Path: "main.go", StartLine: 3, EndLine: 4, Title: "title", Message: "first"},
+						// annotations without location are ignored
+						{Message: "second"},
+					},
+				}, evalerrors.NewErrEvaluationFailed("failed"))
+			},
+			mockSetup: func(mockGitHub *mock_ghclient.MockGitHub) {
+				mockGitHub.EXPECT().
+					StartCheckRun(gomock.Any(), "stacklok", "minder", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ string, opts *github.CreateCheckRunOptions) (*github.CheckRun, error) {
+						require.Equal(t, "failure", opts.GetConclusion())
+						require.Contains(t, opts.Output.GetSummary(), "`failing`")
+						require.NotContains(t, opts.Output.GetSummary(), "`passing`")
+						require.Equal(t, []*github.CheckRunAnnotation{{
+							Path:            github.String("main.go"),
+							StartLine:       github.Int(3),
+							EndLine:         github.Int(4),
+							AnnotationLevel: github.String("failure"),
+							Title:           github.String("title"),
+							Message:         github.String("first"),
+						}}, opts.Output.Annotations)
+						return &github.CheckRun{ID: github.Int64(1)}, nil
+					})
+			},
+		},
+		{
+			name: "errored rule fails the check run",
+			addResult: func(c *CheckRun) {
+				c.AddRuleResult("passing", &interfaces.EvaluationResult{}, nil)
+				c.AddRuleResult("skipped", nil, evalerrors.NewErrEvaluationSkipped("skipped"))
+				c.AddRuleResult("errored", nil, errors.New("ingestion failed"))
+			},
+			mockSetup: func(mockGitHub *mock_ghclient.MockGitHub) {
+				mockGitHub.EXPECT().
+					StartCheckRun(gomock.Any(), "stacklok", "minder", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ string, opts *github.CreateCheckRunOptions) (*github.CheckRun, error) {
+						require.Equal(t, "failure", opts.GetConclusion())
+						require.Equal(t, "1 rule(s) errored", opts.Output.GetTitle())
+						require.Contains(t, opts.Output.GetSummary(), "`errored`")
+						require.NotContains(t, opts.Output.GetSummary(), "`skipped`")
+						return &github.CheckRun{ID: github.Int64(1)}, nil
+					})
+			},
+		},
+		{
+			name: "annotations are sent in batches",
+			addResult: func(c *CheckRun) {
+				c.AddRuleResult("failing", &interfaces.EvaluationResult{
+					Annotations: manyAnnotations,
+				}, evalerrors.NewErrEvaluationFailed("failed"))
+			},
+			mockSetup: func(mockGitHub *mock_ghclient.MockGitHub) {
+				mockGitHub.EXPECT().
+					StartCheckRun(gomock.Any(), "stacklok", "minder", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ string, opts *github.CreateCheckRunOptions) (*github.CheckRun, error) {
+						require.Len(t, opts.Output.Annotations, maxAnnotationsPerRequest)
+						return &github.CheckRun{ID: github.Int64(7)}, nil
+					})
+				mockGitHub.EXPECT().
+					UpdateCheckRun(gomock.Any(), "stacklok", "minder", int64(7), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ string, _ int64, opts *github.UpdateCheckRunOptions) (*github.CheckRun, error) {
+						require.Len(t, opts.Output.Annotations, 1)
+						return &github.CheckRun{ID: github.Int64(7)}, nil
+					})
+			},
+		},
+		{
+			name: "error creating the check run",
+			addResult: func(c *CheckRun) {
+				c.AddRuleResult("rule", nil, nil)
+			},
+			mockSetup: func(mockGitHub *mock_ghclient.MockGitHub) {
+				mockGitHub.EXPECT().
+					StartCheckRun(gomock.Any(), "stacklok", "minder", gomock.Any()).
+					Return(nil, errors.New("boom"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			mockClient := mock_ghclient.NewMockGitHub(ctrl)
+			tt.mockSetup(mockClient)
+
+			checkRun := NewCheckRun("profile", pr)
+			tt.addResult(checkRun)
+
+			err := checkRun.Submit(context.Background(), mockClient)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pr_actions
+
+import (
+	"errors"
+	"fmt"
+
+	pbinternal "github.com/mindersec/minder/internal/proto"
+	"github.com/mindersec/minder/pkg/entities/properties"
+	provifv1 "github.com/mindersec/minder/pkg/providers/v1"
+)
+
+// NewPullRequestReference returns the reference used by the providers to
+// give feedback on the pull request
+func NewPullRequestReference(pr *pbinternal.PullRequest) (*provifv1.PullRequestReference, error) {
+	if pr == nil {
+		return nil, errors.New("pull request was nil")
+	}
+
+	props, err := properties.NewProperties(pr.GetProperties().AsMap())
+	if err != nil {
+		return nil, fmt.Errorf("could not parse pull request properties: %w", err)
+	}
+
+	return &provifv1.PullRequestReference{
+		Owner:      pr.GetRepoOwner(),
+		Repo:       pr.GetRepoName(),
+		Number:     int(pr.GetNumber()),
+		CommitSHA:  pr.GetCommitSha(),
+		Properties: props,
+	}, nil
+}
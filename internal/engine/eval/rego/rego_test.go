@@ -539,3 +539,43 @@ allow {
 	})
 	require.ErrorIs(t, err, engerrors.ErrEvaluationFailed, "should have failed the evaluation")
 }
+
+func TestConstraintsViolationAnnotations(t *testing.T) {
+	t.Parallel()
+
+	e, err := rego.NewRegoEvaluator(
+		&minderv1.RuleType_Definition_Eval_Rego{
+			Type: rego.ConstraintsEvaluationType.String(),
+			Def: `
+package minder
+
+violations[{"msg": msg, "path": "main.go", "line": 3, "end_line": 5}] {
+	input.ingested.data == "foo"
+	msg := "data should not contain foo"
+}
+
+violations[{"msg": msg}] {
+	input.ingested.datum == "bar"
+	msg := "datum should not contain bar"
+}
+`,
+		},
+		nil,
+	)
+	require.NoError(t, err, "could not create evaluator")
+
+	res, err := e.Eval(context.Background(), map[string]any{}, nil, &interfaces.Result{
+		Object: map[string]any{
+			"data":  "foo",
+			"datum": "bar",
+		},
+	})
+	require.ErrorIs(t, err, engerrors.ErrEvaluationFailed, "should have failed the evaluation")
+	require.NotNil(t, res)
+	require.Equal(t, []interfaces.Annotation{{
+		Path:      "main.go",
+		StartLine: 3,
+		EndLine:   5,
+		Message:   "data should not contain foo",
+	}}, res.Annotations)
+}
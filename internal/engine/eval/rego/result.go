@@ -183,6 +183,7 @@ func (c *constraintsEvaluator) parseResult(rs rego.ResultSet, _ protoreflect.Pro
 	if resBuilder == nil {
 		return nil, fmt.Errorf("invalid format: %s", c.format)
 	}
+	var annotations []interfaces.Annotation
 	for _, r := range rs {
 		v, err := resultToViolation(r)
 		if err != nil {
@@ -193,9 +194,17 @@ func (c *constraintsEvaluator) parseResult(rs rego.ResultSet, _ protoreflect.Pro
 		if err != nil {
 			return nil, fmt.Errorf("cannot add result: %w", err)
 		}
+
+		if annotation := resultToAnnotation(r); annotation != nil {
+			annotations = append(annotations, *annotation)
+		}
 	}
 
-	return resBuilder.formatResults()
+	res, err := resBuilder.formatResults()
+	if res != nil {
+		res.Annotations = annotations
+	}
+	return res, err
 }
 
 func (c *constraintsEvaluator) resultsBuilder(rs rego.ResultSet) resultBuilder {
@@ -228,6 +237,56 @@ func resultToViolation(r rego.Result) (any, error) {
 	return msg, nil
 }
 
+// resultToAnnotation returns an annotation for violations whose details
+// point at a file, through the "path" and "line" keys and optionally the
+// "end_line" key. Otherwise, it returns nil.
+func resultToAnnotation(r rego.Result) *interfaces.Annotation {
+	detmap, ok := r.Bindings["details"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	path, ok := detmap["path"].(string)
+	if !ok || path == "" {
+		return nil
+	}
+
+	line := detailsInt(detmap["line"])
+	if line <= 0 {
+		return nil
+	}
+
+	msg, _ := detmap["msg"].(string)
+
+	return &interfaces.Annotation{
+		Path:      path,
+		StartLine: line,
+		EndLine:   detailsInt(detmap["end_line"]),
+		Message:   msg,
+	}
+}
+
+// detailsInt converts a numeric value of the violation details to an int,
+// returning 0 if the value is not a number
+func detailsInt(v any) int {
+	switch n := v.(type) {
+	case json.Number:
+		i, err := n.Int64()
+		if err != nil {
+			return 0
+		}
+		return int(i)
+	case float64:
+		return int(n)
+	case int:
+		return n
+	case int64:
+		return int(n)
+	default:
+		return 0
+	}
+}
+
 type resultBuilder interface {
 	addResult(msg any) error
 	formatResults() (*interfaces.EvaluationResult, error)
@@ -14,7 +14,6 @@ import (
 	"strings"
 	template "text/template"
 
-	"github.com/google/go-github/v63/github"
 	"github.com/rs/zerolog"
 
 	"github.com/mindersec/minder/internal/constants"
@@ -195,7 +194,7 @@ type dependencyAlternatives struct {
 
 // summaryPrHandler is a prStatusHandler that adds a summary text to the PR as a comment.
 type summaryPrHandler struct {
-	cli       provifv1.GitHub
+	cli       provifv1.PullRequestReviewer
 	pr        *pbinternal.PullRequest
 	trustyUrl string
 
@@ -229,32 +228,32 @@ func (sph *summaryPrHandler) submit(ctx context.Context, ruleConfig *config) err
 	action := ruleConfig.Action
 
 	// Check all the tracked dependencies. If any of them call for the PR
-	// to be blocked, set the review action to REQUEST_CHANGES
-	var reviewAction string = "COMMENT"
+	// to be blocked, request changes in the review
+	var requestChanges bool
 	for _, d := range sph.trackedAlternatives {
 		if d.BlockPR {
-			reviewAction = "REQUEST_CHANGES"
+			requestChanges = true
 			break
 		}
 	}
 
+	prRef, err := pr_actions.NewPullRequestReference(sph.pr)
+	if err != nil {
+		return fmt.Errorf("could not reference pull request: %w", err)
+	}
+
 	switch action {
 	case pr_actions.ActionReviewPr:
-		_, err = sph.cli.CreateReview(
-			ctx, sph.pr.GetRepoOwner(), sph.pr.GetRepoName(), int(sph.pr.GetNumber()),
-			&github.PullRequestReviewRequest{
-				NodeID:   new(string),
-				CommitID: &sph.pr.CommitSha,
-				Body:     &summary,
-				Event:    github.String(reviewAction),
-				Comments: []*github.DraftReviewComment{},
-			},
-		)
+		_, err = sph.cli.SubmitPullRequestReview(ctx, prRef, &provifv1.PullRequestReview{
+			CommitSHA:      sph.pr.CommitSha,
+			Body:           summary,
+			RequestChanges: requestChanges,
+		})
 		if err != nil {
 			return fmt.Errorf("submitting pr summary: %w", err)
 		}
 	case pr_actions.ActionSummary:
-		_, err = sph.cli.CreateIssueComment(ctx, sph.pr.GetRepoOwner(), sph.pr.GetRepoName(), int(sph.pr.GetNumber()), summary)
+		_, err = sph.cli.CreatePullRequestComment(ctx, prRef, summary)
 		if err != nil {
 			return fmt.Errorf("could not create comment: %w", err)
 		}
@@ -429,7 +428,7 @@ func (sph *summaryPrHandler) compileTemplate(malicious []maliciousTemplateData,
 
 func newSummaryPrHandler(
 	pr *pbinternal.PullRequest,
-	cli provifv1.GitHub,
+	cli provifv1.PullRequestReviewer,
 	trustyUrl string,
 ) (*summaryPrHandler, error) {
 	tmpl, err := template.New("comment").Parse(commentTemplate)
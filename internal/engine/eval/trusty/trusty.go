@@ -35,18 +35,27 @@ const (
 
 // Evaluator is the trusty evaluator
 type Evaluator struct {
-	cli      provifv1.GitHub
-	endpoint string
-	client   trusty.Trusty
+	cli         provifv1.PullRequestReviewer
+	endpoint    string
+	client      trusty.Trusty
+	profileOnly bool
+}
+
+var _ eoptions.SupportsProfileOnly = (*Evaluator)(nil)
+
+// SetProfileOnly keeps the evaluator from submitting its summary to the
+// pull request, whatever the action of the rule.
+func (e *Evaluator) SetProfileOnly() {
+	e.profileOnly = true
 }
 
 // NewTrustyEvaluator creates a new trusty evaluator
 func NewTrustyEvaluator(
 	ctx context.Context,
-	ghcli provifv1.GitHub,
+	cli provifv1.PullRequestReviewer,
 	opts ...eoptions.Option,
 ) (*Evaluator, error) {
-	if ghcli == nil {
+	if cli == nil {
 		return nil, fmt.Errorf("provider builder is nil")
 	}
 
@@ -66,7 +75,7 @@ func NewTrustyEvaluator(
 	})
 
 	evaluator := &Evaluator{
-		cli:      ghcli,
+		cli:      cli,
 		endpoint: trustyEndpoint,
 		client:   trustyClient,
 	}
@@ -140,7 +149,9 @@ func (e *Evaluator) Eval(
 		return &interfaces.EvaluationResult{}, nil
 	}
 
-	if err := submitSummary(ctx, prSummaryHandler, ruleConfig); err != nil {
+	if e.profileOnly {
+		logger.Debug().Msgf("profile only, not submitting pull request summary")
+	} else if err := submitSummary(ctx, prSummaryHandler, ruleConfig); err != nil {
 		logger.Err(err).Msgf("Failed generating PR summary: %s", err.Error())
 		return nil, fmt.Errorf("submitting pull request summary: %w", err)
 	}
@@ -8,13 +8,19 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/google/go-github/v63/github"
-
 	"github.com/mindersec/minder/internal/engine/eval/pr_actions"
 	pbinternal "github.com/mindersec/minder/internal/proto"
+	"github.com/mindersec/minder/pkg/engine/v1/interfaces"
 	provifv1 "github.com/mindersec/minder/pkg/providers/v1"
 )
 
+// Client is the provider client used by the vulncheck evaluator to fetch the
+// patches of a pull request and to give feedback on it
+type Client interface {
+	provifv1.PullRequestReviewer
+	provifv1.REST
+}
+
 type prStatusHandler interface {
 	trackVulnerableDep(
 		ctx context.Context,
@@ -25,23 +31,35 @@ type prStatusHandler interface {
 	submit(ctx context.Context) error
 }
 
+// annotatingPrStatusHandler is implemented by the handlers that report the
+// vulnerable dependencies as annotations of the evaluation result
+type annotatingPrStatusHandler interface {
+	getAnnotations() []interfaces.Annotation
+}
+
 func newPrStatusHandler(
 	ctx context.Context,
 	action pr_actions.Action,
 	pr *pbinternal.PullRequest,
-	client provifv1.GitHub,
+	client Client,
 ) (prStatusHandler, error) {
 	switch action {
 	case pr_actions.ActionReviewPr:
 		return newReviewPrHandler(ctx, pr, client)
 	case pr_actions.ActionCommitStatus:
-		return newCommitStatusPrHandler(ctx, pr, client)
+		ghClient, err := provifv1.As[provifv1.GitHub](client)
+		if err != nil {
+			return nil, fmt.Errorf("action %s is only supported by GitHub providers", action)
+		}
+		return newCommitStatusPrHandler(ctx, pr, ghClient)
 	case pr_actions.ActionComment:
-		return newReviewPrHandler(ctx, pr, client, withVulnsFoundReviewStatus(github.String("COMMENT")))
+		return newReviewPrHandler(ctx, pr, client, withCommentOnVulnsFound())
 	case pr_actions.ActionProfileOnly:
 		return newProfileOnlyPrHandler(), nil
 	case pr_actions.ActionSummary:
 		return newSummaryPrHandler(ctx, pr, client), nil
+	case pr_actions.ActionCheckRun:
+		return newCheckRunPrHandler(ctx, pr, client), nil
 	default:
 		return nil, fmt.Errorf("unknown action: %s", action)
 	}
@@ -11,7 +11,6 @@ import (
 	"fmt"
 	htmltemplate "html/template"
 	"regexp"
-	"strconv"
 	"strings"
 	"text/template"
 )
@@ -52,6 +51,11 @@ const (
 	pkgRepoLookupError           = "Error looking up package in the package database."
 )
 
+const (
+	vulnFoundCheckRunFmt          = "Dependency %s@%s has known vulnerabilities: %s."
+	vulnPatchedVersionCheckRunFmt = " Version %s fixes them."
+)
+
 const (
 	minderTemplateMagicCommentName = "minderCommentBody"
 	//nolint:lll
@@ -216,7 +220,7 @@ type statusReport struct {
 	StatusText          string
 	CommitSHA           string
 	TrackedDependencies []dependencyVulnerabilities
-	ReviewID            int64
+	ReviewID            string
 }
 
 func counter(condition func(dep dependencyVulnerabilities) bool) func(deps []dependencyVulnerabilities) int {
@@ -327,7 +331,7 @@ func render(templateName, templateString string, object interface{}) (string, er
 
 type magicCommentInfo struct {
 	ContentSha string `json:"ContentSha"`
-	ReviewID   int64  `json:"ReviewID"`
+	ReviewID   string `json:"ReviewID"`
 }
 
 func extractContentShaAndReviewID(input string) (magicCommentInfo, error) {
@@ -340,20 +344,15 @@ func extractContentShaAndReviewID(input string) (magicCommentInfo, error) {
 
 	jsonPart := matches[1]
 
-	var strMagicCommentInfo struct {
-		ContentSha string `json:"ContentSha"`
-		ReviewID   string `json:"ReviewID"` // Assuming you're handling ReviewID as a string
-	}
-	err := json.Unmarshal([]byte(jsonPart), &strMagicCommentInfo)
+	var contentInfo magicCommentInfo
+	err := json.Unmarshal([]byte(jsonPart), &contentInfo)
 	if err != nil {
 		return magicCommentInfo{}, fmt.Errorf("error unmarshalling JSON: %w", err)
 	}
 
-	var contentInfo magicCommentInfo
-	contentInfo.ContentSha = strMagicCommentInfo.ContentSha
-	contentInfo.ReviewID, err = strconv.ParseInt(strMagicCommentInfo.ReviewID, 10, 64)
-	if err != nil {
-		return magicCommentInfo{}, fmt.Errorf("error parsing ReviewID: %w", err)
+	// review IDs are opaque to minder, but are always made of word characters
+	if !regexp.MustCompile(`^\w*$`).MatchString(contentInfo.ReviewID) {
+		return magicCommentInfo{}, fmt.Errorf("error parsing ReviewID: %s", contentInfo.ReviewID)
 	}
 
 	return contentInfo, nil
@@ -16,7 +16,9 @@ import (
 	"github.com/google/go-github/v63/github"
 	"github.com/rs/zerolog"
 
+	"github.com/mindersec/minder/internal/engine/eval/pr_actions"
 	pbinternal "github.com/mindersec/minder/internal/proto"
+	"github.com/mindersec/minder/pkg/engine/v1/interfaces"
 	provifv1 "github.com/mindersec/minder/pkg/providers/v1"
 )
 
@@ -51,7 +53,7 @@ func countLeadingWhitespace(line string) int {
 
 func locateDepInPr(
 	ctx context.Context,
-	client provifv1.GitHub,
+	client provifv1.REST,
 	dep *pbinternal.PrDependencies_ContextualDependency,
 	patch patchLocatorFormatter,
 ) (*reviewLocation, error) {
@@ -103,70 +105,76 @@ func reviewBodyWithSuggestion(comment string) string {
 }
 
 type reviewPrHandler struct {
-	cli provifv1.GitHub
-	pr  *pbinternal.PullRequest
+	cli   Client
+	pr    *pbinternal.PullRequest
+	prRef *provifv1.PullRequestReference
 
 	trackedDeps []dependencyVulnerabilities
 
 	authorizedUser     int64
-	minderStatusReport *github.IssueComment
-	comments           []*github.DraftReviewComment
+	minderStatusReport *provifv1.PullRequestComment
+	comments           []*provifv1.PullRequestReviewComment
 
-	status     *string
-	text       *string
-	failStatus *string
+	requestChanges     bool
+	text               *string
+	failRequestChanges bool
 
 	logger zerolog.Logger
 }
 
 type reviewPrHandlerOption func(*reviewPrHandler)
 
-// WithSetReviewStatus is an option to set the vulnsFoundReviewStatus field of reviewPrHandler.
-func withVulnsFoundReviewStatus(status *string) reviewPrHandlerOption {
+// withCommentOnVulnsFound is an option to only comment, instead of requesting
+// changes, when vulnerabilities are found.
+func withCommentOnVulnsFound() reviewPrHandlerOption {
 	return func(r *reviewPrHandler) {
-		r.failStatus = status
+		r.failRequestChanges = false
 	}
 }
 
 func newReviewPrHandler(
 	ctx context.Context,
 	pr *pbinternal.PullRequest,
-	cli provifv1.GitHub,
+	cli Client,
 	opts ...reviewPrHandlerOption,
 ) (*reviewPrHandler, error) {
 	if pr == nil {
 		return nil, fmt.Errorf("pr was nil, can't review")
 	}
 
+	prRef, err := pr_actions.NewPullRequestReference(pr)
+	if err != nil {
+		return nil, fmt.Errorf("could not reference pull request: %w", err)
+	}
+
 	logger := zerolog.Ctx(ctx).With().
 		Int64("pull-number", pr.Number).
 		Str("repo-owner", pr.RepoOwner).
 		Str("repo-name", pr.RepoName).
 		Logger()
-	cliUserId, err := cli.GetUserId(ctx)
+	cliUserId, err := cli.GetReviewerID(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("could not get authenticated user: %w", err)
 	}
 
 	// if the user wants minder to request changes on a pull request, they need to
 	// be different identities
-	var failStatus *string
-	if pr.AuthorId == cliUserId {
-		failStatus = github.String("COMMENT")
-		logger.Debug().Msg("author is the same as the authenticated user, can only comment")
-	} else {
-		failStatus = github.String("REQUEST_CHANGES")
+	failRequestChanges := pr.AuthorId != cliUserId
+	if failRequestChanges {
 		logger.Debug().Msg("author is different than the authenticated user, can request changes")
+	} else {
+		logger.Debug().Msg("author is the same as the authenticated user, can only comment")
 	}
 
 	handler := &reviewPrHandler{
-		cli:            cli,
-		pr:             pr,
-		comments:       []*github.DraftReviewComment{},
-		logger:         logger,
-		failStatus:     failStatus,
-		trackedDeps:    []dependencyVulnerabilities{},
-		authorizedUser: cliUserId,
+		cli:                cli,
+		pr:                 pr,
+		prRef:              prRef,
+		comments:           []*provifv1.PullRequestReviewComment{},
+		logger:             logger,
+		failRequestChanges: failRequestChanges,
+		trackedDeps:        []dependencyVulnerabilities{},
+		authorizedUser:     cliUserId,
 	}
 
 	for _, opt := range opts {
@@ -243,16 +251,16 @@ func (ra *reviewPrHandler) trackVulnerableDep(
 		body = pkgRepoLookupError
 	}
 
-	reviewComment := &github.DraftReviewComment{
-		Path: github.String(dep.File.Name),
-		Body: github.String(body),
+	reviewComment := &provifv1.PullRequestReviewComment{
+		Path: dep.File.Name,
+		Body: body,
 	}
 
 	if lineTo > 0 {
-		reviewComment.StartLine = github.Int(location.lineToChange)
-		reviewComment.Line = github.Int(location.lineToChange + lineTo)
+		reviewComment.StartLine = location.lineToChange
+		reviewComment.Line = location.lineToChange + lineTo
 	} else {
-		reviewComment.Line = github.Int(location.lineToChange)
+		reviewComment.Line = location.lineToChange
 	}
 
 	ra.comments = append(ra.comments, reviewComment)
@@ -299,32 +307,28 @@ func (ra *reviewPrHandler) setStatus() {
 	if len(ra.comments) > 0 {
 		// if this pass produced comments, request changes
 		ra.text = github.String(vulnsFoundText)
-		ra.status = ra.failStatus
+		ra.requestChanges = ra.failRequestChanges
 		ra.logger.Debug().Msg("vulnerabilities found")
 	} else {
 		// if this pass produced no comments, resolve the minder review
-		ra.status = github.String("COMMENT")
+		ra.requestChanges = false
 		ra.text = github.String(noVulsFoundText)
 		ra.logger.Debug().Msg("no vulnerabilities found")
 	}
 
-	ra.logger.Debug().Str("status", *ra.status).Msg("will set review status")
+	ra.logger.Debug().Bool("request-changes", ra.requestChanges).Msg("will set review status")
 }
 
 func (ra *reviewPrHandler) findPreviousStatusComment(ctx context.Context) error {
-	comments, err := ra.cli.ListIssueComments(ctx, ra.pr.RepoOwner, ra.pr.RepoName, int(ra.pr.Number),
-		&github.IssueListCommentsOptions{
-			Sort:      github.String("updated"),
-			Direction: github.String("asc"),
-		})
+	comments, err := ra.cli.ListPullRequestComments(ctx, ra.prRef)
 	if err != nil {
 		return fmt.Errorf("could not list comments: %w", err)
 	}
 
 	ra.minderStatusReport = nil
 	for _, comment := range comments {
-		isMinder := comment.GetUser().GetID() == ra.authorizedUser
-		if isMinder && strings.HasPrefix(comment.GetBody(), statusBodyMagicCommentPrefix) {
+		isMinder := comment.AuthorID == ra.authorizedUser
+		if isMinder && strings.HasPrefix(comment.Body, statusBodyMagicCommentPrefix) {
 			ra.minderStatusReport = comment
 			break
 		}
@@ -338,7 +342,7 @@ func (ra *reviewPrHandler) getMagicCommentInfo() magicCommentInfo {
 		return magicCommentInfo{}
 	}
 
-	mci, err := extractContentShaAndReviewID(ra.minderStatusReport.GetBody())
+	mci, err := extractContentShaAndReviewID(ra.minderStatusReport.Body)
 	if err != nil {
 		ra.logger.Warn().Msg("could not extract content sha and review id from previous status comment")
 		// non-fatal error, we can still post the status report
@@ -347,13 +351,13 @@ func (ra *reviewPrHandler) getMagicCommentInfo() magicCommentInfo {
 	return mci
 }
 
-func (ra *reviewPrHandler) submitReview(ctx context.Context, mci magicCommentInfo) (int64, error) {
+func (ra *reviewPrHandler) submitReview(ctx context.Context, mci magicCommentInfo) (string, error) {
 	// if the previous review was on the same commit, keep it
 	if mci.ContentSha == ra.pr.GetCommitSha() {
 		ra.logger.Debug().
-			Int64("review-id", mci.ReviewID).
+			Str("review-id", mci.ReviewID).
 			Msg("previous review was on the same commit, will keep it")
-		return 0, nil
+		return "", nil
 	}
 
 	if err := ra.dismissReview(ctx, mci); err != nil {
@@ -363,35 +367,29 @@ func (ra *reviewPrHandler) submitReview(ctx context.Context, mci magicCommentInf
 	return ra.createReview(ctx)
 }
 
-func (ra *reviewPrHandler) createReview(ctx context.Context) (int64, error) {
-	var err error
-
+func (ra *reviewPrHandler) createReview(ctx context.Context) (string, error) {
 	if len(ra.comments) == 0 {
-		return 0, nil
+		return "", nil
 	}
 
-	review := &github.PullRequestReviewRequest{
-		CommitID: github.String(ra.pr.CommitSha),
-		Event:    ra.status,
-		Comments: ra.comments,
+	review := &provifv1.PullRequestReview{
+		CommitSHA:      ra.pr.CommitSha,
+		RequestChanges: ra.requestChanges,
+		Comments:       ra.comments,
 	}
 
-	r, err := ra.cli.CreateReview(
-		ctx,
-		ra.pr.RepoOwner,
-		ra.pr.RepoName,
-		int(ra.pr.Number),
-		review,
-	)
+	r, err := ra.cli.SubmitPullRequestReview(ctx, ra.prRef, review)
 	if err != nil {
-		return 0, fmt.Errorf("could not create review: %w", err)
+		return "", fmt.Errorf("could not create review: %w", err)
 	}
 
-	return r.GetID(), nil
+	return r.ID, nil
 }
 
 func (ra *reviewPrHandler) dismissReview(ctx context.Context, mci magicCommentInfo) error {
-	if mci.ReviewID == 0 {
+	// status comments written before review IDs were strings use 0 when
+	// there was no review
+	if mci.ReviewID == "" || mci.ReviewID == "0" {
 		ra.logger.Debug().Msg("no previous review to dismiss")
 		return nil
 	}
@@ -401,17 +399,7 @@ func (ra *reviewPrHandler) dismissReview(ctx context.Context, mci magicCommentIn
 		return nil
 	}
 
-	dismissReview := &github.PullRequestReviewDismissalRequest{
-		Message: github.String(reviewBodyDismissCommentText),
-	}
-
-	_, err := ra.cli.DismissReview(
-		ctx,
-		ra.pr.RepoOwner,
-		ra.pr.RepoName,
-		int(ra.pr.Number),
-		mci.ReviewID,
-		dismissReview)
+	err := ra.cli.DismissPullRequestReview(ctx, ra.prRef, mci.ReviewID, reviewBodyDismissCommentText)
 	if err != nil {
 		return fmt.Errorf("could not dismiss review: %w", err)
 	}
@@ -432,13 +420,7 @@ func (ra *reviewPrHandler) updateStatusReportComment(ctx context.Context, mci ma
 	}
 
 	if ra.minderStatusReport == nil {
-		if ra.minderStatusReport, err = ra.cli.CreateIssueComment(
-			ctx,
-			ra.pr.RepoOwner,
-			ra.pr.RepoName,
-			int(ra.pr.GetNumber()),
-			statusBody,
-		); err != nil {
+		if ra.minderStatusReport, err = ra.cli.CreatePullRequestComment(ctx, ra.prRef, statusBody); err != nil {
 			return fmt.Errorf("failed to create minder status report comment: %w", err)
 		}
 
@@ -446,13 +428,7 @@ func (ra *reviewPrHandler) updateStatusReportComment(ctx context.Context, mci ma
 	}
 
 	if mci.ContentSha != ra.pr.GetCommitSha() {
-		if err := ra.cli.UpdateIssueComment(
-			ctx,
-			ra.pr.RepoOwner,
-			ra.pr.RepoName,
-			ra.minderStatusReport.GetID(),
-			statusBody,
-		); err != nil {
+		if err := ra.cli.UpdatePullRequestComment(ctx, ra.prRef, ra.minderStatusReport.ID, statusBody); err != nil {
 			return fmt.Errorf("failed to update minder status report comment: %w", err)
 		}
 	}
@@ -464,6 +440,9 @@ func (ra *reviewPrHandler) updateStatusReportComment(ctx context.Context, mci ma
 type commitStatusPrHandler struct {
 	// embed the reviewPrHandler to automatically satisfy the prStatusHandler interface
 	reviewPrHandler
+
+	// commit statuses are only supported by GitHub
+	ghCli provifv1.GitHub
 }
 
 func newCommitStatusPrHandler(
@@ -476,7 +455,7 @@ func newCommitStatusPrHandler(
 		ctx,
 		pr,
 		client,
-		withVulnsFoundReviewStatus(github.String("COMMENT")),
+		withCommentOnVulnsFound(),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("could not create review handler: %w", err)
@@ -484,6 +463,7 @@ func newCommitStatusPrHandler(
 
 	return &commitStatusPrHandler{
 		reviewPrHandler: *rph,
+		ghCli:           client,
 	}, nil
 }
 
@@ -523,13 +503,13 @@ func (csh *commitStatusPrHandler) setCommitStatus(
 		Str("commit-sha", csh.pr.CommitSha).
 		Msg("setting commit status")
 
-	_, err := csh.cli.SetCommitStatus(ctx, csh.pr.RepoOwner, csh.pr.RepoName, csh.pr.CommitSha, commitStatus)
+	_, err := csh.ghCli.SetCommitStatus(ctx, csh.pr.RepoOwner, csh.pr.RepoName, csh.pr.CommitSha, commitStatus)
 	return err
 }
 
 // summaryPrHandler is a prStatusHandler that adds a summary text to the PR as a comment.
 type summaryPrHandler struct {
-	cli provifv1.GitHub
+	cli provifv1.PullRequestReviewer
 	pr  *pbinternal.PullRequest
 
 	logger      zerolog.Logger
@@ -566,7 +546,12 @@ func (sph *summaryPrHandler) submit(ctx context.Context) error {
 		return fmt.Errorf("could not generate summary: %w", err)
 	}
 
-	_, err = sph.cli.CreateIssueComment(ctx, sph.pr.GetRepoOwner(), sph.pr.GetRepoName(), int(sph.pr.GetNumber()), summary)
+	prRef, err := pr_actions.NewPullRequestReference(sph.pr)
+	if err != nil {
+		return fmt.Errorf("could not reference pull request: %w", err)
+	}
+
+	_, err = sph.cli.CreatePullRequestComment(ctx, prRef, summary)
 	if err != nil {
 		return fmt.Errorf("could not create comment: %w", err)
 	}
@@ -577,7 +562,7 @@ func (sph *summaryPrHandler) submit(ctx context.Context) error {
 func newSummaryPrHandler(
 	ctx context.Context,
 	pr *pbinternal.PullRequest,
-	cli provifv1.GitHub,
+	cli provifv1.PullRequestReviewer,
 ) *summaryPrHandler {
 	logger := zerolog.Ctx(ctx).With().
 		Int64("pull-number", pr.Number).
@@ -593,6 +578,78 @@ func newSummaryPrHandler(
 	}
 }
 
+// checkRunPrHandler is a prStatusHandler that locates the vulnerable dependencies
+// in the PR and turns them into annotations. The check run itself is created once
+// per profile by the engine, so submitting does nothing.
+type checkRunPrHandler struct {
+	cli provifv1.REST
+	pr  *pbinternal.PullRequest
+
+	logger      zerolog.Logger
+	annotations []interfaces.Annotation
+}
+
+func newCheckRunPrHandler(
+	ctx context.Context,
+	pr *pbinternal.PullRequest,
+	cli provifv1.REST,
+) *checkRunPrHandler {
+	logger := zerolog.Ctx(ctx).With().
+		Int64("pull-number", pr.Number).
+		Str("repo-owner", pr.RepoOwner).
+		Str("repo-name", pr.RepoName).
+		Logger()
+
+	return &checkRunPrHandler{
+		cli:    cli,
+		pr:     pr,
+		logger: logger,
+	}
+}
+
+func (crh *checkRunPrHandler) trackVulnerableDep(
+	ctx context.Context,
+	dep *pbinternal.PrDependencies_ContextualDependency,
+	vulnResp *VulnerabilityResponse,
+	patch patchLocatorFormatter,
+) error {
+	location, err := locateDepInPr(ctx, crh.cli, dep, patch)
+	if err != nil {
+		return fmt.Errorf("could not locate dependency in PR: %w", err)
+	}
+
+	ids := make([]string, 0, len(vulnResp.Vulns))
+	for _, vuln := range vulnResp.Vulns {
+		ids = append(ids, vuln.ID)
+	}
+
+	message := fmt.Sprintf(vulnFoundCheckRunFmt, dep.Dep.Name, dep.Dep.Version, strings.Join(ids, ", "))
+	if patch.HasPatchedVersion() {
+		message += fmt.Sprintf(vulnPatchedVersionCheckRunFmt, patch.GetPatchedVersion())
+	}
+
+	crh.annotations = append(crh.annotations, interfaces.Annotation{
+		Path:      dep.File.Name,
+		StartLine: location.lineToChange,
+		Title:     fmt.Sprintf("Vulnerable dependency %s", dep.Dep.Name),
+		Message:   message,
+	})
+
+	crh.logger.Debug().
+		Str("dep-name", dep.Dep.Name).
+		Msg("vulnerable dependency found")
+
+	return nil
+}
+
+func (_ *checkRunPrHandler) submit(_ context.Context) error {
+	return nil
+}
+
+func (crh *checkRunPrHandler) getAnnotations() []interfaces.Annotation {
+	return crh.annotations
+}
+
 // just satisfies the interface but really does nothing. Useful for testing.
 type profileOnlyPrHandler struct{}
 
@@ -20,14 +20,14 @@ import (
 
 	pbinternal "github.com/mindersec/minder/internal/proto"
 	mock_ghclient "github.com/mindersec/minder/internal/providers/github/mock"
+	provifv1 "github.com/mindersec/minder/pkg/providers/v1"
 )
 
 const (
 	githubSubmitterID = 144222806
 	githubMinderID    = 123456789
 
-	minderReviewID  = 987654321
-	minderReviewUrl = "https://github.com/repos/jakubtestorg/bad-npm/pulls/43#pullrequestreview-987654321"
+	minderReviewID = "987654321"
 
 	commitSHA        = "27d6810b861c81e8c61e09c651875f5a976781d1"
 	anotherCommitSha = "27d6810b861c81e8c61e09c651875f5a976781d2"
@@ -49,24 +49,24 @@ func TestReviewPrHandlerNoVulnerabilities(t *testing.T) {
 		AuthorId:  githubSubmitterID,
 	}
 
-	mockClient.EXPECT().GetUserId(gomock.Any()).Return(int64(githubSubmitterID), nil)
+	mockClient.EXPECT().GetReviewerID(gomock.Any()).Return(int64(githubSubmitterID), nil)
 	handler, err := newReviewPrHandler(context.TODO(), pr, mockClient)
 	require.NoError(t, err)
 	require.NotNil(t, handler)
 
-	report := createStatusReport(noVulsFoundText, commitSHA, 0)
+	report := createStatusReport(noVulsFoundText, commitSHA, "")
 
 	expBody, err := report.render()
 	require.NoError(t, err)
 	require.NotNil(t, expBody)
 
 	mockClient.EXPECT().
-		ListIssueComments(gomock.Any(), pr.RepoOwner, pr.RepoName, int(pr.Number), gomock.Any()).
-		Return([]*github.IssueComment{}, nil)
+		ListPullRequestComments(gomock.Any(), gomock.Any()).
+		Return([]*provifv1.PullRequestComment{}, nil)
 
 	mockClient.EXPECT().
-		CreateIssueComment(gomock.Any(), pr.RepoOwner, pr.RepoName, int(pr.Number), gomock.Any()).
-		Return(&github.IssueComment{ID: github.Int64(123)}, nil)
+		CreatePullRequestComment(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&provifv1.PullRequestComment{ID: "123"}, nil)
 
 	err = handler.submit(context.Background())
 	require.NoError(t, err)
@@ -88,7 +88,7 @@ func TestReviewPrHandlerVulnerabilitiesDifferentIdentities(t *testing.T) {
 		AuthorId:  githubSubmitterID,
 	}
 
-	mockClient.EXPECT().GetUserId(gomock.Any()).Return(int64(githubMinderID), nil)
+	mockClient.EXPECT().GetReviewerID(gomock.Any()).Return(int64(githubMinderID), nil)
 	handler, err := newReviewPrHandler(context.TODO(), pr, mockClient)
 	require.NoError(t, err)
 	require.NotNil(t, handler)
@@ -142,7 +142,7 @@ func TestReviewPrHandlerVulnerabilitiesDifferentIdentities(t *testing.T) {
 	err = handler.trackVulnerableDep(context.TODO(), dep, &vulnResp, patchPackage)
 	require.NoError(t, err)
 
-	statusReport := createStatusReport(vulnsFoundText, commitSHA, 0, dependencyVulnerabilities{
+	statusReport := createStatusReport(vulnsFoundText, commitSHA, "", dependencyVulnerabilities{
 		Dependency:      dep.Dep,
 		Vulnerabilities: vulnResp.Vulns,
 		PatchVersion:    "0.6.0",
@@ -154,27 +154,27 @@ func TestReviewPrHandlerVulnerabilitiesDifferentIdentities(t *testing.T) {
 	require.NotEmpty(t, expStatusBody)
 
 	mockClient.EXPECT().
-		CreateReview(gomock.Any(), pr.RepoOwner, pr.RepoName, int(pr.Number), &github.PullRequestReviewRequest{
-			CommitID: github.String(commitSHA),
-			Event:    github.String("REQUEST_CHANGES"),
-			Comments: []*github.DraftReviewComment{
+		SubmitPullRequestReview(gomock.Any(), gomock.Any(), &provifv1.PullRequestReview{
+			CommitSHA:      commitSHA,
+			RequestChanges: true,
+			Comments: []*provifv1.PullRequestReviewComment{
 				{
-					Path:      github.String(dep.File.Name),
-					StartLine: github.Int(1),
-					Line:      github.Int(4),
-					Body:      github.String("```suggestion\n\n  \"version\": \"0.6.0\",\n  \"resolved\": \"https://registry.npmjs.org/mongodb/-/mongodb-0.6.0.tgz\",\n  \"integrity\": \"sha512-+1+2+3+4+5+6+7+8+9+0\",\n```\n"),
+					Path:      dep.File.Name,
+					StartLine: 1,
+					Line:      4,
+					Body:      "```suggestion\n\n  \"version\": \"0.6.0\",\n  \"resolved\": \"https://registry.npmjs.org/mongodb/-/mongodb-0.6.0.tgz\",\n  \"integrity\": \"sha512-+1+2+3+4+5+6+7+8+9+0\",\n```\n",
 				},
 			},
-		}).Return(&github.PullRequestReview{HTMLURL: github.String(minderReviewUrl)}, nil)
+		}).Return(&provifv1.PullRequestReview{}, nil)
 
 	mockClient.EXPECT().
-		ListIssueComments(gomock.Any(), pr.RepoOwner, pr.RepoName, int(pr.Number), gomock.Any()).
-		Return([]*github.IssueComment{{
-			ID: github.Int64(12345), User: &github.User{ID: github.Int64(githubMinderID)}, Body: github.String(statusBodyMagicComment)},
+		ListPullRequestComments(gomock.Any(), gomock.Any()).
+		Return([]*provifv1.PullRequestComment{{
+			ID: "12345", AuthorID: githubMinderID, Body: statusBodyMagicComment},
 		}, nil)
 
 	mockClient.EXPECT().
-		UpdateIssueComment(gomock.Any(), pr.RepoOwner, pr.RepoName, int64(12345), expStatusBody).
+		UpdatePullRequestComment(gomock.Any(), gomock.Any(), "12345", expStatusBody).
 		Return(nil)
 
 	err = handler.submit(context.Background())
@@ -197,7 +197,7 @@ func TestReviewPrHandlerVulnerabilitiesErrLookUpPackage(t *testing.T) {
 		AuthorId:  githubSubmitterID,
 	}
 
-	mockClient.EXPECT().GetUserId(gomock.Any()).Return(int64(githubMinderID), nil)
+	mockClient.EXPECT().GetReviewerID(gomock.Any()).Return(int64(githubMinderID), nil)
 	handler, err := newReviewPrHandler(context.TODO(), pr, mockClient)
 	require.NoError(t, err)
 	require.NotNil(t, handler)
@@ -248,28 +248,24 @@ func TestReviewPrHandlerVulnerabilitiesErrLookUpPackage(t *testing.T) {
 	require.NoError(t, err)
 
 	mockClient.EXPECT().
-		ListIssueComments(gomock.Any(), pr.RepoOwner, pr.RepoName, int(pr.Number), gomock.Any()).
-		Return([]*github.IssueComment{}, nil)
+		ListPullRequestComments(gomock.Any(), gomock.Any()).
+		Return([]*provifv1.PullRequestComment{}, nil)
 
 	mockClient.EXPECT().
-		CreateReview(gomock.Any(), pr.RepoOwner, pr.RepoName, int(pr.Number), &github.PullRequestReviewRequest{
-			CommitID: github.String(commitSHA),
-			Event:    github.String("REQUEST_CHANGES"),
-			Comments: []*github.DraftReviewComment{
+		SubmitPullRequestReview(gomock.Any(), gomock.Any(), &provifv1.PullRequestReview{
+			CommitSHA:      commitSHA,
+			RequestChanges: true,
+			Comments: []*provifv1.PullRequestReviewComment{
 				{
-					Path: github.String(dep.File.Name),
-					Line: github.Int(1),
-					Body: github.String(pkgRepoInfoNotFound),
+					Path: dep.File.Name,
+					Line: 1,
+					Body: pkgRepoInfoNotFound,
 				},
-			}}).Return(
-		&github.PullRequestReview{
-			HTMLURL: github.String(minderReviewUrl),
-			ID:      github.Int64(minderReviewID),
-		}, nil)
+			}}).Return(&provifv1.PullRequestReview{ID: minderReviewID}, nil)
 
 	mockClient.EXPECT().
-		CreateIssueComment(gomock.Any(), pr.RepoOwner, pr.RepoName, int(pr.Number), gomock.Any()).
-		Return(&github.IssueComment{ID: github.Int64(123)}, nil)
+		CreatePullRequestComment(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&provifv1.PullRequestComment{ID: "123"}, nil)
 
 	err = handler.submit(context.Background())
 	require.NoError(t, err)
@@ -291,7 +287,7 @@ func TestReviewPrHandlerVulnerabilitiesWithNoPatchVersion(t *testing.T) {
 		AuthorId:  githubSubmitterID,
 	}
 
-	mockClient.EXPECT().GetUserId(gomock.Any()).Return(int64(githubMinderID), nil)
+	mockClient.EXPECT().GetReviewerID(gomock.Any()).Return(int64(githubMinderID), nil)
 	handler, err := newReviewPrHandler(context.TODO(), pr, mockClient)
 	require.NoError(t, err)
 	require.NotNil(t, handler)
@@ -335,7 +331,7 @@ func TestReviewPrHandlerVulnerabilitiesWithNoPatchVersion(t *testing.T) {
 	err = handler.trackVulnerableDep(context.TODO(), dep, &vulnResp, patchPackage)
 	require.NoError(t, err)
 
-	statusReport := createStatusReport(vulnsFoundText, commitSHA, 0, dependencyVulnerabilities{
+	statusReport := createStatusReport(vulnsFoundText, commitSHA, "", dependencyVulnerabilities{
 		Dependency:      dep.Dep,
 		Vulnerabilities: vulnResp.Vulns,
 		PatchVersion:    "",
@@ -349,26 +345,26 @@ func TestReviewPrHandlerVulnerabilitiesWithNoPatchVersion(t *testing.T) {
 	expCommentBody := fmt.Sprintf(vulnFoundWithNoPatchFmt, dep.Dep.Name)
 
 	mockClient.EXPECT().
-		CreateReview(gomock.Any(), pr.RepoOwner, pr.RepoName, int(pr.Number), &github.PullRequestReviewRequest{
-			CommitID: github.String(commitSHA),
-			Event:    github.String("REQUEST_CHANGES"),
-			Comments: []*github.DraftReviewComment{
+		SubmitPullRequestReview(gomock.Any(), gomock.Any(), &provifv1.PullRequestReview{
+			CommitSHA:      commitSHA,
+			RequestChanges: true,
+			Comments: []*provifv1.PullRequestReviewComment{
 				{
-					Path: github.String(dep.File.Name),
-					Line: github.Int(1),
-					Body: github.String(expCommentBody),
+					Path: dep.File.Name,
+					Line: 1,
+					Body: expCommentBody,
 				},
 			},
-		}).Return(&github.PullRequestReview{HTMLURL: github.String(minderReviewUrl)}, nil)
+		}).Return(&provifv1.PullRequestReview{}, nil)
 
 	mockClient.EXPECT().
-		ListIssueComments(gomock.Any(), pr.RepoOwner, pr.RepoName, int(pr.Number), gomock.Any()).
-		Return([]*github.IssueComment{{
-			ID: github.Int64(12345), User: &github.User{ID: github.Int64(githubMinderID)}, Body: github.String(statusBodyMagicComment)},
+		ListPullRequestComments(gomock.Any(), gomock.Any()).
+		Return([]*provifv1.PullRequestComment{{
+			ID: "12345", AuthorID: githubMinderID, Body: statusBodyMagicComment},
 		}, nil)
 
 	mockClient.EXPECT().
-		UpdateIssueComment(gomock.Any(), pr.RepoOwner, pr.RepoName, int64(12345), expStatusBody).
+		UpdatePullRequestComment(gomock.Any(), gomock.Any(), "12345", expStatusBody).
 		Return(nil)
 
 	err = handler.submit(context.Background())
@@ -391,7 +387,7 @@ func TestReviewPrHandlerVulnerabilitiesDismissReview(t *testing.T) {
 		AuthorId:  githubSubmitterID,
 	}
 
-	mockClient.EXPECT().GetUserId(gomock.Any()).Return(int64(minderReviewID), nil)
+	mockClient.EXPECT().GetReviewerID(gomock.Any()).Return(int64(githubMinderID), nil)
 	handler, err := newReviewPrHandler(context.TODO(), pr, mockClient)
 	require.NoError(t, err)
 	require.NotNil(t, handler)
@@ -449,37 +445,30 @@ func TestReviewPrHandlerVulnerabilitiesDismissReview(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEmpty(t, expStatusBody)
 
-	mockClient.EXPECT().DismissReview(gomock.Any(), pr.RepoOwner, pr.RepoName, int(pr.Number), int64(minderReviewID),
-		&github.PullRequestReviewDismissalRequest{
-			Message: github.String(reviewBodyDismissCommentText),
-		})
+	mockClient.EXPECT().DismissPullRequestReview(gomock.Any(), gomock.Any(), minderReviewID, reviewBodyDismissCommentText)
 
 	expCommentBody := fmt.Sprintf(vulnFoundWithNoPatchFmt, dep.Dep.Name)
 
 	mockClient.EXPECT().
-		CreateReview(gomock.Any(), pr.RepoOwner, pr.RepoName, int(pr.Number), &github.PullRequestReviewRequest{
-			CommitID: github.String(commitSHA),
-			Event:    github.String("REQUEST_CHANGES"),
-			Comments: []*github.DraftReviewComment{
+		SubmitPullRequestReview(gomock.Any(), gomock.Any(), &provifv1.PullRequestReview{
+			CommitSHA:      commitSHA,
+			RequestChanges: true,
+			Comments: []*provifv1.PullRequestReviewComment{
 				{
-					Path: github.String(dep.File.Name),
-					Line: github.Int(1),
-					Body: github.String(expCommentBody),
+					Path: dep.File.Name,
+					Line: 1,
+					Body: expCommentBody,
 				},
-			}}).Return(
-		&github.PullRequestReview{
-			HTMLURL: github.String(minderReviewUrl),
-			ID:      github.Int64(minderReviewID),
-		}, nil)
+			}}).Return(&provifv1.PullRequestReview{ID: minderReviewID}, nil)
 
 	mockClient.EXPECT().
-		ListIssueComments(gomock.Any(), pr.RepoOwner, pr.RepoName, int(pr.Number), gomock.Any()).
-		Return([]*github.IssueComment{{
-			ID: github.Int64(12345), User: &github.User{ID: github.Int64(minderReviewID)}, Body: github.String(statusComment)},
+		ListPullRequestComments(gomock.Any(), gomock.Any()).
+		Return([]*provifv1.PullRequestComment{{
+			ID: "12345", AuthorID: githubMinderID, Body: statusComment},
 		}, nil)
 
 	mockClient.EXPECT().
-		UpdateIssueComment(gomock.Any(), pr.RepoOwner, pr.RepoName, int64(12345), expStatusBody).
+		UpdatePullRequestComment(gomock.Any(), gomock.Any(), "12345", expStatusBody).
 		Return(nil)
 
 	err = handler.trackVulnerableDep(context.TODO(), dep, &vulnResp, patchPackage)
@@ -505,24 +494,24 @@ func TestCommitStatusHandlerNoVulnerabilities(t *testing.T) {
 		AuthorId:  githubSubmitterID,
 	}
 
-	mockClient.EXPECT().GetUserId(gomock.Any()).Return(int64(githubSubmitterID), nil)
+	mockClient.EXPECT().GetReviewerID(gomock.Any()).Return(int64(githubSubmitterID), nil)
 	handler, err := newCommitStatusPrHandler(context.TODO(), pr, mockClient)
 	require.NoError(t, err)
 	require.NotNil(t, handler)
 
-	report := createStatusReport(noVulsFoundText, commitSHA, 0)
+	report := createStatusReport(noVulsFoundText, commitSHA, "")
 
 	expBody, err := report.render()
 	require.NoError(t, err)
 	require.NotNil(t, expBody)
 
 	mockClient.EXPECT().
-		ListIssueComments(gomock.Any(), pr.RepoOwner, pr.RepoName, int(pr.Number), gomock.Any()).
-		Return([]*github.IssueComment{}, nil)
+		ListPullRequestComments(gomock.Any(), gomock.Any()).
+		Return([]*provifv1.PullRequestComment{}, nil)
 
 	mockClient.EXPECT().
-		CreateIssueComment(gomock.Any(), pr.RepoOwner, pr.RepoName, int(pr.Number), expBody).
-		Return(&github.IssueComment{ID: github.Int64(123)}, nil)
+		CreatePullRequestComment(gomock.Any(), gomock.Any(), expBody).
+		Return(&provifv1.PullRequestComment{ID: "123"}, nil)
 
 	mockClient.EXPECT().SetCommitStatus(gomock.Any(), pr.RepoOwner, pr.RepoName, commitSHA, &github.RepoStatus{
 		State:       github.String("success"),
@@ -550,7 +539,7 @@ func TestCommitStatusPrHandlerWithVulnerabilities(t *testing.T) {
 		AuthorId:  githubSubmitterID,
 	}
 
-	mockClient.EXPECT().GetUserId(gomock.Any()).Return(int64(githubMinderID), nil)
+	mockClient.EXPECT().GetReviewerID(gomock.Any()).Return(int64(githubMinderID), nil)
 	handler, err := newCommitStatusPrHandler(context.TODO(), pr, mockClient)
 	require.NoError(t, err)
 	require.NotNil(t, handler)
@@ -604,7 +593,7 @@ func TestCommitStatusPrHandlerWithVulnerabilities(t *testing.T) {
 	err = handler.trackVulnerableDep(context.TODO(), dep, &vulnResp, patchPackage)
 	require.NoError(t, err)
 
-	statusReport := createStatusReport(vulnsFoundText, commitSHA, 0, dependencyVulnerabilities{
+	statusReport := createStatusReport(vulnsFoundText, commitSHA, "", dependencyVulnerabilities{
 		Dependency:      dep.GetDep(),
 		Vulnerabilities: vulnResp.Vulns,
 		PatchVersion:    "0.6.0",
@@ -615,30 +604,29 @@ func TestCommitStatusPrHandlerWithVulnerabilities(t *testing.T) {
 	require.NotEmpty(t, expStatusBody)
 
 	mockClient.EXPECT().
-		CreateReview(gomock.Any(), pr.RepoOwner, pr.RepoName, int(pr.Number), &github.PullRequestReviewRequest{
-			CommitID: github.String(commitSHA),
-			Event:    github.String("COMMENT"),
-			Comments: []*github.DraftReviewComment{
+		SubmitPullRequestReview(gomock.Any(), gomock.Any(), &provifv1.PullRequestReview{
+			CommitSHA: commitSHA,
+			Comments: []*provifv1.PullRequestReviewComment{
 				{
-					Path:      github.String(dep.File.Name),
-					StartLine: github.Int(1),
-					Line:      github.Int(4),
-					Body: github.String("```suggestion\n\n" +
+					Path:      dep.File.Name,
+					StartLine: 1,
+					Line:      4,
+					Body: "```suggestion\n\n" +
 						"  \"version\": \"0.6.0\",\n" +
 						"  \"resolved\": \"https://registry.npmjs.org/mongodb/-/mongodb-0.6.0.tgz\",\n" +
 						"  \"integrity\": \"sha512-+1+2+3+4+5+6+7+8+9+0\",\n" +
-						"```\n"),
+						"```\n",
 				},
 			},
-		}).Return(&github.PullRequestReview{HTMLURL: github.String(minderReviewUrl)}, nil)
+		}).Return(&provifv1.PullRequestReview{}, nil)
 
 	mockClient.EXPECT().
-		ListIssueComments(gomock.Any(), pr.RepoOwner, pr.RepoName, int(pr.Number), gomock.Any()).
-		Return([]*github.IssueComment{}, nil)
+		ListPullRequestComments(gomock.Any(), gomock.Any()).
+		Return([]*provifv1.PullRequestComment{}, nil)
 
 	mockClient.EXPECT().
-		CreateIssueComment(gomock.Any(), pr.RepoOwner, pr.RepoName, int(pr.Number), expStatusBody).
-		Return(&github.IssueComment{ID: github.Int64(123)}, nil)
+		CreatePullRequestComment(gomock.Any(), gomock.Any(), expStatusBody).
+		Return(&provifv1.PullRequestComment{ID: "123"}, nil)
 
 	mockClient.EXPECT().SetCommitStatus(gomock.Any(), pr.RepoOwner, pr.RepoName, commitSHA, &github.RepoStatus{
 		State:       github.String("failure"),
@@ -666,15 +654,12 @@ func TestReviewPrHandlerReviewPriorReview(t *testing.T) {
 		AuthorId:  githubSubmitterID,
 	}
 
-	mockClient.EXPECT().GetUserId(gomock.Any()).Return(int64(githubMinderID), nil)
+	mockClient.EXPECT().GetReviewerID(gomock.Any()).Return(int64(githubMinderID), nil)
 	handler, err := newReviewPrHandler(context.TODO(), pr, mockClient)
 	require.NoError(t, err)
 	require.NotNil(t, handler)
 
-	mockClient.EXPECT().DismissReview(gomock.Any(), pr.RepoOwner, pr.RepoName, int(pr.Number), int64(minderReviewID),
-		&github.PullRequestReviewDismissalRequest{
-			Message: github.String(reviewBodyDismissCommentText),
-		})
+	mockClient.EXPECT().DismissPullRequestReview(gomock.Any(), gomock.Any(), minderReviewID, reviewBodyDismissCommentText)
 
 	statusComment, err := render(minderTemplateMagicCommentName, statusBodyMagicComment, magicCommentInfo{
 		ContentSha: anotherCommitSha,
@@ -683,13 +668,13 @@ func TestReviewPrHandlerReviewPriorReview(t *testing.T) {
 	require.NoError(t, err)
 
 	mockClient.EXPECT().
-		ListIssueComments(gomock.Any(), pr.RepoOwner, pr.RepoName, int(pr.Number), gomock.Any()).
-		Return([]*github.IssueComment{{
-			ID: github.Int64(12345), User: &github.User{ID: github.Int64(githubMinderID)}, Body: github.String(statusComment)},
+		ListPullRequestComments(gomock.Any(), gomock.Any()).
+		Return([]*provifv1.PullRequestComment{{
+			ID: "12345", AuthorID: githubMinderID, Body: statusComment},
 		}, nil)
 
 	mockClient.EXPECT().
-		UpdateIssueComment(gomock.Any(), pr.RepoOwner, pr.RepoName, int64(12345), gomock.Any()).
+		UpdatePullRequestComment(gomock.Any(), gomock.Any(), "12345", gomock.Any()).
 		Return(nil)
 
 	err = handler.submit(context.Background())
@@ -713,28 +698,28 @@ func TestReviewPrHandlerVulnerabilitiesAndNoPriorReview(t *testing.T) {
 		AuthorId:  githubSubmitterID,
 	}
 
-	mockClient.EXPECT().GetUserId(gomock.Any()).Return(int64(githubMinderID), nil)
+	mockClient.EXPECT().GetReviewerID(gomock.Any()).Return(int64(githubMinderID), nil)
 	handler, err := newReviewPrHandler(context.TODO(), pr, mockClient)
 	require.NoError(t, err)
 	require.NotNil(t, handler)
 
 	// Create a single comment to pretend some vulns were found
-	handler.comments = []*github.DraftReviewComment{{
-		Body: github.String("test"),
+	handler.comments = []*provifv1.PullRequestReviewComment{{
+		Body: "test",
 	}}
 
 	mockClient.EXPECT().
-		CreateReview(gomock.Any(), pr.RepoOwner, pr.RepoName, int(pr.Number), gomock.Any()).
-		Return(&github.PullRequestReview{HTMLURL: github.String(minderReviewUrl)}, nil)
+		SubmitPullRequestReview(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&provifv1.PullRequestReview{}, nil)
 
 	mockClient.EXPECT().
-		ListIssueComments(gomock.Any(), pr.RepoOwner, pr.RepoName, int(pr.Number), gomock.Any()).
-		Return([]*github.IssueComment{{
-			ID: github.Int64(12345), User: &github.User{ID: github.Int64(githubMinderID)}, Body: github.String(statusBodyMagicComment)},
+		ListPullRequestComments(gomock.Any(), gomock.Any()).
+		Return([]*provifv1.PullRequestComment{{
+			ID: "12345", AuthorID: githubMinderID, Body: statusBodyMagicComment},
 		}, nil)
 
 	mockClient.EXPECT().
-		UpdateIssueComment(gomock.Any(), pr.RepoOwner, pr.RepoName, int64(12345), gomock.Any()).
+		UpdatePullRequestComment(gomock.Any(), gomock.Any(), "12345", gomock.Any()).
 		Return(nil)
 
 	err = handler.submit(context.Background())
@@ -757,7 +742,7 @@ func TestReviewPrHandlerReviewAlreadyExistsOnSHA(t *testing.T) {
 		AuthorId:  githubSubmitterID,
 	}
 
-	mockClient.EXPECT().GetUserId(gomock.Any()).Return(int64(githubMinderID), nil)
+	mockClient.EXPECT().GetReviewerID(gomock.Any()).Return(int64(githubMinderID), nil)
 	handler, err := newReviewPrHandler(context.TODO(), pr, mockClient)
 	require.NoError(t, err)
 	require.NotNil(t, handler)
@@ -769,21 +754,21 @@ func TestReviewPrHandlerReviewAlreadyExistsOnSHA(t *testing.T) {
 	require.NoError(t, err)
 
 	mockClient.EXPECT().
-		ListIssueComments(gomock.Any(), pr.RepoOwner, pr.RepoName, int(pr.Number), gomock.Any()).
-		Return([]*github.IssueComment{{
-			ID:   github.Int64(12345),
-			User: &github.User{ID: github.Int64(githubMinderID)},
-			Body: github.String(statusComment),
+		ListPullRequestComments(gomock.Any(), gomock.Any()).
+		Return([]*provifv1.PullRequestComment{{
+			ID:       "12345",
+			AuthorID: githubMinderID,
+			Body:     statusComment,
 		},
 		}, nil)
 
 	err = handler.submit(context.Background())
 	require.NoError(t, err)
-	require.Contains(t, handler.minderStatusReport.GetBody(), commitSHA)
+	require.Contains(t, handler.minderStatusReport.Body, commitSHA)
 }
 
 //nolint:unparam
-func createStatusReport(reviewText, sha string, reviewID int64, deps ...dependencyVulnerabilities) vulnerabilityReport {
+func createStatusReport(reviewText, sha string, reviewID string, deps ...dependencyVulnerabilities) vulnerabilityReport {
 	return &statusReport{
 		StatusText:          reviewText,
 		TrackedDependencies: deps,
@@ -791,3 +776,71 @@ func createStatusReport(reviewText, sha string, reviewID int64, deps ...dependen
 		ReviewID:            reviewID,
 	}
 }
+
+func TestCheckRunPrHandlerWithVulnerabilities(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mock_ghclient.NewMockGitHub(ctrl)
+	pr := &pbinternal.PullRequest{
+		Url:       "https://api.github.com/repos/jakubtestorg/bad-npm/pulls/43",
+		CommitSha: commitSHA,
+		Number:    43,
+		RepoOwner: "jakubtestorg",
+		RepoName:  "bad-npm",
+		AuthorId:  githubSubmitterID,
+	}
+
+	handler := newCheckRunPrHandler(context.TODO(), pr, mockClient)
+	require.NotNil(t, handler)
+
+	dep := &pbinternal.PrDependencies_ContextualDependency{
+		Dep: &pbinternal.Dependency{
+			Ecosystem: pbinternal.DepEcosystem_DEP_ECOSYSTEM_NPM,
+			Name:      "mongodb",
+			Version:   "0.5.0",
+		},
+		File: &pbinternal.PrDependencies_ContextualDependency_FilePatch{
+			Name:     "package-lock.json",
+			PatchUrl: "https://example.com/package-lock.json",
+		},
+	}
+
+	patchPackage := &packageJson{
+		Name:    "mongodb",
+		Version: "0.6.0",
+	}
+	mockClient.EXPECT().
+		NewRequest("GET", dep.File.PatchUrl, nil).
+		Return(http.NewRequest("GET", dep.File.PatchUrl, nil))
+	mockClient.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		Return(&http.Response{
+			StatusCode: http.StatusOK,
+			Body: io.NopCloser(strings.NewReader(
+				"{\n  \"node_modules/mongodb\": {\n    \"version\": \"0.5.0\",\n")),
+		}, nil)
+
+	vulnResp := VulnerabilityResponse{
+		[]Vulnerability{
+			{ID: "GHSA-1", Fixed: "0.6.0"},
+			{ID: "GHSA-2", Fixed: "0.6.0"},
+		},
+	}
+	err := handler.trackVulnerableDep(context.TODO(), dep, &vulnResp, patchPackage)
+	require.NoError(t, err)
+
+	// the check run is created by the engine, submitting does not call GitHub
+	err = handler.submit(context.Background())
+	require.NoError(t, err)
+
+	annotations := handler.getAnnotations()
+	require.Len(t, annotations, 1)
+	require.Equal(t, "package-lock.json", annotations[0].Path)
+	require.Equal(t, 3, annotations[0].StartLine)
+	require.Equal(t,
+		"Dependency mongodb@0.5.0 has known vulnerabilities: GHSA-1, GHSA-2. Version 0.6.0 fixes them.",
+		annotations[0].Message)
+}
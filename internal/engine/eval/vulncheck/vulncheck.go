@@ -16,12 +16,12 @@ import (
 	"google.golang.org/protobuf/reflect/protoreflect"
 
 	evalerrors "github.com/mindersec/minder/internal/engine/errors"
+	"github.com/mindersec/minder/internal/engine/eval/pr_actions"
 	"github.com/mindersec/minder/internal/engine/eval/templates"
 	eoptions "github.com/mindersec/minder/internal/engine/options"
 	"github.com/mindersec/minder/internal/flags"
 	pbinternal "github.com/mindersec/minder/internal/proto"
 	"github.com/mindersec/minder/pkg/engine/v1/interfaces"
-	provifv1 "github.com/mindersec/minder/pkg/providers/v1"
 )
 
 const (
@@ -31,11 +31,13 @@ const (
 
 // Evaluator is the vulncheck evaluator
 type Evaluator struct {
-	cli          provifv1.GitHub
+	cli          Client
 	featureFlags openfeature.IClient
+	profileOnly  bool
 }
 
 var _ eoptions.SupportsFlags = (*Evaluator)(nil)
+var _ eoptions.SupportsProfileOnly = (*Evaluator)(nil)
 
 // SetFlagsClient sets the `openfeature` client in the underlying
 // `Evaluator` struct.
@@ -44,17 +46,23 @@ func (e *Evaluator) SetFlagsClient(client openfeature.IClient) error {
 	return nil
 }
 
+// SetProfileOnly keeps the evaluator from replying to the pull request,
+// whatever the action of the rule.
+func (e *Evaluator) SetProfileOnly() {
+	e.profileOnly = true
+}
+
 // NewVulncheckEvaluator creates a new vulncheck evaluator
 func NewVulncheckEvaluator(
-	ghcli provifv1.GitHub,
+	cli Client,
 	opts ...eoptions.Option,
 ) (*Evaluator, error) {
-	if ghcli == nil {
+	if cli == nil {
 		return nil, fmt.Errorf("provider builder is nil")
 	}
 
 	evaluator := &Evaluator{
-		cli: ghcli,
+		cli: cli,
 	}
 
 	for _, opt := range opts {
@@ -73,14 +81,15 @@ func (e *Evaluator) Eval(
 	_ protoreflect.ProtoMessage,
 	res *interfaces.Result,
 ) (*interfaces.EvaluationResult, error) {
-	vulnerablePackages, err := e.getVulnerableDependencies(ctx, pol, res)
+	vulnerablePackages, annotations, err := e.getVulnerableDependencies(ctx, pol, res)
 	if err != nil {
 		return nil, err
 	}
 
+	result := &interfaces.EvaluationResult{Annotations: annotations}
 	if len(vulnerablePackages) > 0 {
 		if e.featureFlags != nil && flags.Bool(ctx, e.featureFlags, flags.VulnCheckErrorTemplate) {
-			return nil, evalerrors.NewDetailedErrEvaluationFailed(
+			return result, evalerrors.NewDetailedErrEvaluationFailed(
 				templates.VulncheckTemplate,
 				map[string]any{"packages": vulnerablePackages},
 				"vulnerable packages: %s",
@@ -88,38 +97,46 @@ func (e *Evaluator) Eval(
 			)
 		}
 
-		return nil, evalerrors.NewErrEvaluationFailed(
+		return result, evalerrors.NewErrEvaluationFailed(
 			"vulnerable packages: %s",
 			strings.Join(vulnerablePackages, ","),
 		)
 	}
 
-	return &interfaces.EvaluationResult{}, nil
+	return result, nil
 }
 
-// getVulnerableDependencies returns a slice containing vulnerable dependencies.
+// getVulnerableDependencies returns a slice containing vulnerable dependencies,
+// along with their annotations if the configured PR action produces any.
 // TODO: it would be nice if we could express this in rego over
 // `input.ingested.deps[_].dep`, rather than building this in to core.
-func (e *Evaluator) getVulnerableDependencies(ctx context.Context, pol map[string]any, res *interfaces.Result) ([]string, error) {
+func (e *Evaluator) getVulnerableDependencies(
+	ctx context.Context, pol map[string]any, res *interfaces.Result,
+) ([]string, []interfaces.Annotation, error) {
 	var vulnerablePackages []string
 
 	prdeps, ok := res.Object.(*pbinternal.PrDependencies)
 	if !ok {
-		return nil, fmt.Errorf("invalid object type for vulncheck evaluator")
+		return nil, nil, fmt.Errorf("invalid object type for vulncheck evaluator")
 	}
 
 	if len(prdeps.Deps) == 0 {
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	ruleConfig, err := parseConfig(pol)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse config: %w", err)
+		return nil, nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	action := ruleConfig.Action
+	if e.profileOnly {
+		action = pr_actions.ActionProfileOnly
 	}
 
-	prReplyHandler, err := newPrStatusHandler(ctx, ruleConfig.Action, prdeps.Pr, e.cli)
+	prReplyHandler, err := newPrStatusHandler(ctx, action, prdeps.Pr, e.cli)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create pr action: %w", err)
+		return nil, nil, fmt.Errorf("failed to create pr action: %w", err)
 	}
 
 	pkgRepoCache := newRepoCache()
@@ -131,7 +148,7 @@ func (e *Evaluator) getVulnerableDependencies(ctx context.Context, pol map[strin
 
 		vulnerable, err := e.checkVulnerabilities(ctx, dep, ruleConfig, pkgRepoCache, prReplyHandler)
 		if err != nil {
-			return nil, fmt.Errorf("failed to check vulnerabilities: %w", err)
+			return nil, nil, fmt.Errorf("failed to check vulnerabilities: %w", err)
 		}
 
 		if vulnerable {
@@ -140,10 +157,15 @@ func (e *Evaluator) getVulnerableDependencies(ctx context.Context, pol map[strin
 	}
 
 	if err := prReplyHandler.submit(ctx); err != nil {
-		return nil, fmt.Errorf("failed to submit pr action: %w", err)
+		return nil, nil, fmt.Errorf("failed to submit pr action: %w", err)
+	}
+
+	var annotations []interfaces.Annotation
+	if annotator, ok := prReplyHandler.(annotatingPrStatusHandler); ok {
+		annotations = annotator.getAnnotations()
 	}
 
-	return vulnerablePackages, nil
+	return vulnerablePackages, annotations, nil
 }
 
 // getPatchedVersion returns a version that patches all known vulnerabilities. If no such version exists, it returns
@@ -5,6 +5,8 @@ package engine
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
@@ -12,6 +14,7 @@ import (
 	"github.com/open-feature/go-sdk/openfeature"
 	"github.com/rs/zerolog"
 
+	"github.com/mindersec/minder/internal/crypto"
 	datasourceservice "github.com/mindersec/minder/internal/datasources/service"
 	"github.com/mindersec/minder/internal/db"
 	"github.com/mindersec/minder/internal/engine/actions"
@@ -19,6 +22,7 @@ import (
 	"github.com/mindersec/minder/internal/engine/actions/remediate"
 	"github.com/mindersec/minder/internal/engine/entities"
 	evalerrors "github.com/mindersec/minder/internal/engine/errors"
+	"github.com/mindersec/minder/internal/engine/eval/pr_actions"
 	"github.com/mindersec/minder/internal/engine/ingestcache"
 	engif "github.com/mindersec/minder/internal/engine/interfaces"
 	eoptions "github.com/mindersec/minder/internal/engine/options"
@@ -26,11 +30,15 @@ import (
 	"github.com/mindersec/minder/internal/entities/properties/service"
 	"github.com/mindersec/minder/internal/history"
 	minderlogger "github.com/mindersec/minder/internal/logger"
+	"github.com/mindersec/minder/internal/projects"
+	pbinternal "github.com/mindersec/minder/internal/proto"
 	"github.com/mindersec/minder/internal/providers/manager"
 	provsel "github.com/mindersec/minder/internal/providers/selectors"
 	pb "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	serverconfig "github.com/mindersec/minder/pkg/config/server"
 	"github.com/mindersec/minder/pkg/engine/selectors"
 	"github.com/mindersec/minder/pkg/engine/v1/interfaces"
+	eventer "github.com/mindersec/minder/pkg/eventer/interfaces"
 	"github.com/mindersec/minder/pkg/profiles"
 	"github.com/mindersec/minder/pkg/profiles/models"
 	provinfv1 "github.com/mindersec/minder/pkg/providers/v1"
@@ -52,6 +60,9 @@ type executor struct {
 	profileStore    profiles.ProfileStore
 	selBuilder      selectors.SelectionBuilder
 	propService     service.PropertiesService
+	alertsCfg       *serverconfig.AlertsConfig
+	cryptoEngine    crypto.Engine
+	evt             eventer.Publisher
 }
 
 // NewExecutor creates a new executor
@@ -64,6 +75,9 @@ func NewExecutor(
 	profileStore profiles.ProfileStore,
 	selBuilder selectors.SelectionBuilder,
 	propService service.PropertiesService,
+	alertsCfg *serverconfig.AlertsConfig,
+	cryptoEngine crypto.Engine,
+	evt eventer.Publisher,
 ) Executor {
 	return &executor{
 		querier:         querier,
@@ -74,6 +88,9 @@ func NewExecutor(
 		profileStore:    profileStore,
 		selBuilder:      selBuilder,
 		propService:     propService,
+		alertsCfg:       alertsCfg,
+		cryptoEngine:    cryptoEngine,
+		evt:             evt,
 	}
 }
 
@@ -140,23 +157,74 @@ func (e *executor) EvalEntityEvent(ctx context.Context, inf *entities.EntityInfo
 		return fmt.Errorf("error while retrieving profiles and rule instances: %w", err)
 	}
 
+	alertsCfg := e.projectAlertsConfig(ctx, inf.ProjectID)
+
 	// For each profile, get the profileEvalStatus first. Then, if the profileEvalStatus is nil
 	// evaluate each rule and store the outcome in the database. If profileEvalStatus is non-nil,
 	// just store it for all rules without evaluation.
 	for _, profile := range profileAggregates {
+		if !shouldEvaluateProfile(inf, &profile) {
+			logger.Debug().Str("profile", profile.Name).Msg("skipping profile not evaluated for this event")
+			continue
+		}
 
 		profileEvalStatus := e.profileEvalStatus(ctx, inf, profile)
 
+		var checkRun *pr_actions.CheckRun
+		if profileEvalStatus == nil {
+			checkRun = newProfileCheckRun(inf, &profile)
+		}
+
 		for _, rule := range profile.Rules {
-			if err := e.evaluateRule(ctx, inf, provider, &profile, &rule, ruleEngineCache, profileEvalStatus); err != nil {
+			if err := e.evaluateRule(
+				ctx, inf, provider, &profile, &rule, ruleEngineCache, profileEvalStatus, checkRun, alertsCfg,
+			); err != nil {
 				return fmt.Errorf("error evaluating entity event: %w", err)
 			}
 		}
+
+		if checkRun != nil {
+			submitCheckRun(ctx, provider, checkRun)
+		}
 	}
 
 	return nil
 }
 
+// projectAlertsConfig returns the alerts configuration of the server with the
+// alert settings of the entity's project applied on top of it. It falls back to
+// the server configuration if the project can't be read.
+func (e *executor) projectAlertsConfig(ctx context.Context, projectID uuid.UUID) *serverconfig.AlertsConfig {
+	project, err := e.querier.GetProjectByID(ctx, projectID)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("error getting project, using the server alerts configuration")
+		return e.alertsCfg
+	}
+
+	meta, err := projects.ParseMetadata(&project)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("error parsing project metadata, using the server alerts configuration")
+		return e.alertsCfg
+	}
+
+	alertsCfg, err := meta.AlertsConfig(e.alertsCfg, e.cryptoEngine)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("error reading project alerts configuration, using the server one")
+		return e.alertsCfg
+	}
+	return alertsCfg
+}
+
+// shouldEvaluateProfile reports whether the profile is evaluated for the event.
+// Profiles with a schedule are only evaluated by the events published for them
+// by the reminder, and those events only evaluate that profile.
+func shouldEvaluateProfile(inf *entities.EntityInfoWrapper, profile *models.ProfileAggregate) bool {
+	if inf.ProfileID != uuid.Nil {
+		return profile.ID == inf.ProfileID
+	}
+	return profile.Schedule == nil
+}
+
 func (e *executor) evaluateRule(
 	ctx context.Context,
 	inf *entities.EntityInfoWrapper,
@@ -165,6 +233,8 @@ func (e *executor) evaluateRule(
 	rule *models.RuleInstance,
 	ruleEngineCache rtengine.Cache,
 	profileEvalStatus error,
+	checkRun *pr_actions.CheckRun,
+	alertsCfg *serverconfig.AlertsConfig,
 ) error {
 	// Create eval status params
 	evalParams, err := e.createEvalStatusParams(ctx, inf, profile, rule)
@@ -180,7 +250,7 @@ func (e *executor) evaluateRule(
 
 	// create the action engine for this rule instance
 	// unlike the rule type engine, this cannot be cached
-	actionEngine, err := actions.NewRuleActions(ctx, ruleEngine.GetRuleType(), provider, &profile.ActionConfig)
+	actionEngine, err := actions.NewRuleActions(ctx, ruleEngine.GetRuleType(), provider, &profile.ActionConfig, alertsCfg, e.evt)
 	if err != nil {
 		return fmt.Errorf("cannot create rule actions engine: %w", err)
 	}
@@ -193,6 +263,8 @@ func (e *executor) evaluateRule(
 	var result *interfaces.EvaluationResult
 	if profileEvalStatus != nil {
 		evalErr = profileEvalStatus
+	} else if exceptionStatus := e.ruleExceptionStatus(ctx, rule, evalParams.EntityID); exceptionStatus != nil {
+		evalErr = exceptionStatus
 	} else {
 		// enrich the logger with the entity type and execution ID
 		ctx := zerolog.Ctx(ctx).With().
@@ -204,6 +276,10 @@ func (e *executor) evaluateRule(
 	}
 	evalParams.SetEvalErr(evalErr)
 
+	if checkRun != nil {
+		checkRun.AddRuleResult(rule.Name, result, evalErr)
+	}
+
 	// Perform actionEngine, if any
 	actionsErr := actionEngine.DoActions(ctx, inf.Entity, evalParams)
 	evalParams.SetActionsErr(ctx, actionsErr)
@@ -215,6 +291,40 @@ func (e *executor) evaluateRule(
 	return e.createOrUpdateEvalStatus(ctx, evalParams)
 }
 
+// newProfileCheckRun returns a check run collecting the outcome of the profile
+// rules if the entity is a pull request and any of the rules is configured to
+// report through a check run. Otherwise, it returns nil.
+func newProfileCheckRun(inf *entities.EntityInfoWrapper, profile *models.ProfileAggregate) *pr_actions.CheckRun {
+	pr, ok := inf.Entity.(*pbinternal.PullRequest)
+	if !ok {
+		return nil
+	}
+
+	for _, rule := range profile.Rules {
+		if pr_actions.RuleDefAction(rule.Def) == pr_actions.ActionCheckRun {
+			return pr_actions.NewCheckRun(profile.Name, pr)
+		}
+	}
+
+	return nil
+}
+
+// submitCheckRun creates the check run of a profile. Failing to do so is not
+// fatal for the evaluation, whose results are already stored.
+func submitCheckRun(ctx context.Context, provider provinfv1.Provider, checkRun *pr_actions.CheckRun) {
+	logger := zerolog.Ctx(ctx)
+
+	client, err := provinfv1.As[provinfv1.GitHub](provider)
+	if err != nil {
+		logger.Warn().Err(err).Msg("provider does not support check runs, skipping")
+		return
+	}
+
+	if err := checkRun.Submit(ctx, client); err != nil {
+		logger.Error().Err(err).Msg("error submitting check run")
+	}
+}
+
 func (e *executor) profileEvalStatus(
 	ctx context.Context,
 	eiw *entities.EntityInfoWrapper,
@@ -262,6 +372,28 @@ func (e *executor) profileEvalStatus(
 	return nil
 }
 
+// ruleExceptionStatus returns an evaluation error if there is an active
+// rule exception for the rule instance and entity, and nil otherwise.
+func (e *executor) ruleExceptionStatus(
+	ctx context.Context,
+	rule *models.RuleInstance,
+	entityID uuid.UUID,
+) error {
+	exception, err := e.querier.GetActiveRuleException(ctx, db.GetActiveRuleExceptionParams{
+		RuleInstanceID:   rule.ID,
+		EntityInstanceID: entityID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error checking rule exceptions: %w", err)
+	}
+
+	return evalerrors.NewErrEvaluationExempt(
+		"rule exception %s until %s approved by %s: %s",
+		exception.ID, exception.ExpiresAt.Format(time.RFC3339), exception.Approver, exception.Reason)
+}
+
 func (e *executor) updateLockLease(
 	ctx context.Context,
 	executionID uuid.UUID,
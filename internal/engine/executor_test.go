@@ -6,6 +6,7 @@ package engine_test
 import (
 	"context"
 	"crypto/rand"
+	"database/sql"
 	"encoding/base64"
 	"encoding/json"
 	"io"
@@ -30,6 +31,7 @@ import (
 	"github.com/mindersec/minder/internal/engine/entities"
 	"github.com/mindersec/minder/internal/entities/models"
 	mockprops "github.com/mindersec/minder/internal/entities/properties/service/mock"
+	"github.com/mindersec/minder/internal/events/stubs"
 	"github.com/mindersec/minder/internal/flags"
 	mockhistory "github.com/mindersec/minder/internal/history/mock"
 	"github.com/mindersec/minder/internal/logger"
@@ -146,6 +148,14 @@ func TestExecutor_handleEntityEvent(t *testing.T) {
 				ProjectID:  projectID,
 			}}, nil)
 
+	// no rule exceptions for the repository
+	mockStore.EXPECT().
+		GetActiveRuleException(gomock.Any(), db.GetActiveRuleExceptionParams{
+			RuleInstanceID:   ruleInstanceID,
+			EntityInstanceID: repositoryID,
+		}).
+		Return(db.RuleException{}, sql.ErrNoRows)
+
 	evaluationID := uuid.New()
 	historyService := mockhistory.NewMockEvaluationHistoryService(ctrl)
 	historyService.EXPECT().
@@ -171,6 +181,11 @@ func TestExecutor_handleEntityEvent(t *testing.T) {
 		}).
 		Return(nil)
 
+	// the project doesn't override the alerts configuration
+	mockStore.EXPECT().
+		GetProjectByID(gomock.Any(), projectID).
+		Return(db.Project{ID: projectID, Metadata: json.RawMessage("{}")}, nil)
+
 	// only one project in the hierarchy
 	mockStore.EXPECT().
 		GetParentProjects(gomock.Any(), projectID).
@@ -337,6 +352,9 @@ default allow = true`,
 		profiles.NewProfileStore(mockStore),
 		selectors.NewEnv(),
 		mockPropSvc,
+		&serverconfig.AlertsConfig{},
+		cryptoEngine,
+		&stubs.StubEventer{},
 	)
 
 	eiw := entities.NewEntityInfoWrapper().
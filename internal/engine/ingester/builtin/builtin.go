@@ -20,6 +20,7 @@ import (
 	pb "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
 	"github.com/mindersec/minder/pkg/engine/v1/interfaces"
 	"github.com/mindersec/minder/pkg/entities/v1/checkpoints"
+	provifv1 "github.com/mindersec/minder/pkg/providers/v1"
 	"github.com/mindersec/minder/pkg/rule_methods"
 )
 
@@ -35,12 +36,13 @@ type BuiltinRuleDataIngest struct {
 	method      string
 }
 
-// NewBuiltinRuleDataIngest creates a new builtin rule data ingest engine
-func NewBuiltinRuleDataIngest(builtinCfg *pb.BuiltinType) (*BuiltinRuleDataIngest, error) {
+// NewBuiltinRuleDataIngest creates a new builtin rule data ingest engine.
+// The provider is made available to the methods which fetch data from it.
+func NewBuiltinRuleDataIngest(builtinCfg *pb.BuiltinType, provider provifv1.Provider) (*BuiltinRuleDataIngest, error) {
 	return &BuiltinRuleDataIngest{
 		builtinCfg:  builtinCfg,
 		method:      builtinCfg.GetMethod(),
-		ruleMethods: &rule_methods.RuleMethods{},
+		ruleMethods: &rule_methods.RuleMethods{Provider: provider},
 	}, nil
 }
 
@@ -86,7 +88,7 @@ func (idi *BuiltinRuleDataIngest) Ingest(ctx context.Context, ent protoreflect.P
 		return nil, fmt.Errorf("rule method should return 3 values")
 	}
 	if !result[1].IsNil() {
-		return nil, fmt.Errorf("error calling rule method")
+		return nil, fmt.Errorf("error calling rule method: %w", result[1].Interface().(error))
 	}
 	if result[0].IsNil() {
 		return nil, fmt.Errorf("error calling rule method")
@@ -10,11 +10,17 @@ import (
 	"context"
 	"testing"
 
+	"github.com/google/go-github/v63/github"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 	"google.golang.org/protobuf/reflect/protoreflect"
 
 	evalerrors "github.com/mindersec/minder/internal/engine/errors"
+	mockghclient "github.com/mindersec/minder/internal/providers/github/mock"
 	pb "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	provifv1 "github.com/mindersec/minder/pkg/providers/v1"
+	mockprov "github.com/mindersec/minder/pkg/providers/v1/mock"
 )
 
 func TestBuiltInWorks(t *testing.T) {
@@ -48,7 +54,7 @@ func TestBuiltInWorks(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			bi, err := NewBuiltinRuleDataIngest(nil)
+			bi, err := NewBuiltinRuleDataIngest(nil, nil)
 			assert.NoError(t, err)
 			bi.method = tt.methodName
 
@@ -78,6 +84,11 @@ func TestBuiltinErrorCases(t *testing.T) {
 				"foo": "bar",
 			},
 		},
+		{
+			name:       "gitlab method without a provider",
+			methodName: "GitLabBranchProtection",
+			ent:        &pb.Repository{},
+		},
 		{
 			name:       "method doesn't match",
 			methodName: "nosuchmethod",
@@ -95,7 +106,7 @@ func TestBuiltinErrorCases(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			bi, err := NewBuiltinRuleDataIngest(nil)
+			bi, err := NewBuiltinRuleDataIngest(nil, nil)
 			assert.NoError(t, err)
 			bi.method = tt.methodName
 
@@ -108,3 +119,100 @@ func TestBuiltinErrorCases(t *testing.T) {
 		})
 	}
 }
+
+func TestBuiltinGitLabBranchProtection(t *testing.T) {
+	t.Parallel()
+
+	repo := &pb.Repository{Owner: "group", Name: "project", RepoId: 42, DefaultBranch: "main"}
+
+	ctrl := gomock.NewController(t)
+	mockGitLab := mockprov.NewMockGitLab(ctrl)
+	mockGitLab.EXPECT().ListBranchProtections(gomock.Any(), repo).
+		Return(map[string]*provifv1.GitLabBranchProtection{
+			"main": {
+				Protected:        true,
+				PushAccessLevel:  provifv1.GitLabAccessLevelNoAccess,
+				MergeAccessLevel: provifv1.GitLabAccessLevelMaintainer,
+				ApprovalRules: map[string]*provifv1.GitLabApprovalRule{
+					"minder": {ApprovalsRequired: 2},
+				},
+			},
+		}, nil)
+
+	bi, err := NewBuiltinRuleDataIngest(&pb.BuiltinType{Method: "GitLabBranchProtection"}, mockGitLab)
+	require.NoError(t, err)
+
+	res, err := bi.Ingest(context.Background(), repo, nil)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"default_branch": "main",
+		"protected_branches": map[string]any{
+			"main": map[string]any{
+				"protected":                    true,
+				"push_access_level":            float64(0),
+				"merge_access_level":           float64(40),
+				"unprotect_access_level":       float64(0),
+				"allow_force_push":             false,
+				"code_owner_approval_required": false,
+				"approval_rules": map[string]any{
+					"minder": map[string]any{"approvals_required": float64(2)},
+				},
+			},
+		},
+	}, res.Object)
+}
+
+func TestBuiltinGitHubRulesets(t *testing.T) {
+	t.Parallel()
+
+	repo := &pb.Repository{Owner: "stacklok", Name: "minder", DefaultBranch: "main"}
+
+	ctrl := gomock.NewController(t)
+	mockGitHub := mockghclient.NewMockGitHub(ctrl)
+	mockGitHub.EXPECT().ListRulesets(gomock.Any(), "stacklok", "minder", true).
+		Return([]*github.Ruleset{{ID: github.Int64(1), Name: "org"}}, nil)
+	mockGitHub.EXPECT().GetRuleset(gomock.Any(), "stacklok", "minder", int64(1)).
+		Return(&github.Ruleset{
+			ID:          github.Int64(1),
+			Name:        "org",
+			SourceType:  github.String("Organization"),
+			Source:      "stacklok",
+			Enforcement: "active",
+			Rules:       []*github.RepositoryRule{github.NewDeletionRule()},
+		}, nil)
+	mockGitHub.EXPECT().GetRulesForBranch(gomock.Any(), "stacklok", "minder", "main").
+		Return([]*github.RepositoryRule{{
+			Type:              "deletion",
+			RulesetSourceType: "Organization",
+			RulesetSource:     "stacklok",
+			RulesetID:         1,
+		}}, nil)
+
+	bi, err := NewBuiltinRuleDataIngest(&pb.BuiltinType{Method: "GitHubRulesets"}, mockGitHub)
+	require.NoError(t, err)
+
+	res, err := bi.Ingest(context.Background(), repo, nil)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"default_branch": "main",
+		"rulesets": []any{map[string]any{
+			"id":          float64(1),
+			"name":        "org",
+			"source_type": "Organization",
+			"source":      "stacklok",
+			"enforcement": "active",
+			"rules": []any{map[string]any{
+				"type":                "deletion",
+				"ruleset_source_type": "",
+				"ruleset_source":      "",
+				"ruleset_id":          float64(0),
+			}},
+		}},
+		"default_branch_rules": []any{map[string]any{
+			"type":                "deletion",
+			"ruleset_source_type": "Organization",
+			"ruleset_source":      "stacklok",
+			"ruleset_id":          float64(1),
+		}},
+	}, res.Object)
+}
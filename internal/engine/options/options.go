@@ -54,3 +54,25 @@ func WithDataSources(ds *v1datasources.DataSourceRegistry) Option {
 		return nil
 	}
 }
+
+// SupportsProfileOnly interface advertises the fact that the implementer
+// gives feedback through the provider, e.g. by commenting on pull requests,
+// and can be limited to report its findings in the evaluation result only.
+type SupportsProfileOnly interface {
+	SetProfileOnly()
+}
+
+// WithProfileOnly keeps the evaluation engine from writing to the provider,
+// as if the rule asked for the profile_only action. In case the given
+// evaluator does not write to the provider, WithProfileOnly silently
+// ignores the error.
+func WithProfileOnly() Option {
+	return func(e interfaces.Evaluator) error {
+		inner, ok := e.(SupportsProfileOnly)
+		if !ok {
+			return nil
+		}
+		inner.SetProfileOnly()
+		return nil
+	}
+}
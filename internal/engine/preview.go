@@ -0,0 +1,377 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	datasourceservice "github.com/mindersec/minder/internal/datasources/service"
+	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/internal/engine/entities"
+	evalerrors "github.com/mindersec/minder/internal/engine/errors"
+	"github.com/mindersec/minder/internal/engine/ingestcache"
+	engif "github.com/mindersec/minder/internal/engine/interfaces"
+	eoptions "github.com/mindersec/minder/internal/engine/options"
+	"github.com/mindersec/minder/internal/engine/rtengine"
+	"github.com/mindersec/minder/internal/entities/properties/service"
+	"github.com/mindersec/minder/internal/providers/manager"
+	provsel "github.com/mindersec/minder/internal/providers/selectors"
+	pb "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/mindersec/minder/pkg/engine/selectors"
+	"github.com/mindersec/minder/pkg/profiles"
+	"github.com/mindersec/minder/pkg/profiles/models"
+	provinfv1 "github.com/mindersec/minder/pkg/providers/v1"
+)
+
+//go:generate go run go.uber.org/mock/mockgen -package mock_$GOPACKAGE -destination=./mock/$GOFILE -source=./$GOFILE
+
+// DefaultPreviewMaxEntities is the number of entities evaluated by a
+// profile preview when the caller does not set a limit
+const DefaultPreviewMaxEntities = 50
+
+// ProfilePreviewer evaluates profiles which have not been created yet
+type ProfilePreviewer interface {
+	// PreviewProfile evaluates the profile against the entities of the
+	// project matching the selection, up to maxEntities of them. Unlike
+	// the executor, it neither records the evaluation results nor runs
+	// remediations or alerts.
+	PreviewProfile(
+		ctx context.Context,
+		projectID uuid.UUID,
+		profile *pb.Profile,
+		selection []*pb.Profile_Selector,
+		maxEntities int,
+	) (*pb.PreviewProfileResponse, error)
+}
+
+type profilePreviewer struct {
+	querier         db.Store
+	providerManager manager.ProviderManager
+	featureFlags    openfeature.IClient
+	selBuilder      selectors.SelectionBuilder
+	validator       *profiles.Validator
+	propService     service.PropertiesService
+}
+
+// NewProfilePreviewer creates a new profile previewer
+func NewProfilePreviewer(
+	querier db.Store,
+	providerManager manager.ProviderManager,
+	featureFlags openfeature.IClient,
+	selBuilder selectors.SelectionBuilder,
+	selChecker selectors.SelectionChecker,
+	propService service.PropertiesService,
+) ProfilePreviewer {
+	return &profilePreviewer{
+		querier:         querier,
+		providerManager: providerManager,
+		featureFlags:    featureFlags,
+		selBuilder:      selBuilder,
+		validator:       profiles.NewValidator(selChecker),
+		propService:     propService,
+	}
+}
+
+// previewRule is a rule of the previewed profile
+type previewRule struct {
+	rule     models.RuleInstance
+	ruleType string
+}
+
+func (p *profilePreviewer) PreviewProfile(
+	ctx context.Context,
+	projectID uuid.UUID,
+	profile *pb.Profile,
+	selection []*pb.Profile_Selector,
+	maxEntities int,
+) (*pb.PreviewProfileResponse, error) {
+	if maxEntities <= 0 {
+		maxEntities = DefaultPreviewMaxEntities
+	}
+
+	// validate the profile as it would be on creation
+	rulesInProf, err := p.validator.ValidateAndExtractRules(ctx, p.querier, projectID, profile)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.validator.ValidateSelection(profile.GetSelection()); err != nil {
+		return nil, err
+	}
+	if err := p.validator.ValidateSelection(selection); err != nil {
+		return nil, err
+	}
+	profiles.PopulateRuleNames(profile, rulesInProf)
+
+	rulesByEntity := make(map[pb.Entity][]previewRule)
+	err = profiles.TraverseRuleTypesForEntities(profile, func(entity pb.Entity, rule *pb.Profile_Rule) error {
+		ruleInfo, ok := rulesInProf[profiles.RuleTypeAndNamePair{
+			RuleType: rule.GetType(),
+			RuleName: rule.GetName(),
+		}]
+		if !ok {
+			return fmt.Errorf("rule %s of type %s not found after validation", rule.GetName(), rule.GetType())
+		}
+		rulesByEntity[entity] = append(rulesByEntity[entity], previewRule{
+			rule:     models.RuleFromPB(ruleInfo.RuleID, rule),
+			ruleType: rule.GetType(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ents, err := p.querier.GetEntitiesByProjectHierarchy(ctx, []uuid.UUID{projectID})
+	if err != nil {
+		return nil, fmt.Errorf("error listing entities: %w", err)
+	}
+
+	resp := &pb.PreviewProfileResponse{}
+	state := &previewState{
+		providers:  make(map[uuid.UUID]provinfv1.Provider),
+		selections: make(map[pb.Entity]*previewSelection),
+		dssvc:      datasourceservice.NewDataSourceService(p.querier),
+	}
+
+	for _, ent := range ents {
+		entityType := entities.EntityTypeFromDB(ent.EntityType)
+		rules := rulesByEntity[entityType]
+		if len(rules) == 0 {
+			continue
+		}
+
+		if len(resp.Results) >= maxEntities {
+			resp.Truncated = true
+			break
+		}
+
+		result, err := p.previewEntity(ctx, state, ent, profile, selection, rules)
+		if err != nil {
+			return nil, err
+		}
+		if result != nil {
+			resp.Results = append(resp.Results, result)
+		}
+	}
+
+	return resp, nil
+}
+
+// previewState holds what can be shared across the entities of a preview
+type previewState struct {
+	providers  map[uuid.UUID]provinfv1.Provider
+	selections map[pb.Entity]*previewSelection
+	dssvc      datasourceservice.DataSourcesService
+}
+
+// previewSelection contains the selections of an entity type, both from
+// the profile and from the preview request
+type previewSelection struct {
+	profile selectors.Selection
+	request selectors.Selection
+}
+
+// previewEntity evaluates the rules against an entity. It returns nil if
+// the entity does not match the selection of the preview request.
+func (p *profilePreviewer) previewEntity(
+	ctx context.Context,
+	state *previewState,
+	ent db.EntityInstance,
+	profile *pb.Profile,
+	selection []*pb.Profile_Selector,
+	rules []previewRule,
+) (*pb.ProfilePreviewEntityResult, error) {
+	logger := zerolog.Ctx(ctx).With().
+		Str("entity_id", ent.ID.String()).
+		Str("entity_type", string(ent.EntityType)).
+		Logger()
+	ctx = logger.WithContext(ctx)
+
+	entityType := entities.EntityTypeFromDB(ent.EntityType)
+	sel, err := p.selectionsFor(state, entityType, profile.GetSelection(), selection)
+	if err != nil {
+		return nil, err
+	}
+
+	ewp, err := p.propService.EntityWithPropertiesByID(ctx, ent.ID,
+		service.CallBuilder().WithStoreOrTransaction(p.querier))
+	if err != nil {
+		return nil, fmt.Errorf("error getting entity with properties: %w", err)
+	}
+
+	selEnt := provsel.EntityToSelectorEntity(ctx, p.querier, entityType, ewp)
+	if selEnt == nil {
+		return nil, fmt.Errorf("error converting entity to selector entity")
+	}
+
+	// entities not matching the request selection are left out
+	selected, _, err := sel.request.Select(selEnt)
+	if err != nil {
+		logger.Error().Err(err).Msg("error selecting entity for preview")
+		return nil, nil
+	}
+	if !selected {
+		return nil, nil
+	}
+
+	result := &pb.ProfilePreviewEntityResult{
+		Entity: &pb.EntityTypedId{
+			Type: entityType,
+			Id:   ent.ID.String(),
+		},
+		EntityName: ent.Name,
+		Rules:      make([]*pb.ProfilePreviewRuleResult, 0, len(rules)),
+	}
+
+	// as in the executor, entities not matching the profile selection
+	// are skipped
+	var profileEvalStatus error
+	selected, matchedSelector, err := sel.profile.Select(selEnt)
+	if err != nil {
+		profileEvalStatus = fmt.Errorf("error selecting entity: %w", err)
+	} else if !selected {
+		profileEvalStatus = evalerrors.NewErrEvaluationSkipped(
+			"entity not applicable due to profile selector %s", matchedSelector)
+	}
+
+	// an error preparing the evaluation of the entity fails all its
+	// rules, rather than the whole preview
+	withStatus := func(evalErr error) *pb.ProfilePreviewEntityResult {
+		for _, rule := range rules {
+			result.Rules = append(result.Rules, previewRuleResult(rule, evalErr))
+		}
+		return result
+	}
+	if profileEvalStatus != nil {
+		return withStatus(profileEvalStatus), nil
+	}
+
+	entityPB, err := p.propService.EntityWithPropertiesAsProto(ctx, ewp, p.providerManager)
+	if err != nil {
+		return withStatus(fmt.Errorf("error converting entity to protobuf: %w", err)), nil
+	}
+
+	ruleEngineCache, err := p.newRuleEngineCache(ctx, state, ent)
+	if err != nil {
+		return withStatus(err), nil
+	}
+
+	for _, rule := range rules {
+		evalErr := evaluatePreviewRule(ctx, ruleEngineCache, entityPB, rule)
+		result.Rules = append(result.Rules, previewRuleResult(rule, evalErr))
+	}
+	return result, nil
+}
+
+// selectionsFor compiles the selections of an entity type once per preview
+func (p *profilePreviewer) selectionsFor(
+	state *previewState,
+	entityType pb.Entity,
+	profileSelection []*pb.Profile_Selector,
+	requestSelection []*pb.Profile_Selector,
+) (*previewSelection, error) {
+	if sel, ok := state.selections[entityType]; ok {
+		return sel, nil
+	}
+
+	profileSel, err := p.selBuilder.NewSelectionFromProfile(entityType, selectorsFromPB(profileSelection))
+	if err != nil {
+		return nil, fmt.Errorf("error creating selection from profile: %w", err)
+	}
+	requestSel, err := p.selBuilder.NewSelectionFromProfile(entityType, selectorsFromPB(requestSelection))
+	if err != nil {
+		return nil, fmt.Errorf("error creating selection from request: %w", err)
+	}
+
+	sel := &previewSelection{profile: profileSel, request: requestSel}
+	state.selections[entityType] = sel
+	return sel, nil
+}
+
+// newRuleEngineCache creates the rule type engines for an entity. As in
+// the executor, the ingestion cache is not shared across entities.
+func (p *profilePreviewer) newRuleEngineCache(
+	ctx context.Context,
+	state *previewState,
+	ent db.EntityInstance,
+) (rtengine.Cache, error) {
+	provider, ok := state.providers[ent.ProviderID]
+	if !ok {
+		var err error
+		provider, err = p.providerManager.InstantiateFromID(ctx, ent.ProviderID)
+		if err != nil {
+			return nil, fmt.Errorf("could not instantiate provider: %w", err)
+		}
+		state.providers[ent.ProviderID] = provider
+	}
+
+	var ingestCache ingestcache.Cache
+	if ent.EntityType == db.EntitiesArtifact {
+		ingestCache = ingestcache.NewNoopCache()
+	} else {
+		ingestCache = ingestcache.NewCache()
+	}
+
+	ruleEngineCache, err := rtengine.NewRuleEngineCache(
+		ctx,
+		p.querier,
+		ent.EntityType,
+		ent.ProjectID,
+		provider,
+		p.featureFlags,
+		ingestCache,
+		state.dssvc,
+		eoptions.WithFlagsClient(p.featureFlags),
+		// a preview must not comment on or review pull requests
+		eoptions.WithProfileOnly(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch rule type instances for project: %w", err)
+	}
+	return ruleEngineCache, nil
+}
+
+func evaluatePreviewRule(
+	ctx context.Context,
+	ruleEngineCache rtengine.Cache,
+	entity protoreflect.ProtoMessage,
+	rule previewRule,
+) error {
+	ruleEngine, err := ruleEngineCache.GetRuleEngine(ctx, rule.rule.RuleTypeID)
+	if err != nil {
+		return fmt.Errorf("error creating rule type engine: %w", err)
+	}
+
+	// the eval status params only collect the ingestion result here,
+	// nothing is recorded
+	params := &engif.EvalStatusParams{Rule: &rule.rule}
+	_, err = ruleEngine.Eval(ctx, entity, rule.rule.Def, rule.rule.Params, params)
+	return err
+}
+
+func previewRuleResult(rule previewRule, evalErr error) *pb.ProfilePreviewRuleResult {
+	return &pb.ProfilePreviewRuleResult{
+		RuleName: rule.rule.Name,
+		RuleType: rule.ruleType,
+		Status:   string(evalerrors.ErrorAsEvalStatus(evalErr)),
+		Details:  evalerrors.ErrorAsEvalDetails(evalErr),
+	}
+}
+
+func selectorsFromPB(selection []*pb.Profile_Selector) []models.ProfileSelector {
+	sels := make([]models.ProfileSelector, 0, len(selection))
+	for _, sel := range selection {
+		sels = append(sels, models.ProfileSelector{
+			Entity:   pb.EntityFromString(sel.GetEntity()),
+			Selector: sel.GetSelector(),
+		})
+	}
+	return sels
+}
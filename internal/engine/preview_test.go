@@ -0,0 +1,469 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package engine_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-github/v63/github"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	mockdb "github.com/mindersec/minder/database/mock"
+	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/internal/engine"
+	"github.com/mindersec/minder/internal/entities/models"
+	mockprops "github.com/mindersec/minder/internal/entities/properties/service/mock"
+	"github.com/mindersec/minder/internal/flags"
+	pbinternal "github.com/mindersec/minder/internal/proto"
+	mockmanager "github.com/mindersec/minder/internal/providers/manager/mock"
+	"github.com/mindersec/minder/internal/util/ptr"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	mock_selectors "github.com/mindersec/minder/pkg/engine/selectors/mock"
+	mockprovider "github.com/mindersec/minder/pkg/providers/v1/mock"
+)
+
+// previewRuleTypeDef returns a rule type definition for repositories whose
+// evaluation passes or fails depending on allow. Both actions are set up,
+// so that the preview would have something to alert about or remediate.
+func previewRuleTypeDef(t *testing.T, allow bool) json.RawMessage {
+	t.Helper()
+
+	regoDef := "package minder\ndefault allow = false"
+	if allow {
+		regoDef = "package minder\ndefault allow = true"
+	}
+
+	def, err := json.Marshal(&minderv1.RuleType_Definition{
+		InEntity:   minderv1.RepositoryEntity.String(),
+		RuleSchema: &structpb.Struct{},
+		Ingest: &minderv1.RuleType_Definition_Ingest{
+			Type:    "builtin",
+			Builtin: &minderv1.BuiltinType{Method: "Passthrough"},
+		},
+		Eval: &minderv1.RuleType_Definition_Eval{
+			Type: "rego",
+			Rego: &minderv1.RuleType_Definition_Eval_Rego{
+				Type: "deny-by-default",
+				Def:  regoDef,
+			},
+		},
+		Remediate: &minderv1.RuleType_Definition_Remediate{
+			Type: "rest",
+			Rest: &minderv1.RestType{Method: "PATCH", Endpoint: "/repos/foo/test"},
+		},
+		Alert: &minderv1.RuleType_Definition_Alert{
+			Type: "security_advisory",
+			SecurityAdvisory: &minderv1.RuleType_Definition_Alert_AlertTypeSA{
+				Severity: "high",
+			},
+		},
+	})
+	require.NoError(t, err)
+	return def
+}
+
+func TestProfilePreviewer_PreviewProfile(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := uuid.New()
+	providerID := uuid.New()
+	repositoryID := uuid.New()
+	passingRuleTypeID := uuid.New()
+	failingRuleTypeID := uuid.New()
+
+	ruleTypes := []db.RuleType{
+		{
+			ID:         passingRuleTypeID,
+			Name:       "passing",
+			ProjectID:  projectID,
+			Definition: previewRuleTypeDef(t, true),
+		},
+		{
+			ID:         failingRuleTypeID,
+			Name:       "failing",
+			ProjectID:  projectID,
+			Definition: previewRuleTypeDef(t, false),
+		},
+	}
+
+	// -- start expectations
+	// The store and the provider are strict mocks, so any write to the
+	// evaluation history, alert or remediation events, as well as any call
+	// through the provider to open an alert or remediate, fails the test.
+	mockStore := mockdb.NewMockStore(ctrl)
+
+	// only one project in the hierarchy, for the validation of the profile
+	// and for the rule engines
+	mockStore.EXPECT().
+		GetParentProjects(gomock.Any(), projectID).
+		Return([]uuid.UUID{projectID}, nil).
+		AnyTimes()
+
+	for _, rt := range ruleTypes {
+		mockStore.EXPECT().
+			GetRuleTypeByName(gomock.Any(), db.GetRuleTypeByNameParams{
+				Projects: []uuid.UUID{projectID},
+				Name:     rt.Name,
+			}).
+			Return(rt, nil).
+			AnyTimes()
+	}
+
+	// one repository and one artifact, which the profile has no rules for
+	mockStore.EXPECT().
+		GetEntitiesByProjectHierarchy(gomock.Any(), []uuid.UUID{projectID}).
+		Return([]db.EntityInstance{
+			{
+				ID:         repositoryID,
+				EntityType: db.EntitiesRepository,
+				Name:       "foo/test",
+				ProjectID:  projectID,
+				ProviderID: providerID,
+			},
+			{
+				ID:         uuid.New(),
+				EntityType: db.EntitiesArtifact,
+				Name:       "foo/test-image",
+				ProjectID:  projectID,
+				ProviderID: providerID,
+			},
+		}, nil)
+
+	// fill in the provider of the selector entity
+	mockStore.EXPECT().
+		GetProviderByID(gomock.Any(), providerID).
+		Return(db.Provider{
+			ID:        providerID,
+			Name:      "github",
+			ProjectID: projectID,
+			Class:     db.ProviderClassGithub,
+		}, nil)
+
+	mockStore.EXPECT().
+		GetRuleTypesByEntityInHierarchy(gomock.Any(), db.GetRuleTypesByEntityInHierarchyParams{
+			EntityType: db.EntitiesRepository,
+			Projects:   []uuid.UUID{projectID},
+		}).
+		Return(ruleTypes, nil)
+
+	provider := mockprovider.NewMockProvider(ctrl)
+	providerManager := mockmanager.NewMockProviderManager(ctrl)
+	providerManager.EXPECT().
+		InstantiateFromID(gomock.Any(), providerID).
+		Return(provider, nil)
+
+	repo := &minderv1.Repository{
+		Owner:  "foo",
+		Name:   "test",
+		RepoId: 123,
+	}
+	ewp := &models.EntityWithProperties{
+		Entity: models.EntityInstance{
+			ID:         repositoryID,
+			Type:       minderv1.Entity_ENTITY_REPOSITORIES,
+			Name:       "foo/test",
+			ProjectID:  projectID,
+			ProviderID: providerID,
+		},
+	}
+	mockPropSvc := mockprops.NewMockPropertiesService(ctrl)
+	mockPropSvc.EXPECT().
+		EntityWithPropertiesByID(gomock.Any(), repositoryID, gomock.Any()).
+		Return(ewp, nil)
+	mockPropSvc.EXPECT().
+		EntityWithPropertiesAsProto(gomock.Any(), ewp, providerManager).
+		Return(repo, nil)
+
+	mockSelection := mock_selectors.NewMockSelection(ctrl)
+	mockSelection.EXPECT().
+		Select(gomock.Any(), gomock.Any()).
+		Return(true, "", nil).
+		AnyTimes()
+
+	mockSelectionBuilder := mock_selectors.NewMockSelectionBuilder(ctrl)
+	mockSelectionBuilder.EXPECT().
+		NewSelectionFromProfile(gomock.Any(), gomock.Any()).
+		Return(mockSelection, nil).
+		AnyTimes()
+
+	mockSelectionChecker := mock_selectors.NewMockSelectionChecker(ctrl)
+	mockSelectionChecker.EXPECT().
+		CheckSelector(gomock.Any()).
+		Return(nil).
+		AnyTimes()
+	// -- end expectations
+
+	previewer := engine.NewProfilePreviewer(
+		mockStore,
+		providerManager,
+		&flags.FakeClient{},
+		mockSelectionBuilder,
+		mockSelectionChecker,
+		mockPropSvc,
+	)
+
+	profile := &minderv1.Profile{
+		Name:      "preview",
+		Alert:     ptr.Ptr("on"),
+		Remediate: ptr.Ptr("on"),
+		Repository: []*minderv1.Profile_Rule{
+			{Type: "passing", Def: &structpb.Struct{}},
+			{Type: "failing", Def: &structpb.Struct{}},
+		},
+	}
+
+	resp, err := previewer.PreviewProfile(context.Background(), projectID, profile, nil, 0)
+	require.NoError(t, err)
+	require.False(t, resp.GetTruncated())
+	require.Len(t, resp.GetResults(), 1)
+
+	result := resp.GetResults()[0]
+	require.Equal(t, repositoryID.String(), result.GetEntity().GetId())
+	require.Equal(t, "foo/test", result.GetEntityName())
+
+	statuses := make(map[string]string, len(result.GetRules()))
+	for _, rule := range result.GetRules() {
+		statuses[rule.GetRuleType()] = rule.GetStatus()
+	}
+	require.Equal(t, map[string]string{
+		"passing": string(db.EvalStatusTypesSuccess),
+		"failing": string(db.EvalStatusTypesFailure),
+	}, statuses)
+}
+
+func TestProfilePreviewer_PreviewProfileTruncated(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := uuid.New()
+	providerID := uuid.New()
+	ruleType := db.RuleType{
+		ID:         uuid.New(),
+		Name:       "passing",
+		ProjectID:  projectID,
+		Definition: previewRuleTypeDef(t, true),
+	}
+
+	mockStore := mockdb.NewMockStore(ctrl)
+	mockStore.EXPECT().
+		GetParentProjects(gomock.Any(), projectID).
+		Return([]uuid.UUID{projectID}, nil).
+		AnyTimes()
+	mockStore.EXPECT().
+		GetRuleTypeByName(gomock.Any(), gomock.Any()).
+		Return(ruleType, nil).
+		AnyTimes()
+
+	ents := make([]db.EntityInstance, 0, 3)
+	for range 3 {
+		ents = append(ents, db.EntityInstance{
+			ID:         uuid.New(),
+			EntityType: db.EntitiesRepository,
+			ProjectID:  projectID,
+			ProviderID: providerID,
+		})
+	}
+	mockStore.EXPECT().
+		GetEntitiesByProjectHierarchy(gomock.Any(), []uuid.UUID{projectID}).
+		Return(ents, nil)
+	mockStore.EXPECT().
+		GetProviderByID(gomock.Any(), providerID).
+		Return(db.Provider{ID: providerID, Name: "github", Class: db.ProviderClassGithub}, nil).
+		AnyTimes()
+	mockStore.EXPECT().
+		GetRuleTypesByEntityInHierarchy(gomock.Any(), gomock.Any()).
+		Return([]db.RuleType{ruleType}, nil).
+		AnyTimes()
+
+	providerManager := mockmanager.NewMockProviderManager(ctrl)
+	providerManager.EXPECT().
+		InstantiateFromID(gomock.Any(), providerID).
+		Return(mockprovider.NewMockProvider(ctrl), nil)
+
+	mockPropSvc := mockprops.NewMockPropertiesService(ctrl)
+	mockPropSvc.EXPECT().
+		EntityWithPropertiesByID(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, id uuid.UUID, _ any) (*models.EntityWithProperties, error) {
+			return &models.EntityWithProperties{
+				Entity: models.EntityInstance{
+					ID:         id,
+					Type:       minderv1.Entity_ENTITY_REPOSITORIES,
+					ProjectID:  projectID,
+					ProviderID: providerID,
+				},
+			}, nil
+		}).
+		Times(2)
+	mockPropSvc.EXPECT().
+		EntityWithPropertiesAsProto(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&minderv1.Repository{Owner: "foo", Name: "test"}, nil).
+		Times(2)
+
+	mockSelection := mock_selectors.NewMockSelection(ctrl)
+	mockSelection.EXPECT().
+		Select(gomock.Any(), gomock.Any()).
+		Return(true, "", nil).
+		AnyTimes()
+	mockSelectionBuilder := mock_selectors.NewMockSelectionBuilder(ctrl)
+	mockSelectionBuilder.EXPECT().
+		NewSelectionFromProfile(gomock.Any(), gomock.Any()).
+		Return(mockSelection, nil).
+		AnyTimes()
+
+	previewer := engine.NewProfilePreviewer(
+		mockStore,
+		providerManager,
+		&flags.FakeClient{},
+		mockSelectionBuilder,
+		mock_selectors.NewMockSelectionChecker(ctrl),
+		mockPropSvc,
+	)
+
+	profile := &minderv1.Profile{
+		Name:       "preview",
+		Repository: []*minderv1.Profile_Rule{{Type: "passing", Def: &structpb.Struct{}}},
+	}
+
+	resp, err := previewer.PreviewProfile(context.Background(), projectID, profile, nil, 2)
+	require.NoError(t, err)
+	require.True(t, resp.GetTruncated())
+	require.Len(t, resp.GetResults(), 2)
+}
+
+func TestProfilePreviewer_PreviewProfileDoesNotReviewPullRequests(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	projectID := uuid.New()
+	providerID := uuid.New()
+	pullRequestID := uuid.New()
+
+	// a rule which reviews the pull requests it finds invisible characters in
+	def, err := json.Marshal(&minderv1.RuleType_Definition{
+		InEntity:   minderv1.PullRequestEntity.String(),
+		RuleSchema: &structpb.Struct{},
+		Ingest: &minderv1.RuleType_Definition_Ingest{
+			Type: "diff",
+			Diff: &minderv1.DiffType{Type: minderv1.DiffTypeFull},
+		},
+		Eval: &minderv1.RuleType_Definition_Eval{
+			Type:       "homoglyphs",
+			Homoglyphs: &minderv1.RuleType_Definition_Eval_Homoglyphs{Type: "invisible_characters"},
+		},
+	})
+	require.NoError(t, err)
+	ruleType := db.RuleType{
+		ID:         uuid.New(),
+		Name:       "invisible_characters",
+		ProjectID:  projectID,
+		Definition: def,
+	}
+
+	mockStore := mockdb.NewMockStore(ctrl)
+	mockStore.EXPECT().
+		GetParentProjects(gomock.Any(), projectID).
+		Return([]uuid.UUID{projectID}, nil).
+		AnyTimes()
+	mockStore.EXPECT().
+		GetRuleTypeByName(gomock.Any(), gomock.Any()).
+		Return(ruleType, nil).
+		AnyTimes()
+	mockStore.EXPECT().
+		GetEntitiesByProjectHierarchy(gomock.Any(), []uuid.UUID{projectID}).
+		Return([]db.EntityInstance{{
+			ID:         pullRequestID,
+			EntityType: db.EntitiesPullRequest,
+			Name:       "foo/test/1",
+			ProjectID:  projectID,
+			ProviderID: providerID,
+		}}, nil)
+	mockStore.EXPECT().
+		GetProviderByID(gomock.Any(), providerID).
+		Return(db.Provider{ID: providerID, Name: "github", Class: db.ProviderClassGithub}, nil)
+	mockStore.EXPECT().
+		GetRuleTypesByEntityInHierarchy(gomock.Any(), gomock.Any()).
+		Return([]db.RuleType{ruleType}, nil)
+
+	// The provider is a strict mock: the pull request files are the only
+	// thing the preview may ask for, so listing, submitting or dismissing
+	// reviews fails the test.
+	provider := mockprovider.NewMockGitHub(ctrl)
+	provider.EXPECT().
+		ListFiles(gomock.Any(), "foo", "test", 1, gomock.Any(), gomock.Any()).
+		Return([]*github.CommitFile{{
+			Filename: github.String("README.md"),
+			Patch:    github.String("@@ -1,1 +1,1 @@\n-Hello, World!\n+Hello,\u200bWorld!"),
+		}}, &github.Response{}, nil)
+	providerManager := mockmanager.NewMockProviderManager(ctrl)
+	providerManager.EXPECT().
+		InstantiateFromID(gomock.Any(), providerID).
+		Return(provider, nil)
+
+	ewp := &models.EntityWithProperties{
+		Entity: models.EntityInstance{
+			ID:         pullRequestID,
+			Type:       minderv1.Entity_ENTITY_PULL_REQUESTS,
+			Name:       "foo/test/1",
+			ProjectID:  projectID,
+			ProviderID: providerID,
+		},
+	}
+	mockPropSvc := mockprops.NewMockPropertiesService(ctrl)
+	mockPropSvc.EXPECT().
+		EntityWithPropertiesByID(gomock.Any(), pullRequestID, gomock.Any()).
+		Return(ewp, nil)
+	mockPropSvc.EXPECT().
+		EntityWithPropertiesAsProto(gomock.Any(), ewp, providerManager).
+		Return(&pbinternal.PullRequest{
+			RepoOwner: "foo",
+			RepoName:  "test",
+			Number:    1,
+			CommitSha: "abc123",
+		}, nil)
+
+	mockSelection := mock_selectors.NewMockSelection(ctrl)
+	mockSelection.EXPECT().
+		Select(gomock.Any(), gomock.Any()).
+		Return(true, "", nil).
+		AnyTimes()
+	mockSelectionBuilder := mock_selectors.NewMockSelectionBuilder(ctrl)
+	mockSelectionBuilder.EXPECT().
+		NewSelectionFromProfile(gomock.Any(), gomock.Any()).
+		Return(mockSelection, nil).
+		AnyTimes()
+
+	previewer := engine.NewProfilePreviewer(
+		mockStore,
+		providerManager,
+		&flags.FakeClient{},
+		mockSelectionBuilder,
+		mock_selectors.NewMockSelectionChecker(ctrl),
+		mockPropSvc,
+	)
+
+	ruleDef, err := structpb.NewStruct(map[string]any{"action": "review"})
+	require.NoError(t, err)
+	profile := &minderv1.Profile{
+		Name:        "preview",
+		PullRequest: []*minderv1.Profile_Rule{{Type: "invisible_characters", Def: ruleDef}},
+	}
+
+	resp, err := previewer.PreviewProfile(context.Background(), projectID, profile, nil, 0)
+	require.NoError(t, err)
+	require.Len(t, resp.GetResults(), 1)
+	require.Len(t, resp.GetResults()[0].GetRules(), 1)
+	require.Equal(t, string(db.EvalStatusTypesFailure), resp.GetResults()[0].GetRules()[0].GetStatus())
+}
@@ -9,9 +9,11 @@ import (
 	"errors"
 
 	watermill "github.com/ThreeDotsLabs/watermill/message"
+	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 
 	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/internal/engine/entities"
 	"github.com/mindersec/minder/internal/entities/handlers/message"
 	"github.com/mindersec/minder/internal/entities/handlers/strategies"
 	entStrategies "github.com/mindersec/minder/internal/entities/handlers/strategies/entity"
@@ -108,6 +110,10 @@ func (b *handleEntityAndDoBase) handleRefreshEntityAndDo(msg *watermill.Message)
 
 	// If nextMsg is nil, it means we don't need to publish anything (entity not found)
 	if nextMsg != nil {
+		if entMsg.ProfileID != uuid.Nil {
+			nextMsg.Metadata.Set(entities.ProfileIDEventKey, entMsg.ProfileID.String())
+		}
+
 		l.Debug().Msg("publishing message")
 		if err := b.evt.Publish(b.forwardHandlerName, nextMsg); err != nil {
 			l.Error().Err(err).Msg("error publishing message")
@@ -41,6 +41,9 @@ type HandleEntityAndDoMessage struct {
 	// use-case is to include the hook ID in the MatchProps to match against
 	// the entity's hook ID to avoid forwading the message to the wrong entity.
 	MatchProps map[string]any `json:"match_props"`
+	// ProfileID restricts the evaluation of the entity to a single profile.
+	// It is set for the evaluations of profiles with a schedule.
+	ProfileID uuid.UUID `json:"profile_id"`
 }
 
 // NewEntityRefreshAndDoMessage creates a new HandleEntityAndDoMessage struct.
@@ -74,6 +77,12 @@ func (e *HandleEntityAndDoMessage) WithEntityID(entityID uuid.UUID) *HandleEntit
 	return e
 }
 
+// WithProfileID restricts the evaluation of the entity to the given profile.
+func (e *HandleEntityAndDoMessage) WithProfileID(profileID uuid.UUID) *HandleEntityAndDoMessage {
+	e.ProfileID = profileID
+	return e
+}
+
 // WithProviderImplementsHint sets the provider hint for the entity that will be used when looking up the entity.
 // to the provider implements hint
 func (e *HandleEntityAndDoMessage) WithProviderImplementsHint(providerHint string) *HandleEntityAndDoMessage {
@@ -10,6 +10,10 @@ const (
 	DockerHubProvider Experiment = "dockerhub_provider"
 	// GitLabProvider enables the GitLab provider.
 	GitLabProvider Experiment = "gitlab_provider"
+	// BitbucketProvider enables the Bitbucket provider.
+	BitbucketProvider Experiment = "bitbucket_provider"
+	// OCIProvider enables the generic OCI registry provider.
+	OCIProvider Experiment = "oci_provider"
 	// MachineAccounts enables machine accounts (in particular, GitHub Actions) for authorization
 	MachineAccounts Experiment = "machine_accounts"
 	// VulnCheckErrorTemplate enables improved evaluation details
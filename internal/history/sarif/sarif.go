@@ -0,0 +1,308 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sarif converts Minder rule evaluations into SARIF 2.1.0 logs
+// so that findings can be consumed by code-scanning dashboards.
+package sarif
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+const (
+	// Version is the SARIF version produced by this package
+	Version = "2.1.0"
+	// Schema is the JSON schema of the SARIF version produced by this package
+	Schema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	toolName           = "Minder"
+	toolInformationURI = "https://mindersec.github.io"
+)
+
+// Log is the top-level SARIF document
+type Log struct {
+	Version string `json:"version"`
+	Schema  string `json:"$schema"`
+	Runs    []Run  `json:"runs"`
+}
+
+// Run describes a single invocation of an analysis tool
+type Run struct {
+	Tool    Tool     `json:"tool"`
+	Results []Result `json:"results"`
+}
+
+// Tool describes the analysis tool which produced the results
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+// Driver describes the component of the tool which contains the rules
+type Driver struct {
+	Name           string                `json:"name"`
+	InformationURI string                `json:"informationUri,omitempty"`
+	Rules          []ReportingDescriptor `json:"rules"`
+}
+
+// ReportingDescriptor holds the metadata of a single rule
+type ReportingDescriptor struct {
+	ID                   string                  `json:"id"`
+	Name                 string                  `json:"name,omitempty"`
+	ShortDescription     *Message                `json:"shortDescription,omitempty"`
+	FullDescription      *Message                `json:"fullDescription,omitempty"`
+	Help                 *Message                `json:"help,omitempty"`
+	DefaultConfiguration *ReportingConfiguration `json:"defaultConfiguration,omitempty"`
+	Properties           map[string]any          `json:"properties,omitempty"`
+}
+
+// ReportingConfiguration holds the default configuration of a rule
+type ReportingConfiguration struct {
+	Level string `json:"level"`
+}
+
+// Message is a SARIF message string
+type Message struct {
+	Text     string `json:"text"`
+	Markdown string `json:"markdown,omitempty"`
+}
+
+// Result is a single finding
+type Result struct {
+	RuleID     string         `json:"ruleId"`
+	RuleIndex  int            `json:"ruleIndex"`
+	Kind       string         `json:"kind"`
+	Level      string         `json:"level"`
+	Message    Message        `json:"message"`
+	Locations  []Location     `json:"locations,omitempty"`
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+// Location describes where a result was found
+type Location struct {
+	LogicalLocations []LogicalLocation `json:"logicalLocations"`
+}
+
+// LogicalLocation is a location which is not a file, such as a repository or an artifact
+type LogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName,omitempty"`
+	Kind               string `json:"kind,omitempty"`
+}
+
+// SARIF result kinds and levels
+const (
+	kindPass          = "pass"
+	kindFail          = "fail"
+	kindNotApplicable = "notApplicable"
+	kindReview        = "review"
+
+	levelNone    = "none"
+	levelNote    = "note"
+	levelWarning = "warning"
+	levelError   = "error"
+)
+
+// FromRuleEvaluations builds a SARIF log with a single run out of the latest
+// rule evaluation statuses. Rule types are used to fill in the rule metadata
+// (description, guidance, severity) and are looked up by name. Evaluations
+// whose rule type is not found are still reported, but with minimal rule
+// metadata.
+func FromRuleEvaluations(
+	evals []*minderv1.RuleEvaluationStatus,
+	ruleTypes []*minderv1.RuleType,
+) *Log {
+	ruleTypesByName := make(map[string]*minderv1.RuleType, len(ruleTypes))
+	for _, rt := range ruleTypes {
+		ruleTypesByName[rt.GetName()] = rt
+	}
+
+	// Only report the rules which are referenced by the results, in a stable order.
+	var ruleIDs []string
+	for _, eval := range evals {
+		id := eval.GetRuleTypeName()
+		if !slices.Contains(ruleIDs, id) {
+			ruleIDs = append(ruleIDs, id)
+		}
+	}
+	slices.Sort(ruleIDs)
+
+	rules := make([]ReportingDescriptor, 0, len(ruleIDs))
+	for _, id := range ruleIDs {
+		rules = append(rules, reportingDescriptor(id, ruleTypesByName[id]))
+	}
+
+	results := make([]Result, 0, len(evals))
+	for _, eval := range evals {
+		ruleIndex, _ := slices.BinarySearch(ruleIDs, eval.GetRuleTypeName())
+		results = append(results, result(eval, ruleIndex, ruleTypesByName[eval.GetRuleTypeName()]))
+	}
+
+	// Keep the output deterministic regardless of the order the evaluations were fetched in.
+	slices.SortStableFunc(results, func(a, b Result) int {
+		return cmp.Compare(a.RuleID, b.RuleID)
+	})
+
+	return &Log{
+		Version: Version,
+		Schema:  Schema,
+		Runs: []Run{
+			{
+				Tool: Tool{
+					Driver: Driver{
+						Name:           toolName,
+						InformationURI: toolInformationURI,
+						Rules:          rules,
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+}
+
+func reportingDescriptor(id string, rt *minderv1.RuleType) ReportingDescriptor {
+	desc := ReportingDescriptor{
+		ID:   id,
+		Name: id,
+	}
+	if rt == nil {
+		return desc
+	}
+
+	if rt.GetDisplayName() != "" {
+		desc.Name = rt.GetDisplayName()
+	}
+	if rt.GetShortFailureMessage() != "" {
+		desc.ShortDescription = &Message{Text: rt.GetShortFailureMessage()}
+	}
+	if rt.GetDescription() != "" {
+		desc.FullDescription = &Message{Text: rt.GetDescription()}
+	}
+	if rt.GetGuidance() != "" {
+		desc.Help = &Message{Text: rt.GetGuidance(), Markdown: rt.GetGuidance()}
+	}
+
+	sev := severityValue(rt.GetSeverity())
+	desc.DefaultConfiguration = &ReportingConfiguration{Level: levelForSeverity(sev)}
+	desc.Properties = map[string]any{
+		"severity": sev.AsString(),
+	}
+	if score := securitySeverity(sev); score != "" {
+		// GitHub code scanning uses this property to rank security findings
+		desc.Properties["security-severity"] = score
+	}
+
+	return desc
+}
+
+func result(eval *minderv1.RuleEvaluationStatus, ruleIndex int, rt *minderv1.RuleType) Result {
+	kind, level := kindAndLevel(eval.GetStatus(), eval.GetSeverity(), rt)
+
+	res := Result{
+		RuleID:    eval.GetRuleTypeName(),
+		RuleIndex: ruleIndex,
+		Kind:      kind,
+		Level:     level,
+		Message:   Message{Text: resultMessage(eval, rt)},
+		Locations: []Location{
+			{
+				LogicalLocations: []LogicalLocation{
+					{
+						Name:               eval.GetEntityInfo()["name"],
+						FullyQualifiedName: eval.GetEntityInfo()["entity_id"],
+						Kind:               eval.GetEntityInfo()["entity_type"],
+					},
+				},
+			},
+		},
+		Properties: map[string]any{
+			"profile":    eval.GetProfileId(),
+			"rule":       eval.GetRuleDescriptionName(),
+			"evaluation": eval.GetRuleEvaluationId(),
+		},
+	}
+	if eval.GetLastUpdated() != nil {
+		res.Properties["evaluatedAt"] = eval.GetLastUpdated().AsTime().Format(time.RFC3339)
+	}
+
+	return res
+}
+
+func resultMessage(eval *minderv1.RuleEvaluationStatus, rt *minderv1.RuleType) string {
+	details := eval.GetDetails()
+	status := eval.GetStatus()
+
+	if status == "failure" && rt.GetShortFailureMessage() != "" {
+		if details != "" {
+			return fmt.Sprintf("%s: %s", rt.GetShortFailureMessage(), details)
+		}
+		return rt.GetShortFailureMessage()
+	}
+	if details != "" {
+		return details
+	}
+	return fmt.Sprintf("Rule %s evaluated with status %s", eval.GetRuleDescriptionName(), status)
+}
+
+// kindAndLevel maps an evaluation status onto the SARIF result kind and level.
+// Only failures carry a level derived from the severity of the rule.
+func kindAndLevel(status string, evalSeverity *minderv1.Severity, rt *minderv1.RuleType) (string, string) {
+	switch status {
+	case "success":
+		return kindPass, levelNone
+	case "skipped":
+		return kindNotApplicable, levelNone
+	case "failure":
+		sev := evalSeverity
+		if sev == nil {
+			sev = rt.GetSeverity()
+		}
+		return kindFail, levelForSeverity(severityValue(sev))
+	case "error":
+		return kindFail, levelError
+	default:
+		return kindReview, levelNone
+	}
+}
+
+// severityValue returns the value of the severity, defaulting to unknown
+// without modifying the message it was handed.
+func severityValue(sev *minderv1.Severity) minderv1.Severity_Value {
+	if v := sev.GetValue(); v != minderv1.Severity_VALUE_UNSPECIFIED {
+		return v
+	}
+	return minderv1.Severity_VALUE_UNKNOWN
+}
+
+func levelForSeverity(sev minderv1.Severity_Value) string {
+	switch sev {
+	case minderv1.Severity_VALUE_CRITICAL, minderv1.Severity_VALUE_HIGH:
+		return levelError
+	case minderv1.Severity_VALUE_MEDIUM, minderv1.Severity_VALUE_LOW:
+		return levelWarning
+	case minderv1.Severity_VALUE_INFO:
+		return levelNote
+	default:
+		return levelWarning
+	}
+}
+
+func securitySeverity(sev minderv1.Severity_Value) string {
+	switch sev {
+	case minderv1.Severity_VALUE_CRITICAL:
+		return "9.5"
+	case minderv1.Severity_VALUE_HIGH:
+		return "8.0"
+	case minderv1.Severity_VALUE_MEDIUM:
+		return "5.5"
+	case minderv1.Severity_VALUE_LOW:
+		return "2.0"
+	default:
+		return ""
+	}
+}
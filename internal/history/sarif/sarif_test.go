@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sarif
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+func TestFromRuleEvaluations(t *testing.T) {
+	t.Parallel()
+
+	ruleTypes := []*minderv1.RuleType{
+		{
+			Name:                "secret_scanning",
+			DisplayName:         "Secret scanning is enabled",
+			ShortFailureMessage: "Secret scanning is disabled",
+			Description:         "Verifies that secret scanning is enabled",
+			Guidance:            "Enable secret scanning in the repository settings",
+			Severity:            &minderv1.Severity{Value: minderv1.Severity_VALUE_HIGH},
+		},
+	}
+
+	evals := []*minderv1.RuleEvaluationStatus{
+		{
+			RuleEvaluationId:    "eval-2",
+			EntityInfo:          map[string]string{"entity_id": "repo-2", "name": "org/repo2", "entity_type": "repository"},
+			RuleDescriptionName: "secrets",
+			RuleTypeName:        "secret_scanning",
+			ProfileId:           "default",
+			Status:              "success",
+		},
+		{
+			RuleEvaluationId:    "eval-3",
+			EntityInfo:          map[string]string{"entity_id": "artifact-1", "name": "image", "entity_type": "artifact"},
+			RuleDescriptionName: "signed",
+			RuleTypeName:        "artifact_signature",
+			ProfileId:           "default",
+			Status:              "error",
+			Details:             "no signature found",
+		},
+		{
+			RuleEvaluationId:    "eval-1",
+			EntityInfo:          map[string]string{"entity_id": "repo-1", "name": "org/repo1", "entity_type": "repository"},
+			RuleDescriptionName: "secrets",
+			RuleTypeName:        "secret_scanning",
+			ProfileId:           "default",
+			Status:              "failure",
+			Details:             "disabled",
+		},
+	}
+
+	log := FromRuleEvaluations(evals, ruleTypes)
+
+	require.Equal(t, Version, log.Version)
+	require.Len(t, log.Runs, 1)
+
+	run := log.Runs[0]
+	require.Len(t, run.Tool.Driver.Rules, 2)
+
+	// rules are sorted by ID, and only the known rule type carries metadata
+	unknown := run.Tool.Driver.Rules[0]
+	require.Equal(t, "artifact_signature", unknown.ID)
+	require.Nil(t, unknown.Help)
+
+	known := run.Tool.Driver.Rules[1]
+	require.Equal(t, "secret_scanning", known.ID)
+	require.Equal(t, "Secret scanning is enabled", known.Name)
+	require.Equal(t, "Enable secret scanning in the repository settings", known.Help.Text)
+	require.Equal(t, levelError, known.DefaultConfiguration.Level)
+	require.Equal(t, "high", known.Properties["severity"])
+
+	require.Len(t, run.Results, 3)
+
+	errored := run.Results[0]
+	require.Equal(t, "artifact_signature", errored.RuleID)
+	require.Equal(t, 0, errored.RuleIndex)
+	require.Equal(t, kindFail, errored.Kind)
+	require.Equal(t, levelError, errored.Level)
+	require.Equal(t, "no signature found", errored.Message.Text)
+	require.Equal(t, "artifact", errored.Locations[0].LogicalLocations[0].Kind)
+
+	passed := run.Results[1]
+	require.Equal(t, 1, passed.RuleIndex)
+	require.Equal(t, kindPass, passed.Kind)
+	require.Equal(t, levelNone, passed.Level)
+
+	failed := run.Results[2]
+	require.Equal(t, kindFail, failed.Kind)
+	require.Equal(t, levelError, failed.Level)
+	require.Equal(t, "Secret scanning is disabled: disabled", failed.Message.Text)
+	require.Equal(t, "org/repo1", failed.Locations[0].LogicalLocations[0].Name)
+
+	_, err := json.Marshal(log)
+	require.NoError(t, err)
+}
@@ -578,6 +578,8 @@ func mapEvalStatusTypes(
 		return db.EvalStatusTypesSkipped, nil
 	case "pending":
 		return db.EvalStatusTypesPending, nil
+	case "exempt":
+		return db.EvalStatusTypesExempt, nil
 	default:
 		return db.EvalStatusTypes("invalid"),
 			fmt.Errorf("invalid evaluation status: %s", value)
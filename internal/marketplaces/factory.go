@@ -4,10 +4,15 @@
 package marketplaces
 
 import (
+	"context"
+	"crypto"
 	"errors"
 	"fmt"
 	"path/filepath"
 
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+
 	datasourceservice "github.com/mindersec/minder/internal/datasources/service"
 	sub "github.com/mindersec/minder/internal/marketplaces/subscriptions"
 	"github.com/mindersec/minder/pkg/config/server"
@@ -23,6 +28,7 @@ import (
 // implementation of Marketplace. Otherwise, it loads the bundle specified in
 // the service config and builds a single-source Marketplace for it.
 func NewMarketplaceFromServiceConfig(
+	ctx context.Context,
 	config server.MarketplaceConfig,
 	profile profiles.ProfileService,
 	ruleType ruletypes.RuleTypeService,
@@ -41,18 +47,10 @@ func NewMarketplaceFromServiceConfig(
 
 	newSources := make([]src.BundleSource, len(cfgSources))
 	for i, cfgSource := range cfgSources {
-		// This is just used for validation
-		// TODO: support other sources
-		if t, err := cfgSource.GetType(); err != nil || t != server.TgzSource {
-			return nil, fmt.Errorf("unexpected source type: %s", cfgSource.Type)
-		}
-
-		tarPath := filepath.Clean(cfgSource.Location)
-		source, err := src.NewSourceFromTarGZ(tarPath)
+		source, err := newSourceFromConfig(ctx, cfgSource, config.CacheDir)
 		if err != nil {
-			return nil, fmt.Errorf("unable to load tar from path %s: %w", tarPath, err)
+			return nil, err
 		}
-
 		newSources[i] = source
 	}
 
@@ -64,6 +62,53 @@ func NewMarketplaceFromServiceConfig(
 	return marketplace, nil
 }
 
+// newSourceFromConfig loads the bundle of a configured source. If a public
+// key is configured for the source, the bundle must be signed by it.
+func newSourceFromConfig(
+	ctx context.Context,
+	cfgSource server.BundleSourceConfig,
+	cacheDir string,
+) (src.BundleSource, error) {
+	t, err := cfgSource.GetType()
+	if err != nil {
+		return nil, fmt.Errorf("unexpected source type: %s", cfgSource.Type)
+	}
+
+	var key crypto.PublicKey
+	if cfgSource.PublicKey != "" {
+		key, err = mindpak.LoadVerificationKey(cfgSource.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load public key from %s: %w", cfgSource.PublicKey, err)
+		}
+	}
+
+	switch t {
+	case server.OCISource:
+		source, err := src.NewSourceFromOCI(ctx, cfgSource.Location, src.OCIOptions{
+			Digest:        cfgSource.Digest,
+			CacheDir:      cacheDir,
+			PublicKey:     key,
+			RemoteOptions: []remote.Option{remote.WithAuthFromKeychain(authn.DefaultKeychain)},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to load bundle %s: %w", cfgSource.Location, err)
+		}
+		return source, nil
+	default:
+		tarPath := filepath.Clean(cfgSource.Location)
+		var source src.BundleSource
+		if key != nil {
+			source, err = src.NewSignedSourceFromTarGZ(tarPath, key)
+		} else {
+			source, err = src.NewSourceFromTarGZ(tarPath)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to load tar from path %s: %w", tarPath, err)
+		}
+		return source, nil
+	}
+}
+
 // NewMarketplace creates an instance of Marketplace with a single source
 func NewMarketplace(sources []src.BundleSource, subscriptions sub.SubscriptionService) (Marketplace, error) {
 	sourceMapping := make(map[mindpak.BundleID]src.BundleSource)
@@ -7,6 +7,7 @@ package marketplaces
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -18,6 +19,11 @@ import (
 	"github.com/mindersec/minder/pkg/mindpak/sources"
 )
 
+//go:generate go run go.uber.org/mock/mockgen -package mock_$GOPACKAGE -destination=./mock/$GOFILE -source=./$GOFILE
+
+// ErrUnknownBundle is returned when a bundle is not in the marketplace
+var ErrUnknownBundle = errors.New("unknown bundle")
+
 // Marketplace encapsulates the operations which allow profiles and rule types
 // from bundles to projects. Subscriptions are implicitly created and managed
 // by these operations.
@@ -38,6 +44,17 @@ type Marketplace interface {
 		profileName string,
 		qtx db.Querier,
 	) error
+	// Upgrade upgrades the subscription of the project to the version of
+	// the bundle in the marketplace, as set out by the options.
+	Upgrade(
+		ctx context.Context,
+		projectID uuid.UUID,
+		bundleID mindpak.BundleID,
+		opts sub.UpgradeOptions,
+		qtx db.ExtendQuerier,
+	) (*sub.UpgradePlan, error)
+	// BundleVersion returns the version of the bundle in the marketplace
+	BundleVersion(bundleID mindpak.BundleID) (string, error)
 }
 
 // trivial implementation of Marketplace with a single source
@@ -84,10 +101,37 @@ func (s *marketplace) AddProfile(
 	return nil
 }
 
+func (s *marketplace) Upgrade(
+	ctx context.Context,
+	projectID uuid.UUID,
+	bundleID mindpak.BundleID,
+	opts sub.UpgradeOptions,
+	qtx db.ExtendQuerier,
+) (*sub.UpgradePlan, error) {
+	bundle, err := s.getBundle(bundleID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := s.subscriptions.Upgrade(ctx, projectID, bundle, opts, qtx)
+	if err != nil {
+		return nil, fmt.Errorf("error while upgrading subscription: %w", err)
+	}
+	return plan, nil
+}
+
+func (s *marketplace) BundleVersion(bundleID mindpak.BundleID) (string, error) {
+	bundle, err := s.getBundle(bundleID)
+	if err != nil {
+		return "", err
+	}
+	return bundle.GetMetadata().Version, nil
+}
+
 func (s *marketplace) getBundle(bundleID mindpak.BundleID) (reader.BundleReader, error) {
 	source, ok := s.sources[bundleID]
 	if !ok {
-		return nil, fmt.Errorf("unknown bundle: %s", bundleID)
+		return nil, fmt.Errorf("%w: %s", ErrUnknownBundle, bundleID)
 	}
 	bundle, err := source.GetBundle(bundleID)
 	if err != nil {
@@ -113,3 +157,17 @@ func (_ *noopMarketplace) AddProfile(
 ) error {
 	return nil
 }
+
+func (_ *noopMarketplace) Upgrade(
+	_ context.Context,
+	_ uuid.UUID,
+	bundleID mindpak.BundleID,
+	_ sub.UpgradeOptions,
+	_ db.ExtendQuerier,
+) (*sub.UpgradePlan, error) {
+	return nil, fmt.Errorf("%w: %s", ErrUnknownBundle, bundleID)
+}
+
+func (_ *noopMarketplace) BundleVersion(bundleID mindpak.BundleID) (string, error) {
+	return "", fmt.Errorf("%w: %s", ErrUnknownBundle, bundleID)
+}
@@ -73,6 +73,28 @@ func TestMarketplace_AddProfile(t *testing.T) {
 	})
 }
 
+func TestMarketplace_Upgrade(t *testing.T) {
+	t.Parallel()
+	testHarness(t, upgrade, []testScenario{
+		{
+			Name:          "Upgrade returns error when bundle does not exist in source",
+			SourceSetup:   bsf.NewBundleSourceMock(bsf.WithFailedGetBundle),
+			ExpectedError: "error while retrieving bundle",
+		},
+		{
+			Name:              "Upgrade returns error when subscription cannot be upgraded",
+			SourceSetup:       bsf.NewBundleSourceMock(bsf.WithSuccessfulGetBundle(bundleReader)),
+			SubscriptionSetup: ssf.NewSubscriptionServiceMock(ssf.WithFailedUpgrade),
+			ExpectedError:     "error while upgrading subscription",
+		},
+		{
+			Name:              "Upgrade upgrades the subscription of the project",
+			SourceSetup:       bsf.NewBundleSourceMock(bsf.WithSuccessfulGetBundle(bundleReader)),
+			SubscriptionSetup: ssf.NewSubscriptionServiceMock(ssf.WithSuccessfulUpgrade),
+		},
+	})
+}
+
 func testHarness(t *testing.T, method testMethod, scenarios []testScenario) {
 	t.Helper()
 	for _, scenario := range scenarios {
@@ -104,6 +126,8 @@ func testHarness(t *testing.T, method testMethod, scenarios []testScenario) {
 				err = marketplace.Subscribe(ctx, projectID, bundleID, store)
 			case createProfile:
 				err = marketplace.AddProfile(ctx, projectID, bundleID, profileName, store)
+			case upgrade:
+				_, err = marketplace.Upgrade(ctx, projectID, bundleID, subscriptions.UpgradeOptions{}, store)
 			default:
 				t.Fatalf("unknown method %d", method)
 			}
@@ -133,4 +157,5 @@ type testMethod int
 const (
 	subscribe testMethod = iota
 	createProfile
+	upgrade
 )
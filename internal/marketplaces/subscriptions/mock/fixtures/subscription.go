@@ -10,6 +10,7 @@ package fixtures
 import (
 	"errors"
 
+	"github.com/mindersec/minder/internal/marketplaces/subscriptions"
 	mocksubscription "github.com/mindersec/minder/internal/marketplaces/subscriptions/mock"
 	"go.uber.org/mock/gomock"
 )
@@ -56,3 +57,15 @@ func WithFailedCreateProfile(mock SubscriptionMock) {
 		CreateProfile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(errDefault)
 }
+
+func WithSuccessfulUpgrade(mock SubscriptionMock) {
+	mock.EXPECT().
+		Upgrade(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&subscriptions.UpgradePlan{}, nil)
+}
+
+func WithFailedUpgrade(mock SubscriptionMock) {
+	mock.EXPECT().
+		Upgrade(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errDefault)
+}
@@ -15,6 +15,7 @@ import (
 
 	uuid "github.com/google/uuid"
 	db "github.com/mindersec/minder/internal/db"
+	subscriptions "github.com/mindersec/minder/internal/marketplaces/subscriptions"
 	reader "github.com/mindersec/minder/pkg/mindpak/reader"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -70,3 +71,18 @@ func (mr *MockSubscriptionServiceMockRecorder) Subscribe(ctx, projectID, bundle,
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockSubscriptionService)(nil).Subscribe), ctx, projectID, bundle, qtx)
 }
+
+// Upgrade mocks base method.
+func (m *MockSubscriptionService) Upgrade(ctx context.Context, projectID uuid.UUID, bundle reader.BundleReader, opts subscriptions.UpgradeOptions, qtx db.ExtendQuerier) (*subscriptions.UpgradePlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upgrade", ctx, projectID, bundle, opts, qtx)
+	ret0, _ := ret[0].(*subscriptions.UpgradePlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upgrade indicates an expected call of Upgrade.
+func (mr *MockSubscriptionServiceMockRecorder) Upgrade(ctx, projectID, bundle, opts, qtx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upgrade", reflect.TypeOf((*MockSubscriptionService)(nil).Upgrade), ctx, projectID, bundle, opts, qtx)
+}
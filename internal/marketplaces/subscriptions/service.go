@@ -46,6 +46,19 @@ type SubscriptionService interface {
 		profileName string,
 		qtx db.Querier,
 	) error
+	// Upgrade upgrades the rule types, data sources and profiles installed
+	// in the project from the bundle to the version of the bundle. The
+	// returned plan describes the changes, including which items were
+	// modified in the project since they were installed. Unless the options
+	// say otherwise, these items make the upgrade fail with ErrLocalChanges,
+	// and the caller must roll back the transaction.
+	Upgrade(
+		ctx context.Context,
+		projectID uuid.UUID,
+		bundle reader.BundleReader,
+		opts UpgradeOptions,
+		qtx db.ExtendQuerier,
+	) (*UpgradePlan, error)
 }
 
 type subscriptionService struct {
@@ -138,11 +151,21 @@ func (s *subscriptionService) CreateProfile(
 		return fmt.Errorf("error while retrieving profile from bundle: %w", err)
 	}
 
-	_, err = s.profiles.CreateProfile(ctx, projectID, subscription.ID, profile, qtx)
+	created, err := s.profiles.CreateProfile(ctx, projectID, subscription.ID, profile, qtx)
 	if err != nil {
 		return fmt.Errorf("error while creating profile in project: %w", err)
 	}
-	return nil
+
+	// record the profile, so that upgrades can tell if it was modified
+	digest, err := contentDigest(profile)
+	if err != nil {
+		return err
+	}
+	installed, err := installedProfile(ctx, qtx, projectID, created.GetName())
+	if err != nil {
+		return fmt.Errorf("error while reading profile: %w", err)
+	}
+	return recordContent(ctx, qtx, subscription.ID, contentKey{ContentTypeProfile, created.GetName()}, digest, installed)
 }
 
 func (_ *subscriptionService) findSubscription(
@@ -203,7 +226,18 @@ func (s *subscriptionService) upsertBundleRules(
 	subscriptionID uuid.UUID,
 ) error {
 	return bundle.ForEachRuleType(func(ruleType *minderv1.RuleType) error {
-		return s.rules.UpsertRuleType(ctx, projectID, subscriptionID, ruleType, qtx)
+		if err := s.rules.UpsertRuleType(ctx, projectID, subscriptionID, ruleType, qtx); err != nil {
+			return err
+		}
+		digest, err := contentDigest(ruleType)
+		if err != nil {
+			return err
+		}
+		installed, err := installedRuleType(ctx, qtx, projectID, ruleType.GetName())
+		if err != nil {
+			return err
+		}
+		return recordContent(ctx, qtx, subscriptionID, contentKey{ContentTypeRuleType, ruleType.GetName()}, digest, installed)
 	})
 }
 
@@ -215,6 +249,18 @@ func (s *subscriptionService) upsertBundleDataSources(
 	subscriptionID uuid.UUID,
 ) error {
 	return bundle.ForEachDataSource(func(dataSource *minderv1.DataSource) error {
-		return s.dataSources.Upsert(ctx, projectID, subscriptionID, dataSource, datasourceservice.OptionsBuilder().WithTransaction(qtx))
+		err := s.dataSources.Upsert(ctx, projectID, subscriptionID, dataSource, datasourceservice.OptionsBuilder().WithTransaction(qtx))
+		if err != nil {
+			return err
+		}
+		digest, err := contentDigest(dataSource)
+		if err != nil {
+			return err
+		}
+		installed, err := s.installedDataSource(ctx, qtx, projectID, dataSource.GetName())
+		if err != nil {
+			return err
+		}
+		return recordContent(ctx, qtx, subscriptionID, contentKey{ContentTypeDataSource, dataSource.GetName()}, digest, installed)
 	})
 }
@@ -19,6 +19,7 @@ import (
 	dbf "github.com/mindersec/minder/internal/db/fixtures"
 	brf "github.com/mindersec/minder/internal/marketplaces/bundles/mock/fixtures"
 	"github.com/mindersec/minder/internal/marketplaces/subscriptions"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
 	"github.com/mindersec/minder/pkg/mindpak/reader"
 	"github.com/mindersec/minder/pkg/profiles"
 	psf "github.com/mindersec/minder/pkg/profiles/mock/fixtures"
@@ -61,16 +62,16 @@ func TestSubscriptionService_Subscribe(t *testing.T) {
 		},
 		{
 			Name:            "Subscribe returns error if rules cannot be read from bundle",
-			DBSetup:         dbf.NewDBMock(withNotFoundFindSubscription, withBundleUpsert, withSuccessfulCreateSubscription),
+			DBSetup:         dbf.NewDBMock(withNotFoundFindSubscription, withBundleUpsert, withSuccessfulCreateSubscription, withRecordedDataSource),
 			BundleSetup:     brf.NewBundleReaderMock(brf.WithMetadata, brf.WithFailedForEachRuleType, brf.WithSuccessfulForEachDataSource),
-			DataSourceSetup: dsf.NewDataSourcesServiceMock(dsf.WithSuccessfulUpsertDataSource),
+			DataSourceSetup: dsf.NewDataSourcesServiceMock(dsf.WithSuccessfulUpsertDataSource, withInstalledDataSource),
 			ExpectedError:   "error while creating rules in project",
 		},
 		{
 			Name:            "Subscribe returns error if rules cannot be upserted into database",
-			DBSetup:         dbf.NewDBMock(withNotFoundFindSubscription, withBundleUpsert, withSuccessfulCreateSubscription),
+			DBSetup:         dbf.NewDBMock(withNotFoundFindSubscription, withBundleUpsert, withSuccessfulCreateSubscription, withRecordedDataSource),
 			BundleSetup:     brf.NewBundleReaderMock(brf.WithMetadata, brf.WithSuccessfulForEachRuleType, brf.WithSuccessfulForEachDataSource),
-			DataSourceSetup: dsf.NewDataSourcesServiceMock(dsf.WithSuccessfulUpsertDataSource),
+			DataSourceSetup: dsf.NewDataSourcesServiceMock(dsf.WithSuccessfulUpsertDataSource, withInstalledDataSource),
 			RuleTypeSetup:   rsf.NewRuleTypeServiceMock(rsf.WithFailedUpsertRuleType),
 			ExpectedError:   "error while creating rules in project",
 		},
@@ -81,11 +82,19 @@ func TestSubscriptionService_Subscribe(t *testing.T) {
 			ExpectedError: "error while creating data sources in project",
 		},
 		{
-			Name:            "Subscribe creates subscription",
-			DBSetup:         dbf.NewDBMock(withNotFoundFindSubscription, withSuccessfulCreateSubscription, withBundleUpsert),
+			Name: "Subscribe creates subscription",
+			DBSetup: dbf.NewDBMock(withNotFoundFindSubscription, withSuccessfulCreateSubscription, withBundleUpsert,
+				withRecordedDataSource, withRecordedRuleType),
 			BundleSetup:     brf.NewBundleReaderMock(brf.WithMetadata, brf.WithSuccessfulForEachRuleType, brf.WithSuccessfulForEachDataSource),
 			RuleTypeSetup:   rsf.NewRuleTypeServiceMock(rsf.WithSuccessfulUpsertRuleType),
-			DataSourceSetup: dsf.NewDataSourcesServiceMock(dsf.WithSuccessfulUpsertDataSource),
+			DataSourceSetup: dsf.NewDataSourcesServiceMock(dsf.WithSuccessfulUpsertDataSource, withInstalledDataSource),
+		},
+		{
+			Name:            "Subscribe returns error if installed content cannot be recorded",
+			DBSetup:         dbf.NewDBMock(withNotFoundFindSubscription, withSuccessfulCreateSubscription, withBundleUpsert, withFailedRecordDataSource),
+			BundleSetup:     brf.NewBundleReaderMock(brf.WithMetadata, brf.WithSuccessfulForEachDataSource),
+			DataSourceSetup: dsf.NewDataSourcesServiceMock(dsf.WithSuccessfulUpsertDataSource, withInstalledDataSource),
+			ExpectedError:   "error while recording subscription content",
 		},
 	}
 
@@ -151,7 +160,7 @@ func TestSubscriptionService_CreateProfile(t *testing.T) {
 		},
 		{
 			Name:         "CreateProfile creates profile in project",
-			DBSetup:      dbf.NewDBMock(withSuccessfulFindSubscription),
+			DBSetup:      dbf.NewDBMock(withSuccessfulFindSubscription, withRecordedProfile),
 			BundleSetup:  brf.NewBundleReaderMock(brf.WithMetadata, brf.WithSuccessfulGetProfile),
 			ProfileSetup: psf.NewProfileServiceMock(psf.WithSuccessfulCreateSubscriptionProfile),
 		},
@@ -236,6 +245,56 @@ func withFailedBundleUpsert(mock dbf.DBMock) {
 		Return(errDefault)
 }
 
+func withRecordedDataSource(mock dbf.DBMock) {
+	mock.EXPECT().
+		GetDataSourceByName(gomock.Any(), gomock.Any()).
+		Return(db.DataSource{SubscriptionID: uuid.NullUUID{UUID: subscriptionID, Valid: true}}, nil)
+	mock.EXPECT().
+		UpsertSubscriptionContent(gomock.Any(), gomock.Any()).
+		Return(nil)
+}
+
+func withFailedRecordDataSource(mock dbf.DBMock) {
+	mock.EXPECT().
+		GetDataSourceByName(gomock.Any(), gomock.Any()).
+		Return(db.DataSource{SubscriptionID: uuid.NullUUID{UUID: subscriptionID, Valid: true}}, nil)
+	mock.EXPECT().
+		UpsertSubscriptionContent(gomock.Any(), gomock.Any()).
+		Return(errDefault)
+}
+
+func withInstalledDataSource(mock dsf.DataSourcesSvcMock) {
+	mock.EXPECT().
+		GetByName(gomock.Any(), gomock.Any(), projectID, gomock.Any()).
+		Return(&minderv1.DataSource{}, nil)
+}
+
+func withRecordedRuleType(mock dbf.DBMock) {
+	mock.EXPECT().
+		GetRuleTypeByName(gomock.Any(), gomock.Any()).
+		Return(db.RuleType{
+			Definition:     []byte("{}"),
+			SubscriptionID: uuid.NullUUID{UUID: subscriptionID, Valid: true},
+		}, nil)
+	mock.EXPECT().
+		UpsertSubscriptionContent(gomock.Any(), gomock.Any()).
+		Return(nil)
+}
+
+func withRecordedProfile(mock dbf.DBMock) {
+	mock.EXPECT().
+		GetProfileByProjectAndName(gomock.Any(), gomock.Any()).
+		Return([]db.GetProfileByProjectAndNameRow{{
+			Profile: db.Profile{
+				Name:           profileName,
+				SubscriptionID: uuid.NullUUID{UUID: subscriptionID, Valid: true},
+			},
+		}}, nil)
+	mock.EXPECT().
+		UpsertSubscriptionContent(gomock.Any(), gomock.Any()).
+		Return(nil)
+}
+
 func createService(
 	ctrl *gomock.Controller,
 	profileSetup psf.ProfileSvcMockBuilder,
@@ -0,0 +1,529 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package subscriptions
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+
+	datasourceservice "github.com/mindersec/minder/internal/datasources/service"
+	"github.com/mindersec/minder/internal/db"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/mindersec/minder/pkg/mindpak/reader"
+	profsvc "github.com/mindersec/minder/pkg/profiles"
+	"github.com/mindersec/minder/pkg/ruletypes"
+)
+
+// ContentType is the type of an item installed in a project from a bundle
+type ContentType string
+
+const (
+	// ContentTypeRuleType is a rule type installed from a bundle
+	ContentTypeRuleType ContentType = "rule_type"
+	// ContentTypeDataSource is a data source installed from a bundle
+	ContentTypeDataSource ContentType = "data_source"
+	// ContentTypeProfile is a profile created from a bundle
+	ContentTypeProfile ContentType = "profile"
+)
+
+// ChangeKind describes what an upgrade does to an item of a bundle
+type ChangeKind string
+
+const (
+	// ChangeAdded is an item which is new in the bundle
+	ChangeAdded ChangeKind = "added"
+	// ChangeUpdated is an item which changed in the bundle
+	ChangeUpdated ChangeKind = "updated"
+	// ChangeUnchanged is an item which did not change in the bundle. It is
+	// left as-is, including any local modifications.
+	ChangeUnchanged ChangeKind = "unchanged"
+	// ChangeConflict is an item which changed in the bundle, but was also
+	// modified in the project, or was installed before the contents of
+	// subscriptions were recorded and differs from the bundle. The upgrade
+	// fails on conflicts unless it overwrites local changes.
+	ChangeConflict ChangeKind = "conflict"
+	// ChangeRemoved is an item which is no longer in the bundle. It is
+	// left in the project, as it may still be in use.
+	ChangeRemoved ChangeKind = "removed"
+)
+
+// ErrContentConflict is returned when an item of a bundle conflicts with an
+// item of the project which was not installed from the bundle
+var ErrContentConflict = errors.New("bundle content conflicts with project content")
+
+// ErrLocalChanges is returned when upgrading a subscription would discard
+// changes made in the project, and the upgrade does not overwrite them
+var ErrLocalChanges = errors.New("bundle content was modified in the project")
+
+// ContentChange is the change to a single item of a bundle on upgrade
+type ContentChange struct {
+	Type ContentType
+	Name string
+	Kind ChangeKind
+	// LocallyModified is set if the item was modified in the project since
+	// it was installed from the bundle. These items are only updated if the
+	// upgrade overwrites local changes.
+	LocallyModified bool
+}
+
+// UpgradeOptions controls how a subscription is upgraded
+type UpgradeOptions struct {
+	// DryRun reports the changes without applying them
+	DryRun bool
+	// OverwriteLocalChanges updates the items in conflict with the bundle,
+	// discarding the changes made to them in the project
+	OverwriteLocalChanges bool
+}
+
+// UpgradePlan describes the changes made when upgrading a subscription to
+// the version of the bundle in the marketplace
+type UpgradePlan struct {
+	FromVersion string
+	ToVersion   string
+	Changes     []ContentChange
+}
+
+// contentKey identifies an item of a bundle within a subscription
+type contentKey struct {
+	contentType ContentType
+	name        string
+}
+
+// installedContent is the state of an item installed in a project
+type installedContent struct {
+	digest         string
+	subscriptionID uuid.NullUUID
+}
+
+// upgradeItem is an item of the new version of a bundle
+type upgradeItem struct {
+	contentKey
+	content   proto.Message
+	installed func() (*installedContent, error)
+	apply     func() error
+}
+
+func (s *subscriptionService) Upgrade(
+	ctx context.Context,
+	projectID uuid.UUID,
+	bundle reader.BundleReader,
+	opts UpgradeOptions,
+	qtx db.ExtendQuerier,
+) (*UpgradePlan, error) {
+	metadata := bundle.GetMetadata()
+	subscription, err := s.findSubscription(ctx, qtx, projectID, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	contents, err := qtx.ListSubscriptionContents(ctx, subscription.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error while querying subscription contents: %w", err)
+	}
+	recorded := make(map[contentKey]db.SubscriptionContent, len(contents))
+	for _, content := range contents {
+		recorded[contentKey{ContentType(content.ContentType), content.Name}] = content
+	}
+
+	items, removed, err := s.upgradeItems(ctx, qtx, projectID, subscription.ID, bundle, recorded)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &UpgradePlan{
+		FromVersion: subscription.CurrentVersion,
+		ToVersion:   metadata.Version,
+	}
+	// items are applied in order, as rule types may depend on data sources,
+	// and profiles on rule types
+	for _, item := range items {
+		change, err := s.upgradeItem(ctx, qtx, subscription.ID, item, recorded, opts)
+		if err != nil {
+			return nil, err
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+	plan.Changes = append(plan.Changes, removed...)
+
+	if opts.DryRun {
+		return plan, nil
+	}
+
+	// the subscription is only upgraded once all its items are; the caller
+	// rolls back the items applied so far
+	var conflicts []string
+	for _, change := range plan.Changes {
+		if change.Kind == ChangeConflict {
+			conflicts = append(conflicts, fmt.Sprintf("%s %s", change.Type, change.Name))
+		}
+	}
+	if len(conflicts) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrLocalChanges, strings.Join(conflicts, ", "))
+	}
+
+	err = qtx.UpdateSubscriptionVersion(ctx, db.UpdateSubscriptionVersionParams{
+		ID:             subscription.ID,
+		CurrentVersion: metadata.Version,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error while updating subscription version: %w", err)
+	}
+
+	return plan, nil
+}
+
+// upgradeItems lists the items of the new version of the bundle, as well as
+// the recorded items which are no longer in it. Only the profiles which were
+// created from the bundle are upgraded.
+func (s *subscriptionService) upgradeItems(
+	ctx context.Context,
+	qtx db.ExtendQuerier,
+	projectID uuid.UUID,
+	subscriptionID uuid.UUID,
+	bundle reader.BundleReader,
+	recorded map[contentKey]db.SubscriptionContent,
+) ([]upgradeItem, []ContentChange, error) {
+	var items []upgradeItem
+
+	err := bundle.ForEachDataSource(func(dataSource *minderv1.DataSource) error {
+		name := dataSource.GetName()
+		items = append(items, upgradeItem{
+			contentKey: contentKey{ContentTypeDataSource, name},
+			content:    dataSource,
+			installed: func() (*installedContent, error) {
+				return s.installedDataSource(ctx, qtx, projectID, name)
+			},
+			apply: func() error {
+				return s.dataSources.Upsert(ctx, projectID, subscriptionID, dataSource,
+					datasourceservice.OptionsBuilder().WithTransaction(qtx))
+			},
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error while reading data sources from bundle: %w", err)
+	}
+
+	err = bundle.ForEachRuleType(func(ruleType *minderv1.RuleType) error {
+		name := ruleType.GetName()
+		items = append(items, upgradeItem{
+			contentKey: contentKey{ContentTypeRuleType, name},
+			content:    ruleType,
+			installed: func() (*installedContent, error) {
+				return installedRuleType(ctx, qtx, projectID, name)
+			},
+			apply: func() error {
+				return s.rules.UpsertRuleType(ctx, projectID, subscriptionID, ruleType, qtx)
+			},
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error while reading rule types from bundle: %w", err)
+	}
+
+	profileNames, err := subscriptionProfiles(ctx, qtx, projectID, subscriptionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var removed []ContentChange
+	for _, name := range profileNames {
+		profile, err := bundle.GetProfile(name)
+		if errors.Is(err, reader.ErrProfileNotFound) {
+			removed = append(removed, ContentChange{Type: ContentTypeProfile, Name: name, Kind: ChangeRemoved})
+			continue
+		} else if err != nil {
+			return nil, nil, fmt.Errorf("error while retrieving profile from bundle: %w", err)
+		}
+		items = append(items, upgradeItem{
+			contentKey: contentKey{ContentTypeProfile, name},
+			content:    profile,
+			installed: func() (*installedContent, error) {
+				return installedProfile(ctx, qtx, projectID, name)
+			},
+			apply: func() error {
+				_, err := s.profiles.UpdateProfile(ctx, projectID, subscriptionID, profile, qtx)
+				return err
+			},
+		})
+	}
+
+	inBundle := make(map[contentKey]struct{}, len(items))
+	for _, item := range items {
+		inBundle[item.contentKey] = struct{}{}
+	}
+	for key := range recorded {
+		if _, ok := inBundle[key]; ok {
+			continue
+		}
+		if key.contentType == ContentTypeProfile {
+			if slices.Contains(profileNames, key.name) {
+				continue
+			}
+			// profiles deleted from the project are not re-created, and
+			// are only reported if they are no longer in the bundle
+			if _, err := bundle.GetProfile(key.name); !errors.Is(err, reader.ErrProfileNotFound) {
+				continue
+			}
+		}
+		removed = append(removed, ContentChange{Type: key.contentType, Name: key.name, Kind: ChangeRemoved})
+	}
+	slices.SortFunc(removed, func(a, b ContentChange) int {
+		return cmp.Or(strings.Compare(string(a.Type), string(b.Type)), strings.Compare(a.Name, b.Name))
+	})
+
+	return items, removed, nil
+}
+
+// upgradeItem works out the change to a single item, and applies it unless
+// this is a dry run. Items modified in the project are not overwritten unless
+// requested, and are reported as conflicts instead.
+func (s *subscriptionService) upgradeItem(
+	ctx context.Context,
+	qtx db.Querier,
+	subscriptionID uuid.UUID,
+	item upgradeItem,
+	recorded map[contentKey]db.SubscriptionContent,
+	opts UpgradeOptions,
+) (ContentChange, error) {
+	change := ContentChange{Type: item.contentType, Name: item.name}
+
+	bundleDigest, err := contentDigest(item.content)
+	if err != nil {
+		return change, err
+	}
+	installed, err := item.installed()
+	if err != nil {
+		return change, fmt.Errorf("error while reading %s %s: %w", item.contentType, item.name, err)
+	}
+
+	record, isRecorded := recorded[item.contentKey]
+	switch {
+	case installed == nil:
+		change.Kind = ChangeAdded
+	case installed.subscriptionID.UUID != subscriptionID:
+		return change, fmt.Errorf("%w: %s %s was not installed from this bundle",
+			ErrContentConflict, item.contentType, item.name)
+	case installed.digest == bundleDigest:
+		// the project already has the content of the bundle, whatever
+		// happened to it in the meantime
+		change.Kind = ChangeUnchanged
+	case !isRecorded:
+		// installed before the contents of subscriptions were recorded, so
+		// it cannot be told whether the item differs from the bundle because
+		// of an upstream change or a local modification
+		change.LocallyModified = true
+		change.Kind = ChangeConflict
+	default:
+		change.LocallyModified = record.InstalledDigest != installed.digest
+		switch {
+		case record.BundleDigest == bundleDigest:
+			change.Kind = ChangeUnchanged
+		case change.LocallyModified:
+			change.Kind = ChangeConflict
+		default:
+			change.Kind = ChangeUpdated
+		}
+	}
+
+	if change.Kind == ChangeConflict && opts.OverwriteLocalChanges {
+		change.Kind = ChangeUpdated
+	}
+
+	if opts.DryRun || change.Kind == ChangeConflict {
+		return change, nil
+	}
+
+	if change.Kind == ChangeUnchanged {
+		// record the content if it matches the bundle but was not recorded
+		// yet, so that later upgrades can detect local modifications
+		if installed.digest == bundleDigest && (!isRecorded || record.BundleDigest != bundleDigest) {
+			return change, recordContent(ctx, qtx, subscriptionID, item.contentKey, bundleDigest, installed)
+		}
+		return change, nil
+	}
+
+	if err := item.apply(); err != nil {
+		return change, fmt.Errorf("error while upgrading %s %s: %w", item.contentType, item.name, err)
+	}
+	installed, err = item.installed()
+	if err != nil {
+		return change, fmt.Errorf("error while reading %s %s: %w", item.contentType, item.name, err)
+	}
+	if err := recordContent(ctx, qtx, subscriptionID, item.contentKey, bundleDigest, installed); err != nil {
+		return change, err
+	}
+	return change, nil
+}
+
+// subscriptionProfiles returns the names of the profiles of the project
+// which were created from the bundle
+func subscriptionProfiles(
+	ctx context.Context,
+	qtx db.Querier,
+	projectID uuid.UUID,
+	subscriptionID uuid.UUID,
+) ([]string, error) {
+	rows, err := qtx.ListProfilesByProjectIDAndLabel(ctx, db.ListProfilesByProjectIDAndLabelParams{
+		ProjectID:     projectID,
+		IncludeLabels: []string{"*"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error while querying profiles: %w", err)
+	}
+
+	var names []string
+	for _, row := range rows {
+		// there is a row per entity type of the profile
+		if row.Profile.SubscriptionID.UUID == subscriptionID && !slices.Contains(names, row.Profile.Name) {
+			names = append(names, row.Profile.Name)
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// recordContent records the digests of an item installed from a bundle
+func recordContent(
+	ctx context.Context,
+	qtx db.Querier,
+	subscriptionID uuid.UUID,
+	key contentKey,
+	bundleDigest string,
+	installed *installedContent,
+) error {
+	if installed == nil {
+		return fmt.Errorf("%s %s not found after installing it", key.contentType, key.name)
+	}
+	err := qtx.UpsertSubscriptionContent(ctx, db.UpsertSubscriptionContentParams{
+		SubscriptionID:  subscriptionID,
+		ContentType:     string(key.contentType),
+		Name:            key.name,
+		BundleDigest:    bundleDigest,
+		InstalledDigest: installed.digest,
+	})
+	if err != nil {
+		return fmt.Errorf("error while recording subscription content: %w", err)
+	}
+	return nil
+}
+
+func (s *subscriptionService) installedDataSource(
+	ctx context.Context,
+	qtx db.ExtendQuerier,
+	projectID uuid.UUID,
+	name string,
+) (*installedContent, error) {
+	ds, err := qtx.GetDataSourceByName(ctx, db.GetDataSourceByNameParams{
+		Name:     name,
+		Projects: []uuid.UUID{projectID},
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	dsPB, err := s.dataSources.GetByName(ctx, name, projectID, datasourceservice.ReadBuilder().WithTransaction(qtx))
+	if err != nil {
+		return nil, err
+	}
+	return newInstalledContent(dsPB, ds.SubscriptionID)
+}
+
+func installedRuleType(
+	ctx context.Context,
+	qtx db.Querier,
+	projectID uuid.UUID,
+	name string,
+) (*installedContent, error) {
+	rt, err := qtx.GetRuleTypeByName(ctx, db.GetRuleTypeByNameParams{
+		Projects: []uuid.UUID{projectID},
+		Name:     name,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	rtPB, err := ruletypes.RuleTypePBFromDB(&rt)
+	if err != nil {
+		return nil, err
+	}
+	return newInstalledContent(rtPB, rt.SubscriptionID)
+}
+
+func installedProfile(
+	ctx context.Context,
+	qtx db.Querier,
+	projectID uuid.UUID,
+	name string,
+) (*installedContent, error) {
+	rows, err := qtx.GetProfileByProjectAndName(ctx, db.GetProfileByProjectAndNameParams{
+		ProjectID: projectID,
+		Name:      name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	profile, ok := profsvc.MergeDatabaseGetByNameIntoProfiles(rows)[rows[0].Profile.Name]
+	if !ok {
+		return nil, fmt.Errorf("unable to read profile %s", name)
+	}
+	return newInstalledContent(profile, rows[0].Profile.SubscriptionID)
+}
+
+func newInstalledContent(content proto.Message, subscriptionID uuid.NullUUID) (*installedContent, error) {
+	digest, err := contentDigest(content)
+	if err != nil {
+		return nil, err
+	}
+	return &installedContent{digest: digest, subscriptionID: subscriptionID}, nil
+}
+
+// contentDigest hashes an item of a bundle. Fields which are set when
+// storing the item, such as IDs, are ignored.
+func contentDigest(content proto.Message) (string, error) {
+	normalized := proto.Clone(content)
+	switch c := normalized.(type) {
+	case *minderv1.RuleType:
+		c.Id = nil
+		c.Context = nil
+	case *minderv1.DataSource:
+		c.Id = ""
+		c.Context = nil
+	case *minderv1.Profile:
+		c.Id = nil
+		c.Context = nil
+		for _, sel := range c.GetSelection() {
+			sel.Id = ""
+		}
+		// selectors are not read back in a stable order
+		slices.SortFunc(c.Selection, func(a, b *minderv1.Profile_Selector) int {
+			return cmp.Or(strings.Compare(a.GetEntity(), b.GetEntity()), strings.Compare(a.GetSelector(), b.GetSelector()))
+		})
+	}
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(normalized)
+	if err != nil {
+		return "", fmt.Errorf("error while hashing bundle content: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,277 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package subscriptions
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/mindersec/minder/internal/db"
+	dbf "github.com/mindersec/minder/internal/db/fixtures"
+	mockbundle "github.com/mindersec/minder/internal/marketplaces/bundles/mock"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/mindersec/minder/pkg/mindpak"
+	"github.com/mindersec/minder/pkg/ruletypes"
+	rsf "github.com/mindersec/minder/pkg/ruletypes/mock/fixtures"
+)
+
+func TestSubscriptionService_Upgrade(t *testing.T) {
+	t.Parallel()
+
+	subscriptionID := uuid.New()
+	projectID := uuid.New()
+
+	installed := db.RuleType{
+		Name:           "rule",
+		Description:    "old",
+		Definition:     []byte("{}"),
+		SubscriptionID: uuid.NullUUID{UUID: subscriptionID, Valid: true},
+	}
+	modified := installed
+	modified.Description = "modified"
+	upToDate := installed
+	upToDate.Description = "new"
+	notOwned := installed
+	notOwned.SubscriptionID = uuid.NullUUID{}
+
+	// the rule types of the bundle, as they would be read back once installed
+	oldRuleType, err := ruletypes.RuleTypePBFromDB(&installed)
+	require.NoError(t, err)
+	newRuleType, err := ruletypes.RuleTypePBFromDB(&upToDate)
+	require.NoError(t, err)
+
+	record := func(bundle *minderv1.RuleType, rt db.RuleType) db.SubscriptionContent {
+		bundleDigest, err := contentDigest(bundle)
+		require.NoError(t, err)
+		content, err := installedRuleType(context.Background(), getRuleTypeQuerier(t, rt), projectID, rt.Name)
+		require.NoError(t, err)
+		return db.SubscriptionContent{
+			SubscriptionID:  subscriptionID,
+			ContentType:     string(ContentTypeRuleType),
+			Name:            rt.Name,
+			BundleDigest:    bundleDigest,
+			InstalledDigest: content.digest,
+		}
+	}
+
+	scenarios := []struct {
+		Name            string
+		Recorded        []db.SubscriptionContent
+		Installed       []db.RuleType
+		DryRun          bool
+		Overwrite       bool
+		Apply           bool
+		Record          bool
+		ExpectedChanges []ContentChange
+		ExpectedError   string
+	}{
+		{
+			Name:      "Upgrade reports rule types which changed in the bundle",
+			Recorded:  []db.SubscriptionContent{record(oldRuleType, installed)},
+			Installed: []db.RuleType{installed},
+			DryRun:    true,
+			ExpectedChanges: []ContentChange{
+				{Type: ContentTypeRuleType, Name: "rule", Kind: ChangeUpdated},
+			},
+		},
+		{
+			Name:      "Upgrade reports rule types modified in the project as conflicts",
+			Recorded:  []db.SubscriptionContent{record(oldRuleType, installed)},
+			Installed: []db.RuleType{modified},
+			DryRun:    true,
+			ExpectedChanges: []ContentChange{
+				{Type: ContentTypeRuleType, Name: "rule", Kind: ChangeConflict, LocallyModified: true},
+			},
+		},
+		{
+			Name:          "Upgrade fails when rule types were modified in the project",
+			Recorded:      []db.SubscriptionContent{record(oldRuleType, installed)},
+			Installed:     []db.RuleType{modified},
+			ExpectedError: "rule_type rule",
+		},
+		{
+			Name:      "Upgrade reports rule types installed before contents were recorded as conflicts",
+			Installed: []db.RuleType{installed},
+			DryRun:    true,
+			ExpectedChanges: []ContentChange{
+				{Type: ContentTypeRuleType, Name: "rule", Kind: ChangeConflict, LocallyModified: true},
+			},
+		},
+		{
+			Name:          "Upgrade fails when rule types were installed before contents were recorded",
+			Installed:     []db.RuleType{installed},
+			ExpectedError: "bundle content was modified in the project",
+		},
+		{
+			Name:      "Upgrade records rule types which already match the bundle",
+			Installed: []db.RuleType{upToDate},
+			Record:    true,
+			ExpectedChanges: []ContentChange{
+				{Type: ContentTypeRuleType, Name: "rule", Kind: ChangeUnchanged},
+			},
+		},
+		{
+			Name:      "Upgrade leaves rule types which did not change in the bundle",
+			Recorded:  []db.SubscriptionContent{record(newRuleType, installed)},
+			Installed: []db.RuleType{modified},
+			ExpectedChanges: []ContentChange{
+				{Type: ContentTypeRuleType, Name: "rule", Kind: ChangeUnchanged, LocallyModified: true},
+			},
+		},
+		{
+			Name:      "Upgrade updates rule types which changed in the bundle",
+			Recorded:  []db.SubscriptionContent{record(oldRuleType, installed)},
+			Installed: []db.RuleType{installed, upToDate},
+			Apply:     true,
+			ExpectedChanges: []ContentChange{
+				{Type: ContentTypeRuleType, Name: "rule", Kind: ChangeUpdated},
+			},
+		},
+		{
+			Name:      "Upgrade overwrites rule types modified in the project if requested",
+			Recorded:  []db.SubscriptionContent{record(oldRuleType, installed)},
+			Installed: []db.RuleType{modified, upToDate},
+			Overwrite: true,
+			Apply:     true,
+			ExpectedChanges: []ContentChange{
+				{Type: ContentTypeRuleType, Name: "rule", Kind: ChangeUpdated, LocallyModified: true},
+			},
+		},
+		{
+			Name:      "Upgrade adds rule types which are new in the bundle",
+			Installed: []db.RuleType{{}, upToDate},
+			Apply:     true,
+			ExpectedChanges: []ContentChange{
+				{Type: ContentTypeRuleType, Name: "rule", Kind: ChangeAdded},
+			},
+		},
+		{
+			Name: "Upgrade reports rule types which were removed from the bundle",
+			Recorded: []db.SubscriptionContent{
+				record(oldRuleType, installed),
+				{ContentType: string(ContentTypeRuleType), Name: "removed"},
+			},
+			Installed: []db.RuleType{installed},
+			DryRun:    true,
+			ExpectedChanges: []ContentChange{
+				{Type: ContentTypeRuleType, Name: "rule", Kind: ChangeUpdated},
+				{Type: ContentTypeRuleType, Name: "removed", Kind: ChangeRemoved},
+			},
+		},
+		{
+			Name:          "Upgrade returns error when a rule type was not installed from the bundle",
+			Installed:     []db.RuleType{notOwned},
+			ExpectedError: "was not installed from this bundle",
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := context.Background()
+
+			bundle := mockbundle.NewMockBundleReader(ctrl)
+			bundle.EXPECT().GetMetadata().Return(&mindpak.Metadata{Version: "2.0.0"})
+			bundle.EXPECT().ForEachDataSource(gomock.Any()).Return(nil)
+			bundle.EXPECT().ForEachRuleType(gomock.Any()).
+				DoAndReturn(func(fn func(*minderv1.RuleType) error) error {
+					return fn(newRuleType)
+				})
+
+			store := dbf.NewDBMock(func(mock dbf.DBMock) {
+				mock.EXPECT().GetSubscriptionByProjectBundle(gomock.Any(), gomock.Any()).
+					Return(db.Subscription{ID: subscriptionID, CurrentVersion: "1.0.0"}, nil)
+				mock.EXPECT().ListSubscriptionContents(gomock.Any(), subscriptionID).
+					Return(scenario.Recorded, nil)
+				mock.EXPECT().ListProfilesByProjectIDAndLabel(gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+				for _, rt := range scenario.Installed {
+					if rt.Name == "" {
+						mock.EXPECT().GetRuleTypeByName(gomock.Any(), gomock.Any()).
+							Return(db.RuleType{}, sql.ErrNoRows)
+						continue
+					}
+					mock.EXPECT().GetRuleTypeByName(gomock.Any(), gomock.Any()).Return(rt, nil)
+				}
+				if scenario.Apply || scenario.Record {
+					mock.EXPECT().UpsertSubscriptionContent(gomock.Any(), gomock.Any()).Return(nil)
+				}
+				if !scenario.DryRun && scenario.ExpectedError == "" {
+					mock.EXPECT().UpdateSubscriptionVersion(gomock.Any(), db.UpdateSubscriptionVersionParams{
+						ID:             subscriptionID,
+						CurrentVersion: "2.0.0",
+					}).Return(nil)
+				}
+			})(ctrl)
+
+			svc := &subscriptionService{}
+			if scenario.Apply {
+				svc.rules = rsf.NewRuleTypeServiceMock(rsf.WithSuccessfulUpsertRuleType)(ctrl)
+			}
+
+			plan, err := svc.Upgrade(ctx, projectID, bundle, UpgradeOptions{
+				DryRun:                scenario.DryRun,
+				OverwriteLocalChanges: scenario.Overwrite,
+			}, store)
+			if scenario.ExpectedError != "" {
+				require.ErrorContains(t, err, scenario.ExpectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "1.0.0", plan.FromVersion)
+			require.Equal(t, "2.0.0", plan.ToVersion)
+			require.Equal(t, scenario.ExpectedChanges, plan.Changes)
+		})
+	}
+}
+
+func TestContentDigest(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.NewString()
+	project := uuid.NewString()
+	profile := &minderv1.Profile{
+		Name: "profile",
+		Selection: []*minderv1.Profile_Selector{
+			{Entity: "repository", Selector: "b"},
+			{Entity: "repository", Selector: "a"},
+		},
+	}
+	stored := &minderv1.Profile{
+		Id:      &id,
+		Context: &minderv1.Context{Project: &project},
+		Name:    "profile",
+		Selection: []*minderv1.Profile_Selector{
+			{Id: uuid.NewString(), Entity: "repository", Selector: "a"},
+			{Id: uuid.NewString(), Entity: "repository", Selector: "b"},
+		},
+	}
+
+	digest, err := contentDigest(profile)
+	require.NoError(t, err)
+	storedDigest, err := contentDigest(stored)
+	require.NoError(t, err)
+	require.Equal(t, digest, storedDigest, "IDs and selector order should not change the digest")
+	require.Equal(t, "b", profile.Selection[0].Selector, "the content should not be modified")
+
+	stored.DisplayName = "changed"
+	changedDigest, err := contentDigest(stored)
+	require.NoError(t, err)
+	require.NotEqual(t, digest, changedDigest)
+}
+
+func getRuleTypeQuerier(t *testing.T, rt db.RuleType) db.Querier {
+	t.Helper()
+	return dbf.NewDBMock(func(mock dbf.DBMock) {
+		mock.EXPECT().GetRuleTypeByName(gomock.Any(), gomock.Any()).Return(rt, nil)
+	})(gomock.NewController(t))
+}
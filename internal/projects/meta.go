@@ -5,12 +5,17 @@
 package projects
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net"
+	"net/url"
 	"regexp"
 	"strings"
 
+	"github.com/mindersec/minder/internal/crypto"
 	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/pkg/config/server"
 )
 
 const (
@@ -37,6 +42,10 @@ type Metadata struct {
 	// Public is a field that is meant to be read by other systems.
 	// It will be exposed to the public, e.g. via a UI.
 	Public PublicMetadataV1 `json:"public"`
+
+	// Alerts overrides the server configuration of the alert types which
+	// notify services outside of the provider for this project.
+	Alerts AlertsMetadataV1 `json:"alerts,omitempty"`
 }
 
 // PublicMetadataV1 contains public metadata relevant for a project.
@@ -45,6 +54,22 @@ type PublicMetadataV1 struct {
 	DisplayName string `json:"display_name"`
 }
 
+// AlertsMetadataV1 contains the alert settings of a project.
+type AlertsMetadataV1 struct {
+	// WebhookURL is the endpoint the webhook alerts of the project are sent to
+	WebhookURL string `json:"webhook_url,omitempty"`
+	// WebhookSecret is the encrypted secret the webhook alerts of the project are signed with
+	WebhookSecret *crypto.EncryptedData `json:"encrypted_webhook_secret,omitempty"`
+	// ChatKind is the chat service the chat alerts of the project are posted to
+	ChatKind string `json:"chat_kind,omitempty"`
+	// ChatWebhookURL is the encrypted incoming webhook the chat alerts of the project are posted to
+	ChatWebhookURL *crypto.EncryptedData `json:"encrypted_chat_webhook_url,omitempty"`
+	// ChatToken is the encrypted Slack bot token the chat alerts of the project are posted with
+	ChatToken *crypto.EncryptedData `json:"encrypted_chat_token,omitempty"`
+	// ChatChannel is the Slack channel the chat alerts of the project are posted to
+	ChatChannel string `json:"chat_channel,omitempty"`
+}
+
 // NewSelfEnrolledMetadata returns a new Metadata object with the SelfEnrolled field set to true.
 func NewSelfEnrolledMetadata(projectName string) Metadata {
 	return Metadata{
@@ -73,6 +98,95 @@ func ParseMetadata(proj *db.Project) (*Metadata, error) {
 	return &meta, nil
 }
 
+// AlertsConfig returns the alerts configuration of the server with the
+// settings of the project applied on top of it. The secrets of the project
+// are decrypted with the given engine.
+func (m *Metadata) AlertsConfig(cfg *server.AlertsConfig, cryptoEngine crypto.Engine) (*server.AlertsConfig, error) {
+	var out server.AlertsConfig
+	if cfg != nil {
+		out = *cfg
+	}
+
+	if m.Alerts.WebhookURL != "" {
+		secret, err := decryptOptional(cryptoEngine, m.Alerts.WebhookSecret)
+		if err != nil {
+			return nil, fmt.Errorf("error decrypting webhook secret: %w", err)
+		}
+		out.Webhook.URL = m.Alerts.WebhookURL
+		out.Webhook.Secret = secret
+		out.Webhook.SecretFile = ""
+	}
+
+	if m.Alerts.ChatWebhookURL != nil || m.Alerts.ChatToken != nil {
+		webhookURL, err := decryptOptional(cryptoEngine, m.Alerts.ChatWebhookURL)
+		if err != nil {
+			return nil, fmt.Errorf("error decrypting chat webhook URL: %w", err)
+		}
+		token, err := decryptOptional(cryptoEngine, m.Alerts.ChatToken)
+		if err != nil {
+			return nil, fmt.Errorf("error decrypting chat token: %w", err)
+		}
+		if m.Alerts.ChatKind != "" {
+			out.Chat.Kind = m.Alerts.ChatKind
+		}
+		out.Chat.WebhookURL = webhookURL
+		out.Chat.WebhookURLFile = ""
+		out.Chat.Token = token
+		out.Chat.TokenFile = ""
+		out.Chat.Channel = m.Alerts.ChatChannel
+	}
+
+	return &out, nil
+}
+
+// EncryptOptional encrypts a secret of the project metadata. Empty secrets
+// are not stored.
+func EncryptOptional(cryptoEngine crypto.Engine, secret string) (*crypto.EncryptedData, error) {
+	if secret == "" {
+		return nil, nil
+	}
+	encrypted, err := cryptoEngine.EncryptString(secret)
+	if err != nil {
+		return nil, err
+	}
+	return &encrypted, nil
+}
+
+func decryptOptional(cryptoEngine crypto.Engine, secret *crypto.EncryptedData) (string, error) {
+	if secret == nil {
+		return "", nil
+	}
+	return cryptoEngine.DecryptString(*secret)
+}
+
+// ValidateAlertURL validates a destination of the alerts of a project. It
+// must be an https URL whose host only resolves to public addresses, so
+// that the alerts can't be used to reach the network Minder runs in.
+func ValidateAlertURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%w: invalid URL: %w", ErrValidationFailed, err)
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("%w: URL must use https", ErrValidationFailed)
+	}
+	if u.Hostname() == "" {
+		return fmt.Errorf("%w: URL must have a host", ErrValidationFailed)
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil {
+		return fmt.Errorf("%w: cannot resolve the URL host", ErrValidationFailed)
+	}
+	for _, addr := range addrs {
+		if !addr.IP.IsGlobalUnicast() || addr.IP.IsLoopback() || addr.IP.IsPrivate() {
+			return fmt.Errorf("%w: URL host must be a public address", ErrValidationFailed)
+		}
+	}
+
+	return nil
+}
+
 // ValidateName validates the given project name.
 func ValidateName(name string) error {
 	if name == "" {
@@ -5,10 +5,16 @@
 package projects
 
 import (
+	"context"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/mindersec/minder/internal/crypto"
+	mockcrypto "github.com/mindersec/minder/internal/crypto/mock"
+	"github.com/mindersec/minder/pkg/config/server"
 )
 
 func TestValidateName(t *testing.T) {
@@ -72,3 +78,138 @@ func TestValidateName(t *testing.T) {
 		})
 	}
 }
+
+func TestMetadataAlertsConfig(t *testing.T) {
+	t.Parallel()
+
+	encryptedSecret := &crypto.EncryptedData{EncodedData: "encrypted"}
+	encryptedToken := &crypto.EncryptedData{EncodedData: "encrypted-token"}
+
+	serverCfg := &server.AlertsConfig{
+		Webhook: server.WebhookAlertConfig{
+			URL:        "https://example.com/server",
+			SecretFile: "/secrets/webhook",
+			MaxRetries: 5,
+		},
+		Chat: server.ChatAlertConfig{
+			Kind:           "teams",
+			WebhookURLFile: "/secrets/chat",
+			LinkTemplate:   "https://minder.example.com",
+		},
+	}
+
+	tests := []struct {
+		name         string
+		alerts       AlertsMetadataV1
+		expected     server.WebhookAlertConfig
+		expectedChat server.ChatAlertConfig
+	}{
+		{
+			name:         "server configuration is used by default",
+			expected:     serverCfg.Webhook,
+			expectedChat: serverCfg.Chat,
+		},
+		{
+			name: "project webhook overrides the server one",
+			alerts: AlertsMetadataV1{
+				WebhookURL:    "https://example.com/project",
+				WebhookSecret: encryptedSecret,
+			},
+			expected: server.WebhookAlertConfig{
+				URL:        "https://example.com/project",
+				Secret:     "s3cr3t",
+				MaxRetries: 5,
+			},
+			expectedChat: serverCfg.Chat,
+		},
+		{
+			name: "project chat overrides the server one",
+			alerts: AlertsMetadataV1{
+				ChatKind:    "slack",
+				ChatToken:   encryptedToken,
+				ChatChannel: "#alerts",
+			},
+			expected: serverCfg.Webhook,
+			expectedChat: server.ChatAlertConfig{
+				Kind:         "slack",
+				Token:        "xoxb-project",
+				Channel:      "#alerts",
+				LinkTemplate: "https://minder.example.com",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			cryptoEngine := mockcrypto.NewMockEngine(ctrl)
+			cryptoEngine.EXPECT().DecryptString(*encryptedSecret).Return("s3cr3t", nil).AnyTimes()
+			cryptoEngine.EXPECT().DecryptString(*encryptedToken).Return("xoxb-project", nil).AnyTimes()
+
+			meta := &Metadata{Alerts: tt.alerts}
+			cfg, err := meta.AlertsConfig(serverCfg, cryptoEngine)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, cfg.Webhook)
+			require.Equal(t, tt.expectedChat, cfg.Chat)
+			require.Equal(t, "https://example.com/server", serverCfg.Webhook.URL)
+		})
+	}
+}
+
+func TestValidateAlertURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{
+			name: "public https address",
+			url:  "https://8.8.8.8/hook",
+		},
+		{
+			name:    "http is refused",
+			url:     "http://8.8.8.8/hook",
+			wantErr: true,
+		},
+		{
+			name:    "missing host",
+			url:     "https:///hook",
+			wantErr: true,
+		},
+		{
+			name:    "loopback address",
+			url:     "https://127.0.0.1/hook",
+			wantErr: true,
+		},
+		{
+			name:    "private address",
+			url:     "https://10.0.0.1/hook",
+			wantErr: true,
+		},
+		{
+			name:    "link-local address",
+			url:     "https://169.254.169.254/latest/meta-data",
+			wantErr: true,
+		},
+		{
+			name:    "IPv6 loopback address",
+			url:     "https://[::1]/hook",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateAlertURL(context.Background(), tt.url)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrValidationFailed)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
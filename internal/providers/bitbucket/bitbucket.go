@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package bitbucket provides the Bitbucket Cloud OAuth provider implementation
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"golang.org/x/oauth2"
+
+	"github.com/mindersec/minder/internal/db"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	config "github.com/mindersec/minder/pkg/config/server"
+	provifv1 "github.com/mindersec/minder/pkg/providers/v1"
+)
+
+// Class is the string that represents the Bitbucket provider class
+const Class = "bitbucket"
+
+// DefaultEndpoint is the Bitbucket Cloud REST API endpoint
+const DefaultEndpoint = "https://api.bitbucket.org/2.0/"
+
+// Implements is the list of provider types that the Bitbucket provider implements
+var Implements = []db.ProviderType{
+	db.ProviderTypeGit,
+	db.ProviderTypeRest,
+	db.ProviderTypeRepoLister,
+}
+
+// AuthorizationFlows is the list of authorization flows that the Bitbucket provider supports
+var AuthorizationFlows = []db.AuthorizationFlow{
+	db.AuthorizationFlowUserInput,
+	db.AuthorizationFlowOauth2AuthorizationCodeFlow,
+}
+
+// Ensure that the Bitbucket provider implements the right interfaces
+var _ provifv1.Git = (*bitbucketClient)(nil)
+var _ provifv1.REST = (*bitbucketClient)(nil)
+var _ provifv1.RepoLister = (*bitbucketClient)(nil)
+
+type bitbucketClient struct {
+	cred       provifv1.BitbucketCredential
+	cli        *http.Client
+	bbcfg      *minderv1.BitbucketProviderConfig
+	webhookURL string
+	gitConfig  config.GitConfig
+
+	// secret for the webhook. This is stored in the
+	// structure to allow efficient fetching.
+	currentWebhookSecret string
+}
+
+// New creates a new Bitbucket provider
+// Note that the webhook URL should already contain the provider class in the path
+func New(
+	cred provifv1.BitbucketCredential,
+	cfg *minderv1.BitbucketProviderConfig,
+	webhookURL string,
+	currentWebhookSecret string,
+) (*bitbucketClient, error) {
+	// TODO: We need a context here.
+	cli := oauth2.NewClient(context.Background(), cred.GetAsOAuth2TokenSource())
+
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = DefaultEndpoint
+	}
+
+	if webhookURL == "" {
+		return nil, errors.New("webhook URL is required")
+	}
+
+	return &bitbucketClient{
+		cred:                 cred,
+		cli:                  cli,
+		bbcfg:                cfg,
+		webhookURL:           webhookURL,
+		currentWebhookSecret: currentWebhookSecret,
+	}, nil
+}
+
+type bbConfigWrapper struct {
+	Bitbucket *minderv1.BitbucketProviderConfig `json:"bitbucket" yaml:"bitbucket" mapstructure:"bitbucket" validate:"required"`
+}
+
+// ParseV1Config parses the raw configuration into a BitbucketProviderConfig
+func ParseV1Config(rawCfg json.RawMessage) (*minderv1.BitbucketProviderConfig, error) {
+	var cfg bbConfigWrapper
+	if err := json.Unmarshal(rawCfg, &cfg); err != nil {
+		return nil, err
+	}
+
+	if cfg.Bitbucket == nil {
+		// Return a default but working config
+		return &minderv1.BitbucketProviderConfig{}, nil
+	}
+
+	return cfg.Bitbucket, nil
+}
+
+// MarshalV1Config marshals and validates the given config
+// so it can safely be stored in the database
+func MarshalV1Config(rawCfg json.RawMessage) (json.RawMessage, error) {
+	var w bbConfigWrapper
+	if err := json.Unmarshal(rawCfg, &w); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(w)
+}
+
+// CanImplement returns true if the provider can implement the given trait
+func (_ *bitbucketClient) CanImplement(trait minderv1.ProviderType) bool {
+	return trait == minderv1.ProviderType_PROVIDER_TYPE_GIT ||
+		trait == minderv1.ProviderType_PROVIDER_TYPE_REST ||
+		trait == minderv1.ProviderType_PROVIDER_TYPE_REPO_LISTER
+}
+
+func (c *bitbucketClient) GetCredential() provifv1.BitbucketCredential {
+	return c.cred
+}
+
+// SupportsEntity implements the Provider interface
+func (_ *bitbucketClient) SupportsEntity(entType minderv1.Entity) bool {
+	return entType == minderv1.Entity_ENTITY_REPOSITORIES ||
+		entType == minderv1.Entity_ENTITY_PULL_REQUESTS
+}
@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	provifv1 "github.com/mindersec/minder/pkg/providers/v1"
+)
+
+// Do implements the REST provider interface
+func (c *bitbucketClient) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	req = req.WithContext(ctx)
+	return c.cli.Do(req)
+}
+
+// GetBaseURL implements the REST provider interface
+func (c *bitbucketClient) GetBaseURL() string {
+	return c.bbcfg.Endpoint
+}
+
+// NewRequest implements the REST provider interface
+func (c *bitbucketClient) NewRequest(method, requestPath string, body any) (*http.Request, error) {
+	u, err := getParsedURL(c.bbcfg.Endpoint, requestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf io.ReadWriter
+	if body != nil {
+		buf = &bytes.Buffer{}
+		enc := json.NewEncoder(buf)
+		enc.SetEscapeHTML(false)
+		err := enc.Encode(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := http.NewRequest(method, u.String(), buf)
+	if err != nil {
+		return nil, err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	req.Header.Set("Accept", "application/json")
+	// TODO: Get User-Agent from constants
+	req.Header.Set("User-Agent", "Minder")
+
+	c.cred.SetAuthorizationHeader(req)
+
+	return req, nil
+}
+
+type genericRESTClient interface {
+	// Do sends an HTTP request and returns an HTTP response
+	Do(ctx context.Context, req *http.Request) (*http.Response, error)
+	NewRequest(method, requestUrl string, body any) (*http.Request, error)
+	GetBaseURL() string
+}
+
+// bbRESTGet fetches a single resource from the Bitbucket API and decodes it into out.
+func bbRESTGet[T any](ctx context.Context, cli genericRESTClient, path string, out T) error {
+	// NewRequest already has the base URL configured, the path
+	// will get appended to it.
+	req, err := cli.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := cli.Do(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to get resource '%s': %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		if resp.StatusCode == http.StatusNotFound {
+			return provifv1.ErrEntityNotFound
+		}
+		return fmt.Errorf("failed to get resource '%s': %s", path, resp.Status)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// paginated is the envelope Bitbucket uses for list responses.
+type paginated[T any] struct {
+	Values []T    `json:"values"`
+	Next   string `json:"next,omitempty"`
+}
+
+// maxPages bounds the number of pages we follow when listing, so that a
+// misbehaving server can't make us loop forever.
+const maxPages = 100
+
+// bbRESTList follows the Bitbucket pagination links starting at path and
+// returns all values found.
+func bbRESTList[T any](ctx context.Context, cli genericRESTClient, path string) ([]T, error) {
+	var out []T
+	next := path
+	for i := 0; next != "" && i < maxPages; i++ {
+		page := paginated[T]{}
+		if err := bbRESTGet(ctx, cli, next, &page); err != nil {
+			return nil, err
+		}
+
+		out = append(out, page.Values...)
+
+		rel, err := relativeToBase(cli.GetBaseURL(), page.Next)
+		if err != nil {
+			return nil, err
+		}
+		next = rel
+	}
+
+	return out, nil
+}
+
+// relativeToBase turns the absolute "next" URL returned by Bitbucket into
+// a path relative to the configured endpoint, so it can be passed to NewRequest.
+func relativeToBase(endpoint, next string) (string, error) {
+	if next == "" {
+		return "", nil
+	}
+
+	base, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	nu, err := url.Parse(next)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse next page URL: %w", err)
+	}
+
+	if nu.Host != "" && nu.Host != base.Host {
+		return "", fmt.Errorf("next page URL %s does not match endpoint host", next)
+	}
+
+	rel := strings.TrimPrefix(nu.Path, strings.TrimSuffix(base.Path, "/"))
+	if nu.RawQuery != "" {
+		rel += "?" + nu.RawQuery
+	}
+
+	return rel, nil
+}
+
+func getParsedURL(endpoint, path string) (*url.URL, error) {
+	base, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	// Explicitly parse path and query parameters. This is to ensure that
+	// the path is properly escaped and that the query parameters are
+	// properly encoded.
+	parsedPathAndQuery, err := url.Parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse path: %w", err)
+	}
+
+	u := base.JoinPath(parsedPathAndQuery.Path)
+
+	// These have already been escaped by the URL parser
+	u.RawQuery = parsedPathAndQuery.RawQuery
+	u.Fragment = parsedPathAndQuery.Fragment
+
+	return u, nil
+}
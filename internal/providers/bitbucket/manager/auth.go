@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/bitbucket"
+
+	"github.com/mindersec/minder/internal/db"
+	m "github.com/mindersec/minder/internal/providers/manager"
+	provv1 "github.com/mindersec/minder/pkg/providers/v1"
+)
+
+// NewOAuthConfig implements the providerClassOAuthManager interface
+func (g *providerClassManager) NewOAuthConfig(_ db.ProviderClass, cli bool) (*oauth2.Config, error) {
+	oauthClientConfig := &g.bbpcfg.OAuthClientConfig
+	oauthConfig := getOauthConfig(oauthClientConfig.RedirectURI, cli, g.bbpcfg.Scopes)
+
+	clientId, err := oauthClientConfig.GetClientID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client ID: %w", err)
+	}
+
+	clientSecret, err := oauthClientConfig.GetClientSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client secret: %w", err)
+	}
+
+	// this is currently only used for testing as bitbucket uses well-known endpoints
+	if oauthClientConfig.Endpoint != nil && oauthClientConfig.Endpoint.TokenURL != "" {
+		oauthConfig.Endpoint = oauth2.Endpoint{
+			TokenURL: oauthClientConfig.Endpoint.TokenURL,
+		}
+	}
+
+	oauthConfig.ClientID = clientId
+	oauthConfig.ClientSecret = clientSecret
+	return oauthConfig, nil
+}
+
+// ValidateCredentials implements the providerClassOAuthManager interface
+func (_ *providerClassManager) ValidateCredentials(
+	_ context.Context, cred provv1.Credential, _ *m.CredentialVerifyParams,
+) error {
+	tokenCred, ok := cred.(provv1.OAuth2TokenCredential)
+	if !ok {
+		return fmt.Errorf("invalid credential type: %T", cred)
+	}
+
+	_, err := tokenCred.GetAsOAuth2TokenSource().Token()
+	if err != nil {
+		return fmt.Errorf("cannot get token from credential: %w", err)
+	}
+
+	return nil
+}
+
+func getOauthConfig(redirectUrlBase string, cli bool, scopes []string) *oauth2.Config {
+	var redirectUrl string
+
+	if cli {
+		redirectUrl = fmt.Sprintf("%s/cli", redirectUrlBase)
+	} else {
+		redirectUrl = fmt.Sprintf("%s/web", redirectUrlBase)
+	}
+
+	return &oauth2.Config{
+		RedirectURL: redirectUrl,
+		Scopes:      scopes,
+		// TODO: This should come from the provider config
+		Endpoint: bitbucket.Endpoint,
+	}
+}
@@ -0,0 +1,187 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package manager
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/mindersec/minder/internal/providers/gitlab/webhooksecret"
+)
+
+const (
+	// MaxBytesLimit is the maximum number of bytes to read from the response body
+	// We limit to 1MB to prevent abuse
+	MaxBytesLimit int64 = 1 << 20
+
+	// eventKeyHeader is the header Bitbucket uses to convey the event type
+	eventKeyHeader = "X-Event-Key"
+	// signatureHeader is the header Bitbucket uses to convey the HMAC signature
+	// of the payload. It is only sent when the webhook has a secret.
+	signatureHeader = "X-Hub-Signature"
+	// signaturePrefix is the prefix of the signature header value
+	signaturePrefix = "sha256="
+)
+
+// Bitbucket webhook event keys
+const (
+	eventRepoPush            = "repo:push"
+	eventPullRequestCreated  = "pullrequest:created"
+	eventPullRequestUpdated  = "pullrequest:updated"
+	eventPullRequestMerged   = "pullrequest:fulfilled"
+	eventPullRequestDeclined = "pullrequest:rejected"
+)
+
+// GetWebhookHandler implements the ProviderManager interface
+// Note that this is where the whole webhook handler is defined and
+// will live.
+func (m *providerClassManager) GetWebhookHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		l := zerolog.Ctx(m.parentContext).With().
+			Str("webhook", "bitbucket").
+			Str("method", r.Method).
+			Str("path", r.URL.Path).
+			Str("remote", r.RemoteAddr).
+			Str("user-agent", r.UserAgent()).
+			Str("content-type", r.Header.Get("Content-Type")).
+			Logger()
+
+		// Validate the webhook signature. This consumes the body, so
+		// validateRequest puts it back for the event handlers.
+		if err := m.validateRequest(r); err != nil {
+			l.Error().Err(err).Msg("invalid webhook request")
+			http.Error(w, "invalid webhook request", http.StatusUnauthorized)
+			return
+		}
+
+		eventType := r.Header.Get(eventKeyHeader)
+		if eventType == "" {
+			l.Error().Msg("missing X-Event-Key header")
+			http.Error(w, "missing X-Event-Key header", http.StatusBadRequest)
+			return
+		}
+
+		l = l.With().Str("event", eventType).Logger()
+
+		disp := m.getWebhookEventDispatcher(eventType)
+
+		if err := disp(l, r); err != nil {
+			l.Error().Err(err).Msg("error handling webhook event")
+			http.Error(w, "error handling webhook event", http.StatusInternalServerError)
+			return
+		}
+
+		l.Debug().Msg("processed webhook event successfully")
+	})
+}
+
+// getWebhookEventDispatcher returns the appropriate webhook event dispatcher for the given event type
+// It returns a function that is meant to do the actual handling of the event.
+func (m *providerClassManager) getWebhookEventDispatcher(
+	eventType string,
+) func(l zerolog.Logger, r *http.Request) error {
+	switch eventType {
+	case eventRepoPush:
+		return m.handleRepoPush
+	case eventPullRequestCreated, eventPullRequestUpdated,
+		eventPullRequestMerged, eventPullRequestDeclined:
+		return m.handlePullRequest
+	default:
+		return m.handleNoop
+	}
+}
+
+// handleNoop is a no-op handler for unhandled webhook events
+func (_ *providerClassManager) handleNoop(l zerolog.Logger, _ *http.Request) error {
+	l.Debug().Msg("unhandled webhook event")
+	return nil
+}
+
+func (m *providerClassManager) validateRequest(r *http.Request) error {
+	sig := r.Header.Get(signatureHeader)
+	if sig == "" {
+		return errors.New("missing X-Hub-Signature header")
+	}
+
+	payload, err := io.ReadAll(wrapSafe(r.Body))
+	if err != nil {
+		return fmt.Errorf("error reading request body: %w", err)
+	}
+	_ = r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(payload))
+
+	if err := m.validateSignature(sig, payload, r); err != nil {
+		return fmt.Errorf("invalid X-Hub-Signature header: %w", err)
+	}
+
+	return nil
+}
+
+// validateSignature validates the incoming Bitbucket webhook signature.
+// Each webhook gets its own secret, derived from the server secret and
+// the last element of the webhook URL path (which is unique per entity).
+func (m *providerClassManager) validateSignature(sig string, payload []byte, req *http.Request) error {
+	// Extract the unique ID from the URL path
+	path := req.URL.Path
+	uniq := path[strings.LastIndex(path, "/")+1:]
+
+	// uniq must be a valid UUID
+	if _, err := uuid.Parse(uniq); err != nil {
+		return errors.New("invalid unique ID")
+	}
+
+	if !strings.HasPrefix(sig, signaturePrefix) {
+		return errors.New("unsupported signature algorithm")
+	}
+
+	mac, err := hex.DecodeString(strings.TrimPrefix(sig, signaturePrefix))
+	if err != nil {
+		return errors.New("malformed signature")
+	}
+
+	for _, base := range append([]string{m.currentWebhookSecret}, m.previousWebhookSecrets...) {
+		if validMAC(base, uniq, payload, mac) {
+			return nil
+		}
+	}
+
+	return errors.New("invalid webhook signature")
+}
+
+// validMAC checks the payload signature against the webhook secret
+// derived from base and uniq.
+func validMAC(base, uniq string, payload, mac []byte) bool {
+	secret, err := webhooksecret.New(base, uniq)
+	if err != nil {
+		return false
+	}
+
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write(payload)
+	return hmac.Equal(mac, h.Sum(nil))
+}
+
+func decodeJSONSafe[T any](r io.ReadCloser, v *T) error {
+	rs := wrapSafe(r)
+	defer r.Close()
+
+	dec := json.NewDecoder(rs)
+	return dec.Decode(v)
+}
+
+// wrapSafe wraps the io.Reader in a LimitReader to prevent abuse
+func wrapSafe(r io.Reader) io.Reader {
+	return io.LimitReader(r, MaxBytesLimit)
+}
@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package manager
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	entmsg "github.com/mindersec/minder/internal/entities/handlers/message"
+	"github.com/mindersec/minder/internal/providers/bitbucket"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/mindersec/minder/pkg/entities/properties"
+	"github.com/mindersec/minder/pkg/eventer/constants"
+)
+
+// repoPushEvent is the payload of the repo:push event. We only care
+// about which repository was pushed to.
+type repoPushEvent struct {
+	Repository *bitbucket.Repository `json:"repository"`
+}
+
+// pullRequestEvent is the payload of the pullrequest:* events
+type pullRequestEvent struct {
+	PullRequest *bitbucket.PullRequest `json:"pullrequest"`
+	Repository  *bitbucket.Repository  `json:"repository"`
+}
+
+func (m *providerClassManager) handleRepoPush(l zerolog.Logger, r *http.Request) error {
+	l.Debug().Msg("handling push event")
+
+	pushEvent := repoPushEvent{}
+	if err := decodeJSONSafe(r.Body, &pushEvent); err != nil {
+		l.Error().Err(err).Msg("error decoding push event")
+		return fmt.Errorf("error decoding push event: %w", err)
+	}
+
+	if pushEvent.Repository == nil || pushEvent.Repository.UUID == "" {
+		l.Error().Msg("push event missing repository UUID")
+		return errors.New("push event missing repository UUID")
+	}
+
+	identifyingProps, err := repoIdentifyingProperties(pushEvent.Repository)
+	if err != nil {
+		l.Error().Err(err).Msg("error creating identifying properties")
+		return err
+	}
+
+	// Form message to publish
+	outm := entmsg.NewEntityRefreshAndDoMessage()
+	outm.WithEntity(minderv1.Entity_ENTITY_REPOSITORIES, identifyingProps)
+	outm.WithProviderClassHint(bitbucket.Class)
+
+	l.Debug().Msg("publishing refresh and eval message")
+	return m.publish(constants.TopicQueueRefreshEntityAndEvaluate, outm)
+}
+
+func (m *providerClassManager) handlePullRequest(l zerolog.Logger, r *http.Request) error {
+	l.Debug().Msg("handling pull request event")
+
+	prEvent := pullRequestEvent{}
+	if err := decodeJSONSafe(r.Body, &prEvent); err != nil {
+		l.Error().Err(err).Msg("error decoding pull request event")
+		return fmt.Errorf("error decoding pull request event: %w", err)
+	}
+
+	if prEvent.PullRequest == nil || prEvent.PullRequest.ID == 0 {
+		return errors.New("pull request event missing ID")
+	}
+
+	if prEvent.Repository == nil || prEvent.Repository.UUID == "" {
+		return errors.New("pull request event missing repository UUID")
+	}
+
+	var queueTopic string
+	switch r.Header.Get(eventKeyHeader) {
+	case eventPullRequestCreated:
+		queueTopic = constants.TopicQueueOriginatingEntityAdd
+	case eventPullRequestMerged, eventPullRequestDeclined:
+		queueTopic = constants.TopicQueueOriginatingEntityDelete
+	case eventPullRequestUpdated:
+		queueTopic = constants.TopicQueueRefreshEntityAndEvaluate
+	default:
+		return nil
+	}
+
+	repoProps, err := repoIdentifyingProperties(prEvent.Repository)
+	if err != nil {
+		return err
+	}
+
+	// Form identifying properties
+	identifyingProps, err := properties.NewProperties(map[string]any{
+		properties.PropertyUpstreamID: bitbucket.FormatPullRequestUpstreamID(
+			prEvent.Repository.UUID, prEvent.PullRequest.ID),
+		bitbucket.PullRequestNumber:     bitbucket.FormatPullRequestNumber(prEvent.PullRequest.ID),
+		bitbucket.RepoPropertyWorkspace: repoProps.GetProperty(bitbucket.RepoPropertyWorkspace).GetString(),
+		bitbucket.RepoPropertySlug:      repoProps.GetProperty(bitbucket.RepoPropertySlug).GetString(),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating identifying properties: %w", err)
+	}
+
+	// Form message to publish
+	outm := entmsg.NewEntityRefreshAndDoMessage()
+	outm.WithEntity(minderv1.Entity_ENTITY_PULL_REQUESTS, identifyingProps)
+	outm.WithOriginator(minderv1.Entity_ENTITY_REPOSITORIES, repoProps)
+	outm.WithProviderClassHint(bitbucket.Class)
+
+	return m.publish(queueTopic, outm)
+}
+
+// repoIdentifyingProperties returns the properties needed to look up
+// a repository, both in the database and upstream.
+func repoIdentifyingProperties(repo *bitbucket.Repository) (*properties.Properties, error) {
+	ws, slug, err := bitbucket.RepoWorkspaceAndSlug(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	props, err := properties.NewProperties(map[string]any{
+		properties.PropertyUpstreamID:   repo.UUID,
+		bitbucket.RepoPropertyWorkspace: ws,
+		bitbucket.RepoPropertySlug:      slug,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating repo identifying properties: %w", err)
+	}
+
+	return props, nil
+}
+
+func (m *providerClassManager) publish(queueTopic string, outm *entmsg.HandleEntityAndDoMessage) error {
+	// Convert message for publishing
+	msgID := uuid.New().String()
+	msg := message.NewMessage(msgID, nil)
+	if err := outm.ToMessage(msg); err != nil {
+		return fmt.Errorf("error converting message to protobuf: %w", err)
+	}
+
+	// Publish message
+	if err := m.pub.Publish(queueTopic, msg); err != nil {
+		return fmt.Errorf("error publishing refresh and eval message: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,207 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package manager
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	entmsg "github.com/mindersec/minder/internal/entities/handlers/message"
+	"github.com/mindersec/minder/internal/providers/gitlab/webhooksecret"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/mindersec/minder/pkg/eventer/constants"
+)
+
+const (
+	hookUniq = "8e7c1a24-3bd8-4f7f-9b0a-5a7e4ff2f3b1"
+
+	pushPayload = `{
+  "repository": {
+    "uuid": "{repo-uuid}",
+    "full_name": "my-ws/my-repo",
+    "workspace": {"slug": "my-ws"},
+    "slug": "my-repo"
+  }
+}`
+
+	prPayload = `{
+  "pullrequest": {"id": 7},
+  "repository": {
+    "uuid": "{repo-uuid}",
+    "full_name": "my-ws/my-repo"
+  }
+}`
+)
+
+type fakePublisher struct {
+	topic string
+	msg   *message.Message
+}
+
+func (f *fakePublisher) Publish(topic string, messages ...*message.Message) error {
+	f.topic = topic
+	f.msg = messages[0]
+	return nil
+}
+
+func sign(t *testing.T, base string, payload string) string {
+	t.Helper()
+
+	secret, err := webhooksecret.New(base, hookUniq)
+	require.NoError(t, err)
+
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(payload))
+	return signaturePrefix + hex.EncodeToString(h.Sum(nil))
+}
+
+func TestWebhookHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		eventKey   string
+		payload    string
+		signature  func(t *testing.T, payload string) string
+		wantStatus int
+		wantTopic  string
+		wantEntity minderv1.Entity
+	}{
+		{
+			name:     "push event",
+			eventKey: eventRepoPush,
+			payload:  pushPayload,
+			signature: func(t *testing.T, payload string) string {
+				t.Helper()
+				return sign(t, "current", payload)
+			},
+			wantStatus: http.StatusOK,
+			wantTopic:  constants.TopicQueueRefreshEntityAndEvaluate,
+			wantEntity: minderv1.Entity_ENTITY_REPOSITORIES,
+		},
+		{
+			name:     "push event signed with a previous secret",
+			eventKey: eventRepoPush,
+			payload:  pushPayload,
+			signature: func(t *testing.T, payload string) string {
+				t.Helper()
+				return sign(t, "previous", payload)
+			},
+			wantStatus: http.StatusOK,
+			wantTopic:  constants.TopicQueueRefreshEntityAndEvaluate,
+			wantEntity: minderv1.Entity_ENTITY_REPOSITORIES,
+		},
+		{
+			name:     "pull request created",
+			eventKey: eventPullRequestCreated,
+			payload:  prPayload,
+			signature: func(t *testing.T, payload string) string {
+				t.Helper()
+				return sign(t, "current", payload)
+			},
+			wantStatus: http.StatusOK,
+			wantTopic:  constants.TopicQueueOriginatingEntityAdd,
+			wantEntity: minderv1.Entity_ENTITY_PULL_REQUESTS,
+		},
+		{
+			name:     "pull request merged",
+			eventKey: eventPullRequestMerged,
+			payload:  prPayload,
+			signature: func(t *testing.T, payload string) string {
+				t.Helper()
+				return sign(t, "current", payload)
+			},
+			wantStatus: http.StatusOK,
+			wantTopic:  constants.TopicQueueOriginatingEntityDelete,
+			wantEntity: minderv1.Entity_ENTITY_PULL_REQUESTS,
+		},
+		{
+			name:     "unhandled event",
+			eventKey: "repo:fork",
+			payload:  `{}`,
+			signature: func(t *testing.T, payload string) string {
+				t.Helper()
+				return sign(t, "current", payload)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:     "invalid signature",
+			eventKey: eventRepoPush,
+			payload:  pushPayload,
+			signature: func(t *testing.T, _ string) string {
+				t.Helper()
+				return sign(t, "current", "tampered")
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:     "missing signature",
+			eventKey: eventRepoPush,
+			payload:  pushPayload,
+			signature: func(t *testing.T, _ string) string {
+				t.Helper()
+				return ""
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:     "missing event key",
+			eventKey: "",
+			payload:  pushPayload,
+			signature: func(t *testing.T, payload string) string {
+				t.Helper()
+				return sign(t, "current", payload)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pub := &fakePublisher{}
+			m := &providerClassManager{
+				parentContext:          context.Background(),
+				pub:                    pub,
+				currentWebhookSecret:   "current",
+				previousWebhookSecrets: []string{"previous"},
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/webhook/bitbucket/"+hookUniq,
+				bytes.NewBufferString(tt.payload))
+			if sig := tt.signature(t, tt.payload); sig != "" {
+				req.Header.Set(signatureHeader, sig)
+			}
+			if tt.eventKey != "" {
+				req.Header.Set(eventKeyHeader, tt.eventKey)
+			}
+
+			rec := httptest.NewRecorder()
+			m.GetWebhookHandler().ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantTopic, pub.topic)
+			if tt.wantTopic == "" {
+				return
+			}
+
+			outm, err := entmsg.ToEntityRefreshAndDo(pub.msg)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantEntity, outm.Entity.Type)
+			assert.Equal(t, "bitbucket", outm.Hint.ProviderClassHint)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/mindersec/minder/pkg/entities/properties"
+)
+
+// Repository Properties
+const (
+	// RepoPropertyWorkspace represents the bitbucket workspace slug
+	RepoPropertyWorkspace = "bitbucket/workspace"
+	// RepoPropertySlug represents the bitbucket repository slug
+	RepoPropertySlug = "bitbucket/repo_slug"
+	// RepoPropertyRepoName represents the bitbucket repository display name
+	RepoPropertyRepoName = "bitbucket/repo_name"
+	// RepoPropertyDefaultBranch represents the bitbucket main branch
+	RepoPropertyDefaultBranch = "bitbucket/default_branch"
+	// RepoPropertyCloneURL represents the bitbucket repo clone URL
+	RepoPropertyCloneURL = "bitbucket/clone_url"
+	// RepoPropertyHookUUID represents the bitbucket repo hook UUID
+	RepoPropertyHookUUID = "bitbucket/hook_uuid"
+	// RepoPropertyHookURL represents the bitbucket repo hook URL
+	RepoPropertyHookURL = "bitbucket/hook_url"
+)
+
+// Pull Request Properties
+const (
+	// PullRequestNumber represents the bitbucket pull request number
+	PullRequestNumber = "bitbucket/pull_request_number"
+	// PullRequestRepoUUID represents the UUID of the repository the pull request targets
+	PullRequestRepoUUID = "bitbucket/repo_uuid"
+	// PullRequestAuthor represents the bitbucket author UUID
+	PullRequestAuthor = "bitbucket/author"
+)
+
+// FetchAllProperties implements the provider interface
+func (c *bitbucketClient) FetchAllProperties(
+	ctx context.Context, getByProps *properties.Properties, entType minderv1.Entity, cachedProps *properties.Properties,
+) (*properties.Properties, error) {
+	if !c.SupportsEntity(entType) {
+		return nil, fmt.Errorf("entity type %s not supported", entType)
+	}
+
+	//nolint:exhaustive // We only support two entity types for now.
+	switch entType {
+	case minderv1.Entity_ENTITY_REPOSITORIES:
+		return c.getPropertiesForRepo(ctx, getByProps, cachedProps)
+	case minderv1.Entity_ENTITY_PULL_REQUESTS:
+		return c.getPropertiesForPullRequest(ctx, getByProps, cachedProps)
+	default:
+		return nil, fmt.Errorf("entity type %s not supported", entType)
+	}
+}
+
+// FetchProperty implements the provider interface
+func (c *bitbucketClient) FetchProperty(
+	ctx context.Context, getByProps *properties.Properties, entType minderv1.Entity, key string,
+) (*properties.Property, error) {
+	props, err := c.FetchAllProperties(ctx, getByProps, entType, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return props.GetProperty(key), nil
+}
+
+// GetEntityName implements the provider interface
+func (c *bitbucketClient) GetEntityName(entityType minderv1.Entity, props *properties.Properties) (string, error) {
+	if props == nil {
+		return "", errors.New("properties are nil")
+	}
+
+	if !c.SupportsEntity(entityType) {
+		return "", fmt.Errorf("entity type %s not supported", entityType)
+	}
+
+	//nolint:exhaustive // We only support two entity types for now.
+	switch entityType {
+	case minderv1.Entity_ENTITY_REPOSITORIES:
+		return getRepoNameFromProperties(props)
+	case minderv1.Entity_ENTITY_PULL_REQUESTS:
+		return getPullRequestNameFromProperties(props)
+	default:
+		return "", fmt.Errorf("entity type %s not supported", entityType)
+	}
+}
+
+// PropertiesToProtoMessage implements the ProtoMessageConverter interface
+func (c *bitbucketClient) PropertiesToProtoMessage(
+	entType minderv1.Entity, props *properties.Properties,
+) (protoreflect.ProtoMessage, error) {
+	if !c.SupportsEntity(entType) {
+		return nil, fmt.Errorf("entity type %s is not supported by the bitbucket provider", entType)
+	}
+
+	//nolint:exhaustive // We only support two entity types for now.
+	switch entType {
+	case minderv1.Entity_ENTITY_REPOSITORIES:
+		return repoV1FromProperties(props)
+	case minderv1.Entity_ENTITY_PULL_REQUESTS:
+		return pullRequestV1FromProperties(props)
+	default:
+		return nil, fmt.Errorf("entity type %s not supported", entType)
+	}
+}
+
+func getStringProp(props *properties.Properties, key string) (string, error) {
+	value, err := props.GetProperty(key).AsString()
+	if err != nil {
+		return "", fmt.Errorf("property %s not found or not a string", key)
+	}
+
+	return value, nil
+}
+
+// firstStringProp returns the first non-empty value of key in the given
+// property sets. This is used to fall back to cached properties when
+// the lookup properties don't carry the information we need.
+func firstStringProp(key string, propSets ...*properties.Properties) string {
+	for _, ps := range propSets {
+		if ps == nil {
+			continue
+		}
+		if v := ps.GetProperty(key).GetString(); v != "" {
+			return v
+		}
+	}
+	return ""
+}
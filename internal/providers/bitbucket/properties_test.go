@@ -0,0 +1,294 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mindersec/minder/internal/providers/credentials"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/mindersec/minder/pkg/entities/properties"
+)
+
+const (
+	testRepoUUID = "{b5a7f9d0-0c55-4d7e-8d2f-0d9d1f5c9a10}"
+)
+
+func testRepository() *Repository {
+	return &Repository{
+		UUID:       testRepoUUID,
+		Name:       "My Repo",
+		Slug:       "my-repo",
+		FullName:   "my-ws/my-repo",
+		IsPrivate:  true,
+		Workspace:  Workspace{Slug: "my-ws"},
+		MainBranch: &Branch{Name: "main"},
+		Links: Links{
+			Clone: []Link{
+				{Name: "ssh", Href: "git@bitbucket.org:my-ws/my-repo.git"},
+				{Name: "https", Href: "https://bitbucket.org/my-ws/my-repo.git"},
+			},
+		},
+	}
+}
+
+func Test_bitbucketClient_GetEntityName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		entityType minderv1.Entity
+		props      *properties.Properties
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "nil properties",
+			entityType: minderv1.Entity_ENTITY_REPOSITORIES,
+			wantErr:    true,
+		},
+		{
+			name:       "repository",
+			entityType: minderv1.Entity_ENTITY_REPOSITORIES,
+			props: MustNewProperties(map[string]any{
+				RepoPropertyWorkspace: "my-ws",
+				RepoPropertySlug:      "my-repo",
+			}),
+			want: "my-ws/my-repo",
+		},
+		{
+			name:       "repository lacks slug",
+			entityType: minderv1.Entity_ENTITY_REPOSITORIES,
+			props: MustNewProperties(map[string]any{
+				RepoPropertyWorkspace: "my-ws",
+			}),
+			wantErr: true,
+		},
+		{
+			name:       "pull request",
+			entityType: minderv1.Entity_ENTITY_PULL_REQUESTS,
+			props: MustNewProperties(map[string]any{
+				RepoPropertyWorkspace: "my-ws",
+				RepoPropertySlug:      "my-repo",
+				PullRequestNumber:     "42",
+			}),
+			want: "my-ws/my-repo/42",
+		},
+		{
+			name:       "unsupported entity type",
+			entityType: minderv1.Entity_ENTITY_ARTIFACTS,
+			props:      MustNewProperties(map[string]any{}),
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &bitbucketClient{}
+			got, err := c.GetEntityName(tt.entityType, tt.props)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_bitbucketClient_FetchAllProperties(t *testing.T) {
+	t.Parallel()
+
+	pr := &PullRequest{
+		ID:          42,
+		Author:      Account{UUID: "{author}"},
+		Source:      PullRequestEndpoint{Branch: Branch{Name: "feature"}, Commit: Commit{Hash: "abc123"}},
+		Destination: PullRequestEndpoint{Branch: Branch{Name: "main"}},
+		Links:       Links{HTML: Link{Href: "https://bitbucket.org/my-ws/my-repo/pull-requests/42"}},
+	}
+
+	tests := []struct {
+		name       string
+		getByProps *properties.Properties
+		cached     *properties.Properties
+		entType    minderv1.Entity
+		want       map[string]any
+		wantErr    bool
+	}{
+		{
+			name:    "unsupported entity type",
+			entType: minderv1.Entity_ENTITY_UNSPECIFIED,
+			wantErr: true,
+		},
+		{
+			name: "repository by name",
+			getByProps: MustNewProperties(map[string]any{
+				properties.PropertyName: "my-ws/my-repo",
+			}),
+			entType: minderv1.Entity_ENTITY_REPOSITORIES,
+			want: map[string]any{
+				properties.PropertyUpstreamID:     testRepoUUID,
+				properties.PropertyName:           "my-ws/my-repo",
+				properties.RepoPropertyIsPrivate:  true,
+				properties.RepoPropertyIsFork:     false,
+				RepoPropertyDefaultBranch:         "main",
+				RepoPropertyCloneURL:              "https://bitbucket.org/my-ws/my-repo.git",
+				properties.RepoPropertyIsArchived: false,
+			},
+		},
+		{
+			name: "repository by upstream ID falls back to cached properties",
+			getByProps: MustNewProperties(map[string]any{
+				properties.PropertyUpstreamID: testRepoUUID,
+			}),
+			cached: MustNewProperties(map[string]any{
+				RepoPropertyWorkspace: "my-ws",
+				RepoPropertySlug:      "my-repo",
+			}),
+			entType: minderv1.Entity_ENTITY_REPOSITORIES,
+			want: map[string]any{
+				properties.PropertyName: "my-ws/my-repo",
+			},
+		},
+		{
+			name: "repository upstream ID mismatch",
+			getByProps: MustNewProperties(map[string]any{
+				properties.PropertyUpstreamID: "{another-repo}",
+				properties.PropertyName:       "my-ws/my-repo",
+			}),
+			entType: minderv1.Entity_ENTITY_REPOSITORIES,
+			wantErr: true,
+		},
+		{
+			name: "repository without enough properties",
+			getByProps: MustNewProperties(map[string]any{
+				properties.PropertyUpstreamID: testRepoUUID,
+			}),
+			entType: minderv1.Entity_ENTITY_REPOSITORIES,
+			wantErr: true,
+		},
+		{
+			name: "repository not found",
+			getByProps: MustNewProperties(map[string]any{
+				properties.PropertyName: "my-ws/missing",
+			}),
+			entType: minderv1.Entity_ENTITY_REPOSITORIES,
+			wantErr: true,
+		},
+		{
+			name: "pull request",
+			getByProps: MustNewProperties(map[string]any{
+				properties.PropertyUpstreamID: FormatPullRequestUpstreamID(testRepoUUID, 42),
+				PullRequestNumber:             "42",
+				RepoPropertyWorkspace:         "my-ws",
+				RepoPropertySlug:              "my-repo",
+			}),
+			entType: minderv1.Entity_ENTITY_PULL_REQUESTS,
+			want: map[string]any{
+				properties.PropertyName:                 "my-ws/my-repo/42",
+				properties.PullRequestCommitSHA:         "abc123",
+				properties.PullRequestBaseDefaultBranch: "main",
+				properties.PullRequestTargetBranch:      "feature",
+				properties.PullRequestTargetCloneURL:    "https://bitbucket.org/my-ws/my-repo.git",
+				PullRequestAuthor:                       "{author}",
+			},
+		},
+		{
+			name: "pull request upstream ID mismatch",
+			getByProps: MustNewProperties(map[string]any{
+				properties.PropertyUpstreamID: FormatPullRequestUpstreamID(testRepoUUID, 43),
+				PullRequestNumber:             "42",
+				RepoPropertyWorkspace:         "my-ws",
+				RepoPropertySlug:              "my-repo",
+			}),
+			entType: minderv1.Entity_ENTITY_PULL_REQUESTS,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /repositories/my-ws/my-repo", func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				assert.NoError(t, json.NewEncoder(w).Encode(testRepository()))
+			})
+			mux.HandleFunc("GET /repositories/my-ws/my-repo/pullrequests/42", func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				assert.NoError(t, json.NewEncoder(w).Encode(pr))
+			})
+			ts := httptest.NewServer(mux)
+			defer ts.Close()
+
+			c := newTestBitbucketProvider(ts.URL)
+
+			got, err := c.FetchAllProperties(context.Background(), tt.getByProps, tt.entType, tt.cached)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			for key, want := range tt.want {
+				gp := got.GetProperty(key)
+				require.NotNil(t, gp, "property %s not found", key)
+				assert.Equal(t, want, gp.RawValue(), "property %s", key)
+			}
+		})
+	}
+}
+
+func TestPropertiesToProtoMessage(t *testing.T) {
+	t.Parallel()
+
+	c := &bitbucketClient{}
+
+	repoProps, err := bitbucketRepoToProperties(testRepository())
+	require.NoError(t, err)
+
+	msg, err := c.PropertiesToProtoMessage(minderv1.Entity_ENTITY_REPOSITORIES, repoProps)
+	require.NoError(t, err)
+
+	repo, ok := msg.(*minderv1.Repository)
+	require.True(t, ok)
+	assert.Equal(t, "my-ws", repo.GetOwner())
+	assert.Equal(t, "my-repo", repo.GetName())
+	assert.Equal(t, "main", repo.GetDefaultBranch())
+	assert.True(t, repo.GetIsPrivate())
+
+	_, err = c.PropertiesToProtoMessage(minderv1.Entity_ENTITY_ARTIFACTS, repoProps)
+	assert.Error(t, err)
+}
+
+// MustNewProperties creates Properties from a map or panics
+func MustNewProperties(props map[string]any) *properties.Properties {
+	p, err := properties.NewProperties(props)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func newTestBitbucketProvider(endpoint string) *bitbucketClient {
+	return &bitbucketClient{
+		cred: credentials.NewBitbucketTokenCredential("token"),
+		bbcfg: &minderv1.BitbucketProviderConfig{
+			Endpoint: endpoint,
+		},
+		cli:                  &http.Client{},
+		webhookURL:           "https://minder.example.com/api/v1/webhook/bitbucket",
+		currentWebhookSecret: "test-secret",
+	}
+}
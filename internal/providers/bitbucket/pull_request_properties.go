@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	pbinternal "github.com/mindersec/minder/internal/proto"
+	"github.com/mindersec/minder/pkg/entities/properties"
+)
+
+// FormatPullRequestUpstreamID returns the upstream ID for a bitbucket pull request.
+// Bitbucket pull request IDs are only unique within a repository, so the
+// repository UUID is part of the upstream ID.
+func FormatPullRequestUpstreamID(repoUUID string, id int) string {
+	return fmt.Sprintf("%s/%d", repoUUID, id)
+}
+
+// FormatPullRequestNumber returns the pull request number as stored in the properties
+func FormatPullRequestNumber(id int) string {
+	return strconv.Itoa(id)
+}
+
+func (c *bitbucketClient) getPropertiesForPullRequest(
+	ctx context.Context, getByProps *properties.Properties, cachedProps *properties.Properties,
+) (*properties.Properties, error) {
+	uid, err := getByProps.GetProperty(properties.PropertyUpstreamID).AsString()
+	if err != nil {
+		return nil, fmt.Errorf("upstream ID not found or invalid: %w", err)
+	}
+
+	number := firstStringProp(PullRequestNumber, getByProps, cachedProps)
+	if number == "" {
+		return nil, errors.New("pull request number not found")
+	}
+
+	ws := firstStringProp(RepoPropertyWorkspace, getByProps, cachedProps)
+	slug := firstStringProp(RepoPropertySlug, getByProps, cachedProps)
+	if ws == "" || slug == "" {
+		return nil, errors.New("pull request repository workspace or slug not found")
+	}
+
+	prURLPath, err := url.JoinPath("repositories", url.PathEscape(ws), url.PathEscape(slug), "pullrequests", number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to join URL path for pull request: %w", err)
+	}
+
+	pr := &PullRequest{}
+	if err := bbRESTGet(ctx, c, prURLPath, pr); err != nil {
+		return nil, err
+	}
+
+	repoPath, err := url.JoinPath("repositories", url.PathEscape(ws), url.PathEscape(slug))
+	if err != nil {
+		return nil, fmt.Errorf("failed to join URL path for repository: %w", err)
+	}
+
+	repo := &Repository{}
+	if err := bbRESTGet(ctx, c, repoPath, repo); err != nil {
+		return nil, fmt.Errorf("failed to get repository: %w", err)
+	}
+
+	// Validate - pull request upstream ID must match the one we requested
+	if res := FormatPullRequestUpstreamID(repo.UUID, pr.ID); res != uid {
+		return nil, fmt.Errorf("pull request ID mismatch: %s != %s", res, uid)
+	}
+
+	outProps, err := bitbucketPullRequestToProperties(pr, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert pull request to properties: %w", err)
+	}
+
+	return outProps, nil
+}
+
+func bitbucketPullRequestToProperties(pr *PullRequest, repo *Repository) (*properties.Properties, error) {
+	ws, slug, err := RepoWorkspaceAndSlug(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	// The source repository is only different from the destination
+	// repository for pull requests coming from forks.
+	sourceCloneURL := repo.cloneURL()
+	if pr.Source.Repository != nil && pr.Source.Repository.UUID != repo.UUID {
+		sourceCloneURL = pr.Source.Repository.cloneURL()
+	}
+
+	number := FormatPullRequestNumber(pr.ID)
+
+	outProps, err := properties.NewProperties(map[string]any{
+		// Unique upstream ID for the pull request
+		properties.PropertyUpstreamID:           FormatPullRequestUpstreamID(repo.UUID, pr.ID),
+		properties.PropertyName:                 formatPullRequestName(ws, slug, number),
+		properties.PullRequestCommitSHA:         pr.Source.Commit.Hash,
+		properties.PullRequestBaseCloneURL:      repo.cloneURL(),
+		properties.PullRequestBaseDefaultBranch: pr.Destination.Branch.Name,
+		properties.PullRequestTargetCloneURL:    sourceCloneURL,
+		properties.PullRequestTargetBranch:      pr.Source.Branch.Name,
+		properties.PullRequestUpstreamURL:       pr.Links.HTML.Href,
+		RepoPropertyWorkspace:                   ws,
+		RepoPropertySlug:                        slug,
+		PullRequestNumber:                       number,
+		PullRequestRepoUUID:                     repo.UUID,
+		PullRequestAuthor:                       pr.Author.UUID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create properties: %w", err)
+	}
+
+	return outProps, nil
+}
+
+func pullRequestV1FromProperties(prProps *properties.Properties) (*pbinternal.PullRequest, error) {
+	_, err := prProps.GetProperty(properties.PropertyUpstreamID).AsString()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upstream ID: %w", err)
+	}
+
+	number, err := getStringProp(prProps, PullRequestNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request number: %w", err)
+	}
+
+	ws, err := getStringProp(prProps, RepoPropertyWorkspace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	slug, err := getStringProp(prProps, RepoPropertySlug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository slug: %w", err)
+	}
+
+	commitSha, err := getStringProp(prProps, properties.PullRequestCommitSHA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit SHA: %w", err)
+	}
+
+	prURL, err := getStringProp(prProps, properties.PullRequestUpstreamURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request URL: %w", err)
+	}
+
+	id, err := strconv.ParseInt(number, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse pull request number: %w", err)
+	}
+
+	// Bitbucket identifies authors with UUIDs, so AuthorId is left unset.
+	// The author is still available in the properties.
+	pbPR := &pbinternal.PullRequest{
+		Number:         id,
+		RepoOwner:      ws,
+		RepoName:       slug,
+		CommitSha:      commitSha,
+		Url:            prURL,
+		BaseCloneUrl:   prProps.GetProperty(properties.PullRequestBaseCloneURL).GetString(),
+		TargetCloneUrl: prProps.GetProperty(properties.PullRequestTargetCloneURL).GetString(),
+		BaseRef:        prProps.GetProperty(properties.PullRequestBaseDefaultBranch).GetString(),
+		TargetRef:      prProps.GetProperty(properties.PullRequestTargetBranch).GetString(),
+		Properties:     prProps.ToProtoStruct(),
+	}
+
+	return pbPR, nil
+}
+
+func getPullRequestNameFromProperties(props *properties.Properties) (string, error) {
+	ws, err := getStringProp(props, RepoPropertyWorkspace)
+	if err != nil {
+		return "", err
+	}
+
+	slug, err := getStringProp(props, RepoPropertySlug)
+	if err != nil {
+		return "", err
+	}
+
+	number, err := getStringProp(props, PullRequestNumber)
+	if err != nil {
+		return "", err
+	}
+
+	return formatPullRequestName(ws, slug, number), nil
+}
+
+func formatPullRequestName(workspace, slug, number string) string {
+	return fmt.Sprintf("%s/%s/%s", workspace, slug, number)
+}
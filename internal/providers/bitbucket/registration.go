@@ -0,0 +1,284 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/mindersec/minder/internal/providers/gitlab/webhooksecret"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/mindersec/minder/pkg/entities/properties"
+)
+
+const webhookDescription = "Minder webhook"
+
+// webhookEvents is the list of events minder subscribes to
+var webhookEvents = []string{
+	"repo:push",
+	"pullrequest:created",
+	"pullrequest:updated",
+	"pullrequest:fulfilled",
+	"pullrequest:rejected",
+}
+
+// RegisterEntity implements the Provider interface
+func (c *bitbucketClient) RegisterEntity(
+	ctx context.Context, entType minderv1.Entity, props *properties.Properties,
+) (*properties.Properties, error) {
+	if !c.SupportsEntity(entType) {
+		return nil, errors.New("unsupported entity type")
+	}
+
+	if entType != minderv1.Entity_ENTITY_REPOSITORIES {
+		// We only explicitly register repositories
+		// Pull requests are handled via origination
+		return props, nil
+	}
+
+	upstreamID := props.GetProperty(properties.PropertyUpstreamID).GetString()
+	if upstreamID == "" {
+		return nil, errors.New("missing upstream ID")
+	}
+
+	hooksPath, err := c.hooksPath(props)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.cleanUpStaleWebhooks(ctx, hooksPath); err != nil {
+		// This is a non-fatal error and may be transient. We log it and
+		// continue with the registration.
+		zerolog.Ctx(ctx).Error().
+			Str("upstreamID", upstreamID).
+			Str("provider-class", Class).
+			Err(err).Msg("failed to clean up stale webhooks")
+	}
+
+	whprops, err := c.createWebhook(ctx, hooksPath)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().
+			Str("upstreamID", upstreamID).
+			Str("provider-class", Class).
+			Err(err).Msg("failed to create webhook")
+		return nil, errors.New("failed to create webhook")
+	}
+
+	return props.Merge(whprops), nil
+}
+
+// DeregisterEntity implements the Provider interface
+func (c *bitbucketClient) DeregisterEntity(
+	ctx context.Context, entType minderv1.Entity, props *properties.Properties,
+) error {
+	if !c.SupportsEntity(entType) {
+		return errors.New("unsupported entity type")
+	}
+
+	if entType != minderv1.Entity_ENTITY_REPOSITORIES {
+		// Nothing was registered upstream for other entities
+		return nil
+	}
+
+	hookUUID := props.GetProperty(RepoPropertyHookUUID).GetString()
+	if hookUUID == "" {
+		return errors.New("missing hook UUID")
+	}
+
+	hooksPath, err := c.hooksPath(props)
+	if err != nil {
+		return err
+	}
+
+	// There is already enough context in the error message
+	return c.deleteWebhook(ctx, hooksPath, hookUUID)
+}
+
+// ReregisterEntity implements the Provider interface
+func (c *bitbucketClient) ReregisterEntity(
+	ctx context.Context, entType minderv1.Entity, props *properties.Properties,
+) error {
+	if !c.SupportsEntity(entType) {
+		return errors.New("unsupported entity type")
+	}
+
+	if entType != minderv1.Entity_ENTITY_REPOSITORIES {
+		return nil
+	}
+
+	hookUUID := props.GetProperty(RepoPropertyHookUUID).GetString()
+	if hookUUID == "" {
+		return errors.New("missing hook UUID")
+	}
+
+	hookURL := props.GetProperty(RepoPropertyHookURL).GetString()
+	if hookURL == "" {
+		return errors.New("missing hook URL")
+	}
+
+	hooksPath, err := c.hooksPath(props)
+	if err != nil {
+		return err
+	}
+
+	return c.updateWebhook(ctx, hooksPath, hookUUID, hookURL)
+}
+
+func (c *bitbucketClient) hooksPath(props *properties.Properties) (string, error) {
+	repoPath, err := c.repoPathFromProperties(props, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return url.JoinPath(repoPath, "hooks")
+}
+
+func (c *bitbucketClient) createWebhook(ctx context.Context, hooksPath string) (*properties.Properties, error) {
+	hookUUID := uuid.New()
+	webhookUniqueURL, err := url.JoinPath(c.webhookURL, hookUUID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to join URL path for webhook: %w", err)
+	}
+
+	sec, err := webhooksecret.New(c.currentWebhookSecret, hookUUID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook secret: %w", err)
+	}
+
+	hreq := &hook{
+		URL:         webhookUniqueURL,
+		Description: webhookDescription,
+		Active:      true,
+		Secret:      sec,
+		Events:      webhookEvents,
+	}
+
+	created, err := c.doWebhookRequest(ctx, http.MethodPost, hooksPath, hreq, http.StatusCreated)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	outProps, err := properties.NewProperties(map[string]interface{}{
+		RepoPropertyHookUUID: created.UUID,
+		RepoPropertyHookURL:  created.URL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create properties: %w", err)
+	}
+
+	return outProps, nil
+}
+
+func (c *bitbucketClient) deleteWebhook(ctx context.Context, hooksPath, hookUUID string) error {
+	deleteHookPath, err := url.JoinPath(hooksPath, url.PathEscape(hookUUID))
+	if err != nil {
+		return fmt.Errorf("failed to join URL path for hook: %w", err)
+	}
+
+	req, err := c.NewRequest(http.MethodDelete, deleteHookPath, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.Do(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// A missing webhook is considered already deleted
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to delete webhook: unexpected status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (c *bitbucketClient) cleanUpStaleWebhooks(ctx context.Context, hooksPath string) error {
+	hooks, err := bbRESTList[*hook](ctx, c, hooksPath)
+	if err != nil {
+		return fmt.Errorf("failed to get webhooks: %w", err)
+	}
+
+	for _, h := range hooks {
+		if strings.HasPrefix(h.URL, c.webhookURL) {
+			if err := c.deleteWebhook(ctx, hooksPath, h.UUID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *bitbucketClient) updateWebhook(ctx context.Context, hooksPath, hookUUID string, hookURL string) error {
+	// We don't need to update the webhook URL, as it's unique for each
+	// registration. We only need to update the secret.
+	updateHookPath, err := url.JoinPath(hooksPath, url.PathEscape(hookUUID))
+	if err != nil {
+		return fmt.Errorf("failed to join URL path for hook: %w", err)
+	}
+
+	hookURLParsed, err := url.Parse(hookURL)
+	if err != nil {
+		return fmt.Errorf("failed to parse hook URL: %w", err)
+	}
+
+	// We need to extract the UUID from the webhook URL. The UUID is
+	// the last part of the path.
+	hookMinderUUID := hookURLParsed.Path[strings.LastIndex(hookURLParsed.Path, "/")+1:]
+
+	sec, err := webhooksecret.New(c.currentWebhookSecret, hookMinderUUID)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook secret: %w", err)
+	}
+
+	hreq := &hook{
+		URL:         hookURL,
+		Description: webhookDescription,
+		Active:      true,
+		Secret:      sec,
+		Events:      webhookEvents,
+	}
+
+	if _, err := c.doWebhookRequest(ctx, http.MethodPut, updateHookPath, hreq, http.StatusOK); err != nil {
+		return fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	return nil
+}
+
+func (c *bitbucketClient) doWebhookRequest(
+	ctx context.Context, method, hookPath string, hreq *hook, expectedStatus int,
+) (*hook, error) {
+	req, err := c.NewRequest(method, hookPath, hreq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != expectedStatus {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	out := &hook{}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return out, nil
+}
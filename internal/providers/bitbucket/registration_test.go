@@ -0,0 +1,253 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/mindersec/minder/pkg/entities/properties"
+)
+
+const (
+	hooksPath = "/repositories/my-ws/my-repo/hooks"
+)
+
+func registeredRepoProps() *properties.Properties {
+	return MustNewProperties(map[string]any{
+		properties.PropertyUpstreamID: testRepoUUID,
+		RepoPropertyWorkspace:         "my-ws",
+		RepoPropertySlug:              "my-repo",
+		RepoPropertyHookUUID:          "{hook-uuid}",
+		RepoPropertyHookURL: "https://minder.example.com/api/v1/webhook/bitbucket/" +
+			"8e7c1a24-3bd8-4f7f-9b0a-5a7e4ff2f3b1",
+	})
+}
+
+func TestRegisterEntity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		entityType   minderv1.Entity
+		props        *properties.Properties
+		listStatus   int
+		createStatus int
+		wantErr      bool
+		wantDeleted  []string
+	}{
+		{
+			name:       "registers repository and cleans up stale hooks",
+			entityType: minderv1.Entity_ENTITY_REPOSITORIES,
+			props: MustNewProperties(map[string]any{
+				properties.PropertyUpstreamID: testRepoUUID,
+				properties.PropertyName:       "my-ws/my-repo",
+			}),
+			listStatus:   http.StatusOK,
+			createStatus: http.StatusCreated,
+			wantDeleted:  []string{"{stale}"},
+		},
+		{
+			name:       "error cleaning up stale webhooks still succeeds",
+			entityType: minderv1.Entity_ENTITY_REPOSITORIES,
+			props: MustNewProperties(map[string]any{
+				properties.PropertyUpstreamID: testRepoUUID,
+				properties.PropertyName:       "my-ws/my-repo",
+			}),
+			listStatus:   http.StatusInternalServerError,
+			createStatus: http.StatusCreated,
+		},
+		{
+			name:       "error creating webhook",
+			entityType: minderv1.Entity_ENTITY_REPOSITORIES,
+			props: MustNewProperties(map[string]any{
+				properties.PropertyUpstreamID: testRepoUUID,
+				properties.PropertyName:       "my-ws/my-repo",
+			}),
+			listStatus:   http.StatusOK,
+			createStatus: http.StatusForbidden,
+			wantErr:      true,
+			wantDeleted:  []string{"{stale}"},
+		},
+		{
+			name:       "missing upstream ID",
+			entityType: minderv1.Entity_ENTITY_REPOSITORIES,
+			props: MustNewProperties(map[string]any{
+				properties.PropertyName: "my-ws/my-repo",
+			}),
+			wantErr: true,
+		},
+		{
+			name:       "pull requests are not registered upstream",
+			entityType: minderv1.Entity_ENTITY_PULL_REQUESTS,
+			props: MustNewProperties(map[string]any{
+				properties.PropertyUpstreamID: FormatPullRequestUpstreamID(testRepoUUID, 1),
+			}),
+		},
+		{
+			name:       "unsupported entity type",
+			entityType: minderv1.Entity_ENTITY_UNSPECIFIED,
+			props: MustNewProperties(map[string]any{
+				properties.PropertyUpstreamID: testRepoUUID,
+			}),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var mu sync.Mutex
+			var deleted []string
+
+			c := &bitbucketClient{}
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET "+hooksPath, func(w http.ResponseWriter, _ *http.Request) {
+				if tt.listStatus != http.StatusOK {
+					w.WriteHeader(tt.listStatus)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				assert.NoError(t, json.NewEncoder(w).Encode(paginated[*hook]{
+					Values: []*hook{
+						{UUID: "{stale}", URL: c.webhookURL + "/old"},
+						{UUID: "{foreign}", URL: "https://ci.example.com/hook"},
+					},
+				}))
+			})
+			mux.HandleFunc("POST "+hooksPath, func(w http.ResponseWriter, r *http.Request) {
+				if tt.createStatus != http.StatusCreated {
+					w.WriteHeader(tt.createStatus)
+					return
+				}
+				in := &hook{}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(in))
+				assert.True(t, strings.HasPrefix(in.URL, c.webhookURL+"/"))
+				assert.NotEmpty(t, in.Secret)
+				assert.ElementsMatch(t, webhookEvents, in.Events)
+
+				in.UUID = "{new-hook}"
+				in.Secret = ""
+				w.WriteHeader(http.StatusCreated)
+				assert.NoError(t, json.NewEncoder(w).Encode(in))
+			})
+			mux.HandleFunc("DELETE "+hooksPath+"/{uuid}", func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				deleted = append(deleted, r.PathValue("uuid"))
+				w.WriteHeader(http.StatusNoContent)
+			})
+			ts := httptest.NewServer(mux)
+			defer ts.Close()
+
+			*c = *newTestBitbucketProvider(ts.URL)
+
+			props, err := c.RegisterEntity(context.Background(), tt.entityType, tt.props)
+			assert.Equal(t, tt.wantDeleted, deleted)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			if tt.entityType == minderv1.Entity_ENTITY_REPOSITORIES {
+				assert.Equal(t, "{new-hook}", props.GetProperty(RepoPropertyHookUUID).GetString())
+				assert.True(t, strings.HasPrefix(props.GetProperty(RepoPropertyHookURL).GetString(), c.webhookURL))
+			}
+		})
+	}
+}
+
+func TestDeregisterEntity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		props        *properties.Properties
+		deleteStatus int
+		wantErr      bool
+	}{
+		{
+			name:         "deletes webhook",
+			props:        registeredRepoProps(),
+			deleteStatus: http.StatusNoContent,
+		},
+		{
+			name:         "already deleted webhook is not an error",
+			props:        registeredRepoProps(),
+			deleteStatus: http.StatusNotFound,
+		},
+		{
+			name:         "upstream error",
+			props:        registeredRepoProps(),
+			deleteStatus: http.StatusInternalServerError,
+			wantErr:      true,
+		},
+		{
+			name: "missing hook UUID",
+			props: MustNewProperties(map[string]any{
+				properties.PropertyUpstreamID: testRepoUUID,
+				RepoPropertyWorkspace:         "my-ws",
+				RepoPropertySlug:              "my-repo",
+			}),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("DELETE "+hooksPath+"/{uuid}", func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "{hook-uuid}", r.PathValue("uuid"))
+				w.WriteHeader(tt.deleteStatus)
+			})
+			ts := httptest.NewServer(mux)
+			defer ts.Close()
+
+			c := newTestBitbucketProvider(ts.URL)
+			err := c.DeregisterEntity(context.Background(), minderv1.Entity_ENTITY_REPOSITORIES, tt.props)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestReregisterEntity(t *testing.T) {
+	t.Parallel()
+
+	var updated *hook
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT "+hooksPath+"/{uuid}", func(w http.ResponseWriter, r *http.Request) {
+		updated = &hook{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(updated))
+		w.Header().Set("Content-Type", "application/json")
+		assert.NoError(t, json.NewEncoder(w).Encode(updated))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	c := newTestBitbucketProvider(ts.URL)
+	props := registeredRepoProps()
+
+	err := c.ReregisterEntity(context.Background(), minderv1.Entity_ENTITY_REPOSITORIES, props)
+	require.NoError(t, err)
+	require.NotNil(t, updated)
+	assert.Equal(t, props.GetProperty(RepoPropertyHookURL).GetString(), updated.URL)
+	assert.NotEmpty(t, updated.Secret)
+}
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/rs/zerolog"
+
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+// ListAllRepositories implements the RepoLister interface. If a workspace
+// is configured only its repositories are listed, otherwise all the
+// repositories the credential is a member of are returned.
+func (c *bitbucketClient) ListAllRepositories(ctx context.Context) ([]*minderv1.Repository, error) {
+	listPath := "repositories?role=member"
+	if ws := c.bbcfg.GetWorkspace(); ws != "" {
+		p, err := url.JoinPath("repositories", url.PathEscape(ws))
+		if err != nil {
+			return nil, fmt.Errorf("failed to join URL path for repositories: %w", err)
+		}
+		listPath = p
+	}
+
+	bbRepos, err := bbRESTList[*Repository](ctx, c, listPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	if len(bbRepos) == 0 {
+		zerolog.Ctx(ctx).Debug().Msg("no repositories found")
+		return nil, nil
+	}
+
+	repos := make([]*minderv1.Repository, 0, len(bbRepos))
+	for _, r := range bbRepos {
+		props, err := bitbucketRepoToProperties(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert repository to properties: %w", err)
+		}
+
+		outRep, err := repoV1FromProperties(props)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert properties to repository: %w", err)
+		}
+
+		repos = append(repos, outRep)
+	}
+
+	zerolog.Ctx(ctx).Debug().Int("num_repos", len(repos)).Msg("found repositories in bitbucket provider")
+
+	return repos, nil
+}
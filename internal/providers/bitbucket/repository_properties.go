@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/mindersec/minder/pkg/entities/properties"
+)
+
+func (c *bitbucketClient) getPropertiesForRepo(
+	ctx context.Context, getByProps *properties.Properties, cachedProps *properties.Properties,
+) (*properties.Properties, error) {
+	repoPath, err := c.repoPathFromProperties(getByProps, cachedProps)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := &Repository{}
+	if err := bbRESTGet(ctx, c, repoPath, repo); err != nil {
+		return nil, err
+	}
+
+	// Validate - if we were asked for a specific upstream ID, the repository
+	// we got back must match it. Repositories can be renamed or transferred,
+	// in which case the old name may point to a different repository.
+	if uid := getByProps.GetProperty(properties.PropertyUpstreamID).GetString(); uid != "" && uid != repo.UUID {
+		return nil, fmt.Errorf("repository UUID mismatch: %s != %s", repo.UUID, uid)
+	}
+
+	outProps, err := bitbucketRepoToProperties(repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert repository to properties: %w", err)
+	}
+
+	return getByProps.Merge(outProps), nil
+}
+
+// repoPathFromProperties figures out the API path of a repository. The
+// workspace and slug are preferred, then the full name, and lastly the
+// repository UUID within the configured workspace.
+func (c *bitbucketClient) repoPathFromProperties(
+	getByProps *properties.Properties, cachedProps *properties.Properties,
+) (string, error) {
+	ws := firstStringProp(RepoPropertyWorkspace, getByProps, cachedProps)
+	slug := firstStringProp(RepoPropertySlug, getByProps, cachedProps)
+
+	if ws == "" || slug == "" {
+		if name := firstStringProp(properties.PropertyName, getByProps, cachedProps); name != "" {
+			var found bool
+			ws, slug, found = strings.Cut(name, "/")
+			if !found {
+				return "", fmt.Errorf("invalid repository name %q, expected workspace/slug", name)
+			}
+		}
+	}
+
+	if ws == "" || slug == "" {
+		uid := getByProps.GetProperty(properties.PropertyUpstreamID).GetString()
+		if uid == "" || c.bbcfg.GetWorkspace() == "" {
+			return "", errors.New("not enough properties to identify the repository")
+		}
+		// Bitbucket accepts the repository UUID in place of the slug
+		ws, slug = c.bbcfg.GetWorkspace(), uid
+	}
+
+	return url.JoinPath("repositories", url.PathEscape(ws), url.PathEscape(slug))
+}
+
+func bitbucketRepoToProperties(repo *Repository) (*properties.Properties, error) {
+	if repo.UUID == "" {
+		return nil, errors.New("bitbucket repository has no UUID")
+	}
+
+	ws, slug, err := RepoWorkspaceAndSlug(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var defaultBranch string
+	if repo.MainBranch != nil {
+		defaultBranch = repo.MainBranch.Name
+	}
+
+	outProps, err := properties.NewProperties(map[string]any{
+		properties.PropertyUpstreamID:    repo.UUID,
+		properties.PropertyName:          formatRepoName(ws, slug),
+		properties.RepoPropertyIsPrivate: repo.IsPrivate,
+		// Bitbucket Cloud has no notion of archived repositories
+		properties.RepoPropertyIsArchived: false,
+		properties.RepoPropertyIsFork:     repo.Parent != nil,
+		RepoPropertyWorkspace:             ws,
+		RepoPropertySlug:                  slug,
+		RepoPropertyRepoName:              repo.Name,
+		RepoPropertyDefaultBranch:         defaultBranch,
+		RepoPropertyCloneURL:              repo.cloneURL(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create properties: %w", err)
+	}
+
+	return outProps, nil
+}
+
+// RepoWorkspaceAndSlug returns the workspace and slug of the repository,
+// falling back to parsing the full name if the payload is missing them
+// (as is the case for some webhook payloads).
+func RepoWorkspaceAndSlug(repo *Repository) (string, string, error) {
+	ws, slug := repo.Workspace.Slug, repo.Slug
+	if ws != "" && slug != "" {
+		return ws, slug, nil
+	}
+
+	ws, slug, found := strings.Cut(repo.FullName, "/")
+	if !found || ws == "" || slug == "" {
+		return "", "", fmt.Errorf("bitbucket repository %s has no workspace or slug", repo.UUID)
+	}
+
+	return ws, slug, nil
+}
+
+func repoV1FromProperties(repoProperties *properties.Properties) (*minderv1.Repository, error) {
+	if _, err := repoProperties.GetProperty(properties.PropertyUpstreamID).AsString(); err != nil {
+		return nil, fmt.Errorf("error fetching upstream ID property: %w", err)
+	}
+
+	slug, err := repoProperties.GetProperty(RepoPropertySlug).AsString()
+	if err != nil {
+		return nil, fmt.Errorf("error fetching slug property: %w", err)
+	}
+
+	owner, err := repoProperties.GetProperty(RepoPropertyWorkspace).AsString()
+	if err != nil {
+		return nil, fmt.Errorf("error fetching workspace property: %w", err)
+	}
+
+	isPrivate, err := repoProperties.GetProperty(properties.RepoPropertyIsPrivate).AsBool()
+	if err != nil {
+		return nil, fmt.Errorf("error fetching is_private property: %w", err)
+	}
+
+	isFork, err := repoProperties.GetProperty(properties.RepoPropertyIsFork).AsBool()
+	if err != nil {
+		return nil, fmt.Errorf("error fetching is_fork property: %w", err)
+	}
+
+	// Bitbucket identifies repositories with UUIDs, so there is no
+	// numeric repository ID to report.
+	pbRepo := &minderv1.Repository{
+		Name:          slug,
+		Owner:         owner,
+		CloneUrl:      repoProperties.GetProperty(RepoPropertyCloneURL).GetString(),
+		IsPrivate:     isPrivate,
+		IsFork:        isFork,
+		DefaultBranch: repoProperties.GetProperty(RepoPropertyDefaultBranch).GetString(),
+		Properties:    repoProperties.ToProtoStruct(),
+	}
+
+	return pbRepo, nil
+}
+
+func getRepoNameFromProperties(props *properties.Properties) (string, error) {
+	ws, err := getStringProp(props, RepoPropertyWorkspace)
+	if err != nil {
+		return "", err
+	}
+
+	slug, err := getStringProp(props, RepoPropertySlug)
+	if err != nil {
+		return "", err
+	}
+
+	return formatRepoName(ws, slug), nil
+}
+
+func formatRepoName(workspace, slug string) string {
+	return workspace + "/" + slug
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bitbucket
+
+// The types in this file model the subset of the Bitbucket Cloud 2.0 API
+// that minder relies on. They are shared between the REST client and the
+// webhook handlers, as Bitbucket uses the same representation in both.
+
+// Link is a single hypermedia link
+type Link struct {
+	Href string `json:"href"`
+	Name string `json:"name,omitempty"`
+}
+
+// Links contains the links of a Bitbucket object
+type Links struct {
+	HTML  Link   `json:"html"`
+	Clone []Link `json:"clone,omitempty"`
+}
+
+// Workspace is a Bitbucket workspace
+type Workspace struct {
+	UUID string `json:"uuid"`
+	Slug string `json:"slug"`
+}
+
+// Branch is a Bitbucket branch reference
+type Branch struct {
+	Name string `json:"name"`
+}
+
+// Commit is a Bitbucket commit reference
+type Commit struct {
+	Hash string `json:"hash"`
+}
+
+// Account is a Bitbucket user or team
+type Account struct {
+	UUID        string `json:"uuid"`
+	AccountID   string `json:"account_id"`
+	DisplayName string `json:"display_name"`
+}
+
+// Repository is a Bitbucket repository
+type Repository struct {
+	UUID       string      `json:"uuid"`
+	Name       string      `json:"name"`
+	Slug       string      `json:"slug"`
+	FullName   string      `json:"full_name"`
+	IsPrivate  bool        `json:"is_private"`
+	Workspace  Workspace   `json:"workspace"`
+	MainBranch *Branch     `json:"mainbranch,omitempty"`
+	Parent     *Repository `json:"parent,omitempty"`
+	Links      Links       `json:"links"`
+}
+
+// PullRequestEndpoint is the source or destination of a pull request
+type PullRequestEndpoint struct {
+	Branch     Branch      `json:"branch"`
+	Commit     Commit      `json:"commit"`
+	Repository *Repository `json:"repository,omitempty"`
+}
+
+// PullRequest is a Bitbucket pull request
+type PullRequest struct {
+	ID          int                 `json:"id"`
+	Title       string              `json:"title"`
+	State       string              `json:"state"`
+	Author      Account             `json:"author"`
+	Source      PullRequestEndpoint `json:"source"`
+	Destination PullRequestEndpoint `json:"destination"`
+	Links       Links               `json:"links"`
+}
+
+// hook is a Bitbucket repository webhook
+type hook struct {
+	UUID        string   `json:"uuid,omitempty"`
+	URL         string   `json:"url"`
+	Description string   `json:"description"`
+	Active      bool     `json:"active"`
+	Secret      string   `json:"secret,omitempty"`
+	Events      []string `json:"events"`
+}
+
+// cloneURL returns the HTTPS clone URL of the repository
+func (r *Repository) cloneURL() string {
+	for _, l := range r.Links.Clone {
+		if l.Name == "https" {
+			return l.Href
+		}
+	}
+	return ""
+}
@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package credentials
+
+import (
+	"net/http"
+
+	"github.com/go-git/go-git/v5"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"golang.org/x/oauth2"
+
+	provifv1 "github.com/mindersec/minder/pkg/providers/v1"
+)
+
+// bitbucketTokenUsername is the username Bitbucket expects when
+// authenticating git operations with an OAuth or access token.
+const bitbucketTokenUsername = "x-token-auth"
+
+// BitbucketTokenCredential is a credential that uses a token
+type BitbucketTokenCredential struct {
+	token string
+}
+
+// Ensure that the BitbucketTokenCredential implements the BitbucketCredential interface
+var _ provifv1.BitbucketCredential = (*BitbucketTokenCredential)(nil)
+
+// NewBitbucketTokenCredential creates a new BitbucketTokenCredential from the token
+func NewBitbucketTokenCredential(token string) *BitbucketTokenCredential {
+	return &BitbucketTokenCredential{
+		token: token,
+	}
+}
+
+// SetAuthorizationHeader sets the authorization header on the request
+func (t *BitbucketTokenCredential) SetAuthorizationHeader(req *http.Request) {
+	req.Header.Set("Authorization", "Bearer "+t.token)
+}
+
+// AddToPushOptions adds the credential to the git push options
+func (t *BitbucketTokenCredential) AddToPushOptions(options *git.PushOptions, _ string) {
+	options.Auth = &githttp.BasicAuth{
+		Username: bitbucketTokenUsername,
+		Password: t.token,
+	}
+}
+
+// AddToCloneOptions adds the credential to the git clone options
+func (t *BitbucketTokenCredential) AddToCloneOptions(options *git.CloneOptions) {
+	options.Auth = &githttp.BasicAuth{
+		Username: bitbucketTokenUsername,
+		Password: t.token,
+	}
+}
+
+// GetAsOAuth2TokenSource returns the token as an OAuth2 token source
+func (t *BitbucketTokenCredential) GetAsOAuth2TokenSource() oauth2.TokenSource {
+	return oauth2.StaticTokenSource(
+		&oauth2.Token{AccessToken: t.token},
+	)
+}
+
+// GetCredential implements the DirectCredential interface
+func (t *BitbucketTokenCredential) GetCredential() string {
+	return t.token
+}
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package credentials
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"golang.org/x/oauth2"
+
+	provifv1 "github.com/mindersec/minder/pkg/providers/v1"
+)
+
+// OCIRegistryCredential is a credential used to authenticate to an OCI registry.
+// If a username is set, the token is used as the password for basic
+// authentication, otherwise it is sent as a bearer token.
+type OCIRegistryCredential struct {
+	username string
+	token    string
+}
+
+// Ensure that the OCIRegistryCredential implements the OCICredential interface
+var _ provifv1.OCICredential = (*OCIRegistryCredential)(nil)
+
+// NewOCIRegistryCredential creates a new OCIRegistryCredential
+func NewOCIRegistryCredential(username, token string) *OCIRegistryCredential {
+	return &OCIRegistryCredential{
+		username: username,
+		token:    token,
+	}
+}
+
+// SetAuthorizationHeader sets the authorization header on the request
+func (c *OCIRegistryCredential) SetAuthorizationHeader(req *http.Request) {
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.token)
+		return
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+}
+
+// GetAsOAuth2TokenSource returns the token as an OAuth2 token source
+func (c *OCIRegistryCredential) GetAsOAuth2TokenSource() oauth2.TokenSource {
+	return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: c.token})
+}
+
+// GetAsContainerAuthenticator returns the credential as a container registry authenticator
+func (c *OCIRegistryCredential) GetAsContainerAuthenticator() authn.Authenticator {
+	if c.username == "" && c.token == "" {
+		return authn.Anonymous
+	}
+	if c.username != "" {
+		return &authn.Basic{
+			Username: c.username,
+			Password: c.token,
+		}
+	}
+	return &authn.Bearer{Token: c.token}
+}
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package credentials
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOCIRegistryCredentialSetAuthorizationHeader(t *testing.T) {
+	t.Parallel()
+
+	req := &http.Request{
+		Header: http.Header{},
+	}
+	NewOCIRegistryCredential("", "test_token").SetAuthorizationHeader(req)
+	require.Equal(t, "Bearer test_token", req.Header.Get("Authorization"))
+
+	req = &http.Request{
+		Header: http.Header{},
+	}
+	NewOCIRegistryCredential("robot", "test_token").SetAuthorizationHeader(req)
+	user, pass, ok := req.BasicAuth()
+	require.True(t, ok)
+	require.Equal(t, "robot", user)
+	require.Equal(t, "test_token", pass)
+}
+
+func TestOCIRegistryCredentialGetAsContainerAuthenticator(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, &authn.Bearer{Token: "test_token"},
+		NewOCIRegistryCredential("", "test_token").GetAsContainerAuthenticator())
+	require.Equal(t, &authn.Basic{Username: "robot", Password: "test_token"},
+		NewOCIRegistryCredential("robot", "test_token").GetAsContainerAuthenticator())
+	require.Equal(t, authn.Anonymous,
+		NewOCIRegistryCredential("", "").GetAsContainerAuthenticator())
+}
@@ -13,6 +13,7 @@ import (
 	"net/http"
 	"net/url"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -535,6 +536,62 @@ func (c *GitHub) UpdateBranchProtection(
 	return err
 }
 
+// ListRulesets returns the rulesets of a repository. If includeParents is
+// true, the rulesets configured at the organization level which apply to the
+// repository are returned as well. The rules of the rulesets are not included.
+func (c *GitHub) ListRulesets(ctx context.Context, owner, repo string, includeParents bool) ([]*github.Ruleset, error) {
+	rulesets, _, err := c.client.Repositories.GetAllRulesets(ctx, owner, repo, includeParents)
+	if err != nil {
+		return nil, fmt.Errorf("error listing rulesets: %w", err)
+	}
+	return rulesets, nil
+}
+
+// GetRuleset returns a ruleset along with its rules. The ruleset can be
+// configured at the repository or at the organization level.
+func (c *GitHub) GetRuleset(ctx context.Context, owner, repo string, id int64) (*github.Ruleset, error) {
+	ruleset, _, err := c.client.Repositories.GetRuleset(ctx, owner, repo, id, true)
+	if err != nil {
+		return nil, fmt.Errorf("error getting ruleset: %w", err)
+	}
+	return ruleset, nil
+}
+
+// GetRulesForBranch returns the rules which apply to a branch, whichever
+// ruleset they come from
+func (c *GitHub) GetRulesForBranch(ctx context.Context, owner, repo, branch string) ([]*github.RepositoryRule, error) {
+	if branch == "" {
+		return nil, ErrBranchNameEmpty
+	}
+	rules, _, err := c.client.Repositories.GetRulesForBranch(ctx, owner, repo, branch)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rules for branch: %w", err)
+	}
+	return rules, nil
+}
+
+// CreateRuleset creates a repository ruleset
+func (c *GitHub) CreateRuleset(
+	ctx context.Context, owner, repo string, ruleset *github.Ruleset,
+) (*github.Ruleset, error) {
+	created, _, err := c.client.Repositories.CreateRuleset(ctx, owner, repo, ruleset)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ruleset: %w", err)
+	}
+	return created, nil
+}
+
+// UpdateRuleset updates a repository ruleset
+func (c *GitHub) UpdateRuleset(
+	ctx context.Context, owner, repo string, id int64, ruleset *github.Ruleset,
+) (*github.Ruleset, error) {
+	updated, _, err := c.client.Repositories.UpdateRuleset(ctx, owner, repo, id, ruleset)
+	if err != nil {
+		return nil, fmt.Errorf("error updating ruleset: %w", err)
+	}
+	return updated, nil
+}
+
 // GetBaseURL returns the base URL for the REST API.
 func (c *GitHub) GetBaseURL() string {
 	return c.client.BaseURL.String()
@@ -717,6 +774,217 @@ func (c *GitHub) ListPullRequests(
 	return prs, nil
 }
 
+// GetCommitAuthor returns the name and primary e-mail of the acting user.
+// The login is used as the name if the user has not set one.
+func (c *GitHub) GetCommitAuthor(ctx context.Context) (string, string, error) {
+	email, err := c.GetPrimaryEmail(ctx)
+	if err != nil {
+		return "", "", fmt.Errorf("cannot get primary email: %w", err)
+	}
+
+	// we ignore errors here, as we can still create a commit without a name
+	name, _ := c.GetName(ctx)
+	if name == "" {
+		name, _ = c.GetLogin(ctx)
+	}
+	return name, email, nil
+}
+
+// FindChangeRequest returns the open pull request from the head branch
+func (c *GitHub) FindChangeRequest(
+	ctx context.Context, repo *minderv1.Repository, head string,
+) (*provifv1.ChangeRequest, error) {
+	opts := &github.PullRequestListOptions{
+		// TODO: This is not working as expected, need to fix this
+		// Head: fmt.Sprintf("%s:%s", repo.GetOwner(), head),
+		State: "open",
+	}
+	openPrs, err := c.ListPullRequests(ctx, repo.GetOwner(), repo.GetName(), opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, pr := range openPrs {
+		if pr.GetHead().GetRef() == head {
+			return pullRequestToChangeRequest(pr), nil
+		}
+	}
+	return nil, nil
+}
+
+// CreateChangeRequest opens a pull request from the head branch
+func (c *GitHub) CreateChangeRequest(
+	ctx context.Context, repo *minderv1.Repository, title, body, head, base string,
+) (*provifv1.ChangeRequest, error) {
+	pr, err := c.CreatePullRequest(ctx, repo.GetOwner(), repo.GetName(), title, body, head, base)
+	if err != nil {
+		return nil, err
+	}
+	return pullRequestToChangeRequest(pr), nil
+}
+
+// UpdateChangeRequest updates the title and body of a pull request
+func (c *GitHub) UpdateChangeRequest(
+	ctx context.Context, repo *minderv1.Repository, number int, title, body string,
+) error {
+	_, _, err := c.client.PullRequests.Edit(ctx, repo.GetOwner(), repo.GetName(), number, &github.PullRequest{
+		Title: github.String(title),
+		Body:  github.String(body),
+	})
+	return err
+}
+
+// CloseChangeRequest closes a pull request
+func (c *GitHub) CloseChangeRequest(ctx context.Context, repo *minderv1.Repository, number int) error {
+	_, err := c.ClosePullRequest(ctx, repo.GetOwner(), repo.GetName(), number)
+	return err
+}
+
+func pullRequestToChangeRequest(pr *github.PullRequest) *provifv1.ChangeRequest {
+	return &provifv1.ChangeRequest{
+		Number: pr.GetNumber(),
+		Title:  pr.GetTitle(),
+		Body:   pr.GetBody(),
+	}
+}
+
+// GetReviewerID returns the ID of the acting user
+func (c *GitHub) GetReviewerID(ctx context.Context) (int64, error) {
+	return c.GetUserId(ctx)
+}
+
+// ListPullRequestReviews returns the reviews of a pull request
+func (c *GitHub) ListPullRequestReviews(
+	ctx context.Context, pr *provifv1.PullRequestReference,
+) ([]*provifv1.PullRequestReview, error) {
+	var reviews []*provifv1.PullRequestReview
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		page, resp, err := c.client.PullRequests.ListReviews(ctx, pr.Owner, pr.Repo, pr.Number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error listing reviews for PR %s/%s/%d: %w", pr.Owner, pr.Repo, pr.Number, err)
+		}
+		for _, r := range page {
+			reviews = append(reviews, &provifv1.PullRequestReview{
+				ID:             strconv.FormatInt(r.GetID(), 10),
+				AuthorID:       r.GetUser().GetID(),
+				Body:           r.GetBody(),
+				CommitSHA:      r.GetCommitID(),
+				RequestChanges: r.GetState() == "CHANGES_REQUESTED",
+				Dismissed:      r.GetState() == "DISMISSED",
+			})
+		}
+		if resp.NextPage == 0 {
+			return reviews, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// SubmitPullRequestReview creates a review on a pull request
+func (c *GitHub) SubmitPullRequestReview(
+	ctx context.Context, pr *provifv1.PullRequestReference, review *provifv1.PullRequestReview,
+) (*provifv1.PullRequestReview, error) {
+	event := "COMMENT"
+	if review.RequestChanges {
+		event = "REQUEST_CHANGES"
+	}
+
+	req := &github.PullRequestReviewRequest{
+		CommitID: github.String(pr.CommitSHA),
+		Event:    github.String(event),
+		Comments: make([]*github.DraftReviewComment, 0, len(review.Comments)),
+	}
+	if review.Body != "" {
+		req.Body = github.String(review.Body)
+	}
+	for _, comment := range review.Comments {
+		draft := &github.DraftReviewComment{
+			Path: github.String(comment.Path),
+			Body: github.String(comment.Body),
+			Line: github.Int(comment.Line),
+		}
+		if comment.StartLine > 0 && comment.StartLine < comment.Line {
+			draft.StartLine = github.Int(comment.StartLine)
+		}
+		req.Comments = append(req.Comments, draft)
+	}
+
+	r, err := c.CreateReview(ctx, pr.Owner, pr.Repo, pr.Number, req)
+	if err != nil {
+		return nil, err
+	}
+
+	submitted := *review
+	submitted.ID = strconv.FormatInt(r.GetID(), 10)
+	submitted.AuthorID = r.GetUser().GetID()
+	submitted.CommitSHA = pr.CommitSHA
+	return &submitted, nil
+}
+
+// DismissPullRequestReview dismisses a review of a pull request
+func (c *GitHub) DismissPullRequestReview(
+	ctx context.Context, pr *provifv1.PullRequestReference, reviewID string, message string,
+) error {
+	id, err := strconv.ParseInt(reviewID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid review ID %s: %w", reviewID, err)
+	}
+
+	_, err = c.DismissReview(ctx, pr.Owner, pr.Repo, pr.Number, id, &github.PullRequestReviewDismissalRequest{
+		Message: github.String(message),
+	})
+	return err
+}
+
+// ListPullRequestComments returns the comments of a pull request
+func (c *GitHub) ListPullRequestComments(
+	ctx context.Context, pr *provifv1.PullRequestReference,
+) ([]*provifv1.PullRequestComment, error) {
+	comments, err := c.ListIssueComments(ctx, pr.Owner, pr.Repo, pr.Number, &github.IssueListCommentsOptions{
+		Sort:      github.String("updated"),
+		Direction: github.String("asc"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*provifv1.PullRequestComment, 0, len(comments))
+	for _, comment := range comments {
+		out = append(out, issueCommentToPullRequestComment(comment))
+	}
+	return out, nil
+}
+
+// CreatePullRequestComment creates a comment on a pull request
+func (c *GitHub) CreatePullRequestComment(
+	ctx context.Context, pr *provifv1.PullRequestReference, body string,
+) (*provifv1.PullRequestComment, error) {
+	comment, err := c.CreateIssueComment(ctx, pr.Owner, pr.Repo, pr.Number, body)
+	if err != nil {
+		return nil, err
+	}
+	return issueCommentToPullRequestComment(comment), nil
+}
+
+// UpdatePullRequestComment updates a comment on a pull request
+func (c *GitHub) UpdatePullRequestComment(
+	ctx context.Context, pr *provifv1.PullRequestReference, commentID string, body string,
+) error {
+	id, err := strconv.ParseInt(commentID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid comment ID %s: %w", commentID, err)
+	}
+	return c.UpdateIssueComment(ctx, pr.Owner, pr.Repo, id, body)
+}
+
+func issueCommentToPullRequestComment(comment *github.IssueComment) *provifv1.PullRequestComment {
+	return &provifv1.PullRequestComment{
+		ID:       strconv.FormatInt(comment.GetID(), 10),
+		AuthorID: comment.GetUser().GetID(),
+		Body:     comment.GetBody(),
+	}
+}
+
 // CreateIssueComment creates a comment on a pull request or an issue
 func (c *GitHub) CreateIssueComment(
 	ctx context.Context, owner, repo string, number int, comment string,
@@ -1722,3 +1722,32 @@ func TestCloseSecurityAdvisory(t *testing.T) {
 		})
 	}
 }
+
+func TestListPullRequestReviews(t *testing.T) {
+	t.Parallel()
+
+	th := setupTest(t)
+	th.gh.client = github.NewClient(&http.Client{
+		Transport: &mockTransport{
+			response: &http.Response{
+				StatusCode: http.StatusOK,
+				Body: io.NopCloser(strings.NewReader(`[
+					{"id": 1, "user": {"id": 10}, "body": "first", "commit_id": "abc", "state": "CHANGES_REQUESTED"},
+					{"id": 2, "user": {"id": 10}, "body": "second", "commit_id": "def", "state": "DISMISSED"}
+				]`)),
+				Header: make(http.Header),
+			},
+		},
+	})
+
+	reviews, err := th.gh.ListPullRequestReviews(context.Background(), &provifv1.PullRequestReference{
+		Owner:  "test-owner",
+		Repo:   "test-repo",
+		Number: 1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*provifv1.PullRequestReview{
+		{ID: "1", AuthorID: 10, Body: "first", CommitSHA: "abc", RequestChanges: true},
+		{ID: "2", AuthorID: 10, Body: "second", CommitSHA: "def", Dismissed: true},
+	}, reviews)
+}
@@ -0,0 +1,217 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package properties
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	go_github "github.com/google/go-github/v63/github"
+	"github.com/rs/zerolog"
+
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/mindersec/minder/pkg/entities/properties"
+	v1 "github.com/mindersec/minder/pkg/providers/v1"
+)
+
+// Organization Properties
+const (
+	// OrgPropertyLogin represents the github organization login
+	OrgPropertyLogin = "github/org_login"
+	// OrgPropertyTwoFactorRequirement is true if the organization requires 2FA for its members
+	OrgPropertyTwoFactorRequirement = "github/two_factor_requirement_enabled"
+	// OrgPropertyDefaultRepoPermission represents the base permission of members on the organization repositories
+	OrgPropertyDefaultRepoPermission = "github/default_repository_permission"
+	// OrgPropertyMembersCanCreateRepos is true if members can create repositories
+	OrgPropertyMembersCanCreateRepos = "github/members_can_create_repositories"
+	// OrgPropertyMembersCanCreatePublicRepos is true if members can create public repositories
+	OrgPropertyMembersCanCreatePublicRepos = "github/members_can_create_public_repositories"
+	// OrgPropertyMembersCanForkPrivateRepos is true if members can fork private repositories
+	OrgPropertyMembersCanForkPrivateRepos = "github/members_can_fork_private_repositories"
+	// OrgPropertyWebCommitSignoffRequired is true if commits made through the web UI must be signed off
+	OrgPropertyWebCommitSignoffRequired = "github/web_commit_signoff_required"
+	// OrgPropertyAdvancedSecurityForNewRepos is true if GitHub Advanced Security is enabled for new repositories
+	OrgPropertyAdvancedSecurityForNewRepos = "github/advanced_security_enabled_for_new_repositories"
+	// OrgPropertyDependabotAlertsForNewRepos is true if Dependabot alerts are enabled for new repositories
+	OrgPropertyDependabotAlertsForNewRepos = "github/dependabot_alerts_enabled_for_new_repositories"
+	// OrgPropertySecretScanningForNewRepos is true if secret scanning is enabled for new repositories
+	OrgPropertySecretScanningForNewRepos = "github/secret_scanning_enabled_for_new_repositories"
+	// OrgPropertySecretScanningPushProtectionForNewRepos is true if secret scanning push protection
+	// is enabled for new repositories
+	OrgPropertySecretScanningPushProtectionForNewRepos = "github/secret_scanning_push_protection_enabled_for_new_repositories"
+	// OrgPropertyDefaultWorkflowPermissions represents the default permissions of the GITHUB_TOKEN in workflows
+	OrgPropertyDefaultWorkflowPermissions = "github/default_workflow_permissions"
+	// OrgPropertyCanApprovePullRequestReviews is true if workflows can approve pull requests
+	OrgPropertyCanApprovePullRequestReviews = "github/can_approve_pull_request_reviews"
+)
+
+// OrganizationFetcher is a property fetcher for github organizations
+type OrganizationFetcher struct {
+	propertyFetcherBase
+}
+
+// NewOrganizationFetcher creates a new OrganizationFetcher
+func NewOrganizationFetcher() *OrganizationFetcher {
+	return &OrganizationFetcher{
+		propertyFetcherBase: propertyFetcherBase{
+			propertyOrigins: []propertyOrigin{
+				{
+					keys: []string{
+						// general entity
+						properties.PropertyName,
+						properties.PropertyUpstreamID,
+						// github-specific
+						OrgPropertyLogin,
+						OrgPropertyTwoFactorRequirement,
+						OrgPropertyDefaultRepoPermission,
+						OrgPropertyMembersCanCreateRepos,
+						OrgPropertyMembersCanCreatePublicRepos,
+						OrgPropertyMembersCanForkPrivateRepos,
+						OrgPropertyWebCommitSignoffRequired,
+						OrgPropertyAdvancedSecurityForNewRepos,
+						OrgPropertyDependabotAlertsForNewRepos,
+						OrgPropertySecretScanningForNewRepos,
+						OrgPropertySecretScanningPushProtectionForNewRepos,
+					},
+					wrapper: getOrganizationWrapper,
+				},
+				{
+					keys: []string{
+						OrgPropertyDefaultWorkflowPermissions,
+						OrgPropertyCanApprovePullRequestReviews,
+					},
+					wrapper: getOrganizationWorkflowPermissionsWrapper,
+				},
+			},
+			operationalProperties: []string{},
+		},
+	}
+}
+
+// GetName returns the name of the organization, which is its login
+func (_ *OrganizationFetcher) GetName(props *properties.Properties) (string, error) {
+	login, err := props.GetProperty(OrgPropertyLogin).AsString()
+	if err != nil {
+		return "", fmt.Errorf("failed to get organization login: %w", err)
+	}
+	if login == "" {
+		return "", errors.New("missing required org-login property value")
+	}
+
+	return login, nil
+}
+
+// GitHubOrganizationToMap converts a github organization to a map
+func GitHubOrganizationToMap(org *go_github.Organization) map[string]any {
+	return map[string]any{
+		// general entity
+		properties.PropertyUpstreamID: properties.NumericalValueToUpstreamID(org.GetID()),
+		properties.PropertyName:       org.GetLogin(),
+		// github-specific
+		OrgPropertyLogin:                                   org.GetLogin(),
+		OrgPropertyTwoFactorRequirement:                    org.GetTwoFactorRequirementEnabled(),
+		OrgPropertyDefaultRepoPermission:                   org.GetDefaultRepoPermission(),
+		OrgPropertyMembersCanCreateRepos:                   org.GetMembersCanCreateRepos(),
+		OrgPropertyMembersCanCreatePublicRepos:             org.GetMembersCanCreatePublicRepos(),
+		OrgPropertyMembersCanForkPrivateRepos:              org.GetMembersCanForkPrivateRepos(),
+		OrgPropertyWebCommitSignoffRequired:                org.GetWebCommitSignoffRequired(),
+		OrgPropertyAdvancedSecurityForNewRepos:             org.GetAdvancedSecurityEnabledForNewRepos(),
+		OrgPropertyDependabotAlertsForNewRepos:             org.GetDependabotAlertsEnabledForNewRepos(),
+		OrgPropertySecretScanningForNewRepos:               org.GetSecretScanningEnabledForNewRepos(),
+		OrgPropertySecretScanningPushProtectionForNewRepos: org.GetSecretScanningPushProtectionEnabledForNewRepos(),
+	}
+}
+
+func getOrganizationWrapper(
+	ctx context.Context, ghCli *go_github.Client, _ bool, getByProps *properties.Properties,
+) (map[string]any, error) {
+	org, err := getOrganization(ctx, ghCli, getByProps)
+	if err != nil {
+		return nil, err
+	}
+
+	return GitHubOrganizationToMap(org), nil
+}
+
+func getOrganizationWorkflowPermissionsWrapper(
+	ctx context.Context, ghCli *go_github.Client, _ bool, getByProps *properties.Properties,
+) (map[string]any, error) {
+	login := getByProps.GetProperty(OrgPropertyLogin).GetString()
+	if login == "" {
+		org, err := getOrganization(ctx, ghCli, getByProps)
+		if err != nil {
+			return nil, err
+		}
+		login = org.GetLogin()
+	}
+
+	perms, result, err := ghCli.Actions.GetDefaultWorkflowPermissionsInOrganization(ctx, login)
+	if err != nil {
+		// reading the workflow permissions requires administration access to
+		// the organization, which not every token has. The properties are then
+		// left unset rather than failing the whole entity.
+		if result != nil && (result.StatusCode == http.StatusNotFound || result.StatusCode == http.StatusForbidden) {
+			zerolog.Ctx(ctx).Debug().Err(err).Str("org", login).Msg("cannot read default workflow permissions")
+			return map[string]any{}, nil
+		}
+		return nil, fmt.Errorf("failed to fetch default workflow permissions: %w", err)
+	}
+
+	return map[string]any{
+		OrgPropertyDefaultWorkflowPermissions:   perms.GetDefaultWorkflowPermissions(),
+		OrgPropertyCanApprovePullRequestReviews: perms.GetCanApprovePullRequestReviews(),
+	}, nil
+}
+
+// getOrganization fetches the organization by its upstream ID if known,
+// falling back to the login or the entity name
+func getOrganization(
+	ctx context.Context, ghCli *go_github.Client, getByProps *properties.Properties,
+) (*go_github.Organization, error) {
+	var org *go_github.Organization
+	var result *go_github.Response
+	var err error
+
+	if upstreamID, idErr := getByProps.GetProperty(properties.PropertyUpstreamID).AsInt64(); idErr == nil {
+		org, result, err = ghCli.Organizations.GetByID(ctx, upstreamID)
+	} else {
+		login := getByProps.GetProperty(OrgPropertyLogin).GetString()
+		if login == "" {
+			login = getByProps.GetProperty(properties.PropertyName).GetString()
+		}
+		if login == "" {
+			return nil, errors.New("missing required properties, either upstream ID, org-login or name")
+		}
+		org, result, err = ghCli.Organizations.Get(ctx, login)
+	}
+	if err != nil {
+		if result != nil && result.StatusCode == http.StatusNotFound {
+			return nil, v1.ErrEntityNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch organization: %w", err)
+	}
+
+	return org, nil
+}
+
+// EntityInstanceV1FromOrganizationProperties creates a new EntityInstance from the given properties
+func EntityInstanceV1FromOrganizationProperties(props *properties.Properties) (*minderv1.EntityInstance, error) {
+	_, err := props.GetProperty(properties.PropertyUpstreamID).AsString()
+	if err != nil {
+		return nil, fmt.Errorf("upstream ID not found or invalid: %w", err)
+	}
+
+	login, err := props.GetProperty(OrgPropertyLogin).AsString()
+	if err != nil {
+		return nil, fmt.Errorf("organization login not found or invalid: %w", err)
+	}
+
+	return &minderv1.EntityInstance{
+		Type:       minderv1.Entity_ENTITY_ORGANIZATION,
+		Name:       login,
+		Properties: props.ToProtoStruct(),
+	}, nil
+}
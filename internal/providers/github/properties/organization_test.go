@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package properties
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	go_github "github.com/google/go-github/v63/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mindersec/minder/pkg/entities/properties"
+	v1 "github.com/mindersec/minder/pkg/providers/v1"
+)
+
+const orgJSON = `{
+	"login": "testorg",
+	"id": 13579,
+	"two_factor_requirement_enabled": true,
+	"default_repository_permission": "read",
+	"members_can_create_repositories": false,
+	"web_commit_signoff_required": true,
+	"secret_scanning_enabled_for_new_repositories": true
+}`
+
+func newOrgTestClient(t *testing.T, workflowPermsStatus int) *go_github.Client {
+	t.Helper()
+
+	mocksrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body string
+		switch r.URL.Path {
+		case "/orgs/testorg", "/organizations/13579":
+			body = orgJSON
+		case "/orgs/testorg/actions/permissions/workflow":
+			if workflowPermsStatus != http.StatusOK {
+				w.WriteHeader(workflowPermsStatus)
+				return
+			}
+			body = `{"default_workflow_permissions": "read", "can_approve_pull_request_reviews": false}`
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write([]byte(body))
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(mocksrv.Close)
+
+	cli := go_github.NewClient(mocksrv.Client())
+	baseURL, err := url.Parse(mocksrv.URL + "/")
+	require.NoError(t, err)
+	cli.BaseURL = baseURL
+	return cli
+}
+
+func TestOrganizationFetcherGetName(t *testing.T) {
+	t.Parallel()
+
+	fetcher := NewOrganizationFetcher()
+
+	props, err := properties.NewProperties(map[string]any{OrgPropertyLogin: "testorg"})
+	require.NoError(t, err)
+	name, err := fetcher.GetName(props)
+	require.NoError(t, err)
+	require.Equal(t, "testorg", name)
+
+	props, err = properties.NewProperties(map[string]any{})
+	require.NoError(t, err)
+	_, err = fetcher.GetName(props)
+	require.Error(t, err)
+}
+
+func TestGetOrganizationWrapper(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		getByProps map[string]any
+		wantErr    error
+	}{
+		{
+			name:       "by upstream ID",
+			getByProps: map[string]any{properties.PropertyUpstreamID: "13579"},
+		},
+		{
+			name:       "by name",
+			getByProps: map[string]any{properties.PropertyName: "testorg"},
+		},
+		{
+			name:       "unknown organization",
+			getByProps: map[string]any{OrgPropertyLogin: "otherorg"},
+			wantErr:    v1.ErrEntityNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			getByProps, err := properties.NewProperties(tt.getByProps)
+			require.NoError(t, err)
+
+			got, err := getOrganizationWrapper(context.Background(), newOrgTestClient(t, http.StatusOK), true, getByProps)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "13579", got[properties.PropertyUpstreamID])
+			require.Equal(t, "testorg", got[properties.PropertyName])
+			require.Equal(t, "testorg", got[OrgPropertyLogin])
+			require.Equal(t, true, got[OrgPropertyTwoFactorRequirement])
+			require.Equal(t, "read", got[OrgPropertyDefaultRepoPermission])
+			require.Equal(t, false, got[OrgPropertyMembersCanCreateRepos])
+			require.Equal(t, true, got[OrgPropertySecretScanningForNewRepos])
+		})
+	}
+}
+
+func TestGetOrganizationWorkflowPermissionsWrapper(t *testing.T) {
+	t.Parallel()
+
+	getByProps, err := properties.NewProperties(map[string]any{OrgPropertyLogin: "testorg"})
+	require.NoError(t, err)
+
+	got, err := getOrganizationWorkflowPermissionsWrapper(
+		context.Background(), newOrgTestClient(t, http.StatusOK), true, getByProps)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		OrgPropertyDefaultWorkflowPermissions:   "read",
+		OrgPropertyCanApprovePullRequestReviews: false,
+	}, got)
+
+	// a token without administration access leaves the properties unset
+	got, err = getOrganizationWorkflowPermissionsWrapper(
+		context.Background(), newOrgTestClient(t, http.StatusForbidden), true, getByProps)
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
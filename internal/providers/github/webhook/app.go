@@ -161,6 +161,13 @@ func HandleGitHubAppWebhook(
 		case "installation_repositories":
 			wes.Accepted = true
 			results, processingErr = processInstallationRepositoriesAppEvent(ctx, store, rawWBPayload)
+		case "organization":
+			wes.Accepted = true
+			var res *processingResult
+			res, processingErr = processOrganizationEvent(ctx, rawWBPayload)
+			if res != nil {
+				results = []*processingResult{res}
+			}
 		default:
 			l.Info().Msgf("webhook event %s not handled", wes.Typ)
 		}
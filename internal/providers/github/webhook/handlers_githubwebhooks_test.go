@@ -3202,6 +3202,81 @@ func (s *UnitTestSuite) TestHandleGitHubAppWebHook() {
 			},
 		},
 
+		{
+			name: "organization renamed",
+			// https://docs.github.com/en/webhooks/webhook-events-and-payloads#organization
+			event: "organization",
+			// https://pkg.go.dev/github.com/google/go-github/v63/github#OrganizationEvent
+			payload: &github.OrganizationEvent{
+				Action: github.String("renamed"),
+				Organization: &github.Organization{
+					ID:    github.Int64(13579),
+					Login: github.String("stacklok"),
+				},
+				Installation: &github.Installation{
+					ID: github.Int64(54321),
+				},
+			},
+			topic:      constants.TopicQueueRefreshEntityAndEvaluate,
+			statusCode: http.StatusOK,
+			queued: func(t *testing.T, event string, ch <-chan *message.Message) {
+				t.Helper()
+
+				var evt entMsg.HandleEntityAndDoMessage
+
+				received := withTimeout(ch, timeout)
+				require.NotNilf(t, received, "no event received after waiting %s", timeout)
+				require.Equal(t, "12345", received.Metadata["id"])
+				require.Equal(t, event, received.Metadata["type"])
+
+				err := json.Unmarshal(received.Payload, &evt)
+				require.NoError(t, err)
+				require.Equal(t, "github", evt.Hint.ProviderImplementsHint)
+				require.Equal(t, v1.Entity_ENTITY_ORGANIZATION, evt.Entity.Type)
+				require.Equal(t, "13579", evt.Entity.GetByProps[properties.PropertyUpstreamID])
+
+				received = withTimeout(ch, timeout)
+				require.Nil(t, received)
+			},
+		},
+		{
+			name: "organization deleted",
+			// https://docs.github.com/en/webhooks/webhook-events-and-payloads#organization
+			event: "organization",
+			// https://pkg.go.dev/github.com/google/go-github/v63/github#OrganizationEvent
+			payload: &github.OrganizationEvent{
+				Action: github.String("deleted"),
+				Organization: &github.Organization{
+					ID:    github.Int64(13579),
+					Login: github.String("stacklok"),
+				},
+				Installation: &github.Installation{
+					ID: github.Int64(54321),
+				},
+			},
+			topic:      constants.TopicQueueGetEntityAndDelete,
+			statusCode: http.StatusOK,
+			queued: func(t *testing.T, event string, ch <-chan *message.Message) {
+				t.Helper()
+
+				var evt entMsg.HandleEntityAndDoMessage
+
+				received := withTimeout(ch, timeout)
+				require.NotNilf(t, received, "no event received after waiting %s", timeout)
+				require.Equal(t, "12345", received.Metadata["id"])
+				require.Equal(t, event, received.Metadata["type"])
+
+				err := json.Unmarshal(received.Payload, &evt)
+				require.NoError(t, err)
+				require.Equal(t, "github", evt.Hint.ProviderImplementsHint)
+				require.Equal(t, v1.Entity_ENTITY_ORGANIZATION, evt.Entity.Type)
+				require.Equal(t, "13579", evt.Entity.GetByProps[properties.PropertyUpstreamID])
+
+				received = withTimeout(ch, timeout)
+				require.Nil(t, received)
+			},
+		},
+
 		// garbage
 		{
 			name:  "garbage",
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/mindersec/minder/internal/db"
+	entityMessage "github.com/mindersec/minder/internal/entities/handlers/message"
+	ghprop "github.com/mindersec/minder/internal/providers/github/properties"
+	pb "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/mindersec/minder/pkg/entities/properties"
+	"github.com/mindersec/minder/pkg/eventer/constants"
+)
+
+type organizationEvent struct {
+	Action *string       `json:"action,omitempty"`
+	Org    *organization `json:"organization,omitempty"`
+}
+
+func (o *organizationEvent) GetAction() string {
+	if o.Action != nil {
+		return *o.Action
+	}
+	return ""
+}
+
+func (o *organizationEvent) GetOrg() *organization {
+	return o.Org
+}
+
+type organization struct {
+	ID    *int64  `json:"id,omitempty"`
+	Login *string `json:"login,omitempty"`
+}
+
+func (o *organization) GetID() int64 {
+	if o.ID != nil {
+		return *o.ID
+	}
+	return 0
+}
+
+func (o *organization) GetLogin() string {
+	if o.Login != nil {
+		return *o.Login
+	}
+	return ""
+}
+
+// processOrganizationEvent processes events related to changes to an
+// organization. Deleted organizations are removed, while any other change
+// (e.g. renames or membership changes) triggers a refresh of the
+// organization properties followed by an evaluation.
+func processOrganizationEvent(
+	ctx context.Context,
+	payload []byte,
+) (*processingResult, error) {
+	var event *organizationEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal organization event: %w", err)
+	}
+
+	if event.GetAction() == "" {
+		return nil, errors.New("organization event action not found")
+	}
+
+	if event.GetOrg() == nil {
+		return nil, errors.New("organization event organization not found")
+	}
+
+	if event.GetOrg().GetID() == 0 {
+		return nil, errors.New("organization event organization id not found")
+	}
+
+	lookByProps, err := properties.NewProperties(map[string]any{
+		properties.PropertyUpstreamID: properties.NumericalValueToUpstreamID(event.GetOrg().GetID()),
+		ghprop.OrgPropertyLogin:       event.GetOrg().GetLogin(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating organization properties: %w", err)
+	}
+
+	topic := constants.TopicQueueRefreshEntityAndEvaluate
+	if event.GetAction() == webhookActionEventDeleted {
+		topic = constants.TopicQueueGetEntityAndDelete
+	}
+
+	zerolog.Ctx(ctx).Info().
+		Str("org", event.GetOrg().GetLogin()).
+		Str("action", event.GetAction()).
+		Msgf("processing organization event => %s", topic)
+
+	return &processingResult{
+		topic: topic,
+		wrapper: entityMessage.NewEntityRefreshAndDoMessage().
+			WithEntity(pb.Entity_ENTITY_ORGANIZATION, lookByProps).
+			WithProviderImplementsHint(string(db.ProviderTypeGithub)),
+	}, nil
+}
@@ -105,6 +105,37 @@ func glRESTGet[T any](ctx context.Context, cli genericRESTClient, path string, o
 	return nil
 }
 
+// glRESTSend sends the body with the given method and decodes the response
+// into out, unless it's nil
+func glRESTSend(ctx context.Context, cli genericRESTClient, method string, path string, body any, out any) error {
+	req, err := cli.NewRequest(method, path, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := cli.Do(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to send request to '%s': %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		if resp.StatusCode == http.StatusNotFound {
+			return provifv1.ErrEntityNotFound
+		}
+		return fmt.Errorf("failed to send request to '%s': %s", path, resp.Status)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
 func getParsedURL(endpoint, path string) (*url.URL, error) {
 	base, err := url.Parse(endpoint)
 	if err != nil {
@@ -119,7 +150,9 @@ func getParsedURL(endpoint, path string) (*url.URL, error) {
 		return nil, fmt.Errorf("failed to parse path: %w", err)
 	}
 
-	u := base.JoinPath(parsedPathAndQuery.Path)
+	// The escaped path is joined, so escaped slashes in path parameters,
+	// e.g. branch names, are kept escaped.
+	u := base.JoinPath(parsedPathAndQuery.EscapedPath())
 
 	// These have already been escaped by the URL parser
 	u.RawQuery = parsedPathAndQuery.RawQuery
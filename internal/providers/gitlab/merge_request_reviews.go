@@ -0,0 +1,278 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/mindersec/minder/internal/util/ptr"
+	provifv1 "github.com/mindersec/minder/pkg/providers/v1"
+)
+
+// GitLab has no merge request reviews with a body and line comments, so a
+// review is modeled as a thread on the merge request, which carries the
+// overall body, plus one thread per line comment. Since threads are not tied
+// to a commit, the commit reviewed is recorded in a hidden marker, and line
+// threads are linked to the review thread with another marker. Dismissing a
+// review resolves all of its threads.
+const (
+	reviewCommitMarkerFmt = "<!-- minder: review-commit: %s -->"
+	reviewThreadMarkerFmt = "<!-- minder: review: %s -->"
+
+	// defaultReviewBody is used for reviews which only have line comments,
+	// as GitLab threads can't be empty
+	defaultReviewBody = "Minder reviewed the changes of this merge request."
+
+	positionTypeText = "text"
+
+	// suggestionFence is the start of a GitHub flavored markdown suggestion
+	suggestionFence = "```suggestion\n"
+)
+
+var reviewCommitMarkerRe = regexp.MustCompile(`\n*<!-- minder: review-commit: (\w*) -->$`)
+
+// GetReviewerID returns the ID of the authenticated user
+func (c *gitlabClient) GetReviewerID(ctx context.Context) (int64, error) {
+	user := &gitlab.User{}
+	if err := glRESTGet(ctx, c, "user", user); err != nil {
+		return 0, fmt.Errorf("cannot get authenticated user: %w", err)
+	}
+	return int64(user.ID), nil
+}
+
+// ListPullRequestReviews returns the reviews submitted on the merge request
+func (c *gitlabClient) ListPullRequestReviews(
+	ctx context.Context, pr *provifv1.PullRequestReference,
+) ([]*provifv1.PullRequestReview, error) {
+	discussions, err := c.listMergeRequestDiscussions(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews := make([]*provifv1.PullRequestReview, 0)
+	for _, d := range discussions {
+		if len(d.Notes) == 0 {
+			continue
+		}
+		first := d.Notes[0]
+		body, commitSHA, ok := parseReviewBody(first.Body)
+		if !ok {
+			continue
+		}
+		reviews = append(reviews, &provifv1.PullRequestReview{
+			ID:        d.ID,
+			AuthorID:  int64(first.Author.ID),
+			Body:      body,
+			CommitSHA: commitSHA,
+			Dismissed: first.Resolved,
+		})
+	}
+	return reviews, nil
+}
+
+// SubmitPullRequestReview opens a thread on the merge request with the body
+// of the review, and a thread on each of the commented lines. GitLab can't
+// request changes with a review, so such reviews are submitted as comments.
+func (c *gitlabClient) SubmitPullRequestReview(
+	ctx context.Context, pr *provifv1.PullRequestReference, review *provifv1.PullRequestReview,
+) (*provifv1.PullRequestReview, error) {
+	discussionsPath := mergeRequestReferencePath(pr, "discussions")
+
+	// line comments are positioned against the diff of the merge request
+	mr := &gitlab.MergeRequest{}
+	if len(review.Comments) > 0 {
+		if err := glRESTGet(ctx, c, mergeRequestReferencePath(pr), mr); err != nil {
+			return nil, fmt.Errorf("failed to get merge request: %w", err)
+		}
+	}
+
+	body := review.Body
+	if body == "" {
+		body = defaultReviewBody
+	}
+	thread := &gitlab.Discussion{}
+	if err := glRESTSend(ctx, c, http.MethodPost, discussionsPath, &gitlab.CreateMergeRequestDiscussionOptions{
+		Body: ptr.Ptr(body + "\n\n" + fmt.Sprintf(reviewCommitMarkerFmt, pr.CommitSHA)),
+	}, thread); err != nil {
+		return nil, fmt.Errorf("failed to create review thread: %w", err)
+	}
+
+	for _, comment := range review.Comments {
+		if err := glRESTSend(ctx, c, http.MethodPost, discussionsPath, &gitlab.CreateMergeRequestDiscussionOptions{
+			Body: ptr.Ptr(translateSuggestion(comment) + "\n\n" + fmt.Sprintf(reviewThreadMarkerFmt, thread.ID)),
+			Position: &gitlab.PositionOptions{
+				BaseSHA:      ptr.Ptr(mr.DiffRefs.BaseSha),
+				StartSHA:     ptr.Ptr(mr.DiffRefs.StartSha),
+				HeadSHA:      ptr.Ptr(mr.DiffRefs.HeadSha),
+				PositionType: ptr.Ptr(positionTypeText),
+				NewPath:      ptr.Ptr(comment.Path),
+				NewLine:      ptr.Ptr(comment.Line),
+			},
+		}, nil); err != nil {
+			return nil, fmt.Errorf("failed to comment on %s:%d: %w", comment.Path, comment.Line, err)
+		}
+	}
+
+	submitted := &provifv1.PullRequestReview{
+		ID:        thread.ID,
+		Body:      review.Body,
+		CommitSHA: pr.CommitSHA,
+		Comments:  review.Comments,
+	}
+	if len(thread.Notes) > 0 {
+		submitted.AuthorID = int64(thread.Notes[0].Author.ID)
+	}
+	return submitted, nil
+}
+
+// DismissPullRequestReview replies to the review thread with the message and
+// resolves the threads of the review
+func (c *gitlabClient) DismissPullRequestReview(
+	ctx context.Context, pr *provifv1.PullRequestReference, reviewID string, message string,
+) error {
+	threadPath := mergeRequestReferencePath(pr, "discussions", reviewID)
+	if err := glRESTSend(ctx, c, http.MethodPost, threadPath+"/notes", &gitlab.AddMergeRequestDiscussionNoteOptions{
+		Body: &message,
+	}, nil); err != nil {
+		return fmt.Errorf("failed to reply to review thread: %w", err)
+	}
+
+	discussions, err := c.listMergeRequestDiscussions(ctx, pr)
+	if err != nil {
+		return err
+	}
+
+	reviewMarker := fmt.Sprintf(reviewThreadMarkerFmt, reviewID)
+	for _, d := range discussions {
+		if len(d.Notes) == 0 || d.Notes[0].Resolved {
+			continue
+		}
+		if d.ID != reviewID && !strings.Contains(d.Notes[0].Body, reviewMarker) {
+			continue
+		}
+		if err := glRESTSend(ctx, c, http.MethodPut, mergeRequestReferencePath(pr, "discussions", d.ID),
+			&gitlab.ResolveMergeRequestDiscussionOptions{
+				Resolved: ptr.Ptr(true),
+			}, nil); err != nil {
+			return fmt.Errorf("failed to resolve thread %s: %w", d.ID, err)
+		}
+	}
+	return nil
+}
+
+// ListPullRequestComments returns the comments on the merge request, leaving
+// out system notes and the notes of threads
+func (c *gitlabClient) ListPullRequestComments(
+	ctx context.Context, pr *provifv1.PullRequestReference,
+) ([]*provifv1.PullRequestComment, error) {
+	query := url.Values{}
+	query.Set("sort", "asc")
+	query.Set("order_by", "updated_at")
+	query.Set("per_page", "100")
+
+	notes := []*gitlab.Note{}
+	if err := glRESTGet(ctx, c, mergeRequestReferencePath(pr, "notes")+"?"+query.Encode(), &notes); err != nil {
+		return nil, fmt.Errorf("failed to list merge request notes: %w", err)
+	}
+
+	comments := make([]*provifv1.PullRequestComment, 0, len(notes))
+	for _, note := range notes {
+		if note.System || note.Type != "" {
+			continue
+		}
+		comments = append(comments, noteToPullRequestComment(note))
+	}
+	return comments, nil
+}
+
+// CreatePullRequestComment adds a note to the merge request
+func (c *gitlabClient) CreatePullRequestComment(
+	ctx context.Context, pr *provifv1.PullRequestReference, body string,
+) (*provifv1.PullRequestComment, error) {
+	note := &gitlab.Note{}
+	if err := glRESTSend(ctx, c, http.MethodPost, mergeRequestReferencePath(pr, "notes"),
+		&gitlab.CreateMergeRequestNoteOptions{
+			Body: &body,
+		}, note); err != nil {
+		return nil, fmt.Errorf("failed to create merge request note: %w", err)
+	}
+	return noteToPullRequestComment(note), nil
+}
+
+// UpdatePullRequestComment replaces the body of a merge request note
+func (c *gitlabClient) UpdatePullRequestComment(
+	ctx context.Context, pr *provifv1.PullRequestReference, commentID string, body string,
+) error {
+	if err := glRESTSend(ctx, c, http.MethodPut, mergeRequestReferencePath(pr, "notes", commentID),
+		&gitlab.UpdateMergeRequestNoteOptions{
+			Body: &body,
+		}, nil); err != nil {
+		return fmt.Errorf("failed to update merge request note: %w", err)
+	}
+	return nil
+}
+
+func (c *gitlabClient) listMergeRequestDiscussions(
+	ctx context.Context, pr *provifv1.PullRequestReference,
+) ([]*gitlab.Discussion, error) {
+	discussions := []*gitlab.Discussion{}
+	if err := glRESTGet(ctx, c, mergeRequestReferencePath(pr, "discussions")+"?per_page=100", &discussions); err != nil {
+		return nil, fmt.Errorf("failed to list merge request threads: %w", err)
+	}
+	return discussions, nil
+}
+
+// mergeRequestReferencePath returns the path of a resource of the merge
+// request. The project is looked up by ID if the pull request properties
+// have it, or by its path otherwise.
+func mergeRequestReferencePath(pr *provifv1.PullRequestReference, resource ...string) string {
+	pid := pr.Properties.GetProperty(PullRequestProjectID).GetString()
+	if pid == "" {
+		pid = url.PathEscape(pr.Owner + "/" + pr.Repo)
+	}
+
+	mrPath := fmt.Sprintf("projects/%s/merge_requests/%d", pid, pr.Number)
+	for _, r := range resource {
+		mrPath += "/" + url.PathEscape(r)
+	}
+	return mrPath
+}
+
+// parseReviewBody returns the body of a review thread without the commit
+// marker, and the commit recorded in the marker. It returns false if the
+// body has no marker, i.e. the thread is not a review.
+func parseReviewBody(body string) (string, string, bool) {
+	m := reviewCommitMarkerRe.FindStringSubmatchIndex(body)
+	if m == nil {
+		return body, "", false
+	}
+	return body[:m[0]], body[m[2]:m[3]], true
+}
+
+// translateSuggestion converts multi-line GitHub suggestions, which replace
+// the lines from StartLine to Line, into GitLab ones, which are relative to
+// the commented line.
+func translateSuggestion(comment *provifv1.PullRequestReviewComment) string {
+	if comment.StartLine <= 0 || comment.StartLine >= comment.Line {
+		return comment.Body
+	}
+	return strings.ReplaceAll(comment.Body, suggestionFence,
+		fmt.Sprintf("```suggestion:-%d+0\n", comment.Line-comment.StartLine))
+}
+
+func noteToPullRequestComment(note *gitlab.Note) *provifv1.PullRequestComment {
+	return &provifv1.PullRequestComment{
+		ID:       strconv.Itoa(note.ID),
+		AuthorID: int64(note.Author.ID),
+		Body:     note.Body,
+	}
+}
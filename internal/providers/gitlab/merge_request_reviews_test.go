@@ -0,0 +1,218 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mindersec/minder/pkg/entities/properties"
+	provifv1 "github.com/mindersec/minder/pkg/providers/v1"
+)
+
+const testCommitSHA = "27d6810b861c81e8c61e09c651875f5a976781d1"
+
+func newTestPullRequestReference(t *testing.T) *provifv1.PullRequestReference {
+	t.Helper()
+
+	props, err := properties.NewProperties(map[string]any{
+		PullRequestProjectID: "42",
+	})
+	require.NoError(t, err)
+
+	return &provifv1.PullRequestReference{
+		Owner:      "group",
+		Repo:       "project",
+		Number:     7,
+		CommitSHA:  testCommitSHA,
+		Properties: props,
+	}
+}
+
+func TestMergeRequestReferencePath(t *testing.T) {
+	t.Parallel()
+
+	pr := newTestPullRequestReference(t)
+	require.Equal(t, "projects/42/merge_requests/7/notes/3", mergeRequestReferencePath(pr, "notes", "3"))
+
+	// without the project ID, the project is looked up by its path
+	pr.Properties = nil
+	require.Equal(t, "projects/group%2Fproject/merge_requests/7", mergeRequestReferencePath(pr))
+}
+
+func TestSubmitPullRequestReview(t *testing.T) {
+	t.Parallel()
+
+	var threads []map[string]any
+	mocksrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/projects/42/merge_requests/7":
+			_, err := w.Write([]byte(`{"iid": 7, "diff_refs": {"base_sha": "base", "head_sha": "head", "start_sha": "start"}}`))
+			assert.NoError(t, err)
+		case r.Method == http.MethodPost && r.URL.Path == "/projects/42/merge_requests/7/discussions":
+			var body map[string]any
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			threads = append(threads, body)
+			w.WriteHeader(http.StatusCreated)
+			_, err := w.Write([]byte(`{"id": "abc123", "notes": [{"id": 1, "author": {"id": 99}}]}`))
+			assert.NoError(t, err)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mocksrv.Close()
+
+	glc := newTestClient(mocksrv)
+	review, err := glc.SubmitPullRequestReview(context.Background(), newTestPullRequestReference(t),
+		&provifv1.PullRequestReview{
+			RequestChanges: true,
+			Comments: []*provifv1.PullRequestReviewComment{{
+				Path:      "package.json",
+				StartLine: 3,
+				Line:      5,
+				Body:      "```suggestion\nfixed\n```\n",
+			}},
+		})
+	require.NoError(t, err)
+	require.Equal(t, "abc123", review.ID)
+	require.Equal(t, int64(99), review.AuthorID)
+	require.Equal(t, testCommitSHA, review.CommitSHA)
+
+	require.Len(t, threads, 2)
+	require.Equal(t, defaultReviewBody+"\n\n<!-- minder: review-commit: "+testCommitSHA+" -->", threads[0]["body"])
+	require.Nil(t, threads[0]["position"])
+
+	require.Equal(t, "```suggestion:-2+0\nfixed\n```\n\n\n<!-- minder: review: abc123 -->", threads[1]["body"])
+	require.Equal(t, map[string]any{
+		"base_sha":      "base",
+		"head_sha":      "head",
+		"start_sha":     "start",
+		"position_type": "text",
+		"new_path":      "package.json",
+		"new_line":      float64(5),
+	}, threads[1]["position"])
+}
+
+func TestListPullRequestReviews(t *testing.T) {
+	t.Parallel()
+
+	mocksrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/projects/42/merge_requests/7/discussions", r.URL.Path)
+		_, err := w.Write([]byte(`[
+			{"id": "other", "notes": [{"id": 1, "body": "LGTM", "author": {"id": 1}}]},
+			{"id": "review", "notes": [{"id": 2, "body": "Found issues\n\n<!-- minder: review-commit: ` +
+			testCommitSHA + ` -->", "author": {"id": 99}, "resolved": true}]}
+		]`))
+		assert.NoError(t, err)
+	}))
+	defer mocksrv.Close()
+
+	glc := newTestClient(mocksrv)
+	reviews, err := glc.ListPullRequestReviews(context.Background(), newTestPullRequestReference(t))
+	require.NoError(t, err)
+	require.Equal(t, []*provifv1.PullRequestReview{{
+		ID:        "review",
+		AuthorID:  99,
+		Body:      "Found issues",
+		CommitSHA: testCommitSHA,
+		Dismissed: true,
+	}}, reviews)
+}
+
+func TestDismissPullRequestReview(t *testing.T) {
+	t.Parallel()
+
+	resolved := map[string]bool{}
+	var reply string
+	mocksrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/projects/42/merge_requests/7/discussions/review/notes":
+			var body map[string]any
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			reply, _ = body["body"].(string)
+			w.WriteHeader(http.StatusCreated)
+			_, err := w.Write([]byte(`{"id": 3}`))
+			assert.NoError(t, err)
+		case r.Method == http.MethodGet && r.URL.Path == "/projects/42/merge_requests/7/discussions":
+			_, err := w.Write([]byte(`[
+				{"id": "review", "notes": [{"id": 1, "body": "review"}]},
+				{"id": "line", "notes": [{"id": 2, "body": "fix this\n\n<!-- minder: review: review -->"}]},
+				{"id": "other", "notes": [{"id": 4, "body": "unrelated"}]}
+			]`))
+			assert.NoError(t, err)
+		case r.Method == http.MethodPut:
+			var body map[string]any
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, true, body["resolved"])
+			resolved[r.URL.Path] = true
+			_, err := w.Write([]byte(`{}`))
+			assert.NoError(t, err)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mocksrv.Close()
+
+	glc := newTestClient(mocksrv)
+	err := glc.DismissPullRequestReview(context.Background(), newTestPullRequestReference(t), "review", "outdated")
+	require.NoError(t, err)
+	require.Equal(t, "outdated", reply)
+	require.Equal(t, map[string]bool{
+		"/projects/42/merge_requests/7/discussions/review": true,
+		"/projects/42/merge_requests/7/discussions/line":   true,
+	}, resolved)
+}
+
+func TestPullRequestComments(t *testing.T) {
+	t.Parallel()
+
+	mocksrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/projects/42/merge_requests/7/notes":
+			assert.Equal(t, "asc", r.URL.Query().Get("sort"))
+			assert.Equal(t, "updated_at", r.URL.Query().Get("order_by"))
+			_, err := w.Write([]byte(`[
+				{"id": 1, "body": "added 1 commit", "system": true, "author": {"id": 1}},
+				{"id": 2, "body": "thread note", "type": "DiscussionNote", "author": {"id": 99}},
+				{"id": 3, "body": "status", "author": {"id": 99}}
+			]`))
+			assert.NoError(t, err)
+		case r.Method == http.MethodPost && r.URL.Path == "/projects/42/merge_requests/7/notes":
+			w.WriteHeader(http.StatusCreated)
+			_, err := w.Write([]byte(`{"id": 4, "body": "new", "author": {"id": 99}}`))
+			assert.NoError(t, err)
+		case r.Method == http.MethodPut && r.URL.Path == "/projects/42/merge_requests/7/notes/3":
+			var body map[string]any
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "updated", body["body"])
+			_, err := w.Write([]byte(`{"id": 3}`))
+			assert.NoError(t, err)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mocksrv.Close()
+
+	glc := newTestClient(mocksrv)
+	pr := newTestPullRequestReference(t)
+
+	comments, err := glc.ListPullRequestComments(context.Background(), pr)
+	require.NoError(t, err)
+	require.Equal(t, []*provifv1.PullRequestComment{{ID: "3", AuthorID: 99, Body: "status"}}, comments)
+
+	comment, err := glc.CreatePullRequestComment(context.Background(), pr, "new")
+	require.NoError(t, err)
+	require.Equal(t, &provifv1.PullRequestComment{ID: "4", AuthorID: 99, Body: "new"}, comment)
+
+	require.NoError(t, glc.UpdatePullRequestComment(context.Background(), pr, "3", "updated"))
+}
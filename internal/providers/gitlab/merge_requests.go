@@ -0,0 +1,176 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/go-git/go-git/v5"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/mindersec/minder/internal/util/ptr"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	provifv1 "github.com/mindersec/minder/pkg/providers/v1"
+)
+
+const (
+	// GitLab accepts any username along with a personal access token,
+	// but requires this one along with an OAuth2 token.
+	pushUsername = "oauth2"
+
+	mergeRequestStateCloseEvent = "close"
+)
+
+// GetCommitAuthor returns the name and e-mail of the authenticated user
+func (c *gitlabClient) GetCommitAuthor(ctx context.Context) (string, string, error) {
+	user := &gitlab.User{}
+	if err := glRESTGet(ctx, c, "user", user); err != nil {
+		return "", "", fmt.Errorf("cannot get authenticated user: %w", err)
+	}
+	if user.Email == "" {
+		return "", "", fmt.Errorf("authenticated user %s has no e-mail address", user.Username)
+	}
+
+	name := user.Name
+	if name == "" {
+		name = user.Username
+	}
+	return name, user.Email, nil
+}
+
+// AddAuthToPushOptions adds the credential to the push options
+func (c *gitlabClient) AddAuthToPushOptions(_ context.Context, options *git.PushOptions) error {
+	c.cred.AddToPushOptions(options, pushUsername)
+	return nil
+}
+
+// FindChangeRequest returns the open merge request from the head branch
+func (c *gitlabClient) FindChangeRequest(
+	ctx context.Context, repo *minderv1.Repository, head string,
+) (*provifv1.ChangeRequest, error) {
+	mrsPath, err := mergeRequestsPath(repo)
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	query.Set("state", "opened")
+	query.Set("source_branch", head)
+
+	mrs := []*gitlab.MergeRequest{}
+	if err := glRESTGet(ctx, c, mrsPath+"?"+query.Encode(), &mrs); err != nil {
+		return nil, fmt.Errorf("failed to list merge requests: %w", err)
+	}
+	if len(mrs) == 0 {
+		return nil, nil
+	}
+	return mergeRequestToChangeRequest(mrs[0]), nil
+}
+
+// CreateChangeRequest opens a merge request from the head branch
+func (c *gitlabClient) CreateChangeRequest(
+	ctx context.Context, repo *minderv1.Repository, title, body, head, base string,
+) (*provifv1.ChangeRequest, error) {
+	mrsPath, err := mergeRequestsPath(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	mr, err := c.doSendMergeRequest(ctx, http.MethodPost, mrsPath, &gitlab.CreateMergeRequestOptions{
+		Title:              &title,
+		Description:        &body,
+		SourceBranch:       &head,
+		TargetBranch:       &base,
+		RemoveSourceBranch: ptr.Ptr(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create merge request: %w", err)
+	}
+	return mergeRequestToChangeRequest(mr), nil
+}
+
+// UpdateChangeRequest updates the title and description of a merge request
+func (c *gitlabClient) UpdateChangeRequest(
+	ctx context.Context, repo *minderv1.Repository, number int, title, body string,
+) error {
+	mrPath, err := mergeRequestPath(repo, number)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doSendMergeRequest(ctx, http.MethodPut, mrPath, &gitlab.UpdateMergeRequestOptions{
+		Title:       &title,
+		Description: &body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update merge request: %w", err)
+	}
+	return nil
+}
+
+// CloseChangeRequest closes a merge request
+func (c *gitlabClient) CloseChangeRequest(ctx context.Context, repo *minderv1.Repository, number int) error {
+	mrPath, err := mergeRequestPath(repo, number)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doSendMergeRequest(ctx, http.MethodPut, mrPath, &gitlab.UpdateMergeRequestOptions{
+		StateEvent: ptr.Ptr(mergeRequestStateCloseEvent),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to close merge request: %w", err)
+	}
+	return nil
+}
+
+func (c *gitlabClient) doSendMergeRequest(
+	ctx context.Context, method string, path string, body any,
+) (*gitlab.MergeRequest, error) {
+	req, err := c.NewRequest(method, path, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send merge request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	mr := &gitlab.MergeRequest{}
+	if err := json.NewDecoder(resp.Body).Decode(mr); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return mr, nil
+}
+
+func mergeRequestsPath(repo *minderv1.Repository) (string, error) {
+	return projectPath(repo, "merge_requests")
+}
+
+func mergeRequestPath(repo *minderv1.Repository, iid int) (string, error) {
+	mrsPath, err := mergeRequestsPath(repo)
+	if err != nil {
+		return "", err
+	}
+	return url.JoinPath(mrsPath, fmt.Sprintf("%d", iid))
+}
+
+func mergeRequestToChangeRequest(mr *gitlab.MergeRequest) *provifv1.ChangeRequest {
+	return &provifv1.ChangeRequest{
+		// the IID identifies the merge request within the project
+		Number: mr.IID,
+		Title:  mr.Title,
+		Body:   mr.Description,
+	}
+}
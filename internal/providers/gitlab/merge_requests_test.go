@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	provifv1 "github.com/mindersec/minder/pkg/providers/v1"
+)
+
+func TestMergeRequests(t *testing.T) {
+	t.Parallel()
+
+	repo := &minderv1.Repository{Owner: "group", Name: "project", RepoId: 42}
+
+	tests := []struct {
+		name        string
+		call        func(*gitlabClient) (*provifv1.ChangeRequest, error)
+		mockHandler func(t *testing.T) http.HandlerFunc
+		want        *provifv1.ChangeRequest
+		wantErr     bool
+	}{
+		{
+			name: "find open merge request",
+			call: func(glc *gitlabClient) (*provifv1.ChangeRequest, error) {
+				return glc.FindChangeRequest(context.Background(), repo, "minder_fix")
+			},
+			mockHandler: func(t *testing.T) http.HandlerFunc {
+				t.Helper()
+				return func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, http.MethodGet, r.Method)
+					assert.Equal(t, "/projects/42/merge_requests", r.URL.Path)
+					assert.Equal(t, "opened", r.URL.Query().Get("state"))
+					assert.Equal(t, "minder_fix", r.URL.Query().Get("source_branch"))
+					_, err := w.Write([]byte(`[{"iid": 7, "title": "Fix", "description": "body"}]`))
+					assert.NoError(t, err)
+				}
+			},
+			want: &provifv1.ChangeRequest{Number: 7, Title: "Fix", Body: "body"},
+		},
+		{
+			name: "no open merge request",
+			call: func(glc *gitlabClient) (*provifv1.ChangeRequest, error) {
+				return glc.FindChangeRequest(context.Background(), repo, "minder_fix")
+			},
+			mockHandler: func(t *testing.T) http.HandlerFunc {
+				t.Helper()
+				return func(w http.ResponseWriter, _ *http.Request) {
+					_, err := w.Write([]byte(`[]`))
+					assert.NoError(t, err)
+				}
+			},
+		},
+		{
+			name: "create merge request",
+			call: func(glc *gitlabClient) (*provifv1.ChangeRequest, error) {
+				return glc.CreateChangeRequest(context.Background(), repo, "Fix", "body", "minder_fix", "main")
+			},
+			mockHandler: func(t *testing.T) http.HandlerFunc {
+				t.Helper()
+				return func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, http.MethodPost, r.Method)
+					assert.Equal(t, "/projects/42/merge_requests", r.URL.Path)
+					var body map[string]any
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, "minder_fix", body["source_branch"])
+					assert.Equal(t, "main", body["target_branch"])
+					assert.Equal(t, "Fix", body["title"])
+					assert.Equal(t, "body", body["description"])
+					w.WriteHeader(http.StatusCreated)
+					_, err := w.Write([]byte(`{"iid": 8, "title": "Fix", "description": "body"}`))
+					assert.NoError(t, err)
+				}
+			},
+			want: &provifv1.ChangeRequest{Number: 8, Title: "Fix", Body: "body"},
+		},
+		{
+			name: "close merge request",
+			call: func(glc *gitlabClient) (*provifv1.ChangeRequest, error) {
+				return nil, glc.CloseChangeRequest(context.Background(), repo, 8)
+			},
+			mockHandler: func(t *testing.T) http.HandlerFunc {
+				t.Helper()
+				return func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, http.MethodPut, r.Method)
+					assert.Equal(t, "/projects/42/merge_requests/8", r.URL.Path)
+					var body map[string]any
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, "close", body["state_event"])
+					_, err := w.Write([]byte(`{"iid": 8}`))
+					assert.NoError(t, err)
+				}
+			},
+		},
+		{
+			name: "update merge request fails",
+			call: func(glc *gitlabClient) (*provifv1.ChangeRequest, error) {
+				return nil, glc.UpdateChangeRequest(context.Background(), repo, 8, "Fix", "body")
+			},
+			mockHandler: func(t *testing.T) http.HandlerFunc {
+				t.Helper()
+				return func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusForbidden)
+				}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mocksrv := httptest.NewServer(tt.mockHandler(t))
+			defer mocksrv.Close()
+
+			glc := &gitlabClient{
+				cred: &mockCredentials{},
+				glcfg: &minderv1.GitLabProviderConfig{
+					Endpoint: mocksrv.URL,
+				},
+				cli: mocksrv.Client(),
+			}
+
+			got, err := tt.call(glc)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,362 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	provifv1 "github.com/mindersec/minder/pkg/providers/v1"
+)
+
+// protectedBranchesPerPage is the page size used to list protected branches
+// and approval rules. Only the first page is fetched.
+const protectedBranchesPerPage = 100
+
+// The types below mirror the GitLab protected branches and approval rules
+// APIs. They're declared here as the updates need fields, such as
+// _destroy, which the client library doesn't expose consistently.
+// See https://docs.gitlab.com/ee/api/protected_branches.html and
+// https://docs.gitlab.com/ee/api/merge_request_approvals.html
+
+type protectedBranch struct {
+	ID                        int                  `json:"id"`
+	Name                      string               `json:"name"`
+	PushAccessLevels          []*branchAccessLevel `json:"push_access_levels"`
+	MergeAccessLevels         []*branchAccessLevel `json:"merge_access_levels"`
+	UnprotectAccessLevels     []*branchAccessLevel `json:"unprotect_access_levels"`
+	AllowForcePush            bool                 `json:"allow_force_push"`
+	CodeOwnerApprovalRequired bool                 `json:"code_owner_approval_required"`
+}
+
+type branchAccessLevel struct {
+	ID          int  `json:"id,omitempty"`
+	AccessLevel *int `json:"access_level,omitempty"`
+	UserID      int  `json:"user_id,omitempty"`
+	GroupID     int  `json:"group_id,omitempty"`
+	DeployKeyID int  `json:"deploy_key_id,omitempty"`
+	Destroy     bool `json:"_destroy,omitempty"`
+}
+
+type protectBranchOptions struct {
+	Name                      string `json:"name"`
+	PushAccessLevel           int    `json:"push_access_level"`
+	MergeAccessLevel          int    `json:"merge_access_level"`
+	UnprotectAccessLevel      int    `json:"unprotect_access_level"`
+	AllowForcePush            bool   `json:"allow_force_push"`
+	CodeOwnerApprovalRequired bool   `json:"code_owner_approval_required"`
+}
+
+type updateProtectedBranchOptions struct {
+	AllowForcePush            bool                 `json:"allow_force_push"`
+	CodeOwnerApprovalRequired bool                 `json:"code_owner_approval_required"`
+	AllowedToPush             []*branchAccessLevel `json:"allowed_to_push,omitempty"`
+	AllowedToMerge            []*branchAccessLevel `json:"allowed_to_merge,omitempty"`
+	AllowedToUnprotect        []*branchAccessLevel `json:"allowed_to_unprotect,omitempty"`
+}
+
+type approvalRule struct {
+	ID                            int                `json:"id"`
+	Name                          string             `json:"name"`
+	ApprovalsRequired             int                `json:"approvals_required"`
+	AppliesToAllProtectedBranches bool               `json:"applies_to_all_protected_branches"`
+	ProtectedBranches             []*protectedBranch `json:"protected_branches"`
+}
+
+type approvalRuleOptions struct {
+	Name               string `json:"name,omitempty"`
+	ApprovalsRequired  int    `json:"approvals_required"`
+	ProtectedBranchIDs []int  `json:"protected_branch_ids,omitempty"`
+}
+
+// ListBranchProtections returns the protection of the protected branches
+func (c *gitlabClient) ListBranchProtections(
+	ctx context.Context, repo *minderv1.Repository,
+) (map[string]*provifv1.GitLabBranchProtection, error) {
+	branches, err := c.listProtectedBranches(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := c.listApprovalRules(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]*provifv1.GitLabBranchProtection, len(branches))
+	for _, branch := range branches {
+		out[branch.Name] = toBranchProtection(branch, rules)
+	}
+	return out, nil
+}
+
+// GetBranchProtection returns the protection of a branch
+func (c *gitlabClient) GetBranchProtection(
+	ctx context.Context, repo *minderv1.Repository, branch string,
+) (*provifv1.GitLabBranchProtection, error) {
+	current, rules, err := c.getProtectedBranch(ctx, repo, branch)
+	if err != nil {
+		return nil, err
+	}
+	return toBranchProtection(current, rules), nil
+}
+
+// UpdateBranchProtection protects a branch and updates its protection and
+// approval rules. Approval rules are matched by name, existing rules which
+// are shared with other branches are updated for all of them.
+func (c *gitlabClient) UpdateBranchProtection(
+	ctx context.Context, repo *minderv1.Repository, branch string, protection *provifv1.GitLabBranchProtection,
+) error {
+	current, rules, err := c.getProtectedBranch(ctx, repo, branch)
+	if err != nil {
+		return err
+	}
+
+	branchesPath, err := projectPath(repo, "protected_branches")
+	if err != nil {
+		return err
+	}
+
+	if current == nil {
+		current = &protectedBranch{}
+		err = glRESTSend(ctx, c, http.MethodPost, branchesPath, &protectBranchOptions{
+			Name:                      branch,
+			PushAccessLevel:           protection.PushAccessLevel,
+			MergeAccessLevel:          protection.MergeAccessLevel,
+			UnprotectAccessLevel:      protection.UnprotectAccessLevel,
+			AllowForcePush:            protection.AllowForcePush,
+			CodeOwnerApprovalRequired: protection.CodeOwnerApprovalRequired,
+		}, current)
+		if err != nil {
+			return fmt.Errorf("failed to protect branch: %w", err)
+		}
+	} else {
+		branchPath, err := url.JoinPath(branchesPath, url.PathEscape(branch))
+		if err != nil {
+			return fmt.Errorf("failed to join URL path for protected branch: %w", err)
+		}
+		err = glRESTSend(ctx, c, http.MethodPatch, branchPath, &updateProtectedBranchOptions{
+			AllowForcePush:            protection.AllowForcePush,
+			CodeOwnerApprovalRequired: protection.CodeOwnerApprovalRequired,
+			AllowedToPush:             accessLevelChanges(current.PushAccessLevels, protection.PushAccessLevel),
+			AllowedToMerge:            accessLevelChanges(current.MergeAccessLevels, protection.MergeAccessLevel),
+			AllowedToUnprotect:        accessLevelChanges(current.UnprotectAccessLevels, protection.UnprotectAccessLevel),
+		}, nil)
+		if err != nil {
+			return fmt.Errorf("failed to update protected branch: %w", err)
+		}
+	}
+
+	return c.updateApprovalRules(ctx, repo, current, rules, protection.ApprovalRules)
+}
+
+func (c *gitlabClient) updateApprovalRules(
+	ctx context.Context,
+	repo *minderv1.Repository,
+	branch *protectedBranch,
+	current []*approvalRule,
+	desired map[string]*provifv1.GitLabApprovalRule,
+) error {
+	rulesPath, err := projectPath(repo, "approval_rules")
+	if err != nil {
+		return err
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(desired)) {
+		rule := desired[name]
+		if rule == nil {
+			continue
+		}
+
+		idx := slices.IndexFunc(current, func(r *approvalRule) bool {
+			return r.Name == name && r.appliesTo(branch)
+		})
+		if idx == -1 {
+			err = glRESTSend(ctx, c, http.MethodPost, rulesPath, &approvalRuleOptions{
+				Name:               name,
+				ApprovalsRequired:  rule.ApprovalsRequired,
+				ProtectedBranchIDs: []int{branch.ID},
+			}, nil)
+			if err != nil {
+				return fmt.Errorf("failed to create approval rule %s: %w", name, err)
+			}
+			continue
+		}
+
+		existing := current[idx]
+		if existing.ApprovalsRequired == rule.ApprovalsRequired {
+			continue
+		}
+		rulePath, err := url.JoinPath(rulesPath, fmt.Sprintf("%d", existing.ID))
+		if err != nil {
+			return fmt.Errorf("failed to join URL path for approval rule: %w", err)
+		}
+		err = glRESTSend(ctx, c, http.MethodPut, rulePath, &approvalRuleOptions{
+			ApprovalsRequired: rule.ApprovalsRequired,
+		}, nil)
+		if err != nil {
+			return fmt.Errorf("failed to update approval rule %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// getProtectedBranch returns the protected branch, or nil if the branch
+// is not protected, along with the approval rules of the project
+func (c *gitlabClient) getProtectedBranch(
+	ctx context.Context, repo *minderv1.Repository, branch string,
+) (*protectedBranch, []*approvalRule, error) {
+	branches, err := c.listProtectedBranches(ctx, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	rules, err := c.listApprovalRules(ctx, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	idx := slices.IndexFunc(branches, func(b *protectedBranch) bool {
+		return b.Name == branch
+	})
+	if idx == -1 {
+		return nil, rules, nil
+	}
+	return branches[idx], rules, nil
+}
+
+func (c *gitlabClient) listProtectedBranches(
+	ctx context.Context, repo *minderv1.Repository,
+) ([]*protectedBranch, error) {
+	branchesPath, err := projectPath(repo, "protected_branches")
+	if err != nil {
+		return nil, err
+	}
+
+	branches := []*protectedBranch{}
+	path := fmt.Sprintf("%s?per_page=%d", branchesPath, protectedBranchesPerPage)
+	if err := glRESTGet(ctx, c, path, &branches); err != nil {
+		return nil, fmt.Errorf("failed to list protected branches: %w", err)
+	}
+	return branches, nil
+}
+
+// listApprovalRules lists the approval rules of the project. Approval rules
+// are not available in all GitLab tiers, in which case there are none.
+func (c *gitlabClient) listApprovalRules(
+	ctx context.Context, repo *minderv1.Repository,
+) ([]*approvalRule, error) {
+	rulesPath, err := projectPath(repo, "approval_rules")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.NewRequest(http.MethodGet, fmt.Sprintf("%s?per_page=%d", rulesPath, protectedBranchesPerPage), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list approval rules: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	} else if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list approval rules: %s", resp.Status)
+	}
+
+	rules := []*approvalRule{}
+	if err := json.NewDecoder(resp.Body).Decode(&rules); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return rules, nil
+}
+
+// appliesTo tells whether the approval rule applies to merge requests
+// targeting the branch, which is nil if the branch is not protected.
+// Rules which are not scoped to protected branches apply to all branches.
+func (r *approvalRule) appliesTo(branch *protectedBranch) bool {
+	if len(r.ProtectedBranches) == 0 && !r.AppliesToAllProtectedBranches {
+		return true
+	}
+	if branch == nil {
+		return false
+	}
+	return r.AppliesToAllProtectedBranches || slices.ContainsFunc(r.ProtectedBranches, func(b *protectedBranch) bool {
+		return b.ID == branch.ID
+	})
+}
+
+func toBranchProtection(branch *protectedBranch, rules []*approvalRule) *provifv1.GitLabBranchProtection {
+	protection := provifv1.NewGitLabBranchProtection()
+	if branch != nil {
+		protection.Protected = true
+		protection.PushAccessLevel = roleAccessLevel(branch.PushAccessLevels)
+		protection.MergeAccessLevel = roleAccessLevel(branch.MergeAccessLevels)
+		protection.UnprotectAccessLevel = roleAccessLevel(branch.UnprotectAccessLevels)
+		protection.AllowForcePush = branch.AllowForcePush
+		protection.CodeOwnerApprovalRequired = branch.CodeOwnerApprovalRequired
+	}
+
+	for _, rule := range rules {
+		if rule.appliesTo(branch) {
+			protection.ApprovalRules[rule.Name] = &provifv1.GitLabApprovalRule{
+				ApprovalsRequired: rule.ApprovalsRequired,
+			}
+		}
+	}
+	return protection
+}
+
+// roleAccessLevel returns the access level granted to a role, as opposed to
+// specific users, groups or deploy keys. Only one role can be granted access.
+func roleAccessLevel(levels []*branchAccessLevel) int {
+	if role := roleAccess(levels); role != nil {
+		return *role.AccessLevel
+	}
+	return provifv1.GitLabAccessLevelNoAccess
+}
+
+func roleAccess(levels []*branchAccessLevel) *branchAccessLevel {
+	for _, level := range levels {
+		if level.AccessLevel != nil && level.UserID == 0 && level.GroupID == 0 && level.DeployKeyID == 0 {
+			return level
+		}
+	}
+	return nil
+}
+
+// accessLevelChanges returns the changes replacing the access granted to a
+// role, keeping the access granted to users, groups and deploy keys
+func accessLevelChanges(current []*branchAccessLevel, level int) []*branchAccessLevel {
+	role := roleAccess(current)
+	if role == nil && level == provifv1.GitLabAccessLevelNoAccess {
+		return nil
+	} else if role != nil && *role.AccessLevel == level {
+		return nil
+	}
+
+	var changes []*branchAccessLevel
+	if role != nil {
+		changes = append(changes, &branchAccessLevel{ID: role.ID, Destroy: true})
+	}
+	return append(changes, &branchAccessLevel{AccessLevel: &level})
+}
+
+// projectPath returns the path of a resource of the project. The repository
+// ID is the GitLab project ID.
+func projectPath(repo *minderv1.Repository, resource string) (string, error) {
+	if repo.GetRepoId() == 0 {
+		return "", fmt.Errorf("repository %s/%s has no project ID", repo.GetOwner(), repo.GetName())
+	}
+	return url.JoinPath("projects", FormatRepositoryUpstreamID(int(repo.GetRepoId())), resource)
+}
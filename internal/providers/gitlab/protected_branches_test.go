@@ -0,0 +1,212 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	provifv1 "github.com/mindersec/minder/pkg/providers/v1"
+)
+
+const (
+	protectedBranchesJSON = `[{
+		"id": 1,
+		"name": "main",
+		"push_access_levels": [{"id": 11, "access_level": 0}],
+		"merge_access_levels": [{"id": 12, "access_level": 30}, {"id": 13, "access_level": 40, "user_id": 5}],
+		"unprotect_access_levels": [{"id": 14, "access_level": 40}],
+		"allow_force_push": true,
+		"code_owner_approval_required": false
+	}]`
+	approvalRulesJSON = `[
+		{"id": 21, "name": "minder", "approvals_required": 1, "protected_branches": [{"id": 1, "name": "main"}]},
+		{"id": 22, "name": "release", "approvals_required": 2, "protected_branches": [{"id": 2, "name": "release"}]},
+		{"id": 23, "name": "all", "approvals_required": 1}
+	]`
+)
+
+func TestListBranchProtections(t *testing.T) {
+	t.Parallel()
+
+	repo := &minderv1.Repository{Owner: "group", Name: "project", RepoId: 42}
+
+	mocksrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body string
+		switch r.URL.Path {
+		case "/projects/42/protected_branches":
+			body = protectedBranchesJSON
+		case "/projects/42/approval_rules":
+			body = approvalRulesJSON
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write([]byte(body))
+		assert.NoError(t, err)
+	}))
+	defer mocksrv.Close()
+
+	glc := newTestClient(mocksrv)
+
+	got, err := glc.ListBranchProtections(context.Background(), repo)
+	require.NoError(t, err)
+	require.Equal(t, map[string]*provifv1.GitLabBranchProtection{
+		"main": {
+			Protected:            true,
+			PushAccessLevel:      provifv1.GitLabAccessLevelNoAccess,
+			MergeAccessLevel:     provifv1.GitLabAccessLevelDeveloper,
+			UnprotectAccessLevel: provifv1.GitLabAccessLevelMaintainer,
+			AllowForcePush:       true,
+			ApprovalRules: map[string]*provifv1.GitLabApprovalRule{
+				"minder": {ApprovalsRequired: 1},
+				"all":    {ApprovalsRequired: 1},
+			},
+		},
+	}, got)
+
+	unprotected, err := glc.GetBranchProtection(context.Background(), repo, "feature")
+	require.NoError(t, err)
+	require.False(t, unprotected.Protected)
+	require.Equal(t, provifv1.GitLabAccessLevelMaintainer, unprotected.PushAccessLevel)
+	require.Equal(t, map[string]*provifv1.GitLabApprovalRule{
+		"all": {ApprovalsRequired: 1},
+	}, unprotected.ApprovalRules)
+}
+
+func TestUpdateBranchProtection(t *testing.T) {
+	t.Parallel()
+
+	repo := &minderv1.Repository{Owner: "group", Name: "project", RepoId: 42}
+
+	tests := []struct {
+		name       string
+		branch     string
+		protection *provifv1.GitLabBranchProtection
+		// wantRequests maps "METHOD path" of the expected updates to
+		// assertions on their body
+		wantRequests map[string]func(t *testing.T, body map[string]any)
+	}{
+		{
+			name:   "protect unprotected branch",
+			branch: "feature/x",
+			protection: &provifv1.GitLabBranchProtection{
+				PushAccessLevel:      provifv1.GitLabAccessLevelNoAccess,
+				MergeAccessLevel:     provifv1.GitLabAccessLevelMaintainer,
+				UnprotectAccessLevel: provifv1.GitLabAccessLevelMaintainer,
+				ApprovalRules: map[string]*provifv1.GitLabApprovalRule{
+					"minder": {ApprovalsRequired: 2},
+				},
+			},
+			wantRequests: map[string]func(t *testing.T, body map[string]any){
+				"POST /projects/42/protected_branches": func(t *testing.T, body map[string]any) {
+					t.Helper()
+					assert.Equal(t, "feature/x", body["name"])
+					assert.Equal(t, float64(0), body["push_access_level"])
+					assert.Equal(t, float64(40), body["merge_access_level"])
+				},
+				"POST /projects/42/approval_rules": func(t *testing.T, body map[string]any) {
+					t.Helper()
+					assert.Equal(t, "minder", body["name"])
+					assert.Equal(t, float64(2), body["approvals_required"])
+					assert.Equal(t, []any{float64(3)}, body["protected_branch_ids"])
+				},
+			},
+		},
+		{
+			name:   "update protected branch",
+			branch: "main",
+			protection: &provifv1.GitLabBranchProtection{
+				PushAccessLevel:      provifv1.GitLabAccessLevelNoAccess,
+				MergeAccessLevel:     provifv1.GitLabAccessLevelMaintainer,
+				UnprotectAccessLevel: provifv1.GitLabAccessLevelMaintainer,
+				ApprovalRules: map[string]*provifv1.GitLabApprovalRule{
+					"minder": {ApprovalsRequired: 2},
+					"all":    {ApprovalsRequired: 1},
+				},
+			},
+			wantRequests: map[string]func(t *testing.T, body map[string]any){
+				"PATCH /projects/42/protected_branches/main": func(t *testing.T, body map[string]any) {
+					t.Helper()
+					assert.Equal(t, false, body["allow_force_push"])
+					assert.NotContains(t, body, "allowed_to_push")
+					assert.NotContains(t, body, "allowed_to_unprotect")
+					// the role access is replaced, the user access is kept
+					assert.Equal(t, []any{
+						map[string]any{"id": float64(12), "_destroy": true},
+						map[string]any{"access_level": float64(40)},
+					}, body["allowed_to_merge"])
+				},
+				"PUT /projects/42/approval_rules/21": func(t *testing.T, body map[string]any) {
+					t.Helper()
+					assert.Equal(t, float64(2), body["approvals_required"])
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			seen := map[string]bool{}
+			mocksrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					var body string
+					switch r.URL.Path {
+					case "/projects/42/protected_branches":
+						body = protectedBranchesJSON
+					case "/projects/42/approval_rules":
+						body = approvalRulesJSON
+					}
+					_, err := w.Write([]byte(body))
+					assert.NoError(t, err)
+					return
+				}
+
+				key := r.Method + " " + r.URL.EscapedPath()
+				check, ok := tt.wantRequests[key]
+				if !assert.True(t, ok, "unexpected request %s", key) {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				seen[key] = true
+
+				var body map[string]any
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				check(t, body)
+
+				w.WriteHeader(http.StatusCreated)
+				_, err := w.Write([]byte(`{"id": 3}`))
+				assert.NoError(t, err)
+			}))
+			defer mocksrv.Close()
+
+			glc := newTestClient(mocksrv)
+
+			err := glc.UpdateBranchProtection(context.Background(), repo, tt.branch, tt.protection)
+			require.NoError(t, err)
+			for key := range tt.wantRequests {
+				require.True(t, seen[key], "missing request %s", key)
+			}
+		})
+	}
+}
+
+func newTestClient(mocksrv *httptest.Server) *gitlabClient {
+	return &gitlabClient{
+		cred: &mockCredentials{},
+		glcfg: &minderv1.GitLabProviderConfig{
+			Endpoint: mocksrv.URL,
+		},
+		cli: mocksrv.Client(),
+	}
+}
@@ -6,7 +6,9 @@ package oci
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -14,6 +16,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
 	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/rs/zerolog"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -29,15 +32,36 @@ type OCI struct {
 
 	registry string
 	baseURL  string
+
+	// registryAuth indicates whether the credential should be
+	// used when talking to the registry
+	registryAuth bool
+}
+
+// Option is a functional option for the OCI client
+type Option func(*OCI)
+
+// WithRegistryAuth makes the OCI client authenticate to the registry
+// using its credential. Without this option, registry requests are anonymous.
+func WithRegistryAuth() Option {
+	return func(o *OCI) {
+		o.registryAuth = true
+	}
 }
 
 // New creates a new OCI client
-func New(cred provifv1.Credential, registry, baseURL string) *OCI {
-	return &OCI{
+func New(cred provifv1.Credential, registry, baseURL string, opts ...Option) *OCI {
+	o := &OCI{
 		cred:     cred,
 		registry: registry,
 		baseURL:  baseURL,
 	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
 }
 
 // CanImplement returns true/false depending on whether the OCI client can implement the specified trait
@@ -55,15 +79,22 @@ func (o *OCI) ListTags(ctx context.Context, contname string) ([]string, error) {
 		return nil, fmt.Errorf("parsing repo %q: %w", src, err)
 	}
 
-	puller, err := remote.NewPuller()
+	ropts, err := o.remoteOptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	puller, err := remote.NewPuller(ropts...)
 	if err != nil {
 		return nil, err
 	}
 
 	lister, err := puller.Lister(ctx, repo)
 	if err != nil {
-		if strings.Contains(err.Error(), "status code 404") {
-			return nil, fmt.Errorf("no such repository: %s", repo)
+		var terr *transport.Error
+		if (errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound) ||
+			strings.Contains(err.Error(), "status code 404") {
+			return nil, fmt.Errorf("no such repository: %s: %w", repo, provifv1.ErrEntityNotFound)
 		}
 		return nil, fmt.Errorf("reading tags for %s: %w", repo, err)
 	}
@@ -88,6 +119,28 @@ func (o *OCI) ListTags(ctx context.Context, contname string) ([]string, error) {
 	return outtags, nil
 }
 
+// ListRepositories lists the repositories of the registry using the catalog
+// endpoint. Note that registries may only return the repositories the
+// credential has access to, and some do not implement the endpoint at all.
+func (o *OCI) ListRepositories(ctx context.Context) ([]string, error) {
+	reg, err := name.NewRegistry(o.registry)
+	if err != nil {
+		return nil, fmt.Errorf("parsing registry %q: %w", o.registry, err)
+	}
+
+	ropts, err := o.remoteOptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	repos, err := remote.Catalog(ctx, reg, ropts...)
+	if err != nil {
+		return nil, fmt.Errorf("reading catalog for %s: %w", reg, err)
+	}
+
+	return repos, nil
+}
+
 // GetDigest returns the digest for a given tag
 func (o *OCI) GetDigest(ctx context.Context, contname, tag string) (string, error) {
 	ref, err := o.getReference(contname, tag)
@@ -95,7 +148,7 @@ func (o *OCI) GetDigest(ctx context.Context, contname, tag string) (string, erro
 		return "", fmt.Errorf("failed to get reference: %w", err)
 	}
 
-	return getDigestFromRef(ctx, ref)
+	return o.getDigestFromRef(ctx, ref)
 }
 
 // GetReferrer returns the referrer for the given tag of the given container in the given namespace
@@ -106,7 +159,7 @@ func (o *OCI) GetReferrer(ctx context.Context, contname, tag, artifactType strin
 		return "", fmt.Errorf("failed to get reference: %w", err)
 	}
 
-	dig, err := getDigestFromRef(ctx, ref)
+	dig, err := o.getDigestFromRef(ctx, ref)
 	if err != nil {
 		return "", fmt.Errorf("failed to get digest: %w", err)
 	}
@@ -116,9 +169,12 @@ func (o *OCI) GetReferrer(ctx context.Context, contname, tag, artifactType strin
 		return "", fmt.Errorf("failed to get digest name: %w", err)
 	}
 
-	refer, err := remote.Referrers(digname,
-		remote.WithContext(ctx), remote.WithUserAgent(constants.ServerUserAgent),
-		remote.WithFilter("artifactType", artifactType))
+	ropts, err := o.remoteOptions(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	refer, err := remote.Referrers(digname, append(ropts, remote.WithFilter("artifactType", artifactType))...)
 	if err != nil {
 		return "", fmt.Errorf("failed to get referrer: %w", err)
 	}
@@ -134,7 +190,12 @@ func (o *OCI) GetManifest(ctx context.Context, contname, tag string) (*v1.Manife
 		return nil, fmt.Errorf("failed to get reference: %w", err)
 	}
 
-	img, err := remote.Image(ref, remote.WithContext(ctx), remote.WithUserAgent(constants.ServerUserAgent))
+	ropts, err := o.remoteOptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := remote.Image(ref, ropts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get image: %w", err)
 	}
@@ -158,6 +219,10 @@ func (o *OCI) GetAuthenticator() (authn.Authenticator, error) {
 		return authn.Anonymous, nil
 	}
 
+	if ocicred, ok := o.cred.(provifv1.OCICredential); ok {
+		return ocicred.GetAsContainerAuthenticator(), nil
+	}
+
 	oauth2cred, ok := o.cred.(provifv1.OAuth2TokenCredential)
 	if !ok {
 		return nil, fmt.Errorf("credential is not an OAuth2 token credential")
@@ -248,10 +313,13 @@ func (o *OCI) getReference(contname, tag string) (name.Reference, error) {
 
 // getDigestFromRef returns the digest of a container image reference
 // TODO: Move this to a more appropriate location
-// TODO: Implement authentication
-// TODO: Implement authentication
-func getDigestFromRef(ctx context.Context, ref name.Reference) (string, error) {
-	img, err := remote.Image(ref, remote.WithContext(ctx), remote.WithUserAgent(constants.ServerUserAgent))
+func (o *OCI) getDigestFromRef(ctx context.Context, ref name.Reference) (string, error) {
+	ropts, err := o.remoteOptions(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	img, err := remote.Image(ref, ropts...)
 	if err != nil {
 		return "", fmt.Errorf("failed to get image: %w", err)
 	}
@@ -263,3 +331,22 @@ func getDigestFromRef(ctx context.Context, ref name.Reference) (string, error) {
 
 	return digest.String(), nil
 }
+
+// remoteOptions returns the options used for every request to the registry
+func (o *OCI) remoteOptions(ctx context.Context) ([]remote.Option, error) {
+	opts := []remote.Option{
+		remote.WithContext(ctx),
+		remote.WithUserAgent(constants.ServerUserAgent),
+	}
+
+	if !o.registryAuth {
+		return opts, nil
+	}
+
+	auth, err := o.GetAuthenticator()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get authenticator: %w", err)
+	}
+
+	return append(opts, remote.WithAuth(auth)), nil
+}
@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package manager contains the OCI registry provider class manager
+package manager
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"golang.org/x/oauth2"
+
+	"github.com/mindersec/minder/internal/crypto"
+	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/internal/providers/credentials"
+	m "github.com/mindersec/minder/internal/providers/manager"
+	"github.com/mindersec/minder/internal/providers/ociregistry"
+	v1 "github.com/mindersec/minder/pkg/providers/v1"
+)
+
+type providerClassManager struct {
+	store    db.Store
+	crypteng crypto.Engine
+}
+
+// NewOCIProviderClassManager creates a new provider class manager for the generic OCI registry provider
+func NewOCIProviderClassManager(crypteng crypto.Engine, store db.Store) *providerClassManager {
+	return &providerClassManager{
+		store:    store,
+		crypteng: crypteng,
+	}
+}
+
+// GetSupportedClasses implements the ProviderClassManager interface
+func (_ *providerClassManager) GetSupportedClasses() []db.ProviderClass {
+	return []db.ProviderClass{db.ProviderClassOci}
+}
+
+// Build implements the ProviderClassManager interface
+func (g *providerClassManager) Build(ctx context.Context, config *db.Provider) (v1.Provider, error) {
+	class := config.Class
+	// This should be validated by the caller, but let's check anyway
+	if !slices.Contains(g.GetSupportedClasses(), class) {
+		return nil, fmt.Errorf("provider does not implement %s", ociregistry.Class)
+	}
+
+	if config.Version != v1.V1 {
+		return nil, fmt.Errorf("provider version not supported")
+	}
+
+	cfg, err := ociregistry.ParseV1Config(config.Definition)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing OCI config: %w", err)
+	}
+
+	token, err := g.getProviderToken(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch credentials: %w", err)
+	}
+
+	cli, err := ociregistry.New(credentials.NewOCIRegistryCredential(cfg.GetUsername(), token), cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error creating OCI registry client: %w", err)
+	}
+	return cli, nil
+}
+
+// Delete implements the ProviderClassManager interface
+func (_ *providerClassManager) Delete(_ context.Context, _ *db.Provider) error {
+	// Nothing is registered upstream for OCI registries
+	return nil
+}
+
+// MarshallConfig implements the ProviderClassManager interface
+func (m *providerClassManager) MarshallConfig(
+	_ context.Context, class db.ProviderClass, config json.RawMessage,
+) (json.RawMessage, error) {
+	if !slices.Contains(m.GetSupportedClasses(), class) {
+		return nil, fmt.Errorf("provider does not implement %s", string(class))
+	}
+
+	return ociregistry.MarshalV1Config(config)
+}
+
+// GetWebhookHandler implements the ProviderClassManager interface.
+// There is no common webhook format for OCI registries.
+func (_ *providerClassManager) GetWebhookHandler() http.Handler {
+	return nil
+}
+
+// NewOAuthConfig implements the providerClassOAuthManager interface
+func (_ *providerClassManager) NewOAuthConfig(_ db.ProviderClass, _ bool) (*oauth2.Config, error) {
+	return nil, errors.New("OCI registry providers do not support OAuth")
+}
+
+// ValidateCredentials implements the providerClassOAuthManager interface
+func (_ *providerClassManager) ValidateCredentials(
+	_ context.Context, cred v1.Credential, _ *m.CredentialVerifyParams,
+) error {
+	tokenCred, ok := cred.(v1.OAuth2TokenCredential)
+	if !ok {
+		return fmt.Errorf("invalid credential type: %T", cred)
+	}
+
+	token, err := tokenCred.GetAsOAuth2TokenSource().Token()
+	if err != nil {
+		return fmt.Errorf("cannot get token from credential: %w", err)
+	}
+
+	if token.AccessToken == "" {
+		return errors.New("token is empty")
+	}
+
+	return nil
+}
+
+func (m *providerClassManager) getProviderToken(
+	ctx context.Context,
+	prov *db.Provider,
+) (string, error) {
+	encToken, err := m.store.GetAccessTokenByProjectID(ctx,
+		db.GetAccessTokenByProjectIDParams{Provider: prov.Name, ProjectID: prov.ProjectID})
+	if errors.Is(err, sql.ErrNoRows) {
+		// Anonymous access to the registry
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("error getting credential: %w", err)
+	}
+
+	if !encToken.EncryptedAccessToken.Valid {
+		return "", fmt.Errorf("no secret found for provider %s", encToken.Provider)
+	}
+
+	encryptedData, err := crypto.DeserializeEncryptedData(encToken.EncryptedAccessToken.RawMessage)
+	if err != nil {
+		return "", err
+	}
+
+	decryptedToken, err := m.crypteng.DecryptOAuthToken(encryptedData)
+	if err != nil {
+		return "", fmt.Errorf("error decrypting access token: %w", err)
+	}
+
+	return decryptedToken.AccessToken, nil
+}
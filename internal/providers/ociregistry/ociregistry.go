@@ -0,0 +1,201 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ociregistry provides a provider for generic OCI registries,
+// such as Harbor, Quay or Artifactory.
+package ociregistry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/internal/providers/oci"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/mindersec/minder/pkg/entities/properties"
+	provifv1 "github.com/mindersec/minder/pkg/providers/v1"
+)
+
+// Class is the string that represents the generic OCI registry provider class
+const Class = "oci"
+
+// Implements is the list of provider types that the OCI registry provider implements
+var Implements = []db.ProviderType{
+	db.ProviderTypeImageLister,
+	db.ProviderTypeOci,
+}
+
+// AuthorizationFlows is the list of authorization flows that the OCI registry provider supports
+var AuthorizationFlows = []db.AuthorizationFlow{
+	db.AuthorizationFlowUserInput,
+}
+
+// registryClient is the provider for a generic OCI registry
+type registryClient struct {
+	*oci.OCI
+	cfg *minderv1.OCIProviderConfig
+
+	// registry is the host of the registry, without a scheme
+	registry string
+}
+
+// Ensure that the OCI registry client implements the right interfaces
+var _ provifv1.ImageLister = (*registryClient)(nil)
+var _ provifv1.OCI = (*registryClient)(nil)
+
+// New creates a new OCI registry provider
+func New(cred provifv1.OCICredential, cfg *minderv1.OCIProviderConfig) (*registryClient, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid OCI provider config: %w", err)
+	}
+
+	registry := registryHost(cfg.GetBaseUrl())
+	baseURL := registry
+	if ns := cfg.GetNamespace(); ns != "" {
+		baseURL = fmt.Sprintf("%s/%s", registry, strings.Trim(ns, "/"))
+	}
+
+	return &registryClient{
+		OCI:      oci.New(cred, registry, baseURL, oci.WithRegistryAuth()),
+		cfg:      cfg,
+		registry: registry,
+	}, nil
+}
+
+type ociConfigWrapper struct {
+	OCI *minderv1.OCIProviderConfig `json:"oci" yaml:"oci" mapstructure:"oci" validate:"required"`
+}
+
+// ParseV1Config parses the raw config into an OCIProviderConfig struct
+func ParseV1Config(rawCfg json.RawMessage) (*minderv1.OCIProviderConfig, error) {
+	var w ociConfigWrapper
+	if err := provifv1.ParseAndValidate(rawCfg, &w); err != nil {
+		return nil, err
+	}
+
+	// Validate the config according to the protobuf validation rules.
+	if err := w.OCI.Validate(); err != nil {
+		return nil, fmt.Errorf("error validating OCI v1 provider config: %w", err)
+	}
+
+	return w.OCI, nil
+}
+
+// MarshalV1Config marshals and validates the given config
+// so it can safely be stored in the database
+func MarshalV1Config(rawCfg json.RawMessage) (json.RawMessage, error) {
+	var w ociConfigWrapper
+	if err := json.Unmarshal(rawCfg, &w); err != nil {
+		return nil, err
+	}
+
+	if w.OCI == nil {
+		return nil, fmt.Errorf("OCI provider config is required")
+	}
+
+	err := w.OCI.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("error validating provider config: %w", err)
+	}
+
+	return json.Marshal(w)
+}
+
+// CanImplement returns true if the provider can implement the specified trait
+func (_ *registryClient) CanImplement(trait minderv1.ProviderType) bool {
+	return trait == minderv1.ProviderType_PROVIDER_TYPE_IMAGE_LISTER ||
+		trait == minderv1.ProviderType_PROVIDER_TYPE_OCI
+}
+
+// GetNamespaceURL returns the URL of the namespace within the registry
+func (r *registryClient) GetNamespaceURL() string {
+	return fmt.Sprintf("https://%s", r.namespacePrefix())
+}
+
+// ListImages lists the images in the configured namespace using the
+// registry catalog. The names are returned relative to the namespace.
+func (r *registryClient) ListImages(ctx context.Context) ([]string, error) {
+	repos, err := r.ListRepositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ns := r.getNamespace()
+	if ns == "" {
+		return repos, nil
+	}
+
+	var images []string
+	for _, repo := range repos {
+		if img, ok := strings.CutPrefix(repo, ns+"/"); ok {
+			images = append(images, img)
+		}
+	}
+
+	return images, nil
+}
+
+// SupportsEntity implements the Provider interface
+func (_ *registryClient) SupportsEntity(entType minderv1.Entity) bool {
+	return entType == minderv1.Entity_ENTITY_ARTIFACTS
+}
+
+// RegisterEntity implements the Provider interface. Registries have no
+// common webhook mechanism, so registering an artifact only validates it.
+func (r *registryClient) RegisterEntity(
+	_ context.Context, entType minderv1.Entity, props *properties.Properties,
+) (*properties.Properties, error) {
+	if !r.SupportsEntity(entType) {
+		return nil, errors.New("unsupported entity type")
+	}
+
+	if props.GetProperty(properties.PropertyUpstreamID) == nil {
+		return nil, fmt.Errorf("artifact is missing the upstream ID")
+	}
+
+	return props, nil
+}
+
+// DeregisterEntity implements the Provider interface
+func (r *registryClient) DeregisterEntity(
+	_ context.Context, entType minderv1.Entity, _ *properties.Properties,
+) error {
+	if !r.SupportsEntity(entType) {
+		return errors.New("unsupported entity type")
+	}
+
+	// Nothing was created upstream when registering, so nothing to clean up
+	return nil
+}
+
+// ReregisterEntity implements the Provider interface
+func (r *registryClient) ReregisterEntity(
+	_ context.Context, entType minderv1.Entity, _ *properties.Properties,
+) error {
+	if !r.SupportsEntity(entType) {
+		return errors.New("unsupported entity type")
+	}
+
+	return nil
+}
+
+// getNamespace returns the configured namespace, without leading or trailing slashes
+func (r *registryClient) getNamespace() string {
+	return strings.Trim(r.cfg.GetNamespace(), "/")
+}
+
+// namespacePrefix returns the registry host joined with the namespace, if any
+func (r *registryClient) namespacePrefix() string {
+	if ns := r.getNamespace(); ns != "" {
+		return fmt.Sprintf("%s/%s", r.registry, ns)
+	}
+	return r.registry
+}
+
+// registryHost strips the optional scheme and trailing slash from the base URL
+func registryHost(baseURL string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(baseURL, "https://"), "/")
+}
@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ociregistry
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/mindersec/minder/internal/providers/credentials"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/mindersec/minder/pkg/entities/properties"
+	provifv1 "github.com/mindersec/minder/pkg/providers/v1"
+)
+
+// newTestRegistry starts an in-process registry seeded with the given repositories
+// and returns its host.
+func newTestRegistry(t *testing.T, repos ...string) string {
+	t.Helper()
+
+	ts := httptest.NewServer(registry.New())
+	t.Cleanup(ts.Close)
+
+	u, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+
+	for _, repo := range repos {
+		img, err := random.Image(64, 1)
+		require.NoError(t, err)
+
+		ref, err := name.ParseReference(fmt.Sprintf("%s/%s:latest", u.Host, repo))
+		require.NoError(t, err)
+		require.NoError(t, remote.Write(ref, img))
+	}
+
+	return u.Host
+}
+
+func newTestClient(t *testing.T, host string, ns string) *registryClient {
+	t.Helper()
+
+	cfg := &minderv1.OCIProviderConfig{BaseUrl: host}
+	if ns != "" {
+		cfg.Namespace = proto.String(ns)
+	}
+
+	c, err := New(credentials.NewOCIRegistryCredential("", ""), cfg)
+	require.NoError(t, err)
+	return c
+}
+
+func TestListImages(t *testing.T) {
+	t.Parallel()
+
+	host := newTestRegistry(t, "team/app", "team/tools/cli", "other/app")
+
+	tests := []struct {
+		name      string
+		namespace string
+		want      []string
+	}{
+		{
+			name:      "filters by namespace",
+			namespace: "team",
+			want:      []string{"app", "tools/cli"},
+		},
+		{
+			name:      "namespace with slashes",
+			namespace: "/team/",
+			want:      []string{"app", "tools/cli"},
+		},
+		{
+			name: "no namespace lists everything",
+			want: []string{"other/app", "team/app", "team/tools/cli"},
+		},
+		{
+			name:      "empty namespace",
+			namespace: "nobody",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := newTestClient(t, host, tt.namespace)
+			images, err := c.ListImages(context.Background())
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, images)
+		})
+	}
+}
+
+func TestFetchAllProperties(t *testing.T) {
+	t.Parallel()
+
+	host := newTestRegistry(t, "team/app")
+
+	tests := []struct {
+		name     string
+		getBy    map[string]any
+		wantErr  error
+		anyError bool
+	}{
+		{
+			name:  "by image name",
+			getBy: map[string]any{ArtifactPropertyImageName: "app"},
+		},
+		{
+			name:  "by name",
+			getBy: map[string]any{properties.PropertyName: "team/app"},
+		},
+		{
+			name:  "by upstream ID",
+			getBy: map[string]any{properties.PropertyUpstreamID: host + "/team/app"},
+		},
+		{
+			name:    "missing image",
+			getBy:   map[string]any{ArtifactPropertyImageName: "missing"},
+			wantErr: provifv1.ErrEntityNotFound,
+		},
+		{
+			name:     "name outside of namespace",
+			getBy:    map[string]any{properties.PropertyName: "other/app"},
+			anyError: true,
+		},
+		{
+			name:     "upstream ID in another registry",
+			getBy:    map[string]any{properties.PropertyUpstreamID: "example.com/team/app"},
+			anyError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := newTestClient(t, host, "team")
+			getBy, err := properties.NewProperties(tt.getBy)
+			require.NoError(t, err)
+
+			props, err := c.FetchAllProperties(context.Background(), getBy, minderv1.Entity_ENTITY_ARTIFACTS, nil)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			if tt.anyError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, host+"/team/app", props.GetProperty(properties.PropertyUpstreamID).GetString())
+			assert.Equal(t, "team/app", props.GetProperty(properties.PropertyName).GetString())
+			assert.Equal(t, "app", props.GetProperty(ArtifactPropertyImageName).GetString())
+
+			entName, err := c.GetEntityName(minderv1.Entity_ENTITY_ARTIFACTS, props)
+			require.NoError(t, err)
+			assert.Equal(t, "team/app", entName)
+
+			msg, err := c.PropertiesToProtoMessage(minderv1.Entity_ENTITY_ARTIFACTS, props)
+			require.NoError(t, err)
+			artifact, ok := msg.(*minderv1.Artifact)
+			require.True(t, ok)
+			assert.Equal(t, "team", artifact.GetOwner())
+			assert.Equal(t, "app", artifact.GetName())
+			assert.Equal(t, "container", artifact.GetType())
+		})
+	}
+}
+
+func TestUnsupportedEntity(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, "registry.example.com", "team")
+	props, err := properties.NewProperties(map[string]any{
+		properties.PropertyUpstreamID: "registry.example.com/team/app",
+	})
+	require.NoError(t, err)
+
+	_, err = c.RegisterEntity(context.Background(), minderv1.Entity_ENTITY_REPOSITORIES, props)
+	assert.Error(t, err)
+	_, err = c.FetchAllProperties(context.Background(), props, minderv1.Entity_ENTITY_REPOSITORIES, nil)
+	assert.Error(t, err)
+
+	got, err := c.RegisterEntity(context.Background(), minderv1.Entity_ENTITY_ARTIFACTS, props)
+	require.NoError(t, err)
+	assert.Equal(t, props, got)
+}
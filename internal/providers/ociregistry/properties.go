@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ociregistry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/mindersec/minder/internal/verifier/verifyif"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	"github.com/mindersec/minder/pkg/entities/properties"
+)
+
+const (
+	// ArtifactPropertyRegistry is the host of the registry the artifact lives in
+	ArtifactPropertyRegistry = "oci/registry"
+	// ArtifactPropertyNamespace is the namespace of the artifact within the registry
+	ArtifactPropertyNamespace = "oci/namespace"
+	// ArtifactPropertyImageName is the name of the artifact relative to the namespace
+	ArtifactPropertyImageName = "oci/image_name"
+)
+
+// FetchAllProperties implements the provider interface
+func (r *registryClient) FetchAllProperties(
+	ctx context.Context, getByProps *properties.Properties, entType minderv1.Entity, _ *properties.Properties,
+) (*properties.Properties, error) {
+	if !r.SupportsEntity(entType) {
+		return nil, fmt.Errorf("entity type %s not supported", entType)
+	}
+
+	imageName, err := r.imageNameFromProperties(getByProps)
+	if err != nil {
+		return nil, err
+	}
+
+	// Listing the tags ensures that the repository exists and is
+	// accessible with the configured credentials.
+	if _, err := r.ListTags(ctx, imageName); err != nil {
+		return nil, err
+	}
+
+	return r.artifactToProperties(imageName)
+}
+
+// FetchProperty implements the provider interface
+func (r *registryClient) FetchProperty(
+	ctx context.Context, getByProps *properties.Properties, entType minderv1.Entity, key string,
+) (*properties.Property, error) {
+	props, err := r.FetchAllProperties(ctx, getByProps, entType, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	value := props.GetProperty(key)
+	if value == nil {
+		return nil, errors.New("requested property not found")
+	}
+
+	return value, nil
+}
+
+// GetEntityName implements the provider interface
+func (r *registryClient) GetEntityName(entType minderv1.Entity, props *properties.Properties) (string, error) {
+	if props == nil {
+		return "", errors.New("properties are nil")
+	}
+
+	if !r.SupportsEntity(entType) {
+		return "", fmt.Errorf("entity type %s not supported", entType)
+	}
+
+	imageName, err := props.GetProperty(ArtifactPropertyImageName).AsString()
+	if err != nil {
+		return "", fmt.Errorf("artifact image name not found: %w", err)
+	}
+
+	return r.qualifiedName(imageName), nil
+}
+
+// PropertiesToProtoMessage implements the Provider interface
+func (r *registryClient) PropertiesToProtoMessage(
+	entType minderv1.Entity, props *properties.Properties,
+) (protoreflect.ProtoMessage, error) {
+	if !r.SupportsEntity(entType) {
+		return nil, fmt.Errorf("entity type %s not supported", entType)
+	}
+
+	upstreamID, err := props.GetProperty(properties.PropertyUpstreamID).AsString()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artifact upstream ID: %w", err)
+	}
+
+	// The artifact ingester builds the image reference from the owner
+	// and the name, so the namespace is used as the owner here.
+	return &minderv1.Artifact{
+		ArtifactPk: upstreamID,
+		Name:       props.GetProperty(ArtifactPropertyImageName).GetString(),
+		Owner:      props.GetProperty(ArtifactPropertyNamespace).GetString(),
+		Type:       props.GetProperty(properties.ArtifactPropertyType).GetString(),
+	}, nil
+}
+
+func (r *registryClient) artifactToProperties(imageName string) (*properties.Properties, error) {
+	return properties.NewProperties(map[string]any{
+		// general entity
+		properties.PropertyUpstreamID: fmt.Sprintf("%s/%s", r.registry, r.qualifiedName(imageName)),
+		properties.PropertyName:       r.qualifiedName(imageName),
+		// general artifact
+		properties.ArtifactPropertyType: string(verifyif.ArtifactTypeContainer),
+		// oci-specific
+		ArtifactPropertyRegistry:  r.registry,
+		ArtifactPropertyNamespace: r.getNamespace(),
+		ArtifactPropertyImageName: imageName,
+	})
+}
+
+// imageNameFromProperties returns the image name relative to the configured
+// namespace. The image can be looked up by its image name, by its name
+// within the registry or by its upstream ID.
+func (r *registryClient) imageNameFromProperties(props *properties.Properties) (string, error) {
+	if props == nil {
+		return "", errors.New("properties are nil")
+	}
+
+	if imageName := props.GetProperty(ArtifactPropertyImageName).GetString(); imageName != "" {
+		return imageName, nil
+	}
+
+	name := props.GetProperty(properties.PropertyName).GetString()
+	if name == "" {
+		upstreamID := props.GetProperty(properties.PropertyUpstreamID).GetString()
+		var ok bool
+		name, ok = strings.CutPrefix(upstreamID, r.registry+"/")
+		if !ok {
+			return "", errors.New("missing required properties, either name or upstream ID in this registry")
+		}
+	}
+
+	ns := r.getNamespace()
+	if ns == "" {
+		return name, nil
+	}
+
+	imageName, ok := strings.CutPrefix(name, ns+"/")
+	if !ok {
+		return "", fmt.Errorf("artifact %s is not in namespace %s", name, ns)
+	}
+
+	return imageName, nil
+}
+
+// qualifiedName returns the name of the image within the registry
+func (r *registryClient) qualifiedName(imageName string) string {
+	if ns := r.getNamespace(); ns != "" {
+		return fmt.Sprintf("%s/%s", ns, imageName)
+	}
+	return imageName
+}
@@ -7,9 +7,11 @@ import (
 	"fmt"
 
 	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/internal/providers/bitbucket"
 	"github.com/mindersec/minder/internal/providers/dockerhub"
 	ghclient "github.com/mindersec/minder/internal/providers/github/clients"
 	"github.com/mindersec/minder/internal/providers/gitlab"
+	"github.com/mindersec/minder/internal/providers/ociregistry"
 )
 
 // ProviderClassDefinition contains the static fields needed when creating a provider
@@ -35,6 +37,14 @@ var supportedProviderClassDefinitions = map[string]ProviderClassDefinition{
 		Traits:             gitlab.Implements,
 		AuthorizationFlows: gitlab.AuthorizationFlows,
 	},
+	bitbucket.Class: {
+		Traits:             bitbucket.Implements,
+		AuthorizationFlows: bitbucket.AuthorizationFlows,
+	},
+	ociregistry.Class: {
+		Traits:             ociregistry.Implements,
+		AuthorizationFlows: ociregistry.AuthorizationFlows,
+	},
 }
 
 // GetProviderClassDefinition returns the provider definition for the given provider class
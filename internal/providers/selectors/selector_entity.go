@@ -94,6 +94,20 @@ func pullRequestToSelectorEntity(
 	return selEnt
 }
 
+func organizationToSelectorEntity(
+	entityWithProps *models.EntityWithProperties, selProv *internalpb.SelectorProvider,
+) *internalpb.SelectorEntity {
+	selEnt := buildBaseSelectorEntity(entityWithProps, selProv)
+	selEnt.Entity = &internalpb.SelectorEntity_Organization{
+		Organization: &internalpb.SelectorOrganization{
+			Name:       entityWithProps.Entity.Name,
+			Properties: entityWithProps.Properties.ToProtoStruct(),
+			Provider:   selProv,
+		},
+	}
+	return selEnt
+}
+
 // newConverterFactory creates a new converterFactory with the default converters for each entity type
 func newConverter(entType minderv1.Entity) toSelectorEntity {
 	switch entType { // nolint:exhaustive
@@ -103,6 +117,8 @@ func newConverter(entType minderv1.Entity) toSelectorEntity {
 		return artifactToSelectorEntity
 	case minderv1.Entity_ENTITY_PULL_REQUESTS:
 		return pullRequestToSelectorEntity
+	case minderv1.Entity_ENTITY_ORGANIZATION:
+		return organizationToSelectorEntity
 	}
 	return nil
 }
@@ -94,6 +94,15 @@ func checkSelEntPullRequest(t *testing.T, got, expected *internalpb.SelectorPull
 	checkProps(t, got.Properties, propMap)
 }
 
+func checkSelEntOrganization(t *testing.T, got, expected *internalpb.SelectorOrganization, propMap map[string]any, expProvider *db.Provider) {
+	t.Helper()
+
+	assert.Equal(t, got.Name, expected.Name)
+	assert.Equal(t, got.GetProvider().GetName(), expProvider.Name)
+	assert.Equal(t, got.GetProvider().GetClass(), string(expProvider.Class))
+	checkProps(t, got.Properties, propMap)
+}
+
 func checkSelEnt(t *testing.T, got, expected *internalpb.SelectorEntity, propMap map[string]any, expProvider *db.Provider) {
 	t.Helper()
 
@@ -105,6 +114,8 @@ func checkSelEnt(t *testing.T, got, expected *internalpb.SelectorEntity, propMap
 		checkSelEntArtifact(t, got.GetArtifact(), expected.GetArtifact(), propMap, expProvider)
 	case minderv1.Entity_ENTITY_PULL_REQUESTS:
 		checkSelEntPullRequest(t, got.GetPullRequest(), expected.GetPullRequest(), propMap, expProvider)
+	case minderv1.Entity_ENTITY_ORGANIZATION:
+		checkSelEntOrganization(t, got.GetOrganization(), expected.GetOrganization(), propMap, expProvider)
 	}
 }
 
@@ -210,6 +221,32 @@ func TestEntityToSelectorEntity(t *testing.T) {
 			expDbProv: &gitlabProvider,
 			success:   true,
 		},
+		{
+			name:       "Organization",
+			entityType: minderv1.Entity_ENTITY_ORGANIZATION,
+			entityName: "testorg",
+			entityProps: map[string]any{
+				properties.PropertyUpstreamID:                 "13579",
+				ghprops.OrgPropertyLogin:                      "testorg",
+				ghprops.OrgPropertyTwoFactorRequirement:       true,
+				ghprops.OrgPropertyDefaultRepoPermission:      "read",
+				ghprops.OrgPropertyDefaultWorkflowPermissions: "read",
+			},
+			expSelEnt: &internalpb.SelectorEntity{
+				EntityType: minderv1.Entity_ENTITY_ORGANIZATION,
+				Name:       "testorg",
+				Entity: &internalpb.SelectorEntity_Organization{
+					Organization: &internalpb.SelectorOrganization{
+						Name: "testorg",
+					},
+				},
+			},
+			dbSetup: dbf.NewDBMock(
+				withGetProviderByID(githubProvider, nil),
+			),
+			expDbProv: &githubProvider,
+			success:   true,
+		},
 		{
 			name:       "Repository but no querier provided",
 			entityType: minderv1.Entity_ENTITY_REPOSITORIES,
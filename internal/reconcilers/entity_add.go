@@ -4,7 +4,10 @@
 package reconcilers
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/ThreeDotsLabs/watermill/message"
@@ -12,15 +15,19 @@ import (
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 
+	"github.com/mindersec/minder/internal/db"
+	entityMessage "github.com/mindersec/minder/internal/entities/handlers/message"
+	propertyService "github.com/mindersec/minder/internal/entities/properties/service"
 	"github.com/mindersec/minder/internal/logger"
 	"github.com/mindersec/minder/internal/reconcilers/messages"
 	pb "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
 	"github.com/mindersec/minder/pkg/entities/properties"
+	"github.com/mindersec/minder/pkg/eventer/constants"
 )
 
 // handleEntityAddEvent handles the entity add event.
 // Although this method is meant to be generic and handle all types of entities,
-// it currently only does so for repositories.
+// it currently only does so for repositories and organizations.
 // Todo: Utilise for other entities when such are supported.
 // nolint
 func (r *Reconciler) handleEntityAddEvent(msg *message.Message) error {
@@ -41,7 +48,7 @@ func (r *Reconciler) handleEntityAddEvent(msg *message.Message) error {
 		return nil
 	}
 
-	if event.EntityType != pb.Entity_ENTITY_REPOSITORIES {
+	if event.EntityType != pb.Entity_ENTITY_REPOSITORIES && event.EntityType != pb.Entity_ENTITY_ORGANIZATION {
 		l.Debug().Str("entity_type", event.EntityType.String()).Msg("unsupported entity type")
 		return nil
 	}
@@ -66,6 +73,10 @@ func (r *Reconciler) handleEntityAddEvent(msg *message.Message) error {
 	logger.BusinessRecord(ctx).ProviderID = event.ProviderID
 	logger.BusinessRecord(ctx).Project = event.ProjectID
 
+	if event.EntityType == pb.Entity_ENTITY_ORGANIZATION {
+		return r.addOrganization(ctx, &event, fetchByProps)
+	}
+
 	dbProvider, err := r.store.GetProviderByID(ctx, event.ProviderID)
 	if err != nil {
 		return fmt.Errorf("error retrieving provider: %w", err)
@@ -87,3 +98,75 @@ func (r *Reconciler) handleEntityAddEvent(msg *message.Message) error {
 	logger.BusinessRecord(ctx).Repository = repoID
 	return nil
 }
+
+// addOrganization stores the organization entity along with its properties
+// and requests its evaluation. Organizations don't originate from any other
+// entity and need no registration steps upstream, so they're created directly.
+func (r *Reconciler) addOrganization(
+	ctx context.Context,
+	event *messages.MinderEvent,
+	fetchByProps *properties.Properties,
+) error {
+	prov, err := r.providerManager.InstantiateFromID(ctx, event.ProviderID)
+	if err != nil {
+		return fmt.Errorf("error instantiating provider: %w", err)
+	}
+
+	orgProps, err := r.propSvc.RetrieveAllProperties(
+		ctx, prov, event.ProjectID, event.ProviderID,
+		fetchByProps, pb.Entity_ENTITY_ORGANIZATION, nil)
+	if err != nil {
+		return fmt.Errorf("error fetching properties for organization: %w", err)
+	}
+
+	name, err := prov.GetEntityName(pb.Entity_ENTITY_ORGANIZATION, orgProps)
+	if err != nil {
+		return fmt.Errorf("error getting entity name: %w", err)
+	}
+
+	ent, err := db.WithTransaction(r.store, func(t db.ExtendQuerier) (db.EntityInstance, error) {
+		ent, err := t.GetEntityByName(ctx, db.GetEntityByNameParams{
+			ProjectID:  event.ProjectID,
+			ProviderID: event.ProviderID,
+			EntityType: db.EntitiesOrganization,
+			Name:       name,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			ent, err = t.CreateOrEnsureEntityByID(ctx, db.CreateOrEnsureEntityByIDParams{
+				ID:         uuid.New(),
+				EntityType: db.EntitiesOrganization,
+				Name:       name,
+				ProjectID:  event.ProjectID,
+				ProviderID: event.ProviderID,
+			})
+		}
+		if err != nil {
+			return db.EntityInstance{}, fmt.Errorf("error storing organization: %w", err)
+		}
+
+		err = r.propSvc.ReplaceAllProperties(ctx, ent.ID, orgProps,
+			propertyService.CallBuilder().WithStoreOrTransaction(t))
+		if err != nil {
+			return db.EntityInstance{}, fmt.Errorf("error persisting properties: %w", err)
+		}
+		return ent, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	msg := message.NewMessage(uuid.New().String(), nil)
+	if err := entityMessage.NewEntityRefreshAndDoMessage().WithEntityID(ent.ID).ToMessage(msg); err != nil {
+		return fmt.Errorf("error creating evaluation message: %w", err)
+	}
+	msg.SetContext(ctx)
+	if err := r.evt.Publish(constants.TopicQueueRefreshEntityByIDAndEvaluate, msg); err != nil {
+		return fmt.Errorf("error publishing evaluation message: %w", err)
+	}
+
+	zerolog.Ctx(ctx).Info().
+		Str("entity_id", ent.ID.String()).
+		Str("organization", name).
+		Msg("organization registered")
+	return nil
+}
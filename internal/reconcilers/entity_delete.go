@@ -12,6 +12,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/rs/zerolog"
 
+	"github.com/mindersec/minder/internal/db"
 	"github.com/mindersec/minder/internal/entities/properties/service"
 	minderlogger "github.com/mindersec/minder/internal/logger"
 	"github.com/mindersec/minder/internal/reconcilers/messages"
@@ -37,7 +38,7 @@ func (r *Reconciler) handleEntityDeleteEvent(msg *message.Message) error {
 		return nil
 	}
 
-	if event.EntityType != pb.Entity_ENTITY_REPOSITORIES {
+	if event.EntityType != pb.Entity_ENTITY_REPOSITORIES && event.EntityType != pb.Entity_ENTITY_ORGANIZATION {
 		l.Error().Str("entity_type", event.EntityType.String()).Msg("entity type not supported")
 		return nil
 	}
@@ -53,6 +54,18 @@ func (r *Reconciler) handleEntityDeleteEvent(msg *message.Message) error {
 	minderlogger.BusinessRecord(ctx).Project = event.ProjectID
 
 	l.Info().Msg("handling entity delete event")
+	if event.EntityType == pb.Entity_ENTITY_ORGANIZATION {
+		// Organizations are not tracked outside the entity tables, so the
+		// entity is removed directly. Its properties are removed in cascade.
+		if err := r.store.DeleteEntity(ctx, db.DeleteEntityParams{
+			ID:        event.EntityID,
+			ProjectID: event.ProjectID,
+		}); err != nil {
+			return fmt.Errorf("error deleting organization: %w", err)
+		}
+		return nil
+	}
+
 	// Remove the entry in the DB. There's no need to clean any webhook we created for this repository, as GitHub
 	// will automatically remove them when the repository is deleted.
 	err := r.repos.DeleteByID(ctx, event.EntityID, event.ProjectID)
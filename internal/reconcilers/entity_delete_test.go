@@ -15,6 +15,7 @@ import (
 
 	mockdb "github.com/mindersec/minder/database/mock"
 	df "github.com/mindersec/minder/database/mock/fixtures"
+	"github.com/mindersec/minder/internal/db"
 	"github.com/mindersec/minder/internal/entities/properties/service"
 	"github.com/mindersec/minder/internal/reconcilers/messages"
 	mockrepo "github.com/mindersec/minder/internal/repositories/mock"
@@ -28,6 +29,8 @@ var (
 	projectID    = uuid.New()
 	providerID   = uuid.New()
 	repositoryID = uuid.New()
+
+	organizationID = uuid.New()
 )
 
 type testCase struct {
@@ -111,6 +114,32 @@ func TestHandleEntityDelete(t *testing.T) {
 			},
 			err: true,
 		},
+		{
+			name: "organization",
+			mockStoreFunc: df.NewMockStore(
+				func(mockStore *mockdb.MockStore) {
+					mockStore.EXPECT().
+						DeleteEntity(gomock.Any(), db.DeleteEntityParams{
+							ID:        organizationID,
+							ProjectID: projectID,
+						}).
+						Return(nil)
+				},
+			),
+			messageFunc: func(t *testing.T) *message.Message {
+				t.Helper()
+				m := message.NewMessage(uuid.New().String(), nil)
+				eiw := messages.NewMinderEvent().
+					WithProviderID(providerID).
+					WithProjectID(projectID).
+					WithEntityType(pb.Entity_ENTITY_ORGANIZATION).
+					WithEntityID(organizationID)
+
+				err := eiw.ToMessage(m)
+				require.NoError(t, err, "invalid message")
+				return m
+			},
+		},
 		{
 			name:          "bad message",
 			mockStoreFunc: nil,
@@ -172,6 +201,7 @@ func setUp(t *testing.T, tt testCase, ctrl *gomock.Controller) *Reconciler {
 		nil, // crypto.Engine not used in these tests
 		nil, // manager.ProviderManager not used in these tests
 		repoService,
+		nil, // propertyService.PropertiesService not used in these tests
 	)
 	require.NoError(t, err)
 
@@ -10,36 +10,69 @@ import (
 
 	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/google/uuid"
+
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
 )
 
-// EntityReminderEvent is an event that is published by the reminder service to trigger repo reconciliation
+// EntityReminderEvent is an event that is published by the reminder service to trigger entity reconciliation
 type EntityReminderEvent struct {
 	// Project is the project that the event is relevant to
 	Project uuid.UUID `json:"project"`
-	// ProviderID is the provider of the repository
+	// ProviderID is the provider of the entity
 	ProviderID uuid.UUID `json:"provider"`
-	// EntityID is the entity id of the repository to be reconciled
+	// EntityID is the entity id of the entity to be reconciled
 	EntityID uuid.UUID `json:"entity_id"`
+	// EntityType is the type of the entity to be reconciled
+	EntityType minderv1.Entity `json:"entity_type"`
+	// ProfileID is the profile to evaluate the entity against, for profiles
+	// with a schedule. It is nil for reminders to evaluate the entity against
+	// all the profiles without a schedule.
+	ProfileID uuid.UUID `json:"profile_id"`
+}
+
+// NewEntityReminderMessage creates a new entity reminder message
+func NewEntityReminderMessage(
+	providerId uuid.UUID, entityID uuid.UUID, projectID uuid.UUID, entityType minderv1.Entity,
+) (*message.Message, error) {
+	evt := &EntityReminderEvent{
+		Project:    projectID,
+		ProviderID: providerId,
+		EntityID:   entityID,
+		EntityType: entityType,
+	}
+
+	evtStr, err := json.Marshal(evt)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling entity reminder event: %w", err)
+	}
+
+	msg := message.NewMessage(uuid.New().String(), evtStr)
+	return msg, nil
 }
 
-// NewEntityReminderMessage creates a new repo reminder message
-func NewEntityReminderMessage(providerId uuid.UUID, entityID uuid.UUID, projectID uuid.UUID) (*message.Message, error) {
+// NewProfileEvaluationReminderMessage creates a new message reminding to
+// evaluate an entity against a profile with a schedule
+func NewProfileEvaluationReminderMessage(
+	providerId uuid.UUID, entityID uuid.UUID, projectID uuid.UUID, entityType minderv1.Entity, profileID uuid.UUID,
+) (*message.Message, error) {
 	evt := &EntityReminderEvent{
 		Project:    projectID,
 		ProviderID: providerId,
 		EntityID:   entityID,
+		EntityType: entityType,
+		ProfileID:  profileID,
 	}
 
 	evtStr, err := json.Marshal(evt)
 	if err != nil {
-		return nil, fmt.Errorf("error marshalling repo reminder event: %w", err)
+		return nil, fmt.Errorf("error marshalling profile evaluation reminder event: %w", err)
 	}
 
 	msg := message.NewMessage(uuid.New().String(), evtStr)
 	return msg, nil
 }
 
-// EntityReminderEventFromMessage creates a new repo reminder event from a message
+// EntityReminderEventFromMessage creates a new entity reminder event from a message
 func EntityReminderEventFromMessage(msg *message.Message) (*EntityReminderEvent, error) {
 	var evt EntityReminderEvent
 	if err := json.Unmarshal(msg.Payload, &evt); err != nil {
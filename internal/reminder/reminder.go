@@ -16,6 +16,7 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/internal/engine/entities"
 	remindermessages "github.com/mindersec/minder/internal/reminder/messages"
 	reminderconfig "github.com/mindersec/minder/pkg/config/reminder"
 	"github.com/mindersec/minder/pkg/eventer/constants"
@@ -37,25 +38,44 @@ type reminder struct {
 	stop     chan struct{}
 	stopOnce sync.Once
 
-	repositoryCursor uuid.UUID
+	// entities holds the reminder state of each entity type reminders are sent for
+	entities []*entityReminder
+
+	// schedules holds the state of the evaluations of the profiles with a
+	// schedule. It is nil if those evaluations are disabled.
+	schedules *profileScheduler
 
 	ticker *time.Ticker
 
 	eventPublisher message.Publisher
 }
 
+// entityReminder holds the reminder state of a single entity type
+type entityReminder struct {
+	entityType db.Entities
+	interval   time.Duration
+	minElapsed time.Duration
+
+	// cursor is the ID of the last entity of this type that was fetched
+	cursor uuid.UUID
+	// skipTicks is the number of ticks to skip before sending the next batch
+	skipTicks int64
+}
+
 // NewReminder creates a new reminder instance
 func NewReminder(ctx context.Context, store db.Store, config *reminderconfig.Config) (Interface, error) {
 	r := &reminder{
-		store: store,
-		cfg:   config,
-		stop:  make(chan struct{}),
+		store:     store,
+		cfg:       config,
+		stop:      make(chan struct{}),
+		entities:  newEntityReminders(config.RecurrenceConfig),
+		schedules: newProfileScheduler(config.RecurrenceConfig.ProfileSchedules),
 	}
 
-	// Set to a random UUID to start
-	r.repositoryCursor = uuid.New()
 	logger := zerolog.Ctx(ctx)
-	logger.Info().Msgf("initial repository cursor: %s", r.repositoryCursor)
+	for _, e := range r.entities {
+		logger.Info().Msgf("initial %s cursor: %s", e.entityType, e.cursor)
+	}
 
 	pub, err := r.getMessagePublisher(ctx)
 	if err != nil {
@@ -66,6 +86,35 @@ func NewReminder(ctx context.Context, store db.Store, config *reminderconfig.Con
 	return r, nil
 }
 
+func newEntityReminders(cfg reminderconfig.RecurrenceConfig) []*entityReminder {
+	entityTypes := []struct {
+		entityType db.Entities
+		cfg        reminderconfig.EntityRecurrenceConfig
+	}{
+		{db.EntitiesRepository, cfg.EntityTypes.Repository},
+		{db.EntitiesArtifact, cfg.EntityTypes.Artifact},
+		{db.EntitiesPullRequest, cfg.EntityTypes.PullRequest},
+		{db.EntitiesRelease, cfg.EntityTypes.Release},
+	}
+
+	reminders := make([]*entityReminder, 0, len(entityTypes))
+	for _, et := range entityTypes {
+		if !et.cfg.Enabled {
+			continue
+		}
+
+		reminders = append(reminders, &entityReminder{
+			entityType: et.entityType,
+			interval:   cfg.GetInterval(et.cfg),
+			minElapsed: cfg.GetMinElapsed(et.cfg),
+			// Set to a random UUID to start
+			cursor: uuid.New(),
+		})
+	}
+
+	return reminders
+}
+
 // Start starts the reminder by sending reminders at regular intervals
 func (r *reminder) Start(ctx context.Context) error {
 	logger := zerolog.Ctx(ctx)
@@ -123,34 +172,70 @@ func (r *reminder) Stop() {
 }
 
 func (r *reminder) sendReminders(ctx context.Context) error {
-	logger := zerolog.Ctx(ctx)
+	var errs []error
+	for _, e := range r.entities {
+		if !e.due(r.cfg.RecurrenceConfig.Interval) {
+			continue
+		}
 
-	// Fetch a batch of repositories
-	repos, err := r.getRepositoryBatch(ctx)
+		if err := r.sendEntityReminders(ctx, e); err != nil {
+			errs = append(errs, fmt.Errorf("error sending %s reminders: %w", e.entityType, err))
+		}
+	}
+
+	if r.schedules != nil {
+		if err := r.sendScheduledEvaluations(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("error sending scheduled evaluations: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// due reports whether a batch should be sent for the entity type on the
+// current tick. Entity types with an interval longer than the global interval
+// only send a batch every few ticks.
+func (e *entityReminder) due(tick time.Duration) bool {
+	if e.skipTicks > 0 {
+		e.skipTicks--
+		return false
+	}
+
+	if tick > 0 {
+		e.skipTicks = int64((e.interval+tick-1)/tick) - 1
+	}
+	return true
+}
+
+func (r *reminder) sendEntityReminders(ctx context.Context, e *entityReminder) error {
+	logger := zerolog.Ctx(ctx).With().Str("entity_type", string(e.entityType)).Logger()
+
+	// Fetch a batch of entities
+	ents, err := r.getEntityBatch(ctx, e)
 	if err != nil {
-		return fmt.Errorf("error fetching repository batch: %w", err)
+		return fmt.Errorf("error fetching entity batch: %w", err)
 	}
 
-	if len(repos) == 0 {
-		logger.Debug().Msg("no repositories to send reminders for")
+	if len(ents) == 0 {
+		logger.Debug().Msg("no entities to send reminders for")
 		return nil
 	}
 
-	logger.Info().Msgf("created repository batch of size: %d", len(repos))
+	logger.Info().Msgf("created entity batch of size: %d", len(ents))
 
-	messages, err := createReminderMessages(ctx, repos)
+	messages, err := createReminderMessages(ctx, ents)
 	if err != nil {
 		return fmt.Errorf("error creating reminder messages: %w", err)
 	}
 
-	err = r.eventPublisher.Publish(constants.TopicQueueRepoReminder, messages...)
+	err = r.eventPublisher.Publish(constants.TopicQueueEntityReminder, messages...)
 	if err != nil {
 		return fmt.Errorf("error publishing messages: %w", err)
 	}
 
-	repoIds := make([]uuid.UUID, len(repos))
-	for _, repo := range repos {
-		repoIds = append(repoIds, repo.ID)
+	entityIds := make([]uuid.UUID, 0, len(ents))
+	for _, ent := range ents {
+		entityIds = append(entityIds, ent.ID)
 	}
 
 	// TODO: Collect Metrics
@@ -159,7 +244,7 @@ func (r *reminder) sendReminders(ctx context.Context) error {
 	// - UpDownCounter: Average reminders sent per batch
 	// - Histogram: reminder_last_sent time distribution
 
-	err = r.store.UpdateReminderLastSentForRepositories(ctx, repoIds)
+	err = r.store.UpdateReminderLastSentForEntities(ctx, entityIds)
 	if err != nil {
 		return fmt.Errorf("reminders published but error updating last sent time: %w", err)
 	}
@@ -167,106 +252,114 @@ func (r *reminder) sendReminders(ctx context.Context) error {
 	return nil
 }
 
-func (r *reminder) getRepositoryBatch(ctx context.Context) ([]db.Repository, error) {
+func (r *reminder) getEntityBatch(ctx context.Context, e *entityReminder) ([]db.EntityInstance, error) {
 	logger := zerolog.Ctx(ctx)
 
-	logger.Debug().Msgf("fetching repositories after cursor: %s", r.repositoryCursor)
-	repos, err := r.store.ListRepositoriesAfterID(ctx, db.ListRepositoriesAfterIDParams{
-		ID:    r.repositoryCursor,
-		Limit: int64(r.cfg.RecurrenceConfig.BatchSize),
+	logger.Debug().Msgf("fetching %s entities after cursor: %s", e.entityType, e.cursor)
+	ents, err := r.store.ListEntitiesAfterID(ctx, db.ListEntitiesAfterIDParams{
+		EntityType: e.entityType,
+		ID:         e.cursor,
+		Limit:      int64(r.cfg.RecurrenceConfig.BatchSize),
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	eligibleRepos, err := r.getEligibleRepositories(ctx, repos)
+	eligibleEntities, err := r.getEligibleEntities(ctx, e, ents)
 	if err != nil {
 		return nil, err
 	}
-	logger.Debug().Msgf("%d/%d repositories are eligible for reminders", len(eligibleRepos), len(repos))
+	logger.Debug().Msgf("%d/%d %s entities are eligible for reminders",
+		len(eligibleEntities), len(ents), e.entityType)
 
-	r.updateRepositoryCursor(ctx, repos)
+	r.updateEntityCursor(ctx, e, ents)
 
-	return eligibleRepos, nil
+	return eligibleEntities, nil
 }
 
-func (r *reminder) getEligibleRepositories(ctx context.Context, repos []db.Repository) ([]db.Repository, error) {
-	eligibleRepos := make([]db.Repository, 0, len(repos))
+func (r *reminder) getEligibleEntities(
+	ctx context.Context, e *entityReminder, ents []db.EntityInstance,
+) ([]db.EntityInstance, error) {
+	eligibleEntities := make([]db.EntityInstance, 0, len(ents))
 
-	// We have a slice of repositories, but the sqlc-generated code wants a slice of UUIDs,
+	// We have a slice of entities, but the sqlc-generated code wants a slice of UUIDs,
 	// and similarly returns slices of ID -> date (in possibly different order), so we need
 	// to do a bunch of mapping here.
-	repoIds := make([]uuid.UUID, 0, len(repos))
-	for _, repo := range repos {
-		repoIds = append(repoIds, repo.ID)
+	entityIds := make([]uuid.UUID, 0, len(ents))
+	for _, ent := range ents {
+		entityIds = append(entityIds, ent.ID)
 	}
-	oldestRuleEvals, err := r.store.ListOldestRuleEvaluationsByRepositoryId(ctx, repoIds)
+	oldestRuleEvals, err := r.store.ListOldestRuleEvaluationsByEntityID(ctx, entityIds)
 	if err != nil {
 		return nil, err
 	}
 	idToLastUpdate := make(map[uuid.UUID]time.Time, len(oldestRuleEvals))
 	for _, times := range oldestRuleEvals {
-		idToLastUpdate[times.RepositoryID] = times.OldestLastUpdated
+		idToLastUpdate[times.EntityID] = times.OldestLastUpdated
 	}
 
-	cutoff := time.Now().Add(-1 * r.cfg.RecurrenceConfig.MinElapsed)
-	for _, repo := range repos {
-		if t, ok := idToLastUpdate[repo.ID]; ok && t.Before(cutoff) {
-			eligibleRepos = append(eligibleRepos, repo)
+	cutoff := time.Now().Add(-1 * e.minElapsed)
+	for _, ent := range ents {
+		if t, ok := idToLastUpdate[ent.ID]; ok && t.Before(cutoff) {
+			eligibleEntities = append(eligibleEntities, ent)
 		}
 	}
 
-	return eligibleRepos, nil
+	return eligibleEntities, nil
 }
 
-func (r *reminder) updateRepositoryCursor(ctx context.Context, repos []db.Repository) {
+func (r *reminder) updateEntityCursor(ctx context.Context, e *entityReminder, ents []db.EntityInstance) {
 	logger := zerolog.Ctx(ctx)
 
-	if len(repos) == 0 {
-		r.repositoryCursor = uuid.Nil
+	if len(ents) == 0 {
+		e.cursor = uuid.Nil
 	} else {
-		r.repositoryCursor = repos[len(repos)-1].ID
-		r.adjustCursorForEndOfList(ctx)
+		e.cursor = ents[len(ents)-1].ID
+		r.adjustCursorForEndOfList(ctx, e)
 	}
 
-	logger.Debug().Msgf("updated repository cursor to: %s", r.repositoryCursor)
+	logger.Debug().Msgf("updated %s cursor to: %s", e.entityType, e.cursor)
 }
 
-func (r *reminder) adjustCursorForEndOfList(ctx context.Context) {
+func (r *reminder) adjustCursorForEndOfList(ctx context.Context, e *entityReminder) {
 	logger := zerolog.Ctx(ctx)
 	// Check if the cursor is the last element in the db
-	exists, err := r.store.RepositoryExistsAfterID(ctx, r.repositoryCursor)
+	exists, err := r.store.EntityExistsAfterID(ctx, db.EntityExistsAfterIDParams{
+		EntityType: e.entityType,
+		ID:         e.cursor,
+	})
 	if err != nil {
-		logger.Error().Err(err).Msgf("unable to check if repository exists after cursor: %s"+
-			", resetting cursor to zero uuid", r.repositoryCursor)
-		r.repositoryCursor = uuid.Nil
+		logger.Error().Err(err).Msgf("unable to check if %s exists after cursor: %s"+
+			", resetting cursor to zero uuid", e.entityType, e.cursor)
+		e.cursor = uuid.Nil
 		return
 	}
 
 	if !exists {
-		logger.Info().Msgf("cursor %s is at the end of the list, resetting cursor to zero uuid",
-			r.repositoryCursor)
-		r.repositoryCursor = uuid.Nil
+		logger.Info().Msgf("%s cursor %s is at the end of the list, resetting cursor to zero uuid",
+			e.entityType, e.cursor)
+		e.cursor = uuid.Nil
 	}
 }
 
-func createReminderMessages(ctx context.Context, repos []db.Repository) ([]*message.Message, error) {
+func createReminderMessages(ctx context.Context, ents []db.EntityInstance) ([]*message.Message, error) {
 	logger := zerolog.Ctx(ctx)
 
-	messages := make([]*message.Message, 0, len(repos))
-	for _, repo := range repos {
-		repoReconcileMessage, err := remindermessages.NewEntityReminderMessage(
-			repo.ProviderID, repo.ID, repo.ProjectID,
+	messages := make([]*message.Message, 0, len(ents))
+	for _, ent := range ents {
+		reminderMessage, err := remindermessages.NewEntityReminderMessage(
+			ent.ProviderID, ent.ID, ent.ProjectID, entities.EntityTypeFromDB(ent.EntityType),
 		)
 		if err != nil {
 			return nil, fmt.Errorf("error creating reminder message: %w", err)
 		}
 
 		logger.Debug().
-			Str("repo", repo.ID.String()).
+			Str("entity", ent.ID.String()).
+			Str("entity_type", string(ent.EntityType)).
 			Msg("created reminder message")
 
-		messages = append(messages, repoReconcileMessage)
+		messages = append(messages, reminderMessage)
 	}
 
 	return messages, nil
@@ -19,17 +19,17 @@ import (
 	reminderconfig "github.com/mindersec/minder/pkg/config/reminder"
 )
 
-func Test_getRepositoryBatch(t *testing.T) {
+func Test_getEntityBatch(t *testing.T) {
 	t.Parallel()
 
 	type expectedOutput struct {
-		repos      []db.Repository
-		repoCursor uuid.UUID
+		entities     []db.EntityInstance
+		entityCursor uuid.UUID
 	}
 
 	type input struct {
-		repos []db.Repository
-		cfg   reminderconfig.RecurrenceConfig
+		entities   []db.EntityInstance
+		minElapsed time.Duration
 	}
 
 	tests := []struct {
@@ -40,105 +40,87 @@ func Test_getRepositoryBatch(t *testing.T) {
 		err            string
 	}{
 		{
-			name: "no repos",
+			name: "no entities",
 			input: input{
-				cfg: reminderconfig.RecurrenceConfig{
-					BatchSize:  5,
-					MinElapsed: time.Hour,
-				},
+				minElapsed: time.Hour,
 			},
 			setup: func(store *mockdb.MockStore, _ input) {
-				store.EXPECT().ListRepositoriesAfterID(gomock.Any(), gomock.Any()).Return(nil, nil)
-				store.EXPECT().ListOldestRuleEvaluationsByRepositoryId(gomock.Any(), []uuid.UUID{}).Return(nil, nil)
+				store.EXPECT().ListEntitiesAfterID(gomock.Any(), gomock.Any()).Return(nil, nil)
+				store.EXPECT().ListOldestRuleEvaluationsByEntityID(gomock.Any(), []uuid.UUID{}).Return(nil, nil)
 			},
 		},
 		{
-			name: "error listing repos",
+			name: "error listing entities",
 			input: input{
-				cfg: reminderconfig.RecurrenceConfig{
-					BatchSize:  5,
-					MinElapsed: time.Hour,
-				},
+				minElapsed: time.Hour,
 			},
 			setup: func(store *mockdb.MockStore, _ input) {
-				store.EXPECT().ListRepositoriesAfterID(gomock.Any(), gomock.Any()).Return(nil, sql.ErrConnDone)
+				store.EXPECT().ListEntitiesAfterID(gomock.Any(), gomock.Any()).Return(nil, sql.ErrConnDone)
 			},
 			err: sql.ErrConnDone.Error(),
 		},
 		{
-			name: "repo exists after ID",
+			name: "entity exists after ID",
 			input: input{
-				repos: getReposTillId(t, 2),
-				cfg: reminderconfig.RecurrenceConfig{
-					BatchSize:  5,
-					MinElapsed: time.Minute,
-				},
+				entities:   getEntitiesTillId(t, 2),
+				minElapsed: time.Minute,
 			},
 			expectedOutput: expectedOutput{
-				repos:      getReposTillId(t, 2),
-				repoCursor: generateUUIDFromNum(t, 2),
+				entities:     getEntitiesTillId(t, 2),
+				entityCursor: generateUUIDFromNum(t, 2),
 			},
 			setup: func(store *mockdb.MockStore, in input) {
-				store.EXPECT().ListRepositoriesAfterID(gomock.Any(), gomock.Any()).Return(in.repos, nil)
-				store.EXPECT().ListOldestRuleEvaluationsByRepositoryId(gomock.Any(), gomock.Any()).Return(getStandardOldestRuleEvals(t, in.repos), nil)
-				store.EXPECT().RepositoryExistsAfterID(gomock.Any(), gomock.Any()).Return(true, nil)
+				store.EXPECT().ListEntitiesAfterID(gomock.Any(), gomock.Any()).Return(in.entities, nil)
+				store.EXPECT().ListOldestRuleEvaluationsByEntityID(gomock.Any(), gomock.Any()).Return(getStandardOldestRuleEvals(t, in.entities), nil)
+				store.EXPECT().EntityExistsAfterID(gomock.Any(), gomock.Any()).Return(true, nil)
 			},
 		},
 		{
-			name: "repo does not exist after ID",
+			name: "entity does not exist after ID",
 			input: input{
-				repos: getReposTillId(t, 2),
-				cfg: reminderconfig.RecurrenceConfig{
-					BatchSize:  5,
-					MinElapsed: time.Minute,
-				},
+				entities:   getEntitiesTillId(t, 2),
+				minElapsed: time.Minute,
 			},
 			expectedOutput: expectedOutput{
-				repos: getReposTillId(t, 2),
+				entities: getEntitiesTillId(t, 2),
 			},
 			setup: func(store *mockdb.MockStore, in input) {
-				store.EXPECT().ListRepositoriesAfterID(gomock.Any(), gomock.Any()).Return(in.repos, nil)
-				store.EXPECT().ListOldestRuleEvaluationsByRepositoryId(gomock.Any(), gomock.Any()).Return(getStandardOldestRuleEvals(t, in.repos), nil)
-				store.EXPECT().RepositoryExistsAfterID(gomock.Any(), gomock.Any()).Return(false, nil)
+				store.EXPECT().ListEntitiesAfterID(gomock.Any(), gomock.Any()).Return(in.entities, nil)
+				store.EXPECT().ListOldestRuleEvaluationsByEntityID(gomock.Any(), gomock.Any()).Return(getStandardOldestRuleEvals(t, in.entities), nil)
+				store.EXPECT().EntityExistsAfterID(gomock.Any(), gomock.Any()).Return(false, nil)
 			},
 		},
 		{
-			name: "error checking if repo exists after ID",
+			name: "error checking if entity exists after ID",
 			input: input{
-				repos: getReposTillId(t, 3),
-				cfg: reminderconfig.RecurrenceConfig{
-					BatchSize:  5,
-					MinElapsed: time.Minute,
-				},
+				entities:   getEntitiesTillId(t, 3),
+				minElapsed: time.Minute,
 			},
 			expectedOutput: expectedOutput{
-				repos: getReposTillId(t, 3),
+				entities: getEntitiesTillId(t, 3),
 			},
 			setup: func(store *mockdb.MockStore, in input) {
-				store.EXPECT().ListRepositoriesAfterID(gomock.Any(), gomock.Any()).Return(in.repos, nil)
-				store.EXPECT().ListOldestRuleEvaluationsByRepositoryId(gomock.Any(), gomock.Any()).Return(getStandardOldestRuleEvals(t, in.repos), nil)
-				store.EXPECT().RepositoryExistsAfterID(gomock.Any(), gomock.Any()).Return(false, sql.ErrConnDone)
+				store.EXPECT().ListEntitiesAfterID(gomock.Any(), gomock.Any()).Return(in.entities, nil)
+				store.EXPECT().ListOldestRuleEvaluationsByEntityID(gomock.Any(), gomock.Any()).Return(getStandardOldestRuleEvals(t, in.entities), nil)
+				store.EXPECT().EntityExistsAfterID(gomock.Any(), gomock.Any()).Return(false, sql.ErrConnDone)
 			},
 		},
 		{
-			name: "some repos are eligible",
+			name: "some entities are eligible",
 			input: input{
-				repos: getReposTillId(t, 3),
-				cfg: reminderconfig.RecurrenceConfig{
-					BatchSize:  5,
-					MinElapsed: 10 * time.Minute,
-				},
+				entities:   getEntitiesTillId(t, 3),
+				minElapsed: 10 * time.Minute,
 			},
 			expectedOutput: expectedOutput{
-				repos:      getReposTillId(t, 2),
-				repoCursor: generateUUIDFromNum(t, 3),
+				entities:     getEntitiesTillId(t, 2),
+				entityCursor: generateUUIDFromNum(t, 3),
 			},
 			setup: func(store *mockdb.MockStore, in input) {
-				store.EXPECT().ListRepositoriesAfterID(gomock.Any(), gomock.Any()).Return(in.repos, nil)
-				oldestRuleEvals := getStandardOldestRuleEvals(t, in.repos)
+				store.EXPECT().ListEntitiesAfterID(gomock.Any(), gomock.Any()).Return(in.entities, nil)
+				oldestRuleEvals := getStandardOldestRuleEvals(t, in.entities)
 				oldestRuleEvals[2].OldestLastUpdated = time.Now().Add(-time.Second)
-				store.EXPECT().ListOldestRuleEvaluationsByRepositoryId(gomock.Any(), gomock.Any()).Return(oldestRuleEvals, nil)
-				store.EXPECT().RepositoryExistsAfterID(gomock.Any(), gomock.Any()).Return(true, nil)
+				store.EXPECT().ListOldestRuleEvaluationsByEntityID(gomock.Any(), gomock.Any()).Return(oldestRuleEvals, nil)
+				store.EXPECT().EntityExistsAfterID(gomock.Any(), gomock.Any()).Return(true, nil)
 			},
 		},
 	}
@@ -154,19 +136,25 @@ func Test_getRepositoryBatch(t *testing.T) {
 			store := mockdb.NewMockStore(ctrl)
 			test.setup(store, test.input)
 			cfg := &reminderconfig.Config{
-				RecurrenceConfig: test.input.cfg,
+				RecurrenceConfig: reminderconfig.RecurrenceConfig{
+					BatchSize: 5,
+				},
 			}
 
 			r := createTestReminder(t, store, cfg)
+			e := &entityReminder{
+				entityType: db.EntitiesRepository,
+				minElapsed: test.input.minElapsed,
+			}
 
-			got, err := r.getRepositoryBatch(context.Background())
+			got, err := r.getEntityBatch(context.Background(), e)
 			if test.err != "" {
 				require.ErrorContains(t, err, test.err)
 				return
 			}
 			require.NoError(t, err)
-			require.ElementsMatch(t, got, test.expectedOutput.repos)
-			require.Equal(t, test.expectedOutput.repoCursor, r.repositoryCursor)
+			require.ElementsMatch(t, got, test.expectedOutput.entities)
+			require.Equal(t, test.expectedOutput.entityCursor, e.cursor)
 		})
 	}
 }
@@ -186,15 +174,18 @@ func generateUUIDFromNum(t *testing.T, num int) uuid.UUID {
 	return u
 }
 
-func getReposTillId(t *testing.T, id int) []db.Repository {
+func getEntitiesTillId(t *testing.T, id int) []db.EntityInstance {
 	t.Helper()
 
-	repos := make([]db.Repository, 0, id)
+	ents := make([]db.EntityInstance, 0, id)
 	for i := 1; i <= id; i++ {
-		repos = append(repos, db.Repository{ID: generateUUIDFromNum(t, i)})
+		ents = append(ents, db.EntityInstance{
+			ID:         generateUUIDFromNum(t, i),
+			EntityType: db.EntitiesRepository,
+		})
 	}
 
-	return repos
+	return ents
 }
 
 func createTestReminder(t *testing.T, store db.Store, config *reminderconfig.Config) *reminder {
@@ -206,16 +197,61 @@ func createTestReminder(t *testing.T, store db.Store, config *reminderconfig.Con
 	}
 }
 
-func getStandardOldestRuleEvals(t *testing.T, repos []db.Repository) []db.ListOldestRuleEvaluationsByRepositoryIdRow {
+func getStandardOldestRuleEvals(t *testing.T, ents []db.EntityInstance) []db.ListOldestRuleEvaluationsByEntityIDRow {
 	t.Helper()
 
-	oldestRuleEvals := make([]db.ListOldestRuleEvaluationsByRepositoryIdRow, 0, len(repos))
-	for _, repo := range repos {
-		oldestRuleEvals = append(oldestRuleEvals, db.ListOldestRuleEvaluationsByRepositoryIdRow{
-			RepositoryID:      repo.ID,
+	oldestRuleEvals := make([]db.ListOldestRuleEvaluationsByEntityIDRow, 0, len(ents))
+	for _, ent := range ents {
+		oldestRuleEvals = append(oldestRuleEvals, db.ListOldestRuleEvaluationsByEntityIDRow{
+			EntityID:          ent.ID,
 			OldestLastUpdated: time.Now().Add(-time.Hour),
 		})
 	}
 
 	return oldestRuleEvals
 }
+
+func Test_entityReminderDue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		interval time.Duration
+		tick     time.Duration
+		expected []bool
+	}{
+		{
+			name:     "same as global interval",
+			interval: time.Hour,
+			tick:     time.Hour,
+			expected: []bool{true, true, true},
+		},
+		{
+			name:     "multiple of global interval",
+			interval: 3 * time.Hour,
+			tick:     time.Hour,
+			expected: []bool{true, false, false, true, false, false},
+		},
+		{
+			name:     "rounded up to global interval",
+			interval: 90 * time.Minute,
+			tick:     time.Hour,
+			expected: []bool{true, false, true, false},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := &entityReminder{interval: test.interval}
+			got := make([]bool, 0, len(test.expected))
+			for range test.expected {
+				got = append(got, e.due(test.tick))
+			}
+			require.Equal(t, test.expected, got)
+		})
+	}
+}
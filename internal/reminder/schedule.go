@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reminder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/internal/engine/entities"
+	remindermessages "github.com/mindersec/minder/internal/reminder/messages"
+	reminderconfig "github.com/mindersec/minder/pkg/config/reminder"
+	"github.com/mindersec/minder/pkg/eventer/constants"
+	"github.com/mindersec/minder/pkg/profiles/models"
+)
+
+// profileScheduler holds the state of the evaluations of the profiles with a
+// schedule. Those profiles are not evaluated on entity events, so the reminder
+// sends an evaluation for each entity they apply to whenever it is due.
+type profileScheduler struct {
+	retryAfter time.Duration
+
+	// sent holds when an evaluation was last sent for a profile and entity,
+	// so it isn't sent again while it is being processed
+	sent map[profileEntity]time.Time
+}
+
+type profileEntity struct {
+	profileID uuid.UUID
+	entityID  uuid.UUID
+}
+
+func newProfileScheduler(cfg reminderconfig.ProfileSchedulesConfig) *profileScheduler {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	return &profileScheduler{
+		retryAfter: cfg.RetryAfter,
+		sent:       make(map[profileEntity]time.Time),
+	}
+}
+
+func (r *reminder) sendScheduledEvaluations(ctx context.Context) error {
+	logger := zerolog.Ctx(ctx)
+
+	profiles, err := r.store.ListScheduledProfiles(ctx)
+	if err != nil {
+		return fmt.Errorf("error listing scheduled profiles: %w", err)
+	}
+
+	now := time.Now()
+	r.schedules.forgetSentBefore(now.Add(-r.schedules.retryAfter))
+
+	var errs []error
+	for _, profile := range profiles {
+		messages, sent, err := r.getDueProfileEvaluations(ctx, profile, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error getting evaluations of profile %s: %w", profile.ID, err))
+			continue
+		}
+
+		if len(messages) == 0 {
+			continue
+		}
+
+		logger.Info().Str("profile_id", profile.ID.String()).
+			Msgf("sending %d scheduled evaluations", len(messages))
+
+		if err := r.eventPublisher.Publish(constants.TopicQueueEntityReminder, messages...); err != nil {
+			errs = append(errs, fmt.Errorf("error publishing evaluations of profile %s: %w", profile.ID, err))
+			continue
+		}
+
+		for _, key := range sent {
+			r.schedules.sent[key] = now
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// getDueProfileEvaluations returns the messages for the entities a profile
+// is due to be evaluated for, up to the batch size, along with the keys to
+// record them as sent with
+func (r *reminder) getDueProfileEvaluations(
+	ctx context.Context, profile db.Profile, now time.Time,
+) ([]*message.Message, []profileEntity, error) {
+	schedule, err := models.ScheduleFromDB(profile.ScheduleCron, profile.ScheduleMinFreshness)
+	if err != nil {
+		return nil, nil, err
+	}
+	if schedule == nil {
+		return nil, nil, nil
+	}
+
+	ents, err := r.store.ListProfileEvaluationTimesByEntity(ctx, db.ListProfileEvaluationTimesByEntityParams{
+		ProjectID: profile.ProjectID,
+		ProfileID: profile.ID,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error listing entities: %w", err)
+	}
+
+	messages := make([]*message.Message, 0)
+	sent := make([]profileEntity, 0)
+	for _, ent := range ents {
+		if len(messages) >= r.cfg.RecurrenceConfig.BatchSize {
+			break
+		}
+
+		key := profileEntity{profileID: profile.ID, entityID: ent.EntityID}
+		if _, ok := r.schedules.sent[key]; ok {
+			continue
+		}
+
+		// a NULL time means the profile was never fully evaluated for the entity
+		if !schedule.Due(ent.OldestLastUpdated.Time, now) {
+			continue
+		}
+
+		msg, err := remindermessages.NewProfileEvaluationReminderMessage(
+			ent.ProviderID, ent.EntityID, ent.ProjectID, entities.EntityTypeFromDB(ent.EntityType), profile.ID,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error creating reminder message: %w", err)
+		}
+
+		messages = append(messages, msg)
+		sent = append(sent, key)
+	}
+
+	return messages, sent, nil
+}
+
+// forgetSentBefore drops the evaluations sent before the cutoff, so they are
+// sent again if they are still due
+func (s *profileScheduler) forgetSentBefore(cutoff time.Time) {
+	for key, sentAt := range s.sent {
+		if sentAt.Before(cutoff) {
+			delete(s.sent, key)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reminder
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockdb "github.com/mindersec/minder/database/mock"
+	"github.com/mindersec/minder/internal/db"
+	remindermessages "github.com/mindersec/minder/internal/reminder/messages"
+	reminderconfig "github.com/mindersec/minder/pkg/config/reminder"
+	"github.com/mindersec/minder/pkg/eventer/constants"
+)
+
+type fakePublisher struct {
+	published map[string][]*message.Message
+}
+
+func (f *fakePublisher) Publish(topic string, messages ...*message.Message) error {
+	f.published[topic] = append(f.published[topic], messages...)
+	return nil
+}
+
+func (_ *fakePublisher) Close() error {
+	return nil
+}
+
+func Test_sendScheduledEvaluations(t *testing.T) {
+	t.Parallel()
+
+	profile := db.Profile{
+		ID:                   uuid.New(),
+		ProjectID:            uuid.New(),
+		ScheduleMinFreshness: "24h",
+	}
+
+	// never evaluated
+	newEntity := db.ListProfileEvaluationTimesByEntityRow{
+		EntityID:   generateUUIDFromNum(t, 1),
+		EntityType: db.EntitiesRepository,
+		ProjectID:  profile.ProjectID,
+		ProviderID: uuid.New(),
+	}
+	staleEntity := db.ListProfileEvaluationTimesByEntityRow{
+		EntityID:   generateUUIDFromNum(t, 2),
+		EntityType: db.EntitiesRepository,
+		ProjectID:  profile.ProjectID,
+		ProviderID: uuid.New(),
+		OldestLastUpdated: sql.NullTime{
+			Time:  time.Now().Add(-25 * time.Hour),
+			Valid: true,
+		},
+	}
+	freshEntity := db.ListProfileEvaluationTimesByEntityRow{
+		EntityID:   generateUUIDFromNum(t, 3),
+		EntityType: db.EntitiesRepository,
+		ProjectID:  profile.ProjectID,
+		ProviderID: uuid.New(),
+		OldestLastUpdated: sql.NullTime{
+			Time:  time.Now().Add(-time.Hour),
+			Valid: true,
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ListScheduledProfiles(gomock.Any()).Return([]db.Profile{profile}, nil).Times(2)
+	store.EXPECT().ListProfileEvaluationTimesByEntity(gomock.Any(), db.ListProfileEvaluationTimesByEntityParams{
+		ProjectID: profile.ProjectID,
+		ProfileID: profile.ID,
+	}).Return([]db.ListProfileEvaluationTimesByEntityRow{newEntity, staleEntity, freshEntity}, nil).Times(2)
+
+	pub := &fakePublisher{published: map[string][]*message.Message{}}
+	r := createTestReminder(t, store, &reminderconfig.Config{
+		RecurrenceConfig: reminderconfig.RecurrenceConfig{
+			BatchSize: 5,
+		},
+	})
+	r.eventPublisher = pub
+	r.schedules = newProfileScheduler(reminderconfig.ProfileSchedulesConfig{
+		Enabled:    true,
+		RetryAfter: time.Hour,
+	})
+
+	require.NoError(t, r.sendScheduledEvaluations(context.Background()))
+
+	published := pub.published[constants.TopicQueueEntityReminder]
+	require.Len(t, published, 2)
+	for i, ent := range []db.ListProfileEvaluationTimesByEntityRow{newEntity, staleEntity} {
+		evt, err := remindermessages.EntityReminderEventFromMessage(published[i])
+		require.NoError(t, err)
+		require.Equal(t, ent.EntityID, evt.EntityID)
+		require.Equal(t, ent.ProviderID, evt.ProviderID)
+		require.Equal(t, profile.ID, evt.ProfileID)
+	}
+
+	// evaluations which were just sent aren't sent again until they are retried
+	require.NoError(t, r.sendScheduledEvaluations(context.Background()))
+	require.Len(t, pub.published[constants.TopicQueueEntityReminder], 2)
+}
+
+func Test_getDueProfileEvaluationsBatchSize(t *testing.T) {
+	t.Parallel()
+
+	profile := db.Profile{
+		ID:           uuid.New(),
+		ProjectID:    uuid.New(),
+		ScheduleCron: "0 2 * * *",
+	}
+
+	ents := make([]db.ListProfileEvaluationTimesByEntityRow, 0, 3)
+	for i := 1; i <= 3; i++ {
+		ents = append(ents, db.ListProfileEvaluationTimesByEntityRow{
+			EntityID:   generateUUIDFromNum(t, i),
+			EntityType: db.EntitiesRepository,
+			ProjectID:  profile.ProjectID,
+		})
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ListProfileEvaluationTimesByEntity(gomock.Any(), gomock.Any()).Return(ents, nil)
+
+	r := createTestReminder(t, store, &reminderconfig.Config{
+		RecurrenceConfig: reminderconfig.RecurrenceConfig{
+			BatchSize: 2,
+		},
+	})
+	r.schedules = newProfileScheduler(reminderconfig.ProfileSchedulesConfig{
+		Enabled: true,
+	})
+
+	messages, sent, err := r.getDueProfileEvaluations(context.Background(), profile, time.Now())
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	require.Equal(t, []profileEntity{
+		{profileID: profile.ID, entityID: ents[0].EntityID},
+		{profileID: profile.ID, entityID: ents[1].EntityID},
+	}, sent)
+}
@@ -8,9 +8,10 @@ import (
 	"fmt"
 
 	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 
-	reconcilermessages "github.com/mindersec/minder/internal/reconcilers/messages"
+	entityMessage "github.com/mindersec/minder/internal/entities/handlers/message"
 	remindermessages "github.com/mindersec/minder/internal/reminder/messages"
 	"github.com/mindersec/minder/pkg/eventer/constants"
 	"github.com/mindersec/minder/pkg/eventer/interfaces"
@@ -28,7 +29,7 @@ func NewReminderProcessor(evt interfaces.Interface) *ReminderProcessor {
 
 // Register implements the Consumer interface.
 func (rp *ReminderProcessor) Register(r interfaces.Registrar) {
-	r.Register(constants.TopicQueueRepoReminder, rp.reminderMessageHandler)
+	r.Register(constants.TopicQueueEntityReminder, rp.reminderMessageHandler)
 }
 
 func (rp *ReminderProcessor) reminderMessageHandler(msg *message.Message) error {
@@ -39,14 +40,27 @@ func (rp *ReminderProcessor) reminderMessageHandler(msg *message.Message) error
 
 	log.Info().Msgf("Received reminder event: %v", evt)
 
-	repoReconcileMsg, err := reconcilermessages.NewRepoReconcilerMessage(evt.ProviderID, evt.EntityID, evt.Project)
-	if err != nil {
-		return fmt.Errorf("error creating repo reconcile event: %w", err)
+	if evt.EntityID == uuid.Nil {
+		// there's no point in retrying an event without an entity
+		log.Error().Msg("entityID is nil")
+		return nil
+	}
+
+	entRefresh := entityMessage.NewEntityRefreshAndDoMessage().
+		WithEntityID(evt.EntityID).
+		WithProfileID(evt.ProfileID)
+
+	m := message.NewMessage(uuid.New().String(), nil)
+	m.SetContext(msg.Context())
+	if err := entRefresh.ToMessage(m); err != nil {
+		// no point in retrying, so we return nil
+		log.Error().Err(err).Msg("error marshalling message")
+		return nil
 	}
 
 	// This is a non-fatal error, so we'll just log it and continue with the next ones
-	if err := rp.evt.Publish(constants.TopicQueueReconcileRepoInit, repoReconcileMsg); err != nil {
-		log.Printf("error publishing reconciler event: %v", err)
+	if err := rp.evt.Publish(constants.TopicQueueRefreshEntityByIDAndEvaluate, m); err != nil {
+		log.Printf("error publishing refresh event: %v", err)
 	}
 	return nil
 }
@@ -0,0 +1,187 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package roles
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/mindersec/minder/internal/authz"
+	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/internal/util"
+	pb "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+func (_ *roleService) CreateCustomRole(ctx context.Context, qtx db.Querier, authzClient authz.Client,
+	targetProject uuid.UUID, role *pb.Role) (*pb.Role, error) {
+	permissions, err := validateCustomRole(ctx, authzClient, targetProject, role)
+	if err != nil {
+		return nil, err
+	}
+
+	customRole, err := qtx.CreateCustomRole(ctx, db.CreateCustomRoleParams{
+		ProjectID:   targetProject,
+		Name:        role.GetName(),
+		DisplayName: role.GetDisplayName(),
+		Description: role.GetDescription(),
+		Permissions: permissions,
+	})
+	if err != nil {
+		if db.ErrIsUniqueViolation(err) {
+			return nil, util.UserVisibleError(codes.AlreadyExists, "role %s already exists in the project", role.GetName())
+		}
+		return nil, status.Errorf(codes.Internal, "error creating role: %v", err)
+	}
+
+	if err := authzClient.WriteCustomRole(ctx, targetProject, authz.Role(customRole.Name), customRole.Permissions); err != nil {
+		// The transaction is rolled back, so don't leave any of the
+		// permissions behind in the authorization store either
+		if err := authzClient.DeleteCustomRole(ctx, targetProject, authz.Role(customRole.Name)); err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Str("role", customRole.Name).
+				Msg("error removing the permissions of a role which wasn't created")
+		}
+		return nil, status.Errorf(codes.Internal, "error writing role permissions: %v", err)
+	}
+
+	return CustomRoleToProto(customRole), nil
+}
+
+func (_ *roleService) UpdateCustomRole(ctx context.Context, qtx db.Querier, authzClient authz.Client,
+	targetProject uuid.UUID, role *pb.Role) (*pb.Role, error) {
+	permissions, err := validateCustomRole(ctx, authzClient, targetProject, role)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := qtx.GetCustomRoleByName(ctx, db.GetCustomRoleByNameParams{
+		ProjectID: targetProject,
+		Name:      role.GetName(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, util.UserVisibleError(codes.NotFound, "role %s not found in the project", role.GetName())
+		}
+		return nil, status.Errorf(codes.Internal, "error getting role: %v", err)
+	}
+
+	customRole, err := qtx.UpdateCustomRole(ctx, db.UpdateCustomRoleParams{
+		ProjectID:   targetProject,
+		Name:        role.GetName(),
+		DisplayName: role.GetDisplayName(),
+		Description: role.GetDescription(),
+		Permissions: permissions,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, util.UserVisibleError(codes.NotFound, "role %s not found in the project", role.GetName())
+		}
+		return nil, status.Errorf(codes.Internal, "error updating role: %v", err)
+	}
+
+	if err := authzClient.WriteCustomRole(ctx, targetProject, authz.Role(customRole.Name), customRole.Permissions); err != nil {
+		// The transaction is rolled back, so grant the previous permissions again
+		if err := authzClient.WriteCustomRole(ctx, targetProject, authz.Role(previous.Name), previous.Permissions); err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Str("role", previous.Name).
+				Msg("error restoring the permissions of a role which wasn't updated")
+		}
+		return nil, status.Errorf(codes.Internal, "error writing role permissions: %v", err)
+	}
+
+	return CustomRoleToProto(customRole), nil
+}
+
+func (_ *roleService) DeleteCustomRole(ctx context.Context, qtx db.Querier, authzClient authz.Client,
+	targetProject uuid.UUID, name string) (*pb.Role, error) {
+	customRole, err := qtx.DeleteCustomRole(ctx, db.DeleteCustomRoleParams{
+		ProjectID: targetProject,
+		Name:      name,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, util.UserVisibleError(codes.NotFound, "role %s not found in the project", name)
+		}
+		return nil, status.Errorf(codes.Internal, "error deleting role: %v", err)
+	}
+
+	if err := authzClient.DeleteCustomRole(ctx, targetProject, authz.Role(customRole.Name)); err != nil {
+		// The transaction is rolled back, so grant the permissions again.
+		// Assignments which were removed already can't be restored.
+		if err := authzClient.WriteCustomRole(ctx, targetProject, authz.Role(customRole.Name), customRole.Permissions); err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Str("role", customRole.Name).
+				Msg("error restoring the permissions of a role which wasn't deleted")
+		}
+		return nil, status.Errorf(codes.Internal, "error deleting role from authorization store: %v", err)
+	}
+
+	return CustomRoleToProto(customRole), nil
+}
+
+// CustomRoleToProto converts a custom role from the database to its API representation
+func CustomRoleToProto(role db.CustomRole) *pb.Role {
+	return &pb.Role{
+		Name:        role.Name,
+		DisplayName: role.DisplayName,
+		Description: role.Description,
+		Permissions: role.Permissions,
+	}
+}
+
+// SyncCustomRole brings the permissions of a custom role in the authorization
+// store in line with the database, removing them if the role doesn't exist.
+// The permissions are written to the authorization store before the database
+// transaction is committed, so this undoes them when the commit fails.
+func SyncCustomRole(ctx context.Context, qtx db.Querier, authzClient authz.Client,
+	targetProject uuid.UUID, name string) error {
+	customRole, err := qtx.GetCustomRoleByName(ctx, db.GetCustomRoleByNameParams{
+		ProjectID: targetProject,
+		Name:      name,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return authzClient.DeleteCustomRole(ctx, targetProject, authz.Role(name))
+	} else if err != nil {
+		return err
+	}
+	return authzClient.WriteCustomRole(ctx, targetProject, authz.Role(customRole.Name), customRole.Permissions)
+}
+
+// validateCustomRole checks the name and permissions of a custom role, and
+// returns its permissions sorted and without duplicates.  The caller must
+// hold every permission of the role on the project, so that they can't
+// grant more than they have.
+func validateCustomRole(ctx context.Context, authzClient authz.Client,
+	targetProject uuid.UUID, role *pb.Role) ([]string, error) {
+	if !authz.Role(role.GetName()).IsCustom() {
+		return nil, util.UserVisibleError(codes.InvalidArgument, "role %s is a built-in role", role.GetName())
+	}
+	if len(role.GetPermissions()) == 0 {
+		return nil, util.UserVisibleError(codes.InvalidArgument, "a custom role must have at least one permission")
+	}
+
+	permissions := slices.Clone(role.GetPermissions())
+	for _, perm := range permissions {
+		if err := authz.ValidatePermission(perm); err != nil {
+			return nil, util.UserVisibleError(codes.InvalidArgument, "%s", err.Error())
+		}
+	}
+	slices.Sort(permissions)
+	permissions = slices.Compact(permissions)
+
+	for _, perm := range permissions {
+		if err := authzClient.Check(ctx, perm, targetProject); err != nil {
+			if errors.Is(err, authz.ErrNotAuthorized) {
+				return nil, util.UserVisibleError(codes.PermissionDenied,
+					"cannot grant the %s permission, which you don't hold on the project", perm)
+			}
+			return nil, status.Errorf(codes.Internal, "error checking permission %s: %v", perm, err)
+		}
+	}
+	return permissions, nil
+}
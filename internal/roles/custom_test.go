@@ -0,0 +1,411 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package roles
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/mindersec/minder/internal/auth"
+	"github.com/mindersec/minder/internal/auth/keycloak"
+	mockauth "github.com/mindersec/minder/internal/auth/mock"
+	"github.com/mindersec/minder/internal/authz"
+	"github.com/mindersec/minder/internal/authz/mock"
+	"github.com/mindersec/minder/internal/db"
+	dbf "github.com/mindersec/minder/internal/db/fixtures"
+	minderv1 "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+)
+
+func TestCreateCustomRole(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name                string
+		role                *minderv1.Role
+		dBSetup             dbf.DBMockBuilder
+		notAllowed          bool
+		expectedPermissions []string
+		expectedError       string
+	}{
+		{
+			name: "error when the name is a built-in role",
+			role: &minderv1.Role{
+				Name:        authz.RoleEditor.String(),
+				Permissions: []string{"get"},
+			},
+			expectedError: "role editor is a built-in role",
+		},
+		{
+			name: "error when there are no permissions",
+			role: &minderv1.Role{
+				Name: customRole.String(),
+			},
+			expectedError: "a custom role must have at least one permission",
+		},
+		{
+			name: "error when a permission is invalid",
+			role: &minderv1.Role{
+				Name:        customRole.String(),
+				Permissions: []string{"get", "admin"},
+			},
+			expectedError: "invalid permission admin",
+		},
+		{
+			name: "error when the caller doesn't hold a permission",
+			role: &minderv1.Role{
+				Name:        customRole.String(),
+				Permissions: []string{"get"},
+			},
+			notAllowed:    true,
+			expectedError: "cannot grant the get permission",
+		},
+		{
+			name: "error when the role already exists",
+			role: &minderv1.Role{
+				Name:        customRole.String(),
+				Permissions: []string{"get"},
+			},
+			dBSetup: dbf.NewDBMock(
+				withCreateCustomRole(db.CustomRole{}, &pq.Error{Code: "23505"}),
+			),
+			expectedError: "role data_source_manager already exists in the project",
+		},
+		{
+			name: "role created successfully",
+			role: &minderv1.Role{
+				Name:        customRole.String(),
+				DisplayName: "Data Source Manager",
+				Permissions: []string{"get", "data_source_get", "get"},
+			},
+			dBSetup: dbf.NewDBMock(
+				withCreateCustomRole(db.CustomRole{
+					Name:        customRole.String(),
+					DisplayName: "Data Source Manager",
+					Permissions: []string{"data_source_get", "get"},
+				}, nil),
+			),
+			expectedPermissions: []string{"data_source_get", "get"},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := context.Background()
+
+			var store db.Store
+			if scenario.dBSetup != nil {
+				store = scenario.dBSetup(ctrl)
+			}
+
+			authzClient := &mock.SimpleClient{}
+			if !scenario.notAllowed {
+				authzClient.Allowed = []uuid.UUID{project}
+			}
+
+			service := NewRoleService()
+			role, err := service.CreateCustomRole(ctx, store, authzClient, project, scenario.role)
+
+			if scenario.expectedError != "" {
+				require.ErrorContains(t, err, scenario.expectedError)
+				require.Empty(t, authzClient.CustomRoles[project])
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, scenario.expectedPermissions, role.GetPermissions())
+			require.Equal(t, scenario.expectedPermissions, authzClient.CustomRoles[project][customRole])
+		})
+	}
+}
+
+func TestUpdateCustomRole(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name          string
+		dBSetup       dbf.DBMockBuilder
+		expectedError string
+	}{
+		{
+			name: "error when the role doesn't exist",
+			dBSetup: dbf.NewDBMock(
+				withGetCustomRoleByName(db.CustomRole{}, sql.ErrNoRows),
+			),
+			expectedError: "role data_source_manager not found in the project",
+		},
+		{
+			name: "role updated successfully",
+			dBSetup: dbf.NewDBMock(
+				withGetCustomRoleByName(db.CustomRole{
+					Name:        customRole.String(),
+					Permissions: []string{"data_source_get"},
+				}, nil),
+				withUpdateCustomRole(db.CustomRole{
+					Name:        customRole.String(),
+					Permissions: []string{"data_source_create"},
+				}, nil),
+			),
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := context.Background()
+
+			store := scenario.dBSetup(ctrl)
+			authzClient := &mock.SimpleClient{
+				Allowed: []uuid.UUID{project},
+				CustomRoles: map[uuid.UUID]map[authz.Role][]string{
+					project: {customRole: {"data_source_get"}},
+				},
+			}
+
+			service := NewRoleService()
+			_, err := service.UpdateCustomRole(ctx, store, authzClient, project, &minderv1.Role{
+				Name:        customRole.String(),
+				Permissions: []string{"data_source_create"},
+			})
+
+			if scenario.expectedError != "" {
+				require.ErrorContains(t, err, scenario.expectedError)
+				require.Equal(t, []string{"data_source_get"}, authzClient.CustomRoles[project][customRole])
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, []string{"data_source_create"}, authzClient.CustomRoles[project][customRole])
+		})
+	}
+}
+
+func TestDeleteCustomRole(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		name          string
+		dBSetup       dbf.DBMockBuilder
+		expectedError string
+	}{
+		{
+			name: "error when the role doesn't exist",
+			dBSetup: dbf.NewDBMock(
+				withDeleteCustomRole(db.CustomRole{}, sql.ErrNoRows),
+			),
+			expectedError: "role data_source_manager not found in the project",
+		},
+		{
+			name: "role deleted successfully",
+			dBSetup: dbf.NewDBMock(
+				withDeleteCustomRole(db.CustomRole{
+					Name:        customRole.String(),
+					Permissions: []string{"data_source_get"},
+				}, nil),
+			),
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := context.Background()
+
+			store := scenario.dBSetup(ctrl)
+			authzClient := &mock.SimpleClient{
+				Assignments: map[uuid.UUID][]*minderv1.RoleAssignment{
+					project: {
+						{Subject: subject, Role: customRole.String()},
+						{Subject: "other", Role: authz.RoleAdmin.String()},
+					},
+				},
+				CustomRoles: map[uuid.UUID]map[authz.Role][]string{
+					project: {customRole: {"data_source_get"}},
+				},
+			}
+
+			service := NewRoleService()
+			_, err := service.DeleteCustomRole(ctx, store, authzClient, project, customRole.String())
+
+			if scenario.expectedError != "" {
+				require.ErrorContains(t, err, scenario.expectedError)
+				require.Len(t, authzClient.Assignments[project], 2)
+				return
+			}
+			require.NoError(t, err)
+
+			// the assignments of the role are removed along with it
+			require.NotContains(t, authzClient.CustomRoles[project], customRole)
+			require.Len(t, authzClient.Assignments[project], 1)
+			require.Equal(t, authz.RoleAdmin.String(), authzClient.Assignments[project][0].Role)
+		})
+	}
+}
+
+// failingCustomRoleClient fails to write the permissions of custom roles
+// after writing some of them, like the authorization store failing halfway.
+type failingCustomRoleClient struct {
+	*mock.SimpleClient
+}
+
+func (f failingCustomRoleClient) WriteCustomRole(ctx context.Context, project uuid.UUID, role authz.Role, permissions []string) error {
+	if len(permissions) > 1 {
+		_ = f.SimpleClient.WriteCustomRole(ctx, project, role, permissions[:1])
+		return errors.New("authorization store unavailable")
+	}
+	return f.SimpleClient.WriteCustomRole(ctx, project, role, permissions)
+}
+
+func TestCustomRoleCompensation(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	permissions := []string{"data_source_create", "data_source_get"}
+
+	t.Run("create removes the written permissions", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := dbf.NewDBMock(withCreateCustomRole(db.CustomRole{
+			Name:        customRole.String(),
+			Permissions: permissions,
+		}, nil))(ctrl)
+		authzClient := failingCustomRoleClient{&mock.SimpleClient{Allowed: []uuid.UUID{project}}}
+
+		_, err := NewRoleService().CreateCustomRole(ctx, store, authzClient, project, &minderv1.Role{
+			Name:        customRole.String(),
+			Permissions: permissions,
+		})
+		require.ErrorContains(t, err, "error writing role permissions")
+		require.NotContains(t, authzClient.CustomRoles[project], customRole)
+	})
+
+	t.Run("update restores the previous permissions", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := dbf.NewDBMock(
+			withGetCustomRoleByName(db.CustomRole{
+				Name:        customRole.String(),
+				Permissions: []string{"data_source_get"},
+			}, nil),
+			withUpdateCustomRole(db.CustomRole{
+				Name:        customRole.String(),
+				Permissions: permissions,
+			}, nil),
+		)(ctrl)
+		authzClient := failingCustomRoleClient{&mock.SimpleClient{
+			Allowed: []uuid.UUID{project},
+			CustomRoles: map[uuid.UUID]map[authz.Role][]string{
+				project: {customRole: {"data_source_get"}},
+			},
+		}}
+
+		_, err := NewRoleService().UpdateCustomRole(ctx, store, authzClient, project, &minderv1.Role{
+			Name:        customRole.String(),
+			Permissions: permissions,
+		})
+		require.ErrorContains(t, err, "error writing role permissions")
+		require.Equal(t, []string{"data_source_get"}, authzClient.CustomRoles[project][customRole])
+	})
+
+	t.Run("sync removes the permissions of a role which wasn't committed", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := dbf.NewDBMock(withGetCustomRoleByName(db.CustomRole{}, sql.ErrNoRows))(ctrl)
+		authzClient := &mock.SimpleClient{
+			CustomRoles: map[uuid.UUID]map[authz.Role][]string{
+				project: {customRole: permissions},
+			},
+		}
+
+		require.NoError(t, SyncCustomRole(ctx, store, authzClient, project, customRole.String()))
+		require.NotContains(t, authzClient.CustomRoles[project], customRole)
+	})
+}
+
+func TestUpdateRoleAssignmentFromCustomRole(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	store := dbf.NewDBMock(withGetUser(validUser, nil))(ctrl)
+	authzClient := &mock.SimpleClient{
+		Assignments: map[uuid.UUID][]*minderv1.RoleAssignment{
+			project: {{Subject: subject, Role: customRole.String()}},
+		},
+	}
+
+	idClient := mockauth.NewMockResolver(ctrl)
+	idClient.EXPECT().Resolve(ctx, subject).Return(&auth.Identity{
+		UserID:   subject,
+		Provider: &keycloak.KeyCloak{},
+	}, nil)
+
+	service := NewRoleService()
+	_, err := service.UpdateRoleAssignment(ctx, store, authzClient, idClient, project, subject, authz.RoleViewer)
+	require.NoError(t, err)
+
+	require.Len(t, authzClient.Assignments[project], 1)
+	require.Equal(t, authz.RoleViewer.String(), authzClient.Assignments[project][0].Role)
+}
+
+var customRole = authz.Role("data_source_manager")
+
+func withCreateCustomRole(result db.CustomRole, err error) func(dbf.DBMock) {
+	return func(mock dbf.DBMock) {
+		mock.EXPECT().
+			CreateCustomRole(gomock.Any(), gomock.Any()).
+			Return(result, err)
+	}
+}
+
+func withGetCustomRoleByName(result db.CustomRole, err error) func(dbf.DBMock) {
+	return func(mock dbf.DBMock) {
+		mock.EXPECT().
+			GetCustomRoleByName(gomock.Any(), gomock.Any()).
+			Return(result, err)
+	}
+}
+
+func withUpdateCustomRole(result db.CustomRole, err error) func(dbf.DBMock) {
+	return func(mock dbf.DBMock) {
+		mock.EXPECT().
+			UpdateCustomRole(gomock.Any(), gomock.Any()).
+			Return(result, err)
+	}
+}
+
+func withDeleteCustomRole(result db.CustomRole, err error) func(dbf.DBMock) {
+	return func(mock dbf.DBMock) {
+		mock.EXPECT().
+			DeleteCustomRole(gomock.Any(), gomock.Any()).
+			Return(result, err)
+	}
+}
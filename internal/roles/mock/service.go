@@ -45,6 +45,21 @@ func (m *MockRoleService) EXPECT() *MockRoleServiceMockRecorder {
 	return m.recorder
 }
 
+// CreateCustomRole mocks base method.
+func (m *MockRoleService) CreateCustomRole(ctx context.Context, qtx db.Querier, authzClient authz.Client, targetProject uuid.UUID, role *v1.Role) (*v1.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCustomRole", ctx, qtx, authzClient, targetProject, role)
+	ret0, _ := ret[0].(*v1.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCustomRole indicates an expected call of CreateCustomRole.
+func (mr *MockRoleServiceMockRecorder) CreateCustomRole(ctx, qtx, authzClient, targetProject, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomRole", reflect.TypeOf((*MockRoleService)(nil).CreateCustomRole), ctx, qtx, authzClient, targetProject, role)
+}
+
 // CreateRoleAssignment mocks base method.
 func (m *MockRoleService) CreateRoleAssignment(ctx context.Context, qtx db.Querier, authzClient authz.Client, idClient auth.Resolver, targetProject uuid.UUID, subject string, authzRole authz.Role) (*v1.RoleAssignment, error) {
 	m.ctrl.T.Helper()
@@ -60,6 +75,21 @@ func (mr *MockRoleServiceMockRecorder) CreateRoleAssignment(ctx, qtx, authzClien
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRoleAssignment", reflect.TypeOf((*MockRoleService)(nil).CreateRoleAssignment), ctx, qtx, authzClient, idClient, targetProject, subject, authzRole)
 }
 
+// DeleteCustomRole mocks base method.
+func (m *MockRoleService) DeleteCustomRole(ctx context.Context, qtx db.Querier, authzClient authz.Client, targetProject uuid.UUID, name string) (*v1.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCustomRole", ctx, qtx, authzClient, targetProject, name)
+	ret0, _ := ret[0].(*v1.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCustomRole indicates an expected call of DeleteCustomRole.
+func (mr *MockRoleServiceMockRecorder) DeleteCustomRole(ctx, qtx, authzClient, targetProject, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomRole", reflect.TypeOf((*MockRoleService)(nil).DeleteCustomRole), ctx, qtx, authzClient, targetProject, name)
+}
+
 // RemoveRoleAssignment mocks base method.
 func (m *MockRoleService) RemoveRoleAssignment(ctx context.Context, qtx db.Querier, authzClient authz.Client, idClient auth.Resolver, targetProject uuid.UUID, subject string, roleToRemove authz.Role) (*v1.RoleAssignment, error) {
 	m.ctrl.T.Helper()
@@ -75,6 +105,21 @@ func (mr *MockRoleServiceMockRecorder) RemoveRoleAssignment(ctx, qtx, authzClien
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveRoleAssignment", reflect.TypeOf((*MockRoleService)(nil).RemoveRoleAssignment), ctx, qtx, authzClient, idClient, targetProject, subject, roleToRemove)
 }
 
+// UpdateCustomRole mocks base method.
+func (m *MockRoleService) UpdateCustomRole(ctx context.Context, qtx db.Querier, authzClient authz.Client, targetProject uuid.UUID, role *v1.Role) (*v1.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCustomRole", ctx, qtx, authzClient, targetProject, role)
+	ret0, _ := ret[0].(*v1.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCustomRole indicates an expected call of UpdateCustomRole.
+func (mr *MockRoleServiceMockRecorder) UpdateCustomRole(ctx, qtx, authzClient, targetProject, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomRole", reflect.TypeOf((*MockRoleService)(nil).UpdateCustomRole), ctx, qtx, authzClient, targetProject, role)
+}
+
 // UpdateRoleAssignment mocks base method.
 func (m *MockRoleService) UpdateRoleAssignment(ctx context.Context, qtx db.Querier, authzClient authz.Client, idClient auth.Resolver, targetProject uuid.UUID, subject string, authzRole authz.Role) (*v1.RoleAssignment, error) {
 	m.ctrl.T.Helper()
@@ -36,6 +36,18 @@ type RoleService interface {
 	// RemoveRoleAssignment removes the role assignment for the user on a project
 	RemoveRoleAssignment(ctx context.Context, qtx db.Querier, authzClient authz.Client, idClient auth.Resolver,
 		targetProject uuid.UUID, subject string, roleToRemove authz.Role) (*pb.RoleAssignment, error)
+
+	// CreateCustomRole defines a custom role on a project
+	CreateCustomRole(ctx context.Context, qtx db.Querier, authzClient authz.Client,
+		targetProject uuid.UUID, role *pb.Role) (*pb.Role, error)
+
+	// UpdateCustomRole updates the description and permissions of a custom role on a project
+	UpdateCustomRole(ctx context.Context, qtx db.Querier, authzClient authz.Client,
+		targetProject uuid.UUID, role *pb.Role) (*pb.Role, error)
+
+	// DeleteCustomRole deletes a custom role from a project, along with its assignments
+	DeleteCustomRole(ctx context.Context, qtx db.Querier, authzClient authz.Client,
+		targetProject uuid.UUID, name string) (*pb.Role, error)
 }
 
 type roleService struct {
@@ -119,11 +131,8 @@ func (_ *roleService) UpdateRoleAssignment(ctx context.Context, qtx db.Querier,
 
 	for _, a := range as {
 		if a.Subject == identity.String() {
-			roleToDelete, err := authz.ParseRole(a.Role)
-			if err != nil {
-				return nil, util.UserVisibleError(codes.Internal, "%s", err.Error())
-			}
-			if err := authzClient.Delete(ctx, identity.String(), roleToDelete, targetProject); err != nil {
+			// Assignments may be to custom roles, so the role isn't parsed
+			if err := authzClient.Delete(ctx, identity.String(), authz.Role(a.Role), targetProject); err != nil {
 				return nil, status.Errorf(codes.Internal, "error deleting previous role assignment: %v", err)
 			}
 		}
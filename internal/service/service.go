@@ -24,6 +24,7 @@ import (
 	"github.com/mindersec/minder/internal/email/awsses"
 	"github.com/mindersec/minder/internal/email/noop"
 	"github.com/mindersec/minder/internal/engine"
+	"github.com/mindersec/minder/internal/engine/actions/alert/webhook"
 	"github.com/mindersec/minder/internal/entities/handlers"
 	propService "github.com/mindersec/minder/internal/entities/properties/service"
 	"github.com/mindersec/minder/internal/flags"
@@ -33,6 +34,7 @@ import (
 	"github.com/mindersec/minder/internal/metrics/meters"
 	"github.com/mindersec/minder/internal/projects"
 	"github.com/mindersec/minder/internal/providers"
+	bitbucketmanager "github.com/mindersec/minder/internal/providers/bitbucket/manager"
 	"github.com/mindersec/minder/internal/providers/dockerhub"
 	ghprov "github.com/mindersec/minder/internal/providers/github"
 	"github.com/mindersec/minder/internal/providers/github/clients"
@@ -41,6 +43,7 @@ import (
 	"github.com/mindersec/minder/internal/providers/github/service"
 	gitlabmanager "github.com/mindersec/minder/internal/providers/gitlab/manager"
 	"github.com/mindersec/minder/internal/providers/manager"
+	ocimanager "github.com/mindersec/minder/internal/providers/ociregistry/manager"
 	"github.com/mindersec/minder/internal/providers/ratecache"
 	"github.com/mindersec/minder/internal/providers/session"
 	provtelemetry "github.com/mindersec/minder/internal/providers/telemetry"
@@ -96,7 +99,7 @@ func AllInOneServerService(
 	ruleSvc := ruletypes.NewRuleTypeService()
 	roleScv := roles.NewRoleService()
 	dataSourcesSvc := datasourcessvc.NewDataSourceService(store)
-	marketplace, err := marketplaces.NewMarketplaceFromServiceConfig(cfg.Marketplace, profileSvc, ruleSvc, dataSourcesSvc)
+	marketplace, err := marketplaces.NewMarketplaceFromServiceConfig(ctx, cfg.Marketplace, profileSvc, ruleSvc, dataSourcesSvc)
 	if err != nil {
 		return fmt.Errorf("failed to create marketplace: %w", err)
 	}
@@ -141,6 +144,14 @@ func AllInOneServerService(
 		provmans = append(provmans, dockerhubProviderManager)
 	}
 
+	if flags.Bool(ctx, featureFlagClient, flags.OCIProvider) {
+		ociProviderManager := ocimanager.NewOCIProviderClassManager(
+			cryptoEngine,
+			store,
+		)
+		provmans = append(provmans, ociProviderManager)
+	}
+
 	if flags.Bool(ctx, featureFlagClient, flags.GitLabProvider) {
 		gitlabProviderManager, err := gitlabmanager.NewGitLabProviderClassManager(
 			ctx,
@@ -157,6 +168,22 @@ func AllInOneServerService(
 		provmans = append(provmans, gitlabProviderManager)
 	}
 
+	if flags.Bool(ctx, featureFlagClient, flags.BitbucketProvider) {
+		bitbucketProviderManager, err := bitbucketmanager.NewBitbucketProviderClassManager(
+			ctx,
+			cryptoEngine,
+			store,
+			evt,
+			cfg.Provider.Bitbucket,
+			cfg.WebhookConfig,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create bitbucket provider manager: %w", err)
+		}
+
+		provmans = append(provmans, bitbucketProviderManager)
+	}
+
 	providerManager, closer, err := manager.NewProviderManager(ctx, providerStore,
 		provmans...)
 	if err != nil {
@@ -172,6 +199,7 @@ func AllInOneServerService(
 	repos := repositories.NewRepositoryService(store, propSvc, evt, providerManager)
 	projectDeleter := projects.NewProjectDeleter(authzClient, providerManager)
 	sessionsService := session.NewProviderSessionService(providerManager, providerStore, store)
+	previewer := engine.NewProfilePreviewer(store, providerManager, featureFlagClient, selChecker, selChecker, propSvc)
 
 	s := controlplane.NewServer(
 		store,
@@ -188,6 +216,7 @@ func AllInOneServerService(
 		roleScv,
 		profileSvc,
 		historySvc,
+		previewer,
 		ruleSvc,
 		dataSourcesSvc,
 		ghProviders,
@@ -197,17 +226,21 @@ func AllInOneServerService(
 		sessionsService,
 		projectDeleter,
 		projectCreator,
+		marketplace,
 		featureFlagClient,
 	)
 
-	// Subscribe to events from the identity server
-	err = controlplane.SubscribeToIdentityEvents(ctx, store, authzClient, cfg, projectDeleter)
-	if err != nil {
-		return fmt.Errorf("unable to subscribe to identity server events: %w", err)
-	}
-	err = controlplane.SubscribeToAdminEvents(ctx, store, authzClient, cfg, projectDeleter)
-	if err != nil {
-		return fmt.Errorf("unable to subscribe to account events: %w", err)
+	// Subscribe to events from the identity server, unless a generic OIDC
+	// provider replaces it.  Those have no standard API for events.
+	if cfg.Identity.DefaultOIDC() == nil {
+		err = controlplane.SubscribeToIdentityEvents(ctx, store, authzClient, cfg, projectDeleter)
+		if err != nil {
+			return fmt.Errorf("unable to subscribe to identity server events: %w", err)
+		}
+		err = controlplane.SubscribeToAdminEvents(ctx, store, authzClient, cfg, projectDeleter)
+		if err != nil {
+			return fmt.Errorf("unable to subscribe to account events: %w", err)
+		}
 	}
 
 	aggr := eea.NewEEA(store, evt, &cfg.Events.Aggregator, propSvc, providerManager)
@@ -235,6 +268,9 @@ func AllInOneServerService(
 		profileStore,
 		selEnv,
 		propSvc,
+		&cfg.Alerts,
+		cryptoEngine,
+		evt,
 	)
 
 	handler := engine.NewExecutorEventHandler(
@@ -246,8 +282,11 @@ func AllInOneServerService(
 
 	evt.ConsumeEvents(handler)
 
+	// Register the deliverer of the webhook alerts queued by the executor
+	evt.ConsumeEvents(webhook.NewDeliverer(&cfg.Alerts.Webhook, store))
+
 	// Register the reconciler to handle entity events
-	rec, err := reconcilers.NewReconciler(store, evt, cryptoEngine, providerManager, repos)
+	rec, err := reconcilers.NewReconciler(store, evt, cryptoEngine, providerManager, repos, propSvc)
 	if err != nil {
 		return fmt.Errorf("unable to create reconciler: %w", err)
 	}
@@ -1154,6 +1154,7 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 
 const (
 	ProfileService_CreateProfile_FullMethodName             = "/minder.v1.ProfileService/CreateProfile"
+	ProfileService_PreviewProfile_FullMethodName            = "/minder.v1.ProfileService/PreviewProfile"
 	ProfileService_UpdateProfile_FullMethodName             = "/minder.v1.ProfileService/UpdateProfile"
 	ProfileService_PatchProfile_FullMethodName              = "/minder.v1.ProfileService/PatchProfile"
 	ProfileService_DeleteProfile_FullMethodName             = "/minder.v1.ProfileService/DeleteProfile"
@@ -1163,6 +1164,9 @@ const (
 	ProfileService_GetProfileStatusByName_FullMethodName    = "/minder.v1.ProfileService/GetProfileStatusByName"
 	ProfileService_GetProfileStatusById_FullMethodName      = "/minder.v1.ProfileService/GetProfileStatusById"
 	ProfileService_GetProfileStatusByProject_FullMethodName = "/minder.v1.ProfileService/GetProfileStatusByProject"
+	ProfileService_CreateRuleException_FullMethodName       = "/minder.v1.ProfileService/CreateRuleException"
+	ProfileService_ListRuleExceptions_FullMethodName        = "/minder.v1.ProfileService/ListRuleExceptions"
+	ProfileService_DeleteRuleException_FullMethodName       = "/minder.v1.ProfileService/DeleteRuleException"
 )
 
 // ProfileServiceClient is the client API for ProfileService service.
@@ -1170,6 +1174,7 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ProfileServiceClient interface {
 	CreateProfile(ctx context.Context, in *CreateProfileRequest, opts ...grpc.CallOption) (*CreateProfileResponse, error)
+	PreviewProfile(ctx context.Context, in *PreviewProfileRequest, opts ...grpc.CallOption) (*PreviewProfileResponse, error)
 	UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*UpdateProfileResponse, error)
 	PatchProfile(ctx context.Context, in *PatchProfileRequest, opts ...grpc.CallOption) (*PatchProfileResponse, error)
 	DeleteProfile(ctx context.Context, in *DeleteProfileRequest, opts ...grpc.CallOption) (*DeleteProfileResponse, error)
@@ -1179,6 +1184,9 @@ type ProfileServiceClient interface {
 	GetProfileStatusByName(ctx context.Context, in *GetProfileStatusByNameRequest, opts ...grpc.CallOption) (*GetProfileStatusByNameResponse, error)
 	GetProfileStatusById(ctx context.Context, in *GetProfileStatusByIdRequest, opts ...grpc.CallOption) (*GetProfileStatusByIdResponse, error)
 	GetProfileStatusByProject(ctx context.Context, in *GetProfileStatusByProjectRequest, opts ...grpc.CallOption) (*GetProfileStatusByProjectResponse, error)
+	CreateRuleException(ctx context.Context, in *CreateRuleExceptionRequest, opts ...grpc.CallOption) (*CreateRuleExceptionResponse, error)
+	ListRuleExceptions(ctx context.Context, in *ListRuleExceptionsRequest, opts ...grpc.CallOption) (*ListRuleExceptionsResponse, error)
+	DeleteRuleException(ctx context.Context, in *DeleteRuleExceptionRequest, opts ...grpc.CallOption) (*DeleteRuleExceptionResponse, error)
 }
 
 type profileServiceClient struct {
@@ -1199,6 +1207,16 @@ func (c *profileServiceClient) CreateProfile(ctx context.Context, in *CreateProf
 	return out, nil
 }
 
+func (c *profileServiceClient) PreviewProfile(ctx context.Context, in *PreviewProfileRequest, opts ...grpc.CallOption) (*PreviewProfileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewProfileResponse)
+	err := c.cc.Invoke(ctx, ProfileService_PreviewProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *profileServiceClient) UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*UpdateProfileResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdateProfileResponse)
@@ -1289,11 +1307,42 @@ func (c *profileServiceClient) GetProfileStatusByProject(ctx context.Context, in
 	return out, nil
 }
 
+func (c *profileServiceClient) CreateRuleException(ctx context.Context, in *CreateRuleExceptionRequest, opts ...grpc.CallOption) (*CreateRuleExceptionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateRuleExceptionResponse)
+	err := c.cc.Invoke(ctx, ProfileService_CreateRuleException_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) ListRuleExceptions(ctx context.Context, in *ListRuleExceptionsRequest, opts ...grpc.CallOption) (*ListRuleExceptionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRuleExceptionsResponse)
+	err := c.cc.Invoke(ctx, ProfileService_ListRuleExceptions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) DeleteRuleException(ctx context.Context, in *DeleteRuleExceptionRequest, opts ...grpc.CallOption) (*DeleteRuleExceptionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteRuleExceptionResponse)
+	err := c.cc.Invoke(ctx, ProfileService_DeleteRuleException_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProfileServiceServer is the server API for ProfileService service.
 // All implementations must embed UnimplementedProfileServiceServer
 // for forward compatibility.
 type ProfileServiceServer interface {
 	CreateProfile(context.Context, *CreateProfileRequest) (*CreateProfileResponse, error)
+	PreviewProfile(context.Context, *PreviewProfileRequest) (*PreviewProfileResponse, error)
 	UpdateProfile(context.Context, *UpdateProfileRequest) (*UpdateProfileResponse, error)
 	PatchProfile(context.Context, *PatchProfileRequest) (*PatchProfileResponse, error)
 	DeleteProfile(context.Context, *DeleteProfileRequest) (*DeleteProfileResponse, error)
@@ -1303,6 +1352,9 @@ type ProfileServiceServer interface {
 	GetProfileStatusByName(context.Context, *GetProfileStatusByNameRequest) (*GetProfileStatusByNameResponse, error)
 	GetProfileStatusById(context.Context, *GetProfileStatusByIdRequest) (*GetProfileStatusByIdResponse, error)
 	GetProfileStatusByProject(context.Context, *GetProfileStatusByProjectRequest) (*GetProfileStatusByProjectResponse, error)
+	CreateRuleException(context.Context, *CreateRuleExceptionRequest) (*CreateRuleExceptionResponse, error)
+	ListRuleExceptions(context.Context, *ListRuleExceptionsRequest) (*ListRuleExceptionsResponse, error)
+	DeleteRuleException(context.Context, *DeleteRuleExceptionRequest) (*DeleteRuleExceptionResponse, error)
 	mustEmbedUnimplementedProfileServiceServer()
 }
 
@@ -1316,6 +1368,9 @@ type UnimplementedProfileServiceServer struct{}
 func (UnimplementedProfileServiceServer) CreateProfile(context.Context, *CreateProfileRequest) (*CreateProfileResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateProfile not implemented")
 }
+func (UnimplementedProfileServiceServer) PreviewProfile(context.Context, *PreviewProfileRequest) (*PreviewProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewProfile not implemented")
+}
 func (UnimplementedProfileServiceServer) UpdateProfile(context.Context, *UpdateProfileRequest) (*UpdateProfileResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateProfile not implemented")
 }
@@ -1343,6 +1398,15 @@ func (UnimplementedProfileServiceServer) GetProfileStatusById(context.Context, *
 func (UnimplementedProfileServiceServer) GetProfileStatusByProject(context.Context, *GetProfileStatusByProjectRequest) (*GetProfileStatusByProjectResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetProfileStatusByProject not implemented")
 }
+func (UnimplementedProfileServiceServer) CreateRuleException(context.Context, *CreateRuleExceptionRequest) (*CreateRuleExceptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRuleException not implemented")
+}
+func (UnimplementedProfileServiceServer) ListRuleExceptions(context.Context, *ListRuleExceptionsRequest) (*ListRuleExceptionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRuleExceptions not implemented")
+}
+func (UnimplementedProfileServiceServer) DeleteRuleException(context.Context, *DeleteRuleExceptionRequest) (*DeleteRuleExceptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRuleException not implemented")
+}
 func (UnimplementedProfileServiceServer) mustEmbedUnimplementedProfileServiceServer() {}
 func (UnimplementedProfileServiceServer) testEmbeddedByValue()                        {}
 
@@ -1382,6 +1446,24 @@ func _ProfileService_CreateProfile_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProfileService_PreviewProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).PreviewProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_PreviewProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).PreviewProfile(ctx, req.(*PreviewProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProfileService_UpdateProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateProfileRequest)
 	if err := dec(in); err != nil {
@@ -1544,6 +1626,60 @@ func _ProfileService_GetProfileStatusByProject_Handler(srv interface{}, ctx cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProfileService_CreateRuleException_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRuleExceptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).CreateRuleException(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_CreateRuleException_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).CreateRuleException(ctx, req.(*CreateRuleExceptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_ListRuleExceptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRuleExceptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).ListRuleExceptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_ListRuleExceptions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).ListRuleExceptions(ctx, req.(*ListRuleExceptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_DeleteRuleException_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRuleExceptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).DeleteRuleException(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_DeleteRuleException_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).DeleteRuleException(ctx, req.(*DeleteRuleExceptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProfileService_ServiceDesc is the grpc.ServiceDesc for ProfileService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1555,6 +1691,10 @@ var ProfileService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateProfile",
 			Handler:    _ProfileService_CreateProfile_Handler,
 		},
+		{
+			MethodName: "PreviewProfile",
+			Handler:    _ProfileService_PreviewProfile_Handler,
+		},
 		{
 			MethodName: "UpdateProfile",
 			Handler:    _ProfileService_UpdateProfile_Handler,
@@ -1591,6 +1731,18 @@ var ProfileService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProfileStatusByProject",
 			Handler:    _ProfileService_GetProfileStatusByProject_Handler,
 		},
+		{
+			MethodName: "CreateRuleException",
+			Handler:    _ProfileService_CreateRuleException_Handler,
+		},
+		{
+			MethodName: "ListRuleExceptions",
+			Handler:    _ProfileService_ListRuleExceptions_Handler,
+		},
+		{
+			MethodName: "DeleteRuleException",
+			Handler:    _ProfileService_DeleteRuleException_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "minder/v1/minder.proto",
@@ -2219,9 +2371,11 @@ var RuleTypeService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	EvalResultsService_ListEvaluationResults_FullMethodName = "/minder.v1.EvalResultsService/ListEvaluationResults"
-	EvalResultsService_ListEvaluationHistory_FullMethodName = "/minder.v1.EvalResultsService/ListEvaluationHistory"
-	EvalResultsService_GetEvaluationHistory_FullMethodName  = "/minder.v1.EvalResultsService/GetEvaluationHistory"
+	EvalResultsService_ListEvaluationResults_FullMethodName   = "/minder.v1.EvalResultsService/ListEvaluationResults"
+	EvalResultsService_ListEvaluationHistory_FullMethodName   = "/minder.v1.EvalResultsService/ListEvaluationHistory"
+	EvalResultsService_GetEvaluationHistory_FullMethodName    = "/minder.v1.EvalResultsService/GetEvaluationHistory"
+	EvalResultsService_GetEvaluationTrends_FullMethodName     = "/minder.v1.EvalResultsService/GetEvaluationTrends"
+	EvalResultsService_ExportEvaluationResults_FullMethodName = "/minder.v1.EvalResultsService/ExportEvaluationResults"
 )
 
 // EvalResultsServiceClient is the client API for EvalResultsService service.
@@ -2231,6 +2385,8 @@ type EvalResultsServiceClient interface {
 	ListEvaluationResults(ctx context.Context, in *ListEvaluationResultsRequest, opts ...grpc.CallOption) (*ListEvaluationResultsResponse, error)
 	ListEvaluationHistory(ctx context.Context, in *ListEvaluationHistoryRequest, opts ...grpc.CallOption) (*ListEvaluationHistoryResponse, error)
 	GetEvaluationHistory(ctx context.Context, in *GetEvaluationHistoryRequest, opts ...grpc.CallOption) (*GetEvaluationHistoryResponse, error)
+	GetEvaluationTrends(ctx context.Context, in *GetEvaluationTrendsRequest, opts ...grpc.CallOption) (*GetEvaluationTrendsResponse, error)
+	ExportEvaluationResults(ctx context.Context, in *ExportEvaluationResultsRequest, opts ...grpc.CallOption) (*ExportEvaluationResultsResponse, error)
 }
 
 type evalResultsServiceClient struct {
@@ -2271,6 +2427,26 @@ func (c *evalResultsServiceClient) GetEvaluationHistory(ctx context.Context, in
 	return out, nil
 }
 
+func (c *evalResultsServiceClient) GetEvaluationTrends(ctx context.Context, in *GetEvaluationTrendsRequest, opts ...grpc.CallOption) (*GetEvaluationTrendsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEvaluationTrendsResponse)
+	err := c.cc.Invoke(ctx, EvalResultsService_GetEvaluationTrends_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *evalResultsServiceClient) ExportEvaluationResults(ctx context.Context, in *ExportEvaluationResultsRequest, opts ...grpc.CallOption) (*ExportEvaluationResultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportEvaluationResultsResponse)
+	err := c.cc.Invoke(ctx, EvalResultsService_ExportEvaluationResults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // EvalResultsServiceServer is the server API for EvalResultsService service.
 // All implementations must embed UnimplementedEvalResultsServiceServer
 // for forward compatibility.
@@ -2278,6 +2454,8 @@ type EvalResultsServiceServer interface {
 	ListEvaluationResults(context.Context, *ListEvaluationResultsRequest) (*ListEvaluationResultsResponse, error)
 	ListEvaluationHistory(context.Context, *ListEvaluationHistoryRequest) (*ListEvaluationHistoryResponse, error)
 	GetEvaluationHistory(context.Context, *GetEvaluationHistoryRequest) (*GetEvaluationHistoryResponse, error)
+	GetEvaluationTrends(context.Context, *GetEvaluationTrendsRequest) (*GetEvaluationTrendsResponse, error)
+	ExportEvaluationResults(context.Context, *ExportEvaluationResultsRequest) (*ExportEvaluationResultsResponse, error)
 	mustEmbedUnimplementedEvalResultsServiceServer()
 }
 
@@ -2297,6 +2475,12 @@ func (UnimplementedEvalResultsServiceServer) ListEvaluationHistory(context.Conte
 func (UnimplementedEvalResultsServiceServer) GetEvaluationHistory(context.Context, *GetEvaluationHistoryRequest) (*GetEvaluationHistoryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetEvaluationHistory not implemented")
 }
+func (UnimplementedEvalResultsServiceServer) GetEvaluationTrends(context.Context, *GetEvaluationTrendsRequest) (*GetEvaluationTrendsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEvaluationTrends not implemented")
+}
+func (UnimplementedEvalResultsServiceServer) ExportEvaluationResults(context.Context, *ExportEvaluationResultsRequest) (*ExportEvaluationResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportEvaluationResults not implemented")
+}
 func (UnimplementedEvalResultsServiceServer) mustEmbedUnimplementedEvalResultsServiceServer() {}
 func (UnimplementedEvalResultsServiceServer) testEmbeddedByValue()                            {}
 
@@ -2372,6 +2556,42 @@ func _EvalResultsService_GetEvaluationHistory_Handler(srv interface{}, ctx conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EvalResultsService_GetEvaluationTrends_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEvaluationTrendsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EvalResultsServiceServer).GetEvaluationTrends(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EvalResultsService_GetEvaluationTrends_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EvalResultsServiceServer).GetEvaluationTrends(ctx, req.(*GetEvaluationTrendsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EvalResultsService_ExportEvaluationResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportEvaluationResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EvalResultsServiceServer).ExportEvaluationResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EvalResultsService_ExportEvaluationResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EvalResultsServiceServer).ExportEvaluationResults(ctx, req.(*ExportEvaluationResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // EvalResultsService_ServiceDesc is the grpc.ServiceDesc for EvalResultsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -2391,6 +2611,14 @@ var EvalResultsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetEvaluationHistory",
 			Handler:    _EvalResultsService_GetEvaluationHistory_Handler,
 		},
+		{
+			MethodName: "GetEvaluationTrends",
+			Handler:    _EvalResultsService_GetEvaluationTrends_Handler,
+		},
+		{
+			MethodName: "ExportEvaluationResults",
+			Handler:    _EvalResultsService_ExportEvaluationResults_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "minder/v1/minder.proto",
@@ -2402,6 +2630,9 @@ const (
 	PermissionsService_AssignRole_FullMethodName          = "/minder.v1.PermissionsService/AssignRole"
 	PermissionsService_UpdateRole_FullMethodName          = "/minder.v1.PermissionsService/UpdateRole"
 	PermissionsService_RemoveRole_FullMethodName          = "/minder.v1.PermissionsService/RemoveRole"
+	PermissionsService_CreateCustomRole_FullMethodName    = "/minder.v1.PermissionsService/CreateCustomRole"
+	PermissionsService_UpdateCustomRole_FullMethodName    = "/minder.v1.PermissionsService/UpdateCustomRole"
+	PermissionsService_DeleteCustomRole_FullMethodName    = "/minder.v1.PermissionsService/DeleteCustomRole"
 )
 
 // PermissionsServiceClient is the client API for PermissionsService service.
@@ -2413,6 +2644,9 @@ type PermissionsServiceClient interface {
 	AssignRole(ctx context.Context, in *AssignRoleRequest, opts ...grpc.CallOption) (*AssignRoleResponse, error)
 	UpdateRole(ctx context.Context, in *UpdateRoleRequest, opts ...grpc.CallOption) (*UpdateRoleResponse, error)
 	RemoveRole(ctx context.Context, in *RemoveRoleRequest, opts ...grpc.CallOption) (*RemoveRoleResponse, error)
+	CreateCustomRole(ctx context.Context, in *CreateCustomRoleRequest, opts ...grpc.CallOption) (*CreateCustomRoleResponse, error)
+	UpdateCustomRole(ctx context.Context, in *UpdateCustomRoleRequest, opts ...grpc.CallOption) (*UpdateCustomRoleResponse, error)
+	DeleteCustomRole(ctx context.Context, in *DeleteCustomRoleRequest, opts ...grpc.CallOption) (*DeleteCustomRoleResponse, error)
 }
 
 type permissionsServiceClient struct {
@@ -2473,6 +2707,36 @@ func (c *permissionsServiceClient) RemoveRole(ctx context.Context, in *RemoveRol
 	return out, nil
 }
 
+func (c *permissionsServiceClient) CreateCustomRole(ctx context.Context, in *CreateCustomRoleRequest, opts ...grpc.CallOption) (*CreateCustomRoleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateCustomRoleResponse)
+	err := c.cc.Invoke(ctx, PermissionsService_CreateCustomRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsServiceClient) UpdateCustomRole(ctx context.Context, in *UpdateCustomRoleRequest, opts ...grpc.CallOption) (*UpdateCustomRoleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateCustomRoleResponse)
+	err := c.cc.Invoke(ctx, PermissionsService_UpdateCustomRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permissionsServiceClient) DeleteCustomRole(ctx context.Context, in *DeleteCustomRoleRequest, opts ...grpc.CallOption) (*DeleteCustomRoleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCustomRoleResponse)
+	err := c.cc.Invoke(ctx, PermissionsService_DeleteCustomRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PermissionsServiceServer is the server API for PermissionsService service.
 // All implementations must embed UnimplementedPermissionsServiceServer
 // for forward compatibility.
@@ -2482,6 +2746,9 @@ type PermissionsServiceServer interface {
 	AssignRole(context.Context, *AssignRoleRequest) (*AssignRoleResponse, error)
 	UpdateRole(context.Context, *UpdateRoleRequest) (*UpdateRoleResponse, error)
 	RemoveRole(context.Context, *RemoveRoleRequest) (*RemoveRoleResponse, error)
+	CreateCustomRole(context.Context, *CreateCustomRoleRequest) (*CreateCustomRoleResponse, error)
+	UpdateCustomRole(context.Context, *UpdateCustomRoleRequest) (*UpdateCustomRoleResponse, error)
+	DeleteCustomRole(context.Context, *DeleteCustomRoleRequest) (*DeleteCustomRoleResponse, error)
 	mustEmbedUnimplementedPermissionsServiceServer()
 }
 
@@ -2507,6 +2774,15 @@ func (UnimplementedPermissionsServiceServer) UpdateRole(context.Context, *Update
 func (UnimplementedPermissionsServiceServer) RemoveRole(context.Context, *RemoveRoleRequest) (*RemoveRoleResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveRole not implemented")
 }
+func (UnimplementedPermissionsServiceServer) CreateCustomRole(context.Context, *CreateCustomRoleRequest) (*CreateCustomRoleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCustomRole not implemented")
+}
+func (UnimplementedPermissionsServiceServer) UpdateCustomRole(context.Context, *UpdateCustomRoleRequest) (*UpdateCustomRoleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCustomRole not implemented")
+}
+func (UnimplementedPermissionsServiceServer) DeleteCustomRole(context.Context, *DeleteCustomRoleRequest) (*DeleteCustomRoleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCustomRole not implemented")
+}
 func (UnimplementedPermissionsServiceServer) mustEmbedUnimplementedPermissionsServiceServer() {}
 func (UnimplementedPermissionsServiceServer) testEmbeddedByValue()                            {}
 
@@ -2618,6 +2894,60 @@ func _PermissionsService_RemoveRole_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PermissionsService_CreateCustomRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCustomRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsServiceServer).CreateCustomRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PermissionsService_CreateCustomRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsServiceServer).CreateCustomRole(ctx, req.(*CreateCustomRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionsService_UpdateCustomRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCustomRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsServiceServer).UpdateCustomRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PermissionsService_UpdateCustomRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsServiceServer).UpdateCustomRole(ctx, req.(*UpdateCustomRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PermissionsService_DeleteCustomRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCustomRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermissionsServiceServer).DeleteCustomRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PermissionsService_DeleteCustomRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermissionsServiceServer).DeleteCustomRole(ctx, req.(*DeleteCustomRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PermissionsService_ServiceDesc is the grpc.ServiceDesc for PermissionsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -2645,6 +2975,126 @@ var PermissionsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveRole",
 			Handler:    _PermissionsService_RemoveRole_Handler,
 		},
+		{
+			MethodName: "CreateCustomRole",
+			Handler:    _PermissionsService_CreateCustomRole_Handler,
+		},
+		{
+			MethodName: "UpdateCustomRole",
+			Handler:    _PermissionsService_UpdateCustomRole_Handler,
+		},
+		{
+			MethodName: "DeleteCustomRole",
+			Handler:    _PermissionsService_DeleteCustomRole_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "minder/v1/minder.proto",
+}
+
+const (
+	AuditService_ListAuditEvents_FullMethodName = "/minder.v1.AuditService/ListAuditEvents"
+)
+
+// AuditServiceClient is the client API for AuditService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AuditService exposes the audit log, which records the mutating calls
+// made to the API.
+type AuditServiceClient interface {
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
+}
+
+type auditServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAuditServiceClient(cc grpc.ClientConnInterface) AuditServiceClient {
+	return &auditServiceClient{cc}
+}
+
+func (c *auditServiceClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, AuditService_ListAuditEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuditServiceServer is the server API for AuditService service.
+// All implementations must embed UnimplementedAuditServiceServer
+// for forward compatibility.
+//
+// AuditService exposes the audit log, which records the mutating calls
+// made to the API.
+type AuditServiceServer interface {
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
+	mustEmbedUnimplementedAuditServiceServer()
+}
+
+// UnimplementedAuditServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAuditServiceServer struct{}
+
+func (UnimplementedAuditServiceServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+func (UnimplementedAuditServiceServer) mustEmbedUnimplementedAuditServiceServer() {}
+func (UnimplementedAuditServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeAuditServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuditServiceServer will
+// result in compilation errors.
+type UnsafeAuditServiceServer interface {
+	mustEmbedUnimplementedAuditServiceServer()
+}
+
+func RegisterAuditServiceServer(s grpc.ServiceRegistrar, srv AuditServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAuditServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AuditService_ServiceDesc, srv)
+}
+
+func _AuditService_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuditServiceServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuditService_ListAuditEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuditServiceServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AuditService_ServiceDesc is the grpc.ServiceDesc for AuditService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AuditService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "minder.v1.AuditService",
+	HandlerType: (*AuditServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _AuditService_ListAuditEvents_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "minder/v1/minder.proto",
@@ -2658,6 +3108,8 @@ const (
 	ProjectsService_UpdateProject_FullMethodName                  = "/minder.v1.ProjectsService/UpdateProject"
 	ProjectsService_PatchProject_FullMethodName                   = "/minder.v1.ProjectsService/PatchProject"
 	ProjectsService_CreateEntityReconciliationTask_FullMethodName = "/minder.v1.ProjectsService/CreateEntityReconciliationTask"
+	ProjectsService_ExportProject_FullMethodName                  = "/minder.v1.ProjectsService/ExportProject"
+	ProjectsService_ImportProject_FullMethodName                  = "/minder.v1.ProjectsService/ImportProject"
 )
 
 // ProjectsServiceClient is the client API for ProjectsService service.
@@ -2671,6 +3123,8 @@ type ProjectsServiceClient interface {
 	UpdateProject(ctx context.Context, in *UpdateProjectRequest, opts ...grpc.CallOption) (*UpdateProjectResponse, error)
 	PatchProject(ctx context.Context, in *PatchProjectRequest, opts ...grpc.CallOption) (*PatchProjectResponse, error)
 	CreateEntityReconciliationTask(ctx context.Context, in *CreateEntityReconciliationTaskRequest, opts ...grpc.CallOption) (*CreateEntityReconciliationTaskResponse, error)
+	ExportProject(ctx context.Context, in *ExportProjectRequest, opts ...grpc.CallOption) (*ExportProjectResponse, error)
+	ImportProject(ctx context.Context, in *ImportProjectRequest, opts ...grpc.CallOption) (*ImportProjectResponse, error)
 }
 
 type projectsServiceClient struct {
@@ -2751,6 +3205,26 @@ func (c *projectsServiceClient) CreateEntityReconciliationTask(ctx context.Conte
 	return out, nil
 }
 
+func (c *projectsServiceClient) ExportProject(ctx context.Context, in *ExportProjectRequest, opts ...grpc.CallOption) (*ExportProjectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportProjectResponse)
+	err := c.cc.Invoke(ctx, ProjectsService_ExportProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectsServiceClient) ImportProject(ctx context.Context, in *ImportProjectRequest, opts ...grpc.CallOption) (*ImportProjectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportProjectResponse)
+	err := c.cc.Invoke(ctx, ProjectsService_ImportProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProjectsServiceServer is the server API for ProjectsService service.
 // All implementations must embed UnimplementedProjectsServiceServer
 // for forward compatibility.
@@ -2762,6 +3236,8 @@ type ProjectsServiceServer interface {
 	UpdateProject(context.Context, *UpdateProjectRequest) (*UpdateProjectResponse, error)
 	PatchProject(context.Context, *PatchProjectRequest) (*PatchProjectResponse, error)
 	CreateEntityReconciliationTask(context.Context, *CreateEntityReconciliationTaskRequest) (*CreateEntityReconciliationTaskResponse, error)
+	ExportProject(context.Context, *ExportProjectRequest) (*ExportProjectResponse, error)
+	ImportProject(context.Context, *ImportProjectRequest) (*ImportProjectResponse, error)
 	mustEmbedUnimplementedProjectsServiceServer()
 }
 
@@ -2793,6 +3269,12 @@ func (UnimplementedProjectsServiceServer) PatchProject(context.Context, *PatchPr
 func (UnimplementedProjectsServiceServer) CreateEntityReconciliationTask(context.Context, *CreateEntityReconciliationTaskRequest) (*CreateEntityReconciliationTaskResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateEntityReconciliationTask not implemented")
 }
+func (UnimplementedProjectsServiceServer) ExportProject(context.Context, *ExportProjectRequest) (*ExportProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportProject not implemented")
+}
+func (UnimplementedProjectsServiceServer) ImportProject(context.Context, *ImportProjectRequest) (*ImportProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportProject not implemented")
+}
 func (UnimplementedProjectsServiceServer) mustEmbedUnimplementedProjectsServiceServer() {}
 func (UnimplementedProjectsServiceServer) testEmbeddedByValue()                         {}
 
@@ -2940,6 +3422,42 @@ func _ProjectsService_CreateEntityReconciliationTask_Handler(srv interface{}, ct
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProjectsService_ExportProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectsServiceServer).ExportProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectsService_ExportProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectsServiceServer).ExportProject(ctx, req.(*ExportProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectsService_ImportProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectsServiceServer).ImportProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectsService_ImportProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectsServiceServer).ImportProject(ctx, req.(*ImportProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProjectsService_ServiceDesc is the grpc.ServiceDesc for ProjectsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -2975,6 +3493,14 @@ var ProjectsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateEntityReconciliationTask",
 			Handler:    _ProjectsService_CreateEntityReconciliationTask_Handler,
 		},
+		{
+			MethodName: "ExportProject",
+			Handler:    _ProjectsService_ExportProject_Handler,
+		},
+		{
+			MethodName: "ImportProject",
+			Handler:    _ProjectsService_ImportProject_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "minder/v1/minder.proto",
@@ -3449,3 +3975,149 @@ var InviteService_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "minder/v1/minder.proto",
 }
+
+const (
+	BundleService_ListBundleSubscriptions_FullMethodName = "/minder.v1.BundleService/ListBundleSubscriptions"
+	BundleService_UpgradeBundle_FullMethodName           = "/minder.v1.BundleService/UpgradeBundle"
+)
+
+// BundleServiceClient is the client API for BundleService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// BundleService manages the subscriptions of projects to the bundles of the
+// marketplace
+type BundleServiceClient interface {
+	ListBundleSubscriptions(ctx context.Context, in *ListBundleSubscriptionsRequest, opts ...grpc.CallOption) (*ListBundleSubscriptionsResponse, error)
+	UpgradeBundle(ctx context.Context, in *UpgradeBundleRequest, opts ...grpc.CallOption) (*UpgradeBundleResponse, error)
+}
+
+type bundleServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBundleServiceClient(cc grpc.ClientConnInterface) BundleServiceClient {
+	return &bundleServiceClient{cc}
+}
+
+func (c *bundleServiceClient) ListBundleSubscriptions(ctx context.Context, in *ListBundleSubscriptionsRequest, opts ...grpc.CallOption) (*ListBundleSubscriptionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBundleSubscriptionsResponse)
+	err := c.cc.Invoke(ctx, BundleService_ListBundleSubscriptions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bundleServiceClient) UpgradeBundle(ctx context.Context, in *UpgradeBundleRequest, opts ...grpc.CallOption) (*UpgradeBundleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpgradeBundleResponse)
+	err := c.cc.Invoke(ctx, BundleService_UpgradeBundle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BundleServiceServer is the server API for BundleService service.
+// All implementations must embed UnimplementedBundleServiceServer
+// for forward compatibility.
+//
+// BundleService manages the subscriptions of projects to the bundles of the
+// marketplace
+type BundleServiceServer interface {
+	ListBundleSubscriptions(context.Context, *ListBundleSubscriptionsRequest) (*ListBundleSubscriptionsResponse, error)
+	UpgradeBundle(context.Context, *UpgradeBundleRequest) (*UpgradeBundleResponse, error)
+	mustEmbedUnimplementedBundleServiceServer()
+}
+
+// UnimplementedBundleServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBundleServiceServer struct{}
+
+func (UnimplementedBundleServiceServer) ListBundleSubscriptions(context.Context, *ListBundleSubscriptionsRequest) (*ListBundleSubscriptionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBundleSubscriptions not implemented")
+}
+func (UnimplementedBundleServiceServer) UpgradeBundle(context.Context, *UpgradeBundleRequest) (*UpgradeBundleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpgradeBundle not implemented")
+}
+func (UnimplementedBundleServiceServer) mustEmbedUnimplementedBundleServiceServer() {}
+func (UnimplementedBundleServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeBundleServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BundleServiceServer will
+// result in compilation errors.
+type UnsafeBundleServiceServer interface {
+	mustEmbedUnimplementedBundleServiceServer()
+}
+
+func RegisterBundleServiceServer(s grpc.ServiceRegistrar, srv BundleServiceServer) {
+	// If the following call pancis, it indicates UnimplementedBundleServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BundleService_ServiceDesc, srv)
+}
+
+func _BundleService_ListBundleSubscriptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBundleSubscriptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BundleServiceServer).ListBundleSubscriptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BundleService_ListBundleSubscriptions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BundleServiceServer).ListBundleSubscriptions(ctx, req.(*ListBundleSubscriptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BundleService_UpgradeBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpgradeBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BundleServiceServer).UpgradeBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BundleService_UpgradeBundle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BundleServiceServer).UpgradeBundle(ctx, req.(*UpgradeBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BundleService_ServiceDesc is the grpc.ServiceDesc for BundleService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BundleService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "minder.v1.BundleService",
+	HandlerType: (*BundleServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListBundleSubscriptions",
+			Handler:    _BundleService_ListBundleSubscriptions_Handler,
+		},
+		{
+			MethodName: "UpgradeBundle",
+			Handler:    _BundleService_UpgradeBundle_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "minder/v1/minder.proto",
+}
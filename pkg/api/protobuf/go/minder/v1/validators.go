@@ -8,10 +8,12 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/itchyny/gojq"
 	"github.com/open-policy-agent/opa/v1/ast"
+	"github.com/robfig/cron/v3"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/mindersec/minder/internal/util"
@@ -114,6 +116,24 @@ func (d *DockerHubProviderConfig) Validate() error {
 	return nil
 }
 
+// Ensure OCIProviderConfig implements the Validator interface
+var _ Validator = (*OCIProviderConfig)(nil)
+
+// Validate is a utility function which allows for the validation of a struct.
+func (o *OCIProviderConfig) Validate() error {
+	if o.GetBaseUrl() == "" {
+		return fmt.Errorf("base_url is required")
+	}
+
+	// The base URL is a registry host, optionally prefixed with https://
+	host := strings.TrimPrefix(o.GetBaseUrl(), "https://")
+	if strings.Contains(host, "://") || strings.Contains(strings.TrimSuffix(host, "/"), "/") {
+		return fmt.Errorf("base_url must be a registry host, e.g. registry.example.com")
+	}
+
+	return nil
+}
+
 // Ensure Entity implements the Validator interface
 var _ Validator = (*Entity)(nil)
 
@@ -413,6 +433,14 @@ func (rem *RuleType_Definition_Remediate) Validate() error {
 		if err := rem.GetGhBranchProtection().Validate(); err != nil {
 			return err
 		}
+	} else if rem.Type == "gl_branch_protection" {
+		if err := rem.GetGlBranchProtection().Validate(); err != nil {
+			return err
+		}
+	} else if rem.Type == "gh_ruleset" {
+		if err := rem.GetGhRuleset().Validate(); err != nil {
+			return err
+		}
 	} else {
 		return fmt.Errorf("%w: remediate type cannot be empty", ErrInvalidRuleTypeDefinition)
 	}
@@ -446,6 +474,38 @@ func (ghp *RuleType_Definition_Remediate_GhBranchProtectionType) Validate() erro
 	return nil
 }
 
+// Validate validates a GitLab branch protection remediation
+func (glp *RuleType_Definition_Remediate_GlBranchProtectionType) Validate() error {
+	if glp == nil {
+		return fmt.Errorf("%w: gitlab branch protection remediation is nil", ErrInvalidRuleTypeDefinition)
+	}
+
+	_, err := util.NewSafeTextTemplate(&glp.Patch, "patch")
+	if err != nil {
+		return fmt.Errorf("%w: patch template is not parsable: %w", ErrInvalidRuleTypeDefinition, err)
+	}
+
+	return nil
+}
+
+// Validate validates a GitHub ruleset remediation
+func (ghr *RuleType_Definition_Remediate_GhRulesetType) Validate() error {
+	if ghr == nil {
+		return fmt.Errorf("%w: github ruleset remediation is nil", ErrInvalidRuleTypeDefinition)
+	}
+
+	if ghr.Name == "" {
+		return fmt.Errorf("%w: ruleset name cannot be empty", ErrInvalidRuleTypeDefinition)
+	}
+
+	_, err := util.NewSafeTextTemplate(&ghr.Patch, "patch")
+	if err != nil {
+		return fmt.Errorf("%w: patch template is not parsable: %w", ErrInvalidRuleTypeDefinition, err)
+	}
+
+	return nil
+}
+
 // Validate validates a pull request remediation
 func (prRem *RuleType_Definition_Remediate_PullRequestRemediation) Validate() error {
 	if prRem == nil {
@@ -546,6 +606,43 @@ func (p *Profile) Validate() error {
 		}
 	}
 
+	for i, o := range p.GetOrganization() {
+		if err := validateRule(o); err != nil {
+			return fmt.Errorf("organization rule %d is invalid: %w", i, err)
+		}
+	}
+
+	if p.GetSchedule() != nil {
+		if err := p.GetSchedule().Validate(); err != nil {
+			return fmt.Errorf("schedule is invalid: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Validate validates a profile schedule
+func (s *Profile_Schedule) Validate() error {
+	if (s.GetCron() == "") == (s.GetMinFreshness() == "") {
+		return fmt.Errorf("%w: exactly one of cron or min_freshness must be set", ErrValidationFailed)
+	}
+
+	if s.GetCron() != "" {
+		if _, err := cron.ParseStandard(s.GetCron()); err != nil {
+			return fmt.Errorf("%w: invalid cron expression: %w", ErrValidationFailed, err)
+		}
+	}
+
+	if s.GetMinFreshness() != "" {
+		d, err := time.ParseDuration(s.GetMinFreshness())
+		if err != nil {
+			return fmt.Errorf("%w: invalid min_freshness: %w", ErrValidationFailed, err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("%w: min_freshness must be positive", ErrValidationFailed)
+		}
+	}
+
 	return nil
 }
 
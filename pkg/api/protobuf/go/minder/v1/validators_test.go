@@ -517,6 +517,44 @@ func TestRuleType_Definition_Remediate_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid GitLab branch protection remediation",
+			rem: &RuleType_Definition_Remediate{
+				Type: "gl_branch_protection",
+				GlBranchProtection: &RuleType_Definition_Remediate_GlBranchProtectionType{
+					Patch: "patch content",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing GitLab branch protection remediation",
+			rem: &RuleType_Definition_Remediate{
+				Type: "gl_branch_protection",
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid GitHub ruleset remediation",
+			rem: &RuleType_Definition_Remediate{
+				Type: "gh_ruleset",
+				GhRuleset: &RuleType_Definition_Remediate_GhRulesetType{
+					Name:  "minder",
+					Patch: "patch content",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GitHub ruleset remediation without a name",
+			rem: &RuleType_Definition_Remediate{
+				Type: "gh_ruleset",
+				GhRuleset: &RuleType_Definition_Remediate_GhRulesetType{
+					Patch: "patch content",
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name:    "nil remediation",
 			rem:     nil,
@@ -669,3 +707,115 @@ func TestRuleType_Definition_Remediate_GhBranchProtectionType_Validate(t *testin
 		})
 	}
 }
+
+func TestOCIProviderConfig_Validate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		cfg     *OCIProviderConfig
+		wantErr bool
+	}{
+		{
+			name: "valid registry host",
+			cfg: &OCIProviderConfig{
+				BaseUrl: "harbor.example.com",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid registry host with https scheme and port",
+			cfg: &OCIProviderConfig{
+				BaseUrl: "https://quay.example.com:8443/",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "empty base url",
+			cfg:     &OCIProviderConfig{},
+			wantErr: true,
+		},
+		{
+			name: "plain http scheme",
+			cfg: &OCIProviderConfig{
+				BaseUrl: "http://harbor.example.com",
+			},
+			wantErr: true,
+		},
+		{
+			name: "base url with path",
+			cfg: &OCIProviderConfig{
+				BaseUrl: "harbor.example.com/my-project",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProfile_Schedule_Validate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		schedule *Profile_Schedule
+		wantErr  bool
+	}{
+		{
+			name:     "cron",
+			schedule: &Profile_Schedule{Cron: "0 2 * * *"},
+			wantErr:  false,
+		},
+		{
+			name:     "min freshness",
+			schedule: &Profile_Schedule{MinFreshness: "24h"},
+			wantErr:  false,
+		},
+		{
+			name:     "empty",
+			schedule: &Profile_Schedule{},
+			wantErr:  true,
+		},
+		{
+			name:     "both cron and min freshness",
+			schedule: &Profile_Schedule{Cron: "0 2 * * *", MinFreshness: "24h"},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid cron",
+			schedule: &Profile_Schedule{Cron: "every night"},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid min freshness",
+			schedule: &Profile_Schedule{MinFreshness: "1 day"},
+			wantErr:  true,
+		},
+		{
+			name:     "negative min freshness",
+			schedule: &Profile_Schedule{MinFreshness: "-1h"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.schedule.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -75,6 +75,44 @@ func TestValidateConfig(t *testing.T) {
 			},
 			errMsg: "cannot be negative",
 		},
+		{
+			name: "NegativeEntityTypeInterval",
+			config: reminder.Config{
+				RecurrenceConfig: reminder.RecurrenceConfig{
+					Interval:   parseTimeDuration(t, "1h"),
+					BatchSize:  100,
+					MinElapsed: parseTimeDuration(t, "1h"),
+					EntityTypes: reminder.EntityTypesConfig{
+						Artifact: reminder.EntityRecurrenceConfig{
+							Enabled:  true,
+							Interval: parseTimeDuration(t, "-1h"),
+						},
+					},
+				},
+				EventConfig: serverconfig.EventConfig{
+					Driver: constants.SQLDriver,
+				},
+			},
+			errMsg: "entity_types.artifact.interval -1h0m0s cannot be negative",
+		},
+		{
+			name: "NegativeProfileSchedulesRetryAfter",
+			config: reminder.Config{
+				RecurrenceConfig: reminder.RecurrenceConfig{
+					Interval:   parseTimeDuration(t, "1h"),
+					BatchSize:  100,
+					MinElapsed: parseTimeDuration(t, "1h"),
+					ProfileSchedules: reminder.ProfileSchedulesConfig{
+						Enabled:    true,
+						RetryAfter: parseTimeDuration(t, "-1h"),
+					},
+				},
+				EventConfig: serverconfig.EventConfig{
+					Driver: constants.SQLDriver,
+				},
+			},
+			errMsg: "profile_schedules.retry_after -1h0m0s cannot be negative",
+		},
 		{
 			name: "UnsupportedDriver",
 			config: reminder.Config{
@@ -134,6 +172,47 @@ recurrence:
 	require.Equal(t, "info", cfg.LoggingConfig.Level)
 }
 
+func TestReadConfigEntityTypes(t *testing.T) {
+	t.Parallel()
+
+	cfgstr := `---
+recurrence:
+  interval: "1h"
+  min_elapsed: "1h"
+  entity_types:
+    pull_request:
+      interval: "6h"
+      min_elapsed: "30m"
+    release:
+      enabled: false
+`
+
+	cfgbuf := bytes.NewBufferString(cfgstr)
+
+	v := viper.New()
+	reminder.SetViperDefaults(v)
+
+	v.SetConfigType("yaml")
+	require.NoError(t, v.ReadConfig(cfgbuf), "Unexpected error")
+
+	cfg, err := config.ReadConfigFromViper[reminder.Config](v)
+	require.NoError(t, err, "Unexpected error")
+
+	rc := cfg.RecurrenceConfig
+	require.True(t, rc.EntityTypes.Repository.Enabled)
+	require.True(t, rc.EntityTypes.Artifact.Enabled)
+	require.True(t, rc.EntityTypes.PullRequest.Enabled)
+	require.False(t, rc.EntityTypes.Release.Enabled)
+
+	require.Equal(t, parseTimeDuration(t, "1h"), rc.GetInterval(rc.EntityTypes.Repository))
+	require.Equal(t, parseTimeDuration(t, "1h"), rc.GetMinElapsed(rc.EntityTypes.Repository))
+	require.Equal(t, parseTimeDuration(t, "6h"), rc.GetInterval(rc.EntityTypes.PullRequest))
+	require.Equal(t, parseTimeDuration(t, "30m"), rc.GetMinElapsed(rc.EntityTypes.PullRequest))
+
+	require.True(t, rc.ProfileSchedules.Enabled)
+	require.Equal(t, parseTimeDuration(t, "1h"), rc.ProfileSchedules.RetryAfter)
+}
+
 func TestReadConfigWithCommandLineArgOverrides(t *testing.T) {
 	t.Parallel()
 
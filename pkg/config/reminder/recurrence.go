@@ -22,6 +22,60 @@ type RecurrenceConfig struct {
 	BatchSize int `mapstructure:"batch_size" default:"100"`
 	// MinElapsed is the minimum time after last update before sending a reminder
 	MinElapsed time.Duration `mapstructure:"min_elapsed" default:"1h"`
+	// EntityTypes contains the recurrence settings for each entity type
+	EntityTypes EntityTypesConfig `mapstructure:"entity_types"`
+	// ProfileSchedules contains the settings for evaluating profiles with a schedule
+	ProfileSchedules ProfileSchedulesConfig `mapstructure:"profile_schedules"`
+}
+
+// ProfileSchedulesConfig contains the settings for evaluating profiles with a
+// schedule. Schedules are checked on every reminder interval, so the interval
+// bounds how late a scheduled evaluation may be sent.
+type ProfileSchedulesConfig struct {
+	// Enabled controls whether evaluations are sent for profiles with a schedule
+	Enabled bool `mapstructure:"enabled" default:"true"`
+	// RetryAfter is the time after which an evaluation that was sent, but whose
+	// results were not recorded, is sent again
+	RetryAfter time.Duration `mapstructure:"retry_after" default:"1h"`
+}
+
+// EntityTypesConfig contains the recurrence settings for each entity type
+// the reminder sends reminders for
+type EntityTypesConfig struct {
+	Repository  EntityRecurrenceConfig `mapstructure:"repository"`
+	Artifact    EntityRecurrenceConfig `mapstructure:"artifact"`
+	PullRequest EntityRecurrenceConfig `mapstructure:"pull_request"`
+	Release     EntityRecurrenceConfig `mapstructure:"release"`
+}
+
+// EntityRecurrenceConfig contains the recurrence settings for a single entity type
+type EntityRecurrenceConfig struct {
+	// Enabled controls whether reminders are sent for the entity type
+	Enabled bool `mapstructure:"enabled" default:"true"`
+	// Interval is the time between reminders for the entity type. It is rounded up
+	// to a multiple of the global interval. Zero means the global interval is used.
+	Interval time.Duration `mapstructure:"interval" default:"0s"`
+	// MinElapsed is the minimum time after last update before sending a reminder
+	// for the entity type. Zero means the global min_elapsed is used.
+	MinElapsed time.Duration `mapstructure:"min_elapsed" default:"0s"`
+}
+
+// GetInterval returns the interval for the entity type, falling back to
+// the global interval if it isn't set
+func (r RecurrenceConfig) GetInterval(e EntityRecurrenceConfig) time.Duration {
+	if e.Interval > 0 {
+		return e.Interval
+	}
+	return r.Interval
+}
+
+// GetMinElapsed returns the minimum elapsed time for the entity type, falling
+// back to the global minimum elapsed time if it isn't set
+func (r RecurrenceConfig) GetMinElapsed(e EntityRecurrenceConfig) time.Duration {
+	if e.MinElapsed > 0 {
+		return e.MinElapsed
+	}
+	return r.MinElapsed
 }
 
 // Validate checks that the recurrence config is valid
@@ -34,6 +88,24 @@ func (r RecurrenceConfig) Validate() error {
 		return fmt.Errorf("interval %s cannot be negative", r.Interval)
 	}
 
+	for name, e := range map[string]EntityRecurrenceConfig{
+		"repository":   r.EntityTypes.Repository,
+		"artifact":     r.EntityTypes.Artifact,
+		"pull_request": r.EntityTypes.PullRequest,
+		"release":      r.EntityTypes.Release,
+	} {
+		if e.Interval < 0 {
+			return fmt.Errorf("entity_types.%s.interval %s cannot be negative", name, e.Interval)
+		}
+		if e.MinElapsed < 0 {
+			return fmt.Errorf("entity_types.%s.min_elapsed %s cannot be negative", name, e.MinElapsed)
+		}
+	}
+
+	if r.ProfileSchedules.RetryAfter < 0 {
+		return fmt.Errorf("profile_schedules.retry_after %s cannot be negative", r.ProfileSchedules.RetryAfter)
+	}
+
 	return nil
 }
 
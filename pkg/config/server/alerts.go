@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"strings"
+	"time"
+)
+
+// AlertsConfig is the configuration for the alert types which notify
+// services outside of the provider
+type AlertsConfig struct {
+	// Webhook is the configuration for the outbound webhook alert
+	Webhook WebhookAlertConfig `mapstructure:"webhook"`
+	// Chat is the configuration for the chat notification alert
+	Chat ChatAlertConfig `mapstructure:"chat"`
+}
+
+// WebhookAlertConfig is the configuration for the outbound webhook alert,
+// which POSTs signed CloudEvents when a rule evaluation changes state
+type WebhookAlertConfig struct {
+	// URL is the endpoint the CloudEvents are sent to. Webhook alerts are
+	// skipped if it is not set.
+	URL string `mapstructure:"url"`
+	// Secret is the secret used to sign the payloads with HMAC-SHA256
+	Secret string `mapstructure:"secret"`
+	// SecretFile is the location of the file containing the signing secret
+	SecretFile string `mapstructure:"secret_file"`
+	// MaxRetries is the number of times a failed delivery is retried
+	MaxRetries uint64 `mapstructure:"max_retries" default:"5"`
+	// InitialBackoff is the time to wait before the first retry. It is
+	// doubled after every failed attempt.
+	InitialBackoff time.Duration `mapstructure:"initial_backoff" default:"1s"`
+	// Timeout is the timeout of a single delivery attempt
+	Timeout time.Duration `mapstructure:"timeout" default:"10s"`
+}
+
+// GetSecret returns the secret used to sign the webhook payloads
+func (w *WebhookAlertConfig) GetSecret() (string, error) {
+	return fileOrArg(w.SecretFile, w.Secret, "webhook alert secret")
+}
+
+// ChatAlertConfig is the configuration for the chat notification alert,
+// which posts messages to a Slack or Microsoft Teams incoming webhook, or to
+// a Slack channel through the Slack Web API
+type ChatAlertConfig struct {
+	// Kind is the chat service the incoming webhook belongs to, one of
+	// "slack" or "teams"
+	Kind string `mapstructure:"kind" default:"slack"`
+	// WebhookURL is the incoming webhook URL. Chat alerts are skipped if
+	// neither it nor WebhookURLFile are set.
+	WebhookURL string `mapstructure:"webhook_url"`
+	// WebhookURLFile is the location of the file containing the incoming webhook URL
+	WebhookURLFile string `mapstructure:"webhook_url_file"`
+	// Token is a Slack bot token. When it is set, messages are posted to
+	// Channel through the Slack Web API instead of the incoming webhook, so
+	// that resolving an alert updates the original message.
+	Token string `mapstructure:"token"`
+	// TokenFile is the location of the file containing the Slack bot token
+	TokenFile string `mapstructure:"token_file"`
+	// Channel is the Slack channel the messages are posted to with the bot token
+	Channel string `mapstructure:"channel"`
+	// LinkTemplate is a Go template for the link added to the messages. It is
+	// rendered with the ProjectID, Profile, Rule, RuleType, EntityType, EntityID
+	// and EntityName fields. No link is added if it is empty.
+	LinkTemplate string `mapstructure:"link_template"`
+	// Timeout is the timeout of a single message post
+	Timeout time.Duration `mapstructure:"timeout" default:"10s"`
+}
+
+// GetWebhookURL returns the incoming webhook URL of the chat alert
+func (c *ChatAlertConfig) GetWebhookURL() (string, error) {
+	url, err := fileOrArg(c.WebhookURLFile, c.WebhookURL, "chat alert webhook URL")
+	return strings.TrimSpace(url), err
+}
+
+// GetToken returns the Slack bot token of the chat alert
+func (c *ChatAlertConfig) GetToken() (string, error) {
+	token, err := fileOrArg(c.TokenFile, c.Token, "chat alert token")
+	return strings.TrimSpace(token), err
+}
+
+// IsConfigured returns true if chat alerts have somewhere to post messages to
+func (c *ChatAlertConfig) IsConfigured() bool {
+	return c.WebhookURL != "" || c.WebhookURLFile != "" || c.Token != "" || c.TokenFile != ""
+}
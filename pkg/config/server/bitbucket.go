@@ -0,0 +1,17 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+// BitbucketConfig is the configuration for the Bitbucket OAuth providers
+type BitbucketConfig struct {
+	OAuthClientConfig `mapstructure:",squash"`
+
+	// WebhookSecrets is the configuration for the Bitbucket webhook secrets
+	// setup and verification. Each webhook gets a secret derived from these,
+	// which Bitbucket uses to sign the payloads it sends us.
+	WebhookSecrets `mapstructure:",squash"`
+
+	// Scopes is the list of scopes to request from the Bitbucket OAuth provider
+	Scopes []string `mapstructure:"scopes"`
+}
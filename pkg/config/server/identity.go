@@ -5,6 +5,7 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
@@ -21,6 +22,85 @@ import (
 type IdentityConfigWrapper struct {
 	Server            IdentityConfig `mapstructure:"server"`
 	AdditionalIssuers []string       `mapstructure:"additional_issuers"`
+	// OIDC configures generic OpenID Connect identity providers.  A provider
+	// with an empty name replaces the Keycloak identity server as the default
+	// identity provider.
+	OIDC []OIDCConfig `mapstructure:"oidc"`
+}
+
+// DefaultOIDC returns the OIDC provider which replaces the Keycloak identity
+// server, or nil if the Keycloak identity server is used.
+func (w *IdentityConfigWrapper) DefaultOIDC() *OIDCConfig {
+	for i := range w.OIDC {
+		if w.OIDC[i].Name == "" {
+			return &w.OIDC[i]
+		}
+	}
+	return nil
+}
+
+// OIDCConfig is the configuration for a generic OpenID Connect identity provider
+type OIDCConfig struct {
+	// Name is the short name of the provider, which prefixes the identities it
+	// vends.  An empty name makes this the default identity provider.
+	Name string `mapstructure:"name"`
+	// Issuer is the `iss` claim of the tokens vended by the provider.  The
+	// signing keys are discovered from the `.well-known/openid-configuration`
+	// document under this URL.
+	Issuer string `mapstructure:"issuer"`
+	// Audience is the expected audience for JWT tokens (see OpenID spec)
+	Audience string `mapstructure:"audience"`
+	// Claims are the names of the token claims which identify users
+	Claims OIDCClaimsConfig `mapstructure:"claims"`
+}
+
+// Validate checks that the OIDC provider configuration is complete
+func (c *OIDCConfig) Validate() error {
+	if c.Issuer == "" {
+		return fmt.Errorf("issuer is required for OIDC provider %q", c.Name)
+	}
+	if _, err := url.Parse(c.Issuer); err != nil {
+		return fmt.Errorf("invalid issuer for OIDC provider %q: %w", c.Name, err)
+	}
+	if c.Audience == "" {
+		return fmt.Errorf("audience is required for OIDC provider %q", c.Name)
+	}
+	return nil
+}
+
+// OIDCClaimsConfig maps token claims to the fields of an identity.  Claims
+// which are not set use the standard OpenID Connect claim.
+type OIDCClaimsConfig struct {
+	// Subject is the claim with the stable unique identifier of the user
+	Subject string `mapstructure:"subject"`
+	// HumanName is the claim with the human-readable name of the user
+	HumanName string `mapstructure:"human_name"`
+	// Email is the claim with the email address of the user
+	Email string `mapstructure:"email"`
+	// EmailVerified is the claim which tells whether the identity server
+	// verified the email address of the user
+	EmailVerified string `mapstructure:"email_verified"`
+	// FirstName is the claim with the first name of the user
+	FirstName string `mapstructure:"first_name"`
+	// LastName is the claim with the last name of the user
+	LastName string `mapstructure:"last_name"`
+}
+
+// WithDefaults returns the claims configuration with the standard OpenID
+// Connect claims in place of the ones which are not set.
+func (c OIDCClaimsConfig) WithDefaults() OIDCClaimsConfig {
+	setDefault := func(claim *string, def string) {
+		if *claim == "" {
+			*claim = def
+		}
+	}
+	setDefault(&c.Subject, "sub")
+	setDefault(&c.HumanName, "preferred_username")
+	setDefault(&c.Email, "email")
+	setDefault(&c.EmailVerified, "email_verified")
+	setDefault(&c.FirstName, "given_name")
+	setDefault(&c.LastName, "family_name")
+	return c
 }
 
 // IdentityConfig is the configuration for the identity provider in minder server
@@ -14,6 +14,8 @@ type ConfigBundleSource string
 const (
 	// TgzSource represents a bundle in a .tar.gz file
 	TgzSource ConfigBundleSource = "tgz"
+	// OCISource represents a bundle published to an OCI registry
+	OCISource ConfigBundleSource = "oci"
 	// Unknown is a default value
 	Unknown = "unknown"
 )
@@ -27,20 +29,31 @@ var (
 type MarketplaceConfig struct {
 	Enabled bool                 `mapstructure:"enabled" default:"false"`
 	Sources []BundleSourceConfig `mapstructure:"sources"`
+	// CacheDir is the directory where bundles pulled from OCI registries
+	// are cached. Bundles are pulled on every start if it is empty.
+	CacheDir string `mapstructure:"cache_dir"`
 }
 
 // BundleSourceConfig holds details about where the bundle gets loaded from
 type BundleSourceConfig struct {
-	Type     string `mapstructure:"type"`
+	Type string `mapstructure:"type"`
+	// Location is the path of the .tar.gz file for tgz sources, or the
+	// reference of the bundle for oci sources
 	Location string `mapstructure:"location"`
+	// Digest pins the digest of a bundle pulled from an OCI registry
+	Digest string `mapstructure:"digest"`
+	// PublicKey is the path to a PEM encoded public key. If set, the
+	// bundle must be signed by the matching private key.
+	PublicKey string `mapstructure:"public_key"`
 }
 
 // GetType returns the source as an enum type, or error if invalid
 // TODO: investigate whether mapstructure would allow us to validate during
 // deserialization.
 func (b *BundleSourceConfig) GetType() (ConfigBundleSource, error) {
-	if b.Type == string(TgzSource) {
-		return TgzSource, nil
+	switch ConfigBundleSource(b.Type) {
+	case TgzSource, OCISource:
+		return ConfigBundleSource(b.Type), nil
 	}
 	return Unknown, fmt.Errorf("%w: %s", ErrInvalidBundleSource, b.Type)
 }
@@ -3,7 +3,32 @@
 
 package server
 
+import "time"
+
 // MetricsConfig is the configuration for the metrics
 type MetricsConfig struct {
 	Enabled bool `mapstructure:"enabled" default:"true"`
+	// Compliance is the configuration for the compliance posture gauges
+	Compliance ComplianceMetricsConfig `mapstructure:"compliance"`
+}
+
+// ComplianceMetricsConfig is the configuration for the gauges reporting
+// the number of entities per evaluation status, project, profile and
+// rule type
+type ComplianceMetricsConfig struct {
+	// Enabled turns on the compliance gauges. They are computed from the
+	// latest evaluation statuses of all projects.
+	Enabled bool `mapstructure:"enabled" default:"false"`
+	// RefreshInterval is the time between two computations of the gauges
+	RefreshInterval time.Duration `mapstructure:"refresh_interval" default:"5m"`
+	// IncludeProfile adds the profile label to the gauges. When disabled,
+	// entities are counted per project and rule type only.
+	IncludeProfile bool `mapstructure:"include_profile" default:"true"`
+	// IncludeRuleType adds the rule type label to the gauges. When disabled,
+	// entities are counted per project and profile only.
+	IncludeRuleType bool `mapstructure:"include_rule_type" default:"true"`
+	// MaxSeries bounds the number of project, profile and rule type
+	// combinations reported. The combinations with the most entities are
+	// kept and the number of dropped ones is reported separately.
+	MaxSeries int `mapstructure:"max_series" default:"1000"`
 }
@@ -186,6 +186,15 @@ func pullRequestEnvFactory() (*cel.Env, error) {
 		"internal.SelectorPullRequest")
 }
 
+// organizationEnvFactory is a factory for creating a CEL environment
+// for the SelectorOrganization type representing an organization
+func organizationEnvFactory() (*cel.Env, error) {
+	return newEnvForEntity(
+		"organization",
+		&internalpb.SelectorOrganization{},
+		"internal.SelectorOrganization")
+}
+
 // newEnvForEntity creates a new CEL environment for an entity. All environments are allowed to
 // use the generic "entity" variable plus the specific entity type is also declared as variable
 // with the appropriate type.
@@ -289,6 +298,7 @@ func NewEnv() *Env {
 		minderv1.Entity_ENTITY_REPOSITORIES:  repoEnvFactory,
 		minderv1.Entity_ENTITY_ARTIFACTS:     artifactEnvFactory,
 		minderv1.Entity_ENTITY_PULL_REQUESTS: pullRequestEnvFactory,
+		minderv1.Entity_ENTITY_ORGANIZATION:  organizationEnvFactory,
 	}
 
 	entityEnvs := make(map[minderv1.Entity]*entityEnvCache, len(factoryMap))
@@ -522,6 +532,8 @@ func inputAsMap(se *internalpb.SelectorEntity) (map[string]any, error) {
 		value = se.GetArtifact()
 	case minderv1.Entity_ENTITY_PULL_REQUESTS:
 		value = se.GetPullRequest()
+	case minderv1.Entity_ENTITY_ORGANIZATION:
+		value = se.GetOrganization()
 	default:
 		return nil, fmt.Errorf("unsupported entity type [%d]: %s", se.GetEntityType(), se.GetEntityType().ToString())
 	}
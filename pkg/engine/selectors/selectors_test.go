@@ -25,6 +25,7 @@ func TestNewSelectorEngine(t *testing.T) {
 	require.NotNil(t, env.entityEnvs)
 	require.NotNil(t, env.entityEnvs[minderv1.Entity_ENTITY_REPOSITORIES])
 	require.NotNil(t, env.entityEnvs[minderv1.Entity_ENTITY_ARTIFACTS])
+	require.NotNil(t, env.entityEnvs[minderv1.Entity_ENTITY_ORGANIZATION])
 }
 
 type testProviderSelectorBuilder func() *internalpb.SelectorProvider
@@ -42,6 +43,7 @@ type testSelectorEntityBuilder func() *internalpb.SelectorEntity
 type testRepoOption func(selRepo *internalpb.SelectorRepository)
 type testArtifactOption func(selArtifact *internalpb.SelectorArtifact)
 type testPrOption func(selPr *internalpb.SelectorPullRequest)
+type testOrgOption func(selOrg *internalpb.SelectorOrganization)
 
 func newTestArtifactSelectorEntity(provSelBld testProviderSelectorBuilder, artifactOpts ...testArtifactOption) testSelectorEntityBuilder {
 	return func() *internalpb.SelectorEntity {
@@ -152,6 +154,40 @@ func newTestPullRequestSelectorEntity(provSelBld testProviderSelectorBuilder, pr
 	}
 }
 
+func newTestOrganizationSelectorEntity(provSelBld testProviderSelectorBuilder, orgOpts ...testOrgOption) testSelectorEntityBuilder {
+	return func() *internalpb.SelectorEntity {
+		org := &internalpb.SelectorEntity{
+			EntityType: minderv1.Entity_ENTITY_ORGANIZATION,
+			Name:       "testorg",
+			Entity: &internalpb.SelectorEntity_Organization{
+				Organization: &internalpb.SelectorOrganization{
+					Name: "testorg",
+				},
+			},
+		}
+
+		for _, opt := range orgOpts {
+			opt(org.Entity.(*internalpb.SelectorEntity_Organization).Organization)
+		}
+
+		provSel := provSelBld()
+		org.Provider = provSel
+		org.Entity.(*internalpb.SelectorEntity_Organization).Organization.Provider = provSel
+
+		return org
+	}
+}
+
+func orgWithProperties(properties map[string]any) testOrgOption {
+	return func(selOrg *internalpb.SelectorOrganization) {
+		protoProperties, err := structpb.NewStruct(properties)
+		if err != nil {
+			panic(err)
+		}
+		selOrg.Properties = protoProperties
+	}
+}
+
 func TestSelectSelectorEntity(t *testing.T) {
 	t.Parallel()
 
@@ -239,6 +275,33 @@ func TestSelectSelectorEntity(t *testing.T) {
 			selectorEntityBld: newTestPullRequestSelectorEntity(newGithubProviderSelector()),
 			selected:          false,
 		},
+		{
+			name: "Simple true organization expression",
+			exprs: []models.ProfileSelector{
+				{
+					Entity:   minderv1.Entity_ENTITY_ORGANIZATION,
+					Selector: "organization.name == 'testorg'",
+				},
+			},
+			selectorEntityBld: newTestOrganizationSelectorEntity(newGithubProviderSelector()),
+			selected:          true,
+		},
+		{
+			name: "Organization property expression",
+			exprs: []models.ProfileSelector{
+				{
+					Entity:   minderv1.Entity_ENTITY_ORGANIZATION,
+					Selector: "organization.properties.github['two_factor_requirement_enabled'] == true",
+				},
+			},
+			selectorEntityBld: newTestOrganizationSelectorEntity(
+				newGithubProviderSelector(),
+				orgWithProperties(map[string]any{
+					"github": map[string]any{"two_factor_requirement_enabled": false},
+				}),
+			),
+			selected: false,
+		},
 		{
 			name: "Simple true generic entity expression for repo entity type",
 			exprs: []models.ProfileSelector{
@@ -56,6 +56,24 @@ type EvaluationResult struct {
 	// Output is the output of the evaluation. This contains a list of additional
 	// information about the evaluation, which may be used in downstream actions.
 	Output any
+	// Annotations are findings of the evaluation which point at specific lines
+	// of a file, e.g. so that they can be shown on a pull request check run.
+	Annotations []Annotation
+}
+
+// Annotation is a finding of an evaluation located in a file
+type Annotation struct {
+	// Path is the path of the file, relative to the root of the repository
+	Path string
+	// StartLine is the first line the finding applies to
+	StartLine int
+	// EndLine is the last line the finding applies to. If unset, the finding
+	// only applies to StartLine.
+	EndLine int
+	// Title is a short summary of the finding
+	Title string
+	// Message describes the finding
+	Message string
 }
 
 // GetCheckpoint returns the checkpoint of the result
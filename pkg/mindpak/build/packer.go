@@ -7,7 +7,9 @@ package build
 
 import (
 	"archive/tar"
+	"bytes"
 	"compress/gzip"
+	"crypto"
 	"errors"
 	"fmt"
 	"io"
@@ -31,6 +33,12 @@ func NewPacker() *Packer {
 type InitOptions struct {
 	*mindpak.Metadata
 	Path string
+	// SigningKey is used to sign the bundle manifest. The bundle is left
+	// unsigned if it is nil.
+	SigningKey crypto.Signer
+	// Out receives the paths of the files written to the bundle directory,
+	// one per line. Nothing is reported if it is nil.
+	Out io.Writer
 }
 
 // Validate checks the initializer options
@@ -89,18 +97,54 @@ func (_ *Packer) InitBundle(opts *InitOptions) (*mindpak.Bundle, error) {
 	t := time.Now()
 	bundle.Metadata.Date = &t
 
-	f, err := os.Create(filepath.Join(opts.Path, mindpak.ManifestFileName))
-	if err != nil {
-		return nil, fmt.Errorf("opening manifest file: %w", err)
+	var manifest bytes.Buffer
+	if err := bundle.Manifest.Write(&manifest); err != nil {
+		return nil, fmt.Errorf("writing manifest data: %w", err)
 	}
 
-	if err := bundle.Manifest.Write(f); err != nil {
-		return nil, fmt.Errorf("writing manifest data: %w", err)
+	manifestPath := filepath.Join(opts.Path, mindpak.ManifestFileName)
+	if err := os.WriteFile(manifestPath, manifest.Bytes(), 0600); err != nil {
+		return nil, fmt.Errorf("writing manifest file: %w", err)
+	}
+	opts.reportWrite(manifestPath)
+
+	if err := writeSignature(opts, manifest.Bytes()); err != nil {
+		return nil, err
 	}
-	fmt.Printf("wrote to %s\n", f.Name())
 	return bundle, nil
 }
 
+// writeSignature writes the detached signature of the manifest next to it.
+// Any signature left from a previous build is removed when the bundle is
+// not signed, as it no longer matches the manifest.
+func writeSignature(opts *InitOptions, manifest []byte) error {
+	sigPath := filepath.Join(opts.Path, mindpak.SignatureFileName)
+	if opts.SigningKey == nil {
+		if err := os.Remove(sigPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("removing stale signature: %w", err)
+		}
+		return nil
+	}
+
+	sig, err := mindpak.SignManifest(opts.SigningKey, manifest)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(sigPath, sig, 0600); err != nil {
+		return fmt.Errorf("writing signature file: %w", err)
+	}
+	opts.reportWrite(sigPath)
+	return nil
+}
+
+// reportWrite tells the caller that a file was written
+func (opts *InitOptions) reportWrite(path string) {
+	if opts.Out == nil {
+		return
+	}
+	fmt.Fprintf(opts.Out, "wrote to %s\n", path)
+}
+
 // WriteToFile writes the bundle to a file on disk.
 func (p *Packer) WriteToFile(bundle *mindpak.Bundle, path string) error {
 	path = filepath.Clean(path)
@@ -7,6 +7,7 @@
 package build
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"testing"
@@ -122,6 +123,10 @@ func TestPackerInitBundle(t *testing.T) {
 			t.Parallel()
 
 			tc.prepare(t, tc.opts)
+			var out bytes.Buffer
+			if tc.opts != nil {
+				tc.opts.Out = &out
+			}
 			p := NewPacker()
 
 			// Run the bundle initialization
@@ -131,7 +136,9 @@ func TestPackerInitBundle(t *testing.T) {
 				return
 			}
 			require.NoError(t, err)
-			require.FileExists(t, filepath.Join(tc.opts.Path, mindpak.ManifestFileName))
+			manifestPath := filepath.Join(tc.opts.Path, mindpak.ManifestFileName)
+			require.FileExists(t, manifestPath)
+			require.Contains(t, out.String(), "wrote to "+manifestPath+"\n")
 		})
 	}
 }
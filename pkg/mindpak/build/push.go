@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package build
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+
+	"github.com/mindersec/minder/pkg/mindpak"
+)
+
+// Push publishes the bundle archive at path to an OCI registry as an
+// artifact with a single layer holding the archive. The bundle is verified
+// against its manifest before being pushed. It returns the digest of the
+// pushed artifact, which pins the bundle when loading it.
+func (_ *Packer) Push(ctx context.Context, path string, reference string, opts ...remote.Option) (v1.Hash, error) {
+	ref, err := name.ParseReference(reference)
+	if err != nil {
+		return v1.Hash{}, fmt.Errorf("parsing reference %q: %w", reference, err)
+	}
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return v1.Hash{}, fmt.Errorf("reading bundle archive: %w", err)
+	}
+
+	bundle, err := mindpak.NewBundleFromTarGZReader(bytes.NewReader(data))
+	if err != nil {
+		return v1.Hash{}, fmt.Errorf("loading bundle archive: %w", err)
+	}
+	if err := bundle.Verify(); err != nil {
+		return v1.Hash{}, fmt.Errorf("bundle failed verification: %w", err)
+	}
+
+	img, err := mutate.Append(empty.Image, mutate.Addendum{
+		Layer: static.NewLayer(data, types.MediaType(mindpak.LayerMediaType)),
+		Annotations: map[string]string{
+			"org.opencontainers.image.title": filepath.Base(path),
+		},
+	})
+	if err != nil {
+		return v1.Hash{}, fmt.Errorf("creating bundle artifact: %w", err)
+	}
+	img = mutate.MediaType(img, types.OCIManifestSchema1)
+	img = mutate.ConfigMediaType(img, types.MediaType(mindpak.ConfigMediaType))
+
+	if err := remote.Write(ref, img, append(opts, remote.WithContext(ctx))...); err != nil {
+		return v1.Hash{}, fmt.Errorf("pushing bundle to %s: %w", ref, err)
+	}
+
+	digest, err := img.Digest()
+	if err != nil {
+		return v1.Hash{}, fmt.Errorf("computing bundle artifact digest: %w", err)
+	}
+	return digest, nil
+}
@@ -9,6 +9,7 @@ import (
 	"archive/tar"
 	"compress/gzip"
 	"crypto/sha256"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
@@ -69,9 +70,20 @@ func NewBundleFromTarGZ(path string) (*Bundle, error) {
 	}
 	defer file.Close()
 
-	gz, err := gzip.NewReader(file)
+	bundle, err := NewBundleFromTarGZReader(file)
 	if err != nil {
-		return nil, fmt.Errorf("error while creating gzip reader for %s: %w", path, err)
+		return nil, fmt.Errorf("reading bundle from %q: %w", path, err)
+	}
+	return bundle, nil
+}
+
+// NewBundleFromTarGZReader loads a bundle from a .tar.gz stream containing
+// the bundle structure. Like NewBundleFromTarGZ, it loads the entire
+// contents of the bundle into memory.
+func NewBundleFromTarGZReader(r io.Reader) (*Bundle, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("error while creating gzip reader: %w", err)
 	}
 	defer gz.Close()
 
@@ -85,7 +97,7 @@ func NewBundleFromTarGZ(path string) (*Bundle, error) {
 		Source: sourceFS,
 	}
 	if err := bundle.ReadSource(); err != nil {
-		return nil, fmt.Errorf("reading bundle data from %q: %w", path, err)
+		return nil, fmt.Errorf("reading bundle data: %w", err)
 	}
 
 	return bundle, nil
@@ -185,10 +197,56 @@ func pathInKnownDirectory(path string) bool {
 		strings.HasPrefix(path, ManifestFileName)
 }
 
-// Verify checks the contents of the bundle against its manifest
-func (_ *Bundle) Verify() error {
-	// FIXME(puerco): Implement
-	return nil
+// Verify checks the contents of the bundle against its manifest. Every file
+// in the bundle must be listed in the manifest with a matching digest, and
+// every file listed in the manifest must be present in the bundle.
+func (b *Bundle) Verify() error {
+	if b.Manifest == nil || b.Manifest.Files == nil {
+		return fmt.Errorf("bundle has no manifest")
+	}
+	if b.Files == nil {
+		return fmt.Errorf("bundle contents have not been read")
+	}
+
+	errs := []error{}
+	errs = append(errs, verifyFiles(PathProfiles, b.Manifest.Files.Profiles, b.Files.Profiles)...)
+	errs = append(errs, verifyFiles(PathRuleTypes, b.Manifest.Files.RuleTypes, b.Files.RuleTypes)...)
+	errs = append(errs, verifyFiles(PathDataSources, b.Manifest.Files.DataSources, b.Files.DataSources)...)
+	return errors.Join(errs...)
+}
+
+// verifyFiles compares the files of one of the bundle directories with the
+// entries of the manifest
+func verifyFiles(dir string, expected []*File, actual []*File) []error {
+	errs := []error{}
+
+	manifestHashes := make(map[string]string, len(expected))
+	for _, f := range expected {
+		digest, ok := f.Hashes[SHA256]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s/%s: no %s digest in manifest", dir, f.Name, SHA256))
+			continue
+		}
+		manifestHashes[f.Name] = digest
+	}
+
+	for _, f := range actual {
+		digest, ok := manifestHashes[f.Name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s/%s: file not listed in manifest", dir, f.Name))
+			continue
+		}
+		delete(manifestHashes, f.Name)
+		if digest != f.Hashes[SHA256] {
+			errs = append(errs, fmt.Errorf("%s/%s: %s digest does not match manifest", dir, f.Name, SHA256))
+		}
+	}
+
+	for name := range manifestHashes {
+		errs = append(errs, fmt.Errorf("%s/%s: file listed in manifest is missing", dir, name))
+	}
+
+	return errs
 }
 
 func copyTarIntoMemory(tarReader *tar.Reader) (fs.StatFS, error) {
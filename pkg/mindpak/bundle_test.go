@@ -7,6 +7,7 @@ import (
 	"io/fs"
 	"os"
 	"testing"
+	"testing/fstest"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/require"
@@ -96,3 +97,75 @@ func TestReadSource(t *testing.T) {
 
 	}
 }
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	manifest, err := os.ReadFile("testdata/t2/manifest.json")
+	require.NoError(t, err)
+	profile, err := os.ReadFile("testdata/t2/profiles/branch-protection-github-profile.yaml")
+	require.NoError(t, err)
+	ruleType, err := os.ReadFile("testdata/t2/rule_types/branch_protection_enabled.yaml")
+	require.NoError(t, err)
+	dataSource, err := os.ReadFile("testdata/t2/data_sources/osv.yaml")
+	require.NoError(t, err)
+
+	bundleFS := func() fstest.MapFS {
+		return fstest.MapFS{
+			ManifestFileName: {Data: manifest},
+			PathProfiles + "/branch-protection-github-profile.yaml": {Data: profile},
+			PathRuleTypes + "/branch_protection_enabled.yaml":       {Data: ruleType},
+			PathDataSources + "/osv.yaml":                           {Data: dataSource},
+		}
+	}
+
+	for _, tc := range []struct {
+		name    string
+		modify  func(fstest.MapFS)
+		errMsgs []string
+	}{
+		{
+			name:   "valid",
+			modify: func(fstest.MapFS) {},
+		},
+		{
+			name: "tampered file",
+			modify: func(m fstest.MapFS) {
+				m[PathRuleTypes+"/branch_protection_enabled.yaml"] = &fstest.MapFile{Data: []byte("tampered")}
+			},
+			errMsgs: []string{"rule_types/branch_protection_enabled.yaml: sha-256 digest does not match manifest"},
+		},
+		{
+			name: "unlisted file",
+			modify: func(m fstest.MapFS) {
+				m[PathProfiles+"/extra.yaml"] = &fstest.MapFile{Data: []byte("extra")}
+			},
+			errMsgs: []string{"profiles/extra.yaml: file not listed in manifest"},
+		},
+		{
+			name: "missing file",
+			modify: func(m fstest.MapFS) {
+				delete(m, PathDataSources+"/osv.yaml")
+			},
+			errMsgs: []string{"data_sources/osv.yaml: file listed in manifest is missing"},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			m := bundleFS()
+			tc.modify(m)
+
+			b := Bundle{Source: m}
+			require.NoError(t, b.ReadSource())
+			err := b.Verify()
+			if len(tc.errMsgs) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			for _, msg := range tc.errMsgs {
+				require.ErrorContains(t, err, msg)
+			}
+		})
+	}
+}
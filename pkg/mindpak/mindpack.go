@@ -24,6 +24,16 @@ const (
 	ManifestFileName = "manifest.json"
 )
 
+const (
+	// ConfigMediaType is the media type of the config of a bundle
+	// published as an OCI artifact, which identifies the artifact type
+	ConfigMediaType = "application/vnd.minder.mindpak.config.v1+json"
+
+	// LayerMediaType is the media type of the layer holding the bundle
+	// archive in a bundle published as an OCI artifact
+	LayerMediaType = "application/vnd.minder.mindpak.layer.v1.tar+gzip"
+)
+
 const (
 	// SHA256 is the algorith name constant for the manifest and tests
 	SHA256 = HashAlgorithm("sha-256")
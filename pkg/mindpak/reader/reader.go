@@ -5,6 +5,7 @@
 package reader
 
 import (
+	"errors"
 	"fmt"
 	"io/fs"
 	"strings"
@@ -14,6 +15,10 @@ import (
 	"github.com/mindersec/minder/pkg/profiles"
 )
 
+// ErrProfileNotFound is returned by GetProfile when the bundle has no
+// profile with the given name
+var ErrProfileNotFound = errors.New("profile does not exist in bundle")
+
 // BundleReader provides a high-level interface for accessing the contents of
 // a Bundle
 type BundleReader interface {
@@ -70,7 +75,7 @@ func (b *bundleReader) GetProfile(name string) (*v1.Profile, error) {
 	// validate that profile exists
 	_, ok := b.profiles[name]
 	if !ok {
-		return nil, fmt.Errorf("profile does not exist in bundle: %s", name)
+		return nil, fmt.Errorf("%w: %s", ErrProfileNotFound, name)
 	}
 
 	// read from bundle
@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mindpak
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// SignatureFileName is the name of the file holding the detached signature
+// of the manifest. As the manifest records the digests of all the files in
+// the bundle, signing it covers the whole bundle.
+const SignatureFileName = ManifestFileName + ".sig"
+
+var (
+	// ErrBundleNotSigned is returned when verifying the signature of a
+	// bundle which has none
+	ErrBundleNotSigned = errors.New("bundle is not signed")
+	// ErrInvalidSignature is returned when the signature of a bundle does
+	// not match its manifest
+	ErrInvalidSignature = errors.New("invalid bundle signature")
+)
+
+// LoadSigningKey reads a PEM encoded, unencrypted ECDSA or Ed25519 private
+// key from path, e.g. one created with `openssl genpkey -algorithm ed25519`.
+func LoadSigningKey(path string) (crypto.Signer, error) {
+	block, err := readPEMFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var key any
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q in %s", block.Type, path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing private key: %w", err)
+	}
+
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		return k, nil
+	case ed25519.PrivateKey:
+		return k, nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+}
+
+// LoadVerificationKey reads a PEM encoded ECDSA or Ed25519 public key from
+// path.
+func LoadVerificationKey(path string) (crypto.PublicKey, error) {
+	block, err := readPEMFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("unsupported PEM block type %q in %s", block.Type, path)
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing public key: %w", err)
+	}
+
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		return k, nil
+	case ed25519.PublicKey:
+		return k, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+// SignManifest signs the serialized manifest with key and returns the
+// base64 encoded signature, as written to SignatureFileName.
+func SignManifest(key crypto.Signer, manifest []byte) ([]byte, error) {
+	var sig []byte
+	var err error
+	switch key.Public().(type) {
+	case ed25519.PublicKey:
+		sig, err = key.Sign(rand.Reader, manifest, crypto.Hash(0))
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(manifest)
+		sig, err = key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	default:
+		return nil, fmt.Errorf("unsupported signing key type %T", key.Public())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("signing manifest: %w", err)
+	}
+
+	encoded := make([]byte, base64.StdEncoding.EncodedLen(len(sig)))
+	base64.StdEncoding.Encode(encoded, sig)
+	return encoded, nil
+}
+
+// VerifySignature checks the detached signature of the bundle manifest
+// against key. ErrBundleNotSigned is returned if the bundle has no
+// signature. This does not check the files against the manifest, which is
+// done by Verify.
+func (b *Bundle) VerifySignature(key crypto.PublicKey) error {
+	if b.Source == nil {
+		return fmt.Errorf("unable to verify signature, mindpak filesystem not defined")
+	}
+
+	manifest, err := fs.ReadFile(b.Source, ManifestFileName)
+	if err != nil {
+		return fmt.Errorf("reading manifest: %w", err)
+	}
+
+	encoded, err := fs.ReadFile(b.Source, SignatureFileName)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrBundleNotSigned
+	} else if err != nil {
+		return fmt.Errorf("reading signature: %w", err)
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(encoded)))
+	if err != nil {
+		return fmt.Errorf("%w: decoding signature: %w", ErrInvalidSignature, err)
+	}
+
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		if !ed25519.Verify(k, manifest, sig) {
+			return ErrInvalidSignature
+		}
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(manifest)
+		if !ecdsa.VerifyASN1(k, digest[:], sig) {
+			return ErrInvalidSignature
+		}
+	default:
+		return fmt.Errorf("unsupported verification key type %T", key)
+	}
+
+	return nil
+}
+
+func readPEMFile(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("reading key file: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", path)
+	}
+	return block, nil
+}
@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mindpak
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifySignature(t *testing.T) {
+	t.Parallel()
+
+	manifest := []byte(`{"metadata":{"name":"test"},"files":{}}`)
+
+	for _, tc := range []struct {
+		name     string
+		keyType  string
+		modify   func(fstest.MapFS)
+		wrongKey bool
+		wantErr  error
+	}{
+		{name: "ed25519", keyType: "ed25519"},
+		{name: "ecdsa", keyType: "ecdsa"},
+		{
+			name:    "tampered manifest",
+			keyType: "ed25519",
+			modify: func(m fstest.MapFS) {
+				m[ManifestFileName] = &fstest.MapFile{Data: []byte(`{"files":{}}`)}
+			},
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "unsigned",
+			keyType: "ecdsa",
+			modify: func(m fstest.MapFS) {
+				delete(m, SignatureFileName)
+			},
+			wantErr: ErrBundleNotSigned,
+		},
+		{
+			name:     "wrong key",
+			keyType:  "ecdsa",
+			wrongKey: true,
+			wantErr:  ErrInvalidSignature,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			privPath, pubPath := writeTestKeys(t, dir, tc.keyType)
+
+			signer, err := LoadSigningKey(privPath)
+			require.NoError(t, err)
+			sig, err := SignManifest(signer, manifest)
+			require.NoError(t, err)
+
+			if tc.wrongKey {
+				_, pubPath = writeTestKeys(t, t.TempDir(), tc.keyType)
+			}
+			pub, err := LoadVerificationKey(pubPath)
+			require.NoError(t, err)
+
+			m := fstest.MapFS{
+				ManifestFileName:  {Data: manifest},
+				SignatureFileName: {Data: sig},
+			}
+			if tc.modify != nil {
+				tc.modify(m)
+			}
+
+			b := Bundle{Source: m}
+			err = b.VerifySignature(pub)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestLoadVerificationKeyInvalid(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	privPath, _ := writeTestKeys(t, dir, "ed25519")
+
+	// a private key is not a valid verification key
+	_, err := LoadVerificationKey(privPath)
+	require.Error(t, err)
+
+	_, err = LoadVerificationKey(filepath.Join(dir, "missing.pem"))
+	require.Error(t, err)
+}
+
+func writeTestKeys(t *testing.T, dir string, keyType string) (string, string) {
+	t.Helper()
+
+	var priv crypto.Signer
+	var err error
+	switch keyType {
+	case "ed25519":
+		_, priv, err = ed25519.GenerateKey(rand.Reader)
+	case "ecdsa":
+		priv, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	}
+	require.NoError(t, err)
+
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	pubDER, err := x509.MarshalPKIXPublicKey(priv.Public())
+	require.NoError(t, err)
+
+	privPath := filepath.Join(dir, "key.pem")
+	pubPath := filepath.Join(dir, "key.pub")
+	require.NoError(t, os.WriteFile(privPath,
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}), 0600))
+	require.NoError(t, os.WriteFile(pubPath,
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0600))
+	return privPath, pubPath
+}
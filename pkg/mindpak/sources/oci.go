@@ -0,0 +1,220 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sources
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+
+	"github.com/mindersec/minder/pkg/mindpak"
+)
+
+const (
+	// maxOCIBundleSize bounds the size of the bundle archive pulled from
+	// a registry, as bundles are loaded into memory
+	maxOCIBundleSize = 64 << 20
+
+	cachedManifestFileName = "manifest.json"
+	cachedBundleFileName   = "bundle.tar.gz"
+)
+
+// OCIOptions configures how a bundle is pulled from an OCI registry
+type OCIOptions struct {
+	// Digest pins the digest of the bundle artifact. It must match the
+	// digest of the reference, if the reference has one.
+	Digest string
+	// CacheDir is the directory where pulled bundles are cached by digest.
+	// Bundles pinned by digest are loaded from the cache without reaching
+	// the registry. Bundles are not cached if it is empty.
+	CacheDir string
+	// PublicKey, if set, is the key which must have signed the bundle
+	PublicKey crypto.PublicKey
+	// RemoteOptions are passed to the registry client, e.g. for
+	// authentication
+	RemoteOptions []remote.Option
+}
+
+// NewSourceFromOCI creates a singleBundleSource from a bundle published to
+// an OCI registry, e.g. with `mindev bundle push`. The bundle is verified
+// like bundles loaded from a .tar.gz file.
+func NewSourceFromOCI(ctx context.Context, reference string, opts OCIOptions) (BundleSource, error) {
+	ref, err := ociReference(reference, opts.Digest)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := fetchOCIBundle(ctx, ref, opts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to pull bundle from %s: %w", ref, err)
+	}
+
+	bundle, err := mindpak.NewBundleFromTarGZReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("unable to load bundle from %s: %w", ref, err)
+	}
+	return newVerifiedSource(bundle, opts.PublicKey)
+}
+
+// ociReference parses the reference, pinning it to digest if set
+func ociReference(reference string, digest string) (name.Reference, error) {
+	ref, err := name.ParseReference(reference)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bundle reference %q: %w", reference, err)
+	}
+	if digest == "" {
+		return ref, nil
+	}
+
+	if d, ok := ref.(name.Digest); ok && d.DigestStr() != digest {
+		return nil, fmt.Errorf("digest of reference %q does not match %s", reference, digest)
+	}
+	pinned, err := name.NewDigest(fmt.Sprintf("%s@%s", ref.Context().Name(), digest))
+	if err != nil {
+		return nil, fmt.Errorf("invalid bundle digest %q: %w", digest, err)
+	}
+	return pinned, nil
+}
+
+// fetchOCIBundle returns the bundle archive, from the cache if possible
+func fetchOCIBundle(ctx context.Context, ref name.Reference, opts OCIOptions) ([]byte, error) {
+	if d, ok := ref.(name.Digest); ok && opts.CacheDir != "" {
+		if data, err := readCachedBundle(opts.CacheDir, d.DigestStr()); err == nil {
+			return data, nil
+		}
+	}
+
+	ropts := append([]remote.Option{remote.WithContext(ctx)}, opts.RemoteOptions...)
+	// the manifest is checked against the digest of the reference, if any
+	desc, err := remote.Get(ref, ropts...)
+	if err != nil {
+		return nil, fmt.Errorf("fetching bundle manifest: %w", err)
+	}
+
+	if opts.CacheDir != "" {
+		if data, err := readCachedBundle(opts.CacheDir, desc.Digest.String()); err == nil {
+			return data, nil
+		}
+	}
+
+	layerDesc, err := bundleLayer(desc.Manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	layer, err := remote.Layer(ref.Context().Digest(layerDesc.Digest.String()), ropts...)
+	if err != nil {
+		return nil, fmt.Errorf("fetching bundle layer: %w", err)
+	}
+	rc, err := layer.Compressed()
+	if err != nil {
+		return nil, fmt.Errorf("fetching bundle layer: %w", err)
+	}
+	defer rc.Close()
+
+	// the layer contents are checked against its digest while reading
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("reading bundle layer: %w", err)
+	}
+
+	if opts.CacheDir != "" {
+		if err := writeCachedBundle(opts.CacheDir, desc.Digest.String(), desc.Manifest, data); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// bundleLayer returns the descriptor of the layer holding the bundle archive
+func bundleLayer(rawManifest []byte) (*v1.Descriptor, error) {
+	manifest, err := v1.ParseManifest(bytes.NewReader(rawManifest))
+	if err != nil {
+		return nil, fmt.Errorf("parsing bundle manifest: %w", err)
+	}
+	if manifest.Config.MediaType != mindpak.ConfigMediaType {
+		return nil, fmt.Errorf("artifact is not a bundle, unexpected config media type %q", manifest.Config.MediaType)
+	}
+	if len(manifest.Layers) != 1 || manifest.Layers[0].MediaType != mindpak.LayerMediaType {
+		return nil, errors.New("bundle artifact must have a single bundle layer")
+	}
+	if manifest.Layers[0].Size > maxOCIBundleSize {
+		return nil, fmt.Errorf("bundle layer exceeds the maximum size of %d bytes", maxOCIBundleSize)
+	}
+	return &manifest.Layers[0], nil
+}
+
+// readCachedBundle reads the bundle archive cached for the digest. The
+// cached manifest is checked against the digest, and the archive against
+// the manifest.
+func readCachedBundle(cacheDir string, digest string) ([]byte, error) {
+	dir := cacheEntryDir(cacheDir, digest)
+
+	rawManifest, err := os.ReadFile(filepath.Join(dir, cachedManifestFileName))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkDigest(rawManifest, digest); err != nil {
+		return nil, err
+	}
+
+	layerDesc, err := bundleLayer(rawManifest)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, cachedBundleFileName))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkDigest(data, layerDesc.Digest.String()); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func writeCachedBundle(cacheDir string, digest string, rawManifest []byte, data []byte) error {
+	dir := cacheEntryDir(cacheDir, digest)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("creating bundle cache directory: %w", err)
+	}
+	// the manifest is written last, so a partially written entry is
+	// ignored when reading the cache
+	if err := os.WriteFile(filepath.Join(dir, cachedBundleFileName), data, 0600); err != nil {
+		return fmt.Errorf("writing cached bundle: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, cachedManifestFileName), rawManifest, 0600); err != nil {
+		return fmt.Errorf("writing cached bundle manifest: %w", err)
+	}
+	return nil
+}
+
+func cacheEntryDir(cacheDir string, digest string) string {
+	return filepath.Join(filepath.Clean(cacheDir), strings.ReplaceAll(digest, ":", "-"))
+}
+
+func checkDigest(data []byte, digest string) error {
+	expected, err := v1.NewHash(digest)
+	if err != nil {
+		return err
+	}
+	actual, _, err := v1.SHA256(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if actual != expected {
+		return fmt.Errorf("digest mismatch: expected %s, got %s", expected, actual)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sources_test
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mindersec/minder/pkg/mindpak"
+	"github.com/mindersec/minder/pkg/mindpak/build"
+	"github.com/mindersec/minder/pkg/mindpak/sources"
+)
+
+func TestNewSourceFromOCI(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(registry.New())
+	t.Cleanup(ts.Close)
+	u, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+
+	reference := fmt.Sprintf("%s/bundles/t2:v0.0.1", u.Host)
+	digest, err := build.NewPacker().Push(context.Background(), sampleDataPath, reference)
+	require.NoError(t, err)
+
+	// an image which is not a bundle
+	notBundle := fmt.Sprintf("%s/bundles/image:latest", u.Host)
+	img, err := random.Image(64, 1)
+	require.NoError(t, err)
+	notBundleRef, err := name.ParseReference(notBundle)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(notBundleRef, img))
+
+	cacheDir := t.TempDir()
+
+	scenarios := []struct {
+		Name          string
+		Reference     string
+		Options       sources.OCIOptions
+		ExpectedError string
+	}{
+		{
+			Name:      "loads bundle by tag",
+			Reference: reference,
+		},
+		{
+			Name:      "loads bundle pinned by digest",
+			Reference: reference,
+			Options:   sources.OCIOptions{Digest: digest.String(), CacheDir: cacheDir},
+		},
+		{
+			Name:      "loads bundle by digest reference",
+			Reference: fmt.Sprintf("%s/bundles/t2@%s", u.Host, digest),
+		},
+		{
+			Name:          "refuses mismatching digest",
+			Reference:     fmt.Sprintf("%s/bundles/t2@%s", u.Host, digest),
+			Options:       sources.OCIOptions{Digest: "sha256:" + fmt.Sprintf("%064d", 0)},
+			ExpectedError: "does not match",
+		},
+		{
+			Name:          "refuses wrong digest",
+			Reference:     reference,
+			Options:       sources.OCIOptions{Digest: "sha256:" + fmt.Sprintf("%064d", 0)},
+			ExpectedError: "unable to pull bundle",
+		},
+		{
+			Name:          "refuses artifacts which are not bundles",
+			Reference:     notBundle,
+			ExpectedError: "artifact is not a bundle",
+		},
+		{
+			Name:          "refuses unsigned bundle",
+			Reference:     reference,
+			Options:       sources.OCIOptions{PublicKey: testPublicKey(t)},
+			ExpectedError: mindpak.ErrBundleNotSigned.Error(),
+		},
+	}
+
+	for i := range scenarios {
+		scenario := scenarios[i]
+		t.Run(scenario.Name, func(t *testing.T) {
+			t.Parallel()
+			source, err := sources.NewSourceFromOCI(context.Background(), scenario.Reference, scenario.Options)
+			if scenario.ExpectedError != "" {
+				require.ErrorContains(t, err, scenario.ExpectedError)
+				return
+			}
+			require.NoError(t, err)
+			bundle, err := source.GetBundle(mindpak.ID("stacklok", "t2"))
+			require.NoError(t, err)
+			require.Equal(t, "t2", bundle.GetMetadata().Name)
+		})
+	}
+}
+
+func TestNewSourceFromOCI_Cache(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(registry.New())
+	u, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+
+	reference := fmt.Sprintf("%s/bundles/t2:v0.0.1", u.Host)
+	digest, err := build.NewPacker().Push(context.Background(), sampleDataPath, reference)
+	require.NoError(t, err)
+
+	opts := sources.OCIOptions{Digest: digest.String(), CacheDir: t.TempDir()}
+	_, err = sources.NewSourceFromOCI(context.Background(), reference, opts)
+	require.NoError(t, err)
+
+	// bundles pinned by digest are loaded from the cache once pulled
+	ts.Close()
+	source, err := sources.NewSourceFromOCI(context.Background(), reference, opts)
+	require.NoError(t, err)
+	_, err = source.GetBundle(mindpak.ID("stacklok", "t2"))
+	require.NoError(t, err)
+
+	// bundles not pinned by digest require the registry
+	_, err = sources.NewSourceFromOCI(context.Background(), reference, sources.OCIOptions{CacheDir: opts.CacheDir})
+	require.Error(t, err)
+}
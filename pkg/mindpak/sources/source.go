@@ -5,6 +5,7 @@
 package sources
 
 import (
+	"crypto"
 	"errors"
 	"fmt"
 
@@ -35,9 +36,34 @@ func NewSourceFromTarGZ(path string) (BundleSource, error) {
 	if err != nil {
 		return nil, fmt.Errorf("unable to load bundle from %s: %w", path, err)
 	}
+	return newVerifiedSource(bundle, nil)
+}
+
+// NewSignedSourceFromTarGZ creates a singleBundleSource from a .tar.gz file
+// whose manifest must be signed by the given key. Unsigned bundles are
+// refused.
+func NewSignedSourceFromTarGZ(path string, key crypto.PublicKey) (BundleSource, error) {
+	if key == nil {
+		return nil, fmt.Errorf("no key to verify the bundle signature")
+	}
+	bundle, err := mindpak.NewBundleFromTarGZ(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load bundle from %s: %w", path, err)
+	}
+	return newVerifiedSource(bundle, key)
+}
+
+// newVerifiedSource checks the bundle against its manifest and, if key is
+// not nil, the signature of the manifest.
+func newVerifiedSource(bundle *mindpak.Bundle, key crypto.PublicKey) (BundleSource, error) {
 	if err := bundle.Verify(); err != nil {
 		return nil, fmt.Errorf("bundle failed verification: %w", err)
 	}
+	if key != nil {
+		if err := bundle.VerifySignature(key); err != nil {
+			return nil, fmt.Errorf("bundle failed signature verification: %w", err)
+		}
+	}
 	bundleReader := reader.NewBundleReader(bundle)
 	return &singleBundleSource{bundle: bundleReader}, nil
 }
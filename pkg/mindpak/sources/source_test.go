@@ -4,11 +4,17 @@
 package sources_test
 
 import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/mindersec/minder/pkg/mindpak"
+	"github.com/mindersec/minder/pkg/mindpak/build"
 	"github.com/mindersec/minder/pkg/mindpak/sources"
 )
 
@@ -77,3 +83,49 @@ const (
 	invalidPath    = "this is not a path"
 	sampleDataPath = "testdata/bundle.tar.gz"
 )
+
+func TestNewSignedSourceFromTarGZ_Unsigned(t *testing.T) {
+	t.Parallel()
+	_, pub, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	bundle, err := sources.NewSignedSourceFromTarGZ(sampleDataPath, pub)
+	require.Nil(t, bundle)
+	require.ErrorIs(t, err, mindpak.ErrBundleNotSigned)
+}
+
+func TestNewSignedSourceFromTarGZ_Signed(t *testing.T) {
+	t.Parallel()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, mindpak.PathProfiles), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, mindpak.PathProfiles, "profile.yaml"), []byte("test"), 0o600))
+
+	packer := build.NewPacker()
+	bundle, err := packer.InitBundle(&build.InitOptions{
+		Metadata:   &mindpak.Metadata{Namespace: "stacklok", Name: "signed"},
+		Path:       dir,
+		SigningKey: priv,
+	})
+	require.NoError(t, err)
+	tarPath := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	require.NoError(t, packer.WriteToFile(bundle, tarPath))
+
+	source, err := sources.NewSignedSourceFromTarGZ(tarPath, pub)
+	require.NoError(t, err)
+	_, err = source.GetBundle(mindpak.ID("stacklok", "signed"))
+	require.NoError(t, err)
+
+	otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, err = sources.NewSignedSourceFromTarGZ(tarPath, otherPub)
+	require.ErrorIs(t, err, mindpak.ErrInvalidSignature)
+}
+
+func testPublicKey(t *testing.T) crypto.PublicKey {
+	t.Helper()
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return pub
+}
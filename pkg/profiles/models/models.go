@@ -22,6 +22,8 @@ type ProfileAggregate struct {
 	ActionConfig ActionConfiguration
 	Rules        []RuleInstance
 	Selectors    []ProfileSelector
+	// Schedule is nil for profiles evaluated on every entity event
+	Schedule *ProfileSchedule
 }
 
 // ActionConfiguration stores the configuration state for a profile
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// ProfileSchedule is the schedule on which a profile is evaluated, instead of
+// on every event of the entities it applies to
+type ProfileSchedule struct {
+	// Cron is set for profiles evaluated every time a cron expression fires
+	Cron cron.Schedule
+	// MinFreshness is set for profiles evaluated when their results are older
+	MinFreshness time.Duration
+}
+
+// ScheduleFromDB parses the schedule of a profile as stored in the database.
+// It returns nil if the profile has no schedule.
+func ScheduleFromDB(cronExpr, minFreshness string) (*ProfileSchedule, error) {
+	if cronExpr == "" && minFreshness == "" {
+		return nil, nil
+	}
+
+	sched := &ProfileSchedule{}
+	if cronExpr != "" {
+		c, err := cron.ParseStandard(cronExpr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", cronExpr, err)
+		}
+		sched.Cron = c
+	}
+
+	if minFreshness != "" {
+		d, err := time.ParseDuration(minFreshness)
+		if err != nil {
+			return nil, fmt.Errorf("invalid minimum freshness %q: %w", minFreshness, err)
+		}
+		sched.MinFreshness = d
+	}
+
+	return sched, nil
+}
+
+// Due reports whether a profile should be evaluated again for an entity it
+// was last evaluated for at lastRun. A zero lastRun means it never was.
+// Cron expressions are evaluated in UTC.
+func (s *ProfileSchedule) Due(lastRun time.Time, now time.Time) bool {
+	if lastRun.IsZero() {
+		return true
+	}
+
+	if s.Cron != nil && !s.Cron.Next(lastRun.UTC()).After(now) {
+		return true
+	}
+
+	return s.MinFreshness > 0 && !lastRun.Add(s.MinFreshness).After(now)
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleFromDB(t *testing.T) {
+	t.Parallel()
+
+	sched, err := ScheduleFromDB("", "")
+	require.NoError(t, err)
+	require.Nil(t, sched)
+
+	sched, err = ScheduleFromDB("", "12h")
+	require.NoError(t, err)
+	require.Nil(t, sched.Cron)
+	require.Equal(t, 12*time.Hour, sched.MinFreshness)
+
+	_, err = ScheduleFromDB("every night", "")
+	require.Error(t, err)
+}
+
+func TestProfileScheduleDue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 10, 16, 3, 0, 0, 0, time.UTC)
+
+	nightly, err := ScheduleFromDB("0 2 * * *", "")
+	require.NoError(t, err)
+	daily, err := ScheduleFromDB("", "24h")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		schedule *ProfileSchedule
+		lastRun  time.Time
+		expected bool
+	}{
+		{
+			name:     "never evaluated",
+			schedule: nightly,
+			expected: true,
+		},
+		{
+			name:     "cron fired since the last evaluation",
+			schedule: nightly,
+			lastRun:  now.Add(-2 * time.Hour),
+			expected: true,
+		},
+		{
+			name:     "cron did not fire since the last evaluation",
+			schedule: nightly,
+			lastRun:  now.Add(-30 * time.Minute),
+			expected: false,
+		},
+		{
+			name:     "results are stale",
+			schedule: daily,
+			lastRun:  now.Add(-25 * time.Hour),
+			expected: true,
+		},
+		{
+			name:     "results are fresh",
+			schedule: daily,
+			lastRun:  now.Add(-time.Hour),
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.expected, tt.schedule.Due(tt.lastRun, now))
+		})
+	}
+}
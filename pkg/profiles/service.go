@@ -142,13 +142,16 @@ func (p *profileService) CreateProfile(
 	}
 
 	params := db.CreateProfileParams{
-		ProjectID:      projectID,
-		Name:           name,
-		DisplayName:    displayName,
-		Labels:         profile.GetLabels(),
-		Remediate:      db.ValidateRemediateType(profile.GetRemediate()),
-		Alert:          db.ValidateAlertType(profile.GetAlert()),
-		SubscriptionID: uuid.NullUUID{UUID: subscriptionID, Valid: subscriptionID != uuid.Nil},
+		ProjectID:            projectID,
+		Name:                 name,
+		DisplayName:          displayName,
+		Labels:               profile.GetLabels(),
+		Remediate:            db.ValidateRemediateType(profile.GetRemediate()),
+		Alert:                db.ValidateAlertType(profile.GetAlert()),
+		SubscriptionID:       uuid.NullUUID{UUID: subscriptionID, Valid: subscriptionID != uuid.Nil},
+		ScheduleCron:         profile.GetSchedule().GetCron(),
+		ScheduleMinFreshness: profile.GetSchedule().GetMinFreshness(),
+		Inherited:            profile.GetInherited(),
 	}
 
 	// Create profile
@@ -171,6 +174,7 @@ func (p *profileService) CreateProfile(
 		minderv1.Entity_ENTITY_PIPELINE_RUN:       profile.GetPipelineRun(),
 		minderv1.Entity_ENTITY_TASK_RUN:           profile.GetTaskRun(),
 		minderv1.Entity_ENTITY_BUILD:              profile.GetBuild(),
+		minderv1.Entity_ENTITY_ORGANIZATION:       profile.GetOrganization(),
 	} {
 		if err := createProfileRulesForEntity(ctx, ent, &newProfile, qtx, entRules, rulesInProf); err != nil {
 			return nil, err
@@ -182,7 +186,7 @@ func (p *profileService) CreateProfile(
 	}
 
 	logger.BusinessRecord(ctx).Profile = logger.Profile{Name: profile.Name, ID: newProfile.ID}
-	p.sendNewProfileEvent(projectID)
+	p.sendNewProfileEvent(ctx, projectID, newProfile.Inherited, qtx)
 
 	profile.Id = ptr.Ptr(newProfile.ID.String())
 	profile.Context = &minderv1.Context{
@@ -240,12 +244,15 @@ func (p *profileService) UpdateProfile(
 
 	// Update top-level profile db object
 	updatedProfile, err := qtx.UpdateProfile(ctx, db.UpdateProfileParams{
-		ProjectID:   projectID,
-		ID:          oldDBProfile.ID,
-		DisplayName: displayName,
-		Labels:      profile.GetLabels(),
-		Remediate:   db.ValidateRemediateType(profile.GetRemediate()),
-		Alert:       db.ValidateAlertType(profile.GetAlert()),
+		ProjectID:            projectID,
+		ID:                   oldDBProfile.ID,
+		DisplayName:          displayName,
+		Labels:               profile.GetLabels(),
+		Remediate:            db.ValidateRemediateType(profile.GetRemediate()),
+		Alert:                db.ValidateAlertType(profile.GetAlert()),
+		ScheduleCron:         profile.GetSchedule().GetCron(),
+		ScheduleMinFreshness: profile.GetSchedule().GetMinFreshness(),
+		Inherited:            profile.GetInherited(),
 	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "error updating profile: %v", err)
@@ -261,6 +268,7 @@ func (p *profileService) UpdateProfile(
 		minderv1.Entity_ENTITY_PIPELINE_RUN:       profile.GetPipelineRun(),
 		minderv1.Entity_ENTITY_TASK_RUN:           profile.GetTaskRun(),
 		minderv1.Entity_ENTITY_BUILD:              profile.GetBuild(),
+		minderv1.Entity_ENTITY_ORGANIZATION:       profile.GetOrganization(),
 	} {
 		if err = updateProfileRulesForEntity(ctx, ent, &updatedProfile, qtx, entRules); err != nil {
 			return nil, err
@@ -306,7 +314,7 @@ func (p *profileService) UpdateProfile(
 	profile.Alert = ptr.Ptr(string(updatedProfile.Alert.ActionType))
 
 	// re-trigger profile evaluation
-	p.sendNewProfileEvent(projectID)
+	p.sendNewProfileEvent(ctx, projectID, updatedProfile.Inherited || oldDBProfile.Inherited, qtx)
 
 	return profile, nil
 }
@@ -411,17 +419,36 @@ func createProfileRulesForEntity(
 }
 
 func (p *profileService) sendNewProfileEvent(
+	ctx context.Context,
 	projectID uuid.UUID,
+	inherited bool,
+	qtx db.Querier,
 ) {
-	// both errors in this case are considered non-fatal
-	msg, err := reconcilers.NewProfileInitMessage(projectID)
-	if err != nil {
-		log.Printf("error creating reconciler event: %v", err)
+	projects := []uuid.UUID{projectID}
+	// inherited profiles apply to the entities of all the descendants
+	if inherited {
+		children, err := qtx.GetChildrenProjects(ctx, projectID)
+		if err != nil {
+			log.Printf("error getting children projects: %v", err)
+		} else {
+			projects = make([]uuid.UUID, 0, len(children))
+			for _, child := range children {
+				projects = append(projects, child.ID)
+			}
+		}
 	}
 
-	// This is a non-fatal error, so we'll just log it and continue with the next ones
-	if err := p.publisher.Publish(constants.TopicQueueReconcileProfileInit, msg); err != nil {
-		log.Printf("error publishing reconciler event: %v", err)
+	for _, project := range projects {
+		// both errors in this case are considered non-fatal
+		msg, err := reconcilers.NewProfileInitMessage(project)
+		if err != nil {
+			log.Printf("error creating reconciler event: %v", err)
+		}
+
+		// This is a non-fatal error, so we'll just log it and continue with the next ones
+		if err := p.publisher.Publish(constants.TopicQueueReconcileProfileInit, msg); err != nil {
+			log.Printf("error publishing reconciler event: %v", err)
+		}
 	}
 }
 
@@ -77,12 +77,20 @@ func (p *profileStore) GetProfilesForEvaluation(
 	}
 
 	// Finally, create the ProfileAggregate instances
-	aggregates := make([]models.ProfileAggregate, len(profiles))
+	aggregates := make([]models.ProfileAggregate, 0, len(profiles))
 	for _, profile := range profiles {
+		// Profiles of parent projects only apply here if they are inherited
+		if profile.Profile.ProjectID != projectID && !profile.Profile.Inherited {
+			continue
+		}
 		profileRules, ok := rulesByProfileID[profile.Profile.ID]
 		if !ok {
 			return nil, fmt.Errorf("could not find rule instances for profile %s: %w", profile.Profile.ID, err)
 		}
+		schedule, err := models.ScheduleFromDB(profile.Profile.ScheduleCron, profile.Profile.ScheduleMinFreshness)
+		if err != nil {
+			return nil, fmt.Errorf("error while parsing schedule of profile %s: %w", profile.Profile.ID, err)
+		}
 		aggregate := models.ProfileAggregate{
 			ID:   profile.Profile.ID,
 			Name: profile.Profile.Name,
@@ -92,6 +100,7 @@ func (p *profileStore) GetProfilesForEvaluation(
 			},
 			Rules:     profileRules,
 			Selectors: models.SelectorSliceFromDB(profile.ProfilesWithSelectors),
+			Schedule:  schedule,
 		}
 		aggregates = append(aggregates, aggregate)
 	}
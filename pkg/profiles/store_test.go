@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package profiles_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockdb "github.com/mindersec/minder/database/mock"
+	"github.com/mindersec/minder/internal/db"
+	"github.com/mindersec/minder/pkg/profiles"
+)
+
+func TestGetProfilesForEvaluation(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	parentID := uuid.New()
+	childID := uuid.New()
+
+	local := db.Profile{ID: uuid.New(), Name: "local", ProjectID: childID}
+	inherited := db.Profile{ID: uuid.New(), Name: "inherited", ProjectID: parentID, Inherited: true}
+	parentOnly := db.Profile{ID: uuid.New(), Name: "parent-only", ProjectID: parentID}
+
+	rule := func(profileID uuid.UUID) db.RuleInstance {
+		return db.RuleInstance{
+			ID:         uuid.New(),
+			ProfileID:  profileID,
+			RuleTypeID: uuid.New(),
+			Def:        []byte(`{}`),
+			Params:     []byte(`{}`),
+		}
+	}
+
+	mockStore := mockdb.NewMockStore(ctrl)
+	mockStore.EXPECT().GetParentProjects(gomock.Any(), childID).
+		Return([]uuid.UUID{childID, parentID}, nil)
+	mockStore.EXPECT().GetRuleInstancesEntityInProjects(gomock.Any(), db.GetRuleInstancesEntityInProjectsParams{
+		EntityType: db.EntitiesRepository,
+		ProjectIds: []uuid.UUID{childID, parentID},
+	}).Return([]db.RuleInstance{rule(local.ID), rule(inherited.ID), rule(parentOnly.ID)}, nil)
+	mockStore.EXPECT().BulkGetProfilesByID(gomock.Any(), gomock.Any()).
+		Return([]db.BulkGetProfilesByIDRow{
+			{Profile: local},
+			{Profile: inherited},
+			{Profile: parentOnly},
+		}, nil)
+
+	aggregates, err := profiles.NewProfileStore(mockStore).
+		GetProfilesForEvaluation(context.Background(), childID, db.EntitiesRepository)
+	require.NoError(t, err)
+
+	// the profiles of the parent which aren't inherited don't apply to the child
+	names := make([]string, 0, len(aggregates))
+	for _, agg := range aggregates {
+		names = append(names, agg.Name)
+	}
+	require.ElementsMatch(t, []string{"local", "inherited"}, names)
+}
@@ -114,6 +114,8 @@ func GetRulesForEntity(p *pb.Profile, entity pb.Entity) ([]*pb.Profile_Rule, err
 		return p.TaskRun, nil
 	case pb.Entity_ENTITY_BUILD:
 		return p.Build, nil
+	case pb.Entity_ENTITY_ORGANIZATION:
+		return p.Organization, nil
 	case pb.Entity_ENTITY_UNSPECIFIED:
 		return nil, fmt.Errorf("entity type unspecified")
 	default:
@@ -132,6 +134,7 @@ func TraverseRuleTypesForEntities(p *pb.Profile, fn func(pb.Entity, *pb.Profile_
 		pb.Entity_ENTITY_PIPELINE_RUN:       p.PipelineRun,
 		pb.Entity_ENTITY_TASK_RUN:           p.TaskRun,
 		pb.Entity_ENTITY_BUILD:              p.Build,
+		pb.Entity_ENTITY_ORGANIZATION:       p.Organization,
 	}
 
 	for entity, rules := range pairs {
@@ -226,6 +229,8 @@ func MergeDatabaseListIntoProfiles[T db.ProfileRow](ppl []T) map[string]*pb.Prof
 				profiles[p.GetProfile().Name].Alert = proto.String(string(db.ActionTypeOn))
 			}
 
+			profiles[p.GetProfile().Name].Schedule = scheduleFromDB(p.GetProfile())
+			profiles[p.GetProfile().Name].Inherited = p.GetProfile().Inherited
 			selectorsToProfile(profiles[p.GetProfile().Name], p.GetSelectors())
 		}
 		if pm := rowInfoToProfileMap(
@@ -389,9 +394,25 @@ func dbProfileToPB(p db.Profile) *pb.Profile {
 		outprof.Alert = proto.String(string(db.ActionTypeOn))
 	}
 
+	outprof.Schedule = scheduleFromDB(p)
+	outprof.Inherited = p.Inherited
+
 	return outprof
 }
 
+// scheduleFromDB returns the schedule of the profile, or nil if the profile
+// is evaluated on every entity event
+func scheduleFromDB(p db.Profile) *pb.Profile_Schedule {
+	if p.ScheduleCron == "" && p.ScheduleMinFreshness == "" {
+		return nil
+	}
+
+	return &pb.Profile_Schedule{
+		Cron:         p.ScheduleCron,
+		MinFreshness: p.ScheduleMinFreshness,
+	}
+}
+
 // rowInfoToProfileMap adds the database row information to the given map of
 // profiles. This assumes that the profiles belong to the same project.
 // Note that this function is thought to be called from scpecific Merge functions
@@ -439,6 +460,8 @@ func rowInfoToProfileMap(
 		profile.TaskRun = ruleset
 	case pb.Entity_ENTITY_BUILD:
 		profile.Build = ruleset
+	case pb.Entity_ENTITY_ORGANIZATION:
+		profile.Organization = ruleset
 	case pb.Entity_ENTITY_UNSPECIFIED:
 		// This shouldn't happen
 		log.Printf("unknown entity found in database: %s", entity)
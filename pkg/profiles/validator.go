@@ -167,6 +167,7 @@ func validateRuleNames(profile *minderv1.Profile) error {
 		minderv1.Entity_ENTITY_PIPELINE_RUN:       profile.GetPipelineRun(),
 		minderv1.Entity_ENTITY_TASK_RUN:           profile.GetTaskRun(),
 		minderv1.Entity_ENTITY_BUILD:              profile.GetBuild(),
+		minderv1.Entity_ENTITY_ORGANIZATION:       profile.GetOrganization(),
 	} {
 		if err := validateRuleNamesForEntity(ent, entRules); err != nil {
 			return err
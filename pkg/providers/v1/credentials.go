@@ -60,3 +60,18 @@ type GitLabCredential interface {
 	GitCredential
 	OAuth2TokenCredential
 }
+
+// BitbucketCredential is the interface for credentials used when interacting with Bitbucket
+type BitbucketCredential interface {
+	RestCredential
+	GitCredential
+	OAuth2TokenCredential
+}
+
+// OCICredential is the interface for credentials used when interacting with OCI registries
+type OCICredential interface {
+	RestCredential
+	OAuth2TokenCredential
+
+	GetAsContainerAuthenticator() authn.Authenticator
+}
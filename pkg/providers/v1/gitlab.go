@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Minder Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+// GitLab access levels, as used by protected branches
+// See https://docs.gitlab.com/ee/api/protected_branches.html#valid-access-levels
+const (
+	// GitLabAccessLevelNoAccess denies access to everyone
+	GitLabAccessLevelNoAccess = 0
+	// GitLabAccessLevelDeveloper grants access to developers and maintainers
+	GitLabAccessLevelDeveloper = 30
+	// GitLabAccessLevelMaintainer grants access to maintainers
+	GitLabAccessLevelMaintainer = 40
+	// GitLabAccessLevelAdmin grants access to instance administrators
+	GitLabAccessLevelAdmin = 60
+)
+
+// GitLabBranchProtection describes the protection of a GitLab branch along
+// with the approval rules scoped to it. It's the document which is ingested
+// and patched by rules about GitLab protected branches.
+type GitLabBranchProtection struct {
+	// Protected is true if the branch is protected. It's ignored when the
+	// protection is updated, as updating it always protects the branch.
+	Protected bool `json:"protected"`
+	// PushAccessLevel is the role allowed to push to the branch
+	PushAccessLevel int `json:"push_access_level"`
+	// MergeAccessLevel is the role allowed to merge into the branch
+	MergeAccessLevel int `json:"merge_access_level"`
+	// UnprotectAccessLevel is the role allowed to unprotect the branch
+	UnprotectAccessLevel int `json:"unprotect_access_level"`
+	// AllowForcePush allows force pushes to the branch
+	AllowForcePush bool `json:"allow_force_push"`
+	// CodeOwnerApprovalRequired requires approval from code owners
+	CodeOwnerApprovalRequired bool `json:"code_owner_approval_required"`
+	// ApprovalRules are the merge request approval rules scoped to the
+	// branch, keyed by name so they can be patched individually. Rules
+	// missing from an update are left untouched.
+	ApprovalRules map[string]*GitLabApprovalRule `json:"approval_rules"`
+}
+
+// GitLabApprovalRule is a merge request approval rule
+type GitLabApprovalRule struct {
+	// ApprovalsRequired is the number of approvals required by the rule
+	ApprovalsRequired int `json:"approvals_required"`
+}
+
+// NewGitLabBranchProtection returns the protection of an unprotected branch,
+// using the defaults GitLab applies when protecting a branch
+func NewGitLabBranchProtection() *GitLabBranchProtection {
+	return &GitLabBranchProtection{
+		PushAccessLevel:      GitLabAccessLevelMaintainer,
+		MergeAccessLevel:     GitLabAccessLevelMaintainer,
+		UnprotectAccessLevel: GitLabAccessLevelMaintainer,
+		ApprovalRules:        map[string]*GitLabApprovalRule{},
+	}
+}
@@ -351,32 +351,46 @@ func (mr *MockGitMockRecorder) SupportsEntity(entType any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SupportsEntity", reflect.TypeOf((*MockGit)(nil).SupportsEntity), entType)
 }
 
-// MockREST is a mock of REST interface.
-type MockREST struct {
+// MockChangeRequester is a mock of ChangeRequester interface.
+type MockChangeRequester struct {
 	ctrl     *gomock.Controller
-	recorder *MockRESTMockRecorder
+	recorder *MockChangeRequesterMockRecorder
 	isgomock struct{}
 }
 
-// MockRESTMockRecorder is the mock recorder for MockREST.
-type MockRESTMockRecorder struct {
-	mock *MockREST
+// MockChangeRequesterMockRecorder is the mock recorder for MockChangeRequester.
+type MockChangeRequesterMockRecorder struct {
+	mock *MockChangeRequester
 }
 
-// NewMockREST creates a new mock instance.
-func NewMockREST(ctrl *gomock.Controller) *MockREST {
-	mock := &MockREST{ctrl: ctrl}
-	mock.recorder = &MockRESTMockRecorder{mock}
+// NewMockChangeRequester creates a new mock instance.
+func NewMockChangeRequester(ctrl *gomock.Controller) *MockChangeRequester {
+	mock := &MockChangeRequester{ctrl: ctrl}
+	mock.recorder = &MockChangeRequesterMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockREST) EXPECT() *MockRESTMockRecorder {
+func (m *MockChangeRequester) EXPECT() *MockChangeRequesterMockRecorder {
 	return m.recorder
 }
 
+// AddAuthToPushOptions mocks base method.
+func (m *MockChangeRequester) AddAuthToPushOptions(ctx context.Context, options *git.PushOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAuthToPushOptions", ctx, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAuthToPushOptions indicates an expected call of AddAuthToPushOptions.
+func (mr *MockChangeRequesterMockRecorder) AddAuthToPushOptions(ctx, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAuthToPushOptions", reflect.TypeOf((*MockChangeRequester)(nil).AddAuthToPushOptions), ctx, options)
+}
+
 // CanImplement mocks base method.
-func (m *MockREST) CanImplement(trait v10.ProviderType) bool {
+func (m *MockChangeRequester) CanImplement(trait v10.ProviderType) bool {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CanImplement", trait)
 	ret0, _ := ret[0].(bool)
@@ -384,42 +398,56 @@ func (m *MockREST) CanImplement(trait v10.ProviderType) bool {
 }
 
 // CanImplement indicates an expected call of CanImplement.
-func (mr *MockRESTMockRecorder) CanImplement(trait any) *gomock.Call {
+func (mr *MockChangeRequesterMockRecorder) CanImplement(trait any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanImplement", reflect.TypeOf((*MockREST)(nil).CanImplement), trait)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanImplement", reflect.TypeOf((*MockChangeRequester)(nil).CanImplement), trait)
 }
 
-// DeregisterEntity mocks base method.
-func (m *MockREST) DeregisterEntity(ctx context.Context, entType v10.Entity, props *properties.Properties) error {
+// CloseChangeRequest mocks base method.
+func (m *MockChangeRequester) CloseChangeRequest(ctx context.Context, repo *v10.Repository, number int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeregisterEntity", ctx, entType, props)
+	ret := m.ctrl.Call(m, "CloseChangeRequest", ctx, repo, number)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeregisterEntity indicates an expected call of DeregisterEntity.
-func (mr *MockRESTMockRecorder) DeregisterEntity(ctx, entType, props any) *gomock.Call {
+// CloseChangeRequest indicates an expected call of CloseChangeRequest.
+func (mr *MockChangeRequesterMockRecorder) CloseChangeRequest(ctx, repo, number any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeregisterEntity", reflect.TypeOf((*MockREST)(nil).DeregisterEntity), ctx, entType, props)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseChangeRequest", reflect.TypeOf((*MockChangeRequester)(nil).CloseChangeRequest), ctx, repo, number)
 }
 
-// Do mocks base method.
-func (m *MockREST) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
+// CreateChangeRequest mocks base method.
+func (m *MockChangeRequester) CreateChangeRequest(ctx context.Context, repo *v10.Repository, title, body, head, base string) (*v11.ChangeRequest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Do", ctx, req)
-	ret0, _ := ret[0].(*http.Response)
+	ret := m.ctrl.Call(m, "CreateChangeRequest", ctx, repo, title, body, head, base)
+	ret0, _ := ret[0].(*v11.ChangeRequest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Do indicates an expected call of Do.
-func (mr *MockRESTMockRecorder) Do(ctx, req any) *gomock.Call {
+// CreateChangeRequest indicates an expected call of CreateChangeRequest.
+func (mr *MockChangeRequesterMockRecorder) CreateChangeRequest(ctx, repo, title, body, head, base any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockREST)(nil).Do), ctx, req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChangeRequest", reflect.TypeOf((*MockChangeRequester)(nil).CreateChangeRequest), ctx, repo, title, body, head, base)
+}
+
+// DeregisterEntity mocks base method.
+func (m *MockChangeRequester) DeregisterEntity(ctx context.Context, entType v10.Entity, props *properties.Properties) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeregisterEntity", ctx, entType, props)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeregisterEntity indicates an expected call of DeregisterEntity.
+func (mr *MockChangeRequesterMockRecorder) DeregisterEntity(ctx, entType, props any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeregisterEntity", reflect.TypeOf((*MockChangeRequester)(nil).DeregisterEntity), ctx, entType, props)
 }
 
 // FetchAllProperties mocks base method.
-func (m *MockREST) FetchAllProperties(ctx context.Context, getByProps *properties.Properties, entType v10.Entity, cachedProps *properties.Properties) (*properties.Properties, error) {
+func (m *MockChangeRequester) FetchAllProperties(ctx context.Context, getByProps *properties.Properties, entType v10.Entity, cachedProps *properties.Properties) (*properties.Properties, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FetchAllProperties", ctx, getByProps, entType, cachedProps)
 	ret0, _ := ret[0].(*properties.Properties)
@@ -428,13 +456,13 @@ func (m *MockREST) FetchAllProperties(ctx context.Context, getByProps *propertie
 }
 
 // FetchAllProperties indicates an expected call of FetchAllProperties.
-func (mr *MockRESTMockRecorder) FetchAllProperties(ctx, getByProps, entType, cachedProps any) *gomock.Call {
+func (mr *MockChangeRequesterMockRecorder) FetchAllProperties(ctx, getByProps, entType, cachedProps any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAllProperties", reflect.TypeOf((*MockREST)(nil).FetchAllProperties), ctx, getByProps, entType, cachedProps)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAllProperties", reflect.TypeOf((*MockChangeRequester)(nil).FetchAllProperties), ctx, getByProps, entType, cachedProps)
 }
 
 // FetchProperty mocks base method.
-func (m *MockREST) FetchProperty(ctx context.Context, getByProps *properties.Properties, entType v10.Entity, key string) (*properties.Property, error) {
+func (m *MockChangeRequester) FetchProperty(ctx context.Context, getByProps *properties.Properties, entType v10.Entity, key string) (*properties.Property, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FetchProperty", ctx, getByProps, entType, key)
 	ret0, _ := ret[0].(*properties.Property)
@@ -443,57 +471,59 @@ func (m *MockREST) FetchProperty(ctx context.Context, getByProps *properties.Pro
 }
 
 // FetchProperty indicates an expected call of FetchProperty.
-func (mr *MockRESTMockRecorder) FetchProperty(ctx, getByProps, entType, key any) *gomock.Call {
+func (mr *MockChangeRequesterMockRecorder) FetchProperty(ctx, getByProps, entType, key any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchProperty", reflect.TypeOf((*MockREST)(nil).FetchProperty), ctx, getByProps, entType, key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchProperty", reflect.TypeOf((*MockChangeRequester)(nil).FetchProperty), ctx, getByProps, entType, key)
 }
 
-// GetBaseURL mocks base method.
-func (m *MockREST) GetBaseURL() string {
+// FindChangeRequest mocks base method.
+func (m *MockChangeRequester) FindChangeRequest(ctx context.Context, repo *v10.Repository, head string) (*v11.ChangeRequest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBaseURL")
-	ret0, _ := ret[0].(string)
-	return ret0
+	ret := m.ctrl.Call(m, "FindChangeRequest", ctx, repo, head)
+	ret0, _ := ret[0].(*v11.ChangeRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// GetBaseURL indicates an expected call of GetBaseURL.
-func (mr *MockRESTMockRecorder) GetBaseURL() *gomock.Call {
+// FindChangeRequest indicates an expected call of FindChangeRequest.
+func (mr *MockChangeRequesterMockRecorder) FindChangeRequest(ctx, repo, head any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBaseURL", reflect.TypeOf((*MockREST)(nil).GetBaseURL))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindChangeRequest", reflect.TypeOf((*MockChangeRequester)(nil).FindChangeRequest), ctx, repo, head)
 }
 
-// GetEntityName mocks base method.
-func (m *MockREST) GetEntityName(entType v10.Entity, props *properties.Properties) (string, error) {
+// GetCommitAuthor mocks base method.
+func (m *MockChangeRequester) GetCommitAuthor(ctx context.Context) (string, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEntityName", entType, props)
+	ret := m.ctrl.Call(m, "GetCommitAuthor", ctx)
 	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// GetEntityName indicates an expected call of GetEntityName.
-func (mr *MockRESTMockRecorder) GetEntityName(entType, props any) *gomock.Call {
+// GetCommitAuthor indicates an expected call of GetCommitAuthor.
+func (mr *MockChangeRequesterMockRecorder) GetCommitAuthor(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntityName", reflect.TypeOf((*MockREST)(nil).GetEntityName), entType, props)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommitAuthor", reflect.TypeOf((*MockChangeRequester)(nil).GetCommitAuthor), ctx)
 }
 
-// NewRequest mocks base method.
-func (m *MockREST) NewRequest(method, url string, body any) (*http.Request, error) {
+// GetEntityName mocks base method.
+func (m *MockChangeRequester) GetEntityName(entType v10.Entity, props *properties.Properties) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "NewRequest", method, url, body)
-	ret0, _ := ret[0].(*http.Request)
+	ret := m.ctrl.Call(m, "GetEntityName", entType, props)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// NewRequest indicates an expected call of NewRequest.
-func (mr *MockRESTMockRecorder) NewRequest(method, url, body any) *gomock.Call {
+// GetEntityName indicates an expected call of GetEntityName.
+func (mr *MockChangeRequesterMockRecorder) GetEntityName(entType, props any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewRequest", reflect.TypeOf((*MockREST)(nil).NewRequest), method, url, body)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntityName", reflect.TypeOf((*MockChangeRequester)(nil).GetEntityName), entType, props)
 }
 
 // PropertiesToProtoMessage mocks base method.
-func (m *MockREST) PropertiesToProtoMessage(entType v10.Entity, props *properties.Properties) (protoreflect.ProtoMessage, error) {
+func (m *MockChangeRequester) PropertiesToProtoMessage(entType v10.Entity, props *properties.Properties) (protoreflect.ProtoMessage, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "PropertiesToProtoMessage", entType, props)
 	ret0, _ := ret[0].(protoreflect.ProtoMessage)
@@ -502,13 +532,13 @@ func (m *MockREST) PropertiesToProtoMessage(entType v10.Entity, props *propertie
 }
 
 // PropertiesToProtoMessage indicates an expected call of PropertiesToProtoMessage.
-func (mr *MockRESTMockRecorder) PropertiesToProtoMessage(entType, props any) *gomock.Call {
+func (mr *MockChangeRequesterMockRecorder) PropertiesToProtoMessage(entType, props any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PropertiesToProtoMessage", reflect.TypeOf((*MockREST)(nil).PropertiesToProtoMessage), entType, props)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PropertiesToProtoMessage", reflect.TypeOf((*MockChangeRequester)(nil).PropertiesToProtoMessage), entType, props)
 }
 
 // RegisterEntity mocks base method.
-func (m *MockREST) RegisterEntity(ctx context.Context, entType v10.Entity, props *properties.Properties) (*properties.Properties, error) {
+func (m *MockChangeRequester) RegisterEntity(ctx context.Context, entType v10.Entity, props *properties.Properties) (*properties.Properties, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "RegisterEntity", ctx, entType, props)
 	ret0, _ := ret[0].(*properties.Properties)
@@ -517,13 +547,13 @@ func (m *MockREST) RegisterEntity(ctx context.Context, entType v10.Entity, props
 }
 
 // RegisterEntity indicates an expected call of RegisterEntity.
-func (mr *MockRESTMockRecorder) RegisterEntity(ctx, entType, props any) *gomock.Call {
+func (mr *MockChangeRequesterMockRecorder) RegisterEntity(ctx, entType, props any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterEntity", reflect.TypeOf((*MockREST)(nil).RegisterEntity), ctx, entType, props)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterEntity", reflect.TypeOf((*MockChangeRequester)(nil).RegisterEntity), ctx, entType, props)
 }
 
 // ReregisterEntity mocks base method.
-func (m *MockREST) ReregisterEntity(ctx context.Context, entType v10.Entity, props *properties.Properties) error {
+func (m *MockChangeRequester) ReregisterEntity(ctx context.Context, entType v10.Entity, props *properties.Properties) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ReregisterEntity", ctx, entType, props)
 	ret0, _ := ret[0].(error)
@@ -531,13 +561,13 @@ func (m *MockREST) ReregisterEntity(ctx context.Context, entType v10.Entity, pro
 }
 
 // ReregisterEntity indicates an expected call of ReregisterEntity.
-func (mr *MockRESTMockRecorder) ReregisterEntity(ctx, entType, props any) *gomock.Call {
+func (mr *MockChangeRequesterMockRecorder) ReregisterEntity(ctx, entType, props any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReregisterEntity", reflect.TypeOf((*MockREST)(nil).ReregisterEntity), ctx, entType, props)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReregisterEntity", reflect.TypeOf((*MockChangeRequester)(nil).ReregisterEntity), ctx, entType, props)
 }
 
 // SupportsEntity mocks base method.
-func (m *MockREST) SupportsEntity(entType v10.Entity) bool {
+func (m *MockChangeRequester) SupportsEntity(entType v10.Entity) bool {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SupportsEntity", entType)
 	ret0, _ := ret[0].(bool)
@@ -545,37 +575,51 @@ func (m *MockREST) SupportsEntity(entType v10.Entity) bool {
 }
 
 // SupportsEntity indicates an expected call of SupportsEntity.
-func (mr *MockRESTMockRecorder) SupportsEntity(entType any) *gomock.Call {
+func (mr *MockChangeRequesterMockRecorder) SupportsEntity(entType any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SupportsEntity", reflect.TypeOf((*MockREST)(nil).SupportsEntity), entType)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SupportsEntity", reflect.TypeOf((*MockChangeRequester)(nil).SupportsEntity), entType)
 }
 
-// MockRepoLister is a mock of RepoLister interface.
-type MockRepoLister struct {
+// UpdateChangeRequest mocks base method.
+func (m *MockChangeRequester) UpdateChangeRequest(ctx context.Context, repo *v10.Repository, number int, title, body string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChangeRequest", ctx, repo, number, title, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateChangeRequest indicates an expected call of UpdateChangeRequest.
+func (mr *MockChangeRequesterMockRecorder) UpdateChangeRequest(ctx, repo, number, title, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChangeRequest", reflect.TypeOf((*MockChangeRequester)(nil).UpdateChangeRequest), ctx, repo, number, title, body)
+}
+
+// MockPullRequestReviewer is a mock of PullRequestReviewer interface.
+type MockPullRequestReviewer struct {
 	ctrl     *gomock.Controller
-	recorder *MockRepoListerMockRecorder
+	recorder *MockPullRequestReviewerMockRecorder
 	isgomock struct{}
 }
 
-// MockRepoListerMockRecorder is the mock recorder for MockRepoLister.
-type MockRepoListerMockRecorder struct {
-	mock *MockRepoLister
+// MockPullRequestReviewerMockRecorder is the mock recorder for MockPullRequestReviewer.
+type MockPullRequestReviewerMockRecorder struct {
+	mock *MockPullRequestReviewer
 }
 
-// NewMockRepoLister creates a new mock instance.
-func NewMockRepoLister(ctrl *gomock.Controller) *MockRepoLister {
-	mock := &MockRepoLister{ctrl: ctrl}
-	mock.recorder = &MockRepoListerMockRecorder{mock}
+// NewMockPullRequestReviewer creates a new mock instance.
+func NewMockPullRequestReviewer(ctrl *gomock.Controller) *MockPullRequestReviewer {
+	mock := &MockPullRequestReviewer{ctrl: ctrl}
+	mock.recorder = &MockPullRequestReviewerMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockRepoLister) EXPECT() *MockRepoListerMockRecorder {
+func (m *MockPullRequestReviewer) EXPECT() *MockPullRequestReviewerMockRecorder {
 	return m.recorder
 }
 
 // CanImplement mocks base method.
-func (m *MockRepoLister) CanImplement(trait v10.ProviderType) bool {
+func (m *MockPullRequestReviewer) CanImplement(trait v10.ProviderType) bool {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CanImplement", trait)
 	ret0, _ := ret[0].(bool)
@@ -583,13 +627,28 @@ func (m *MockRepoLister) CanImplement(trait v10.ProviderType) bool {
 }
 
 // CanImplement indicates an expected call of CanImplement.
-func (mr *MockRepoListerMockRecorder) CanImplement(trait any) *gomock.Call {
+func (mr *MockPullRequestReviewerMockRecorder) CanImplement(trait any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanImplement", reflect.TypeOf((*MockRepoLister)(nil).CanImplement), trait)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanImplement", reflect.TypeOf((*MockPullRequestReviewer)(nil).CanImplement), trait)
+}
+
+// CreatePullRequestComment mocks base method.
+func (m *MockPullRequestReviewer) CreatePullRequestComment(ctx context.Context, pr *v11.PullRequestReference, body string) (*v11.PullRequestComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePullRequestComment", ctx, pr, body)
+	ret0, _ := ret[0].(*v11.PullRequestComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePullRequestComment indicates an expected call of CreatePullRequestComment.
+func (mr *MockPullRequestReviewerMockRecorder) CreatePullRequestComment(ctx, pr, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePullRequestComment", reflect.TypeOf((*MockPullRequestReviewer)(nil).CreatePullRequestComment), ctx, pr, body)
 }
 
 // DeregisterEntity mocks base method.
-func (m *MockRepoLister) DeregisterEntity(ctx context.Context, entType v10.Entity, props *properties.Properties) error {
+func (m *MockPullRequestReviewer) DeregisterEntity(ctx context.Context, entType v10.Entity, props *properties.Properties) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DeregisterEntity", ctx, entType, props)
 	ret0, _ := ret[0].(error)
@@ -597,13 +656,27 @@ func (m *MockRepoLister) DeregisterEntity(ctx context.Context, entType v10.Entit
 }
 
 // DeregisterEntity indicates an expected call of DeregisterEntity.
-func (mr *MockRepoListerMockRecorder) DeregisterEntity(ctx, entType, props any) *gomock.Call {
+func (mr *MockPullRequestReviewerMockRecorder) DeregisterEntity(ctx, entType, props any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeregisterEntity", reflect.TypeOf((*MockRepoLister)(nil).DeregisterEntity), ctx, entType, props)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeregisterEntity", reflect.TypeOf((*MockPullRequestReviewer)(nil).DeregisterEntity), ctx, entType, props)
+}
+
+// DismissPullRequestReview mocks base method.
+func (m *MockPullRequestReviewer) DismissPullRequestReview(ctx context.Context, pr *v11.PullRequestReference, reviewID, message string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DismissPullRequestReview", ctx, pr, reviewID, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DismissPullRequestReview indicates an expected call of DismissPullRequestReview.
+func (mr *MockPullRequestReviewerMockRecorder) DismissPullRequestReview(ctx, pr, reviewID, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DismissPullRequestReview", reflect.TypeOf((*MockPullRequestReviewer)(nil).DismissPullRequestReview), ctx, pr, reviewID, message)
 }
 
 // FetchAllProperties mocks base method.
-func (m *MockRepoLister) FetchAllProperties(ctx context.Context, getByProps *properties.Properties, entType v10.Entity, cachedProps *properties.Properties) (*properties.Properties, error) {
+func (m *MockPullRequestReviewer) FetchAllProperties(ctx context.Context, getByProps *properties.Properties, entType v10.Entity, cachedProps *properties.Properties) (*properties.Properties, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FetchAllProperties", ctx, getByProps, entType, cachedProps)
 	ret0, _ := ret[0].(*properties.Properties)
@@ -612,13 +685,13 @@ func (m *MockRepoLister) FetchAllProperties(ctx context.Context, getByProps *pro
 }
 
 // FetchAllProperties indicates an expected call of FetchAllProperties.
-func (mr *MockRepoListerMockRecorder) FetchAllProperties(ctx, getByProps, entType, cachedProps any) *gomock.Call {
+func (mr *MockPullRequestReviewerMockRecorder) FetchAllProperties(ctx, getByProps, entType, cachedProps any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAllProperties", reflect.TypeOf((*MockRepoLister)(nil).FetchAllProperties), ctx, getByProps, entType, cachedProps)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAllProperties", reflect.TypeOf((*MockPullRequestReviewer)(nil).FetchAllProperties), ctx, getByProps, entType, cachedProps)
 }
 
 // FetchProperty mocks base method.
-func (m *MockRepoLister) FetchProperty(ctx context.Context, getByProps *properties.Properties, entType v10.Entity, key string) (*properties.Property, error) {
+func (m *MockPullRequestReviewer) FetchProperty(ctx context.Context, getByProps *properties.Properties, entType v10.Entity, key string) (*properties.Property, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FetchProperty", ctx, getByProps, entType, key)
 	ret0, _ := ret[0].(*properties.Property)
@@ -627,13 +700,13 @@ func (m *MockRepoLister) FetchProperty(ctx context.Context, getByProps *properti
 }
 
 // FetchProperty indicates an expected call of FetchProperty.
-func (mr *MockRepoListerMockRecorder) FetchProperty(ctx, getByProps, entType, key any) *gomock.Call {
+func (mr *MockPullRequestReviewerMockRecorder) FetchProperty(ctx, getByProps, entType, key any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchProperty", reflect.TypeOf((*MockRepoLister)(nil).FetchProperty), ctx, getByProps, entType, key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchProperty", reflect.TypeOf((*MockPullRequestReviewer)(nil).FetchProperty), ctx, getByProps, entType, key)
 }
 
 // GetEntityName mocks base method.
-func (m *MockRepoLister) GetEntityName(entType v10.Entity, props *properties.Properties) (string, error) {
+func (m *MockPullRequestReviewer) GetEntityName(entType v10.Entity, props *properties.Properties) (string, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetEntityName", entType, props)
 	ret0, _ := ret[0].(string)
@@ -642,28 +715,58 @@ func (m *MockRepoLister) GetEntityName(entType v10.Entity, props *properties.Pro
 }
 
 // GetEntityName indicates an expected call of GetEntityName.
-func (mr *MockRepoListerMockRecorder) GetEntityName(entType, props any) *gomock.Call {
+func (mr *MockPullRequestReviewerMockRecorder) GetEntityName(entType, props any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntityName", reflect.TypeOf((*MockRepoLister)(nil).GetEntityName), entType, props)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntityName", reflect.TypeOf((*MockPullRequestReviewer)(nil).GetEntityName), entType, props)
 }
 
-// ListAllRepositories mocks base method.
-func (m *MockRepoLister) ListAllRepositories(arg0 context.Context) ([]*v10.Repository, error) {
+// GetReviewerID mocks base method.
+func (m *MockPullRequestReviewer) GetReviewerID(ctx context.Context) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAllRepositories", arg0)
-	ret0, _ := ret[0].([]*v10.Repository)
+	ret := m.ctrl.Call(m, "GetReviewerID", ctx)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListAllRepositories indicates an expected call of ListAllRepositories.
-func (mr *MockRepoListerMockRecorder) ListAllRepositories(arg0 any) *gomock.Call {
+// GetReviewerID indicates an expected call of GetReviewerID.
+func (mr *MockPullRequestReviewerMockRecorder) GetReviewerID(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllRepositories", reflect.TypeOf((*MockRepoLister)(nil).ListAllRepositories), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewerID", reflect.TypeOf((*MockPullRequestReviewer)(nil).GetReviewerID), ctx)
+}
+
+// ListPullRequestComments mocks base method.
+func (m *MockPullRequestReviewer) ListPullRequestComments(ctx context.Context, pr *v11.PullRequestReference) ([]*v11.PullRequestComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPullRequestComments", ctx, pr)
+	ret0, _ := ret[0].([]*v11.PullRequestComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPullRequestComments indicates an expected call of ListPullRequestComments.
+func (mr *MockPullRequestReviewerMockRecorder) ListPullRequestComments(ctx, pr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPullRequestComments", reflect.TypeOf((*MockPullRequestReviewer)(nil).ListPullRequestComments), ctx, pr)
+}
+
+// ListPullRequestReviews mocks base method.
+func (m *MockPullRequestReviewer) ListPullRequestReviews(ctx context.Context, pr *v11.PullRequestReference) ([]*v11.PullRequestReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPullRequestReviews", ctx, pr)
+	ret0, _ := ret[0].([]*v11.PullRequestReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPullRequestReviews indicates an expected call of ListPullRequestReviews.
+func (mr *MockPullRequestReviewerMockRecorder) ListPullRequestReviews(ctx, pr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPullRequestReviews", reflect.TypeOf((*MockPullRequestReviewer)(nil).ListPullRequestReviews), ctx, pr)
 }
 
 // PropertiesToProtoMessage mocks base method.
-func (m *MockRepoLister) PropertiesToProtoMessage(entType v10.Entity, props *properties.Properties) (protoreflect.ProtoMessage, error) {
+func (m *MockPullRequestReviewer) PropertiesToProtoMessage(entType v10.Entity, props *properties.Properties) (protoreflect.ProtoMessage, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "PropertiesToProtoMessage", entType, props)
 	ret0, _ := ret[0].(protoreflect.ProtoMessage)
@@ -672,13 +775,13 @@ func (m *MockRepoLister) PropertiesToProtoMessage(entType v10.Entity, props *pro
 }
 
 // PropertiesToProtoMessage indicates an expected call of PropertiesToProtoMessage.
-func (mr *MockRepoListerMockRecorder) PropertiesToProtoMessage(entType, props any) *gomock.Call {
+func (mr *MockPullRequestReviewerMockRecorder) PropertiesToProtoMessage(entType, props any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PropertiesToProtoMessage", reflect.TypeOf((*MockRepoLister)(nil).PropertiesToProtoMessage), entType, props)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PropertiesToProtoMessage", reflect.TypeOf((*MockPullRequestReviewer)(nil).PropertiesToProtoMessage), entType, props)
 }
 
 // RegisterEntity mocks base method.
-func (m *MockRepoLister) RegisterEntity(ctx context.Context, entType v10.Entity, props *properties.Properties) (*properties.Properties, error) {
+func (m *MockPullRequestReviewer) RegisterEntity(ctx context.Context, entType v10.Entity, props *properties.Properties) (*properties.Properties, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "RegisterEntity", ctx, entType, props)
 	ret0, _ := ret[0].(*properties.Properties)
@@ -687,13 +790,13 @@ func (m *MockRepoLister) RegisterEntity(ctx context.Context, entType v10.Entity,
 }
 
 // RegisterEntity indicates an expected call of RegisterEntity.
-func (mr *MockRepoListerMockRecorder) RegisterEntity(ctx, entType, props any) *gomock.Call {
+func (mr *MockPullRequestReviewerMockRecorder) RegisterEntity(ctx, entType, props any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterEntity", reflect.TypeOf((*MockRepoLister)(nil).RegisterEntity), ctx, entType, props)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterEntity", reflect.TypeOf((*MockPullRequestReviewer)(nil).RegisterEntity), ctx, entType, props)
 }
 
 // ReregisterEntity mocks base method.
-func (m *MockRepoLister) ReregisterEntity(ctx context.Context, entType v10.Entity, props *properties.Properties) error {
+func (m *MockPullRequestReviewer) ReregisterEntity(ctx context.Context, entType v10.Entity, props *properties.Properties) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ReregisterEntity", ctx, entType, props)
 	ret0, _ := ret[0].(error)
@@ -701,13 +804,28 @@ func (m *MockRepoLister) ReregisterEntity(ctx context.Context, entType v10.Entit
 }
 
 // ReregisterEntity indicates an expected call of ReregisterEntity.
-func (mr *MockRepoListerMockRecorder) ReregisterEntity(ctx, entType, props any) *gomock.Call {
+func (mr *MockPullRequestReviewerMockRecorder) ReregisterEntity(ctx, entType, props any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReregisterEntity", reflect.TypeOf((*MockRepoLister)(nil).ReregisterEntity), ctx, entType, props)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReregisterEntity", reflect.TypeOf((*MockPullRequestReviewer)(nil).ReregisterEntity), ctx, entType, props)
+}
+
+// SubmitPullRequestReview mocks base method.
+func (m *MockPullRequestReviewer) SubmitPullRequestReview(ctx context.Context, pr *v11.PullRequestReference, review *v11.PullRequestReview) (*v11.PullRequestReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitPullRequestReview", ctx, pr, review)
+	ret0, _ := ret[0].(*v11.PullRequestReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitPullRequestReview indicates an expected call of SubmitPullRequestReview.
+func (mr *MockPullRequestReviewerMockRecorder) SubmitPullRequestReview(ctx, pr, review any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitPullRequestReview", reflect.TypeOf((*MockPullRequestReviewer)(nil).SubmitPullRequestReview), ctx, pr, review)
 }
 
 // SupportsEntity mocks base method.
-func (m *MockRepoLister) SupportsEntity(entType v10.Entity) bool {
+func (m *MockPullRequestReviewer) SupportsEntity(entType v10.Entity) bool {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SupportsEntity", entType)
 	ret0, _ := ret[0].(bool)
@@ -715,128 +833,250 @@ func (m *MockRepoLister) SupportsEntity(entType v10.Entity) bool {
 }
 
 // SupportsEntity indicates an expected call of SupportsEntity.
-func (mr *MockRepoListerMockRecorder) SupportsEntity(entType any) *gomock.Call {
+func (mr *MockPullRequestReviewerMockRecorder) SupportsEntity(entType any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SupportsEntity", reflect.TypeOf((*MockRepoLister)(nil).SupportsEntity), entType)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SupportsEntity", reflect.TypeOf((*MockPullRequestReviewer)(nil).SupportsEntity), entType)
 }
 
-// MockGetArtifactVersionsFilter is a mock of GetArtifactVersionsFilter interface.
-type MockGetArtifactVersionsFilter struct {
-	ctrl     *gomock.Controller
-	recorder *MockGetArtifactVersionsFilterMockRecorder
-	isgomock struct{}
-}
-
-// MockGetArtifactVersionsFilterMockRecorder is the mock recorder for MockGetArtifactVersionsFilter.
-type MockGetArtifactVersionsFilterMockRecorder struct {
-	mock *MockGetArtifactVersionsFilter
-}
-
-// NewMockGetArtifactVersionsFilter creates a new mock instance.
-func NewMockGetArtifactVersionsFilter(ctrl *gomock.Controller) *MockGetArtifactVersionsFilter {
-	mock := &MockGetArtifactVersionsFilter{ctrl: ctrl}
-	mock.recorder = &MockGetArtifactVersionsFilterMockRecorder{mock}
-	return mock
-}
-
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockGetArtifactVersionsFilter) EXPECT() *MockGetArtifactVersionsFilterMockRecorder {
-	return m.recorder
-}
-
-// IsSkippable mocks base method.
-func (m *MockGetArtifactVersionsFilter) IsSkippable(createdAt time.Time, tags []string) error {
+// UpdatePullRequestComment mocks base method.
+func (m *MockPullRequestReviewer) UpdatePullRequestComment(ctx context.Context, pr *v11.PullRequestReference, commentID, body string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsSkippable", createdAt, tags)
+	ret := m.ctrl.Call(m, "UpdatePullRequestComment", ctx, pr, commentID, body)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// IsSkippable indicates an expected call of IsSkippable.
-func (mr *MockGetArtifactVersionsFilterMockRecorder) IsSkippable(createdAt, tags any) *gomock.Call {
+// UpdatePullRequestComment indicates an expected call of UpdatePullRequestComment.
+func (mr *MockPullRequestReviewerMockRecorder) UpdatePullRequestComment(ctx, pr, commentID, body any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSkippable", reflect.TypeOf((*MockGetArtifactVersionsFilter)(nil).IsSkippable), createdAt, tags)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePullRequestComment", reflect.TypeOf((*MockPullRequestReviewer)(nil).UpdatePullRequestComment), ctx, pr, commentID, body)
 }
 
-// MockArtifactProvider is a mock of ArtifactProvider interface.
-type MockArtifactProvider struct {
+// MockREST is a mock of REST interface.
+type MockREST struct {
 	ctrl     *gomock.Controller
-	recorder *MockArtifactProviderMockRecorder
+	recorder *MockRESTMockRecorder
 	isgomock struct{}
 }
 
-// MockArtifactProviderMockRecorder is the mock recorder for MockArtifactProvider.
-type MockArtifactProviderMockRecorder struct {
-	mock *MockArtifactProvider
+// MockRESTMockRecorder is the mock recorder for MockREST.
+type MockRESTMockRecorder struct {
+	mock *MockREST
 }
 
-// NewMockArtifactProvider creates a new mock instance.
-func NewMockArtifactProvider(ctrl *gomock.Controller) *MockArtifactProvider {
-	mock := &MockArtifactProvider{ctrl: ctrl}
-	mock.recorder = &MockArtifactProviderMockRecorder{mock}
+// NewMockREST creates a new mock instance.
+func NewMockREST(ctrl *gomock.Controller) *MockREST {
+	mock := &MockREST{ctrl: ctrl}
+	mock.recorder = &MockRESTMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockArtifactProvider) EXPECT() *MockArtifactProviderMockRecorder {
+func (m *MockREST) EXPECT() *MockRESTMockRecorder {
 	return m.recorder
 }
 
-// GetArtifactVersions mocks base method.
-func (m *MockArtifactProvider) GetArtifactVersions(ctx context.Context, artifact *v10.Artifact, filter v11.GetArtifactVersionsFilter) ([]*v10.ArtifactVersion, error) {
+// CanImplement mocks base method.
+func (m *MockREST) CanImplement(trait v10.ProviderType) bool {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetArtifactVersions", ctx, artifact, filter)
-	ret0, _ := ret[0].([]*v10.ArtifactVersion)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "CanImplement", trait)
+	ret0, _ := ret[0].(bool)
+	return ret0
 }
 
-// GetArtifactVersions indicates an expected call of GetArtifactVersions.
-func (mr *MockArtifactProviderMockRecorder) GetArtifactVersions(ctx, artifact, filter any) *gomock.Call {
+// CanImplement indicates an expected call of CanImplement.
+func (mr *MockRESTMockRecorder) CanImplement(trait any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArtifactVersions", reflect.TypeOf((*MockArtifactProvider)(nil).GetArtifactVersions), ctx, artifact, filter)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanImplement", reflect.TypeOf((*MockREST)(nil).CanImplement), trait)
 }
 
-// MockGitHub is a mock of GitHub interface.
-type MockGitHub struct {
-	ctrl     *gomock.Controller
-	recorder *MockGitHubMockRecorder
-	isgomock struct{}
+// DeregisterEntity mocks base method.
+func (m *MockREST) DeregisterEntity(ctx context.Context, entType v10.Entity, props *properties.Properties) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeregisterEntity", ctx, entType, props)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// MockGitHubMockRecorder is the mock recorder for MockGitHub.
-type MockGitHubMockRecorder struct {
-	mock *MockGitHub
+// DeregisterEntity indicates an expected call of DeregisterEntity.
+func (mr *MockRESTMockRecorder) DeregisterEntity(ctx, entType, props any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeregisterEntity", reflect.TypeOf((*MockREST)(nil).DeregisterEntity), ctx, entType, props)
 }
 
-// NewMockGitHub creates a new mock instance.
-func NewMockGitHub(ctrl *gomock.Controller) *MockGitHub {
-	mock := &MockGitHub{ctrl: ctrl}
-	mock.recorder = &MockGitHubMockRecorder{mock}
-	return mock
+// Do mocks base method.
+func (m *MockREST) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Do", ctx, req)
+	ret0, _ := ret[0].(*http.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockGitHub) EXPECT() *MockGitHubMockRecorder {
-	return m.recorder
+// Do indicates an expected call of Do.
+func (mr *MockRESTMockRecorder) Do(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockREST)(nil).Do), ctx, req)
 }
 
-// AddAuthToPushOptions mocks base method.
-func (m *MockGitHub) AddAuthToPushOptions(ctx context.Context, options *git.PushOptions) error {
+// FetchAllProperties mocks base method.
+func (m *MockREST) FetchAllProperties(ctx context.Context, getByProps *properties.Properties, entType v10.Entity, cachedProps *properties.Properties) (*properties.Properties, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddAuthToPushOptions", ctx, options)
+	ret := m.ctrl.Call(m, "FetchAllProperties", ctx, getByProps, entType, cachedProps)
+	ret0, _ := ret[0].(*properties.Properties)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchAllProperties indicates an expected call of FetchAllProperties.
+func (mr *MockRESTMockRecorder) FetchAllProperties(ctx, getByProps, entType, cachedProps any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAllProperties", reflect.TypeOf((*MockREST)(nil).FetchAllProperties), ctx, getByProps, entType, cachedProps)
+}
+
+// FetchProperty mocks base method.
+func (m *MockREST) FetchProperty(ctx context.Context, getByProps *properties.Properties, entType v10.Entity, key string) (*properties.Property, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchProperty", ctx, getByProps, entType, key)
+	ret0, _ := ret[0].(*properties.Property)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchProperty indicates an expected call of FetchProperty.
+func (mr *MockRESTMockRecorder) FetchProperty(ctx, getByProps, entType, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchProperty", reflect.TypeOf((*MockREST)(nil).FetchProperty), ctx, getByProps, entType, key)
+}
+
+// GetBaseURL mocks base method.
+func (m *MockREST) GetBaseURL() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBaseURL")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetBaseURL indicates an expected call of GetBaseURL.
+func (mr *MockRESTMockRecorder) GetBaseURL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBaseURL", reflect.TypeOf((*MockREST)(nil).GetBaseURL))
+}
+
+// GetEntityName mocks base method.
+func (m *MockREST) GetEntityName(entType v10.Entity, props *properties.Properties) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntityName", entType, props)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntityName indicates an expected call of GetEntityName.
+func (mr *MockRESTMockRecorder) GetEntityName(entType, props any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntityName", reflect.TypeOf((*MockREST)(nil).GetEntityName), entType, props)
+}
+
+// NewRequest mocks base method.
+func (m *MockREST) NewRequest(method, url string, body any) (*http.Request, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewRequest", method, url, body)
+	ret0, _ := ret[0].(*http.Request)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewRequest indicates an expected call of NewRequest.
+func (mr *MockRESTMockRecorder) NewRequest(method, url, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewRequest", reflect.TypeOf((*MockREST)(nil).NewRequest), method, url, body)
+}
+
+// PropertiesToProtoMessage mocks base method.
+func (m *MockREST) PropertiesToProtoMessage(entType v10.Entity, props *properties.Properties) (protoreflect.ProtoMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PropertiesToProtoMessage", entType, props)
+	ret0, _ := ret[0].(protoreflect.ProtoMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PropertiesToProtoMessage indicates an expected call of PropertiesToProtoMessage.
+func (mr *MockRESTMockRecorder) PropertiesToProtoMessage(entType, props any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PropertiesToProtoMessage", reflect.TypeOf((*MockREST)(nil).PropertiesToProtoMessage), entType, props)
+}
+
+// RegisterEntity mocks base method.
+func (m *MockREST) RegisterEntity(ctx context.Context, entType v10.Entity, props *properties.Properties) (*properties.Properties, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterEntity", ctx, entType, props)
+	ret0, _ := ret[0].(*properties.Properties)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterEntity indicates an expected call of RegisterEntity.
+func (mr *MockRESTMockRecorder) RegisterEntity(ctx, entType, props any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterEntity", reflect.TypeOf((*MockREST)(nil).RegisterEntity), ctx, entType, props)
+}
+
+// ReregisterEntity mocks base method.
+func (m *MockREST) ReregisterEntity(ctx context.Context, entType v10.Entity, props *properties.Properties) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReregisterEntity", ctx, entType, props)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// AddAuthToPushOptions indicates an expected call of AddAuthToPushOptions.
-func (mr *MockGitHubMockRecorder) AddAuthToPushOptions(ctx, options any) *gomock.Call {
+// ReregisterEntity indicates an expected call of ReregisterEntity.
+func (mr *MockRESTMockRecorder) ReregisterEntity(ctx, entType, props any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAuthToPushOptions", reflect.TypeOf((*MockGitHub)(nil).AddAuthToPushOptions), ctx, options)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReregisterEntity", reflect.TypeOf((*MockREST)(nil).ReregisterEntity), ctx, entType, props)
+}
+
+// SupportsEntity mocks base method.
+func (m *MockREST) SupportsEntity(entType v10.Entity) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SupportsEntity", entType)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// SupportsEntity indicates an expected call of SupportsEntity.
+func (mr *MockRESTMockRecorder) SupportsEntity(entType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SupportsEntity", reflect.TypeOf((*MockREST)(nil).SupportsEntity), entType)
+}
+
+// MockRepoLister is a mock of RepoLister interface.
+type MockRepoLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoListerMockRecorder
+	isgomock struct{}
+}
+
+// MockRepoListerMockRecorder is the mock recorder for MockRepoLister.
+type MockRepoListerMockRecorder struct {
+	mock *MockRepoLister
+}
+
+// NewMockRepoLister creates a new mock instance.
+func NewMockRepoLister(ctrl *gomock.Controller) *MockRepoLister {
+	mock := &MockRepoLister{ctrl: ctrl}
+	mock.recorder = &MockRepoListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepoLister) EXPECT() *MockRepoListerMockRecorder {
+	return m.recorder
 }
 
 // CanImplement mocks base method.
-func (m *MockGitHub) CanImplement(trait v10.ProviderType) bool {
+func (m *MockRepoLister) CanImplement(trait v10.ProviderType) bool {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CanImplement", trait)
 	ret0, _ := ret[0].(bool)
@@ -844,577 +1084,1585 @@ func (m *MockGitHub) CanImplement(trait v10.ProviderType) bool {
 }
 
 // CanImplement indicates an expected call of CanImplement.
-func (mr *MockGitHubMockRecorder) CanImplement(trait any) *gomock.Call {
+func (mr *MockRepoListerMockRecorder) CanImplement(trait any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanImplement", reflect.TypeOf((*MockRepoLister)(nil).CanImplement), trait)
+}
+
+// DeregisterEntity mocks base method.
+func (m *MockRepoLister) DeregisterEntity(ctx context.Context, entType v10.Entity, props *properties.Properties) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeregisterEntity", ctx, entType, props)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeregisterEntity indicates an expected call of DeregisterEntity.
+func (mr *MockRepoListerMockRecorder) DeregisterEntity(ctx, entType, props any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeregisterEntity", reflect.TypeOf((*MockRepoLister)(nil).DeregisterEntity), ctx, entType, props)
+}
+
+// FetchAllProperties mocks base method.
+func (m *MockRepoLister) FetchAllProperties(ctx context.Context, getByProps *properties.Properties, entType v10.Entity, cachedProps *properties.Properties) (*properties.Properties, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchAllProperties", ctx, getByProps, entType, cachedProps)
+	ret0, _ := ret[0].(*properties.Properties)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchAllProperties indicates an expected call of FetchAllProperties.
+func (mr *MockRepoListerMockRecorder) FetchAllProperties(ctx, getByProps, entType, cachedProps any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAllProperties", reflect.TypeOf((*MockRepoLister)(nil).FetchAllProperties), ctx, getByProps, entType, cachedProps)
+}
+
+// FetchProperty mocks base method.
+func (m *MockRepoLister) FetchProperty(ctx context.Context, getByProps *properties.Properties, entType v10.Entity, key string) (*properties.Property, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchProperty", ctx, getByProps, entType, key)
+	ret0, _ := ret[0].(*properties.Property)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchProperty indicates an expected call of FetchProperty.
+func (mr *MockRepoListerMockRecorder) FetchProperty(ctx, getByProps, entType, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchProperty", reflect.TypeOf((*MockRepoLister)(nil).FetchProperty), ctx, getByProps, entType, key)
+}
+
+// GetEntityName mocks base method.
+func (m *MockRepoLister) GetEntityName(entType v10.Entity, props *properties.Properties) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntityName", entType, props)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntityName indicates an expected call of GetEntityName.
+func (mr *MockRepoListerMockRecorder) GetEntityName(entType, props any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntityName", reflect.TypeOf((*MockRepoLister)(nil).GetEntityName), entType, props)
+}
+
+// ListAllRepositories mocks base method.
+func (m *MockRepoLister) ListAllRepositories(arg0 context.Context) ([]*v10.Repository, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllRepositories", arg0)
+	ret0, _ := ret[0].([]*v10.Repository)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllRepositories indicates an expected call of ListAllRepositories.
+func (mr *MockRepoListerMockRecorder) ListAllRepositories(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllRepositories", reflect.TypeOf((*MockRepoLister)(nil).ListAllRepositories), arg0)
+}
+
+// PropertiesToProtoMessage mocks base method.
+func (m *MockRepoLister) PropertiesToProtoMessage(entType v10.Entity, props *properties.Properties) (protoreflect.ProtoMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PropertiesToProtoMessage", entType, props)
+	ret0, _ := ret[0].(protoreflect.ProtoMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PropertiesToProtoMessage indicates an expected call of PropertiesToProtoMessage.
+func (mr *MockRepoListerMockRecorder) PropertiesToProtoMessage(entType, props any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PropertiesToProtoMessage", reflect.TypeOf((*MockRepoLister)(nil).PropertiesToProtoMessage), entType, props)
+}
+
+// RegisterEntity mocks base method.
+func (m *MockRepoLister) RegisterEntity(ctx context.Context, entType v10.Entity, props *properties.Properties) (*properties.Properties, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterEntity", ctx, entType, props)
+	ret0, _ := ret[0].(*properties.Properties)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterEntity indicates an expected call of RegisterEntity.
+func (mr *MockRepoListerMockRecorder) RegisterEntity(ctx, entType, props any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterEntity", reflect.TypeOf((*MockRepoLister)(nil).RegisterEntity), ctx, entType, props)
+}
+
+// ReregisterEntity mocks base method.
+func (m *MockRepoLister) ReregisterEntity(ctx context.Context, entType v10.Entity, props *properties.Properties) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReregisterEntity", ctx, entType, props)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReregisterEntity indicates an expected call of ReregisterEntity.
+func (mr *MockRepoListerMockRecorder) ReregisterEntity(ctx, entType, props any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReregisterEntity", reflect.TypeOf((*MockRepoLister)(nil).ReregisterEntity), ctx, entType, props)
+}
+
+// SupportsEntity mocks base method.
+func (m *MockRepoLister) SupportsEntity(entType v10.Entity) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SupportsEntity", entType)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// SupportsEntity indicates an expected call of SupportsEntity.
+func (mr *MockRepoListerMockRecorder) SupportsEntity(entType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SupportsEntity", reflect.TypeOf((*MockRepoLister)(nil).SupportsEntity), entType)
+}
+
+// MockGetArtifactVersionsFilter is a mock of GetArtifactVersionsFilter interface.
+type MockGetArtifactVersionsFilter struct {
+	ctrl     *gomock.Controller
+	recorder *MockGetArtifactVersionsFilterMockRecorder
+	isgomock struct{}
+}
+
+// MockGetArtifactVersionsFilterMockRecorder is the mock recorder for MockGetArtifactVersionsFilter.
+type MockGetArtifactVersionsFilterMockRecorder struct {
+	mock *MockGetArtifactVersionsFilter
+}
+
+// NewMockGetArtifactVersionsFilter creates a new mock instance.
+func NewMockGetArtifactVersionsFilter(ctrl *gomock.Controller) *MockGetArtifactVersionsFilter {
+	mock := &MockGetArtifactVersionsFilter{ctrl: ctrl}
+	mock.recorder = &MockGetArtifactVersionsFilterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGetArtifactVersionsFilter) EXPECT() *MockGetArtifactVersionsFilterMockRecorder {
+	return m.recorder
+}
+
+// IsSkippable mocks base method.
+func (m *MockGetArtifactVersionsFilter) IsSkippable(createdAt time.Time, tags []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSkippable", createdAt, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IsSkippable indicates an expected call of IsSkippable.
+func (mr *MockGetArtifactVersionsFilterMockRecorder) IsSkippable(createdAt, tags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSkippable", reflect.TypeOf((*MockGetArtifactVersionsFilter)(nil).IsSkippable), createdAt, tags)
+}
+
+// MockArtifactProvider is a mock of ArtifactProvider interface.
+type MockArtifactProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockArtifactProviderMockRecorder
+	isgomock struct{}
+}
+
+// MockArtifactProviderMockRecorder is the mock recorder for MockArtifactProvider.
+type MockArtifactProviderMockRecorder struct {
+	mock *MockArtifactProvider
+}
+
+// NewMockArtifactProvider creates a new mock instance.
+func NewMockArtifactProvider(ctrl *gomock.Controller) *MockArtifactProvider {
+	mock := &MockArtifactProvider{ctrl: ctrl}
+	mock.recorder = &MockArtifactProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArtifactProvider) EXPECT() *MockArtifactProviderMockRecorder {
+	return m.recorder
+}
+
+// GetArtifactVersions mocks base method.
+func (m *MockArtifactProvider) GetArtifactVersions(ctx context.Context, artifact *v10.Artifact, filter v11.GetArtifactVersionsFilter) ([]*v10.ArtifactVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArtifactVersions", ctx, artifact, filter)
+	ret0, _ := ret[0].([]*v10.ArtifactVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArtifactVersions indicates an expected call of GetArtifactVersions.
+func (mr *MockArtifactProviderMockRecorder) GetArtifactVersions(ctx, artifact, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArtifactVersions", reflect.TypeOf((*MockArtifactProvider)(nil).GetArtifactVersions), ctx, artifact, filter)
+}
+
+// MockGitHub is a mock of GitHub interface.
+type MockGitHub struct {
+	ctrl     *gomock.Controller
+	recorder *MockGitHubMockRecorder
+	isgomock struct{}
+}
+
+// MockGitHubMockRecorder is the mock recorder for MockGitHub.
+type MockGitHubMockRecorder struct {
+	mock *MockGitHub
+}
+
+// NewMockGitHub creates a new mock instance.
+func NewMockGitHub(ctrl *gomock.Controller) *MockGitHub {
+	mock := &MockGitHub{ctrl: ctrl}
+	mock.recorder = &MockGitHubMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGitHub) EXPECT() *MockGitHubMockRecorder {
+	return m.recorder
+}
+
+// AddAuthToPushOptions mocks base method.
+func (m *MockGitHub) AddAuthToPushOptions(ctx context.Context, options *git.PushOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAuthToPushOptions", ctx, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAuthToPushOptions indicates an expected call of AddAuthToPushOptions.
+func (mr *MockGitHubMockRecorder) AddAuthToPushOptions(ctx, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAuthToPushOptions", reflect.TypeOf((*MockGitHub)(nil).AddAuthToPushOptions), ctx, options)
+}
+
+// CanImplement mocks base method.
+func (m *MockGitHub) CanImplement(trait v10.ProviderType) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanImplement", trait)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CanImplement indicates an expected call of CanImplement.
+func (mr *MockGitHubMockRecorder) CanImplement(trait any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanImplement", reflect.TypeOf((*MockGitHub)(nil).CanImplement), trait)
+}
+
+// Clone mocks base method.
+func (m *MockGitHub) Clone(ctx context.Context, url, branch string) (*git.Repository, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clone", ctx, url, branch)
+	ret0, _ := ret[0].(*git.Repository)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Clone indicates an expected call of Clone.
+func (mr *MockGitHubMockRecorder) Clone(ctx, url, branch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clone", reflect.TypeOf((*MockGitHub)(nil).Clone), ctx, url, branch)
+}
+
+// CloseChangeRequest mocks base method.
+func (m *MockGitHub) CloseChangeRequest(ctx context.Context, repo *v10.Repository, number int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseChangeRequest", ctx, repo, number)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseChangeRequest indicates an expected call of CloseChangeRequest.
+func (mr *MockGitHubMockRecorder) CloseChangeRequest(ctx, repo, number any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseChangeRequest", reflect.TypeOf((*MockGitHub)(nil).CloseChangeRequest), ctx, repo, number)
+}
+
+// ClosePullRequest mocks base method.
+func (m *MockGitHub) ClosePullRequest(ctx context.Context, owner, repo string, number int) (*github.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClosePullRequest", ctx, owner, repo, number)
+	ret0, _ := ret[0].(*github.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClosePullRequest indicates an expected call of ClosePullRequest.
+func (mr *MockGitHubMockRecorder) ClosePullRequest(ctx, owner, repo, number any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClosePullRequest", reflect.TypeOf((*MockGitHub)(nil).ClosePullRequest), ctx, owner, repo, number)
+}
+
+// CloseSecurityAdvisory mocks base method.
+func (m *MockGitHub) CloseSecurityAdvisory(ctx context.Context, owner, repo, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSecurityAdvisory", ctx, owner, repo, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSecurityAdvisory indicates an expected call of CloseSecurityAdvisory.
+func (mr *MockGitHubMockRecorder) CloseSecurityAdvisory(ctx, owner, repo, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSecurityAdvisory", reflect.TypeOf((*MockGitHub)(nil).CloseSecurityAdvisory), ctx, owner, repo, id)
+}
+
+// CreateChangeRequest mocks base method.
+func (m *MockGitHub) CreateChangeRequest(ctx context.Context, repo *v10.Repository, title, body, head, base string) (*v11.ChangeRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChangeRequest", ctx, repo, title, body, head, base)
+	ret0, _ := ret[0].(*v11.ChangeRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateChangeRequest indicates an expected call of CreateChangeRequest.
+func (mr *MockGitHubMockRecorder) CreateChangeRequest(ctx, repo, title, body, head, base any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChangeRequest", reflect.TypeOf((*MockGitHub)(nil).CreateChangeRequest), ctx, repo, title, body, head, base)
+}
+
+// CreateHook mocks base method.
+func (m *MockGitHub) CreateHook(ctx context.Context, owner, repo string, hook *github.Hook) (*github.Hook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHook", ctx, owner, repo, hook)
+	ret0, _ := ret[0].(*github.Hook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHook indicates an expected call of CreateHook.
+func (mr *MockGitHubMockRecorder) CreateHook(ctx, owner, repo, hook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHook", reflect.TypeOf((*MockGitHub)(nil).CreateHook), ctx, owner, repo, hook)
+}
+
+// CreateIssueComment mocks base method.
+func (m *MockGitHub) CreateIssueComment(ctx context.Context, owner, repo string, number int, comment string) (*github.IssueComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIssueComment", ctx, owner, repo, number, comment)
+	ret0, _ := ret[0].(*github.IssueComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIssueComment indicates an expected call of CreateIssueComment.
+func (mr *MockGitHubMockRecorder) CreateIssueComment(ctx, owner, repo, number, comment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIssueComment", reflect.TypeOf((*MockGitHub)(nil).CreateIssueComment), ctx, owner, repo, number, comment)
+}
+
+// CreatePullRequest mocks base method.
+func (m *MockGitHub) CreatePullRequest(ctx context.Context, owner, repo, title, body, head, base string) (*github.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePullRequest", ctx, owner, repo, title, body, head, base)
+	ret0, _ := ret[0].(*github.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePullRequest indicates an expected call of CreatePullRequest.
+func (mr *MockGitHubMockRecorder) CreatePullRequest(ctx, owner, repo, title, body, head, base any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePullRequest", reflect.TypeOf((*MockGitHub)(nil).CreatePullRequest), ctx, owner, repo, title, body, head, base)
+}
+
+// CreatePullRequestComment mocks base method.
+func (m *MockGitHub) CreatePullRequestComment(ctx context.Context, pr *v11.PullRequestReference, body string) (*v11.PullRequestComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePullRequestComment", ctx, pr, body)
+	ret0, _ := ret[0].(*v11.PullRequestComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePullRequestComment indicates an expected call of CreatePullRequestComment.
+func (mr *MockGitHubMockRecorder) CreatePullRequestComment(ctx, pr, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePullRequestComment", reflect.TypeOf((*MockGitHub)(nil).CreatePullRequestComment), ctx, pr, body)
+}
+
+// CreateReview mocks base method.
+func (m *MockGitHub) CreateReview(arg0 context.Context, arg1, arg2 string, arg3 int, arg4 *github.PullRequestReviewRequest) (*github.PullRequestReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReview", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*github.PullRequestReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReview indicates an expected call of CreateReview.
+func (mr *MockGitHubMockRecorder) CreateReview(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReview", reflect.TypeOf((*MockGitHub)(nil).CreateReview), arg0, arg1, arg2, arg3, arg4)
+}
+
+// CreateRuleset mocks base method.
+func (m *MockGitHub) CreateRuleset(ctx context.Context, owner, repo string, ruleset *github.Ruleset) (*github.Ruleset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRuleset", ctx, owner, repo, ruleset)
+	ret0, _ := ret[0].(*github.Ruleset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRuleset indicates an expected call of CreateRuleset.
+func (mr *MockGitHubMockRecorder) CreateRuleset(ctx, owner, repo, ruleset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRuleset", reflect.TypeOf((*MockGitHub)(nil).CreateRuleset), ctx, owner, repo, ruleset)
+}
+
+// CreateSecurityAdvisory mocks base method.
+func (m *MockGitHub) CreateSecurityAdvisory(ctx context.Context, owner, repo, severity, summary, description string, v []*github.AdvisoryVulnerability) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSecurityAdvisory", ctx, owner, repo, severity, summary, description, v)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSecurityAdvisory indicates an expected call of CreateSecurityAdvisory.
+func (mr *MockGitHubMockRecorder) CreateSecurityAdvisory(ctx, owner, repo, severity, summary, description, v any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecurityAdvisory", reflect.TypeOf((*MockGitHub)(nil).CreateSecurityAdvisory), ctx, owner, repo, severity, summary, description, v)
+}
+
+// DeleteHook mocks base method.
+func (m *MockGitHub) DeleteHook(ctx context.Context, owner, repo string, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHook", ctx, owner, repo, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteHook indicates an expected call of DeleteHook.
+func (mr *MockGitHubMockRecorder) DeleteHook(ctx, owner, repo, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHook", reflect.TypeOf((*MockGitHub)(nil).DeleteHook), ctx, owner, repo, id)
+}
+
+// DeregisterEntity mocks base method.
+func (m *MockGitHub) DeregisterEntity(ctx context.Context, entType v10.Entity, props *properties.Properties) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeregisterEntity", ctx, entType, props)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeregisterEntity indicates an expected call of DeregisterEntity.
+func (mr *MockGitHubMockRecorder) DeregisterEntity(ctx, entType, props any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeregisterEntity", reflect.TypeOf((*MockGitHub)(nil).DeregisterEntity), ctx, entType, props)
+}
+
+// DismissPullRequestReview mocks base method.
+func (m *MockGitHub) DismissPullRequestReview(ctx context.Context, pr *v11.PullRequestReference, reviewID, message string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DismissPullRequestReview", ctx, pr, reviewID, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DismissPullRequestReview indicates an expected call of DismissPullRequestReview.
+func (mr *MockGitHubMockRecorder) DismissPullRequestReview(ctx, pr, reviewID, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DismissPullRequestReview", reflect.TypeOf((*MockGitHub)(nil).DismissPullRequestReview), ctx, pr, reviewID, message)
+}
+
+// DismissReview mocks base method.
+func (m *MockGitHub) DismissReview(arg0 context.Context, arg1, arg2 string, arg3 int, arg4 int64, arg5 *github.PullRequestReviewDismissalRequest) (*github.PullRequestReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DismissReview", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(*github.PullRequestReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DismissReview indicates an expected call of DismissReview.
+func (mr *MockGitHubMockRecorder) DismissReview(arg0, arg1, arg2, arg3, arg4, arg5 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DismissReview", reflect.TypeOf((*MockGitHub)(nil).DismissReview), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// Do mocks base method.
+func (m *MockGitHub) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Do", ctx, req)
+	ret0, _ := ret[0].(*http.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Do indicates an expected call of Do.
+func (mr *MockGitHubMockRecorder) Do(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockGitHub)(nil).Do), ctx, req)
+}
+
+// EditHook mocks base method.
+func (m *MockGitHub) EditHook(ctx context.Context, owner, repo string, id int64, hook *github.Hook) (*github.Hook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditHook", ctx, owner, repo, id, hook)
+	ret0, _ := ret[0].(*github.Hook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditHook indicates an expected call of EditHook.
+func (mr *MockGitHubMockRecorder) EditHook(ctx, owner, repo, id, hook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditHook", reflect.TypeOf((*MockGitHub)(nil).EditHook), ctx, owner, repo, id, hook)
+}
+
+// FetchAllProperties mocks base method.
+func (m *MockGitHub) FetchAllProperties(ctx context.Context, getByProps *properties.Properties, entType v10.Entity, cachedProps *properties.Properties) (*properties.Properties, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchAllProperties", ctx, getByProps, entType, cachedProps)
+	ret0, _ := ret[0].(*properties.Properties)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchAllProperties indicates an expected call of FetchAllProperties.
+func (mr *MockGitHubMockRecorder) FetchAllProperties(ctx, getByProps, entType, cachedProps any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAllProperties", reflect.TypeOf((*MockGitHub)(nil).FetchAllProperties), ctx, getByProps, entType, cachedProps)
+}
+
+// FetchProperty mocks base method.
+func (m *MockGitHub) FetchProperty(ctx context.Context, getByProps *properties.Properties, entType v10.Entity, key string) (*properties.Property, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchProperty", ctx, getByProps, entType, key)
+	ret0, _ := ret[0].(*properties.Property)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchProperty indicates an expected call of FetchProperty.
+func (mr *MockGitHubMockRecorder) FetchProperty(ctx, getByProps, entType, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchProperty", reflect.TypeOf((*MockGitHub)(nil).FetchProperty), ctx, getByProps, entType, key)
+}
+
+// FindChangeRequest mocks base method.
+func (m *MockGitHub) FindChangeRequest(ctx context.Context, repo *v10.Repository, head string) (*v11.ChangeRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindChangeRequest", ctx, repo, head)
+	ret0, _ := ret[0].(*v11.ChangeRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindChangeRequest indicates an expected call of FindChangeRequest.
+func (mr *MockGitHubMockRecorder) FindChangeRequest(ctx, repo, head any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindChangeRequest", reflect.TypeOf((*MockGitHub)(nil).FindChangeRequest), ctx, repo, head)
+}
+
+// GetArtifactVersions mocks base method.
+func (m *MockGitHub) GetArtifactVersions(ctx context.Context, artifact *v10.Artifact, filter v11.GetArtifactVersionsFilter) ([]*v10.ArtifactVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArtifactVersions", ctx, artifact, filter)
+	ret0, _ := ret[0].([]*v10.ArtifactVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArtifactVersions indicates an expected call of GetArtifactVersions.
+func (mr *MockGitHubMockRecorder) GetArtifactVersions(ctx, artifact, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArtifactVersions", reflect.TypeOf((*MockGitHub)(nil).GetArtifactVersions), ctx, artifact, filter)
+}
+
+// GetBaseURL mocks base method.
+func (m *MockGitHub) GetBaseURL() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBaseURL")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetBaseURL indicates an expected call of GetBaseURL.
+func (mr *MockGitHubMockRecorder) GetBaseURL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBaseURL", reflect.TypeOf((*MockGitHub)(nil).GetBaseURL))
+}
+
+// GetBranchProtection mocks base method.
+func (m *MockGitHub) GetBranchProtection(arg0 context.Context, arg1, arg2, arg3 string) (*github.Protection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBranchProtection", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*github.Protection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBranchProtection indicates an expected call of GetBranchProtection.
+func (mr *MockGitHubMockRecorder) GetBranchProtection(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBranchProtection", reflect.TypeOf((*MockGitHub)(nil).GetBranchProtection), arg0, arg1, arg2, arg3)
+}
+
+// GetCommitAuthor mocks base method.
+func (m *MockGitHub) GetCommitAuthor(ctx context.Context) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommitAuthor", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetCommitAuthor indicates an expected call of GetCommitAuthor.
+func (mr *MockGitHubMockRecorder) GetCommitAuthor(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommitAuthor", reflect.TypeOf((*MockGitHub)(nil).GetCommitAuthor), ctx)
+}
+
+// GetCredential mocks base method.
+func (m *MockGitHub) GetCredential() v11.GitHubCredential {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCredential")
+	ret0, _ := ret[0].(v11.GitHubCredential)
+	return ret0
+}
+
+// GetCredential indicates an expected call of GetCredential.
+func (mr *MockGitHubMockRecorder) GetCredential() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredential", reflect.TypeOf((*MockGitHub)(nil).GetCredential))
+}
+
+// GetEntityName mocks base method.
+func (m *MockGitHub) GetEntityName(entType v10.Entity, props *properties.Properties) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntityName", entType, props)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntityName indicates an expected call of GetEntityName.
+func (mr *MockGitHubMockRecorder) GetEntityName(entType, props any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntityName", reflect.TypeOf((*MockGitHub)(nil).GetEntityName), entType, props)
+}
+
+// GetLogin mocks base method.
+func (m *MockGitHub) GetLogin(ctx context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLogin", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLogin indicates an expected call of GetLogin.
+func (mr *MockGitHubMockRecorder) GetLogin(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogin", reflect.TypeOf((*MockGitHub)(nil).GetLogin), ctx)
+}
+
+// GetName mocks base method.
+func (m *MockGitHub) GetName(ctx context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetName", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetName indicates an expected call of GetName.
+func (mr *MockGitHubMockRecorder) GetName(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetName", reflect.TypeOf((*MockGitHub)(nil).GetName), ctx)
+}
+
+// GetNamespaceURL mocks base method.
+func (m *MockGitHub) GetNamespaceURL() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNamespaceURL")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetNamespaceURL indicates an expected call of GetNamespaceURL.
+func (mr *MockGitHubMockRecorder) GetNamespaceURL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNamespaceURL", reflect.TypeOf((*MockGitHub)(nil).GetNamespaceURL))
+}
+
+// GetPackageByName mocks base method.
+func (m *MockGitHub) GetPackageByName(arg0 context.Context, arg1, arg2, arg3 string) (*github.Package, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPackageByName", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*github.Package)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPackageByName indicates an expected call of GetPackageByName.
+func (mr *MockGitHubMockRecorder) GetPackageByName(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPackageByName", reflect.TypeOf((*MockGitHub)(nil).GetPackageByName), arg0, arg1, arg2, arg3)
+}
+
+// GetPackageVersionById mocks base method.
+func (m *MockGitHub) GetPackageVersionById(arg0 context.Context, arg1, arg2, arg3 string, arg4 int64) (*github.PackageVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPackageVersionById", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*github.PackageVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPackageVersionById indicates an expected call of GetPackageVersionById.
+func (mr *MockGitHubMockRecorder) GetPackageVersionById(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPackageVersionById", reflect.TypeOf((*MockGitHub)(nil).GetPackageVersionById), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetPrimaryEmail mocks base method.
+func (m *MockGitHub) GetPrimaryEmail(ctx context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrimaryEmail", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrimaryEmail indicates an expected call of GetPrimaryEmail.
+func (mr *MockGitHubMockRecorder) GetPrimaryEmail(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrimaryEmail", reflect.TypeOf((*MockGitHub)(nil).GetPrimaryEmail), ctx)
+}
+
+// GetPullRequest mocks base method.
+func (m *MockGitHub) GetPullRequest(arg0 context.Context, arg1, arg2 string, arg3 int) (*github.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPullRequest", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*github.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPullRequest indicates an expected call of GetPullRequest.
+func (mr *MockGitHubMockRecorder) GetPullRequest(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullRequest", reflect.TypeOf((*MockGitHub)(nil).GetPullRequest), arg0, arg1, arg2, arg3)
+}
+
+// GetRepository mocks base method.
+func (m *MockGitHub) GetRepository(arg0 context.Context, arg1, arg2 string) (*github.Repository, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepository", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*github.Repository)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRepository indicates an expected call of GetRepository.
+func (mr *MockGitHubMockRecorder) GetRepository(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepository", reflect.TypeOf((*MockGitHub)(nil).GetRepository), arg0, arg1, arg2)
+}
+
+// GetReviewerID mocks base method.
+func (m *MockGitHub) GetReviewerID(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewerID", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewerID indicates an expected call of GetReviewerID.
+func (mr *MockGitHubMockRecorder) GetReviewerID(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewerID", reflect.TypeOf((*MockGitHub)(nil).GetReviewerID), ctx)
+}
+
+// GetRulesForBranch mocks base method.
+func (m *MockGitHub) GetRulesForBranch(ctx context.Context, owner, repo, branch string) ([]*github.RepositoryRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRulesForBranch", ctx, owner, repo, branch)
+	ret0, _ := ret[0].([]*github.RepositoryRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRulesForBranch indicates an expected call of GetRulesForBranch.
+func (mr *MockGitHubMockRecorder) GetRulesForBranch(ctx, owner, repo, branch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRulesForBranch", reflect.TypeOf((*MockGitHub)(nil).GetRulesForBranch), ctx, owner, repo, branch)
+}
+
+// GetRuleset mocks base method.
+func (m *MockGitHub) GetRuleset(ctx context.Context, owner, repo string, id int64) (*github.Ruleset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuleset", ctx, owner, repo, id)
+	ret0, _ := ret[0].(*github.Ruleset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRuleset indicates an expected call of GetRuleset.
+func (mr *MockGitHubMockRecorder) GetRuleset(ctx, owner, repo, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuleset", reflect.TypeOf((*MockGitHub)(nil).GetRuleset), ctx, owner, repo, id)
+}
+
+// GetUserId mocks base method.
+func (m *MockGitHub) GetUserId(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserId", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserId indicates an expected call of GetUserId.
+func (mr *MockGitHubMockRecorder) GetUserId(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserId", reflect.TypeOf((*MockGitHub)(nil).GetUserId), ctx)
+}
+
+// IsOrg mocks base method.
+func (m *MockGitHub) IsOrg() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsOrg")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsOrg indicates an expected call of IsOrg.
+func (mr *MockGitHubMockRecorder) IsOrg() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsOrg", reflect.TypeOf((*MockGitHub)(nil).IsOrg))
+}
+
+// ListAllRepositories mocks base method.
+func (m *MockGitHub) ListAllRepositories(arg0 context.Context) ([]*v10.Repository, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllRepositories", arg0)
+	ret0, _ := ret[0].([]*v10.Repository)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllRepositories indicates an expected call of ListAllRepositories.
+func (mr *MockGitHubMockRecorder) ListAllRepositories(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllRepositories", reflect.TypeOf((*MockGitHub)(nil).ListAllRepositories), arg0)
+}
+
+// ListFiles mocks base method.
+func (m *MockGitHub) ListFiles(ctx context.Context, owner, repo string, prNumber, perPage, pageNumber int) ([]*github.CommitFile, *github.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFiles", ctx, owner, repo, prNumber, perPage, pageNumber)
+	ret0, _ := ret[0].([]*github.CommitFile)
+	ret1, _ := ret[1].(*github.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListFiles indicates an expected call of ListFiles.
+func (mr *MockGitHubMockRecorder) ListFiles(ctx, owner, repo, prNumber, perPage, pageNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFiles", reflect.TypeOf((*MockGitHub)(nil).ListFiles), ctx, owner, repo, prNumber, perPage, pageNumber)
+}
+
+// ListHooks mocks base method.
+func (m *MockGitHub) ListHooks(ctx context.Context, owner, repo string) ([]*github.Hook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHooks", ctx, owner, repo)
+	ret0, _ := ret[0].([]*github.Hook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHooks indicates an expected call of ListHooks.
+func (mr *MockGitHubMockRecorder) ListHooks(ctx, owner, repo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHooks", reflect.TypeOf((*MockGitHub)(nil).ListHooks), ctx, owner, repo)
+}
+
+// ListImages mocks base method.
+func (m *MockGitHub) ListImages(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImages", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImages indicates an expected call of ListImages.
+func (mr *MockGitHubMockRecorder) ListImages(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImages", reflect.TypeOf((*MockGitHub)(nil).ListImages), ctx)
+}
+
+// ListIssueComments mocks base method.
+func (m *MockGitHub) ListIssueComments(ctx context.Context, owner, repo string, number int, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIssueComments", ctx, owner, repo, number, opts)
+	ret0, _ := ret[0].([]*github.IssueComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIssueComments indicates an expected call of ListIssueComments.
+func (mr *MockGitHubMockRecorder) ListIssueComments(ctx, owner, repo, number, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIssueComments", reflect.TypeOf((*MockGitHub)(nil).ListIssueComments), ctx, owner, repo, number, opts)
+}
+
+// ListPackagesByRepository mocks base method.
+func (m *MockGitHub) ListPackagesByRepository(arg0 context.Context, arg1, arg2 string, arg3 int64, arg4, arg5 int) ([]*github.Package, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPackagesByRepository", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].([]*github.Package)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPackagesByRepository indicates an expected call of ListPackagesByRepository.
+func (mr *MockGitHubMockRecorder) ListPackagesByRepository(arg0, arg1, arg2, arg3, arg4, arg5 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPackagesByRepository", reflect.TypeOf((*MockGitHub)(nil).ListPackagesByRepository), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// ListPullRequestComments mocks base method.
+func (m *MockGitHub) ListPullRequestComments(ctx context.Context, pr *v11.PullRequestReference) ([]*v11.PullRequestComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPullRequestComments", ctx, pr)
+	ret0, _ := ret[0].([]*v11.PullRequestComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPullRequestComments indicates an expected call of ListPullRequestComments.
+func (mr *MockGitHubMockRecorder) ListPullRequestComments(ctx, pr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPullRequestComments", reflect.TypeOf((*MockGitHub)(nil).ListPullRequestComments), ctx, pr)
+}
+
+// ListPullRequestReviews mocks base method.
+func (m *MockGitHub) ListPullRequestReviews(ctx context.Context, pr *v11.PullRequestReference) ([]*v11.PullRequestReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPullRequestReviews", ctx, pr)
+	ret0, _ := ret[0].([]*v11.PullRequestReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPullRequestReviews indicates an expected call of ListPullRequestReviews.
+func (mr *MockGitHubMockRecorder) ListPullRequestReviews(ctx, pr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPullRequestReviews", reflect.TypeOf((*MockGitHub)(nil).ListPullRequestReviews), ctx, pr)
+}
+
+// ListPullRequests mocks base method.
+func (m *MockGitHub) ListPullRequests(ctx context.Context, owner, repo string, opt *github.PullRequestListOptions) ([]*github.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPullRequests", ctx, owner, repo, opt)
+	ret0, _ := ret[0].([]*github.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPullRequests indicates an expected call of ListPullRequests.
+func (mr *MockGitHubMockRecorder) ListPullRequests(ctx, owner, repo, opt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPullRequests", reflect.TypeOf((*MockGitHub)(nil).ListPullRequests), ctx, owner, repo, opt)
+}
+
+// ListReviews mocks base method.
+func (m *MockGitHub) ListReviews(arg0 context.Context, arg1, arg2 string, arg3 int, arg4 *github.ListOptions) ([]*github.PullRequestReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReviews", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*github.PullRequestReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReviews indicates an expected call of ListReviews.
+func (mr *MockGitHubMockRecorder) ListReviews(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanImplement", reflect.TypeOf((*MockGitHub)(nil).CanImplement), trait)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReviews", reflect.TypeOf((*MockGitHub)(nil).ListReviews), arg0, arg1, arg2, arg3, arg4)
 }
 
-// Clone mocks base method.
-func (m *MockGitHub) Clone(ctx context.Context, url, branch string) (*git.Repository, error) {
+// ListRulesets mocks base method.
+func (m *MockGitHub) ListRulesets(ctx context.Context, owner, repo string, includeParents bool) ([]*github.Ruleset, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Clone", ctx, url, branch)
-	ret0, _ := ret[0].(*git.Repository)
+	ret := m.ctrl.Call(m, "ListRulesets", ctx, owner, repo, includeParents)
+	ret0, _ := ret[0].([]*github.Ruleset)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Clone indicates an expected call of Clone.
-func (mr *MockGitHubMockRecorder) Clone(ctx, url, branch any) *gomock.Call {
+// ListRulesets indicates an expected call of ListRulesets.
+func (mr *MockGitHubMockRecorder) ListRulesets(ctx, owner, repo, includeParents any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clone", reflect.TypeOf((*MockGitHub)(nil).Clone), ctx, url, branch)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRulesets", reflect.TypeOf((*MockGitHub)(nil).ListRulesets), ctx, owner, repo, includeParents)
 }
 
-// ClosePullRequest mocks base method.
-func (m *MockGitHub) ClosePullRequest(ctx context.Context, owner, repo string, number int) (*github.PullRequest, error) {
+// NewRequest mocks base method.
+func (m *MockGitHub) NewRequest(method, url string, body any) (*http.Request, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ClosePullRequest", ctx, owner, repo, number)
-	ret0, _ := ret[0].(*github.PullRequest)
+	ret := m.ctrl.Call(m, "NewRequest", method, url, body)
+	ret0, _ := ret[0].(*http.Request)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ClosePullRequest indicates an expected call of ClosePullRequest.
-func (mr *MockGitHubMockRecorder) ClosePullRequest(ctx, owner, repo, number any) *gomock.Call {
+// NewRequest indicates an expected call of NewRequest.
+func (mr *MockGitHubMockRecorder) NewRequest(method, url, body any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClosePullRequest", reflect.TypeOf((*MockGitHub)(nil).ClosePullRequest), ctx, owner, repo, number)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewRequest", reflect.TypeOf((*MockGitHub)(nil).NewRequest), method, url, body)
 }
 
-// CloseSecurityAdvisory mocks base method.
-func (m *MockGitHub) CloseSecurityAdvisory(ctx context.Context, owner, repo, id string) error {
+// PropertiesToProtoMessage mocks base method.
+func (m *MockGitHub) PropertiesToProtoMessage(entType v10.Entity, props *properties.Properties) (protoreflect.ProtoMessage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CloseSecurityAdvisory", ctx, owner, repo, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "PropertiesToProtoMessage", entType, props)
+	ret0, _ := ret[0].(protoreflect.ProtoMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// CloseSecurityAdvisory indicates an expected call of CloseSecurityAdvisory.
-func (mr *MockGitHubMockRecorder) CloseSecurityAdvisory(ctx, owner, repo, id any) *gomock.Call {
+// PropertiesToProtoMessage indicates an expected call of PropertiesToProtoMessage.
+func (mr *MockGitHubMockRecorder) PropertiesToProtoMessage(entType, props any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSecurityAdvisory", reflect.TypeOf((*MockGitHub)(nil).CloseSecurityAdvisory), ctx, owner, repo, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PropertiesToProtoMessage", reflect.TypeOf((*MockGitHub)(nil).PropertiesToProtoMessage), entType, props)
 }
 
-// CreateHook mocks base method.
-func (m *MockGitHub) CreateHook(ctx context.Context, owner, repo string, hook *github.Hook) (*github.Hook, error) {
+// RegisterEntity mocks base method.
+func (m *MockGitHub) RegisterEntity(ctx context.Context, entType v10.Entity, props *properties.Properties) (*properties.Properties, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateHook", ctx, owner, repo, hook)
-	ret0, _ := ret[0].(*github.Hook)
+	ret := m.ctrl.Call(m, "RegisterEntity", ctx, entType, props)
+	ret0, _ := ret[0].(*properties.Properties)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateHook indicates an expected call of CreateHook.
-func (mr *MockGitHubMockRecorder) CreateHook(ctx, owner, repo, hook any) *gomock.Call {
+// RegisterEntity indicates an expected call of RegisterEntity.
+func (mr *MockGitHubMockRecorder) RegisterEntity(ctx, entType, props any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHook", reflect.TypeOf((*MockGitHub)(nil).CreateHook), ctx, owner, repo, hook)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterEntity", reflect.TypeOf((*MockGitHub)(nil).RegisterEntity), ctx, entType, props)
 }
 
-// CreateIssueComment mocks base method.
-func (m *MockGitHub) CreateIssueComment(ctx context.Context, owner, repo string, number int, comment string) (*github.IssueComment, error) {
+// ReregisterEntity mocks base method.
+func (m *MockGitHub) ReregisterEntity(ctx context.Context, entType v10.Entity, props *properties.Properties) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateIssueComment", ctx, owner, repo, number, comment)
-	ret0, _ := ret[0].(*github.IssueComment)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ReregisterEntity", ctx, entType, props)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// CreateIssueComment indicates an expected call of CreateIssueComment.
-func (mr *MockGitHubMockRecorder) CreateIssueComment(ctx, owner, repo, number, comment any) *gomock.Call {
+// ReregisterEntity indicates an expected call of ReregisterEntity.
+func (mr *MockGitHubMockRecorder) ReregisterEntity(ctx, entType, props any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIssueComment", reflect.TypeOf((*MockGitHub)(nil).CreateIssueComment), ctx, owner, repo, number, comment)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReregisterEntity", reflect.TypeOf((*MockGitHub)(nil).ReregisterEntity), ctx, entType, props)
 }
 
-// CreatePullRequest mocks base method.
-func (m *MockGitHub) CreatePullRequest(ctx context.Context, owner, repo, title, body, head, base string) (*github.PullRequest, error) {
+// SetCommitStatus mocks base method.
+func (m *MockGitHub) SetCommitStatus(arg0 context.Context, arg1, arg2, arg3 string, arg4 *github.RepoStatus) (*github.RepoStatus, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePullRequest", ctx, owner, repo, title, body, head, base)
-	ret0, _ := ret[0].(*github.PullRequest)
+	ret := m.ctrl.Call(m, "SetCommitStatus", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*github.RepoStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreatePullRequest indicates an expected call of CreatePullRequest.
-func (mr *MockGitHubMockRecorder) CreatePullRequest(ctx, owner, repo, title, body, head, base any) *gomock.Call {
+// SetCommitStatus indicates an expected call of SetCommitStatus.
+func (mr *MockGitHubMockRecorder) SetCommitStatus(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePullRequest", reflect.TypeOf((*MockGitHub)(nil).CreatePullRequest), ctx, owner, repo, title, body, head, base)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCommitStatus", reflect.TypeOf((*MockGitHub)(nil).SetCommitStatus), arg0, arg1, arg2, arg3, arg4)
 }
 
-// CreateReview mocks base method.
-func (m *MockGitHub) CreateReview(arg0 context.Context, arg1, arg2 string, arg3 int, arg4 *github.PullRequestReviewRequest) (*github.PullRequestReview, error) {
+// StartCheckRun mocks base method.
+func (m *MockGitHub) StartCheckRun(arg0 context.Context, arg1, arg2 string, arg3 *github.CreateCheckRunOptions) (*github.CheckRun, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateReview", arg0, arg1, arg2, arg3, arg4)
-	ret0, _ := ret[0].(*github.PullRequestReview)
+	ret := m.ctrl.Call(m, "StartCheckRun", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*github.CheckRun)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateReview indicates an expected call of CreateReview.
-func (mr *MockGitHubMockRecorder) CreateReview(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+// StartCheckRun indicates an expected call of StartCheckRun.
+func (mr *MockGitHubMockRecorder) StartCheckRun(arg0, arg1, arg2, arg3 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReview", reflect.TypeOf((*MockGitHub)(nil).CreateReview), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartCheckRun", reflect.TypeOf((*MockGitHub)(nil).StartCheckRun), arg0, arg1, arg2, arg3)
 }
 
-// CreateSecurityAdvisory mocks base method.
-func (m *MockGitHub) CreateSecurityAdvisory(ctx context.Context, owner, repo, severity, summary, description string, v []*github.AdvisoryVulnerability) (string, error) {
+// SubmitPullRequestReview mocks base method.
+func (m *MockGitHub) SubmitPullRequestReview(ctx context.Context, pr *v11.PullRequestReference, review *v11.PullRequestReview) (*v11.PullRequestReview, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSecurityAdvisory", ctx, owner, repo, severity, summary, description, v)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "SubmitPullRequestReview", ctx, pr, review)
+	ret0, _ := ret[0].(*v11.PullRequestReview)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateSecurityAdvisory indicates an expected call of CreateSecurityAdvisory.
-func (mr *MockGitHubMockRecorder) CreateSecurityAdvisory(ctx, owner, repo, severity, summary, description, v any) *gomock.Call {
+// SubmitPullRequestReview indicates an expected call of SubmitPullRequestReview.
+func (mr *MockGitHubMockRecorder) SubmitPullRequestReview(ctx, pr, review any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecurityAdvisory", reflect.TypeOf((*MockGitHub)(nil).CreateSecurityAdvisory), ctx, owner, repo, severity, summary, description, v)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitPullRequestReview", reflect.TypeOf((*MockGitHub)(nil).SubmitPullRequestReview), ctx, pr, review)
 }
 
-// DeleteHook mocks base method.
-func (m *MockGitHub) DeleteHook(ctx context.Context, owner, repo string, id int64) error {
+// SupportsEntity mocks base method.
+func (m *MockGitHub) SupportsEntity(entType v10.Entity) bool {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteHook", ctx, owner, repo, id)
-	ret0, _ := ret[0].(error)
+	ret := m.ctrl.Call(m, "SupportsEntity", entType)
+	ret0, _ := ret[0].(bool)
 	return ret0
 }
 
-// DeleteHook indicates an expected call of DeleteHook.
-func (mr *MockGitHubMockRecorder) DeleteHook(ctx, owner, repo, id any) *gomock.Call {
+// SupportsEntity indicates an expected call of SupportsEntity.
+func (mr *MockGitHubMockRecorder) SupportsEntity(entType any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHook", reflect.TypeOf((*MockGitHub)(nil).DeleteHook), ctx, owner, repo, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SupportsEntity", reflect.TypeOf((*MockGitHub)(nil).SupportsEntity), entType)
 }
 
-// DeregisterEntity mocks base method.
-func (m *MockGitHub) DeregisterEntity(ctx context.Context, entType v10.Entity, props *properties.Properties) error {
+// UpdateBranchProtection mocks base method.
+func (m *MockGitHub) UpdateBranchProtection(arg0 context.Context, arg1, arg2, arg3 string, arg4 *github.ProtectionRequest) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeregisterEntity", ctx, entType, props)
+	ret := m.ctrl.Call(m, "UpdateBranchProtection", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeregisterEntity indicates an expected call of DeregisterEntity.
-func (mr *MockGitHubMockRecorder) DeregisterEntity(ctx, entType, props any) *gomock.Call {
+// UpdateBranchProtection indicates an expected call of UpdateBranchProtection.
+func (mr *MockGitHubMockRecorder) UpdateBranchProtection(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeregisterEntity", reflect.TypeOf((*MockGitHub)(nil).DeregisterEntity), ctx, entType, props)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBranchProtection", reflect.TypeOf((*MockGitHub)(nil).UpdateBranchProtection), arg0, arg1, arg2, arg3, arg4)
 }
 
-// DismissReview mocks base method.
-func (m *MockGitHub) DismissReview(arg0 context.Context, arg1, arg2 string, arg3 int, arg4 int64, arg5 *github.PullRequestReviewDismissalRequest) (*github.PullRequestReview, error) {
+// UpdateChangeRequest mocks base method.
+func (m *MockGitHub) UpdateChangeRequest(ctx context.Context, repo *v10.Repository, number int, title, body string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DismissReview", arg0, arg1, arg2, arg3, arg4, arg5)
-	ret0, _ := ret[0].(*github.PullRequestReview)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateChangeRequest", ctx, repo, number, title, body)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// DismissReview indicates an expected call of DismissReview.
-func (mr *MockGitHubMockRecorder) DismissReview(arg0, arg1, arg2, arg3, arg4, arg5 any) *gomock.Call {
+// UpdateChangeRequest indicates an expected call of UpdateChangeRequest.
+func (mr *MockGitHubMockRecorder) UpdateChangeRequest(ctx, repo, number, title, body any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DismissReview", reflect.TypeOf((*MockGitHub)(nil).DismissReview), arg0, arg1, arg2, arg3, arg4, arg5)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChangeRequest", reflect.TypeOf((*MockGitHub)(nil).UpdateChangeRequest), ctx, repo, number, title, body)
 }
 
-// Do mocks base method.
-func (m *MockGitHub) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
+// UpdateCheckRun mocks base method.
+func (m *MockGitHub) UpdateCheckRun(arg0 context.Context, arg1, arg2 string, arg3 int64, arg4 *github.UpdateCheckRunOptions) (*github.CheckRun, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Do", ctx, req)
-	ret0, _ := ret[0].(*http.Response)
+	ret := m.ctrl.Call(m, "UpdateCheckRun", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*github.CheckRun)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Do indicates an expected call of Do.
-func (mr *MockGitHubMockRecorder) Do(ctx, req any) *gomock.Call {
+// UpdateCheckRun indicates an expected call of UpdateCheckRun.
+func (mr *MockGitHubMockRecorder) UpdateCheckRun(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockGitHub)(nil).Do), ctx, req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCheckRun", reflect.TypeOf((*MockGitHub)(nil).UpdateCheckRun), arg0, arg1, arg2, arg3, arg4)
 }
 
-// EditHook mocks base method.
-func (m *MockGitHub) EditHook(ctx context.Context, owner, repo string, id int64, hook *github.Hook) (*github.Hook, error) {
+// UpdateIssueComment mocks base method.
+func (m *MockGitHub) UpdateIssueComment(ctx context.Context, owner, repo string, number int64, comment string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EditHook", ctx, owner, repo, id, hook)
-	ret0, _ := ret[0].(*github.Hook)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateIssueComment", ctx, owner, repo, number, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// EditHook indicates an expected call of EditHook.
-func (mr *MockGitHubMockRecorder) EditHook(ctx, owner, repo, id, hook any) *gomock.Call {
+// UpdateIssueComment indicates an expected call of UpdateIssueComment.
+func (mr *MockGitHubMockRecorder) UpdateIssueComment(ctx, owner, repo, number, comment any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditHook", reflect.TypeOf((*MockGitHub)(nil).EditHook), ctx, owner, repo, id, hook)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIssueComment", reflect.TypeOf((*MockGitHub)(nil).UpdateIssueComment), ctx, owner, repo, number, comment)
 }
 
-// FetchAllProperties mocks base method.
-func (m *MockGitHub) FetchAllProperties(ctx context.Context, getByProps *properties.Properties, entType v10.Entity, cachedProps *properties.Properties) (*properties.Properties, error) {
+// UpdatePullRequestComment mocks base method.
+func (m *MockGitHub) UpdatePullRequestComment(ctx context.Context, pr *v11.PullRequestReference, commentID, body string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FetchAllProperties", ctx, getByProps, entType, cachedProps)
-	ret0, _ := ret[0].(*properties.Properties)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdatePullRequestComment", ctx, pr, commentID, body)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// FetchAllProperties indicates an expected call of FetchAllProperties.
-func (mr *MockGitHubMockRecorder) FetchAllProperties(ctx, getByProps, entType, cachedProps any) *gomock.Call {
+// UpdatePullRequestComment indicates an expected call of UpdatePullRequestComment.
+func (mr *MockGitHubMockRecorder) UpdatePullRequestComment(ctx, pr, commentID, body any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAllProperties", reflect.TypeOf((*MockGitHub)(nil).FetchAllProperties), ctx, getByProps, entType, cachedProps)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePullRequestComment", reflect.TypeOf((*MockGitHub)(nil).UpdatePullRequestComment), ctx, pr, commentID, body)
 }
 
-// FetchProperty mocks base method.
-func (m *MockGitHub) FetchProperty(ctx context.Context, getByProps *properties.Properties, entType v10.Entity, key string) (*properties.Property, error) {
+// UpdateReview mocks base method.
+func (m *MockGitHub) UpdateReview(arg0 context.Context, arg1, arg2 string, arg3 int, arg4 int64, arg5 string) (*github.PullRequestReview, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FetchProperty", ctx, getByProps, entType, key)
-	ret0, _ := ret[0].(*properties.Property)
+	ret := m.ctrl.Call(m, "UpdateReview", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(*github.PullRequestReview)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FetchProperty indicates an expected call of FetchProperty.
-func (mr *MockGitHubMockRecorder) FetchProperty(ctx, getByProps, entType, key any) *gomock.Call {
+// UpdateReview indicates an expected call of UpdateReview.
+func (mr *MockGitHubMockRecorder) UpdateReview(arg0, arg1, arg2, arg3, arg4, arg5 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchProperty", reflect.TypeOf((*MockGitHub)(nil).FetchProperty), ctx, getByProps, entType, key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReview", reflect.TypeOf((*MockGitHub)(nil).UpdateReview), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
-// GetArtifactVersions mocks base method.
-func (m *MockGitHub) GetArtifactVersions(ctx context.Context, artifact *v10.Artifact, filter v11.GetArtifactVersionsFilter) ([]*v10.ArtifactVersion, error) {
+// UpdateRuleset mocks base method.
+func (m *MockGitHub) UpdateRuleset(ctx context.Context, owner, repo string, id int64, ruleset *github.Ruleset) (*github.Ruleset, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetArtifactVersions", ctx, artifact, filter)
-	ret0, _ := ret[0].([]*v10.ArtifactVersion)
+	ret := m.ctrl.Call(m, "UpdateRuleset", ctx, owner, repo, id, ruleset)
+	ret0, _ := ret[0].(*github.Ruleset)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetArtifactVersions indicates an expected call of GetArtifactVersions.
-func (mr *MockGitHubMockRecorder) GetArtifactVersions(ctx, artifact, filter any) *gomock.Call {
+// UpdateRuleset indicates an expected call of UpdateRuleset.
+func (mr *MockGitHubMockRecorder) UpdateRuleset(ctx, owner, repo, id, ruleset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArtifactVersions", reflect.TypeOf((*MockGitHub)(nil).GetArtifactVersions), ctx, artifact, filter)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRuleset", reflect.TypeOf((*MockGitHub)(nil).UpdateRuleset), ctx, owner, repo, id, ruleset)
 }
 
-// GetBaseURL mocks base method.
-func (m *MockGitHub) GetBaseURL() string {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBaseURL")
-	ret0, _ := ret[0].(string)
-	return ret0
+// MockGitLab is a mock of GitLab interface.
+type MockGitLab struct {
+	ctrl     *gomock.Controller
+	recorder *MockGitLabMockRecorder
+	isgomock struct{}
 }
 
-// GetBaseURL indicates an expected call of GetBaseURL.
-func (mr *MockGitHubMockRecorder) GetBaseURL() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBaseURL", reflect.TypeOf((*MockGitHub)(nil).GetBaseURL))
+// MockGitLabMockRecorder is the mock recorder for MockGitLab.
+type MockGitLabMockRecorder struct {
+	mock *MockGitLab
 }
 
-// GetBranchProtection mocks base method.
-func (m *MockGitHub) GetBranchProtection(arg0 context.Context, arg1, arg2, arg3 string) (*github.Protection, error) {
+// NewMockGitLab creates a new mock instance.
+func NewMockGitLab(ctrl *gomock.Controller) *MockGitLab {
+	mock := &MockGitLab{ctrl: ctrl}
+	mock.recorder = &MockGitLabMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGitLab) EXPECT() *MockGitLabMockRecorder {
+	return m.recorder
+}
+
+// AddAuthToPushOptions mocks base method.
+func (m *MockGitLab) AddAuthToPushOptions(ctx context.Context, options *git.PushOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBranchProtection", arg0, arg1, arg2, arg3)
-	ret0, _ := ret[0].(*github.Protection)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "AddAuthToPushOptions", ctx, options)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetBranchProtection indicates an expected call of GetBranchProtection.
-func (mr *MockGitHubMockRecorder) GetBranchProtection(arg0, arg1, arg2, arg3 any) *gomock.Call {
+// AddAuthToPushOptions indicates an expected call of AddAuthToPushOptions.
+func (mr *MockGitLabMockRecorder) AddAuthToPushOptions(ctx, options any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBranchProtection", reflect.TypeOf((*MockGitHub)(nil).GetBranchProtection), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAuthToPushOptions", reflect.TypeOf((*MockGitLab)(nil).AddAuthToPushOptions), ctx, options)
 }
 
-// GetCredential mocks base method.
-func (m *MockGitHub) GetCredential() v11.GitHubCredential {
+// CanImplement mocks base method.
+func (m *MockGitLab) CanImplement(trait v10.ProviderType) bool {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCredential")
-	ret0, _ := ret[0].(v11.GitHubCredential)
+	ret := m.ctrl.Call(m, "CanImplement", trait)
+	ret0, _ := ret[0].(bool)
 	return ret0
 }
 
-// GetCredential indicates an expected call of GetCredential.
-func (mr *MockGitHubMockRecorder) GetCredential() *gomock.Call {
+// CanImplement indicates an expected call of CanImplement.
+func (mr *MockGitLabMockRecorder) CanImplement(trait any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredential", reflect.TypeOf((*MockGitHub)(nil).GetCredential))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanImplement", reflect.TypeOf((*MockGitLab)(nil).CanImplement), trait)
 }
-
-// GetEntityName mocks base method.
-func (m *MockGitHub) GetEntityName(entType v10.Entity, props *properties.Properties) (string, error) {
+
+// Clone mocks base method.
+func (m *MockGitLab) Clone(ctx context.Context, url, branch string) (*git.Repository, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEntityName", entType, props)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "Clone", ctx, url, branch)
+	ret0, _ := ret[0].(*git.Repository)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetEntityName indicates an expected call of GetEntityName.
-func (mr *MockGitHubMockRecorder) GetEntityName(entType, props any) *gomock.Call {
+// Clone indicates an expected call of Clone.
+func (mr *MockGitLabMockRecorder) Clone(ctx, url, branch any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntityName", reflect.TypeOf((*MockGitHub)(nil).GetEntityName), entType, props)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clone", reflect.TypeOf((*MockGitLab)(nil).Clone), ctx, url, branch)
 }
 
-// GetLogin mocks base method.
-func (m *MockGitHub) GetLogin(ctx context.Context) (string, error) {
+// CloseChangeRequest mocks base method.
+func (m *MockGitLab) CloseChangeRequest(ctx context.Context, repo *v10.Repository, number int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLogin", ctx)
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "CloseChangeRequest", ctx, repo, number)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetLogin indicates an expected call of GetLogin.
-func (mr *MockGitHubMockRecorder) GetLogin(ctx any) *gomock.Call {
+// CloseChangeRequest indicates an expected call of CloseChangeRequest.
+func (mr *MockGitLabMockRecorder) CloseChangeRequest(ctx, repo, number any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogin", reflect.TypeOf((*MockGitHub)(nil).GetLogin), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseChangeRequest", reflect.TypeOf((*MockGitLab)(nil).CloseChangeRequest), ctx, repo, number)
 }
 
-// GetName mocks base method.
-func (m *MockGitHub) GetName(ctx context.Context) (string, error) {
+// CreateChangeRequest mocks base method.
+func (m *MockGitLab) CreateChangeRequest(ctx context.Context, repo *v10.Repository, title, body, head, base string) (*v11.ChangeRequest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetName", ctx)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "CreateChangeRequest", ctx, repo, title, body, head, base)
+	ret0, _ := ret[0].(*v11.ChangeRequest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetName indicates an expected call of GetName.
-func (mr *MockGitHubMockRecorder) GetName(ctx any) *gomock.Call {
+// CreateChangeRequest indicates an expected call of CreateChangeRequest.
+func (mr *MockGitLabMockRecorder) CreateChangeRequest(ctx, repo, title, body, head, base any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetName", reflect.TypeOf((*MockGitHub)(nil).GetName), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChangeRequest", reflect.TypeOf((*MockGitLab)(nil).CreateChangeRequest), ctx, repo, title, body, head, base)
 }
 
-// GetNamespaceURL mocks base method.
-func (m *MockGitHub) GetNamespaceURL() string {
+// CreatePullRequestComment mocks base method.
+func (m *MockGitLab) CreatePullRequestComment(ctx context.Context, pr *v11.PullRequestReference, body string) (*v11.PullRequestComment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetNamespaceURL")
-	ret0, _ := ret[0].(string)
-	return ret0
+	ret := m.ctrl.Call(m, "CreatePullRequestComment", ctx, pr, body)
+	ret0, _ := ret[0].(*v11.PullRequestComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// GetNamespaceURL indicates an expected call of GetNamespaceURL.
-func (mr *MockGitHubMockRecorder) GetNamespaceURL() *gomock.Call {
+// CreatePullRequestComment indicates an expected call of CreatePullRequestComment.
+func (mr *MockGitLabMockRecorder) CreatePullRequestComment(ctx, pr, body any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNamespaceURL", reflect.TypeOf((*MockGitHub)(nil).GetNamespaceURL))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePullRequestComment", reflect.TypeOf((*MockGitLab)(nil).CreatePullRequestComment), ctx, pr, body)
 }
 
-// GetPackageByName mocks base method.
-func (m *MockGitHub) GetPackageByName(arg0 context.Context, arg1, arg2, arg3 string) (*github.Package, error) {
+// DeregisterEntity mocks base method.
+func (m *MockGitLab) DeregisterEntity(ctx context.Context, entType v10.Entity, props *properties.Properties) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPackageByName", arg0, arg1, arg2, arg3)
-	ret0, _ := ret[0].(*github.Package)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "DeregisterEntity", ctx, entType, props)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetPackageByName indicates an expected call of GetPackageByName.
-func (mr *MockGitHubMockRecorder) GetPackageByName(arg0, arg1, arg2, arg3 any) *gomock.Call {
+// DeregisterEntity indicates an expected call of DeregisterEntity.
+func (mr *MockGitLabMockRecorder) DeregisterEntity(ctx, entType, props any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPackageByName", reflect.TypeOf((*MockGitHub)(nil).GetPackageByName), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeregisterEntity", reflect.TypeOf((*MockGitLab)(nil).DeregisterEntity), ctx, entType, props)
 }
 
-// GetPackageVersionById mocks base method.
-func (m *MockGitHub) GetPackageVersionById(arg0 context.Context, arg1, arg2, arg3 string, arg4 int64) (*github.PackageVersion, error) {
+// DismissPullRequestReview mocks base method.
+func (m *MockGitLab) DismissPullRequestReview(ctx context.Context, pr *v11.PullRequestReference, reviewID, message string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPackageVersionById", arg0, arg1, arg2, arg3, arg4)
-	ret0, _ := ret[0].(*github.PackageVersion)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "DismissPullRequestReview", ctx, pr, reviewID, message)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetPackageVersionById indicates an expected call of GetPackageVersionById.
-func (mr *MockGitHubMockRecorder) GetPackageVersionById(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+// DismissPullRequestReview indicates an expected call of DismissPullRequestReview.
+func (mr *MockGitLabMockRecorder) DismissPullRequestReview(ctx, pr, reviewID, message any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPackageVersionById", reflect.TypeOf((*MockGitHub)(nil).GetPackageVersionById), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DismissPullRequestReview", reflect.TypeOf((*MockGitLab)(nil).DismissPullRequestReview), ctx, pr, reviewID, message)
 }
 
-// GetPrimaryEmail mocks base method.
-func (m *MockGitHub) GetPrimaryEmail(ctx context.Context) (string, error) {
+// Do mocks base method.
+func (m *MockGitLab) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPrimaryEmail", ctx)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "Do", ctx, req)
+	ret0, _ := ret[0].(*http.Response)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetPrimaryEmail indicates an expected call of GetPrimaryEmail.
-func (mr *MockGitHubMockRecorder) GetPrimaryEmail(ctx any) *gomock.Call {
+// Do indicates an expected call of Do.
+func (mr *MockGitLabMockRecorder) Do(ctx, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrimaryEmail", reflect.TypeOf((*MockGitHub)(nil).GetPrimaryEmail), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockGitLab)(nil).Do), ctx, req)
 }
 
-// GetPullRequest mocks base method.
-func (m *MockGitHub) GetPullRequest(arg0 context.Context, arg1, arg2 string, arg3 int) (*github.PullRequest, error) {
+// FetchAllProperties mocks base method.
+func (m *MockGitLab) FetchAllProperties(ctx context.Context, getByProps *properties.Properties, entType v10.Entity, cachedProps *properties.Properties) (*properties.Properties, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPullRequest", arg0, arg1, arg2, arg3)
-	ret0, _ := ret[0].(*github.PullRequest)
+	ret := m.ctrl.Call(m, "FetchAllProperties", ctx, getByProps, entType, cachedProps)
+	ret0, _ := ret[0].(*properties.Properties)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetPullRequest indicates an expected call of GetPullRequest.
-func (mr *MockGitHubMockRecorder) GetPullRequest(arg0, arg1, arg2, arg3 any) *gomock.Call {
+// FetchAllProperties indicates an expected call of FetchAllProperties.
+func (mr *MockGitLabMockRecorder) FetchAllProperties(ctx, getByProps, entType, cachedProps any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullRequest", reflect.TypeOf((*MockGitHub)(nil).GetPullRequest), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAllProperties", reflect.TypeOf((*MockGitLab)(nil).FetchAllProperties), ctx, getByProps, entType, cachedProps)
 }
 
-// GetRepository mocks base method.
-func (m *MockGitHub) GetRepository(arg0 context.Context, arg1, arg2 string) (*github.Repository, error) {
+// FetchProperty mocks base method.
+func (m *MockGitLab) FetchProperty(ctx context.Context, getByProps *properties.Properties, entType v10.Entity, key string) (*properties.Property, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRepository", arg0, arg1, arg2)
-	ret0, _ := ret[0].(*github.Repository)
+	ret := m.ctrl.Call(m, "FetchProperty", ctx, getByProps, entType, key)
+	ret0, _ := ret[0].(*properties.Property)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetRepository indicates an expected call of GetRepository.
-func (mr *MockGitHubMockRecorder) GetRepository(arg0, arg1, arg2 any) *gomock.Call {
+// FetchProperty indicates an expected call of FetchProperty.
+func (mr *MockGitLabMockRecorder) FetchProperty(ctx, getByProps, entType, key any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepository", reflect.TypeOf((*MockGitHub)(nil).GetRepository), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchProperty", reflect.TypeOf((*MockGitLab)(nil).FetchProperty), ctx, getByProps, entType, key)
 }
 
-// GetUserId mocks base method.
-func (m *MockGitHub) GetUserId(ctx context.Context) (int64, error) {
+// FindChangeRequest mocks base method.
+func (m *MockGitLab) FindChangeRequest(ctx context.Context, repo *v10.Repository, head string) (*v11.ChangeRequest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserId", ctx)
-	ret0, _ := ret[0].(int64)
+	ret := m.ctrl.Call(m, "FindChangeRequest", ctx, repo, head)
+	ret0, _ := ret[0].(*v11.ChangeRequest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserId indicates an expected call of GetUserId.
-func (mr *MockGitHubMockRecorder) GetUserId(ctx any) *gomock.Call {
+// FindChangeRequest indicates an expected call of FindChangeRequest.
+func (mr *MockGitLabMockRecorder) FindChangeRequest(ctx, repo, head any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserId", reflect.TypeOf((*MockGitHub)(nil).GetUserId), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindChangeRequest", reflect.TypeOf((*MockGitLab)(nil).FindChangeRequest), ctx, repo, head)
 }
 
-// IsOrg mocks base method.
-func (m *MockGitHub) IsOrg() bool {
+// GetBaseURL mocks base method.
+func (m *MockGitLab) GetBaseURL() string {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsOrg")
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "GetBaseURL")
+	ret0, _ := ret[0].(string)
 	return ret0
 }
 
-// IsOrg indicates an expected call of IsOrg.
-func (mr *MockGitHubMockRecorder) IsOrg() *gomock.Call {
+// GetBaseURL indicates an expected call of GetBaseURL.
+func (mr *MockGitLabMockRecorder) GetBaseURL() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsOrg", reflect.TypeOf((*MockGitHub)(nil).IsOrg))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBaseURL", reflect.TypeOf((*MockGitLab)(nil).GetBaseURL))
 }
 
-// ListAllRepositories mocks base method.
-func (m *MockGitHub) ListAllRepositories(arg0 context.Context) ([]*v10.Repository, error) {
+// GetBranchProtection mocks base method.
+func (m *MockGitLab) GetBranchProtection(ctx context.Context, repo *v10.Repository, branch string) (*v11.GitLabBranchProtection, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAllRepositories", arg0)
-	ret0, _ := ret[0].([]*v10.Repository)
+	ret := m.ctrl.Call(m, "GetBranchProtection", ctx, repo, branch)
+	ret0, _ := ret[0].(*v11.GitLabBranchProtection)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListAllRepositories indicates an expected call of ListAllRepositories.
-func (mr *MockGitHubMockRecorder) ListAllRepositories(arg0 any) *gomock.Call {
+// GetBranchProtection indicates an expected call of GetBranchProtection.
+func (mr *MockGitLabMockRecorder) GetBranchProtection(ctx, repo, branch any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllRepositories", reflect.TypeOf((*MockGitHub)(nil).ListAllRepositories), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBranchProtection", reflect.TypeOf((*MockGitLab)(nil).GetBranchProtection), ctx, repo, branch)
 }
 
-// ListFiles mocks base method.
-func (m *MockGitHub) ListFiles(ctx context.Context, owner, repo string, prNumber, perPage, pageNumber int) ([]*github.CommitFile, *github.Response, error) {
+// GetCommitAuthor mocks base method.
+func (m *MockGitLab) GetCommitAuthor(ctx context.Context) (string, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListFiles", ctx, owner, repo, prNumber, perPage, pageNumber)
-	ret0, _ := ret[0].([]*github.CommitFile)
-	ret1, _ := ret[1].(*github.Response)
+	ret := m.ctrl.Call(m, "GetCommitAuthor", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
-// ListFiles indicates an expected call of ListFiles.
-func (mr *MockGitHubMockRecorder) ListFiles(ctx, owner, repo, prNumber, perPage, pageNumber any) *gomock.Call {
+// GetCommitAuthor indicates an expected call of GetCommitAuthor.
+func (mr *MockGitLabMockRecorder) GetCommitAuthor(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFiles", reflect.TypeOf((*MockGitHub)(nil).ListFiles), ctx, owner, repo, prNumber, perPage, pageNumber)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommitAuthor", reflect.TypeOf((*MockGitLab)(nil).GetCommitAuthor), ctx)
 }
 
-// ListHooks mocks base method.
-func (m *MockGitHub) ListHooks(ctx context.Context, owner, repo string) ([]*github.Hook, error) {
+// GetEntityName mocks base method.
+func (m *MockGitLab) GetEntityName(entType v10.Entity, props *properties.Properties) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListHooks", ctx, owner, repo)
-	ret0, _ := ret[0].([]*github.Hook)
+	ret := m.ctrl.Call(m, "GetEntityName", entType, props)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListHooks indicates an expected call of ListHooks.
-func (mr *MockGitHubMockRecorder) ListHooks(ctx, owner, repo any) *gomock.Call {
+// GetEntityName indicates an expected call of GetEntityName.
+func (mr *MockGitLabMockRecorder) GetEntityName(entType, props any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHooks", reflect.TypeOf((*MockGitHub)(nil).ListHooks), ctx, owner, repo)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntityName", reflect.TypeOf((*MockGitLab)(nil).GetEntityName), entType, props)
 }
 
-// ListImages mocks base method.
-func (m *MockGitHub) ListImages(ctx context.Context) ([]string, error) {
+// GetReviewerID mocks base method.
+func (m *MockGitLab) GetReviewerID(ctx context.Context) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListImages", ctx)
-	ret0, _ := ret[0].([]string)
+	ret := m.ctrl.Call(m, "GetReviewerID", ctx)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListImages indicates an expected call of ListImages.
-func (mr *MockGitHubMockRecorder) ListImages(ctx any) *gomock.Call {
+// GetReviewerID indicates an expected call of GetReviewerID.
+func (mr *MockGitLabMockRecorder) GetReviewerID(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImages", reflect.TypeOf((*MockGitHub)(nil).ListImages), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewerID", reflect.TypeOf((*MockGitLab)(nil).GetReviewerID), ctx)
 }
 
-// ListIssueComments mocks base method.
-func (m *MockGitHub) ListIssueComments(ctx context.Context, owner, repo string, number int, opts *github.IssueListCommentsOptions) ([]*github.IssueComment, error) {
+// ListAllRepositories mocks base method.
+func (m *MockGitLab) ListAllRepositories(arg0 context.Context) ([]*v10.Repository, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListIssueComments", ctx, owner, repo, number, opts)
-	ret0, _ := ret[0].([]*github.IssueComment)
+	ret := m.ctrl.Call(m, "ListAllRepositories", arg0)
+	ret0, _ := ret[0].([]*v10.Repository)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListIssueComments indicates an expected call of ListIssueComments.
-func (mr *MockGitHubMockRecorder) ListIssueComments(ctx, owner, repo, number, opts any) *gomock.Call {
+// ListAllRepositories indicates an expected call of ListAllRepositories.
+func (mr *MockGitLabMockRecorder) ListAllRepositories(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIssueComments", reflect.TypeOf((*MockGitHub)(nil).ListIssueComments), ctx, owner, repo, number, opts)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllRepositories", reflect.TypeOf((*MockGitLab)(nil).ListAllRepositories), arg0)
 }
 
-// ListPackagesByRepository mocks base method.
-func (m *MockGitHub) ListPackagesByRepository(arg0 context.Context, arg1, arg2 string, arg3 int64, arg4, arg5 int) ([]*github.Package, error) {
+// ListBranchProtections mocks base method.
+func (m *MockGitLab) ListBranchProtections(ctx context.Context, repo *v10.Repository) (map[string]*v11.GitLabBranchProtection, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPackagesByRepository", arg0, arg1, arg2, arg3, arg4, arg5)
-	ret0, _ := ret[0].([]*github.Package)
+	ret := m.ctrl.Call(m, "ListBranchProtections", ctx, repo)
+	ret0, _ := ret[0].(map[string]*v11.GitLabBranchProtection)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListPackagesByRepository indicates an expected call of ListPackagesByRepository.
-func (mr *MockGitHubMockRecorder) ListPackagesByRepository(arg0, arg1, arg2, arg3, arg4, arg5 any) *gomock.Call {
+// ListBranchProtections indicates an expected call of ListBranchProtections.
+func (mr *MockGitLabMockRecorder) ListBranchProtections(ctx, repo any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPackagesByRepository", reflect.TypeOf((*MockGitHub)(nil).ListPackagesByRepository), arg0, arg1, arg2, arg3, arg4, arg5)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBranchProtections", reflect.TypeOf((*MockGitLab)(nil).ListBranchProtections), ctx, repo)
 }
 
-// ListPullRequests mocks base method.
-func (m *MockGitHub) ListPullRequests(ctx context.Context, owner, repo string, opt *github.PullRequestListOptions) ([]*github.PullRequest, error) {
+// ListPullRequestComments mocks base method.
+func (m *MockGitLab) ListPullRequestComments(ctx context.Context, pr *v11.PullRequestReference) ([]*v11.PullRequestComment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPullRequests", ctx, owner, repo, opt)
-	ret0, _ := ret[0].([]*github.PullRequest)
+	ret := m.ctrl.Call(m, "ListPullRequestComments", ctx, pr)
+	ret0, _ := ret[0].([]*v11.PullRequestComment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListPullRequests indicates an expected call of ListPullRequests.
-func (mr *MockGitHubMockRecorder) ListPullRequests(ctx, owner, repo, opt any) *gomock.Call {
+// ListPullRequestComments indicates an expected call of ListPullRequestComments.
+func (mr *MockGitLabMockRecorder) ListPullRequestComments(ctx, pr any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPullRequests", reflect.TypeOf((*MockGitHub)(nil).ListPullRequests), ctx, owner, repo, opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPullRequestComments", reflect.TypeOf((*MockGitLab)(nil).ListPullRequestComments), ctx, pr)
 }
 
-// ListReviews mocks base method.
-func (m *MockGitHub) ListReviews(arg0 context.Context, arg1, arg2 string, arg3 int, arg4 *github.ListOptions) ([]*github.PullRequestReview, error) {
+// ListPullRequestReviews mocks base method.
+func (m *MockGitLab) ListPullRequestReviews(ctx context.Context, pr *v11.PullRequestReference) ([]*v11.PullRequestReview, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListReviews", arg0, arg1, arg2, arg3, arg4)
-	ret0, _ := ret[0].([]*github.PullRequestReview)
+	ret := m.ctrl.Call(m, "ListPullRequestReviews", ctx, pr)
+	ret0, _ := ret[0].([]*v11.PullRequestReview)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListReviews indicates an expected call of ListReviews.
-func (mr *MockGitHubMockRecorder) ListReviews(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+// ListPullRequestReviews indicates an expected call of ListPullRequestReviews.
+func (mr *MockGitLabMockRecorder) ListPullRequestReviews(ctx, pr any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReviews", reflect.TypeOf((*MockGitHub)(nil).ListReviews), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPullRequestReviews", reflect.TypeOf((*MockGitLab)(nil).ListPullRequestReviews), ctx, pr)
 }
 
 // NewRequest mocks base method.
-func (m *MockGitHub) NewRequest(method, url string, body any) (*http.Request, error) {
+func (m *MockGitLab) NewRequest(method, url string, body any) (*http.Request, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "NewRequest", method, url, body)
 	ret0, _ := ret[0].(*http.Request)
@@ -1423,13 +2671,13 @@ func (m *MockGitHub) NewRequest(method, url string, body any) (*http.Request, er
 }
 
 // NewRequest indicates an expected call of NewRequest.
-func (mr *MockGitHubMockRecorder) NewRequest(method, url, body any) *gomock.Call {
+func (mr *MockGitLabMockRecorder) NewRequest(method, url, body any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewRequest", reflect.TypeOf((*MockGitHub)(nil).NewRequest), method, url, body)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewRequest", reflect.TypeOf((*MockGitLab)(nil).NewRequest), method, url, body)
 }
 
 // PropertiesToProtoMessage mocks base method.
-func (m *MockGitHub) PropertiesToProtoMessage(entType v10.Entity, props *properties.Properties) (protoreflect.ProtoMessage, error) {
+func (m *MockGitLab) PropertiesToProtoMessage(entType v10.Entity, props *properties.Properties) (protoreflect.ProtoMessage, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "PropertiesToProtoMessage", entType, props)
 	ret0, _ := ret[0].(protoreflect.ProtoMessage)
@@ -1438,13 +2686,13 @@ func (m *MockGitHub) PropertiesToProtoMessage(entType v10.Entity, props *propert
 }
 
 // PropertiesToProtoMessage indicates an expected call of PropertiesToProtoMessage.
-func (mr *MockGitHubMockRecorder) PropertiesToProtoMessage(entType, props any) *gomock.Call {
+func (mr *MockGitLabMockRecorder) PropertiesToProtoMessage(entType, props any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PropertiesToProtoMessage", reflect.TypeOf((*MockGitHub)(nil).PropertiesToProtoMessage), entType, props)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PropertiesToProtoMessage", reflect.TypeOf((*MockGitLab)(nil).PropertiesToProtoMessage), entType, props)
 }
 
 // RegisterEntity mocks base method.
-func (m *MockGitHub) RegisterEntity(ctx context.Context, entType v10.Entity, props *properties.Properties) (*properties.Properties, error) {
+func (m *MockGitLab) RegisterEntity(ctx context.Context, entType v10.Entity, props *properties.Properties) (*properties.Properties, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "RegisterEntity", ctx, entType, props)
 	ret0, _ := ret[0].(*properties.Properties)
@@ -1453,13 +2701,13 @@ func (m *MockGitHub) RegisterEntity(ctx context.Context, entType v10.Entity, pro
 }
 
 // RegisterEntity indicates an expected call of RegisterEntity.
-func (mr *MockGitHubMockRecorder) RegisterEntity(ctx, entType, props any) *gomock.Call {
+func (mr *MockGitLabMockRecorder) RegisterEntity(ctx, entType, props any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterEntity", reflect.TypeOf((*MockGitHub)(nil).RegisterEntity), ctx, entType, props)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterEntity", reflect.TypeOf((*MockGitLab)(nil).RegisterEntity), ctx, entType, props)
 }
 
 // ReregisterEntity mocks base method.
-func (m *MockGitHub) ReregisterEntity(ctx context.Context, entType v10.Entity, props *properties.Properties) error {
+func (m *MockGitLab) ReregisterEntity(ctx context.Context, entType v10.Entity, props *properties.Properties) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ReregisterEntity", ctx, entType, props)
 	ret0, _ := ret[0].(error)
@@ -1467,43 +2715,28 @@ func (m *MockGitHub) ReregisterEntity(ctx context.Context, entType v10.Entity, p
 }
 
 // ReregisterEntity indicates an expected call of ReregisterEntity.
-func (mr *MockGitHubMockRecorder) ReregisterEntity(ctx, entType, props any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReregisterEntity", reflect.TypeOf((*MockGitHub)(nil).ReregisterEntity), ctx, entType, props)
-}
-
-// SetCommitStatus mocks base method.
-func (m *MockGitHub) SetCommitStatus(arg0 context.Context, arg1, arg2, arg3 string, arg4 *github.RepoStatus) (*github.RepoStatus, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetCommitStatus", arg0, arg1, arg2, arg3, arg4)
-	ret0, _ := ret[0].(*github.RepoStatus)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// SetCommitStatus indicates an expected call of SetCommitStatus.
-func (mr *MockGitHubMockRecorder) SetCommitStatus(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+func (mr *MockGitLabMockRecorder) ReregisterEntity(ctx, entType, props any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCommitStatus", reflect.TypeOf((*MockGitHub)(nil).SetCommitStatus), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReregisterEntity", reflect.TypeOf((*MockGitLab)(nil).ReregisterEntity), ctx, entType, props)
 }
 
-// StartCheckRun mocks base method.
-func (m *MockGitHub) StartCheckRun(arg0 context.Context, arg1, arg2 string, arg3 *github.CreateCheckRunOptions) (*github.CheckRun, error) {
+// SubmitPullRequestReview mocks base method.
+func (m *MockGitLab) SubmitPullRequestReview(ctx context.Context, pr *v11.PullRequestReference, review *v11.PullRequestReview) (*v11.PullRequestReview, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "StartCheckRun", arg0, arg1, arg2, arg3)
-	ret0, _ := ret[0].(*github.CheckRun)
+	ret := m.ctrl.Call(m, "SubmitPullRequestReview", ctx, pr, review)
+	ret0, _ := ret[0].(*v11.PullRequestReview)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// StartCheckRun indicates an expected call of StartCheckRun.
-func (mr *MockGitHubMockRecorder) StartCheckRun(arg0, arg1, arg2, arg3 any) *gomock.Call {
+// SubmitPullRequestReview indicates an expected call of SubmitPullRequestReview.
+func (mr *MockGitLabMockRecorder) SubmitPullRequestReview(ctx, pr, review any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartCheckRun", reflect.TypeOf((*MockGitHub)(nil).StartCheckRun), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitPullRequestReview", reflect.TypeOf((*MockGitLab)(nil).SubmitPullRequestReview), ctx, pr, review)
 }
 
 // SupportsEntity mocks base method.
-func (m *MockGitHub) SupportsEntity(entType v10.Entity) bool {
+func (m *MockGitLab) SupportsEntity(entType v10.Entity) bool {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SupportsEntity", entType)
 	ret0, _ := ret[0].(bool)
@@ -1511,67 +2744,51 @@ func (m *MockGitHub) SupportsEntity(entType v10.Entity) bool {
 }
 
 // SupportsEntity indicates an expected call of SupportsEntity.
-func (mr *MockGitHubMockRecorder) SupportsEntity(entType any) *gomock.Call {
+func (mr *MockGitLabMockRecorder) SupportsEntity(entType any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SupportsEntity", reflect.TypeOf((*MockGitHub)(nil).SupportsEntity), entType)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SupportsEntity", reflect.TypeOf((*MockGitLab)(nil).SupportsEntity), entType)
 }
 
 // UpdateBranchProtection mocks base method.
-func (m *MockGitHub) UpdateBranchProtection(arg0 context.Context, arg1, arg2, arg3 string, arg4 *github.ProtectionRequest) error {
+func (m *MockGitLab) UpdateBranchProtection(ctx context.Context, repo *v10.Repository, branch string, protection *v11.GitLabBranchProtection) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateBranchProtection", arg0, arg1, arg2, arg3, arg4)
+	ret := m.ctrl.Call(m, "UpdateBranchProtection", ctx, repo, branch, protection)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateBranchProtection indicates an expected call of UpdateBranchProtection.
-func (mr *MockGitHubMockRecorder) UpdateBranchProtection(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBranchProtection", reflect.TypeOf((*MockGitHub)(nil).UpdateBranchProtection), arg0, arg1, arg2, arg3, arg4)
-}
-
-// UpdateCheckRun mocks base method.
-func (m *MockGitHub) UpdateCheckRun(arg0 context.Context, arg1, arg2 string, arg3 int64, arg4 *github.UpdateCheckRunOptions) (*github.CheckRun, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCheckRun", arg0, arg1, arg2, arg3, arg4)
-	ret0, _ := ret[0].(*github.CheckRun)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// UpdateCheckRun indicates an expected call of UpdateCheckRun.
-func (mr *MockGitHubMockRecorder) UpdateCheckRun(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+func (mr *MockGitLabMockRecorder) UpdateBranchProtection(ctx, repo, branch, protection any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCheckRun", reflect.TypeOf((*MockGitHub)(nil).UpdateCheckRun), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBranchProtection", reflect.TypeOf((*MockGitLab)(nil).UpdateBranchProtection), ctx, repo, branch, protection)
 }
 
-// UpdateIssueComment mocks base method.
-func (m *MockGitHub) UpdateIssueComment(ctx context.Context, owner, repo string, number int64, comment string) error {
+// UpdateChangeRequest mocks base method.
+func (m *MockGitLab) UpdateChangeRequest(ctx context.Context, repo *v10.Repository, number int, title, body string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateIssueComment", ctx, owner, repo, number, comment)
+	ret := m.ctrl.Call(m, "UpdateChangeRequest", ctx, repo, number, title, body)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateIssueComment indicates an expected call of UpdateIssueComment.
-func (mr *MockGitHubMockRecorder) UpdateIssueComment(ctx, owner, repo, number, comment any) *gomock.Call {
+// UpdateChangeRequest indicates an expected call of UpdateChangeRequest.
+func (mr *MockGitLabMockRecorder) UpdateChangeRequest(ctx, repo, number, title, body any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIssueComment", reflect.TypeOf((*MockGitHub)(nil).UpdateIssueComment), ctx, owner, repo, number, comment)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChangeRequest", reflect.TypeOf((*MockGitLab)(nil).UpdateChangeRequest), ctx, repo, number, title, body)
 }
 
-// UpdateReview mocks base method.
-func (m *MockGitHub) UpdateReview(arg0 context.Context, arg1, arg2 string, arg3 int, arg4 int64, arg5 string) (*github.PullRequestReview, error) {
+// UpdatePullRequestComment mocks base method.
+func (m *MockGitLab) UpdatePullRequestComment(ctx context.Context, pr *v11.PullRequestReference, commentID, body string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateReview", arg0, arg1, arg2, arg3, arg4, arg5)
-	ret0, _ := ret[0].(*github.PullRequestReview)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdatePullRequestComment", ctx, pr, commentID, body)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// UpdateReview indicates an expected call of UpdateReview.
-func (mr *MockGitHubMockRecorder) UpdateReview(arg0, arg1, arg2, arg3, arg4, arg5 any) *gomock.Call {
+// UpdatePullRequestComment indicates an expected call of UpdatePullRequestComment.
+func (mr *MockGitLabMockRecorder) UpdatePullRequestComment(ctx, pr, commentID, body any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReview", reflect.TypeOf((*MockGitHub)(nil).UpdateReview), arg0, arg1, arg2, arg3, arg4, arg5)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePullRequestComment", reflect.TypeOf((*MockGitLab)(nil).UpdatePullRequestComment), ctx, pr, commentID, body)
 }
 
 // MockImageLister is a mock of ImageLister interface.
@@ -93,6 +93,137 @@ type Git interface {
 	Clone(ctx context.Context, url string, branch string) (*git.Repository, error)
 }
 
+// ChangeRequest is a request to merge a branch into another branch of a
+// repository, i.e. a GitHub pull request or a GitLab merge request
+type ChangeRequest struct {
+	// Number identifies the change request within its repository
+	Number int
+	// Title is the title of the change request
+	Title string
+	// Body is the description of the change request
+	Body string
+}
+
+// ChangeRequester is the trait interface for providers which can propose
+// changes to a repository by pushing a branch and opening a change request
+// from it.
+type ChangeRequester interface {
+	Provider
+
+	// GetCommitAuthor returns the name and e-mail address to author the
+	// commits pushed on behalf of the provider's credential
+	GetCommitAuthor(ctx context.Context) (name string, email string, err error)
+
+	// AddAuthToPushOptions adds the provider's credential to the options
+	// used to push a branch
+	AddAuthToPushOptions(ctx context.Context, options *git.PushOptions) error
+
+	// FindChangeRequest returns the open change request from the head branch
+	// of the repository, or nil if there is none
+	FindChangeRequest(ctx context.Context, repo *minderv1.Repository, head string) (*ChangeRequest, error)
+
+	// CreateChangeRequest opens a change request to merge the head branch
+	// into the base branch of the repository
+	CreateChangeRequest(
+		ctx context.Context, repo *minderv1.Repository, title, body, head, base string,
+	) (*ChangeRequest, error)
+
+	// UpdateChangeRequest updates the title and body of an open change request
+	UpdateChangeRequest(ctx context.Context, repo *minderv1.Repository, number int, title, body string) error
+
+	// CloseChangeRequest closes a change request without merging it
+	CloseChangeRequest(ctx context.Context, repo *minderv1.Repository, number int) error
+}
+
+// PullRequestReference identifies the pull request to give feedback on
+type PullRequestReference struct {
+	// Owner is the owner of the repository, e.g. a GitHub organization or a
+	// GitLab namespace
+	Owner string
+	// Repo is the name of the repository
+	Repo string
+	// Number identifies the pull request within its repository
+	Number int
+	// CommitSHA is the SHA of the head commit of the pull request
+	CommitSHA string
+	// Properties are the properties of the pull request entity. Providers
+	// may use them to find provider-specific identifiers.
+	Properties *properties.Properties
+}
+
+// PullRequestReview is a review of a pull request, made of an overall body
+// and comments on lines of the changed files
+type PullRequestReview struct {
+	// ID identifies the review
+	ID string
+	// AuthorID is the ID of the user who submitted the review
+	AuthorID int64
+	// Body is the overall text of the review
+	Body string
+	// CommitSHA is the commit of the pull request the review was made on
+	CommitSHA string
+	// RequestChanges marks the review as blocking the pull request. Providers
+	// which can't block a pull request with a review submit a comment instead.
+	RequestChanges bool
+	// Dismissed is true if the review no longer applies to the pull request
+	Dismissed bool
+	// Comments are the comments on lines of the changed files
+	Comments []*PullRequestReviewComment
+}
+
+// PullRequestReviewComment is a review comment on lines of a changed file
+type PullRequestReviewComment struct {
+	// Path is the path of the file, relative to the root of the repository
+	Path string
+	// StartLine is the first line of a multi-line comment, or 0 if the
+	// comment applies to a single line
+	StartLine int
+	// Line is the line of the file the comment applies to
+	Line int
+	// Body is the text of the comment. Suggested changes use the GitHub
+	// flavored markdown syntax, providers translate it if needed.
+	Body string
+}
+
+// PullRequestComment is a comment on a pull request as a whole
+type PullRequestComment struct {
+	// ID identifies the comment
+	ID string
+	// AuthorID is the ID of the user who wrote the comment
+	AuthorID int64
+	// Body is the text of the comment
+	Body string
+}
+
+// PullRequestReviewer is the trait interface for providers which can give
+// feedback on pull requests, through reviews commenting on the changed lines
+// and through comments on the pull request itself.
+type PullRequestReviewer interface {
+	Provider
+
+	// GetReviewerID returns the ID of the user submitting the reviews and
+	// comments on behalf of the provider's credential
+	GetReviewerID(ctx context.Context) (int64, error)
+
+	// ListPullRequestReviews returns the reviews of the pull request, oldest first
+	ListPullRequestReviews(ctx context.Context, pr *PullRequestReference) ([]*PullRequestReview, error)
+	// SubmitPullRequestReview submits a review on the head commit of the pull
+	// request and returns it
+	SubmitPullRequestReview(
+		ctx context.Context, pr *PullRequestReference, review *PullRequestReview,
+	) (*PullRequestReview, error)
+	// DismissPullRequestReview dismisses a previous review, explaining why
+	DismissPullRequestReview(ctx context.Context, pr *PullRequestReference, reviewID string, message string) error
+
+	// ListPullRequestComments returns the comments of the pull request,
+	// in ascending order of their last update
+	ListPullRequestComments(ctx context.Context, pr *PullRequestReference) ([]*PullRequestComment, error)
+	// CreatePullRequestComment adds a comment to the pull request
+	CreatePullRequestComment(ctx context.Context, pr *PullRequestReference, body string) (*PullRequestComment, error)
+	// UpdatePullRequestComment replaces the body of a comment
+	UpdatePullRequestComment(ctx context.Context, pr *PullRequestReference, commentID string, body string) error
+}
+
 // REST is the trait interface for interacting with an REST API.
 type REST interface {
 	Provider
@@ -140,6 +271,8 @@ type GitHub interface {
 	RepoLister
 	REST
 	Git
+	ChangeRequester
+	PullRequestReviewer
 	ImageLister
 	ArtifactProvider
 
@@ -147,6 +280,11 @@ type GitHub interface {
 	GetRepository(context.Context, string, string) (*github.Repository, error)
 	GetBranchProtection(context.Context, string, string, string) (*github.Protection, error)
 	UpdateBranchProtection(context.Context, string, string, string, *github.ProtectionRequest) error
+	ListRulesets(ctx context.Context, owner, repo string, includeParents bool) ([]*github.Ruleset, error)
+	GetRuleset(ctx context.Context, owner, repo string, id int64) (*github.Ruleset, error)
+	GetRulesForBranch(ctx context.Context, owner, repo, branch string) ([]*github.RepositoryRule, error)
+	CreateRuleset(ctx context.Context, owner, repo string, ruleset *github.Ruleset) (*github.Ruleset, error)
+	UpdateRuleset(ctx context.Context, owner, repo string, id int64, ruleset *github.Ruleset) (*github.Ruleset, error)
 	ListPackagesByRepository(context.Context, string, string, int64, int, int) ([]*github.Package, error)
 	GetPackageByName(context.Context, string, string, string) (*github.Package, error)
 	GetPackageVersionById(context.Context, string, string, string, int64) (*github.PackageVersion, error)
@@ -179,11 +317,32 @@ type GitHub interface {
 		opts *github.IssueListCommentsOptions,
 	) ([]*github.IssueComment, error)
 	UpdateIssueComment(ctx context.Context, owner, repo string, number int64, comment string) error
-	AddAuthToPushOptions(ctx context.Context, options *git.PushOptions) error
 	StartCheckRun(context.Context, string, string, *github.CreateCheckRunOptions) (*github.CheckRun, error)
 	UpdateCheckRun(context.Context, string, string, int64, *github.UpdateCheckRunOptions) (*github.CheckRun, error)
 }
 
+// GitLab is the interface for interacting with the GitLab REST API
+type GitLab interface {
+	Provider
+	REST
+	Git
+	RepoLister
+	ChangeRequester
+	PullRequestReviewer
+
+	// ListBranchProtections returns the protection of the protected branches
+	// of the repository, keyed by branch name or wildcard
+	ListBranchProtections(ctx context.Context, repo *minderv1.Repository) (map[string]*GitLabBranchProtection, error)
+	// GetBranchProtection returns the protection of a branch. Branches which
+	// are not protected get the protection GitLab would apply by default.
+	GetBranchProtection(ctx context.Context, repo *minderv1.Repository, branch string) (*GitLabBranchProtection, error)
+	// UpdateBranchProtection protects a branch and updates its protection
+	// and approval rules to match the given ones
+	UpdateBranchProtection(
+		ctx context.Context, repo *minderv1.Repository, branch string, protection *GitLabBranchProtection,
+	) error
+}
+
 // ImageLister is the interface for listing images
 type ImageLister interface {
 	Provider
@@ -10,8 +10,12 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/google/go-github/v63/github"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/reflect/protoreflect"
+
+	pb "github.com/mindersec/minder/pkg/api/protobuf/go/minder/v1"
+	provifv1 "github.com/mindersec/minder/pkg/providers/v1"
 )
 
 // Methods is the interface that is used to get the method by name
@@ -20,7 +24,11 @@ type Methods interface {
 }
 
 // RuleMethods is the struct that contains the methods that are used by the rules
-type RuleMethods struct{}
+type RuleMethods struct {
+	// Provider is the provider of the entity, used by methods which
+	// fetch data from the provider. It may be nil.
+	Provider provifv1.Provider
+}
 
 // GetMethod gets the method by name from the RuleMethods struct
 func (r *RuleMethods) GetMethod(mName string) (reflect.Value, error) {
@@ -39,3 +47,74 @@ func (r *RuleMethods) GetMethod(mName string) (reflect.Value, error) {
 func (_ *RuleMethods) Passthrough(_ context.Context, ent protoreflect.ProtoMessage) (json.RawMessage, error) {
 	return protojson.Marshal(ent)
 }
+
+// GitHubRulesets returns the rulesets which apply to a GitHub repository,
+// including the ones inherited from its organization, along with the rules
+// in effect on its default branch
+func (r *RuleMethods) GitHubRulesets(ctx context.Context, ent protoreflect.ProtoMessage) (json.RawMessage, error) {
+	repo, ok := ent.(*pb.Repository)
+	if !ok {
+		return nil, fmt.Errorf("expected repository, got %T", ent)
+	}
+	if r.Provider == nil {
+		return nil, fmt.Errorf("no provider available")
+	}
+	cli, err := provifv1.As[provifv1.GitHub](r.Provider)
+	if err != nil {
+		return nil, fmt.Errorf("provider does not implement github trait: %w", err)
+	}
+
+	summaries, err := cli.ListRulesets(ctx, repo.GetOwner(), repo.GetName(), true)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list rulesets: %w", err)
+	}
+	// the list doesn't include the conditions and rules of the rulesets
+	rulesets := make([]*github.Ruleset, 0, len(summaries))
+	for _, summary := range summaries {
+		ruleset, err := cli.GetRuleset(ctx, repo.GetOwner(), repo.GetName(), summary.GetID())
+		if err != nil {
+			return nil, fmt.Errorf("cannot get ruleset %d: %w", summary.GetID(), err)
+		}
+		rulesets = append(rulesets, ruleset)
+	}
+
+	rules := []*github.RepositoryRule{}
+	if branch := repo.GetDefaultBranch(); branch != "" {
+		rules, err = cli.GetRulesForBranch(ctx, repo.GetOwner(), repo.GetName(), branch)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get rules for branch %s: %w", branch, err)
+		}
+	}
+
+	return json.Marshal(map[string]any{
+		"default_branch":       repo.GetDefaultBranch(),
+		"rulesets":             rulesets,
+		"default_branch_rules": rules,
+	})
+}
+
+// GitLabBranchProtection returns the default branch of a GitLab project
+// along with the protection of its protected branches, keyed by name
+func (r *RuleMethods) GitLabBranchProtection(ctx context.Context, ent protoreflect.ProtoMessage) (json.RawMessage, error) {
+	repo, ok := ent.(*pb.Repository)
+	if !ok {
+		return nil, fmt.Errorf("expected repository, got %T", ent)
+	}
+	if r.Provider == nil {
+		return nil, fmt.Errorf("no provider available")
+	}
+	cli, err := provifv1.As[provifv1.GitLab](r.Provider)
+	if err != nil {
+		return nil, fmt.Errorf("provider does not implement gitlab trait: %w", err)
+	}
+
+	protections, err := cli.ListBranchProtections(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list branch protections: %w", err)
+	}
+
+	return json.Marshal(map[string]any{
+		"default_branch":     repo.GetDefaultBranch(),
+		"protected_branches": protections,
+	})
+}